@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/yourusername/cloud-eye/internal/models"
+	"github.com/yourusername/cloud-eye/internal/pkg/evaluation"
+)
+
+// errFindings 存在超过阈值的未通过项，以退出码3退出
+var errFindings = errors.New("存在未通过的检查项")
+
+var findingHeaders = []string{"STATUS", "SEVERITY", "RESOURCE", "ITEM", "NAME", "MESSAGE"}
+
+// runCheck 离线评估：加载基线包和本地资源配置文件，使用与服务端相同的评估引擎输出检查结果，
+// 不需要连接服务端或数据库
+func runCheck(_ context.Context, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("cloudeye check", flag.ContinueOnError)
+	g := &globalFlags{}
+	fs.StringVar(&g.output, "output", "table", "输出格式：table、json、yaml")
+	fs.StringVar(&g.output, "o", "table", "输出格式（--output的简写）")
+	bundle := fs.String("bundle", "", "基线包，cloudeye item list --all -o json导出的配置项JSON文件，不支持Excel文件")
+	failOn := fs.String("fail-on", models.SeverityHigh, "存在不低于该风险等级的未通过项时以退出码3退出，none表示不检查")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if *bundle == "" {
+		return fmt.Errorf("%w: 需要通过--bundle指定基线包", errUsage)
+	}
+	if len(positional) == 0 {
+		return fmt.Errorf("%w: 需要指定资源配置文件或目录", errUsage)
+	}
+	if *failOn != "none" && !models.ValidSeverity(*failOn) {
+		return fmt.Errorf("%w: 无效的风险等级 %q，可选 %s、none", errUsage, *failOn, strings.Join(models.Severities, "、"))
+	}
+	p, err := g.printer(out)
+	if err != nil {
+		return err
+	}
+
+	items, err := loadBundle(*bundle)
+	if err != nil {
+		return err
+	}
+	resources, err := loadResources(positional)
+	if err != nil {
+		return err
+	}
+
+	report := evaluation.Evaluate(items, resources)
+	if err := p.print(report, findingHeaders, findingRows(report.Findings)); err != nil {
+		return err
+	}
+	if p.format == "table" {
+		s := report.Summary
+		fmt.Fprintf(out, "\n共评估%d个资源：通过%d，未通过%d，错误%d，需人工检查%d\n",
+			s.Resources, s.Passed, s.Failed, s.Errors, s.Manual)
+		if len(s.Unmatched) > 0 {
+			fmt.Fprintf(out, "没有适用配置项的资源：%s\n", strings.Join(s.Unmatched, ", "))
+		}
+	}
+
+	if *failOn != "none" && report.Exceeds(*failOn) {
+		return fmt.Errorf("%w：风险等级不低于%s", errFindings, *failOn)
+	}
+	return nil
+}
+
+// loadBundle 读取基线包中的配置项。基线包是JSON格式的配置项数组、分页结果或完整的API响应；
+// Excel导入模板不包含风险等级、状态和产品编码，不能作为基线包
+func loadBundle(path string) ([]models.ConfigurationItem, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取基线包失败: %w", err)
+	}
+	if isExcel(path, raw) {
+		return nil, fmt.Errorf("基线包 %s 是Excel文件，check只接受JSON基线包，请使用 cloudeye item list --all -o json 导出", path)
+	}
+
+	// 依次去掉API响应和分页结果的data包装
+	for depth := 0; depth < 2; depth++ {
+		var wrapper struct {
+			Data json.RawMessage `json:"data"`
+		}
+		trimmed := strings.TrimSpace(string(raw))
+		if !strings.HasPrefix(trimmed, "{") || json.Unmarshal(raw, &wrapper) != nil || wrapper.Data == nil {
+			break
+		}
+		raw = wrapper.Data
+	}
+
+	if !strings.HasPrefix(strings.TrimSpace(string(raw)), "[") {
+		return nil, fmt.Errorf("基线包 %s 格式无效，应为配置项数组或包含配置项数组的data字段", path)
+	}
+	var items []models.ConfigurationItem
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return nil, fmt.Errorf("解析基线包 %s 失败: %w", path, err)
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("基线包 %s 中没有配置项", path)
+	}
+	for _, item := range items {
+		if item.Provider.Code == "" || item.Product.Code == "" {
+			return nil, fmt.Errorf("基线包 %s 中的配置项 %d 缺少云服务商或产品编码，请在导出时包含provider和product关联", path, item.ID)
+		}
+	}
+	return items, nil
+}
+
+// isExcel 按扩展名或xlsx文件的zip文件头判断是否为Excel文件
+func isExcel(path string, raw []byte) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".xlsx", ".xls", ".xlsm":
+		return true
+	}
+	return bytes.HasPrefix(raw, []byte("PK\x03\x04"))
+}
+
+// loadResources 读取资源配置文件，目录按文件名顺序读取其中的.json文件。
+// 每个文件包含一个资源或资源数组，单个资源未指定id时使用文件名
+func loadResources(paths []string) ([]evaluation.Resource, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("读取资源配置失败: %w", err)
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(path, "*.json"))
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(matches)
+		files = append(files, matches...)
+	}
+
+	var resources []evaluation.Resource
+	for _, file := range files {
+		raw, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("读取资源配置失败: %w", err)
+		}
+
+		var list []evaluation.Resource
+		if strings.HasPrefix(strings.TrimSpace(string(raw)), "[") {
+			err = json.Unmarshal(raw, &list)
+		} else {
+			var res evaluation.Resource
+			err = json.Unmarshal(raw, &res)
+			if res.ID == "" {
+				res.ID = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+			}
+			list = []evaluation.Resource{res}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("解析资源配置 %s 失败: %w", file, err)
+		}
+
+		for _, res := range list {
+			if err := res.Validate(); err != nil {
+				return nil, fmt.Errorf("资源配置 %s 无效: %w", file, err)
+			}
+		}
+		resources = append(resources, list...)
+	}
+	if len(resources) == 0 {
+		return nil, errors.New("没有找到资源配置")
+	}
+	return resources, nil
+}
+
+func findingRows(findings []evaluation.Finding) [][]string {
+	rows := make([][]string, len(findings))
+	for i, f := range findings {
+		rows[i] = []string{f.Status, f.Severity, f.ResourceID, formatID(f.ItemID), f.ItemName, f.Message}
+	}
+	return rows
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadBundle(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("写入文件失败: %v", err)
+		}
+		return path
+	}
+	item := `{"id": 1, "name": "a", "provider": {"code": "aws"}, "product": {"code": "s3"}}`
+
+	for _, content := range []string{
+		"[" + item + "]",
+		`{"data": [` + item + `]}`,
+		`{"code": 0, "data": {"data": [` + item + `], "total": 1}}`,
+	} {
+		items, err := loadBundle(write("bundle.json", content))
+		if err != nil || len(items) != 1 || items[0].Provider.Code != "aws" {
+			t.Fatalf("读取基线包%s返回%v, %v", content, items, err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    string
+	}{
+		{"Excel扩展名", "baseline.xlsx", "[]", "是Excel文件"},
+		{"Excel文件头", "baseline.json", "PK\x03\x04\x14\x00", "是Excel文件"},
+		{"不是数组", "bundle.json", `{"name": "a"}`, "格式无效"},
+		{"空数组", "bundle.json", `{"data": []}`, "没有配置项"},
+		{"无效的JSON", "bundle.json", "[{", "解析基线包"},
+		{"缺少产品编码", "bundle.json", `[{"id": 2, "provider": {"code": "aws"}}]`, "配置项 2 缺少云服务商或产品编码"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadBundle(write(tt.file, tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("错误为%v，期望包含%q", err, tt.want)
+			}
+		})
+	}
+}
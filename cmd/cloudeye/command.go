@@ -0,0 +1,329 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/yourusername/cloud-eye/pkg/client"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultServer 未配置服务地址时使用的默认值
+const defaultServer = "http://localhost:8080"
+
+// globalFlags 所有子命令共用的参数
+type globalFlags struct {
+	server     string
+	token      string
+	configFile string
+	output     string
+}
+
+// newFlagSet 创建子命令的参数集合并注册通用参数
+func newFlagSet(name string) (*flag.FlagSet, *globalFlags) {
+	fs := flag.NewFlagSet("cloudeye "+name, flag.ContinueOnError)
+	g := &globalFlags{}
+	fs.StringVar(&g.server, "server", "", "服务地址")
+	fs.StringVar(&g.token, "token", "", "认证令牌")
+	fs.StringVar(&g.configFile, "config", "", "配置文件，默认~/.cloudeye.yaml")
+	fs.StringVar(&g.output, "output", "table", "输出格式：table、json、yaml")
+	fs.StringVar(&g.output, "o", "table", "输出格式（--output的简写）")
+	return fs, g
+}
+
+// parseArgs 解析参数，允许参数和位置参数交替出现，例如 get 3 -o json
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%w: %v", errUsage, err)
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// client 按参数、环境变量、配置文件的优先级读取服务地址和令牌，创建API客户端
+func (g *globalFlags) client() (*client.Client, error) {
+	v := viper.New()
+	v.SetEnvPrefix("CLOUDEYE")
+	v.AutomaticEnv()
+	v.SetDefault("server", defaultServer)
+
+	path := g.configFile
+	if path == "" {
+		path = v.GetString("config")
+	}
+	explicit := path != ""
+	if !explicit {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, ".cloudeye.yaml")
+		}
+	}
+	if path != "" {
+		v.SetConfigFile(path)
+		// 默认配置文件不存在时忽略
+		if err := v.ReadInConfig(); err != nil && (explicit || !errors.Is(err, fs.ErrNotExist)) {
+			return nil, fmt.Errorf("读取配置文件失败: %w", err)
+		}
+	}
+
+	server, token := v.GetString("server"), v.GetString("token")
+	if g.server != "" {
+		server = g.server
+	}
+	if g.token != "" {
+		token = g.token
+	}
+
+	var opts []client.Option
+	if token != "" {
+		opts = append(opts, client.WithToken(token))
+	}
+	return client.New(server, opts...)
+}
+
+// printer 按输出格式打印结果
+type printer struct {
+	format string
+	out    io.Writer
+}
+
+// printer 校验输出格式并创建打印器
+func (g *globalFlags) printer(out io.Writer) (*printer, error) {
+	switch g.output {
+	case "table", "json", "yaml":
+		return &printer{format: g.output, out: out}, nil
+	default:
+		return nil, fmt.Errorf("%w: 不支持的输出格式 %q，可选 table、json、yaml", errUsage, g.output)
+	}
+}
+
+// print 以JSON或YAML打印data，表格格式时打印headers和rows
+func (p *printer) print(data interface{}, headers []string, rows [][]string) error {
+	switch p.format {
+	case "json":
+		enc := json.NewEncoder(p.out)
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+		return enc.Encode(data)
+	case "yaml":
+		// 经JSON转换，使YAML的键与API字段名一致
+		raw, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+		var v interface{}
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return err
+		}
+		enc := yaml.NewEncoder(p.out)
+		enc.SetIndent(2)
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+		return enc.Close()
+	default:
+		w := tabwriter.NewWriter(p.out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, strings.Join(headers, "\t"))
+		for _, row := range rows {
+			fmt.Fprintln(w, strings.Join(row, "\t"))
+		}
+		return w.Flush()
+	}
+}
+
+// message 打印操作结果，JSON和YAML格式时输出对象
+func (p *printer) message(text string, data map[string]interface{}) error {
+	if p.format == "table" {
+		_, err := fmt.Fprintln(p.out, text)
+		return err
+	}
+	return p.print(data, nil, nil)
+}
+
+// printPage 打印分页结果，表格格式时在标准错误输出分页信息，不影响管道处理
+func (p *printer) printPage(page interface{}, info client.PageInfo, headers []string, rows [][]string) error {
+	if err := p.print(page, headers, rows); err != nil {
+		return err
+	}
+	if p.format == "table" {
+		if info.NextCursor != "" || info.Total < 0 {
+			fmt.Fprintf(os.Stderr, "下一页游标: %s\n", info.NextCursor)
+		} else {
+			fmt.Fprintf(os.Stderr, "第%d页，每页%d条，共%d条\n", info.Page, info.PageSize, info.Total)
+		}
+	}
+	return nil
+}
+
+// listFlags 列表查询的通用参数
+type listFlags struct {
+	page     int
+	pageSize int
+	all      bool
+	keyword  string
+	sort     string
+}
+
+func addListFlags(fs *flag.FlagSet) *listFlags {
+	l := &listFlags{}
+	fs.IntVar(&l.page, "page", 0, "页码")
+	fs.IntVar(&l.pageSize, "page-size", 0, "每页记录数")
+	fs.BoolVar(&l.all, "all", false, "遍历全部记录")
+	fs.StringVar(&l.keyword, "keyword", "", "关键字")
+	fs.StringVar(&l.sort, "sort", "", "排序字段，逗号分隔，前缀-表示降序")
+	return l
+}
+
+func (l *listFlags) options() client.ListOptions {
+	opts := client.ListOptions{Page: l.page, PageSize: l.pageSize, Keyword: l.keyword}
+	if l.sort != "" {
+		opts.Sort = strings.Split(l.sort, ",")
+	}
+	return opts
+}
+
+// field 创建和更新时可通过参数设置的字段
+type field struct {
+	flag    string // 参数名
+	key     string // API字段名
+	usage   string
+	numeric bool
+}
+
+// fieldFlags 注册字段参数，返回参数名到取值的映射
+func fieldFlags(fs *flag.FlagSet, fields []field) map[string]*string {
+	values := make(map[string]*string, len(fields))
+	for _, f := range fields {
+		values[f.flag] = fs.String(f.flag, "", f.usage)
+	}
+	return values
+}
+
+// fieldValues 收集命令行中显式指定的字段，数值字段转换为数字
+func fieldValues(fs *flag.FlagSet, fields []field, values map[string]*string) (map[string]interface{}, error) {
+	result := make(map[string]interface{})
+	var err error
+	fs.Visit(func(f *flag.Flag) {
+		for _, def := range fields {
+			if def.flag != f.Name || err != nil {
+				continue
+			}
+			if !def.numeric {
+				result[def.key] = *values[def.flag]
+				continue
+			}
+			n, parseErr := strconv.ParseUint(*values[def.flag], 10, 64)
+			if parseErr != nil {
+				err = fmt.Errorf("%w: --%s必须是正整数", errUsage, def.flag)
+				return
+			}
+			result[def.key] = n
+		}
+	})
+	return result, err
+}
+
+// clearFields 将--clear指定的字段设置为null，用于在部分更新时清空字段
+func clearFields(patch map[string]interface{}, list string, fields []field) error {
+	if list == "" {
+		return nil
+	}
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		found := false
+		for _, f := range fields {
+			if f.flag == name || f.key == name {
+				patch[f.key] = nil
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("%w: --clear中的未知字段 %q", errUsage, name)
+		}
+	}
+	return nil
+}
+
+// decodeInput 将字段映射转换为创建请求
+func decodeInput(values map[string]interface{}, input interface{}) error {
+	raw, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, input)
+}
+
+// parseID 解析位置参数中的记录ID
+func parseID(positional []string) (uint, error) {
+	if len(positional) != 1 {
+		return 0, fmt.Errorf("%w: 需要指定一个ID", errUsage)
+	}
+	id, err := strconv.ParseUint(positional[0], 10, 64)
+	if err != nil || id == 0 {
+		return 0, fmt.Errorf("%w: 无效的ID %q", errUsage, positional[0])
+	}
+	return uint(id), nil
+}
+
+// parseIDs 解析逗号分隔的ID列表
+func parseIDs(name, value string) ([]uint, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var ids []uint
+	for _, s := range strings.Split(value, ",") {
+		id, err := strconv.ParseUint(strings.TrimSpace(s), 10, 64)
+		if err != nil || id == 0 {
+			return nil, fmt.Errorf("%w: --%s中的无效ID %q", errUsage, name, s)
+		}
+		ids = append(ids, uint(id))
+	}
+	return ids, nil
+}
+
+// splitList 解析逗号分隔的字符串列表
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// formatTime 表格中的时间格式
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+func formatID(id uint) string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+func formatOptionalID(id *uint) string {
+	if id == nil {
+		return "-"
+	}
+	return formatID(*id)
+}
@@ -0,0 +1,106 @@
+// cloudeye CloudEye命令行客户端，用于在脚本中管理云服务商、云产品和配置项
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+const usage = `cloudeye - CloudEye命令行客户端
+
+用法:
+  cloudeye <资源> <操作> [参数]
+  cloudeye import <文件.xlsx>
+  cloudeye export [筛选参数] [--save 路径]
+  cloudeye check --bundle <基线包.json> [--fail-on high] <资源配置文件或目录>...
+
+资源:
+  provider   云服务商
+  product    云产品
+  item       配置项
+
+操作:
+  list               列出记录，--page/--page-size分页，--all遍历全部
+  get <id>           查看详情
+  create             创建记录，字段通过参数指定
+  update <id>        部分更新记录，只修改指定的字段
+  delete <id>        删除记录
+
+通用参数:
+  --server URL       服务地址，默认读取配置文件或环境变量CLOUDEYE_SERVER
+  --token TOKEN      认证令牌，默认读取配置文件或环境变量CLOUDEYE_TOKEN
+  --config FILE      配置文件，默认~/.cloudeye.yaml
+  -o, --output FMT   输出格式：table（默认）、json、yaml
+
+使用 cloudeye <资源> <操作> -h 查看操作的参数。
+`
+
+// errUsage 参数错误，以退出码2退出
+var errUsage = errors.New("参数错误")
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	err := run(ctx, os.Args[1:], os.Stdout)
+	code := exitCode(err)
+	if code == 0 {
+		return
+	}
+	if errors.Is(err, errFindings) {
+		fmt.Fprintln(os.Stderr, err)
+	} else {
+		fmt.Fprintln(os.Stderr, "错误:", err)
+	}
+	os.Exit(code)
+}
+
+// exitCode 返回错误对应的退出码：参数错误为2，检查结果超过阈值为3，其他错误为1
+func exitCode(err error) int {
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.Is(err, errUsage):
+		return 2
+	case errors.Is(err, errFindings):
+		return 3
+	default:
+		return 1
+	}
+}
+
+// run 解析子命令并执行
+func run(ctx context.Context, args []string, out io.Writer) error {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		fmt.Fprint(out, usage)
+		return nil
+	}
+
+	switch args[0] {
+	case "import":
+		return runImport(ctx, args[1:], out)
+	case "export":
+		return runExport(ctx, args[1:], out)
+	case "check":
+		return runCheck(ctx, args[1:], out)
+	}
+
+	res, ok := resources[args[0]]
+	if !ok {
+		return fmt.Errorf("%w: 未知的资源 %q，可选 provider、product、item", errUsage, args[0])
+	}
+	if len(args) < 2 {
+		return fmt.Errorf("%w: 缺少操作，可选 list、get、create、update、delete", errUsage)
+	}
+	action, ok := res[args[1]]
+	if !ok {
+		return fmt.Errorf("%w: 未知的操作 %q", errUsage, args[1])
+	}
+	return action(ctx, args[2:], out)
+}
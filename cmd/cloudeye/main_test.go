@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/cloud-eye/internal/apptest"
+	"github.com/yourusername/cloud-eye/pkg/client"
+)
+
+// runCLI 在隔离的环境中执行命令，不读取用户目录下的配置文件和CLOUDEYE_*环境变量
+func runCLI(t *testing.T, server string, args ...string) (string, error) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("CLOUDEYE_SERVER", "")
+	t.Setenv("CLOUDEYE_TOKEN", "")
+	t.Setenv("CLOUDEYE_CONFIG", "")
+	if server != "" {
+		args = append(args, "--server", server)
+	}
+	var out bytes.Buffer
+	err := run(context.Background(), args, &out)
+	return out.String(), err
+}
+
+func TestRunUsageErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"缺少操作", []string{"provider"}, "缺少操作"},
+		{"未知的资源", []string{"vpc", "list"}, `未知的资源 "vpc"`},
+		{"未知的操作", []string{"provider", "remove", "1"}, `未知的操作 "remove"`},
+		{"未知的参数", []string{"provider", "list", "--verbose"}, "flag provided but not defined"},
+		{"缺少ID", []string{"provider", "get"}, "需要指定一个ID"},
+		{"多个ID", []string{"provider", "delete", "1", "2"}, "需要指定一个ID"},
+		{"ID不是数字", []string{"product", "get", "abc"}, `无效的ID "abc"`},
+		{"ID为0", []string{"item", "get", "0"}, `无效的ID "0"`},
+		{"不支持的输出格式", []string{"provider", "list", "-o", "xml"}, `不支持的输出格式 "xml"`},
+		{"数值字段不是数字", []string{"product", "create", "--provider-id", "aws", "--name", "x"}, "--provider-id必须是正整数"},
+		{"数值字段为负数", []string{"item", "update", "1", "--product-id", "-1"}, "--product-id必须是正整数"},
+		{"清空未知字段", []string{"provider", "update", "1", "--clear", "owner"}, `--clear中的未知字段 "owner"`},
+		{"没有修改的字段", []string{"provider", "update", "1"}, "没有指定要修改的字段"},
+		{"创建时的多余参数", []string{"provider", "create", "extra", "--name", "x"}, "多余的参数"},
+		{"筛选中的无效ID", []string{"item", "list", "--provider-id", "1,x"}, `--provider-id中的无效ID "x"`},
+		{"导入缺少文件", []string{"import"}, "需要指定一个.xlsx文件"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 参数错误在请求服务之前返回
+			out, err := runCLI(t, "", tt.args...)
+			if !errors.Is(err, errUsage) || exitCode(err) != 2 {
+				t.Fatalf("错误为%v，退出码%d，期望参数错误", err, exitCode(err))
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("错误为%v，期望包含%q", err, tt.want)
+			}
+			if out != "" {
+				t.Fatalf("参数错误时输出了%q", out)
+			}
+		})
+	}
+}
+
+func TestRunServerErrors(t *testing.T) {
+	app := apptest.New(t)
+	tests := []struct {
+		name   string
+		args   []string
+		status int
+		want   string
+		fields []string
+	}{
+		{"云服务商不存在", []string{"provider", "get", "999"}, 404, "云服务商不存在", nil},
+		{"删除不存在的配置项", []string{"item", "delete", "99999"}, 404, "配置项不存在", nil},
+		{"缺少必填字段", []string{"provider", "create", "--name", "新服务商"}, 400, "请求参数校验失败", []string{"code"}},
+		{"代码重复", []string{"provider", "create", "--name", "重复", "--code", "AWS"}, 409, "云服务商代码已存在", nil},
+		{"无效的风险等级", []string{"item", "update", "1", "--severity", "urgent"}, 400, "请求参数校验失败", []string{"severity"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := runCLI(t, app.URL(""), tt.args...)
+			var apiErr *client.APIError
+			if !errors.As(err, &apiErr) || exitCode(err) != 1 {
+				t.Fatalf("错误为%v，退出码%d，期望服务端错误", err, exitCode(err))
+			}
+			if apiErr.StatusCode != tt.status || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("错误为%v，期望状态码%d并包含%q", err, tt.status, tt.want)
+			}
+			var fields []string
+			for _, fe := range apiErr.Errors {
+				fields = append(fields, fe.Field)
+				// 字段级错误显示在错误信息中，便于脚本排查
+				if !strings.Contains(err.Error(), fe.Field+": ") {
+					t.Fatalf("错误信息%q中缺少字段%s", err, fe.Field)
+				}
+			}
+			if strings.Join(fields, ",") != strings.Join(tt.fields, ",") {
+				t.Fatalf("字段错误为%v，期望%v", fields, tt.fields)
+			}
+			if out != "" {
+				t.Fatalf("请求失败时输出了%q", out)
+			}
+		})
+	}
+}
+
+func TestRunConfigErrors(t *testing.T) {
+	// 显式指定的配置文件不存在时报错，而不是静默使用默认服务地址
+	_, err := runCLI(t, "", "provider", "list", "--config", filepath.Join(t.TempDir(), "missing.yaml"))
+	if err == nil || !strings.Contains(err.Error(), "读取配置文件失败") || exitCode(err) != 1 {
+		t.Fatalf("配置文件不存在时返回%v", err)
+	}
+
+	// 服务不可达时返回连接错误
+	_, err = runCLI(t, "http://127.0.0.1:1", "provider", "list")
+	if err == nil || errors.Is(err, errUsage) || exitCode(err) != 1 {
+		t.Fatalf("服务不可达时返回%v", err)
+	}
+
+	if exitCode(nil) != 0 || exitCode(errFindings) != 3 {
+		t.Fatal("退出码与约定不一致")
+	}
+}
@@ -0,0 +1,528 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/yourusername/cloud-eye/pkg/client"
+)
+
+// action 子命令的执行函数
+type action func(ctx context.Context, args []string, out io.Writer) error
+
+// resources 资源名称到操作的映射
+var resources = map[string]map[string]action{
+	"provider": {
+		"list":   providerList,
+		"get":    providerGet,
+		"create": providerCreate,
+		"update": providerUpdate,
+		"delete": providerDelete,
+	},
+	"product": {
+		"list":   productList,
+		"get":    productGet,
+		"create": productCreate,
+		"update": productUpdate,
+		"delete": productDelete,
+	},
+	"item": {
+		"list":   itemList,
+		"get":    itemGet,
+		"create": itemCreate,
+		"update": itemUpdate,
+		"delete": itemDelete,
+	},
+}
+
+var providerFields = []field{
+	{flag: "name", key: "name", usage: "名称"},
+	{flag: "code", key: "code", usage: "代码"},
+	{flag: "description", key: "description", usage: "描述"},
+}
+
+var productFields = []field{
+	{flag: "provider-id", key: "cloud_provider_id", usage: "云服务商ID", numeric: true},
+	{flag: "name", key: "name", usage: "名称"},
+	{flag: "code", key: "code", usage: "代码"},
+	{flag: "description", key: "description", usage: "描述"},
+	{flag: "category-id", key: "category_id", usage: "产品类别ID", numeric: true},
+}
+
+var itemFields = []field{
+	{flag: "provider-id", key: "cloud_provider_id", usage: "云服务商ID", numeric: true},
+	{flag: "product-id", key: "product_id", usage: "云产品ID", numeric: true},
+	{flag: "name", key: "name", usage: "名称"},
+	{flag: "recommended-value", key: "recommended_value", usage: "推荐值"},
+	{flag: "risk-description", key: "risk_description", usage: "风险描述"},
+	{flag: "check-method", key: "check_method", usage: "检查方法"},
+	{flag: "configuration-method", key: "configuration_method", usage: "配置方法"},
+	{flag: "reference", key: "reference", usage: "参考资料"},
+	{flag: "severity", key: "severity", usage: "风险等级：critical、high、medium、low、info"},
+	{flag: "status", key: "status", usage: "状态：draft、active、deprecated"},
+}
+
+// 表格列
+
+var providerHeaders = []string{"ID", "CODE", "NAME", "PRODUCTS", "UPDATED"}
+
+func providerRows(providers ...client.Provider) [][]string {
+	rows := make([][]string, 0, len(providers))
+	for _, p := range providers {
+		count := "-"
+		if p.ProductCount != nil {
+			count = fmt.Sprint(*p.ProductCount)
+		}
+		rows = append(rows, []string{formatID(p.ID), p.Code, p.Name, count, formatTime(p.UpdatedAt)})
+	}
+	return rows
+}
+
+var productHeaders = []string{"ID", "PROVIDER", "CODE", "NAME", "CATEGORY", "UPDATED"}
+
+func productRows(products ...client.Product) [][]string {
+	rows := make([][]string, 0, len(products))
+	for _, p := range products {
+		rows = append(rows, []string{
+			formatID(p.ID), formatID(p.CloudProviderID), p.Code, p.Name, formatOptionalID(p.CategoryID), formatTime(p.UpdatedAt),
+		})
+	}
+	return rows
+}
+
+var itemHeaders = []string{"ID", "PROVIDER", "PRODUCT", "SEVERITY", "STATUS", "NAME"}
+
+func itemRows(items ...client.ConfigItem) [][]string {
+	rows := make([][]string, 0, len(items))
+	for _, item := range items {
+		rows = append(rows, []string{
+			formatID(item.ID), formatID(item.CloudProviderID), formatID(item.ProductID), item.Severity, item.Status, item.Name,
+		})
+	}
+	return rows
+}
+
+// 云服务商
+
+func providerList(ctx context.Context, args []string, out io.Writer) error {
+	fs, g := newFlagSet("provider list")
+	list := addListFlags(fs)
+	codes := fs.String("code", "", "云服务商代码，逗号分隔")
+	withCounts := fs.Bool("with-counts", false, "显示产品数")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	c, p, err := setup(g, out)
+	if err != nil {
+		return err
+	}
+
+	filter := client.ProviderFilter{ListOptions: list.options(), Codes: splitList(*codes), WithCounts: *withCounts}
+	switch {
+	case list.all:
+		var providers []client.Provider
+		err := c.EachProvider(ctx, filter, func(provider client.Provider) error {
+			providers = append(providers, provider)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		return p.print(providers, providerHeaders, providerRows(providers...))
+	case list.page > 0 || list.pageSize > 0:
+		page, err := c.ListProvidersPage(ctx, filter)
+		if err != nil {
+			return err
+		}
+		return p.printPage(page, page.PageInfo, providerHeaders, providerRows(page.Data...))
+	default:
+		providers, err := c.ListProviders(ctx, filter)
+		if err != nil {
+			return err
+		}
+		return p.print(providers, providerHeaders, providerRows(providers...))
+	}
+}
+
+func providerGet(ctx context.Context, args []string, out io.Writer) error {
+	return get("provider get", args, out, func(c *client.Client, id uint) (interface{}, [][]string, error) {
+		provider, err := c.GetProvider(ctx, id)
+		if err != nil {
+			return nil, nil, err
+		}
+		return provider, providerRows(*provider), nil
+	}, providerHeaders)
+}
+
+func providerCreate(ctx context.Context, args []string, out io.Writer) error {
+	return create("provider create", args, out, providerFields, func(c *client.Client, values map[string]interface{}) (interface{}, [][]string, error) {
+		var input client.ProviderInput
+		if err := decodeInput(values, &input); err != nil {
+			return nil, nil, err
+		}
+		provider, err := c.CreateProvider(ctx, input)
+		if err != nil {
+			return nil, nil, err
+		}
+		return provider, providerRows(*provider), nil
+	}, providerHeaders)
+}
+
+func providerUpdate(ctx context.Context, args []string, out io.Writer) error {
+	return update("provider update", args, out, providerFields, func(c *client.Client, id uint, patch map[string]interface{}) (interface{}, [][]string, error) {
+		provider, err := c.PatchProvider(ctx, id, patch)
+		if err != nil {
+			return nil, nil, err
+		}
+		return provider, providerRows(*provider), nil
+	}, providerHeaders)
+}
+
+func providerDelete(ctx context.Context, args []string, out io.Writer) error {
+	fs, g := newFlagSet("provider delete")
+	cascade := fs.Bool("cascade", false, "一并删除产品和配置项")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(positional)
+	if err != nil {
+		return err
+	}
+	c, p, err := setup(g, out)
+	if err != nil {
+		return err
+	}
+
+	if err := c.DeleteProvider(ctx, id, *cascade); err != nil {
+		return err
+	}
+	return p.message(fmt.Sprintf("已删除云服务商 %d", id), map[string]interface{}{"id": id, "deleted": true})
+}
+
+// 云产品
+
+func productList(ctx context.Context, args []string, out io.Writer) error {
+	fs, g := newFlagSet("product list")
+	list := addListFlags(fs)
+	providerIDs := fs.String("provider-id", "", "云服务商ID，逗号分隔")
+	codes := fs.String("code", "", "产品代码，逗号分隔")
+	categoryIDs := fs.String("category-id", "", "产品类别ID，逗号分隔，包含子类别")
+	withCounts := fs.Bool("with-counts", false, "显示配置项数")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	filter := client.ProductFilter{ListOptions: list.options(), Codes: splitList(*codes), WithCounts: *withCounts}
+	var err error
+	if filter.ProviderIDs, err = parseIDs("provider-id", *providerIDs); err != nil {
+		return err
+	}
+	if filter.CategoryIDs, err = parseIDs("category-id", *categoryIDs); err != nil {
+		return err
+	}
+	c, p, err := setup(g, out)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case list.all:
+		var products []client.Product
+		err := c.EachProduct(ctx, filter, func(product client.Product) error {
+			products = append(products, product)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		return p.print(products, productHeaders, productRows(products...))
+	case list.page > 0 || list.pageSize > 0:
+		page, err := c.ListProductsPage(ctx, filter)
+		if err != nil {
+			return err
+		}
+		return p.printPage(page, page.PageInfo, productHeaders, productRows(page.Data...))
+	default:
+		products, err := c.ListProducts(ctx, filter)
+		if err != nil {
+			return err
+		}
+		return p.print(products, productHeaders, productRows(products...))
+	}
+}
+
+func productGet(ctx context.Context, args []string, out io.Writer) error {
+	return get("product get", args, out, func(c *client.Client, id uint) (interface{}, [][]string, error) {
+		product, err := c.GetProduct(ctx, id)
+		if err != nil {
+			return nil, nil, err
+		}
+		return product, productRows(*product), nil
+	}, productHeaders)
+}
+
+func productCreate(ctx context.Context, args []string, out io.Writer) error {
+	return create("product create", args, out, productFields, func(c *client.Client, values map[string]interface{}) (interface{}, [][]string, error) {
+		var input client.ProductInput
+		if err := decodeInput(values, &input); err != nil {
+			return nil, nil, err
+		}
+		product, err := c.CreateProduct(ctx, input)
+		if err != nil {
+			return nil, nil, err
+		}
+		return product, productRows(*product), nil
+	}, productHeaders)
+}
+
+func productUpdate(ctx context.Context, args []string, out io.Writer) error {
+	return update("product update", args, out, productFields, func(c *client.Client, id uint, patch map[string]interface{}) (interface{}, [][]string, error) {
+		product, err := c.PatchProduct(ctx, id, patch)
+		if err != nil {
+			return nil, nil, err
+		}
+		return product, productRows(*product), nil
+	}, productHeaders)
+}
+
+func productDelete(ctx context.Context, args []string, out io.Writer) error {
+	return remove("product delete", args, out, "云产品", func(c *client.Client, id uint) error {
+		return c.DeleteProduct(ctx, id)
+	})
+}
+
+// 配置项
+
+// itemFilterFlags 配置项列表和导出共用的筛选参数
+type itemFilterFlags struct {
+	providerIDs *string
+	productIDs  *string
+	categoryIDs *string
+	tags        *string
+	tagMatch    *string
+}
+
+func addItemFilterFlags(fs *flag.FlagSet) *itemFilterFlags {
+	return &itemFilterFlags{
+		providerIDs: fs.String("provider-id", "", "云服务商ID，逗号分隔"),
+		productIDs:  fs.String("product-id", "", "云产品ID，逗号分隔"),
+		categoryIDs: fs.String("category-id", "", "产品类别ID，逗号分隔，包含子类别"),
+		tags:        fs.String("tag", "", "标签名称，逗号分隔"),
+		tagMatch:    fs.String("tag-match", "", "多个标签的匹配方式：or（默认）或and"),
+	}
+}
+
+func (f *itemFilterFlags) filter(opts client.ListOptions) (client.ConfigItemFilter, error) {
+	filter := client.ConfigItemFilter{ListOptions: opts, Tags: splitList(*f.tags), TagMatch: *f.tagMatch}
+	var err error
+	if filter.ProviderIDs, err = parseIDs("provider-id", *f.providerIDs); err != nil {
+		return filter, err
+	}
+	if filter.ProductIDs, err = parseIDs("product-id", *f.productIDs); err != nil {
+		return filter, err
+	}
+	if filter.CategoryIDs, err = parseIDs("category-id", *f.categoryIDs); err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
+
+func itemList(ctx context.Context, args []string, out io.Writer) error {
+	fs, g := newFlagSet("item list")
+	list := addListFlags(fs)
+	filterFlags := addItemFilterFlags(fs)
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	filter, err := filterFlags.filter(list.options())
+	if err != nil {
+		return err
+	}
+	c, p, err := setup(g, out)
+	if err != nil {
+		return err
+	}
+
+	if list.all {
+		var items []client.ConfigItem
+		err := c.EachConfigItem(ctx, filter, func(item client.ConfigItem) error {
+			items = append(items, item)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		return p.print(items, itemHeaders, itemRows(items...))
+	}
+
+	page, err := c.ListConfigItems(ctx, filter)
+	if err != nil {
+		return err
+	}
+	return p.printPage(page, page.PageInfo, itemHeaders, itemRows(page.Data...))
+}
+
+func itemGet(ctx context.Context, args []string, out io.Writer) error {
+	return get("item get", args, out, func(c *client.Client, id uint) (interface{}, [][]string, error) {
+		item, err := c.GetConfigItem(ctx, id)
+		if err != nil {
+			return nil, nil, err
+		}
+		return item, itemRows(*item), nil
+	}, itemHeaders)
+}
+
+func itemCreate(ctx context.Context, args []string, out io.Writer) error {
+	return create("item create", args, out, itemFields, func(c *client.Client, values map[string]interface{}) (interface{}, [][]string, error) {
+		var input client.ConfigItemInput
+		if err := decodeInput(values, &input); err != nil {
+			return nil, nil, err
+		}
+		item, err := c.CreateConfigItem(ctx, input)
+		if err != nil {
+			return nil, nil, err
+		}
+		return item, itemRows(*item), nil
+	}, itemHeaders)
+}
+
+func itemUpdate(ctx context.Context, args []string, out io.Writer) error {
+	return update("item update", args, out, itemFields, func(c *client.Client, id uint, patch map[string]interface{}) (interface{}, [][]string, error) {
+		item, err := c.PatchConfigItem(ctx, id, patch)
+		if err != nil {
+			return nil, nil, err
+		}
+		return item, itemRows(*item), nil
+	}, itemHeaders)
+}
+
+func itemDelete(ctx context.Context, args []string, out io.Writer) error {
+	return remove("item delete", args, out, "配置项", func(c *client.Client, id uint) error {
+		return c.DeleteConfigItem(ctx, id)
+	})
+}
+
+// 通用操作
+
+// setup 创建客户端和打印器
+func setup(g *globalFlags, out io.Writer) (*client.Client, *printer, error) {
+	p, err := g.printer(out)
+	if err != nil {
+		return nil, nil, err
+	}
+	c, err := g.client()
+	if err != nil {
+		return nil, nil, err
+	}
+	return c, p, nil
+}
+
+func get(name string, args []string, out io.Writer,
+	fetch func(c *client.Client, id uint) (interface{}, [][]string, error), headers []string) error {
+	fs, g := newFlagSet(name)
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(positional)
+	if err != nil {
+		return err
+	}
+	c, p, err := setup(g, out)
+	if err != nil {
+		return err
+	}
+
+	data, rows, err := fetch(c, id)
+	if err != nil {
+		return err
+	}
+	return p.print(data, headers, rows)
+}
+
+func create(name string, args []string, out io.Writer, fields []field,
+	save func(c *client.Client, values map[string]interface{}) (interface{}, [][]string, error), headers []string) error {
+	fs, g := newFlagSet(name)
+	values := fieldFlags(fs, fields)
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return fmt.Errorf("%w: 多余的参数 %v", errUsage, positional)
+	}
+	input, err := fieldValues(fs, fields, values)
+	if err != nil {
+		return err
+	}
+	c, p, err := setup(g, out)
+	if err != nil {
+		return err
+	}
+
+	data, rows, err := save(c, input)
+	if err != nil {
+		return err
+	}
+	return p.print(data, headers, rows)
+}
+
+func update(name string, args []string, out io.Writer, fields []field,
+	save func(c *client.Client, id uint, patch map[string]interface{}) (interface{}, [][]string, error), headers []string) error {
+	fs, g := newFlagSet(name)
+	values := fieldFlags(fs, fields)
+	clearList := fs.String("clear", "", "清空的字段，逗号分隔")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(positional)
+	if err != nil {
+		return err
+	}
+	patch, err := fieldValues(fs, fields, values)
+	if err != nil {
+		return err
+	}
+	if err := clearFields(patch, *clearList, fields); err != nil {
+		return err
+	}
+	if len(patch) == 0 {
+		return fmt.Errorf("%w: 没有指定要修改的字段", errUsage)
+	}
+	c, p, err := setup(g, out)
+	if err != nil {
+		return err
+	}
+
+	data, rows, err := save(c, id, patch)
+	if err != nil {
+		return err
+	}
+	return p.print(data, headers, rows)
+}
+
+func remove(name string, args []string, out io.Writer, label string,
+	del func(c *client.Client, id uint) error) error {
+	fs, g := newFlagSet(name)
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(positional)
+	if err != nil {
+		return err
+	}
+	c, p, err := setup(g, out)
+	if err != nil {
+		return err
+	}
+
+	if err := del(c, id); err != nil {
+		return err
+	}
+	return p.message(fmt.Sprintf("已删除%s %d", label, id), map[string]interface{}{"id": id, "deleted": true})
+}
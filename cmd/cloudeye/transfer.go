@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/yourusername/cloud-eye/pkg/client"
+)
+
+// runImport 上传Excel文件导入配置项
+func runImport(ctx context.Context, args []string, out io.Writer) error {
+	fs, g := newFlagSet("import")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("%w: 需要指定一个.xlsx文件", errUsage)
+	}
+	c, p, err := setup(g, out)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(positional[0])
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	count, err := c.ImportConfigItems(ctx, filepath.Base(positional[0]), file)
+	if err != nil {
+		return err
+	}
+	return p.message(fmt.Sprintf("已导入%d个配置项", count), map[string]interface{}{"count": count})
+}
+
+// runExport 将符合条件的配置项导出为Excel文件，指定--save时下载到本地
+func runExport(ctx context.Context, args []string, out io.Writer) error {
+	fs, g := newFlagSet("export")
+	filterFlags := addItemFilterFlags(fs)
+	keyword := fs.String("keyword", "", "关键字")
+	sort := fs.String("sort", "", "排序字段，逗号分隔，前缀-表示降序")
+	groupBy := fs.String("group-by", "", "分组维度：category按产品类别分组排列")
+	save := fs.String("save", "", "将导出的文件下载到该路径，为目录时使用服务端的文件名")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	list := listFlags{keyword: *keyword, sort: *sort}
+	filter, err := filterFlags.filter(list.options())
+	if err != nil {
+		return err
+	}
+	c, p, err := setup(g, out)
+	if err != nil {
+		return err
+	}
+
+	result, err := c.ExportConfigItems(ctx, filter, *groupBy)
+	if err != nil {
+		return err
+	}
+	if *save != "" {
+		path, err := download(ctx, c, result, *save)
+		if err != nil {
+			return err
+		}
+		return p.message(fmt.Sprintf("已下载到 %s", path), map[string]interface{}{"path": path, "size": result.Size})
+	}
+	if p.format == "table" {
+		_, err := fmt.Fprintf(out, "已导出 %s，下载地址 %s（%s前有效）\n",
+			result.FileName, result.DownloadURL, result.ExpiresAt.Local().Format("2006-01-02 15:04:05"))
+		return err
+	}
+	return p.print(result, nil, nil)
+}
+
+// download 下载导出的文件，target为目录时使用服务端的文件名
+func download(ctx context.Context, c *client.Client, result *client.ExportResult, target string) (string, error) {
+	if info, err := os.Stat(target); err == nil && info.IsDir() {
+		target = filepath.Join(target, filepath.Base(result.FileName))
+	}
+
+	file, err := os.Create(target)
+	if err != nil {
+		return "", err
+	}
+	if _, err := c.DownloadFile(ctx, result.DownloadURL, file); err != nil {
+		file.Close()
+		os.Remove(target)
+		return "", err
+	}
+	return target, file.Close()
+}
@@ -17,10 +17,28 @@ CREATE TABLE cloud_providers (
     description TEXT COMMENT '云服务商描述',
     created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
     updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    deleted_at TIMESTAMP NULL DEFAULT NULL COMMENT '删除时间，非空表示在回收站中',
     PRIMARY KEY (id),
-    UNIQUE KEY uk_code (code)
+    UNIQUE KEY uk_code (code),
+    KEY idx_deleted_at (deleted_at)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='云服务商信息表';
 
+-- 创建产品类别表
+DROP TABLE IF EXISTS product_categories;
+CREATE TABLE product_categories (
+    id INT UNSIGNED AUTO_INCREMENT COMMENT '类别ID',
+    parent_id INT UNSIGNED NULL COMMENT '上级类别ID',
+    name VARCHAR(100) NOT NULL COMMENT '类别名称',
+    code VARCHAR(50) NOT NULL COMMENT '类别代码',
+    description TEXT COMMENT '类别描述',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_category_code (code),
+    KEY idx_parent (parent_id),
+    CONSTRAINT fk_category_parent FOREIGN KEY (parent_id) REFERENCES product_categories (id) ON DELETE RESTRICT ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='产品类别表';
+
 -- 创建云产品表
 DROP TABLE IF EXISTS cloud_products;
 CREATE TABLE cloud_products (
@@ -29,13 +47,31 @@ CREATE TABLE cloud_products (
     name VARCHAR(100) NOT NULL COMMENT '产品名称',
     code VARCHAR(50) NOT NULL COMMENT '产品代码',
     description TEXT COMMENT '产品描述',
+    category_id INT UNSIGNED NULL COMMENT '所属类别ID',
     created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
     updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    deleted_at TIMESTAMP NULL DEFAULT NULL COMMENT '删除时间，非空表示在回收站中',
     PRIMARY KEY (id),
     UNIQUE KEY uk_provider_code (cloud_provider_id, code),
-    CONSTRAINT fk_products_provider FOREIGN KEY (cloud_provider_id) REFERENCES cloud_providers (id) ON DELETE CASCADE ON UPDATE CASCADE
+    KEY idx_category (category_id),
+    KEY idx_deleted_at (deleted_at),
+    CONSTRAINT fk_products_provider FOREIGN KEY (cloud_provider_id) REFERENCES cloud_providers (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_products_category FOREIGN KEY (category_id) REFERENCES product_categories (id) ON DELETE SET NULL ON UPDATE CASCADE
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='云产品信息表';
 
+-- 创建控制族表
+DROP TABLE IF EXISTS control_families;
+CREATE TABLE control_families (
+    id INT UNSIGNED AUTO_INCREMENT COMMENT '控制族ID',
+    name VARCHAR(200) NOT NULL COMMENT '控制族名称',
+    code VARCHAR(50) NOT NULL COMMENT '控制族代码',
+    description TEXT COMMENT '控制族描述',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_family_code (code)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='跨云服务商的控制族表';
+
 -- 创建安全配置基线项表
 DROP TABLE IF EXISTS configuration_items;
 CREATE TABLE configuration_items (
@@ -48,14 +84,167 @@ CREATE TABLE configuration_items (
     check_method TEXT COMMENT '检查方法',
     configuration_method TEXT COMMENT '配置方式',
     reference TEXT COMMENT '参考资料',
+    severity VARCHAR(20) NOT NULL DEFAULT 'medium' COMMENT '风险等级：critical/high/medium/low/info',
+    status VARCHAR(20) NOT NULL DEFAULT 'active' COMMENT '状态：draft/active/deprecated',
+    control_family_id INT UNSIGNED NULL COMMENT '所属控制族ID',
     created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
     updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    deleted_at TIMESTAMP NULL DEFAULT NULL COMMENT '删除时间，非空表示在回收站中',
     PRIMARY KEY (id),
     KEY idx_provider_product (cloud_provider_id, product_id),
+    KEY idx_severity (severity),
+    KEY idx_status (status),
+    KEY idx_control_family (control_family_id),
+    KEY idx_deleted_at (deleted_at),
+    FULLTEXT KEY ft_content (name, recommended_value, risk_description, check_method, configuration_method, reference) WITH PARSER ngram,
     CONSTRAINT fk_config_provider FOREIGN KEY (cloud_provider_id) REFERENCES cloud_providers (id) ON DELETE CASCADE ON UPDATE CASCADE,
-    CONSTRAINT fk_config_product FOREIGN KEY (product_id) REFERENCES cloud_products (id) ON DELETE CASCADE ON UPDATE CASCADE
+    CONSTRAINT fk_config_product FOREIGN KEY (product_id) REFERENCES cloud_products (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_config_family FOREIGN KEY (control_family_id) REFERENCES control_families (id) ON DELETE SET NULL ON UPDATE CASCADE
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='安全配置基线项表';
 
+-- 创建标签表
+DROP TABLE IF EXISTS tags;
+CREATE TABLE tags (
+    id INT UNSIGNED AUTO_INCREMENT COMMENT '标签ID',
+    name VARCHAR(50) NOT NULL COMMENT '标签名称',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_tag_name (name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='标签表';
+
+-- 创建云服务商标签关联表
+DROP TABLE IF EXISTS cloud_provider_tags;
+CREATE TABLE cloud_provider_tags (
+    cloud_provider_id INT UNSIGNED NOT NULL COMMENT '云服务商ID',
+    tag_id INT UNSIGNED NOT NULL COMMENT '标签ID',
+    PRIMARY KEY (cloud_provider_id, tag_id),
+    KEY idx_tag (tag_id),
+    CONSTRAINT fk_provider_tags_provider FOREIGN KEY (cloud_provider_id) REFERENCES cloud_providers (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_provider_tags_tag FOREIGN KEY (tag_id) REFERENCES tags (id) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='云服务商标签关联表';
+
+-- 创建云产品标签关联表
+DROP TABLE IF EXISTS cloud_product_tags;
+CREATE TABLE cloud_product_tags (
+    cloud_product_id INT UNSIGNED NOT NULL COMMENT '云产品ID',
+    tag_id INT UNSIGNED NOT NULL COMMENT '标签ID',
+    PRIMARY KEY (cloud_product_id, tag_id),
+    KEY idx_tag (tag_id),
+    CONSTRAINT fk_product_tags_product FOREIGN KEY (cloud_product_id) REFERENCES cloud_products (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_product_tags_tag FOREIGN KEY (tag_id) REFERENCES tags (id) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='云产品标签关联表';
+
+-- 创建配置项标签关联表
+DROP TABLE IF EXISTS configuration_item_tags;
+CREATE TABLE configuration_item_tags (
+    configuration_item_id INT UNSIGNED NOT NULL COMMENT '配置项ID',
+    tag_id INT UNSIGNED NOT NULL COMMENT '标签ID',
+    PRIMARY KEY (configuration_item_id, tag_id),
+    KEY idx_tag (tag_id),
+    CONSTRAINT fk_config_tags_item FOREIGN KEY (configuration_item_id) REFERENCES configuration_items (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_config_tags_tag FOREIGN KEY (tag_id) REFERENCES tags (id) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='配置项标签关联表';
+
+-- 创建Webhook订阅表
+DROP TABLE IF EXISTS webhook_subscriptions;
+CREATE TABLE webhook_subscriptions (
+    id INT UNSIGNED AUTO_INCREMENT COMMENT '订阅ID',
+    name VARCHAR(100) NOT NULL COMMENT '订阅名称',
+    url VARCHAR(500) NOT NULL COMMENT '接收地址',
+    secret VARCHAR(100) NOT NULL COMMENT '签名密钥',
+    events VARCHAR(500) NOT NULL COMMENT '订阅的事件类型，JSON数组',
+    active TINYINT(1) NOT NULL DEFAULT 1 COMMENT '是否启用',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    PRIMARY KEY (id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='Webhook订阅表';
+
+-- 创建Webhook投递记录表
+DROP TABLE IF EXISTS webhook_deliveries;
+CREATE TABLE webhook_deliveries (
+    id INT UNSIGNED AUTO_INCREMENT COMMENT '投递记录ID',
+    subscription_id INT UNSIGNED NOT NULL COMMENT '订阅ID',
+    event_id VARCHAR(64) NOT NULL COMMENT '事件ID',
+    event VARCHAR(50) NOT NULL COMMENT '事件类型',
+    payload MEDIUMTEXT NOT NULL COMMENT '请求体',
+    status VARCHAR(20) NOT NULL COMMENT '投递状态：pending/succeeded/failed',
+    attempts INT NOT NULL DEFAULT 0 COMMENT '已投递次数',
+    response_status INT NULL COMMENT '最近一次投递的响应状态码',
+    response_body TEXT COMMENT '最近一次投递的响应体',
+    error VARCHAR(500) NULL COMMENT '最近一次投递的错误信息',
+    duration_ms BIGINT NULL COMMENT '最近一次投递耗时（毫秒）',
+    last_attempt_at TIMESTAMP NULL DEFAULT NULL COMMENT '最近一次投递时间',
+    next_attempt_at TIMESTAMP NULL DEFAULT NULL COMMENT '下次投递时间',
+    redelivery_of INT UNSIGNED NULL COMMENT '手动重新投递时为原投递记录ID',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    PRIMARY KEY (id),
+    KEY idx_delivery_subscription (subscription_id),
+    KEY idx_delivery_due (status, next_attempt_at),
+    CONSTRAINT fk_delivery_subscription FOREIGN KEY (subscription_id) REFERENCES webhook_subscriptions (id) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='Webhook投递记录表';
+
+-- 创建变更事件表
+DROP TABLE IF EXISTS change_events;
+CREATE TABLE change_events (
+    id INT UNSIGNED AUTO_INCREMENT COMMENT '事件序号，作为SSE事件ID',
+    event VARCHAR(50) NOT NULL COMMENT '事件类型',
+    entity_type VARCHAR(20) NOT NULL COMMENT '实体类型：provider/product/config_item',
+    entity_id INT UNSIGNED NOT NULL COMMENT '实体ID，导入完成事件为0',
+    cloud_provider_id INT UNSIGNED NOT NULL COMMENT '所属云服务商ID',
+    product_id INT UNSIGNED NOT NULL COMMENT '所属云产品ID，云服务商事件为0',
+    data MEDIUMTEXT NOT NULL COMMENT '事件数据',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    PRIMARY KEY (id),
+    KEY idx_change_events_cloud_provider_id (cloud_provider_id),
+    KEY idx_change_events_product_id (product_id),
+    KEY idx_change_events_created_at (created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='变更事件表';
+
+-- 创建后台任务表
+DROP TABLE IF EXISTS jobs;
+CREATE TABLE jobs (
+    id INT UNSIGNED AUTO_INCREMENT COMMENT '主键ID',
+    type VARCHAR(20) NOT NULL COMMENT '任务类型：import/export/evaluation/report',
+    status VARCHAR(20) NOT NULL COMMENT '任务状态：queued/running/succeeded/failed/canceled',
+    params MEDIUMTEXT NOT NULL COMMENT '任务参数（JSON）',
+    progress INT NOT NULL DEFAULT 0 COMMENT '已处理的记录数',
+    total INT NOT NULL DEFAULT 0 COMMENT '待处理的记录总数，未知时为0',
+    message VARCHAR(200) COMMENT '当前步骤',
+    error VARCHAR(1000) COMMENT '失败原因',
+    warnings TEXT COMMENT '非致命问题（JSON数组）',
+    result TEXT COMMENT '结果摘要（JSON）',
+    result_file_id INT UNSIGNED COMMENT '结果文件ID',
+    result_name VARCHAR(200) COMMENT '结果文件下载名称',
+    cancel_requested TINYINT(1) NOT NULL DEFAULT 0 COMMENT '是否已请求取消',
+    attempts INT NOT NULL DEFAULT 0 COMMENT '执行次数',
+    owner VARCHAR(100) COMMENT '领取任务的实例',
+    lease_expires_at TIMESTAMP NULL COMMENT '执行租约到期时间，过期后任务重新放回队列',
+    started_at TIMESTAMP NULL COMMENT '最近一次开始执行时间',
+    finished_at TIMESTAMP NULL COMMENT '结束时间',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    PRIMARY KEY (id),
+    KEY idx_jobs_status (status, lease_expires_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='后台任务表';
+
+-- 创建托管文件表
+DROP TABLE IF EXISTS files;
+CREATE TABLE files (
+    id INT UNSIGNED AUTO_INCREMENT COMMENT '主键ID',
+    kind VARCHAR(20) NOT NULL COMMENT '文件类型：export/import',
+    name VARCHAR(255) NOT NULL COMMENT '下载文件名',
+    storage_key VARCHAR(500) NOT NULL COMMENT '存储键',
+    size BIGINT NOT NULL COMMENT '文件大小（字节）',
+    content_type VARCHAR(100) NOT NULL COMMENT '内容类型',
+    expires_at TIMESTAMP NOT NULL COMMENT '过期时间，过期后由清理任务删除',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    PRIMARY KEY (id),
+    KEY idx_files_expires_at (expires_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='托管文件表';
+
 -- 初始化云服务商数据
 INSERT INTO cloud_providers (name, code, description) VALUES
     ('Amazon Web Services', 'AWS', 'Amazon Web Services (AWS) 是亚马逊（Amazon）公司旗下云计算服务平台，提供包括弹性计算、存储、数据库、机器学习等在内的一系列云服务。'),
@@ -64,6 +253,16 @@ INSERT INTO cloud_providers (name, code, description) VALUES
     ('阿里云', 'ALICLOUD', '阿里云是阿里巴巴集团旗下的云计算品牌，为全球企业、开发者和政府机构提供安全、可靠的计算和数据处理能力。'),
     ('腾讯云', 'TENCENTCLOUD', '腾讯云是腾讯推出的云计算品牌，提供云服务器、云存储、云数据库和大数据处理等基础云计算服务。');
 
+-- 初始化产品类别数据
+INSERT INTO product_categories (parent_id, name, code, description) VALUES
+    (NULL, '计算', 'COMPUTE', '虚拟机、容器等计算资源'),
+    (NULL, '存储', 'STORAGE', '对象、块和文件存储'),
+    (2, '对象存储', 'OBJECT_STORAGE', '海量非结构化数据的对象存储服务'),
+    (NULL, '数据库', 'DATABASE', '托管数据库服务'),
+    (4, '关系型数据库', 'RELATIONAL_DATABASE', '托管关系型数据库服务'),
+    (NULL, '网络', 'NETWORK', '虚拟网络、负载均衡和CDN等网络服务'),
+    (NULL, '身份与访问管理', 'IDENTITY', '账号、权限和密钥管理服务');
+
 -- 初始化云产品数据
 INSERT INTO cloud_products (cloud_provider_id, name, code, description) VALUES
     -- AWS产品
@@ -91,6 +290,11 @@ INSERT INTO cloud_products (cloud_provider_id, name, code, description) VALUES
     (5, '腾讯云对象存储', 'COS', '腾讯云COS是腾讯云提供的一种存储海量文件的分布式存储服务，具有高扩展性、低成本等优点。'),
     (5, '腾讯云数据库', 'TencentDB', '腾讯云数据库是腾讯云提供的高性能、高可靠、高安全、可弹性伸缩的数据库托管服务。');
 
+-- 设置云产品类别
+UPDATE cloud_products SET category_id = 1 WHERE code IN ('EC2', 'AVM', 'GCE', 'ECS', 'CVM');
+UPDATE cloud_products SET category_id = 3 WHERE code IN ('S3', 'BLOB', 'GCS', 'OSS', 'COS');
+UPDATE cloud_products SET category_id = 5 WHERE code IN ('RDS', 'ASQL', 'GSQL', 'TencentDB');
+
 -- 初始化安全配置基线项
 INSERT INTO configuration_items (cloud_provider_id, product_id, name, recommended_value, risk_description, check_method, configuration_method, reference) VALUES
     -- AWS EC2 配置项
@@ -118,4 +322,40 @@ INSERT INTO configuration_items (cloud_provider_id, product_id, name, recommende
     (4, 10, 'ECS实例密码复杂度', '使用高强度密码且定期更换', '弱密码容易被暴力破解，导致系统被入侵。', '检查密码策略是否符合复杂度要求。', '设置包含大小写字母、数字和特殊字符的复杂密码，定期更换。', '阿里云ECS安全最佳实践 https://help.aliyun.com/document_detail/51701.html'),
     
     -- 阿里云OSS配置项
-    (4, 11, 'OSS存储桶访问控制', '使用Bucket ACL和IAM权限控制访问', '不当的访问控制可能导致数据被未授权访问。', '检查OSS Bucket的访问控制设置。', '通过OSS控制台设置合适的Bucket ACL，结合RAM权限策略控制访问。', '阿里云OSS访问控制最佳实践 https://help.aliyun.com/document_detail/31952.html');
\ No newline at end of file
+    (4, 11, 'OSS存储桶访问控制', '使用Bucket ACL和IAM权限控制访问', '不当的访问控制可能导致数据被未授权访问。', '检查OSS Bucket的访问控制设置。', '通过OSS控制台设置合适的Bucket ACL，结合RAM权限策略控制访问。', '阿里云OSS访问控制最佳实践 https://help.aliyun.com/document_detail/31952.html');
+
+-- 设置配置项风险等级
+UPDATE configuration_items SET severity = 'high' WHERE name IN ('S3存储桶公共访问设置', 'RDS数据库公共可访问性', '存储账户公共访问级别', 'OSS存储桶访问控制');
+UPDATE configuration_items SET severity = 'low' WHERE name = 'EC2实例AMI更新状态';
+
+-- 初始化控制族数据
+INSERT INTO control_families (name, code, description) VALUES
+    ('对象存储公共访问', 'STORAGE_PUBLIC_ACCESS', '禁止对象存储被匿名或公共访问'),
+    ('数据库加密', 'DATABASE_ENCRYPTION', '数据库存储层启用静态加密');
+
+UPDATE configuration_items SET control_family_id = 1 WHERE name IN ('S3存储桶公共访问设置', '存储账户公共访问级别', 'OSS存储桶访问控制');
+UPDATE configuration_items SET control_family_id = 2 WHERE name = 'RDS数据库加密设置';
+
+-- 初始化标签数据
+INSERT INTO tags (name) VALUES
+    ('公共访问'),
+    ('加密'),
+    ('网络访问控制'),
+    ('补丁管理'),
+    ('身份认证');
+
+INSERT INTO configuration_item_tags (configuration_item_id, tag_id)
+SELECT ci.id, t.id FROM configuration_items ci JOIN tags t ON t.name = '公共访问'
+WHERE ci.name IN ('S3存储桶公共访问设置', '存储账户公共访问级别', 'OSS存储桶访问控制', 'RDS数据库公共可访问性');
+INSERT INTO configuration_item_tags (configuration_item_id, tag_id)
+SELECT ci.id, t.id FROM configuration_items ci JOIN tags t ON t.name = '加密'
+WHERE ci.name IN ('S3存储桶加密设置', 'RDS数据库加密设置', 'Azure VM磁盘加密', '存储账户加密设置');
+INSERT INTO configuration_item_tags (configuration_item_id, tag_id)
+SELECT ci.id, t.id FROM configuration_items ci JOIN tags t ON t.name = '网络访问控制'
+WHERE ci.name IN ('EC2实例安全组入站规则限制', 'Azure VM网络安全组设置', 'ECS安全组规则配置');
+INSERT INTO configuration_item_tags (configuration_item_id, tag_id)
+SELECT ci.id, t.id FROM configuration_items ci JOIN tags t ON t.name = '补丁管理'
+WHERE ci.name = 'EC2实例AMI更新状态';
+INSERT INTO configuration_item_tags (configuration_item_id, tag_id)
+SELECT ci.id, t.id FROM configuration_items ci JOIN tags t ON t.name = '身份认证'
+WHERE ci.name = 'ECS实例密码复杂度';
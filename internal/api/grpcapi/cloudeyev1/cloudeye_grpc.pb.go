@@ -0,0 +1,997 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: cloudeye.proto
+
+// CloudEye 服务间访问接口，与REST接口共用服务层
+
+package cloudeyev1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	CloudProviderService_ListProviders_FullMethodName     = "/cloudeye.v1.CloudProviderService/ListProviders"
+	CloudProviderService_GetProvider_FullMethodName       = "/cloudeye.v1.CloudProviderService/GetProvider"
+	CloudProviderService_GetProviderByCode_FullMethodName = "/cloudeye.v1.CloudProviderService/GetProviderByCode"
+	CloudProviderService_CreateProvider_FullMethodName    = "/cloudeye.v1.CloudProviderService/CreateProvider"
+	CloudProviderService_UpdateProvider_FullMethodName    = "/cloudeye.v1.CloudProviderService/UpdateProvider"
+	CloudProviderService_PatchProvider_FullMethodName     = "/cloudeye.v1.CloudProviderService/PatchProvider"
+	CloudProviderService_DeleteProvider_FullMethodName    = "/cloudeye.v1.CloudProviderService/DeleteProvider"
+)
+
+// CloudProviderServiceClient is the client API for CloudProviderService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CloudProviderServiceClient interface {
+	ListProviders(ctx context.Context, in *ListProvidersRequest, opts ...grpc.CallOption) (*ListProvidersResponse, error)
+	GetProvider(ctx context.Context, in *GetProviderRequest, opts ...grpc.CallOption) (*Provider, error)
+	GetProviderByCode(ctx context.Context, in *GetProviderByCodeRequest, opts ...grpc.CallOption) (*Provider, error)
+	CreateProvider(ctx context.Context, in *CreateProviderRequest, opts ...grpc.CallOption) (*Provider, error)
+	// 整体更新云服务商，未提供的字段将被清空
+	UpdateProvider(ctx context.Context, in *UpdateProviderRequest, opts ...grpc.CallOption) (*Provider, error)
+	PatchProvider(ctx context.Context, in *PatchProviderRequest, opts ...grpc.CallOption) (*Provider, error)
+	DeleteProvider(ctx context.Context, in *DeleteProviderRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type cloudProviderServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCloudProviderServiceClient(cc grpc.ClientConnInterface) CloudProviderServiceClient {
+	return &cloudProviderServiceClient{cc}
+}
+
+func (c *cloudProviderServiceClient) ListProviders(ctx context.Context, in *ListProvidersRequest, opts ...grpc.CallOption) (*ListProvidersResponse, error) {
+	out := new(ListProvidersResponse)
+	err := c.cc.Invoke(ctx, CloudProviderService_ListProviders_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudProviderServiceClient) GetProvider(ctx context.Context, in *GetProviderRequest, opts ...grpc.CallOption) (*Provider, error) {
+	out := new(Provider)
+	err := c.cc.Invoke(ctx, CloudProviderService_GetProvider_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudProviderServiceClient) GetProviderByCode(ctx context.Context, in *GetProviderByCodeRequest, opts ...grpc.CallOption) (*Provider, error) {
+	out := new(Provider)
+	err := c.cc.Invoke(ctx, CloudProviderService_GetProviderByCode_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudProviderServiceClient) CreateProvider(ctx context.Context, in *CreateProviderRequest, opts ...grpc.CallOption) (*Provider, error) {
+	out := new(Provider)
+	err := c.cc.Invoke(ctx, CloudProviderService_CreateProvider_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudProviderServiceClient) UpdateProvider(ctx context.Context, in *UpdateProviderRequest, opts ...grpc.CallOption) (*Provider, error) {
+	out := new(Provider)
+	err := c.cc.Invoke(ctx, CloudProviderService_UpdateProvider_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudProviderServiceClient) PatchProvider(ctx context.Context, in *PatchProviderRequest, opts ...grpc.CallOption) (*Provider, error) {
+	out := new(Provider)
+	err := c.cc.Invoke(ctx, CloudProviderService_PatchProvider_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudProviderServiceClient) DeleteProvider(ctx context.Context, in *DeleteProviderRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, CloudProviderService_DeleteProvider_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CloudProviderServiceServer is the server API for CloudProviderService service.
+// All implementations must embed UnimplementedCloudProviderServiceServer
+// for forward compatibility
+type CloudProviderServiceServer interface {
+	ListProviders(context.Context, *ListProvidersRequest) (*ListProvidersResponse, error)
+	GetProvider(context.Context, *GetProviderRequest) (*Provider, error)
+	GetProviderByCode(context.Context, *GetProviderByCodeRequest) (*Provider, error)
+	CreateProvider(context.Context, *CreateProviderRequest) (*Provider, error)
+	// 整体更新云服务商，未提供的字段将被清空
+	UpdateProvider(context.Context, *UpdateProviderRequest) (*Provider, error)
+	PatchProvider(context.Context, *PatchProviderRequest) (*Provider, error)
+	DeleteProvider(context.Context, *DeleteProviderRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedCloudProviderServiceServer()
+}
+
+// UnimplementedCloudProviderServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedCloudProviderServiceServer struct {
+}
+
+func (UnimplementedCloudProviderServiceServer) ListProviders(context.Context, *ListProvidersRequest) (*ListProvidersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProviders not implemented")
+}
+func (UnimplementedCloudProviderServiceServer) GetProvider(context.Context, *GetProviderRequest) (*Provider, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProvider not implemented")
+}
+func (UnimplementedCloudProviderServiceServer) GetProviderByCode(context.Context, *GetProviderByCodeRequest) (*Provider, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProviderByCode not implemented")
+}
+func (UnimplementedCloudProviderServiceServer) CreateProvider(context.Context, *CreateProviderRequest) (*Provider, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateProvider not implemented")
+}
+func (UnimplementedCloudProviderServiceServer) UpdateProvider(context.Context, *UpdateProviderRequest) (*Provider, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateProvider not implemented")
+}
+func (UnimplementedCloudProviderServiceServer) PatchProvider(context.Context, *PatchProviderRequest) (*Provider, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PatchProvider not implemented")
+}
+func (UnimplementedCloudProviderServiceServer) DeleteProvider(context.Context, *DeleteProviderRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteProvider not implemented")
+}
+func (UnimplementedCloudProviderServiceServer) mustEmbedUnimplementedCloudProviderServiceServer() {}
+
+// UnsafeCloudProviderServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CloudProviderServiceServer will
+// result in compilation errors.
+type UnsafeCloudProviderServiceServer interface {
+	mustEmbedUnimplementedCloudProviderServiceServer()
+}
+
+func RegisterCloudProviderServiceServer(s grpc.ServiceRegistrar, srv CloudProviderServiceServer) {
+	s.RegisterService(&CloudProviderService_ServiceDesc, srv)
+}
+
+func _CloudProviderService_ListProviders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProvidersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudProviderServiceServer).ListProviders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CloudProviderService_ListProviders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudProviderServiceServer).ListProviders(ctx, req.(*ListProvidersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CloudProviderService_GetProvider_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProviderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudProviderServiceServer).GetProvider(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CloudProviderService_GetProvider_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudProviderServiceServer).GetProvider(ctx, req.(*GetProviderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CloudProviderService_GetProviderByCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProviderByCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudProviderServiceServer).GetProviderByCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CloudProviderService_GetProviderByCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudProviderServiceServer).GetProviderByCode(ctx, req.(*GetProviderByCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CloudProviderService_CreateProvider_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateProviderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudProviderServiceServer).CreateProvider(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CloudProviderService_CreateProvider_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudProviderServiceServer).CreateProvider(ctx, req.(*CreateProviderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CloudProviderService_UpdateProvider_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateProviderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudProviderServiceServer).UpdateProvider(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CloudProviderService_UpdateProvider_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudProviderServiceServer).UpdateProvider(ctx, req.(*UpdateProviderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CloudProviderService_PatchProvider_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatchProviderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudProviderServiceServer).PatchProvider(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CloudProviderService_PatchProvider_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudProviderServiceServer).PatchProvider(ctx, req.(*PatchProviderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CloudProviderService_DeleteProvider_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteProviderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudProviderServiceServer).DeleteProvider(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CloudProviderService_DeleteProvider_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudProviderServiceServer).DeleteProvider(ctx, req.(*DeleteProviderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CloudProviderService_ServiceDesc is the grpc.ServiceDesc for CloudProviderService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CloudProviderService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "cloudeye.v1.CloudProviderService",
+	HandlerType: (*CloudProviderServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListProviders",
+			Handler:    _CloudProviderService_ListProviders_Handler,
+		},
+		{
+			MethodName: "GetProvider",
+			Handler:    _CloudProviderService_GetProvider_Handler,
+		},
+		{
+			MethodName: "GetProviderByCode",
+			Handler:    _CloudProviderService_GetProviderByCode_Handler,
+		},
+		{
+			MethodName: "CreateProvider",
+			Handler:    _CloudProviderService_CreateProvider_Handler,
+		},
+		{
+			MethodName: "UpdateProvider",
+			Handler:    _CloudProviderService_UpdateProvider_Handler,
+		},
+		{
+			MethodName: "PatchProvider",
+			Handler:    _CloudProviderService_PatchProvider_Handler,
+		},
+		{
+			MethodName: "DeleteProvider",
+			Handler:    _CloudProviderService_DeleteProvider_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "cloudeye.proto",
+}
+
+const (
+	CloudProductService_ListProducts_FullMethodName  = "/cloudeye.v1.CloudProductService/ListProducts"
+	CloudProductService_GetProduct_FullMethodName    = "/cloudeye.v1.CloudProductService/GetProduct"
+	CloudProductService_CreateProduct_FullMethodName = "/cloudeye.v1.CloudProductService/CreateProduct"
+	CloudProductService_UpdateProduct_FullMethodName = "/cloudeye.v1.CloudProductService/UpdateProduct"
+	CloudProductService_PatchProduct_FullMethodName  = "/cloudeye.v1.CloudProductService/PatchProduct"
+	CloudProductService_DeleteProduct_FullMethodName = "/cloudeye.v1.CloudProductService/DeleteProduct"
+)
+
+// CloudProductServiceClient is the client API for CloudProductService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CloudProductServiceClient interface {
+	ListProducts(ctx context.Context, in *ListProductsRequest, opts ...grpc.CallOption) (*ListProductsResponse, error)
+	GetProduct(ctx context.Context, in *GetProductRequest, opts ...grpc.CallOption) (*Product, error)
+	CreateProduct(ctx context.Context, in *CreateProductRequest, opts ...grpc.CallOption) (*Product, error)
+	// 整体更新云产品，未提供的字段将被清空
+	UpdateProduct(ctx context.Context, in *UpdateProductRequest, opts ...grpc.CallOption) (*Product, error)
+	PatchProduct(ctx context.Context, in *PatchProductRequest, opts ...grpc.CallOption) (*Product, error)
+	DeleteProduct(ctx context.Context, in *DeleteProductRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type cloudProductServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCloudProductServiceClient(cc grpc.ClientConnInterface) CloudProductServiceClient {
+	return &cloudProductServiceClient{cc}
+}
+
+func (c *cloudProductServiceClient) ListProducts(ctx context.Context, in *ListProductsRequest, opts ...grpc.CallOption) (*ListProductsResponse, error) {
+	out := new(ListProductsResponse)
+	err := c.cc.Invoke(ctx, CloudProductService_ListProducts_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudProductServiceClient) GetProduct(ctx context.Context, in *GetProductRequest, opts ...grpc.CallOption) (*Product, error) {
+	out := new(Product)
+	err := c.cc.Invoke(ctx, CloudProductService_GetProduct_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudProductServiceClient) CreateProduct(ctx context.Context, in *CreateProductRequest, opts ...grpc.CallOption) (*Product, error) {
+	out := new(Product)
+	err := c.cc.Invoke(ctx, CloudProductService_CreateProduct_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudProductServiceClient) UpdateProduct(ctx context.Context, in *UpdateProductRequest, opts ...grpc.CallOption) (*Product, error) {
+	out := new(Product)
+	err := c.cc.Invoke(ctx, CloudProductService_UpdateProduct_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudProductServiceClient) PatchProduct(ctx context.Context, in *PatchProductRequest, opts ...grpc.CallOption) (*Product, error) {
+	out := new(Product)
+	err := c.cc.Invoke(ctx, CloudProductService_PatchProduct_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudProductServiceClient) DeleteProduct(ctx context.Context, in *DeleteProductRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, CloudProductService_DeleteProduct_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CloudProductServiceServer is the server API for CloudProductService service.
+// All implementations must embed UnimplementedCloudProductServiceServer
+// for forward compatibility
+type CloudProductServiceServer interface {
+	ListProducts(context.Context, *ListProductsRequest) (*ListProductsResponse, error)
+	GetProduct(context.Context, *GetProductRequest) (*Product, error)
+	CreateProduct(context.Context, *CreateProductRequest) (*Product, error)
+	// 整体更新云产品，未提供的字段将被清空
+	UpdateProduct(context.Context, *UpdateProductRequest) (*Product, error)
+	PatchProduct(context.Context, *PatchProductRequest) (*Product, error)
+	DeleteProduct(context.Context, *DeleteProductRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedCloudProductServiceServer()
+}
+
+// UnimplementedCloudProductServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedCloudProductServiceServer struct {
+}
+
+func (UnimplementedCloudProductServiceServer) ListProducts(context.Context, *ListProductsRequest) (*ListProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProducts not implemented")
+}
+func (UnimplementedCloudProductServiceServer) GetProduct(context.Context, *GetProductRequest) (*Product, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProduct not implemented")
+}
+func (UnimplementedCloudProductServiceServer) CreateProduct(context.Context, *CreateProductRequest) (*Product, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateProduct not implemented")
+}
+func (UnimplementedCloudProductServiceServer) UpdateProduct(context.Context, *UpdateProductRequest) (*Product, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateProduct not implemented")
+}
+func (UnimplementedCloudProductServiceServer) PatchProduct(context.Context, *PatchProductRequest) (*Product, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PatchProduct not implemented")
+}
+func (UnimplementedCloudProductServiceServer) DeleteProduct(context.Context, *DeleteProductRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteProduct not implemented")
+}
+func (UnimplementedCloudProductServiceServer) mustEmbedUnimplementedCloudProductServiceServer() {}
+
+// UnsafeCloudProductServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CloudProductServiceServer will
+// result in compilation errors.
+type UnsafeCloudProductServiceServer interface {
+	mustEmbedUnimplementedCloudProductServiceServer()
+}
+
+func RegisterCloudProductServiceServer(s grpc.ServiceRegistrar, srv CloudProductServiceServer) {
+	s.RegisterService(&CloudProductService_ServiceDesc, srv)
+}
+
+func _CloudProductService_ListProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudProductServiceServer).ListProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CloudProductService_ListProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudProductServiceServer).ListProducts(ctx, req.(*ListProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CloudProductService_GetProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudProductServiceServer).GetProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CloudProductService_GetProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudProductServiceServer).GetProduct(ctx, req.(*GetProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CloudProductService_CreateProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudProductServiceServer).CreateProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CloudProductService_CreateProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudProductServiceServer).CreateProduct(ctx, req.(*CreateProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CloudProductService_UpdateProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudProductServiceServer).UpdateProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CloudProductService_UpdateProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudProductServiceServer).UpdateProduct(ctx, req.(*UpdateProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CloudProductService_PatchProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatchProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudProductServiceServer).PatchProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CloudProductService_PatchProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudProductServiceServer).PatchProduct(ctx, req.(*PatchProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CloudProductService_DeleteProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudProductServiceServer).DeleteProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CloudProductService_DeleteProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudProductServiceServer).DeleteProduct(ctx, req.(*DeleteProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CloudProductService_ServiceDesc is the grpc.ServiceDesc for CloudProductService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CloudProductService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "cloudeye.v1.CloudProductService",
+	HandlerType: (*CloudProductServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListProducts",
+			Handler:    _CloudProductService_ListProducts_Handler,
+		},
+		{
+			MethodName: "GetProduct",
+			Handler:    _CloudProductService_GetProduct_Handler,
+		},
+		{
+			MethodName: "CreateProduct",
+			Handler:    _CloudProductService_CreateProduct_Handler,
+		},
+		{
+			MethodName: "UpdateProduct",
+			Handler:    _CloudProductService_UpdateProduct_Handler,
+		},
+		{
+			MethodName: "PatchProduct",
+			Handler:    _CloudProductService_PatchProduct_Handler,
+		},
+		{
+			MethodName: "DeleteProduct",
+			Handler:    _CloudProductService_DeleteProduct_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "cloudeye.proto",
+}
+
+const (
+	ConfigurationItemService_ListConfigItems_FullMethodName   = "/cloudeye.v1.ConfigurationItemService/ListConfigItems"
+	ConfigurationItemService_StreamConfigItems_FullMethodName = "/cloudeye.v1.ConfigurationItemService/StreamConfigItems"
+	ConfigurationItemService_GetConfigItem_FullMethodName     = "/cloudeye.v1.ConfigurationItemService/GetConfigItem"
+	ConfigurationItemService_CreateConfigItem_FullMethodName  = "/cloudeye.v1.ConfigurationItemService/CreateConfigItem"
+	ConfigurationItemService_UpdateConfigItem_FullMethodName  = "/cloudeye.v1.ConfigurationItemService/UpdateConfigItem"
+	ConfigurationItemService_PatchConfigItem_FullMethodName   = "/cloudeye.v1.ConfigurationItemService/PatchConfigItem"
+	ConfigurationItemService_DeleteConfigItem_FullMethodName  = "/cloudeye.v1.ConfigurationItemService/DeleteConfigItem"
+	ConfigurationItemService_EvaluateResources_FullMethodName = "/cloudeye.v1.ConfigurationItemService/EvaluateResources"
+)
+
+// ConfigurationItemServiceClient is the client API for ConfigurationItemService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ConfigurationItemServiceClient interface {
+	ListConfigItems(ctx context.Context, in *ListConfigItemsRequest, opts ...grpc.CallOption) (*ListConfigItemsResponse, error)
+	// 逐条返回满足条件的全部配置项，服务端按游标分页读取，适用于大量数据
+	StreamConfigItems(ctx context.Context, in *StreamConfigItemsRequest, opts ...grpc.CallOption) (ConfigurationItemService_StreamConfigItemsClient, error)
+	GetConfigItem(ctx context.Context, in *GetConfigItemRequest, opts ...grpc.CallOption) (*ConfigItem, error)
+	CreateConfigItem(ctx context.Context, in *CreateConfigItemRequest, opts ...grpc.CallOption) (*ConfigItem, error)
+	// 整体更新配置项，未提供的字段将被清空
+	UpdateConfigItem(ctx context.Context, in *UpdateConfigItemRequest, opts ...grpc.CallOption) (*ConfigItem, error)
+	PatchConfigItem(ctx context.Context, in *PatchConfigItemRequest, opts ...grpc.CallOption) (*ConfigItem, error)
+	// 将配置项移入回收站
+	DeleteConfigItem(ctx context.Context, in *DeleteConfigItemRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	EvaluateResources(ctx context.Context, in *EvaluateResourcesRequest, opts ...grpc.CallOption) (*EvaluateResourcesResponse, error)
+}
+
+type configurationItemServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewConfigurationItemServiceClient(cc grpc.ClientConnInterface) ConfigurationItemServiceClient {
+	return &configurationItemServiceClient{cc}
+}
+
+func (c *configurationItemServiceClient) ListConfigItems(ctx context.Context, in *ListConfigItemsRequest, opts ...grpc.CallOption) (*ListConfigItemsResponse, error) {
+	out := new(ListConfigItemsResponse)
+	err := c.cc.Invoke(ctx, ConfigurationItemService_ListConfigItems_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configurationItemServiceClient) StreamConfigItems(ctx context.Context, in *StreamConfigItemsRequest, opts ...grpc.CallOption) (ConfigurationItemService_StreamConfigItemsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ConfigurationItemService_ServiceDesc.Streams[0], ConfigurationItemService_StreamConfigItems_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &configurationItemServiceStreamConfigItemsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ConfigurationItemService_StreamConfigItemsClient interface {
+	Recv() (*ConfigItem, error)
+	grpc.ClientStream
+}
+
+type configurationItemServiceStreamConfigItemsClient struct {
+	grpc.ClientStream
+}
+
+func (x *configurationItemServiceStreamConfigItemsClient) Recv() (*ConfigItem, error) {
+	m := new(ConfigItem)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *configurationItemServiceClient) GetConfigItem(ctx context.Context, in *GetConfigItemRequest, opts ...grpc.CallOption) (*ConfigItem, error) {
+	out := new(ConfigItem)
+	err := c.cc.Invoke(ctx, ConfigurationItemService_GetConfigItem_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configurationItemServiceClient) CreateConfigItem(ctx context.Context, in *CreateConfigItemRequest, opts ...grpc.CallOption) (*ConfigItem, error) {
+	out := new(ConfigItem)
+	err := c.cc.Invoke(ctx, ConfigurationItemService_CreateConfigItem_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configurationItemServiceClient) UpdateConfigItem(ctx context.Context, in *UpdateConfigItemRequest, opts ...grpc.CallOption) (*ConfigItem, error) {
+	out := new(ConfigItem)
+	err := c.cc.Invoke(ctx, ConfigurationItemService_UpdateConfigItem_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configurationItemServiceClient) PatchConfigItem(ctx context.Context, in *PatchConfigItemRequest, opts ...grpc.CallOption) (*ConfigItem, error) {
+	out := new(ConfigItem)
+	err := c.cc.Invoke(ctx, ConfigurationItemService_PatchConfigItem_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configurationItemServiceClient) DeleteConfigItem(ctx context.Context, in *DeleteConfigItemRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ConfigurationItemService_DeleteConfigItem_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configurationItemServiceClient) EvaluateResources(ctx context.Context, in *EvaluateResourcesRequest, opts ...grpc.CallOption) (*EvaluateResourcesResponse, error) {
+	out := new(EvaluateResourcesResponse)
+	err := c.cc.Invoke(ctx, ConfigurationItemService_EvaluateResources_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ConfigurationItemServiceServer is the server API for ConfigurationItemService service.
+// All implementations must embed UnimplementedConfigurationItemServiceServer
+// for forward compatibility
+type ConfigurationItemServiceServer interface {
+	ListConfigItems(context.Context, *ListConfigItemsRequest) (*ListConfigItemsResponse, error)
+	// 逐条返回满足条件的全部配置项，服务端按游标分页读取，适用于大量数据
+	StreamConfigItems(*StreamConfigItemsRequest, ConfigurationItemService_StreamConfigItemsServer) error
+	GetConfigItem(context.Context, *GetConfigItemRequest) (*ConfigItem, error)
+	CreateConfigItem(context.Context, *CreateConfigItemRequest) (*ConfigItem, error)
+	// 整体更新配置项，未提供的字段将被清空
+	UpdateConfigItem(context.Context, *UpdateConfigItemRequest) (*ConfigItem, error)
+	PatchConfigItem(context.Context, *PatchConfigItemRequest) (*ConfigItem, error)
+	// 将配置项移入回收站
+	DeleteConfigItem(context.Context, *DeleteConfigItemRequest) (*emptypb.Empty, error)
+	EvaluateResources(context.Context, *EvaluateResourcesRequest) (*EvaluateResourcesResponse, error)
+	mustEmbedUnimplementedConfigurationItemServiceServer()
+}
+
+// UnimplementedConfigurationItemServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedConfigurationItemServiceServer struct {
+}
+
+func (UnimplementedConfigurationItemServiceServer) ListConfigItems(context.Context, *ListConfigItemsRequest) (*ListConfigItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListConfigItems not implemented")
+}
+func (UnimplementedConfigurationItemServiceServer) StreamConfigItems(*StreamConfigItemsRequest, ConfigurationItemService_StreamConfigItemsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamConfigItems not implemented")
+}
+func (UnimplementedConfigurationItemServiceServer) GetConfigItem(context.Context, *GetConfigItemRequest) (*ConfigItem, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConfigItem not implemented")
+}
+func (UnimplementedConfigurationItemServiceServer) CreateConfigItem(context.Context, *CreateConfigItemRequest) (*ConfigItem, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateConfigItem not implemented")
+}
+func (UnimplementedConfigurationItemServiceServer) UpdateConfigItem(context.Context, *UpdateConfigItemRequest) (*ConfigItem, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateConfigItem not implemented")
+}
+func (UnimplementedConfigurationItemServiceServer) PatchConfigItem(context.Context, *PatchConfigItemRequest) (*ConfigItem, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PatchConfigItem not implemented")
+}
+func (UnimplementedConfigurationItemServiceServer) DeleteConfigItem(context.Context, *DeleteConfigItemRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteConfigItem not implemented")
+}
+func (UnimplementedConfigurationItemServiceServer) EvaluateResources(context.Context, *EvaluateResourcesRequest) (*EvaluateResourcesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EvaluateResources not implemented")
+}
+func (UnimplementedConfigurationItemServiceServer) mustEmbedUnimplementedConfigurationItemServiceServer() {
+}
+
+// UnsafeConfigurationItemServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ConfigurationItemServiceServer will
+// result in compilation errors.
+type UnsafeConfigurationItemServiceServer interface {
+	mustEmbedUnimplementedConfigurationItemServiceServer()
+}
+
+func RegisterConfigurationItemServiceServer(s grpc.ServiceRegistrar, srv ConfigurationItemServiceServer) {
+	s.RegisterService(&ConfigurationItemService_ServiceDesc, srv)
+}
+
+func _ConfigurationItemService_ListConfigItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListConfigItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigurationItemServiceServer).ListConfigItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConfigurationItemService_ListConfigItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigurationItemServiceServer).ListConfigItems(ctx, req.(*ListConfigItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConfigurationItemService_StreamConfigItems_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamConfigItemsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ConfigurationItemServiceServer).StreamConfigItems(m, &configurationItemServiceStreamConfigItemsServer{stream})
+}
+
+type ConfigurationItemService_StreamConfigItemsServer interface {
+	Send(*ConfigItem) error
+	grpc.ServerStream
+}
+
+type configurationItemServiceStreamConfigItemsServer struct {
+	grpc.ServerStream
+}
+
+func (x *configurationItemServiceStreamConfigItemsServer) Send(m *ConfigItem) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ConfigurationItemService_GetConfigItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigurationItemServiceServer).GetConfigItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConfigurationItemService_GetConfigItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigurationItemServiceServer).GetConfigItem(ctx, req.(*GetConfigItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConfigurationItemService_CreateConfigItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateConfigItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigurationItemServiceServer).CreateConfigItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConfigurationItemService_CreateConfigItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigurationItemServiceServer).CreateConfigItem(ctx, req.(*CreateConfigItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConfigurationItemService_UpdateConfigItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateConfigItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigurationItemServiceServer).UpdateConfigItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConfigurationItemService_UpdateConfigItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigurationItemServiceServer).UpdateConfigItem(ctx, req.(*UpdateConfigItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConfigurationItemService_PatchConfigItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatchConfigItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigurationItemServiceServer).PatchConfigItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConfigurationItemService_PatchConfigItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigurationItemServiceServer).PatchConfigItem(ctx, req.(*PatchConfigItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConfigurationItemService_DeleteConfigItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteConfigItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigurationItemServiceServer).DeleteConfigItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConfigurationItemService_DeleteConfigItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigurationItemServiceServer).DeleteConfigItem(ctx, req.(*DeleteConfigItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConfigurationItemService_EvaluateResources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvaluateResourcesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigurationItemServiceServer).EvaluateResources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConfigurationItemService_EvaluateResources_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigurationItemServiceServer).EvaluateResources(ctx, req.(*EvaluateResourcesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ConfigurationItemService_ServiceDesc is the grpc.ServiceDesc for ConfigurationItemService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ConfigurationItemService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "cloudeye.v1.ConfigurationItemService",
+	HandlerType: (*ConfigurationItemServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListConfigItems",
+			Handler:    _ConfigurationItemService_ListConfigItems_Handler,
+		},
+		{
+			MethodName: "GetConfigItem",
+			Handler:    _ConfigurationItemService_GetConfigItem_Handler,
+		},
+		{
+			MethodName: "CreateConfigItem",
+			Handler:    _ConfigurationItemService_CreateConfigItem_Handler,
+		},
+		{
+			MethodName: "UpdateConfigItem",
+			Handler:    _ConfigurationItemService_UpdateConfigItem_Handler,
+		},
+		{
+			MethodName: "PatchConfigItem",
+			Handler:    _ConfigurationItemService_PatchConfigItem_Handler,
+		},
+		{
+			MethodName: "DeleteConfigItem",
+			Handler:    _ConfigurationItemService_DeleteConfigItem_Handler,
+		},
+		{
+			MethodName: "EvaluateResources",
+			Handler:    _ConfigurationItemService_EvaluateResources_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamConfigItems",
+			Handler:       _ConfigurationItemService_StreamConfigItems_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "cloudeye.proto",
+}
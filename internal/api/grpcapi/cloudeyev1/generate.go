@@ -0,0 +1,4 @@
+// Package cloudeyev1 cloudeye.proto生成的消息类型和gRPC服务代码，不要手工修改生成的文件
+package cloudeyev1
+
+//go:generate buf generate
@@ -0,0 +1,210 @@
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/yourusername/cloud-eye/internal/api/grpcapi/cloudeyev1"
+	"github.com/yourusername/cloud-eye/internal/api/handler"
+	"github.com/yourusername/cloud-eye/internal/models"
+	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+	"github.com/yourusername/cloud-eye/internal/repository"
+	"github.com/yourusername/cloud-eye/internal/service"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// streamPageSize 流式返回配置项时每次从数据库读取的数量
+const streamPageSize = 100
+
+// configItemServer 配置项gRPC接口
+type configItemServer struct {
+	cloudeyev1.UnimplementedConfigurationItemServiceServer
+	service service.ConfigurationItemService
+}
+
+// configItemFilter 转换配置项过滤条件
+func configItemFilter(f *cloudeyev1.ConfigItemFilter) (repository.ConfigItemFilter, error) {
+	filter := repository.ConfigItemFilter{
+		Tags:     f.GetTags(),
+		TagMatch: f.GetTagMatch(),
+		Keyword:  keyword(f.GetKeyword()),
+	}
+	var err error
+	if filter.CloudProviderIDs, err = toUints(f.GetCloudProviderIds()); err != nil {
+		return filter, err
+	}
+	if filter.ProductIDs, err = toUints(f.GetProductIds()); err != nil {
+		return filter, err
+	}
+	if filter.CategoryIDs, err = toUints(f.GetCategoryIds()); err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
+
+// ListConfigItems 分页获取配置项
+func (s *configItemServer) ListConfigItems(ctx context.Context, req *cloudeyev1.ListConfigItemsRequest) (*cloudeyev1.ListConfigItemsResponse, error) {
+	filter, err := configItemFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	filter.Page, filter.PageSize, filter.ListOptions = pageOptions(req.GetPage())
+
+	result, err := s.service.GetConfigItemsByFilter(ctx, filter)
+	if err != nil {
+		logger.Error("Failed to list configuration items", err)
+		return nil, statusError(err)
+	}
+
+	items, _ := result.Data.([]models.ConfigurationItem)
+	resp := &cloudeyev1.ListConfigItemsResponse{
+		Items:    make([]*cloudeyev1.ConfigItem, len(items)),
+		PageInfo: toPageInfo(result),
+	}
+	for i := range items {
+		resp.Items[i] = toConfigItem(&items[i])
+	}
+	return resp, nil
+}
+
+// StreamConfigItems 按游标分页逐页读取并逐条发送满足条件的全部配置项，内存占用与总数无关
+func (s *configItemServer) StreamConfigItems(req *cloudeyev1.StreamConfigItemsRequest, stream cloudeyev1.ConfigurationItemService_StreamConfigItemsServer) error {
+	ctx := stream.Context()
+	filter, err := configItemFilter(req.GetFilter())
+	if err != nil {
+		return err
+	}
+	cursor := ""
+	filter.PageSize = streamPageSize
+	filter.ListOptions = repository.ListOptions{
+		Sort:    handler.ParseSortFields(req.GetSort()),
+		Include: include,
+		Cursor:  &cursor,
+	}
+
+	sent := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return status.Error(codes.Canceled, "客户端已取消调用")
+		}
+		result, err := s.service.GetConfigItemsByFilter(ctx, filter)
+		if err != nil {
+			logger.Error("Failed to stream configuration items", err, zap.Int("sent", sent))
+			return statusError(err)
+		}
+
+		items, _ := result.Data.([]models.ConfigurationItem)
+		for i := range items {
+			if err := stream.Send(toConfigItem(&items[i])); err != nil {
+				return err
+			}
+			sent++
+		}
+		if result.NextCursor == "" {
+			return nil
+		}
+		cursor = result.NextCursor
+	}
+}
+
+// GetConfigItem 根据ID获取配置项
+func (s *configItemServer) GetConfigItem(ctx context.Context, req *cloudeyev1.GetConfigItemRequest) (*cloudeyev1.ConfigItem, error) {
+	id, err := requireID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	item, err := s.service.GetConfigItemByID(ctx, id)
+	if err != nil {
+		logger.Error("Failed to get configuration item", err, zap.Uint("id", id))
+		return nil, statusError(err)
+	}
+	return toConfigItem(item), nil
+}
+
+// CreateConfigItem 创建配置项
+func (s *configItemServer) CreateConfigItem(ctx context.Context, req *cloudeyev1.CreateConfigItemRequest) (*cloudeyev1.ConfigItem, error) {
+	in := configItemRequest(req.GetItem())
+	if err := validate(in); err != nil {
+		return nil, err
+	}
+
+	item := in.Model()
+	if err := s.service.CreateConfigItem(ctx, item); err != nil {
+		logger.Error("Failed to create configuration item", err)
+		return nil, statusError(err)
+	}
+	return toConfigItem(item), nil
+}
+
+// UpdateConfigItem 整体更新配置项
+func (s *configItemServer) UpdateConfigItem(ctx context.Context, req *cloudeyev1.UpdateConfigItemRequest) (*cloudeyev1.ConfigItem, error) {
+	id, err := requireID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	in := configItemRequest(req.GetItem())
+	if err := validate(in); err != nil {
+		return nil, err
+	}
+
+	item := in.Model()
+	item.ID = id
+	if err := s.service.UpdateConfigItem(ctx, item); err != nil {
+		logger.Error("Failed to update configuration item", err, zap.Uint("id", id))
+		return nil, statusError(err)
+	}
+	return s.GetConfigItem(ctx, &cloudeyev1.GetConfigItemRequest{Id: req.GetId()})
+}
+
+// PatchConfigItem 按JSON合并补丁部分更新配置项
+func (s *configItemServer) PatchConfigItem(ctx context.Context, req *cloudeyev1.PatchConfigItemRequest) (*cloudeyev1.ConfigItem, error) {
+	id, err := requireID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.service.GetConfigItemByID(ctx, id)
+	if err != nil {
+		logger.Error("Failed to get configuration item for patch", err, zap.Uint("id", id))
+		return nil, statusError(err)
+	}
+	if err := validatePatch(handler.NewConfigItemRequest(current), req.GetMergePatch(), &handler.ConfigItemRequest{}); err != nil {
+		return nil, err
+	}
+
+	item, err := s.service.PatchConfigItem(ctx, id, []byte(req.GetMergePatch()))
+	if err != nil {
+		logger.Error("Failed to patch configuration item", err, zap.Uint("id", id))
+		return nil, statusError(err)
+	}
+	return toConfigItem(item), nil
+}
+
+// DeleteConfigItem 删除配置项（移入回收站）
+func (s *configItemServer) DeleteConfigItem(ctx context.Context, req *cloudeyev1.DeleteConfigItemRequest) (*emptypb.Empty, error) {
+	id, err := requireID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.service.DeleteConfigItem(ctx, id); err != nil {
+		logger.Error("Failed to delete configuration item", err, zap.Uint("id", id))
+		return nil, statusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// EvaluateResources 使用生效中的配置项评估资源配置
+func (s *configItemServer) EvaluateResources(ctx context.Context, req *cloudeyev1.EvaluateResourcesRequest) (*cloudeyev1.EvaluateResourcesResponse, error) {
+	in := evaluationRequest(req)
+	if err := validate(in); err != nil {
+		return nil, err
+	}
+
+	report, err := s.service.EvaluateResources(ctx, in.Model())
+	if err != nil {
+		logger.Error("Failed to evaluate resources", err, zap.Int("count", len(in.Resources)))
+		return nil, statusError(err)
+	}
+	return toEvaluationResponse(report), nil
+}
@@ -0,0 +1,194 @@
+package grpcapi
+
+import (
+	"fmt"
+
+	"github.com/yourusername/cloud-eye/internal/api/grpcapi/cloudeyev1"
+	"github.com/yourusername/cloud-eye/internal/api/handler"
+	"github.com/yourusername/cloud-eye/internal/models"
+	"github.com/yourusername/cloud-eye/internal/pkg/evaluation"
+	"github.com/yourusername/cloud-eye/internal/repository"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func toProvider(p *models.CloudProvider) *cloudeyev1.Provider {
+	return &cloudeyev1.Provider{
+		Id:          uint64(p.ID),
+		Name:        p.Name,
+		Code:        p.Code,
+		Description: p.Description,
+		CreatedAt:   timestamppb.New(p.CreatedAt),
+		UpdatedAt:   timestamppb.New(p.UpdatedAt),
+		Tags:        tagNames(p.Tags),
+	}
+}
+
+func toProduct(p *models.CloudProduct) *cloudeyev1.Product {
+	return &cloudeyev1.Product{
+		Id:              uint64(p.ID),
+		CloudProviderId: uint64(p.CloudProviderID),
+		Name:            p.Name,
+		Code:            p.Code,
+		Description:     p.Description,
+		CategoryId:      optionalID(p.CategoryID),
+		CreatedAt:       timestamppb.New(p.CreatedAt),
+		UpdatedAt:       timestamppb.New(p.UpdatedAt),
+		Tags:            tagNames(p.Tags),
+	}
+}
+
+func toConfigItem(item *models.ConfigurationItem) *cloudeyev1.ConfigItem {
+	return &cloudeyev1.ConfigItem{
+		Id:                  uint64(item.ID),
+		CloudProviderId:     uint64(item.CloudProviderID),
+		ProductId:           uint64(item.ProductID),
+		Name:                item.Name,
+		RecommendedValue:    item.RecommendedValue,
+		RiskDescription:     item.RiskDescription,
+		CheckMethod:         item.CheckMethod,
+		ConfigurationMethod: item.ConfigurationMethod,
+		Reference:           item.Reference,
+		Severity:            item.Severity,
+		Status:              item.Status,
+		ControlFamilyId:     optionalID(item.ControlFamilyID),
+		CreatedAt:           timestamppb.New(item.CreatedAt),
+		UpdatedAt:           timestamppb.New(item.UpdatedAt),
+		Tags:                tagNames(item.Tags),
+	}
+}
+
+func toPageInfo(result *repository.PageResult) *cloudeyev1.PageInfo {
+	return &cloudeyev1.PageInfo{
+		Total:      result.Total,
+		Page:       int32(result.Page),
+		PageSize:   int32(result.PageSize),
+		NextCursor: result.NextCursor,
+		PrevCursor: result.PrevCursor,
+	}
+}
+
+func tagNames(tags []models.Tag) []string {
+	names := make([]string, len(tags))
+	for i, t := range tags {
+		names[i] = t.Name
+	}
+	return names
+}
+
+func optionalID(id *uint) *uint64 {
+	if id == nil {
+		return nil
+	}
+	v := uint64(*id)
+	return &v
+}
+
+func fromOptionalID(id *uint64) *uint {
+	if id == nil {
+		return nil
+	}
+	v := uint(*id)
+	return &v
+}
+
+// 输入消息转换为REST接口的请求DTO，复用相同的校验规则
+
+func providerRequest(in *cloudeyev1.ProviderInput) *handler.ProviderRequest {
+	return &handler.ProviderRequest{
+		Name:        in.GetName(),
+		Code:        in.GetCode(),
+		Description: in.GetDescription(),
+	}
+}
+
+func productRequest(in *cloudeyev1.ProductInput) *handler.ProductRequest {
+	return &handler.ProductRequest{
+		CloudProviderID: uint(in.GetCloudProviderId()),
+		Name:            in.GetName(),
+		Code:            in.GetCode(),
+		Description:     in.GetDescription(),
+		CategoryID:      fromOptionalID(in.CategoryId),
+	}
+}
+
+func configItemRequest(in *cloudeyev1.ConfigItemInput) *handler.ConfigItemRequest {
+	return &handler.ConfigItemRequest{
+		CloudProviderID:     uint(in.GetCloudProviderId()),
+		ProductID:           uint(in.GetProductId()),
+		Name:                in.GetName(),
+		RecommendedValue:    in.GetRecommendedValue(),
+		RiskDescription:     in.GetRiskDescription(),
+		CheckMethod:         in.GetCheckMethod(),
+		ConfigurationMethod: in.GetConfigurationMethod(),
+		Reference:           in.GetReference(),
+		Severity:            in.GetSeverity(),
+		Status:              in.GetStatus(),
+	}
+}
+
+func evaluationRequest(in *cloudeyev1.EvaluateResourcesRequest) *handler.EvaluationRequest {
+	req := &handler.EvaluationRequest{Resources: make([]handler.ResourceRequest, len(in.GetResources()))}
+	for i, r := range in.GetResources() {
+		req.Resources[i] = handler.ResourceRequest{
+			ID:       r.GetId(),
+			Name:     r.GetName(),
+			Provider: r.GetProvider(),
+			Product:  r.GetProduct(),
+			Config:   r.GetConfig().AsMap(),
+		}
+	}
+	return req
+}
+
+func toEvaluationResponse(report *evaluation.Report) *cloudeyev1.EvaluateResourcesResponse {
+	failedBy := make(map[string]int32, len(report.Summary.FailedBy))
+	for severity, n := range report.Summary.FailedBy {
+		failedBy[severity] = int32(n)
+	}
+	resp := &cloudeyev1.EvaluateResourcesResponse{
+		Summary: &cloudeyev1.EvaluationSummary{
+			Resources:          int32(report.Summary.Resources),
+			Findings:           int32(report.Summary.Findings),
+			Passed:             int32(report.Summary.Passed),
+			Failed:             int32(report.Summary.Failed),
+			Errors:             int32(report.Summary.Errors),
+			Manual:             int32(report.Summary.Manual),
+			FailedBySeverity:   failedBy,
+			UnmatchedResources: report.Summary.Unmatched,
+		},
+		Findings: make([]*cloudeyev1.Finding, len(report.Findings)),
+	}
+
+	for i, f := range report.Findings {
+		finding := &cloudeyev1.Finding{
+			ResourceId:   f.ResourceID,
+			ResourceName: f.ResourceName,
+			Provider:     f.Provider,
+			Product:      f.Product,
+			ItemId:       uint64(f.ItemID),
+			ItemName:     f.ItemName,
+			Severity:     f.Severity,
+			Status:       f.Status,
+			Rule:         f.Rule,
+			Message:      f.Message,
+		}
+		if f.Expected != nil {
+			finding.Expected = toValue(f.Expected)
+		}
+		if f.Actual != nil {
+			finding.Actual = toValue(f.Actual)
+		}
+		resp.Findings[i] = finding
+	}
+	return resp
+}
+
+// toValue 转换规则的期望值和资源配置中的实际值，无法表示为JSON的值按字符串输出
+func toValue(v interface{}) *structpb.Value {
+	value, err := structpb.NewValue(v)
+	if err != nil {
+		return structpb.NewStringValue(fmt.Sprint(v))
+	}
+	return value
+}
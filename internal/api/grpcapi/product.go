@@ -0,0 +1,140 @@
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/yourusername/cloud-eye/internal/api/grpcapi/cloudeyev1"
+	"github.com/yourusername/cloud-eye/internal/api/handler"
+	"github.com/yourusername/cloud-eye/internal/models"
+	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+	"github.com/yourusername/cloud-eye/internal/repository"
+	"github.com/yourusername/cloud-eye/internal/service"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// productServer 云产品gRPC接口
+type productServer struct {
+	cloudeyev1.UnimplementedCloudProductServiceServer
+	service service.CloudProductService
+}
+
+// ListProducts 分页获取云产品
+func (s *productServer) ListProducts(ctx context.Context, req *cloudeyev1.ListProductsRequest) (*cloudeyev1.ListProductsResponse, error) {
+	providerIDs, err := toUints(req.GetCloudProviderIds())
+	if err != nil {
+		return nil, err
+	}
+	categoryIDs, err := toUints(req.GetCategoryIds())
+	if err != nil {
+		return nil, err
+	}
+	filter := repository.CloudProductFilter{
+		CloudProviderIDs: providerIDs,
+		CategoryIDs:      categoryIDs,
+		Codes:            req.GetCodes(),
+		Keyword:          keyword(req.GetKeyword()),
+	}
+	filter.Page, filter.PageSize, filter.ListOptions = pageOptions(req.GetPage())
+
+	result, err := s.service.ListProductsPage(ctx, filter)
+	if err != nil {
+		logger.Error("Failed to list cloud products by page", err)
+		return nil, statusError(err)
+	}
+
+	products, _ := result.Data.([]models.CloudProduct)
+	resp := &cloudeyev1.ListProductsResponse{
+		Products: make([]*cloudeyev1.Product, len(products)),
+		PageInfo: toPageInfo(result),
+	}
+	for i := range products {
+		resp.Products[i] = toProduct(&products[i])
+	}
+	return resp, nil
+}
+
+// GetProduct 根据ID获取云产品
+func (s *productServer) GetProduct(ctx context.Context, req *cloudeyev1.GetProductRequest) (*cloudeyev1.Product, error) {
+	id, err := requireID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	product, err := s.service.GetProductByID(ctx, id)
+	if err != nil {
+		logger.Error("Failed to get cloud product", err, zap.Uint("id", id))
+		return nil, statusError(err)
+	}
+	return toProduct(product), nil
+}
+
+// CreateProduct 创建云产品
+func (s *productServer) CreateProduct(ctx context.Context, req *cloudeyev1.CreateProductRequest) (*cloudeyev1.Product, error) {
+	in := productRequest(req.GetProduct())
+	if err := validate(in); err != nil {
+		return nil, err
+	}
+
+	product := in.Model()
+	if err := s.service.CreateProduct(ctx, product); err != nil {
+		logger.Error("Failed to create cloud product", err)
+		return nil, statusError(err)
+	}
+	return toProduct(product), nil
+}
+
+// UpdateProduct 整体更新云产品
+func (s *productServer) UpdateProduct(ctx context.Context, req *cloudeyev1.UpdateProductRequest) (*cloudeyev1.Product, error) {
+	id, err := requireID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	in := productRequest(req.GetProduct())
+	if err := validate(in); err != nil {
+		return nil, err
+	}
+
+	product := in.Model()
+	product.ID = id
+	if err := s.service.UpdateProduct(ctx, product); err != nil {
+		logger.Error("Failed to update cloud product", err, zap.Uint("id", id))
+		return nil, statusError(err)
+	}
+	return s.GetProduct(ctx, &cloudeyev1.GetProductRequest{Id: req.GetId()})
+}
+
+// PatchProduct 按JSON合并补丁部分更新云产品
+func (s *productServer) PatchProduct(ctx context.Context, req *cloudeyev1.PatchProductRequest) (*cloudeyev1.Product, error) {
+	id, err := requireID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.service.GetProductByID(ctx, id)
+	if err != nil {
+		logger.Error("Failed to get cloud product for patch", err, zap.Uint("id", id))
+		return nil, statusError(err)
+	}
+	if err := validatePatch(handler.NewProductRequest(current), req.GetMergePatch(), &handler.ProductRequest{}); err != nil {
+		return nil, err
+	}
+
+	product, err := s.service.PatchProduct(ctx, id, []byte(req.GetMergePatch()))
+	if err != nil {
+		logger.Error("Failed to patch cloud product", err, zap.Uint("id", id))
+		return nil, statusError(err)
+	}
+	return toProduct(product), nil
+}
+
+// DeleteProduct 删除云产品
+func (s *productServer) DeleteProduct(ctx context.Context, req *cloudeyev1.DeleteProductRequest) (*emptypb.Empty, error) {
+	id, err := requireID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.service.DeleteProduct(ctx, id); err != nil {
+		logger.Error("Failed to delete cloud product", err, zap.Uint("id", id))
+		return nil, statusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
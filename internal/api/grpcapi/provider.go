@@ -0,0 +1,140 @@
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/yourusername/cloud-eye/internal/api/grpcapi/cloudeyev1"
+	"github.com/yourusername/cloud-eye/internal/api/handler"
+	"github.com/yourusername/cloud-eye/internal/models"
+	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+	"github.com/yourusername/cloud-eye/internal/repository"
+	"github.com/yourusername/cloud-eye/internal/service"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// providerServer 云服务商gRPC接口
+type providerServer struct {
+	cloudeyev1.UnimplementedCloudProviderServiceServer
+	service service.CloudProviderService
+}
+
+// ListProviders 分页获取云服务商
+func (s *providerServer) ListProviders(ctx context.Context, req *cloudeyev1.ListProvidersRequest) (*cloudeyev1.ListProvidersResponse, error) {
+	filter := repository.CloudProviderFilter{
+		Codes:   req.GetCodes(),
+		Keyword: keyword(req.GetKeyword()),
+	}
+	filter.Page, filter.PageSize, filter.ListOptions = pageOptions(req.GetPage())
+
+	result, err := s.service.ListProvidersPage(ctx, filter)
+	if err != nil {
+		logger.Error("Failed to list cloud providers by page", err)
+		return nil, statusError(err)
+	}
+
+	providers, _ := result.Data.([]models.CloudProvider)
+	resp := &cloudeyev1.ListProvidersResponse{
+		Providers: make([]*cloudeyev1.Provider, len(providers)),
+		PageInfo:  toPageInfo(result),
+	}
+	for i := range providers {
+		resp.Providers[i] = toProvider(&providers[i])
+	}
+	return resp, nil
+}
+
+// GetProvider 根据ID获取云服务商
+func (s *providerServer) GetProvider(ctx context.Context, req *cloudeyev1.GetProviderRequest) (*cloudeyev1.Provider, error) {
+	id, err := requireID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	provider, err := s.service.GetProviderByID(ctx, id)
+	if err != nil {
+		logger.Error("Failed to get cloud provider", err, zap.Uint("id", id))
+		return nil, statusError(err)
+	}
+	return toProvider(provider), nil
+}
+
+// GetProviderByCode 根据代码获取云服务商
+func (s *providerServer) GetProviderByCode(ctx context.Context, req *cloudeyev1.GetProviderByCodeRequest) (*cloudeyev1.Provider, error) {
+	provider, err := s.service.GetProviderByCode(ctx, req.GetCode())
+	if err != nil {
+		logger.Error("Failed to get cloud provider by code", err, zap.String("code", req.GetCode()))
+		return nil, statusError(err)
+	}
+	return toProvider(provider), nil
+}
+
+// CreateProvider 创建云服务商
+func (s *providerServer) CreateProvider(ctx context.Context, req *cloudeyev1.CreateProviderRequest) (*cloudeyev1.Provider, error) {
+	in := providerRequest(req.GetProvider())
+	if err := validate(in); err != nil {
+		return nil, err
+	}
+
+	provider := in.Model()
+	if err := s.service.CreateProvider(ctx, provider); err != nil {
+		logger.Error("Failed to create cloud provider", err)
+		return nil, statusError(err)
+	}
+	return toProvider(provider), nil
+}
+
+// UpdateProvider 整体更新云服务商
+func (s *providerServer) UpdateProvider(ctx context.Context, req *cloudeyev1.UpdateProviderRequest) (*cloudeyev1.Provider, error) {
+	id, err := requireID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	in := providerRequest(req.GetProvider())
+	if err := validate(in); err != nil {
+		return nil, err
+	}
+
+	provider := in.Model()
+	provider.ID = id
+	if err := s.service.UpdateProvider(ctx, provider); err != nil {
+		logger.Error("Failed to update cloud provider", err, zap.Uint("id", id))
+		return nil, statusError(err)
+	}
+	return s.GetProvider(ctx, &cloudeyev1.GetProviderRequest{Id: req.GetId()})
+}
+
+// PatchProvider 按JSON合并补丁部分更新云服务商
+func (s *providerServer) PatchProvider(ctx context.Context, req *cloudeyev1.PatchProviderRequest) (*cloudeyev1.Provider, error) {
+	id, err := requireID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.service.GetProviderByID(ctx, id)
+	if err != nil {
+		logger.Error("Failed to get cloud provider for patch", err, zap.Uint("id", id))
+		return nil, statusError(err)
+	}
+	if err := validatePatch(handler.NewProviderRequest(current), req.GetMergePatch(), &handler.ProviderRequest{}); err != nil {
+		return nil, err
+	}
+
+	provider, err := s.service.PatchProvider(ctx, id, []byte(req.GetMergePatch()))
+	if err != nil {
+		logger.Error("Failed to patch cloud provider", err, zap.Uint("id", id))
+		return nil, statusError(err)
+	}
+	return toProvider(provider), nil
+}
+
+// DeleteProvider 删除云服务商
+func (s *providerServer) DeleteProvider(ctx context.Context, req *cloudeyev1.DeleteProviderRequest) (*emptypb.Empty, error) {
+	id, err := requireID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.service.DeleteProvider(ctx, id, req.GetCascade()); err != nil {
+		logger.Error("Failed to delete cloud provider", err, zap.Uint("id", id))
+		return nil, statusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
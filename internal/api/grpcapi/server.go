@@ -0,0 +1,188 @@
+// Package grpcapi 服务间访问的gRPC接口，与REST接口共用服务层和请求校验规则
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yourusername/cloud-eye/internal/api/grpcapi/cloudeyev1"
+	"github.com/yourusername/cloud-eye/internal/api/handler"
+	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+	"github.com/yourusername/cloud-eye/internal/repository"
+	"github.com/yourusername/cloud-eye/internal/service"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// NewServer 创建gRPC服务端并注册云服务商、云产品和配置项接口
+func NewServer(providerService service.CloudProviderService, productService service.CloudProductService, itemService service.ConfigurationItemService) *grpc.Server {
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(LoggerUnaryInterceptor(), RecoveryUnaryInterceptor()),
+		grpc.ChainStreamInterceptor(LoggerStreamInterceptor(), RecoveryStreamInterceptor()),
+	)
+	cloudeyev1.RegisterCloudProviderServiceServer(s, &providerServer{service: providerService})
+	cloudeyev1.RegisterCloudProductServiceServer(s, &productServer{service: productService})
+	cloudeyev1.RegisterConfigurationItemServiceServer(s, &configItemServer{service: itemService})
+	return s
+}
+
+// LoggerUnaryInterceptor 记录每次调用的方法、状态码和耗时，与REST接口的日志中间件对应
+func LoggerUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logCall(ctx, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// LoggerStreamInterceptor 记录流式调用的方法、状态码和耗时
+func LoggerStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		logCall(ss.Context(), info.FullMethod, start, err)
+		return err
+	}
+}
+
+func logCall(ctx context.Context, method string, start time.Time, err error) {
+	var userAgent string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		userAgent = strings.Join(md.Get("user-agent"), " ")
+	}
+	logger.Info("gRPC Request",
+		zap.String("method", method),
+		zap.String("code", status.Code(err).String()),
+		zap.Duration("latency", time.Since(start)),
+		zap.String("user_agent", userAgent),
+	)
+}
+
+// RecoveryUnaryInterceptor 将处理函数的panic转换为Internal状态，避免单个调用导致进程退出
+func RecoveryUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer recoverCall(info.FullMethod, &err)
+		return handler(ctx, req)
+	}
+}
+
+// RecoveryStreamInterceptor 将流式处理函数的panic转换为Internal状态
+func RecoveryStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer recoverCall(info.FullMethod, &err)
+		return handler(srv, ss)
+	}
+}
+
+func recoverCall(method string, err *error) {
+	if r := recover(); r != nil {
+		logger.Error("gRPC handler panicked", fmt.Errorf("%v", r), zap.String("method", method))
+		*err = status.Error(codes.Internal, "服务器内部错误")
+	}
+}
+
+// statusError 将服务层错误转换为gRPC状态，与REST接口的HandleServiceError对应
+func statusError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	serviceErr, ok := err.(*service.ServiceError)
+	if !ok {
+		logger.Error("gRPC call failed", err)
+		return status.Error(codes.Internal, "服务器内部错误")
+	}
+
+	switch serviceErr.Code {
+	case service.ErrCodeNotFound:
+		return status.Error(codes.NotFound, serviceErr.Message)
+	case service.ErrCodeInvalidData:
+		return status.Error(codes.InvalidArgument, serviceErr.Message)
+	case service.ErrCodeDuplicate:
+		return status.Error(codes.AlreadyExists, serviceErr.Message)
+	case service.ErrCodeConflict:
+		return status.Error(codes.FailedPrecondition, serviceErr.Message)
+	default:
+		return status.Error(codes.Internal, serviceErr.Message)
+	}
+}
+
+// validate 按REST请求DTO的规则校验
+func validate(req interface{}) error {
+	return fieldsError(handler.ValidateRequest(req))
+}
+
+// validatePatch 校验应用合并补丁后的结果，与REST接口的部分更新一致
+func validatePatch(current interface{}, patch string, dst interface{}) error {
+	return fieldsError(handler.MergePatchErrors(current, []byte(patch), dst))
+}
+
+// fieldsError 将字段级错误合并为InvalidArgument状态
+func fieldsError(fields []handler.FieldError) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	msgs := make([]string, len(fields))
+	for i, f := range fields {
+		msgs[i] = f.Field + ": " + f.Message
+	}
+	return status.Errorf(codes.InvalidArgument, "请求参数校验失败: %s", strings.Join(msgs, "; "))
+}
+
+// requireID 校验ID参数
+func requireID(id uint64) (uint, error) {
+	if id == 0 || id > uint64(^uint32(0)) {
+		return 0, status.Error(codes.InvalidArgument, "无效的ID参数")
+	}
+	return uint(id), nil
+}
+
+// pageOptions 转换分页参数，默认第1页、每页10条，每页最多100条
+func pageOptions(p *cloudeyev1.PageRequest) (page, pageSize int, opts repository.ListOptions) {
+	page, pageSize = 1, 10
+	if p.GetPage() > 0 {
+		page = int(p.GetPage())
+	}
+	if p.GetPageSize() > 0 {
+		pageSize = int(p.GetPageSize())
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+	opts = repository.ListOptions{
+		Sort:    handler.ParseSortFields(p.GetSort()),
+		Include: include,
+		Cursor:  p.Cursor,
+	}
+	return page, pageSize, opts
+}
+
+// include gRPC响应加载的关联
+var include = []string{"tags"}
+
+// keyword 空字符串表示不过滤
+func keyword(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// toUints 转换ID列表
+func toUints(ids []uint64) ([]uint, error) {
+	var result []uint
+	for _, id := range ids {
+		v, err := requireID(id)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, v)
+	}
+	return result, nil
+}
@@ -0,0 +1,124 @@
+package grpcapi_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/yourusername/cloud-eye/internal/api/grpcapi"
+	"github.com/yourusername/cloud-eye/internal/api/grpcapi/cloudeyev1"
+	"github.com/yourusername/cloud-eye/internal/apptest"
+	grpcgo "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// dial 连接测试服务的gRPC接口，使用官方grpc-go客户端
+func dial(t *testing.T) *grpcgo.ClientConn {
+	t.Helper()
+	app := apptest.New(t)
+	conn, err := grpcgo.Dial(app.GRPCAddr, grpcgo.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("连接gRPC服务失败: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestProviderService(t *testing.T) {
+	conn := dial(t)
+	ctx := context.Background()
+	const svc = "/cloudeye.v1.CloudProviderService/"
+
+	provider := new(cloudeyev1.Provider)
+	if err := conn.Invoke(ctx, svc+"GetProvider", &cloudeyev1.GetProviderRequest{Id: 1}, provider); err != nil {
+		t.Fatalf("GetProvider失败: %v", err)
+	}
+	if provider.Code != "AWS" || provider.CreatedAt == nil {
+		t.Fatalf("GetProvider返回%+v", provider)
+	}
+
+	// 服务层错误转换为对应的状态码
+	tests := []struct {
+		method string
+		req    interface{}
+		code   codes.Code
+	}{
+		{"GetProvider", &cloudeyev1.GetProviderRequest{Id: 999}, codes.NotFound},
+		{"GetProvider", &cloudeyev1.GetProviderRequest{}, codes.InvalidArgument},
+		{"CreateProvider", &cloudeyev1.CreateProviderRequest{Provider: &cloudeyev1.ProviderInput{Name: "重复", Code: "AWS"}}, codes.AlreadyExists},
+		{"CreateProvider", &cloudeyev1.CreateProviderRequest{Provider: &cloudeyev1.ProviderInput{}}, codes.InvalidArgument},
+		{"PatchProvider", &cloudeyev1.PatchProviderRequest{Id: 1, MergePatch: `{"code":""}`}, codes.InvalidArgument},
+		{"DeleteProvider", &cloudeyev1.DeleteProviderRequest{Id: 1}, codes.FailedPrecondition},
+	}
+	for _, tt := range tests {
+		err := conn.Invoke(ctx, svc+tt.method, tt.req, new(cloudeyev1.Provider))
+		if status.Code(err) != tt.code {
+			t.Errorf("%s(%v)返回%v，期望%v", tt.method, tt.req, err, tt.code)
+		}
+	}
+
+	if err := conn.Invoke(ctx, svc+"DeleteProvider", &cloudeyev1.DeleteProviderRequest{Id: 1, Cascade: true}, new(emptypb.Empty)); err != nil {
+		t.Fatalf("级联删除失败: %v", err)
+	}
+	err := conn.Invoke(ctx, svc+"GetProvider", &cloudeyev1.GetProviderRequest{Id: 1}, provider)
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("删除后GetProvider返回%v，期望NotFound", err)
+	}
+}
+
+func TestStreamConfigItems(t *testing.T) {
+	conn := dial(t)
+
+	stream, err := conn.NewStream(context.Background(), &grpcgo.StreamDesc{ServerStreams: true},
+		"/cloudeye.v1.ConfigurationItemService/StreamConfigItems")
+	if err != nil {
+		t.Fatalf("创建流失败: %v", err)
+	}
+	req := &cloudeyev1.StreamConfigItemsRequest{
+		Filter: &cloudeyev1.ConfigItemFilter{CloudProviderIds: []uint64{1}},
+		Sort:   []string{"-id"},
+	}
+	if err := stream.SendMsg(req); err != nil {
+		t.Fatalf("发送请求失败: %v", err)
+	}
+	stream.CloseSend()
+
+	// 演示数据中AWS（ID为1）的产品下有配置项1至6
+	var ids []uint64
+	for {
+		item := new(cloudeyev1.ConfigItem)
+		if err := stream.RecvMsg(item); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("接收配置项失败: %v", err)
+		}
+		if item.CloudProviderId != 1 {
+			t.Fatalf("配置项%d不属于AWS", item.Id)
+		}
+		ids = append(ids, item.Id)
+	}
+	if len(ids) != 6 || ids[0] != 6 || ids[5] != 1 {
+		t.Fatalf("收到的配置项为%v，期望按ID降序的1至6", ids)
+	}
+}
+
+func TestRecoveryInterceptors(t *testing.T) {
+	boom := func() { panic("boom") }
+
+	info := &grpcgo.UnaryServerInfo{FullMethod: "/cloudeye.v1.CloudProviderService/GetProvider"}
+	_, err := grpcapi.RecoveryUnaryInterceptor()(context.Background(), nil, info,
+		func(context.Context, interface{}) (interface{}, error) { boom(); return nil, nil })
+	if st, _ := status.FromError(err); st.Code() != codes.Internal || st.Message() != "服务器内部错误" {
+		t.Fatalf("一元调用panic后返回%v，期望Internal", err)
+	}
+
+	streamInfo := &grpcgo.StreamServerInfo{FullMethod: "/cloudeye.v1.ConfigurationItemService/StreamConfigItems", IsServerStream: true}
+	err = grpcapi.RecoveryStreamInterceptor()(nil, nil, streamInfo,
+		func(interface{}, grpcgo.ServerStream) error { boom(); return nil })
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("流式调用panic后返回%v，期望Internal", err)
+	}
+}
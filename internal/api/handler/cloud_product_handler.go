@@ -4,8 +4,8 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/yourusername/cloud-eye/internal/models"
 	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+	"github.com/yourusername/cloud-eye/internal/repository"
 	"github.com/yourusername/cloud-eye/internal/service"
 	"go.uber.org/zap"
 )
@@ -25,21 +25,76 @@ func NewCloudProductHandler(service service.CloudProductService) *CloudProductHa
 
 // GetAll 获取所有云产品
 // @Summary 获取所有云产品
-// @Description 获取系统中所有可用的云产品列表
+// @Description 获取系统中所有可用的云产品列表，传入page或page_size时返回分页结果
 // @Tags 云产品
 // @Produce json
-// @Success 200 {object} Response{data=[]models.CloudProduct} "成功"
+// @Param cloud_provider_id query []int false "云服务商ID，可传多个" collectionFormat(csv)
+// @Param code query []string false "产品代码，可传多个" collectionFormat(csv)
+// @Param category_id query []int false "产品类别ID，可传多个，包含子类别" collectionFormat(csv)
+// @Param keyword query string false "关键字，匹配名称、代码和描述"
+// @Param with_counts query bool false "是否返回每个产品的配置项数config_item_count"
+// @Param created_from query string false "创建时间起（RFC3339或YYYY-MM-DD）"
+// @Param created_to query string false "创建时间止（RFC3339或YYYY-MM-DD）"
+// @Param updated_from query string false "更新时间起（RFC3339或YYYY-MM-DD）"
+// @Param updated_to query string false "更新时间止（RFC3339或YYYY-MM-DD）"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
+// @Param fields query string false "仅返回的字段"
+// @Param cursor query string false "游标分页位置，传空值从第一页开始，返回分页结果"
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页记录数，默认10"
+// @Param include query string false "加载的关联：provider,category,config_items，默认不加载"
+// @Success 200 {object} Response{data=[]ProductResponse} "成功，分页时data为repository.PageResult"
+// @Failure 400 {object} Response "无效的请求参数"
 // @Failure 500 {object} Response "服务器内部错误"
 // @Router /api/v1/cloud-products [get]
 func (h *CloudProductHandler) GetAll(c *gin.Context) {
-	products, err := h.service.GetAllProducts(c)
+	opts, ok := h.BindListOptions(c)
+	if !ok {
+		return
+	}
+
+	providerIDs, ok := h.GetUintListQueryParam(c, "cloud_provider_id")
+	if !ok {
+		return
+	}
+
+	categoryIDs, ok := h.GetUintListQueryParam(c, "category_id")
+	if !ok {
+		return
+	}
+
+	filter := repository.CloudProductFilter{
+		CloudProviderIDs: providerIDs,
+		Codes:            h.GetListQueryParam(c, "code"),
+		CategoryIDs:      categoryIDs,
+		ListOptions:      opts,
+	}
+	if keyword, ok := h.GetQueryParam(c, "keyword"); ok {
+		filter.Keyword = &keyword
+	}
+
+	// 分页：游标分页或页码分页，未指定分页参数时返回完整列表以兼容旧客户端
+	if filter.CursorMode() || c.Query("page") != "" || c.Query("page_size") != "" {
+		filter.Page = h.GetIntQueryParam(c, "page", 1)
+		filter.PageSize = h.GetIntQueryParam(c, "page_size", 10)
+		result, err := h.service.ListProductsPage(c, filter)
+		if err != nil {
+			logger.Error("Failed to list cloud products by page", err)
+			h.HandleServiceError(c, err)
+			return
+		}
+		h.Success(c, h.SelectFields(toResponse(result), filter.Fields, filter.ResponseKeys(repository.CloudProductListSchema)))
+		return
+	}
+
+	products, err := h.service.ListProducts(c, filter)
 	if err != nil {
 		logger.Error("Failed to get all cloud products", err)
 		h.HandleServiceError(c, err)
 		return
 	}
 
-	h.Success(c, products)
+	h.Success(c, h.SelectFields(toResponse(products), filter.Fields, filter.ResponseKeys(repository.CloudProductListSchema)))
 }
 
 // GetByID 根据ID获取云产品
@@ -48,7 +103,7 @@ func (h *CloudProductHandler) GetAll(c *gin.Context) {
 // @Tags 云产品
 // @Produce json
 // @Param id path int true "云产品ID"
-// @Success 200 {object} Response{data=models.CloudProduct} "成功"
+// @Success 200 {object} Response{data=ProductResponse} "成功"
 // @Failure 400 {object} Response "无效的ID参数"
 // @Failure 404 {object} Response "云产品不存在"
 // @Failure 500 {object} Response "服务器内部错误"
@@ -66,7 +121,7 @@ func (h *CloudProductHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	h.Success(c, product)
+	h.Success(c, toResponse(product))
 }
 
 // GetByProviderID 获取指定云服务商的产品列表
@@ -74,14 +129,14 @@ func (h *CloudProductHandler) GetByID(c *gin.Context) {
 // @Description 根据云服务商ID获取其所有产品
 // @Tags 云产品
 // @Produce json
-// @Param provider_id path int true "云服务商ID"
-// @Success 200 {object} Response{data=[]models.CloudProduct} "成功"
+// @Param id path int true "云服务商ID"
+// @Success 200 {object} Response{data=[]ProductResponse} "成功"
 // @Failure 400 {object} Response "无效的ID参数"
 // @Failure 404 {object} Response "云服务商不存在"
 // @Failure 500 {object} Response "服务器内部错误"
-// @Router /api/v1/cloud-providers/{provider_id}/products [get]
+// @Router /api/v1/cloud-providers/{id}/products [get]
 func (h *CloudProductHandler) GetByProviderID(c *gin.Context) {
-	providerID, ok := h.GetIDFromPath(c, "provider_id")
+	providerID, ok := h.GetIDFromPath(c, "id")
 	if !ok {
 		return
 	}
@@ -93,7 +148,7 @@ func (h *CloudProductHandler) GetByProviderID(c *gin.Context) {
 		return
 	}
 
-	h.Success(c, products)
+	h.Success(c, toResponse(products))
 }
 
 // GetByProviderCode 获取指定云服务商代码的产品列表
@@ -102,7 +157,7 @@ func (h *CloudProductHandler) GetByProviderID(c *gin.Context) {
 // @Tags 云产品
 // @Produce json
 // @Param provider_code path string true "云服务商代码"
-// @Success 200 {object} Response{data=[]models.CloudProduct} "成功"
+// @Success 200 {object} Response{data=[]ProductResponse} "成功"
 // @Failure 404 {object} Response "云服务商不存在"
 // @Failure 500 {object} Response "服务器内部错误"
 // @Router /api/v1/cloud-providers/code/{provider_code}/products [get]
@@ -120,7 +175,7 @@ func (h *CloudProductHandler) GetByProviderCode(c *gin.Context) {
 		return
 	}
 
-	h.Success(c, products)
+	h.Success(c, toResponse(products))
 }
 
 // Create 创建云产品
@@ -129,37 +184,38 @@ func (h *CloudProductHandler) GetByProviderCode(c *gin.Context) {
 // @Tags 云产品
 // @Accept json
 // @Produce json
-// @Param product body models.CloudProduct true "云产品信息"
-// @Success 200 {object} Response{data=models.CloudProduct} "成功"
+// @Param product body ProductRequest true "云产品信息"
+// @Success 200 {object} Response{data=ProductResponse} "成功"
 // @Failure 400 {object} Response "无效的请求参数"
 // @Failure 404 {object} Response "云服务商不存在"
 // @Failure 409 {object} Response "云产品代码已存在"
 // @Failure 500 {object} Response "服务器内部错误"
 // @Router /api/v1/cloud-products [post]
 func (h *CloudProductHandler) Create(c *gin.Context) {
-	var product models.CloudProduct
-	if !h.BindJSON(c, &product) {
+	var req ProductRequest
+	if !h.BindJSON(c, &req) {
 		return
 	}
+	product := req.Model()
 
-	err := h.service.CreateProduct(c, &product)
+	err := h.service.CreateProduct(c, product)
 	if err != nil {
 		logger.Error("Failed to create cloud product", err)
 		h.HandleServiceError(c, err)
 		return
 	}
 
-	h.Success(c, product)
+	h.Success(c, toResponse(product))
 }
 
 // Update 更新云产品
 // @Summary 更新云产品
-// @Description 更新已有的云产品信息
+// @Description 整体替换已有的云产品信息，未提供的字段将被清空，部分更新请使用PATCH
 // @Tags 云产品
 // @Accept json
 // @Produce json
 // @Param id path int true "云产品ID"
-// @Param product body models.CloudProduct true "云产品信息"
+// @Param product body ProductRequest true "云产品信息"
 // @Success 200 {object} Response "成功"
 // @Failure 400 {object} Response "无效的请求参数"
 // @Failure 404 {object} Response "云产品不存在或云服务商不存在"
@@ -172,15 +228,16 @@ func (h *CloudProductHandler) Update(c *gin.Context) {
 		return
 	}
 
-	var product models.CloudProduct
-	if !h.BindJSON(c, &product) {
+	var req ProductRequest
+	if !h.BindJSON(c, &req) {
 		return
 	}
+	product := req.Model()
 
-	// 确保路径参数ID与请求体ID一致
+	// ID以路径参数为准
 	product.ID = id
 
-	err := h.service.UpdateProduct(c, &product)
+	err := h.service.UpdateProduct(c, product)
 	if err != nil {
 		logger.Error("Failed to update cloud product", err, zap.Uint("id", id))
 		h.HandleServiceError(c, err)
@@ -190,9 +247,56 @@ func (h *CloudProductHandler) Update(c *gin.Context) {
 	h.Success(c, gin.H{"message": "云产品更新成功"})
 }
 
+// Patch 部分更新云产品
+// @Summary 部分更新云产品
+// @Description 按JSON合并补丁（RFC 7396）部分更新云产品，只修改补丁中出现的字段，值为null的字段被清空；id、时间戳、关联对象和标签不能通过补丁修改
+// @Tags 云产品
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param id path int true "云产品ID"
+// @Param patch body object true "JSON合并补丁"
+// @Success 200 {object} Response{data=ProductResponse} "成功，返回更新后的云产品"
+// @Failure 400 {object} Response "无效的请求参数"
+// @Failure 404 {object} Response "云产品、云服务商或产品类别不存在"
+// @Failure 409 {object} Response "云产品代码已存在"
+// @Failure 415 {object} Response "不支持的Content-Type"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/cloud-products/{id} [patch]
+func (h *CloudProductHandler) Patch(c *gin.Context) {
+	id, ok := h.GetIDFromPath(c, "id")
+	if !ok {
+		return
+	}
+
+	patch, ok := h.BindMergePatch(c)
+	if !ok {
+		return
+	}
+
+	current, err := h.service.GetProductByID(c, id)
+	if err != nil {
+		logger.Error("Failed to get cloud product for patch", err, zap.Uint("id", id))
+		h.HandleServiceError(c, err)
+		return
+	}
+	var req ProductRequest
+	if !h.ValidateMergePatch(c, NewProductRequest(current), patch, &req) {
+		return
+	}
+
+	product, err := h.service.PatchProduct(c, id, patch)
+	if err != nil {
+		logger.Error("Failed to patch cloud product", err, zap.Uint("id", id))
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.Success(c, toResponse(product))
+}
+
 // Delete 删除云产品
 // @Summary 删除云产品
-// @Description 删除指定的云产品
+// @Description 将指定的云产品移入回收站，其配置项一同移入回收站
 // @Tags 云产品
 // @Produce json
 // @Param id path int true "云产品ID"
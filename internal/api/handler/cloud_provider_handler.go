@@ -1,11 +1,10 @@
 package handler
 
 import (
-	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/yourusername/cloud-eye/internal/models"
 	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+	"github.com/yourusername/cloud-eye/internal/repository"
 	"github.com/yourusername/cloud-eye/internal/service"
 	"go.uber.org/zap"
 )
@@ -25,21 +24,62 @@ func NewCloudProviderHandler(service service.CloudProviderService) *CloudProvide
 
 // GetAll 获取所有云服务商
 // @Summary 获取所有云服务商
-// @Description 获取系统中所有可用的云服务商列表
+// @Description 获取系统中所有可用的云服务商列表，传入page或page_size时返回分页结果
 // @Tags 云服务商
 // @Produce json
-// @Success 200 {object} Response{data=[]models.CloudProvider} "成功"
+// @Param code query []string false "云服务商代码，可传多个" collectionFormat(csv)
+// @Param keyword query string false "关键字，匹配名称、代码和描述"
+// @Param with_counts query bool false "是否返回每个服务商的产品数product_count"
+// @Param created_from query string false "创建时间起（RFC3339或YYYY-MM-DD）"
+// @Param created_to query string false "创建时间止（RFC3339或YYYY-MM-DD）"
+// @Param updated_from query string false "更新时间起（RFC3339或YYYY-MM-DD）"
+// @Param updated_to query string false "更新时间止（RFC3339或YYYY-MM-DD）"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
+// @Param fields query string false "仅返回的字段"
+// @Param cursor query string false "游标分页位置，传空值从第一页开始，返回分页结果"
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页记录数，默认10"
+// @Param include query string false "加载的关联：products，默认不加载"
+// @Success 200 {object} Response{data=[]ProviderResponse} "成功，分页时data为repository.PageResult"
+// @Failure 400 {object} Response "无效的请求参数"
 // @Failure 500 {object} Response "服务器内部错误"
 // @Router /api/v1/cloud-providers [get]
 func (h *CloudProviderHandler) GetAll(c *gin.Context) {
-	providers, err := h.service.GetAllProviders(c)
+	opts, ok := h.BindListOptions(c)
+	if !ok {
+		return
+	}
+
+	filter := repository.CloudProviderFilter{
+		Codes:       h.GetListQueryParam(c, "code"),
+		ListOptions: opts,
+	}
+	if keyword, ok := h.GetQueryParam(c, "keyword"); ok {
+		filter.Keyword = &keyword
+	}
+
+	// 分页：游标分页或页码分页，未指定分页参数时返回完整列表以兼容旧客户端
+	if filter.CursorMode() || c.Query("page") != "" || c.Query("page_size") != "" {
+		filter.Page = h.GetIntQueryParam(c, "page", 1)
+		filter.PageSize = h.GetIntQueryParam(c, "page_size", 10)
+		result, err := h.service.ListProvidersPage(c, filter)
+		if err != nil {
+			logger.Error("Failed to list cloud providers by page", err)
+			h.HandleServiceError(c, err)
+			return
+		}
+		h.Success(c, h.SelectFields(toResponse(result), filter.Fields, filter.ResponseKeys(repository.CloudProviderListSchema)))
+		return
+	}
+
+	providers, err := h.service.ListProviders(c, filter)
 	if err != nil {
 		logger.Error("Failed to get all cloud providers", err)
 		h.HandleServiceError(c, err)
 		return
 	}
 
-	h.Success(c, providers)
+	h.Success(c, h.SelectFields(toResponse(providers), filter.Fields, filter.ResponseKeys(repository.CloudProviderListSchema)))
 }
 
 // GetByID 根据ID获取云服务商
@@ -48,7 +88,7 @@ func (h *CloudProviderHandler) GetAll(c *gin.Context) {
 // @Tags 云服务商
 // @Produce json
 // @Param id path int true "云服务商ID"
-// @Success 200 {object} Response{data=models.CloudProvider} "成功"
+// @Success 200 {object} Response{data=ProviderResponse} "成功"
 // @Failure 400 {object} Response "无效的ID参数"
 // @Failure 404 {object} Response "云服务商不存在"
 // @Failure 500 {object} Response "服务器内部错误"
@@ -66,7 +106,7 @@ func (h *CloudProviderHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	h.Success(c, provider)
+	h.Success(c, toResponse(provider))
 }
 
 // Create 创建云服务商
@@ -75,36 +115,37 @@ func (h *CloudProviderHandler) GetByID(c *gin.Context) {
 // @Tags 云服务商
 // @Accept json
 // @Produce json
-// @Param provider body models.CloudProvider true "云服务商信息"
-// @Success 200 {object} Response{data=models.CloudProvider} "成功"
+// @Param provider body ProviderRequest true "云服务商信息"
+// @Success 200 {object} Response{data=ProviderResponse} "成功"
 // @Failure 400 {object} Response "无效的请求参数"
 // @Failure 409 {object} Response "云服务商代码已存在"
 // @Failure 500 {object} Response "服务器内部错误"
 // @Router /api/v1/cloud-providers [post]
 func (h *CloudProviderHandler) Create(c *gin.Context) {
-	var provider models.CloudProvider
-	if !h.BindJSON(c, &provider) {
+	var req ProviderRequest
+	if !h.BindJSON(c, &req) {
 		return
 	}
+	provider := req.Model()
 
-	err := h.service.CreateProvider(c, &provider)
+	err := h.service.CreateProvider(c, provider)
 	if err != nil {
 		logger.Error("Failed to create cloud provider", err)
 		h.HandleServiceError(c, err)
 		return
 	}
 
-	h.Success(c, provider)
+	h.Success(c, toResponse(provider))
 }
 
 // Update 更新云服务商
 // @Summary 更新云服务商
-// @Description 更新已有的云服务商信息
+// @Description 整体替换已有的云服务商信息，未提供的字段将被清空，部分更新请使用PATCH
 // @Tags 云服务商
 // @Accept json
 // @Produce json
 // @Param id path int true "云服务商ID"
-// @Param provider body models.CloudProvider true "云服务商信息"
+// @Param provider body ProviderRequest true "云服务商信息"
 // @Success 200 {object} Response "成功"
 // @Failure 400 {object} Response "无效的请求参数"
 // @Failure 404 {object} Response "云服务商不存在"
@@ -117,15 +158,16 @@ func (h *CloudProviderHandler) Update(c *gin.Context) {
 		return
 	}
 
-	var provider models.CloudProvider
-	if !h.BindJSON(c, &provider) {
+	var req ProviderRequest
+	if !h.BindJSON(c, &req) {
 		return
 	}
+	provider := req.Model()
 
-	// 确保路径参数ID与请求体ID一致
+	// ID以路径参数为准
 	provider.ID = id
 
-	err := h.service.UpdateProvider(c, &provider)
+	err := h.service.UpdateProvider(c, provider)
 	if err != nil {
 		logger.Error("Failed to update cloud provider", err, zap.Uint("id", id))
 		h.HandleServiceError(c, err)
@@ -135,15 +177,64 @@ func (h *CloudProviderHandler) Update(c *gin.Context) {
 	h.Success(c, gin.H{"message": "云服务商更新成功"})
 }
 
+// Patch 部分更新云服务商
+// @Summary 部分更新云服务商
+// @Description 按JSON合并补丁（RFC 7396）部分更新云服务商，只修改补丁中出现的字段，值为null的字段被清空；id、时间戳、关联对象和标签不能通过补丁修改
+// @Tags 云服务商
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param id path int true "云服务商ID"
+// @Param patch body object true "JSON合并补丁"
+// @Success 200 {object} Response{data=ProviderResponse} "成功，返回更新后的云服务商"
+// @Failure 400 {object} Response "无效的请求参数"
+// @Failure 404 {object} Response "云服务商不存在"
+// @Failure 409 {object} Response "云服务商代码已存在"
+// @Failure 415 {object} Response "不支持的Content-Type"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/cloud-providers/{id} [patch]
+func (h *CloudProviderHandler) Patch(c *gin.Context) {
+	id, ok := h.GetIDFromPath(c, "id")
+	if !ok {
+		return
+	}
+
+	patch, ok := h.BindMergePatch(c)
+	if !ok {
+		return
+	}
+
+	current, err := h.service.GetProviderByID(c, id)
+	if err != nil {
+		logger.Error("Failed to get cloud provider for patch", err, zap.Uint("id", id))
+		h.HandleServiceError(c, err)
+		return
+	}
+	var req ProviderRequest
+	if !h.ValidateMergePatch(c, NewProviderRequest(current), patch, &req) {
+		return
+	}
+
+	provider, err := h.service.PatchProvider(c, id, patch)
+	if err != nil {
+		logger.Error("Failed to patch cloud provider", err, zap.Uint("id", id))
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.Success(c, toResponse(provider))
+}
+
 // Delete 删除云服务商
 // @Summary 删除云服务商
-// @Description 删除指定的云服务商
+// @Description 将指定的云服务商移入回收站，存在产品或配置项时需指定cascade=true，其产品和配置项一同移入回收站
 // @Tags 云服务商
 // @Produce json
 // @Param id path int true "云服务商ID"
+// @Param cascade query bool false "是否一并删除产品和配置项"
 // @Success 200 {object} Response "成功"
 // @Failure 400 {object} Response "无效的ID参数"
 // @Failure 404 {object} Response "云服务商不存在"
+// @Failure 409 {object} Response "存在产品或配置项且未指定cascade"
 // @Failure 500 {object} Response "服务器内部错误"
 // @Router /api/v1/cloud-providers/{id} [delete]
 func (h *CloudProviderHandler) Delete(c *gin.Context) {
@@ -152,7 +243,7 @@ func (h *CloudProviderHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	err := h.service.DeleteProvider(c, id)
+	err := h.service.DeleteProvider(c, id, h.GetBoolQueryParam(c, "cascade", false))
 	if err != nil {
 		logger.Error("Failed to delete cloud provider", err, zap.Uint("id", id))
 		h.HandleServiceError(c, err)
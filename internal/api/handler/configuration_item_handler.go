@@ -1,11 +1,10 @@
 package handler
 
 import (
-	"fmt"
+	"bytes"
+	"mime"
 	"net/http"
-	"path/filepath"
-	"strings"
-	"time"
+	"os"
 
 	"github.com/gin-gonic/gin"
 	"github.com/yourusername/cloud-eye/internal/models"
@@ -20,14 +19,16 @@ import (
 type ConfigurationItemHandler struct {
 	BaseHandler
 	service service.ConfigurationItemService
+	files    service.FileService
 	exporter *excel.ConfigItemExporter
 	importer *excel.ConfigItemImporter
 }
 
 // NewConfigurationItemHandler 创建配置项处理器
-func NewConfigurationItemHandler(service service.ConfigurationItemService) *ConfigurationItemHandler {
+func NewConfigurationItemHandler(service service.ConfigurationItemService, files service.FileService) *ConfigurationItemHandler {
 	return &ConfigurationItemHandler{
 		service:  service,
+		files:    files,
 		exporter: excel.NewConfigItemExporter(),
 		importer: excel.NewConfigItemImporter(),
 	}
@@ -39,7 +40,7 @@ func NewConfigurationItemHandler(service service.ConfigurationItemService) *Conf
 // @Tags 配置项
 // @Produce json
 // @Param id path int true "配置项ID"
-// @Success 200 {object} Response{data=models.ConfigurationItem} "成功"
+// @Success 200 {object} Response{data=ConfigItemResponse} "成功"
 // @Failure 400 {object} Response "无效的ID参数"
 // @Failure 404 {object} Response "配置项不存在"
 // @Failure 500 {object} Response "服务器内部错误"
@@ -57,7 +58,7 @@ func (h *ConfigurationItemHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	h.Success(c, item)
+	h.Success(c, toResponse(item))
 }
 
 // GetByFilter 根据过滤条件获取配置项列表（支持分页）
@@ -65,41 +66,89 @@ func (h *ConfigurationItemHandler) GetByID(c *gin.Context) {
 // @Description 根据过滤条件获取配置项列表，支持分页
 // @Tags 配置项
 // @Produce json
-// @Param cloud_provider_id query int false "云服务商ID"
-// @Param product_id query int false "产品ID"
+// @Param cloud_provider_id query []int false "云服务商ID，可传多个" collectionFormat(csv)
+// @Param product_id query []int false "产品ID，可传多个" collectionFormat(csv)
+// @Param category_id query []int false "产品类别ID，可传多个，包含子类别" collectionFormat(csv)
+// @Param tag query []string false "标签名称，可传多个" collectionFormat(csv)
+// @Param tag_match query string false "多个标签的匹配方式：or包含任一标签（默认），and包含全部标签"
 // @Param keyword query string false "关键词搜索"
+// @Param created_from query string false "创建时间起（RFC3339或YYYY-MM-DD）"
+// @Param created_to query string false "创建时间止（RFC3339或YYYY-MM-DD）"
+// @Param updated_from query string false "更新时间起（RFC3339或YYYY-MM-DD）"
+// @Param updated_to query string false "更新时间止（RFC3339或YYYY-MM-DD）"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序，例如 -updated_at,name"
+// @Param fields query string false "仅返回的字段，例如 name,recommended_value"
+// @Param include query string false "加载的关联：provider,product,product.category,tags，默认加载provider,product，传空值不加载"
+// @Param cursor query string false "游标分页位置，传空值从第一页开始；使用游标时忽略page且不统计总数"
 // @Param page query int false "页码，默认1"
 // @Param page_size query int false "每页记录数，默认10"
 // @Success 200 {object} Response{data=repository.PageResult} "成功"
+// @Failure 400 {object} Response "无效的请求参数"
 // @Failure 500 {object} Response "服务器内部错误"
 // @Router /api/v1/config-items [get]
 func (h *ConfigurationItemHandler) GetByFilter(c *gin.Context) {
-	filter := repository.ConfigItemFilter{
-		Page:     h.GetIntQueryParam(c, "page", 1),
-		PageSize: h.GetIntQueryParam(c, "page_size", 10),
+	filter, ok := h.bindConfigItemFilter(c)
+	if !ok {
+		return
 	}
+	filter.Page = h.GetIntQueryParam(c, "page", 1)
+	filter.PageSize = h.GetIntQueryParam(c, "page_size", 10)
 
-	// 获取可选过滤参数
-	if providerID, ok := h.GetUintQueryParam(c, "cloud_provider_id"); ok {
-		filter.CloudProviderID = &providerID
+	result, err := h.service.GetConfigItemsByFilter(c, filter)
+	if err != nil {
+		logger.Error("Failed to get config items by filter", err)
+		h.HandleServiceError(c, err)
+		return
 	}
 
-	if productID, ok := h.GetUintQueryParam(c, "product_id"); ok {
-		filter.ProductID = &productID
+	h.Success(c, h.SelectFields(toResponse(result), filter.Fields, filter.ResponseKeys(repository.ConfigItemListSchema)))
+}
+
+// bindConfigItemFilter 解析配置项列表的过滤参数
+func (h *BaseHandler) bindConfigItemFilter(c *gin.Context) (repository.ConfigItemFilter, bool) {
+	var filter repository.ConfigItemFilter
+
+	opts, ok := h.BindListOptions(c)
+	if !ok {
+		return filter, false
 	}
+	filter.ListOptions = opts
 
-	if keyword, ok := h.GetQueryParam(c, "keyword"); ok {
-		filter.Keyword = &keyword
+	// 单个ID时保持原有语义（校验服务商/产品是否存在），多个ID时按任一匹配过滤
+	providerIDs, ok := h.GetUintListQueryParam(c, "cloud_provider_id")
+	if !ok {
+		return filter, false
+	}
+	if len(providerIDs) == 1 {
+		filter.CloudProviderID = &providerIDs[0]
+	} else {
+		filter.CloudProviderIDs = providerIDs
 	}
 
-	result, err := h.service.GetConfigItemsByFilter(c, filter)
-	if err != nil {
-		logger.Error("Failed to get config items by filter", err)
-		h.HandleServiceError(c, err)
-		return
+	productIDs, ok := h.GetUintListQueryParam(c, "product_id")
+	if !ok {
+		return filter, false
+	}
+	if len(productIDs) == 1 {
+		filter.ProductID = &productIDs[0]
+	} else {
+		filter.ProductIDs = productIDs
 	}
 
-	h.Success(c, result)
+	categoryIDs, ok := h.GetUintListQueryParam(c, "category_id")
+	if !ok {
+		return filter, false
+	}
+	filter.CategoryIDs = categoryIDs
+
+	filter.Tags = h.GetListQueryParam(c, "tag")
+	filter.TagMatch, _ = h.GetQueryParam(c, "tag_match")
+
+	if keyword, ok := h.GetQueryParam(c, "keyword"); ok {
+		filter.Keyword = &keyword
+	}
+
+	return filter, true
 }
 
 // GetByProviderAndProduct 获取指定云服务商和产品的配置项列表
@@ -107,15 +156,15 @@ func (h *ConfigurationItemHandler) GetByFilter(c *gin.Context) {
 // @Description 根据云服务商ID和产品ID获取配置项列表
 // @Tags 配置项
 // @Produce json
-// @Param provider_id path int true "云服务商ID"
+// @Param id path int true "云服务商ID"
 // @Param product_id path int true "产品ID"
-// @Success 200 {object} Response{data=[]models.ConfigurationItem} "成功"
+// @Success 200 {object} Response{data=[]ConfigItemResponse} "成功"
 // @Failure 400 {object} Response "无效的ID参数"
 // @Failure 404 {object} Response "云服务商或产品不存在"
 // @Failure 500 {object} Response "服务器内部错误"
-// @Router /api/v1/cloud-providers/{provider_id}/products/{product_id}/config-items [get]
+// @Router /api/v1/cloud-providers/{id}/products/{product_id}/config-items [get]
 func (h *ConfigurationItemHandler) GetByProviderAndProduct(c *gin.Context) {
-	providerID, ok := h.GetIDFromPath(c, "provider_id")
+	providerID, ok := h.GetIDFromPath(c, "id")
 	if !ok {
 		return
 	}
@@ -134,7 +183,7 @@ func (h *ConfigurationItemHandler) GetByProviderAndProduct(c *gin.Context) {
 		return
 	}
 
-	h.Success(c, items)
+	h.Success(c, toResponse(items))
 }
 
 // Create 创建配置项
@@ -143,36 +192,37 @@ func (h *ConfigurationItemHandler) GetByProviderAndProduct(c *gin.Context) {
 // @Tags 配置项
 // @Accept json
 // @Produce json
-// @Param item body models.ConfigurationItem true "配置项信息"
-// @Success 200 {object} Response{data=models.ConfigurationItem} "成功"
+// @Param item body ConfigItemRequest true "配置项信息"
+// @Success 200 {object} Response{data=ConfigItemResponse} "成功"
 // @Failure 400 {object} Response "无效的请求参数"
 // @Failure 404 {object} Response "云服务商或产品不存在"
 // @Failure 500 {object} Response "服务器内部错误"
 // @Router /api/v1/config-items [post]
 func (h *ConfigurationItemHandler) Create(c *gin.Context) {
-	var item models.ConfigurationItem
-	if !h.BindJSON(c, &item) {
+	var req ConfigItemRequest
+	if !h.BindJSON(c, &req) {
 		return
 	}
+	item := req.Model()
 
-	err := h.service.CreateConfigItem(c, &item)
+	err := h.service.CreateConfigItem(c, item)
 	if err != nil {
 		logger.Error("Failed to create config item", err)
 		h.HandleServiceError(c, err)
 		return
 	}
 
-	h.Success(c, item)
+	h.Success(c, toResponse(item))
 }
 
 // Update 更新配置项
 // @Summary 更新配置项
-// @Description 更新已有的配置项信息
+// @Description 整体替换已有的配置项信息，未提供的字段将被清空，部分更新请使用PATCH
 // @Tags 配置项
 // @Accept json
 // @Produce json
 // @Param id path int true "配置项ID"
-// @Param item body models.ConfigurationItem true "配置项信息"
+// @Param item body ConfigItemRequest true "配置项信息"
 // @Success 200 {object} Response "成功"
 // @Failure 400 {object} Response "无效的请求参数"
 // @Failure 404 {object} Response "配置项不存在或云服务商或产品不存在"
@@ -184,15 +234,16 @@ func (h *ConfigurationItemHandler) Update(c *gin.Context) {
 		return
 	}
 
-	var item models.ConfigurationItem
-	if !h.BindJSON(c, &item) {
+	var req ConfigItemRequest
+	if !h.BindJSON(c, &req) {
 		return
 	}
+	item := req.Model()
 
-	// 确保路径参数ID与请求体ID一致
+	// ID以路径参数为准
 	item.ID = id
 
-	err := h.service.UpdateConfigItem(c, &item)
+	err := h.service.UpdateConfigItem(c, item)
 	if err != nil {
 		logger.Error("Failed to update config item", err, zap.Uint("id", id))
 		h.HandleServiceError(c, err)
@@ -202,9 +253,55 @@ func (h *ConfigurationItemHandler) Update(c *gin.Context) {
 	h.Success(c, gin.H{"message": "配置项更新成功"})
 }
 
+// Patch 部分更新配置项
+// @Summary 部分更新配置项
+// @Description 按JSON合并补丁（RFC 7396）部分更新配置项，只修改补丁中出现的字段，值为null的字段被清空；id、时间戳、关联对象和标签不能通过补丁修改
+// @Tags 配置项
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param id path int true "配置项ID"
+// @Param patch body object true "JSON合并补丁"
+// @Success 200 {object} Response{data=ConfigItemResponse} "成功，返回更新后的配置项"
+// @Failure 400 {object} Response "无效的请求参数"
+// @Failure 404 {object} Response "配置项、云服务商或产品不存在"
+// @Failure 415 {object} Response "不支持的Content-Type"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/config-items/{id} [patch]
+func (h *ConfigurationItemHandler) Patch(c *gin.Context) {
+	id, ok := h.GetIDFromPath(c, "id")
+	if !ok {
+		return
+	}
+
+	patch, ok := h.BindMergePatch(c)
+	if !ok {
+		return
+	}
+
+	current, err := h.service.GetConfigItemByID(c, id)
+	if err != nil {
+		logger.Error("Failed to get config item for patch", err, zap.Uint("id", id))
+		h.HandleServiceError(c, err)
+		return
+	}
+	var req ConfigItemRequest
+	if !h.ValidateMergePatch(c, NewConfigItemRequest(current), patch, &req) {
+		return
+	}
+
+	item, err := h.service.PatchConfigItem(c, id, patch)
+	if err != nil {
+		logger.Error("Failed to patch config item", err, zap.Uint("id", id))
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.Success(c, toResponse(item))
+}
+
 // Delete 删除配置项
 // @Summary 删除配置项
-// @Description 删除指定的配置项
+// @Description 将指定的配置项移入回收站
 // @Tags 配置项
 // @Produce json
 // @Param id path int true "配置项ID"
@@ -229,35 +326,104 @@ func (h *ConfigurationItemHandler) Delete(c *gin.Context) {
 	h.Success(c, gin.H{"message": "配置项删除成功"})
 }
 
+// Bulk 批量操作配置项
+// @Summary 批量操作配置项
+// @Description 在一个请求中批量创建（create）、部分更新（update）、删除（delete）、移动到其他产品（move）配置项以及添加或移除标签（tag），按顺序执行并返回每项操作的结果；mode为atomic（默认）时任一操作失败则全部回滚并返回422，为best_effort时仅跳过失败的操作，且同一配置项只能有一个操作
+// @Tags 配置项
+// @Accept json
+// @Produce json
+// @Param request body ConfigItemBulkRequest true "批量操作请求，最多500项"
+// @Success 200 {object} Response{data=service.ConfigItemBulkResponse} "成功"
+// @Failure 400 {object} Response "无效的请求参数"
+// @Failure 422 {object} Response{data=service.ConfigItemBulkResponse} "atomic模式下存在失败的操作，已全部回滚"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/config-items/bulk [post]
+func (h *ConfigurationItemHandler) Bulk(c *gin.Context) {
+	var req ConfigItemBulkRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	result, err := h.service.BulkConfigItems(c, req.Model())
+	if err != nil {
+		logger.Error("Failed to apply bulk config item operations", err)
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	if result.Mode == service.BulkModeAtomic && result.Failed > 0 {
+		c.JSON(http.StatusUnprocessableEntity, Response{
+			Code:    4022,
+			Message: "批量操作存在失败项，已全部回滚",
+			Data:    result,
+		})
+		return
+	}
+
+	h.Success(c, result)
+}
+
+// Evaluate 评估资源配置
+// @Summary 评估资源配置
+// @Description 使用全部生效中的配置项评估提交的资源配置。配置项按云服务商和产品编码匹配资源，检查方法中以"check:"开头的行为可自动执行的规则，没有规则的配置项结果为manual
+// @Tags 配置项
+// @Accept json
+// @Produce json
+// @Param request body EvaluationRequest true "待评估的资源，最多1000个"
+// @Success 200 {object} Response{data=evaluation.Report} "成功"
+// @Failure 400 {object} Response "无效的请求参数"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/config-items/evaluate [post]
+func (h *ConfigurationItemHandler) Evaluate(c *gin.Context) {
+	var req EvaluationRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	report, err := h.service.EvaluateResources(c, req.Model())
+	if err != nil {
+		logger.Error("Failed to evaluate resources", err, zap.Int("count", len(req.Resources)))
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.Success(c, report)
+}
+
 // ExportExcel 导出配置项到Excel
 // @Summary 导出配置项到Excel
-// @Description 根据过滤条件导出配置项到Excel文件
+// @Description 根据过滤条件导出配置项到Excel文件，返回带签名的下载链接，链接过期前可通过下载文件接口下载
 // @Tags 配置项
 // @Produce json
-// @Param cloud_provider_id query int false "云服务商ID"
-// @Param product_id query int false "产品ID"
+// @Param cloud_provider_id query []int false "云服务商ID，可传多个" collectionFormat(csv)
+// @Param product_id query []int false "产品ID，可传多个" collectionFormat(csv)
+// @Param category_id query []int false "产品类别ID，可传多个，包含子类别" collectionFormat(csv)
+// @Param tag query []string false "标签名称，可传多个" collectionFormat(csv)
+// @Param tag_match query string false "多个标签的匹配方式：or包含任一标签（默认），and包含全部标签"
 // @Param keyword query string false "关键词搜索"
-// @Success 200 {object} Response "成功"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
+// @Param group_by query string false "分组维度：category按产品类别分组排列，未分类的排在最后"
+// @Success 200 {object} Response{data=FileResponse} "成功"
+// @Failure 400 {object} Response "无效的请求参数"
 // @Failure 500 {object} Response "服务器内部错误"
 // @Router /api/v1/config-items/export [get]
 func (h *ConfigurationItemHandler) ExportExcel(c *gin.Context) {
-	filter := repository.ConfigItemFilter{
-		// 导出时不分页，获取所有符合条件的数据
-		Page:     1,
-		PageSize: 1000, // 较大的页大小，实际会限制在100以内
-	}
-
-	// 获取可选过滤参数
-	if providerID, ok := h.GetUintQueryParam(c, "cloud_provider_id"); ok {
-		filter.CloudProviderID = &providerID
+	filter, ok := h.bindConfigItemFilter(c)
+	if !ok {
+		return
 	}
 
-	if productID, ok := h.GetUintQueryParam(c, "product_id"); ok {
-		filter.ProductID = &productID
-	}
+	// 导出时不分页，获取所有符合条件的数据
+	filter.Page = 1
+	filter.PageSize = 1000 // 较大的页大小，实际会限制在100以内
+	// 导出需要服务商、产品、类别和标签名称，忽略字段选择和关联参数
+	filter.Fields = nil
+	filter.Include = []string{"provider", "product.category", "tags"}
 
-	if keyword, ok := h.GetQueryParam(c, "keyword"); ok {
-		filter.Keyword = &keyword
+	groupBy, _ := h.GetQueryParam(c, "group_by")
+	if groupBy != "" && groupBy != "category" {
+		h.Error(c, http.StatusBadRequest, 4000, "不支持的分组维度: "+groupBy)
+		return
 	}
 
 	// 获取数据
@@ -276,7 +442,11 @@ func (h *ConfigurationItemHandler) ExportExcel(c *gin.Context) {
 		return
 	}
 
-	// 导出到Excel
+	if groupBy == "category" {
+		excel.GroupByCategory(items)
+	}
+
+	// 导出到Excel，导出目录只用于暂存，生成的文件保存到文件存储
 	filePath, err := h.exporter.Export(c, items)
 	if err != nil {
 		logger.Error("Failed to export to Excel", err)
@@ -284,58 +454,47 @@ func (h *ConfigurationItemHandler) ExportExcel(c *gin.Context) {
 		return
 	}
 
+	file, err := h.files.SaveLocal(c, models.FileKindExport, filePath, "")
+	if err != nil {
+		logger.Error("Failed to store exported file", err)
+		h.HandleServiceError(c, err)
+		return
+	}
+
 	// 返回下载链接
-	h.Success(c, gin.H{
-		"message":  "导出成功",
-		"filePath": filePath,
-		"fileName": filepath.Base(filePath),
-	})
+	url, expiresAt := h.files.DownloadURL(file)
+	h.Success(c, NewFileResponse(file, url, expiresAt))
 }
 
 // ImportExcel 从Excel导入配置项
 // @Summary 从Excel导入配置项
-// @Description 从上传的Excel文件导入配置项
+// @Description 从上传的Excel文件导入配置项。文件大小、解压后大小、工作表数和行数受配置限制，无法解析的文件被隔离或删除
 // @Tags 配置项
 // @Accept multipart/form-data
 // @Produce json
 // @Param file formData file true "Excel文件"
 // @Success 200 {object} Response "成功"
 // @Failure 400 {object} Response "无效的文件"
+// @Failure 413 {object} Response "文件超过大小限制"
 // @Failure 500 {object} Response "服务器内部错误"
 // @Router /api/v1/config-items/import [post]
 func (h *ConfigurationItemHandler) ImportExcel(c *gin.Context) {
-	// 从表单获取文件
-	file, header, err := c.Request.FormFile("file")
-	if err != nil {
-		h.Error(c, http.StatusBadRequest, 4000, "请选择要导入的Excel文件")
-		return
-	}
-	defer file.Close()
-
-	// 验证文件类型
-	if !strings.HasSuffix(strings.ToLower(header.Filename), ".xlsx") {
-		h.Error(c, http.StatusBadRequest, 4000, "只支持.xlsx格式的Excel文件")
-		return
-	}
-
-	// 生成唯一文件名
-	filename := fmt.Sprintf("%d_%s", time.Now().Unix(), header.Filename)
-
-	// 保存文件
-	filePath, err := h.importer.SaveUploadedFile(file, filename)
-	if err != nil {
-		logger.Error("Failed to save uploaded file", err)
-		h.Error(c, http.StatusInternalServerError, 5000, "保存文件失败："+err.Error())
+	// 接收并校验上传的文件
+	filePath, name, ok := h.receiveExcelUpload(c, h.importer)
+	if !ok {
 		return
 	}
 
-	// 解析Excel
+	// 解析Excel，无法解析的文件被隔离或删除
 	items, err := h.importer.ImportConfigItems(c, filePath)
 	if err != nil {
 		logger.Error("Failed to parse Excel file", err)
+		h.importer.Discard(filePath)
 		h.Error(c, http.StatusBadRequest, 4000, "解析Excel文件失败："+err.Error())
 		return
 	}
+	// 导入完成后将上传文件移到文件存储，超过保留时长后删除
+	defer h.archiveUpload(c, filePath, name)
 
 	// 批量导入数据
 	err = h.service.BatchImportConfigItems(c, items)
@@ -349,4 +508,43 @@ func (h *ConfigurationItemHandler) ImportExcel(c *gin.Context) {
 		"message": "导入成功",
 		"count":   len(items),
 	})
-}
\ No newline at end of file
+}
+
+// ImportTemplate 下载配置项导入模板
+// @Summary 下载配置项导入模板
+// @Description 生成配置项导入模板，云服务商和云产品列提供现有数据的下拉列表，填写说明工作表包含填写规则和示例
+// @Tags 配置项
+// @Produce application/octet-stream
+// @Success 200 {file} file "导入模板"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/config-items/import-template [get]
+func (h *ConfigurationItemHandler) ImportTemplate(c *gin.Context) {
+	providers, products, err := h.service.GetImportCatalog(c)
+	if err != nil {
+		logger.Error("Failed to get import catalog", err)
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	// 先生成到内存中，生成失败时仍可返回错误响应
+	var buf bytes.Buffer
+	if err := h.importer.WriteTemplate(&buf, providers, products); err != nil {
+		logger.Error("Failed to generate import template", err)
+		h.Error(c, http.StatusInternalServerError, 5000, "生成导入模板失败")
+		return
+	}
+
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": "配置项导入模板.xlsx"}))
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", buf.Bytes())
+}
+
+// archiveUpload 将上传的导入文件保存到文件存储，失败时删除暂存的文件
+func (h *ConfigurationItemHandler) archiveUpload(c *gin.Context, filePath, name string) {
+	if _, err := h.files.SaveLocal(c, models.FileKindImport, filePath, name); err != nil {
+		logger.Error("Failed to archive uploaded file", err, zap.String("path", filePath))
+		if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+			logger.Error("Failed to remove uploaded file", err, zap.String("path", filePath))
+		}
+	}
+}
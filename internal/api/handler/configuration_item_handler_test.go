@@ -0,0 +1,243 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/cloud-eye/internal/api/handler"
+	"github.com/yourusername/cloud-eye/internal/apptest"
+	"github.com/yourusername/cloud-eye/internal/models"
+	"github.com/yourusername/cloud-eye/internal/service"
+)
+
+// doJSON 发送JSON请求并解析统一响应，data解析到out
+func doJSON(t *testing.T, method, url string, body interface{}, out interface{}) (int, handler.Response) {
+	t.Helper()
+	raw, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("编码请求失败: %v", err)
+	}
+	return doRequest(t, method, url, "application/json", bytes.NewReader(raw), out)
+}
+
+// doRequest 发送指定Content-Type的请求并解析统一响应，data解析到out
+func doRequest(t *testing.T, method, url, contentType string, body io.Reader, out interface{}) (int, handler.Response) {
+	t.Helper()
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		t.Fatalf("创建请求失败: %v", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("请求%s %s失败: %v", method, url, err)
+	}
+	defer resp.Body.Close()
+
+	var envelope struct {
+		handler.Response
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		t.Fatalf("解析响应失败: %v", err)
+	}
+	if out != nil && len(envelope.Data) > 0 {
+		if err := json.Unmarshal(envelope.Data, out); err != nil {
+			t.Fatalf("解析响应数据失败: %v", err)
+		}
+	}
+	return resp.StatusCode, envelope.Response
+}
+
+// errorCase 无效输入应返回的状态码和错误信息，fields为字段级校验错误的字段名
+type errorCase struct {
+	name    string
+	method  string
+	path    string
+	body    interface{}
+	status  int
+	message string
+	fields  []string
+}
+
+// runErrorCases 逐个发送请求并检查错误响应；body为string时按原样发送，用于构造无效的JSON
+func runErrorCases(t *testing.T, app *apptest.App, cases []errorCase) {
+	t.Helper()
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var status int
+			var resp handler.Response
+			if raw, ok := tc.body.(string); ok {
+				status, resp = doRequest(t, tc.method, app.URL(tc.path), "application/json", strings.NewReader(raw), nil)
+			} else {
+				status, resp = doJSON(t, tc.method, app.URL(tc.path), tc.body, nil)
+			}
+			if status != tc.status || !strings.Contains(resp.Message, tc.message) {
+				t.Fatalf("返回%d %q，期望%d %q", status, resp.Message, tc.status, tc.message)
+			}
+			if resp.Code == 0 {
+				t.Fatalf("错误响应的code为0")
+			}
+			var fields []string
+			for _, f := range resp.Errors {
+				fields = append(fields, f.Field)
+			}
+			if strings.Join(fields, ",") != strings.Join(tc.fields, ",") {
+				t.Fatalf("字段错误为%+v，期望字段%v", resp.Errors, tc.fields)
+			}
+		})
+	}
+}
+
+// getItem 读取配置项
+func getItem(t *testing.T, app *apptest.App, id string) models.ConfigurationItem {
+	t.Helper()
+	var item models.ConfigurationItem
+	if status, resp := doJSON(t, http.MethodGet, app.URL("/api/v1/config-items/"+id), nil, &item); status != http.StatusOK {
+		t.Fatalf("读取配置项%s返回%d: %s", id, status, resp.Message)
+	}
+	return item
+}
+
+func TestBulkValidatesLikeSingleItemEndpoints(t *testing.T) {
+	app := apptest.New(t)
+	before := getItem(t, app, "1")
+
+	tests := []struct {
+		name    string
+		op      map[string]interface{}
+		wantErr string
+	}{
+		{
+			name:    "create缺少名称和推荐配置值",
+			op:      map[string]interface{}{"op": "create", "item": map[string]interface{}{"cloud_provider_id": 1, "product_id": 1}},
+			wantErr: "recommended_value: 不能为空",
+		},
+		{
+			name: "create的参考资料链接无效",
+			op: map[string]interface{}{"op": "create", "item": map[string]interface{}{
+				"cloud_provider_id": 1, "product_id": 1, "name": "名称", "recommended_value": "值", "reference": "ftp://example.com",
+			}},
+			wantErr: "reference: 包含无效的链接",
+		},
+		{
+			name:    "update清空必填字段",
+			op:      map[string]interface{}{"op": "update", "id": 1, "patch": map[string]interface{}{"recommended_value": nil, "name": ""}},
+			wantErr: "name: 不能为空",
+		},
+		{
+			name:    "update修改关联对象",
+			op:      map[string]interface{}{"op": "update", "id": 1, "patch": map[string]interface{}{"provider": map[string]interface{}{"name": "HACKED"}}},
+			wantErr: "不能通过补丁修改字段: provider",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result service.ConfigItemBulkResponse
+			status, _ := doJSON(t, http.MethodPost, app.URL("/api/v1/config-items/bulk"), map[string]interface{}{
+				"mode":       "best_effort",
+				"operations": []interface{}{tt.op},
+			}, &result)
+			if status != http.StatusOK {
+				t.Fatalf("状态码为%d，期望200", status)
+			}
+			if result.Committed || result.Failed != 1 {
+				t.Fatalf("结果为%+v，期望唯一的操作失败", result)
+			}
+			if got := result.Results[0].Error; !strings.Contains(got, tt.wantErr) {
+				t.Fatalf("错误信息为%q，期望包含%q", got, tt.wantErr)
+			}
+		})
+	}
+
+	if after := getItem(t, app, "1"); after.Name != before.Name || after.RecommendedValue != before.RecommendedValue {
+		t.Fatalf("校验失败的补丁修改了配置项: %q/%q", after.Name, after.RecommendedValue)
+	}
+}
+
+func TestBulkBestEffortAllowsOneOperationPerItem(t *testing.T) {
+	app := apptest.New(t)
+	before := getItem(t, app, "1")
+	target := before.ProductID%2 + 1
+
+	// best_effort模式下前面的update可能单独回滚，后面同一配置项的操作不能基于它的结果执行
+	var result service.ConfigItemBulkResponse
+	status, resp := doJSON(t, http.MethodPost, app.URL("/api/v1/config-items/bulk"), map[string]interface{}{
+		"mode": "best_effort",
+		"operations": []interface{}{
+			map[string]interface{}{"op": "update", "id": 1, "patch": map[string]interface{}{"severity": "critical"}},
+			map[string]interface{}{"op": "move", "id": 1, "product_id": target},
+			map[string]interface{}{"op": "tag", "id": 1, "add_tags": []string{"批量"}},
+			map[string]interface{}{"op": "update", "id": 2, "patch": map[string]interface{}{"severity": "low"}},
+		},
+	}, &result)
+	if status != http.StatusOK || result.Succeeded != 2 || result.Failed != 2 {
+		t.Fatalf("状态码为%d，结果为%+v: %s", status, result, resp.Message)
+	}
+	for _, i := range []int{1, 2} {
+		if got := result.Results[i]; got.Status != service.BulkStatusFailed || !strings.Contains(got.Error, "operations[0]已操作配置项1") {
+			t.Fatalf("第%d项结果为%+v，期望因重复操作失败", i, got)
+		}
+	}
+	if after := getItem(t, app, "1"); after.Severity != "critical" || after.ProductID != before.ProductID || len(after.Tags) != len(before.Tags) {
+		t.Fatalf("配置项为severity=%s, product_id=%d, tags=%v，期望只执行第一个操作", after.Severity, after.ProductID, after.Tags)
+	}
+
+	// atomic模式下全部操作一起提交或回滚，后续操作可以基于前面操作的结果
+	result = service.ConfigItemBulkResponse{}
+	status, resp = doJSON(t, http.MethodPost, app.URL("/api/v1/config-items/bulk"), map[string]interface{}{
+		"operations": []interface{}{
+			map[string]interface{}{"op": "update", "id": 1, "patch": map[string]interface{}{"severity": "info"}},
+			map[string]interface{}{"op": "move", "id": 1, "product_id": target},
+		},
+	}, &result)
+	if status != http.StatusOK || result.Succeeded != 2 {
+		t.Fatalf("状态码为%d，结果为%+v: %s", status, result, resp.Message)
+	}
+	if after := getItem(t, app, "1"); after.Severity != "info" || after.ProductID != target {
+		t.Fatalf("配置项为severity=%s, product_id=%d，期望update和move都生效", after.Severity, after.ProductID)
+	}
+}
+
+func TestBulkCreateIgnoresServerManagedFields(t *testing.T) {
+	app := apptest.New(t)
+
+	var result service.ConfigItemBulkResponse
+	status, resp := doJSON(t, http.MethodPost, app.URL("/api/v1/config-items/bulk"), map[string]interface{}{
+		"operations": []interface{}{
+			map[string]interface{}{"op": "create", "item": map[string]interface{}{
+				"id": 1, "cloud_provider_id": 1, "product_id": 1, "name": "新配置项", "recommended_value": "启用",
+				"control_family_id": 1, "created_at": "2000-01-01T00:00:00Z",
+				"provider": map[string]interface{}{"id": 1, "name": "HACKED"},
+				"product":  map[string]interface{}{"id": 1, "name": "HACKED"},
+			}, "add_tags": []string{"加密"}},
+		},
+	}, &result)
+	if status != http.StatusOK || !result.Committed {
+		t.Fatalf("状态码为%d，结果为%+v: %s", status, result, resp.Message)
+	}
+
+	item := result.Results[0].Item
+	if item == nil || item.ID == 1 {
+		t.Fatalf("创建结果为%+v，期望使用新分配的ID", item)
+	}
+	if item.ControlFamilyID != nil || item.CreatedAt.Year() == 2000 {
+		t.Fatalf("创建时写入了服务端维护的字段: control_family_id=%v, created_at=%v", item.ControlFamilyID, item.CreatedAt)
+	}
+	if got := models.TagNames(item.Tags); len(got) != 1 || got[0] != "加密" {
+		t.Fatalf("标签为%v，期望[加密]", got)
+	}
+
+	var provider models.CloudProvider
+	doJSON(t, http.MethodGet, app.URL("/api/v1/cloud-providers/1"), nil, &provider)
+	if provider.Name == "HACKED" {
+		t.Fatalf("批量创建修改了关联的云服务商")
+	}
+	if existing := getItem(t, app, "1"); existing.Name == "新配置项" {
+		t.Fatalf("批量创建覆盖了已有的配置项")
+	}
+}
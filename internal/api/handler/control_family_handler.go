@@ -0,0 +1,260 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cloud-eye/internal/models"
+	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+	"github.com/yourusername/cloud-eye/internal/service"
+	"go.uber.org/zap"
+)
+
+// ControlFamilyHandler 控制族API处理器
+type ControlFamilyHandler struct {
+	BaseHandler
+	service service.ControlFamilyService
+}
+
+// NewControlFamilyHandler 创建控制族处理器
+func NewControlFamilyHandler(service service.ControlFamilyService) *ControlFamilyHandler {
+	return &ControlFamilyHandler{
+		service: service,
+	}
+}
+
+// LinkConfigItemsRequest 关联配置项请求
+type LinkConfigItemsRequest struct {
+	ConfigItemIDs []uint `json:"config_item_ids" binding:"required"`
+}
+
+// GetAll 获取所有控制族
+// @Summary 获取所有控制族
+// @Description 获取所有跨云服务商的控制族
+// @Tags 控制族
+// @Produce json
+// @Success 200 {object} Response{data=[]models.ControlFamily} "成功"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/control-families [get]
+func (h *ControlFamilyHandler) GetAll(c *gin.Context) {
+	families, err := h.service.GetAllFamilies(c)
+	if err != nil {
+		logger.Error("Failed to get all control families", err)
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.Success(c, families)
+}
+
+// GetByID 根据ID获取控制族
+// @Summary 获取控制族详情
+// @Description 根据ID获取控制族及其成员配置项
+// @Tags 控制族
+// @Produce json
+// @Param id path int true "控制族ID"
+// @Success 200 {object} Response{data=models.ControlFamily} "成功"
+// @Failure 400 {object} Response "无效的ID参数"
+// @Failure 404 {object} Response "控制族不存在"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/control-families/{id} [get]
+func (h *ControlFamilyHandler) GetByID(c *gin.Context) {
+	id, ok := h.GetIDFromPath(c, "id")
+	if !ok {
+		return
+	}
+
+	family, err := h.service.GetFamilyByID(c, id)
+	if err != nil {
+		logger.Error("Failed to get control family by ID", err, zap.Uint("id", id))
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.Success(c, family)
+}
+
+// Create 创建控制族
+// @Summary 创建控制族
+// @Description 创建新的控制族
+// @Tags 控制族
+// @Accept json
+// @Produce json
+// @Param family body models.ControlFamily true "控制族信息"
+// @Success 200 {object} Response{data=models.ControlFamily} "成功"
+// @Failure 400 {object} Response "无效的请求参数"
+// @Failure 409 {object} Response "控制族代码已存在"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/control-families [post]
+func (h *ControlFamilyHandler) Create(c *gin.Context) {
+	var family models.ControlFamily
+	if !h.BindJSON(c, &family) {
+		return
+	}
+
+	err := h.service.CreateFamily(c, &family)
+	if err != nil {
+		logger.Error("Failed to create control family", err)
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.Success(c, family)
+}
+
+// Update 更新控制族
+// @Summary 更新控制族
+// @Description 更新控制族的名称、代码和描述，成员配置项不受影响
+// @Tags 控制族
+// @Accept json
+// @Produce json
+// @Param id path int true "控制族ID"
+// @Param family body models.ControlFamily true "控制族信息"
+// @Success 200 {object} Response "成功"
+// @Failure 400 {object} Response "无效的请求参数"
+// @Failure 404 {object} Response "控制族不存在"
+// @Failure 409 {object} Response "控制族代码已存在"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/control-families/{id} [put]
+func (h *ControlFamilyHandler) Update(c *gin.Context) {
+	id, ok := h.GetIDFromPath(c, "id")
+	if !ok {
+		return
+	}
+
+	var family models.ControlFamily
+	if !h.BindJSON(c, &family) {
+		return
+	}
+
+	// 确保路径参数ID与请求体ID一致
+	family.ID = id
+
+	err := h.service.UpdateFamily(c, &family)
+	if err != nil {
+		logger.Error("Failed to update control family", err, zap.Uint("id", id))
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.Success(c, gin.H{"message": "控制族更新成功"})
+}
+
+// Delete 删除控制族
+// @Summary 删除控制族
+// @Description 删除指定的控制族，成员配置项保留并解除关联
+// @Tags 控制族
+// @Produce json
+// @Param id path int true "控制族ID"
+// @Success 200 {object} Response "成功"
+// @Failure 400 {object} Response "无效的ID参数"
+// @Failure 404 {object} Response "控制族不存在"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/control-families/{id} [delete]
+func (h *ControlFamilyHandler) Delete(c *gin.Context) {
+	id, ok := h.GetIDFromPath(c, "id")
+	if !ok {
+		return
+	}
+
+	err := h.service.DeleteFamily(c, id)
+	if err != nil {
+		logger.Error("Failed to delete control family", err, zap.Uint("id", id))
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.Success(c, gin.H{"message": "控制族删除成功"})
+}
+
+// LinkConfigItems 将配置项加入控制族
+// @Summary 关联配置项
+// @Description 将一个或多个配置项加入控制族，已属于其他控制族的配置项需先解除关联
+// @Tags 控制族
+// @Accept json
+// @Produce json
+// @Param id path int true "控制族ID"
+// @Param request body LinkConfigItemsRequest true "配置项ID列表"
+// @Success 200 {object} Response "成功"
+// @Failure 400 {object} Response "无效的请求参数"
+// @Failure 404 {object} Response "控制族或配置项不存在"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/control-families/{id}/config-items [post]
+func (h *ControlFamilyHandler) LinkConfigItems(c *gin.Context) {
+	id, ok := h.GetIDFromPath(c, "id")
+	if !ok {
+		return
+	}
+
+	var req LinkConfigItemsRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	err := h.service.LinkConfigItems(c, id, req.ConfigItemIDs)
+	if err != nil {
+		logger.Error("Failed to link config items to control family", err, zap.Uint("id", id))
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.Success(c, gin.H{"message": "配置项关联成功"})
+}
+
+// UnlinkConfigItems 将配置项移出控制族
+// @Summary 解除配置项关联
+// @Description 将一个或多个配置项移出控制族
+// @Tags 控制族
+// @Produce json
+// @Param id path int true "控制族ID"
+// @Param config_item_id query []int true "配置项ID，可传多个" collectionFormat(csv)
+// @Success 200 {object} Response "成功"
+// @Failure 400 {object} Response "无效的请求参数"
+// @Failure 404 {object} Response "配置项不属于该控制族"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/control-families/{id}/config-items [delete]
+func (h *ControlFamilyHandler) UnlinkConfigItems(c *gin.Context) {
+	id, ok := h.GetIDFromPath(c, "id")
+	if !ok {
+		return
+	}
+
+	itemIDs, ok := h.GetUintListQueryParam(c, "config_item_id")
+	if !ok {
+		return
+	}
+
+	err := h.service.UnlinkConfigItems(c, id, itemIDs)
+	if err != nil {
+		logger.Error("Failed to unlink config items from control family", err, zap.Uint("id", id))
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.Success(c, gin.H{"message": "配置项解除关联成功"})
+}
+
+// Compare 获取控制族对比视图
+// @Summary 控制族跨云对比
+// @Description 按云服务商并列展示控制族中各配置项的推荐配置值，并标记没有等价配置基线的服务商
+// @Tags 控制族
+// @Produce json
+// @Param id path int true "控制族ID"
+// @Success 200 {object} Response{data=service.FamilyComparison} "成功"
+// @Failure 400 {object} Response "无效的ID参数"
+// @Failure 404 {object} Response "控制族不存在"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/control-families/{id}/comparison [get]
+func (h *ControlFamilyHandler) Compare(c *gin.Context) {
+	id, ok := h.GetIDFromPath(c, "id")
+	if !ok {
+		return
+	}
+
+	comparison, err := h.service.CompareFamily(c, id)
+	if err != nil {
+		logger.Error("Failed to compare control family", err, zap.Uint("id", id))
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.Success(c, comparison)
+}
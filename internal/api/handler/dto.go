@@ -0,0 +1,516 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yourusername/cloud-eye/internal/models"
+	"github.com/yourusername/cloud-eye/internal/pkg/evaluation"
+	"github.com/yourusername/cloud-eye/internal/repository"
+	"github.com/yourusername/cloud-eye/internal/service"
+)
+
+// 请求DTO只包含客户端可以设置的字段，id、时间戳、关联对象和标签由服务端维护；
+// 长度限制与数据库列一致，TEXT列按utf8mb4每字符最多4字节折算为16000个字符
+
+// ProviderRequest 创建或更新云服务商的请求
+type ProviderRequest struct {
+	Name        string `json:"name" binding:"required,max=100"`
+	Code        string `json:"code" binding:"required,max=50,code"`
+	Description string `json:"description" binding:"max=16000"`
+}
+
+// NewProviderRequest 由已有的云服务商生成请求，用于校验合并补丁的结果
+func NewProviderRequest(p *models.CloudProvider) *ProviderRequest {
+	return &ProviderRequest{
+		Name:        p.Name,
+		Code:        p.Code,
+		Description: p.Description,
+	}
+}
+
+// Model 转换为云服务商模型
+func (r *ProviderRequest) Model() *models.CloudProvider {
+	return &models.CloudProvider{
+		Name:        r.Name,
+		Code:        r.Code,
+		Description: r.Description,
+	}
+}
+
+// ProductRequest 创建或更新云产品的请求
+type ProductRequest struct {
+	CloudProviderID uint   `json:"cloud_provider_id" binding:"required"`
+	Name            string `json:"name" binding:"required,max=100"`
+	Code            string `json:"code" binding:"required,max=50,code"`
+	Description     string `json:"description" binding:"max=16000"`
+	CategoryID      *uint  `json:"category_id" binding:"omitempty,min=1"`
+}
+
+// NewProductRequest 由已有的云产品生成请求，用于校验合并补丁的结果
+func NewProductRequest(p *models.CloudProduct) *ProductRequest {
+	return &ProductRequest{
+		CloudProviderID: p.CloudProviderID,
+		Name:            p.Name,
+		Code:            p.Code,
+		Description:     p.Description,
+		CategoryID:      p.CategoryID,
+	}
+}
+
+// Model 转换为云产品模型
+func (r *ProductRequest) Model() *models.CloudProduct {
+	return &models.CloudProduct{
+		CloudProviderID: r.CloudProviderID,
+		Name:            r.Name,
+		Code:            r.Code,
+		Description:     r.Description,
+		CategoryID:      r.CategoryID,
+	}
+}
+
+// CategoryRequest 创建或更新产品类别的请求，parent_id为空时为顶级类别
+type CategoryRequest struct {
+	ParentID    *uint  `json:"parent_id" binding:"omitempty,min=1"`
+	Name        string `json:"name" binding:"required,max=100"`
+	Code        string `json:"code" binding:"required,max=50,code"`
+	Description string `json:"description" binding:"max=16000"`
+}
+
+// Model 转换为产品类别模型
+func (r *CategoryRequest) Model() *models.ProductCategory {
+	return &models.ProductCategory{
+		ParentID:    r.ParentID,
+		Name:        r.Name,
+		Code:        r.Code,
+		Description: r.Description,
+	}
+}
+
+// ConfigItemRequest 创建或更新配置项的请求，控制族成员关系通过控制族接口维护
+type ConfigItemRequest struct {
+	CloudProviderID     uint   `json:"cloud_provider_id" binding:"required"`
+	ProductID           uint   `json:"product_id" binding:"required"`
+	Name                string `json:"name" binding:"required,max=200"`
+	RecommendedValue    string `json:"recommended_value" binding:"required,max=16000"`
+	RiskDescription     string `json:"risk_description" binding:"max=16000"`
+	CheckMethod         string `json:"check_method" binding:"max=16000"`
+	ConfigurationMethod string `json:"configuration_method" binding:"max=16000"`
+	Reference           string `json:"reference" binding:"max=16000,reference"`
+	Severity            string `json:"severity" binding:"omitempty,oneof=critical high medium low info"`
+	Status              string `json:"status" binding:"omitempty,oneof=draft active deprecated"`
+}
+
+// NewConfigItemRequest 由已有的配置项生成请求，用于校验合并补丁的结果
+func NewConfigItemRequest(item *models.ConfigurationItem) *ConfigItemRequest {
+	return &ConfigItemRequest{
+		CloudProviderID:     item.CloudProviderID,
+		ProductID:           item.ProductID,
+		Name:                item.Name,
+		RecommendedValue:    item.RecommendedValue,
+		RiskDescription:     item.RiskDescription,
+		CheckMethod:         item.CheckMethod,
+		ConfigurationMethod: item.ConfigurationMethod,
+		Reference:           item.Reference,
+		Severity:            item.Severity,
+		Status:              item.Status,
+	}
+}
+
+// Model 转换为配置项模型
+func (r *ConfigItemRequest) Model() *models.ConfigurationItem {
+	return &models.ConfigurationItem{
+		CloudProviderID:     r.CloudProviderID,
+		ProductID:           r.ProductID,
+		Name:                r.Name,
+		RecommendedValue:    r.RecommendedValue,
+		RiskDescription:     r.RiskDescription,
+		CheckMethod:         r.CheckMethod,
+		ConfigurationMethod: r.ConfigurationMethod,
+		Reference:           r.Reference,
+		Severity:            r.Severity,
+		Status:              r.Status,
+	}
+}
+
+// ConfigItemBulkOperation 批量操作中的单项操作，create的item与创建配置项接口的请求一致
+type ConfigItemBulkOperation struct {
+	Op         string             `json:"op"`                    // create, update, delete, move, tag
+	ID         uint               `json:"id,omitempty"`          // update、delete、move、tag时的配置项ID
+	Item       *ConfigItemRequest `json:"item,omitempty"`        // create时的配置项
+	Patch      json.RawMessage    `json:"patch,omitempty"`       // update时的JSON合并补丁（RFC 7396），规则与部分更新接口一致
+	ProductID  uint               `json:"product_id,omitempty"`  // move时的目标云产品ID
+	AddTags    []string           `json:"add_tags,omitempty"`    // tag时添加的标签，create时为新配置项的标签
+	RemoveTags []string           `json:"remove_tags,omitempty"` // tag时移除的标签
+}
+
+// ConfigItemBulkRequest 配置项批量操作请求，单项操作的校验失败记录在该项的结果中
+type ConfigItemBulkRequest struct {
+	Mode       string                    `json:"mode"` // atomic（默认）或best_effort
+	Operations []ConfigItemBulkOperation `json:"operations"`
+}
+
+// Model 转换为服务层的批量操作请求，配置项按ConfigItemRequest的规则校验
+func (r *ConfigItemBulkRequest) Model() service.ConfigItemBulkRequest {
+	req := service.ConfigItemBulkRequest{
+		Mode:       r.Mode,
+		Operations: make([]service.ConfigItemBulkOperation, len(r.Operations)),
+		Validate:   validateConfigItem,
+	}
+	for i, op := range r.Operations {
+		req.Operations[i] = service.ConfigItemBulkOperation{
+			Op:         op.Op,
+			ID:         op.ID,
+			Patch:      op.Patch,
+			ProductID:  op.ProductID,
+			AddTags:    op.AddTags,
+			RemoveTags: op.RemoveTags,
+		}
+		if op.Item != nil {
+			req.Operations[i].Item = op.Item.Model()
+		}
+	}
+	return req
+}
+
+// validateConfigItem 按ConfigItemRequest的规则校验配置项
+func validateConfigItem(item *models.ConfigurationItem) error {
+	fields := ValidateRequest(NewConfigItemRequest(item))
+	if len(fields) == 0 {
+		return nil
+	}
+	msgs := make([]string, len(fields))
+	for i, f := range fields {
+		msgs[i] = f.Field + ": " + f.Message
+	}
+	return errors.New("请求参数校验失败: " + strings.Join(msgs, "; "))
+}
+
+// ResourceRequest 待评估的资源，provider和product为云服务商和云产品的编码
+type ResourceRequest struct {
+	ID       string                 `json:"id" binding:"required,max=200"`
+	Name     string                 `json:"name" binding:"max=200"`
+	Provider string                 `json:"provider" binding:"required,max=50"`
+	Product  string                 `json:"product" binding:"required,max=50"`
+	Config   map[string]interface{} `json:"config"`
+}
+
+// EvaluationRequest 资源评估请求
+type EvaluationRequest struct {
+	Resources []ResourceRequest `json:"resources" binding:"required,dive"`
+}
+
+// Model 转换为评估引擎的资源
+func (r *EvaluationRequest) Model() []evaluation.Resource {
+	resources := make([]evaluation.Resource, len(r.Resources))
+	for i, res := range r.Resources {
+		resources[i] = evaluation.Resource(res)
+	}
+	return resources
+}
+
+// TagResponse 标签
+type TagResponse struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+// ProviderResponse 云服务商响应
+type ProviderResponse struct {
+	ID           uint              `json:"id"`
+	Name         string            `json:"name"`
+	Code         string            `json:"code"`
+	Description  string            `json:"description"`
+	CreatedAt    time.Time         `json:"created_at"`
+	UpdatedAt    time.Time         `json:"updated_at"`
+	Products     []ProductResponse `json:"products,omitempty"`      // 仅在加载关联时返回
+	Tags         []TagResponse     `json:"tags,omitempty"`          // 仅在详情中返回
+	ProductCount *int64            `json:"product_count,omitempty"` // 仅在列表查询要求统计时返回
+}
+
+// ProductResponse 云产品响应
+type ProductResponse struct {
+	ID              uint                    `json:"id"`
+	CloudProviderID uint                    `json:"cloud_provider_id"`
+	Name            string                  `json:"name"`
+	Code            string                  `json:"code"`
+	Description     string                  `json:"description"`
+	CategoryID      *uint                   `json:"category_id"`
+	CreatedAt       time.Time               `json:"created_at"`
+	UpdatedAt       time.Time               `json:"updated_at"`
+	Provider        *ProviderResponse       `json:"provider,omitempty"`     // 仅在加载关联时返回
+	Category        *models.ProductCategory `json:"category,omitempty"`     // 仅在加载关联时返回
+	ConfigItems     []ConfigItemResponse    `json:"config_items,omitempty"` // 仅在加载关联时返回
+	Tags            []TagResponse           `json:"tags,omitempty"`
+	ConfigItemCount *int64                  `json:"config_item_count,omitempty"`
+}
+
+// ConfigItemResponse 配置项响应
+type ConfigItemResponse struct {
+	ID                  uint              `json:"id"`
+	CloudProviderID     uint              `json:"cloud_provider_id"`
+	ProductID           uint              `json:"product_id"`
+	Name                string            `json:"name"`
+	RecommendedValue    string            `json:"recommended_value"`
+	RiskDescription     string            `json:"risk_description"`
+	CheckMethod         string            `json:"check_method"`
+	ConfigurationMethod string            `json:"configuration_method"`
+	Reference           string            `json:"reference"`
+	Severity            string            `json:"severity"`
+	Status              string            `json:"status"`
+	ControlFamilyID     *uint             `json:"control_family_id"`
+	CreatedAt           time.Time         `json:"created_at"`
+	UpdatedAt           time.Time         `json:"updated_at"`
+	Provider            *ProviderResponse `json:"provider,omitempty"` // 仅在加载关联时返回
+	Product             *ProductResponse  `json:"product,omitempty"`  // 仅在加载关联时返回
+	Tags                []TagResponse     `json:"tags,omitempty"`
+}
+
+// NewProviderResponse 将云服务商模型转换为响应
+func NewProviderResponse(p *models.CloudProvider) *ProviderResponse {
+	resp := &ProviderResponse{
+		ID:           p.ID,
+		Name:         p.Name,
+		Code:         p.Code,
+		Description:  p.Description,
+		CreatedAt:    p.CreatedAt,
+		UpdatedAt:    p.UpdatedAt,
+		Tags:         newTagResponses(p.Tags),
+		ProductCount: p.ProductCount,
+	}
+	for i := range p.Products {
+		resp.Products = append(resp.Products, *NewProductResponse(&p.Products[i]))
+	}
+	return resp
+}
+
+// NewProductResponse 将云产品模型转换为响应
+func NewProductResponse(p *models.CloudProduct) *ProductResponse {
+	resp := &ProductResponse{
+		ID:              p.ID,
+		CloudProviderID: p.CloudProviderID,
+		Name:            p.Name,
+		Code:            p.Code,
+		Description:     p.Description,
+		CategoryID:      p.CategoryID,
+		CreatedAt:       p.CreatedAt,
+		UpdatedAt:       p.UpdatedAt,
+		Category:        p.Category,
+		Tags:            newTagResponses(p.Tags),
+		ConfigItemCount: p.ConfigItemCount,
+	}
+	// 关联对象未加载时为零值
+	if p.Provider.ID != 0 {
+		resp.Provider = NewProviderResponse(&p.Provider)
+	}
+	for i := range p.ConfigItems {
+		resp.ConfigItems = append(resp.ConfigItems, *NewConfigItemResponse(&p.ConfigItems[i]))
+	}
+	return resp
+}
+
+// NewConfigItemResponse 将配置项模型转换为响应
+func NewConfigItemResponse(item *models.ConfigurationItem) *ConfigItemResponse {
+	resp := &ConfigItemResponse{
+		ID:                  item.ID,
+		CloudProviderID:     item.CloudProviderID,
+		ProductID:           item.ProductID,
+		Name:                item.Name,
+		RecommendedValue:    item.RecommendedValue,
+		RiskDescription:     item.RiskDescription,
+		CheckMethod:         item.CheckMethod,
+		ConfigurationMethod: item.ConfigurationMethod,
+		Reference:           item.Reference,
+		Severity:            item.Severity,
+		Status:              item.Status,
+		ControlFamilyID:     item.ControlFamilyID,
+		CreatedAt:           item.CreatedAt,
+		UpdatedAt:           item.UpdatedAt,
+		Tags:                newTagResponses(item.Tags),
+	}
+	// 关联对象未加载时为零值
+	if item.Provider.ID != 0 {
+		resp.Provider = NewProviderResponse(&item.Provider)
+	}
+	if item.Product.ID != 0 {
+		resp.Product = NewProductResponse(&item.Product)
+	}
+	return resp
+}
+
+// newTagResponses 转换标签列表
+func newTagResponses(tags []models.Tag) []TagResponse {
+	if len(tags) == 0 {
+		return nil
+	}
+	resp := make([]TagResponse, 0, len(tags))
+	for _, t := range tags {
+		resp = append(resp, TagResponse{ID: t.ID, Name: t.Name})
+	}
+	return resp
+}
+
+// toResponse 将模型、模型列表或数据为模型列表的分页结果转换为响应DTO，其他类型原样返回
+func toResponse(data interface{}) interface{} {
+	switch v := data.(type) {
+	case *repository.PageResult:
+		page := *v
+		page.Data = toResponse(v.Data)
+		return &page
+	case *models.CloudProvider:
+		return NewProviderResponse(v)
+	case *models.CloudProduct:
+		return NewProductResponse(v)
+	case *models.ConfigurationItem:
+		return NewConfigItemResponse(v)
+	case []models.CloudProvider:
+		resp := make([]ProviderResponse, 0, len(v))
+		for i := range v {
+			resp = append(resp, *NewProviderResponse(&v[i]))
+		}
+		return resp
+	case []models.CloudProduct:
+		resp := make([]ProductResponse, 0, len(v))
+		for i := range v {
+			resp = append(resp, *NewProductResponse(&v[i]))
+		}
+		return resp
+	case []models.ConfigurationItem:
+		resp := make([]ConfigItemResponse, 0, len(v))
+		for i := range v {
+			resp = append(resp, *NewConfigItemResponse(&v[i]))
+		}
+		return resp
+	default:
+		return data
+	}
+}
+
+// WebhookRequest 创建或更新Webhook订阅的请求
+type WebhookRequest struct {
+	Name   string   `json:"name" binding:"required,max=100"`
+	URL    string   `json:"url" binding:"required,max=500"`
+	Secret string   `json:"secret" binding:"max=100"`  // 签名密钥，创建时为空则自动生成，更新时为空则保留原密钥
+	Events []string `json:"events" binding:"required"` // 订阅的事件类型，*表示全部事件
+	Active *bool    `json:"active"`                    // 是否启用，默认启用
+}
+
+// Model 转换为Webhook订阅模型
+func (r *WebhookRequest) Model() *models.WebhookSubscription {
+	active := true
+	if r.Active != nil {
+		active = *r.Active
+	}
+	return &models.WebhookSubscription{
+		Name:   r.Name,
+		URL:    r.URL,
+		Secret: r.Secret,
+		Events: r.Events,
+		Active: active,
+	}
+}
+
+// WebhookResponse Webhook订阅响应
+type WebhookResponse struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	Active    bool      `json:"active"`
+	Secret    string    `json:"secret,omitempty"` // 仅在创建时返回
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewWebhookResponse 将Webhook订阅模型转换为响应，不包含签名密钥
+func NewWebhookResponse(s *models.WebhookSubscription) *WebhookResponse {
+	return &WebhookResponse{
+		ID:        s.ID,
+		Name:      s.Name,
+		URL:       s.URL,
+		Events:    s.Events,
+		Active:    s.Active,
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+	}
+}
+
+// JobResponse 后台任务响应
+type JobResponse struct {
+	ID              uint            `json:"id"`
+	Type            string          `json:"type"`
+	Status          string          `json:"status"`
+	Progress        int             `json:"progress"`              // 已处理的记录数
+	Total           int             `json:"total"`                 // 待处理的记录总数，未知时为0
+	Percent         int             `json:"percent"`               // 完成百分比，总数未知时为0，成功后为100
+	Message         string          `json:"message,omitempty"`     // 当前步骤
+	Error           string          `json:"error,omitempty"`       // 失败原因
+	Warnings        []string        `json:"warnings,omitempty"`    // 跳过的记录等非致命问题
+	Result          json.RawMessage `json:"result,omitempty"`      // 结果摘要，随任务类型不同
+	ResultURL       string          `json:"result_url,omitempty"`  // 结果文件下载地址，任务成功且有结果文件时返回
+	ResultName      string          `json:"result_name,omitempty"` // 结果文件名称
+	CancelRequested bool            `json:"cancel_requested"`      // 已请求取消，执行中的任务在当前步骤结束后取消
+	Attempts        int             `json:"attempts"`              // 执行次数，服务重启后重新执行时增加
+	CreatedAt       time.Time       `json:"created_at"`
+	StartedAt       *time.Time      `json:"started_at,omitempty"`
+	FinishedAt      *time.Time      `json:"finished_at,omitempty"`
+}
+
+// NewJobResponse 将后台任务模型转换为响应
+func NewJobResponse(j *models.Job) *JobResponse {
+	resp := &JobResponse{
+		ID:              j.ID,
+		Type:            j.Type,
+		Status:          j.Status,
+		Progress:        j.Progress,
+		Total:           j.Total,
+		Message:         j.Message,
+		Error:           j.Error,
+		Warnings:        j.Warnings,
+		CancelRequested: j.CancelRequested,
+		Attempts:        j.Attempts,
+		CreatedAt:       j.CreatedAt,
+		StartedAt:       j.StartedAt,
+		FinishedAt:      j.FinishedAt,
+	}
+	if j.Total > 0 {
+		resp.Percent = j.Progress * 100 / j.Total
+		if resp.Percent > 100 {
+			resp.Percent = 100
+		}
+	}
+	if j.Status == models.JobStatusSucceeded {
+		resp.Percent = 100
+		if j.Result != "" {
+			resp.Result = json.RawMessage(j.Result)
+		}
+		if j.ResultFileID != 0 {
+			resp.ResultURL = fmt.Sprintf("/api/v1/jobs/%d/result", j.ID)
+			resp.ResultName = j.ResultName
+		}
+	}
+	return resp
+}
+
+// FileResponse 托管文件及其下载链接
+type FileResponse struct {
+	FileID      uint      `json:"fileId"`
+	FileName    string    `json:"fileName"`
+	Size        int64     `json:"size"`
+	DownloadURL string    `json:"downloadUrl"` // 带签名的下载地址，在expiresAt之前有效
+	ExpiresAt   time.Time `json:"expiresAt"`
+}
+
+// NewFileResponse 由托管文件和签发的下载链接生成响应
+func NewFileResponse(file *models.StoredFile, url string, expiresAt time.Time) *FileResponse {
+	return &FileResponse{
+		FileID:      file.ID,
+		FileName:    file.Name,
+		Size:        file.Size,
+		DownloadURL: url,
+		ExpiresAt:   expiresAt,
+	}
+}
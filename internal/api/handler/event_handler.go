@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cloud-eye/internal/models"
+	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+	"github.com/yourusername/cloud-eye/internal/repository"
+	"github.com/yourusername/cloud-eye/internal/service"
+	"go.uber.org/zap"
+)
+
+// replayBatchSize 断线续传时每次读取的事件数
+const replayBatchSize = 500
+
+// sseRetry 建议客户端断线后的重连间隔
+const sseRetry = 3 * time.Second
+
+// EventHandler 变更推送API处理器
+type EventHandler struct {
+	BaseHandler
+	service service.ChangeFeedService
+}
+
+// NewEventHandler 创建变更推送处理器
+func NewEventHandler(service service.ChangeFeedService) *EventHandler {
+	return &EventHandler{
+		service: service,
+	}
+}
+
+// Stream 以Server-Sent Events推送数据变更
+// @Summary 订阅数据变更
+// @Description 以SSE推送云服务商、云产品和配置项的创建、更新和删除事件，事件ID为全局递增的序号。
+// @Description 重连时通过Last-Event-ID请求头或last_event_id参数从断点续传；续传点已过期时先推送reset事件，客户端需重新加载数据。
+// @Tags 变更推送
+// @Produce text/event-stream
+// @Param Last-Event-ID header int false "最后收到的事件ID"
+// @Param last_event_id query int false "最后收到的事件ID，未提供请求头时使用"
+// @Param cloud_provider_id query int false "只推送该云服务商及其下属产品和配置项的事件"
+// @Param product_id query int false "只推送该云产品及其配置项的事件"
+// @Param types query string false "实体类型，逗号分隔：provider, product, config_item"
+// @Success 200 {string} string "事件流"
+// @Failure 400 {object} Response "无效的请求参数"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/events/stream [get]
+func (h *EventHandler) Stream(c *gin.Context) {
+	lastEventID, ok := h.lastEventID(c)
+	if !ok {
+		return
+	}
+	filter, ok := h.filter(c)
+	if !ok {
+		return
+	}
+
+	from, expired, err := h.service.ResumePoint(c, lastEventID)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	// 先订阅再回放，回放期间发布的事件在订阅中按序号去重
+	sub := h.service.Subscribe(filter)
+	defer sub.Close()
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", sseRetry.Milliseconds())
+	if expired {
+		logger.Info("Change feed resume point expired", zap.Uint("lastEventId", lastEventID))
+		fmt.Fprintf(c.Writer, "event: reset\ndata: {\"last_event_id\":%d}\n\n", lastEventID)
+	}
+	c.Writer.Flush()
+
+	sent := from
+	for {
+		events, err := h.service.EventsAfter(c, sent, filter, replayBatchSize)
+		if err != nil {
+			return
+		}
+		for i := range events {
+			if !writeEvent(c, &events[i]) {
+				return
+			}
+			sent = events[i].ID
+		}
+		c.Writer.Flush()
+		if len(events) < replayBatchSize {
+			break
+		}
+	}
+
+	heartbeat := time.NewTicker(h.service.Heartbeat())
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case event := <-sub.Events():
+			if event.ID <= sent {
+				continue
+			}
+			if !writeEvent(c, &event) {
+				return
+			}
+			sent = event.ID
+			c.Writer.Flush()
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(c.Writer, ": ping\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		case <-sub.Done():
+			// 服务关闭或推送积压，客户端按retry间隔重连并续传
+			return
+		case <-c.Request.Context().Done():
+			return
+		}
+	}
+}
+
+// writeEvent 写入一条SSE事件，连接已断开时返回false
+func writeEvent(c *gin.Context, event *models.ChangeEvent) bool {
+	data, err := json.Marshal(event)
+	if err != nil {
+		logger.Error("Failed to marshal change event", err, zap.Uint("id", event.ID))
+		return true
+	}
+	_, err = fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Event, data)
+	return err == nil
+}
+
+// lastEventID 读取客户端最后收到的事件ID，EventSource重连时通过Last-Event-ID请求头提供
+func (h *EventHandler) lastEventID(c *gin.Context) (uint, bool) {
+	value := c.GetHeader("Last-Event-ID")
+	if value == "" {
+		value = c.Query("last_event_id")
+	}
+	if value == "" {
+		return 0, true
+	}
+
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		h.Error(c, http.StatusBadRequest, 4000, "无效的事件ID: "+value)
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// filter 解析事件过滤条件
+func (h *EventHandler) filter(c *gin.Context) (repository.ChangeEventFilter, bool) {
+	var filter repository.ChangeEventFilter
+	for _, p := range []struct {
+		name   string
+		target *uint
+	}{
+		{"cloud_provider_id", &filter.CloudProviderID},
+		{"product_id", &filter.ProductID},
+	} {
+		if c.Query(p.name) == "" {
+			continue
+		}
+		id, ok := h.GetUintQueryParam(c, p.name)
+		if !ok {
+			h.Error(c, http.StatusBadRequest, 4000, "无效的"+p.name+"参数")
+			return filter, false
+		}
+		*p.target = id
+	}
+
+	for _, t := range c.QueryArray("types") {
+		for _, entity := range strings.Split(t, ",") {
+			entity = strings.TrimSpace(entity)
+			if entity == "" {
+				continue
+			}
+			if !containsEntity(entity) {
+				h.Error(c, http.StatusBadRequest, 4000, "不支持的实体类型: "+entity)
+				return filter, false
+			}
+			filter.EntityTypes = append(filter.EntityTypes, entity)
+		}
+	}
+	return filter, true
+}
+
+// containsEntity 判断是否为支持订阅的实体类型
+func containsEntity(entity string) bool {
+	for _, e := range models.ChangeEntities {
+		if e == entity {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,150 @@
+package handler_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/cloud-eye/internal/apptest"
+)
+
+// sseEvent 事件流中的一条事件
+type sseEvent struct {
+	ID       string
+	Event    string
+	EntityID uint
+}
+
+// String 格式为"事件类型:实体ID"
+func (e sseEvent) String() string {
+	return fmt.Sprintf("%s:%d", e.Event, e.EntityID)
+}
+
+// openStream 连接事件流，lastEventID非空时通过Last-Event-ID请求头续传；返回的通道在连接断开时关闭
+func openStream(t *testing.T, app *apptest.App, query, lastEventID string) (<-chan sseEvent, context.CancelFunc) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, app.URL("/api/v1/events/stream?"+query), nil)
+	if err != nil {
+		t.Fatalf("创建请求失败: %v", err)
+	}
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	// 服务端订阅后才写出响应头，Do返回时之后发布的事件都会推送
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		cancel()
+		t.Fatalf("连接事件流失败: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		cancel()
+		t.Fatalf("连接事件流返回%d", resp.StatusCode)
+	}
+
+	events := make(chan sseEvent, 64)
+	go func() {
+		defer close(events)
+		defer resp.Body.Close()
+		scanner := bufio.NewScanner(resp.Body)
+		var current sseEvent
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case strings.HasPrefix(line, "id: "):
+				current.ID = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "event: "):
+				current.Event = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				var data struct {
+					EntityID uint `json:"entity_id"`
+				}
+				json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &data)
+				current.EntityID = data.EntityID
+			case line == "" && current.Event != "":
+				events <- current
+				current = sseEvent{}
+			}
+		}
+	}()
+	t.Cleanup(cancel)
+	return events, cancel
+}
+
+// readEvents 按顺序读取n条事件
+func readEvents(t *testing.T, events <-chan sseEvent, n int) []sseEvent {
+	t.Helper()
+	var got []sseEvent
+	for len(got) < n {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				t.Fatalf("事件流已断开，已收到%v", got)
+			}
+			got = append(got, e)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("等待事件超时，已收到%v", got)
+		}
+	}
+	return got
+}
+
+// expectEvents 读取并按顺序比较事件，返回最后一条事件的ID
+func expectEvents(t *testing.T, step string, events <-chan sseEvent, want ...string) string {
+	t.Helper()
+	got := readEvents(t, events, len(want))
+	names := make([]string, len(got))
+	for i, e := range got {
+		names[i] = e.String()
+	}
+	if strings.Join(names, ",") != strings.Join(want, ",") {
+		t.Fatalf("%s: 收到的事件为%v，期望%v", step, names, want)
+	}
+	return got[len(got)-1].ID
+}
+
+// mustRequest 发送请求并要求返回200
+func mustRequest(t *testing.T, app *apptest.App, method, path string, body interface{}) {
+	t.Helper()
+	if status, resp := doJSON(t, method, app.URL(path), body, nil); status != http.StatusOK {
+		t.Fatalf("%s %s返回%d: %s", method, path, status, resp.Message)
+	}
+}
+
+func TestEventStreamResumesFilteredCascadeAndAssociationEvents(t *testing.T) {
+	app := apptest.New(t)
+	// 演示数据中Azure（ID为2）有产品4至6，产品4、5下有配置项7至10
+	const query = "cloud_provider_id=2&types=product,config_item"
+
+	events, disconnect := openStream(t, app, query, "")
+	// 其他云服务商的事件被过滤
+	mustRequest(t, app, http.MethodDelete, "/api/v1/config-items/1", nil)
+	mustRequest(t, app, http.MethodDelete, "/api/v1/cloud-providers/2?cascade=true", nil)
+	// provider类型的事件被过滤，级联删除先下级后上级
+	lastID := expectEvents(t, "级联删除", events,
+		"config_item.deleted:7", "config_item.deleted:8", "config_item.deleted:9", "config_item.deleted:10",
+		"product.deleted:4", "product.deleted:5", "product.deleted:6")
+	disconnect()
+
+	// 断线期间发生的变更在续传时回放
+	mustRequest(t, app, http.MethodPost, "/api/v1/trash/provider/2/restore", nil)
+	mustRequest(t, app, http.MethodPost, "/api/v1/product-categories/2/products", map[string]interface{}{"product_ids": []uint{4}})
+	mustRequest(t, app, http.MethodPost, "/api/v1/tags/attach", map[string]interface{}{"target": "provider", "ids": []uint{2}, "tags": []string{"公有云"}})
+	mustRequest(t, app, http.MethodPost, "/api/v1/tags/attach", map[string]interface{}{"target": "config_item", "ids": []uint{2, 7}, "tags": []string{"加密"}})
+
+	events, _ = openStream(t, app, query, lastID)
+	expectEvents(t, "续传", events,
+		"product.created:4", "product.created:5", "product.created:6",
+		"config_item.created:7", "config_item.created:8", "config_item.created:9", "config_item.created:10",
+		"product.updated:4", "config_item.updated:7")
+
+	// 回放后继续推送新事件
+	mustRequest(t, app, http.MethodPost, "/api/v1/control-families/1/config-items", map[string]interface{}{"config_item_ids": []uint{8}})
+	mustRequest(t, app, http.MethodPost, "/api/v1/tags/detach", map[string]interface{}{"target": "config_item", "ids": []uint{7, 8}, "tags": []string{"加密"}})
+	expectEvents(t, "续传后的实时事件", events, "config_item.updated:8", "config_item.updated:7")
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cloud-eye/internal/models"
+	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+	"github.com/yourusername/cloud-eye/internal/service"
+	"go.uber.org/zap"
+)
+
+// FileHandler 托管文件下载处理器
+type FileHandler struct {
+	BaseHandler
+	service service.FileService
+}
+
+// NewFileHandler 创建托管文件处理器
+func NewFileHandler(service service.FileService) *FileHandler {
+	return &FileHandler{
+		service: service,
+	}
+}
+
+// Download 通过签名链接下载文件
+// @Summary 下载文件
+// @Description 通过导出等接口返回的downloadUrl下载文件，链接包含过期时间和签名，过期后需重新导出；文件超过保留时长后被删除
+// @Tags 文件
+// @Produce application/octet-stream
+// @Param id path int true "文件ID"
+// @Param expires query int true "链接过期时间，Unix时间戳（秒）"
+// @Param signature query string true "链接签名"
+// @Success 200 {file} file "文件内容"
+// @Failure 400 {object} Response "无效的ID参数"
+// @Failure 403 {object} Response "下载链接无效或已过期"
+// @Failure 404 {object} Response "文件不存在或已过期"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/files/{id} [get]
+func (h *FileHandler) Download(c *gin.Context) {
+	id, ok := h.GetIDFromPath(c, "id")
+	if !ok {
+		return
+	}
+
+	expires, _ := strconv.ParseInt(c.Query("expires"), 10, 64)
+	if err := h.service.VerifyLink(id, expires, c.Query("signature")); err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	file, content, err := h.service.Open(c, id)
+	if err != nil {
+		logger.Error("Failed to open file", err, zap.Uint("id", id))
+		h.HandleServiceError(c, err)
+		return
+	}
+	defer content.Close()
+
+	serveFile(c, file, content)
+}
+
+// serveFile 以附件形式返回文件内容
+func serveFile(c *gin.Context, file *models.StoredFile, content io.Reader) {
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": file.Name}))
+	c.Header("Cache-Control", "private, no-store")
+	c.DataFromReader(http.StatusOK, file.Size, file.ContentType, content, nil)
+}
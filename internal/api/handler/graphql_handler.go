@@ -0,0 +1,811 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/yourusername/cloud-eye/internal/models"
+	"github.com/yourusername/cloud-eye/internal/pkg/graphql"
+	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+	"github.com/yourusername/cloud-eye/internal/repository"
+	"github.com/yourusername/cloud-eye/internal/service"
+	"go.uber.org/zap"
+)
+
+// GraphQLHandler GraphQL API处理器
+type GraphQLHandler struct {
+	BaseHandler
+	schema          *graphql.Schema
+	providerService service.CloudProviderService
+	productService  service.CloudProductService
+	itemService     service.ConfigurationItemService
+}
+
+// NewGraphQLHandler 创建GraphQL处理器
+func NewGraphQLHandler(providerService service.CloudProviderService, productService service.CloudProductService, itemService service.ConfigurationItemService) *GraphQLHandler {
+	h := &GraphQLHandler{
+		providerService: providerService,
+		productService:  productService,
+		itemService:     itemService,
+	}
+	h.schema = h.buildSchema()
+	return h
+}
+
+// Query 执行GraphQL查询或变更
+// @Summary 执行GraphQL请求
+// @Description 执行GraphQL查询或变更，Schema见/api/v1/graphql/schema；GET请求通过query、operationName和variables参数传递，只能执行查询。查询和校验失败时返回400，执行中的错误与部分结果一起在errors中返回
+// @Tags GraphQL
+// @Accept json
+// @Produce json
+// @Param request body graphql.Request true "GraphQL请求"
+// @Success 200 {object} graphql.Response "执行结果"
+// @Failure 400 {object} graphql.Response "查询无法解析或校验失败"
+// @Failure 405 {object} graphql.Response "GET请求不能执行变更"
+// @Router /api/v1/graphql [post]
+// @Router /api/v1/graphql [get]
+func (h *GraphQLHandler) Query(c *gin.Context) {
+	req, ok := h.bindRequest(c)
+	if !ok {
+		return
+	}
+
+	doc, err := graphql.Parse(req.Query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, graphql.Response{Errors: []*graphql.Error{toGraphQLError(err)}})
+		return
+	}
+	op, err := doc.Operation(req.OperationName)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, graphql.Response{Errors: []*graphql.Error{toGraphQLError(err)}})
+		return
+	}
+	if c.Request.Method == http.MethodGet && op.Type != "query" {
+		c.JSON(http.StatusMethodNotAllowed, graphql.Response{Errors: []*graphql.Error{
+			graphql.NewError(graphql.CodeBadUserInput, "GET请求只能执行查询，变更请使用POST"),
+		}})
+		return
+	}
+
+	ctx := h.withLoaders(c.Request.Context())
+	resp := h.schema.ExecuteOperation(ctx, doc, op, req.Variables)
+	status := http.StatusOK
+	if resp.Data == nil {
+		status = http.StatusBadRequest
+	}
+	c.JSON(status, resp)
+}
+
+// Schema 获取GraphQL Schema
+// @Summary 获取GraphQL Schema
+// @Description 以SDL格式返回GraphQL Schema
+// @Tags GraphQL
+// @Produce plain
+// @Success 200 {string} string "GraphQL SDL"
+// @Router /api/v1/graphql/schema [get]
+func (h *GraphQLHandler) Schema(c *gin.Context) {
+	c.String(http.StatusOK, h.schema.SDL())
+}
+
+// bindRequest 读取POST请求体或GET查询参数中的GraphQL请求，数字变量保留为json.Number以便按类型转换
+func (h *GraphQLHandler) bindRequest(c *gin.Context) (graphql.Request, bool) {
+	var req graphql.Request
+	badRequest := func(format string, args ...interface{}) (graphql.Request, bool) {
+		c.JSON(http.StatusBadRequest, graphql.Response{Errors: []*graphql.Error{
+			graphql.NewError(graphql.CodeBadUserInput, format, args...),
+		}})
+		return req, false
+	}
+
+	if c.Request.Method == http.MethodGet {
+		req.Query = c.Query("query")
+		req.OperationName = c.Query("operationName")
+		if variables := c.Query("variables"); variables != "" {
+			if err := decodeJSON([]byte(variables), &req.Variables); err != nil {
+				return badRequest("variables不是有效的JSON对象: %v", err)
+			}
+		}
+	} else {
+		body, err := c.GetRawData()
+		if err != nil {
+			return badRequest("读取请求体失败: %v", err)
+		}
+		if err := decodeJSON(body, &req); err != nil {
+			return badRequest("请求体不是有效的JSON: %v", err)
+		}
+	}
+
+	if strings.TrimSpace(req.Query) == "" {
+		return badRequest("缺少query")
+	}
+	return req, true
+}
+
+func decodeJSON(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+// toGraphQLError 转换解析阶段的错误
+func toGraphQLError(err error) *graphql.Error {
+	if gqlErr, ok := err.(*graphql.Error); ok {
+		return gqlErr
+	}
+	return graphql.NewError(graphql.CodeInternal, "%s", err.Error())
+}
+
+// graphQLError 将服务层错误转换为GraphQL错误，错误码放在extensions.code中
+func graphQLError(err error) error {
+	if _, ok := err.(*graphql.Error); ok {
+		return err
+	}
+
+	serviceErr, ok := err.(*service.ServiceError)
+	if !ok {
+		logger.Error("GraphQL resolver failed", err)
+		return graphql.NewError(graphql.CodeInternal, "服务器内部错误")
+	}
+
+	switch serviceErr.Code {
+	case service.ErrCodeNotFound:
+		return graphql.NewError("NOT_FOUND", "%s", serviceErr.Message)
+	case service.ErrCodeInvalidData:
+		return graphql.NewError(graphql.CodeBadUserInput, "%s", serviceErr.Message)
+	case service.ErrCodeDuplicate, service.ErrCodeConflict:
+		return graphql.NewError("CONFLICT", "%s", serviceErr.Message)
+	default:
+		return graphql.NewError(graphql.CodeInternal, "%s", serviceErr.Message)
+	}
+}
+
+// validationError 字段级校验错误，字段列表放在extensions.fields中
+func validationError(fields []FieldError) error {
+	for i := range fields {
+		fields[i].Field = camelCase(fields[i].Field)
+	}
+	err := graphql.NewError(graphql.CodeBadUserInput, "请求参数校验失败")
+	err.Extensions["fields"] = fields
+	return err
+}
+
+// graphQLLoaders 请求级的数据加载器，同一层字段的关联对象合并为一次查询
+type graphQLLoaders struct {
+	providers *graphql.Loader[uint, *models.CloudProvider]
+	products  *graphql.Loader[uint, *models.CloudProduct]
+
+	mu sync.Mutex
+	// 关联列表的加载器按过滤参数区分，参数相同的字段共享加载器
+	productsByProvider map[string]*graphql.Loader[uint, []models.CloudProduct]
+	itemsByProduct     map[string]*graphql.Loader[uint, []models.ConfigurationItem]
+}
+
+type graphQLLoadersKey struct{}
+
+// withLoaders 为请求创建数据加载器
+func (h *GraphQLHandler) withLoaders(ctx context.Context) context.Context {
+	return context.WithValue(ctx, graphQLLoadersKey{}, &graphQLLoaders{
+		providers:          graphql.NewLoader(h.loadProviders),
+		products:           graphql.NewLoader(h.loadProducts),
+		productsByProvider: make(map[string]*graphql.Loader[uint, []models.CloudProduct]),
+		itemsByProduct:     make(map[string]*graphql.Loader[uint, []models.ConfigurationItem]),
+	})
+}
+
+func loadersFrom(ctx context.Context) *graphQLLoaders {
+	return ctx.Value(graphQLLoadersKey{}).(*graphQLLoaders)
+}
+
+// listLoader 获取过滤参数对应的关联列表加载器，不存在时用newBatch创建
+func listLoader[V any](l *graphQLLoaders, loaders map[string]*graphql.Loader[uint, V], filter interface{}, newBatch func() graphql.BatchFunc[uint, V]) *graphql.Loader[uint, V] {
+	key, _ := json.Marshal(filter)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	loader, ok := loaders[string(key)]
+	if !ok {
+		loader = graphql.NewLoader(newBatch())
+		loaders[string(key)] = loader
+	}
+	return loader
+}
+
+// loadProviders 按ID批量加载云服务商
+func (h *GraphQLHandler) loadProviders(ctx context.Context, ids []uint) (map[uint]*models.CloudProvider, error) {
+	providers, err := h.providerService.ListProviders(ctx, repository.CloudProviderFilter{
+		IDs:         ids,
+		ListOptions: repository.ListOptions{Include: graphQLInclude},
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[uint]*models.CloudProvider, len(providers))
+	for i := range providers {
+		result[providers[i].ID] = &providers[i]
+	}
+	return result, nil
+}
+
+// loadProducts 按ID批量加载云产品
+func (h *GraphQLHandler) loadProducts(ctx context.Context, ids []uint) (map[uint]*models.CloudProduct, error) {
+	products, err := h.productService.ListProducts(ctx, repository.CloudProductFilter{
+		IDs:         ids,
+		ListOptions: repository.ListOptions{Include: graphQLInclude},
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[uint]*models.CloudProduct, len(products))
+	for i := range products {
+		result[products[i].ID] = &products[i]
+	}
+	return result, nil
+}
+
+// loadProductsByProvider 批量加载多个云服务商的云产品，每个服务商最多加载filter.PerProviderLimit个
+func (h *GraphQLHandler) loadProductsByProvider(filter repository.CloudProductFilter) graphql.BatchFunc[uint, []models.CloudProduct] {
+	return func(ctx context.Context, providerIDs []uint) (map[uint][]models.CloudProduct, error) {
+		filter := filter
+		filter.CloudProviderIDs = providerIDs
+		products, err := h.productService.ListProducts(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		result := make(map[uint][]models.CloudProduct, len(providerIDs))
+		for _, p := range products {
+			result[p.CloudProviderID] = append(result[p.CloudProviderID], p)
+		}
+		return result, nil
+	}
+}
+
+// loadItemsByProduct 批量加载多个云产品的配置项，每个产品最多加载filter.PerProductLimit个，按服务层的最大页大小逐页读取
+func (h *GraphQLHandler) loadItemsByProduct(filter repository.ConfigItemFilter) graphql.BatchFunc[uint, []models.ConfigurationItem] {
+	return func(ctx context.Context, productIDs []uint) (map[uint][]models.ConfigurationItem, error) {
+		result := make(map[uint][]models.ConfigurationItem, len(productIDs))
+		filter := filter
+		filter.ProductIDs = productIDs
+		filter.PageSize = 100
+		for page, loaded := 1, 0; ; page++ {
+			filter.Page = page
+			pageResult, err := h.itemService.GetConfigItemsByFilter(ctx, filter)
+			if err != nil {
+				return nil, err
+			}
+			items, _ := pageResult.Data.([]models.ConfigurationItem)
+			for _, item := range items {
+				result[item.ProductID] = append(result[item.ProductID], item)
+			}
+			loaded += len(items)
+			if len(items) < filter.PageSize || int64(loaded) >= pageResult.Total {
+				return result, nil
+			}
+		}
+	}
+}
+
+// resolveProviderProducts 批量解析Provider.products，过滤和数量限制在查询中完成
+func (h *GraphQLHandler) resolveProviderProducts(ctx context.Context, p graphql.ResolveParams) ([]interface{}, error) {
+	values := make([]interface{}, len(p.Sources))
+	first := firstArg(p)
+	if first == 0 {
+		for i := range values {
+			values[i] = []models.CloudProduct{}
+		}
+		return values, nil
+	}
+
+	ids := make([]uint, len(p.Sources))
+	for i, source := range p.Sources {
+		ids[i] = source.(*models.CloudProvider).ID
+	}
+	filter := repository.CloudProductFilter{
+		Keyword:          keywordArg(p),
+		PerProviderLimit: first,
+		ListOptions:      repository.ListOptions{Include: graphQLInclude},
+	}
+	l := loadersFrom(ctx)
+	lists, err := listLoader(l, l.productsByProvider, filter, func() graphql.BatchFunc[uint, []models.CloudProduct] {
+		return h.loadProductsByProvider(filter)
+	}).LoadMany(ctx, ids)
+	if err != nil {
+		return nil, graphQLError(err)
+	}
+	for i, products := range lists {
+		values[i] = products
+	}
+	return values, nil
+}
+
+// resolveProductConfigItems 批量解析Product.configItems，过滤和数量限制在查询中完成
+func (h *GraphQLHandler) resolveProductConfigItems(ctx context.Context, p graphql.ResolveParams) ([]interface{}, error) {
+	values := make([]interface{}, len(p.Sources))
+	first := firstArg(p)
+	if first == 0 {
+		for i := range values {
+			values[i] = []models.ConfigurationItem{}
+		}
+		return values, nil
+	}
+
+	ids := make([]uint, len(p.Sources))
+	for i, source := range p.Sources {
+		ids[i] = source.(*models.CloudProduct).ID
+	}
+	filter := repository.ConfigItemFilter{
+		Keyword:         keywordArg(p),
+		Severities:      p.Strings("severity"),
+		Statuses:        p.Strings("status"),
+		PerProductLimit: first,
+		ListOptions:     repository.ListOptions{Include: graphQLInclude},
+	}
+	l := loadersFrom(ctx)
+	lists, err := listLoader(l, l.itemsByProduct, filter, func() graphql.BatchFunc[uint, []models.ConfigurationItem] {
+		return h.loadItemsByProduct(filter)
+	}).LoadMany(ctx, ids)
+	if err != nil {
+		return nil, graphQLError(err)
+	}
+	for i, items := range lists {
+		values[i] = items
+	}
+	return values, nil
+}
+
+// resolveProductProvider 批量解析Product.provider
+func (h *GraphQLHandler) resolveProductProvider(ctx context.Context, p graphql.ResolveParams) ([]interface{}, error) {
+	ids := make([]uint, len(p.Sources))
+	for i, source := range p.Sources {
+		ids[i] = source.(*models.CloudProduct).CloudProviderID
+	}
+	return h.loadProviderValues(ctx, ids)
+}
+
+// resolveItemProvider 批量解析ConfigItem.provider
+func (h *GraphQLHandler) resolveItemProvider(ctx context.Context, p graphql.ResolveParams) ([]interface{}, error) {
+	ids := make([]uint, len(p.Sources))
+	for i, source := range p.Sources {
+		ids[i] = source.(*models.ConfigurationItem).CloudProviderID
+	}
+	return h.loadProviderValues(ctx, ids)
+}
+
+// resolveItemProduct 批量解析ConfigItem.product
+func (h *GraphQLHandler) resolveItemProduct(ctx context.Context, p graphql.ResolveParams) ([]interface{}, error) {
+	ids := make([]uint, len(p.Sources))
+	for i, source := range p.Sources {
+		ids[i] = source.(*models.ConfigurationItem).ProductID
+	}
+	products, err := loadersFrom(ctx).products.LoadMany(ctx, ids)
+	if err != nil {
+		return nil, graphQLError(err)
+	}
+	values := make([]interface{}, len(products))
+	for i, product := range products {
+		values[i] = product
+	}
+	return values, nil
+}
+
+func (h *GraphQLHandler) loadProviderValues(ctx context.Context, ids []uint) ([]interface{}, error) {
+	providers, err := loadersFrom(ctx).providers.LoadMany(ctx, ids)
+	if err != nil {
+		return nil, graphQLError(err)
+	}
+	values := make([]interface{}, len(providers))
+	for i, provider := range providers {
+		values[i] = provider
+	}
+	return values, nil
+}
+
+// provider 查询单个云服务商，不存在时返回null
+func (h *GraphQLHandler) provider(ctx context.Context, p graphql.ResolveParams) (interface{}, error) {
+	id, err := idArg(p, "id")
+	if err != nil {
+		return nil, err
+	}
+	providers, err := loadersFrom(ctx).providers.LoadMany(ctx, []uint{id})
+	if err != nil {
+		return nil, err
+	}
+	return providers[0], nil
+}
+
+// providers 分页查询云服务商
+func (h *GraphQLHandler) providers(ctx context.Context, p graphql.ResolveParams) (interface{}, error) {
+	filter := repository.CloudProviderFilter{
+		Codes:       p.Strings("codes"),
+		Keyword:     keywordArg(p),
+		ListOptions: listOptions(p),
+	}
+	filter.Page, filter.PageSize = pagination(p)
+
+	result, err := h.providerService.ListProvidersPage(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	loaders := loadersFrom(ctx)
+	providers, _ := result.Data.([]models.CloudProvider)
+	for i := range providers {
+		loaders.providers.Prime(providers[i].ID, &providers[i])
+	}
+	return result, nil
+}
+
+// product 查询单个云产品，不存在时返回null
+func (h *GraphQLHandler) product(ctx context.Context, p graphql.ResolveParams) (interface{}, error) {
+	id, err := idArg(p, "id")
+	if err != nil {
+		return nil, err
+	}
+	products, err := loadersFrom(ctx).products.LoadMany(ctx, []uint{id})
+	if err != nil {
+		return nil, err
+	}
+	return products[0], nil
+}
+
+// products 分页查询云产品
+func (h *GraphQLHandler) products(ctx context.Context, p graphql.ResolveParams) (interface{}, error) {
+	providerIDs, err := idListArg(p, "providerId")
+	if err != nil {
+		return nil, err
+	}
+	categoryIDs, err := idListArg(p, "categoryId")
+	if err != nil {
+		return nil, err
+	}
+	filter := repository.CloudProductFilter{
+		CloudProviderIDs: providerIDs,
+		CategoryIDs:      categoryIDs,
+		Codes:            p.Strings("codes"),
+		Keyword:          keywordArg(p),
+		ListOptions:      listOptions(p),
+	}
+	filter.Page, filter.PageSize = pagination(p)
+
+	result, err := h.productService.ListProductsPage(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	loaders := loadersFrom(ctx)
+	products, _ := result.Data.([]models.CloudProduct)
+	for i := range products {
+		loaders.products.Prime(products[i].ID, &products[i])
+	}
+	return result, nil
+}
+
+// configItem 查询单个配置项，不存在时返回null
+func (h *GraphQLHandler) configItem(ctx context.Context, p graphql.ResolveParams) (interface{}, error) {
+	id, err := idArg(p, "id")
+	if err != nil {
+		return nil, err
+	}
+	item, err := h.itemService.GetConfigItemByID(ctx, id)
+	if err != nil {
+		if serviceErr, ok := err.(*service.ServiceError); ok && serviceErr.Code == service.ErrCodeNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return item, nil
+}
+
+// configItems 分页查询配置项
+func (h *GraphQLHandler) configItems(ctx context.Context, p graphql.ResolveParams) (interface{}, error) {
+	filter := repository.ConfigItemFilter{
+		Tags:        p.Strings("tags"),
+		Keyword:     keywordArg(p),
+		ListOptions: listOptions(p),
+	}
+	filter.TagMatch, _ = p.String("tagMatch")
+	filter.Page, filter.PageSize = pagination(p)
+
+	var err error
+	if filter.CloudProviderIDs, err = idListArg(p, "providerId"); err != nil {
+		return nil, err
+	}
+	if filter.ProductIDs, err = idListArg(p, "productId"); err != nil {
+		return nil, err
+	}
+	if filter.CategoryIDs, err = idListArg(p, "categoryId"); err != nil {
+		return nil, err
+	}
+
+	return h.itemService.GetConfigItemsByFilter(ctx, filter)
+}
+
+// createProvider 创建云服务商
+func (h *GraphQLHandler) createProvider(ctx context.Context, p graphql.ResolveParams) (interface{}, error) {
+	var req ProviderRequest
+	if err := bindInput(p, &req); err != nil {
+		return nil, err
+	}
+	provider := req.Model()
+	if err := h.providerService.CreateProvider(ctx, provider); err != nil {
+		logger.Error("Failed to create cloud provider", err)
+		return nil, err
+	}
+	return provider, nil
+}
+
+// updateProvider 整体更新云服务商
+func (h *GraphQLHandler) updateProvider(ctx context.Context, p graphql.ResolveParams) (interface{}, error) {
+	id, err := idArg(p, "id")
+	if err != nil {
+		return nil, err
+	}
+	var req ProviderRequest
+	if err := bindInput(p, &req); err != nil {
+		return nil, err
+	}
+	provider := req.Model()
+	provider.ID = id
+	if err := h.providerService.UpdateProvider(ctx, provider); err != nil {
+		logger.Error("Failed to update cloud provider", err, zap.Uint("id", id))
+		return nil, err
+	}
+	loadersFrom(ctx).providers.Clear(id)
+	return h.providerService.GetProviderByID(ctx, id)
+}
+
+// patchProvider 按合并补丁部分更新云服务商
+func (h *GraphQLHandler) patchProvider(ctx context.Context, p graphql.ResolveParams) (interface{}, error) {
+	id, err := idArg(p, "id")
+	if err != nil {
+		return nil, err
+	}
+	current, err := h.providerService.GetProviderByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	patch, err := bindPatch(p, NewProviderRequest(current), &ProviderRequest{})
+	if err != nil {
+		return nil, err
+	}
+	provider, err := h.providerService.PatchProvider(ctx, id, patch)
+	if err != nil {
+		logger.Error("Failed to patch cloud provider", err, zap.Uint("id", id))
+		return nil, err
+	}
+	loadersFrom(ctx).providers.Clear(id)
+	return provider, nil
+}
+
+// deleteProvider 删除云服务商
+func (h *GraphQLHandler) deleteProvider(ctx context.Context, p graphql.ResolveParams) (interface{}, error) {
+	id, err := idArg(p, "id")
+	if err != nil {
+		return nil, err
+	}
+	if err := h.providerService.DeleteProvider(ctx, id, p.Bool("cascade")); err != nil {
+		logger.Error("Failed to delete cloud provider", err, zap.Uint("id", id))
+		return nil, err
+	}
+	loadersFrom(ctx).providers.Clear(id)
+	return true, nil
+}
+
+// createProduct 创建云产品
+func (h *GraphQLHandler) createProduct(ctx context.Context, p graphql.ResolveParams) (interface{}, error) {
+	var req ProductRequest
+	if err := bindInput(p, &req); err != nil {
+		return nil, err
+	}
+	product := req.Model()
+	if err := h.productService.CreateProduct(ctx, product); err != nil {
+		logger.Error("Failed to create cloud product", err)
+		return nil, err
+	}
+	return product, nil
+}
+
+// updateProduct 整体更新云产品
+func (h *GraphQLHandler) updateProduct(ctx context.Context, p graphql.ResolveParams) (interface{}, error) {
+	id, err := idArg(p, "id")
+	if err != nil {
+		return nil, err
+	}
+	var req ProductRequest
+	if err := bindInput(p, &req); err != nil {
+		return nil, err
+	}
+	product := req.Model()
+	product.ID = id
+	if err := h.productService.UpdateProduct(ctx, product); err != nil {
+		logger.Error("Failed to update cloud product", err, zap.Uint("id", id))
+		return nil, err
+	}
+	loadersFrom(ctx).products.Clear(id)
+	return h.productService.GetProductByID(ctx, id)
+}
+
+// patchProduct 按合并补丁部分更新云产品
+func (h *GraphQLHandler) patchProduct(ctx context.Context, p graphql.ResolveParams) (interface{}, error) {
+	id, err := idArg(p, "id")
+	if err != nil {
+		return nil, err
+	}
+	current, err := h.productService.GetProductByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	patch, err := bindPatch(p, NewProductRequest(current), &ProductRequest{})
+	if err != nil {
+		return nil, err
+	}
+	product, err := h.productService.PatchProduct(ctx, id, patch)
+	if err != nil {
+		logger.Error("Failed to patch cloud product", err, zap.Uint("id", id))
+		return nil, err
+	}
+	loadersFrom(ctx).products.Clear(id)
+	return product, nil
+}
+
+// deleteProduct 删除云产品
+func (h *GraphQLHandler) deleteProduct(ctx context.Context, p graphql.ResolveParams) (interface{}, error) {
+	id, err := idArg(p, "id")
+	if err != nil {
+		return nil, err
+	}
+	if err := h.productService.DeleteProduct(ctx, id); err != nil {
+		logger.Error("Failed to delete cloud product", err, zap.Uint("id", id))
+		return nil, err
+	}
+	loadersFrom(ctx).products.Clear(id)
+	return true, nil
+}
+
+// createConfigItem 创建配置项
+func (h *GraphQLHandler) createConfigItem(ctx context.Context, p graphql.ResolveParams) (interface{}, error) {
+	var req ConfigItemRequest
+	if err := bindInput(p, &req); err != nil {
+		return nil, err
+	}
+	item := req.Model()
+	if err := h.itemService.CreateConfigItem(ctx, item); err != nil {
+		logger.Error("Failed to create configuration item", err)
+		return nil, err
+	}
+	return item, nil
+}
+
+// updateConfigItem 整体更新配置项
+func (h *GraphQLHandler) updateConfigItem(ctx context.Context, p graphql.ResolveParams) (interface{}, error) {
+	id, err := idArg(p, "id")
+	if err != nil {
+		return nil, err
+	}
+	var req ConfigItemRequest
+	if err := bindInput(p, &req); err != nil {
+		return nil, err
+	}
+	item := req.Model()
+	item.ID = id
+	if err := h.itemService.UpdateConfigItem(ctx, item); err != nil {
+		logger.Error("Failed to update configuration item", err, zap.Uint("id", id))
+		return nil, err
+	}
+	return h.itemService.GetConfigItemByID(ctx, id)
+}
+
+// patchConfigItem 按合并补丁部分更新配置项
+func (h *GraphQLHandler) patchConfigItem(ctx context.Context, p graphql.ResolveParams) (interface{}, error) {
+	id, err := idArg(p, "id")
+	if err != nil {
+		return nil, err
+	}
+	current, err := h.itemService.GetConfigItemByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	patch, err := bindPatch(p, NewConfigItemRequest(current), &ConfigItemRequest{})
+	if err != nil {
+		return nil, err
+	}
+	item, err := h.itemService.PatchConfigItem(ctx, id, patch)
+	if err != nil {
+		logger.Error("Failed to patch configuration item", err, zap.Uint("id", id))
+		return nil, err
+	}
+	return item, nil
+}
+
+// deleteConfigItem 删除配置项
+func (h *GraphQLHandler) deleteConfigItem(ctx context.Context, p graphql.ResolveParams) (interface{}, error) {
+	id, err := idArg(p, "id")
+	if err != nil {
+		return nil, err
+	}
+	if err := h.itemService.DeleteConfigItem(ctx, id); err != nil {
+		logger.Error("Failed to delete configuration item", err, zap.Uint("id", id))
+		return nil, err
+	}
+	return true, nil
+}
+
+// bindInput 将input参数转换为请求DTO并按DTO的规则校验
+func bindInput(p graphql.ResolveParams, dst interface{}) error {
+	input, _ := p.Args["input"].(map[string]interface{})
+	data, err := json.Marshal(snakeCaseKeys(input))
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(data, dst)
+	if err == nil {
+		err = binding.Validator.ValidateStruct(dst)
+	}
+	if fields := fieldErrors(err); len(fields) > 0 {
+		return validationError(fields)
+	}
+	if err != nil {
+		return graphql.NewError(graphql.CodeBadUserInput, "%s", err.Error())
+	}
+	return nil
+}
+
+// bindPatch 将patch参数转换为REST接口的合并补丁，并校验应用补丁后的结果
+func bindPatch(p graphql.ResolveParams, current, dst interface{}) ([]byte, error) {
+	patch, ok := p.Args["patch"].(map[string]interface{})
+	if !ok {
+		return nil, graphql.NewError(graphql.CodeBadUserInput, "patch必须是JSON对象")
+	}
+	data, err := json.Marshal(snakeCaseKeys(patch))
+	if err != nil {
+		return nil, err
+	}
+	if fields := MergePatchErrors(current, data, dst); len(fields) > 0 {
+		return nil, validationError(fields)
+	}
+	return data, nil
+}
+
+// snakeCaseKeys 将输入对象的camelCase字段名转换为请求DTO使用的snake_case，
+// 以_id结尾的字段中的ID字符串转换为数字
+func snakeCaseKeys(input map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(input))
+	for key, value := range input {
+		key = snakeCase(key)
+		if s, ok := value.(string); ok && strings.HasSuffix(key, "_id") {
+			if id, err := strconv.ParseUint(s, 10, 64); err == nil {
+				value = id
+			}
+		}
+		result[key] = value
+	}
+	return result
+}
+
+func snakeCase(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if r >= 'A' && r <= 'Z' {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func camelCase(s string) string {
+	parts := strings.Split(s, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
@@ -0,0 +1,257 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cloud-eye/internal/api/handler"
+	"github.com/yourusername/cloud-eye/internal/models"
+	"github.com/yourusername/cloud-eye/internal/repository"
+	"github.com/yourusername/cloud-eye/internal/service"
+)
+
+// serviceCalls 记录GraphQL处理器对服务层列表方法的调用次数
+type serviceCalls struct {
+	mu    sync.Mutex
+	count map[string]int
+}
+
+func (c *serviceCalls) add(method string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.count[method]++
+}
+
+type countingProviderService struct {
+	service.CloudProviderService
+	calls *serviceCalls
+}
+
+func (s countingProviderService) ListProviders(ctx context.Context, filter repository.CloudProviderFilter) ([]models.CloudProvider, error) {
+	s.calls.add("ListProviders")
+	return s.CloudProviderService.ListProviders(ctx, filter)
+}
+
+func (s countingProviderService) ListProvidersPage(ctx context.Context, filter repository.CloudProviderFilter) (*repository.PageResult, error) {
+	s.calls.add("ListProvidersPage")
+	return s.CloudProviderService.ListProvidersPage(ctx, filter)
+}
+
+type countingProductService struct {
+	service.CloudProductService
+	calls *serviceCalls
+}
+
+func (s countingProductService) ListProducts(ctx context.Context, filter repository.CloudProductFilter) ([]models.CloudProduct, error) {
+	s.calls.add("ListProducts")
+	return s.CloudProductService.ListProducts(ctx, filter)
+}
+
+type countingItemService struct {
+	service.ConfigurationItemService
+	calls *serviceCalls
+}
+
+func (s countingItemService) GetConfigItemsByFilter(ctx context.Context, filter repository.ConfigItemFilter) (*repository.PageResult, error) {
+	s.calls.add("GetConfigItemsByFilter")
+	return s.ConfigurationItemService.GetConfigItemsByFilter(ctx, filter)
+}
+
+// newGraphQLRouter 基于演示数据创建GraphQL路由，返回服务层调用计数
+func newGraphQLRouter(t *testing.T) (*gin.Engine, *serviceCalls) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	store := repository.NewMemoryStore()
+	if err := repository.SeedDemoData(context.Background(), store); err != nil {
+		t.Fatalf("写入演示数据失败: %v", err)
+	}
+	providerRepo := repository.NewMemoryCloudProviderRepository(store)
+	productRepo := repository.NewMemoryCloudProductRepository(store)
+	calls := &serviceCalls{count: make(map[string]int)}
+	h := handler.NewGraphQLHandler(
+		countingProviderService{service.NewCloudProviderService(providerRepo, nil), calls},
+		countingProductService{service.NewCloudProductService(productRepo, providerRepo, repository.NewMemoryProductCategoryRepository(store), nil), calls},
+		countingItemService{service.NewConfigurationItemService(repository.NewMemoryConfigurationItemRepository(store), providerRepo, productRepo, nil), calls},
+	)
+	r := gin.New()
+	r.POST("/graphql", h.Query)
+	return r, calls
+}
+
+// postGraphQL 发送GraphQL查询
+func postGraphQL(r *gin.Engine, query string) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(map[string]string{"query": query})
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body)))
+	return w
+}
+
+func TestGraphQLBatchesNestedAssociations(t *testing.T) {
+	r, calls := newGraphQLRouter(t)
+
+	// 5个云服务商、15个云产品、13个配置项，逐个解析关联需要几十次查询
+	w := postGraphQL(r, `{
+		providers(pageSize: 5) {
+			nodes {
+				code
+				products {
+					code
+					provider { code }
+					configItems {
+						name
+						product { code provider { code } }
+					}
+				}
+			}
+		}
+	}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("查询返回%d: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Data struct {
+			Providers struct {
+				Nodes []struct {
+					Code     string
+					Products []struct {
+						Code        string
+						Provider    struct{ Code string }
+						ConfigItems []struct {
+							Name    string
+							Product struct {
+								Code     string
+								Provider struct{ Code string }
+							}
+						}
+					}
+				}
+			}
+		}
+		Errors []interface{}
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || len(resp.Errors) > 0 {
+		t.Fatalf("解析响应失败: %v %s", err, w.Body.String())
+	}
+	var products, items int
+	for _, provider := range resp.Data.Providers.Nodes {
+		for _, product := range provider.Products {
+			products++
+			if product.Provider.Code != provider.Code {
+				t.Errorf("云产品%s的云服务商为%s，期望%s", product.Code, product.Provider.Code, provider.Code)
+			}
+			for _, item := range product.ConfigItems {
+				items++
+				if item.Product.Code != product.Code || item.Product.Provider.Code != provider.Code {
+					t.Errorf("配置项%s的关联为%+v", item.Name, item.Product)
+				}
+			}
+		}
+	}
+	if len(resp.Data.Providers.Nodes) != 5 || products != 15 || items != 13 {
+		t.Fatalf("返回%d个云服务商、%d个云产品、%d个配置项", len(resp.Data.Providers.Nodes), products, items)
+	}
+
+	// 每一层关联只查询一次：云产品按云服务商批量加载一次、配置项的云产品按ID批量加载一次，
+	// 云服务商已由分页查询缓存，不再查询
+	want := map[string]int{
+		"ListProvidersPage":      1,
+		"ListProducts":           2,
+		"GetConfigItemsByFilter": 1,
+	}
+	if !reflect.DeepEqual(calls.count, want) {
+		t.Fatalf("服务层调用为%v，期望%v", calls.count, want)
+	}
+}
+
+func TestGraphQLLimitsNestedLists(t *testing.T) {
+	type response struct {
+		Data struct {
+			Providers struct {
+				Nodes []struct {
+					Code     string
+					Products []struct {
+						Code        string
+						ConfigItems []struct{ Name string }
+					}
+				}
+			}
+		}
+		Errors []struct {
+			Message    string
+			Extensions map[string]interface{}
+		}
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		products int // 每个云服务商返回的云产品数
+		items    int // 所有云产品返回的配置项总数
+		calls    map[string]int
+	}{
+		// 演示数据中每个云服务商有3个云产品，每个服务商只取前1个，仍合并为一次查询
+		{"按父对象限制数量", `{ providers(pageSize: 5) { nodes { code products(first: 1) { code } } } }`, 1, 0,
+			map[string]int{"ListProvidersPage": 1, "ListProducts": 1}},
+		{"云产品超过最大值时按最大值", `{ providers(pageSize: 5) { nodes { code products(first: 1000) { code } } } }`, 3, 0,
+			map[string]int{"ListProvidersPage": 1, "ListProducts": 1}},
+		{"配置项超过最大值时按最大值", `{ providers(pageSize: 5) { nodes { code products(first: 10) { code configItems(first: 1000) { name } } } } }`, 3, 13,
+			map[string]int{"ListProvidersPage": 1, "ListProducts": 1, "GetConfigItemsByFilter": 1}},
+		{"first为0时不查询", `{ providers(pageSize: 5) { nodes { code products(first: 0) { code } } } }`, 0, 0,
+			map[string]int{"ListProvidersPage": 1}},
+		{"过滤条件在查询中执行", `{ providers(pageSize: 5) { nodes { code products(keyword: "EC2") { code configItems(severity: ["high", "critical"]) { name } } } } }`, -1, -1,
+			map[string]int{"ListProvidersPage": 1, "ListProducts": 1, "GetConfigItemsByFilter": 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, calls := newGraphQLRouter(t)
+			w := postGraphQL(r, tt.query)
+			var resp response
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || w.Code != http.StatusOK || len(resp.Errors) > 0 {
+				t.Fatalf("查询返回%d: %s", w.Code, w.Body.String())
+			}
+			items := 0
+			for _, provider := range resp.Data.Providers.Nodes {
+				if tt.products >= 0 && len(provider.Products) != tt.products {
+					t.Errorf("云服务商%s返回%d个云产品，期望%d", provider.Code, len(provider.Products), tt.products)
+				}
+				for _, p := range provider.Products {
+					if tt.products < 0 && p.Code != "EC2" {
+						t.Errorf("关键字过滤返回了云产品%s", p.Code)
+					}
+					items += len(p.ConfigItems)
+				}
+			}
+			if tt.items >= 0 && items != tt.items {
+				t.Errorf("返回%d个配置项，期望%d", items, tt.items)
+			}
+			if !reflect.DeepEqual(calls.count, tt.calls) {
+				t.Errorf("服务层调用为%v，期望%v", calls.count, tt.calls)
+			}
+		})
+	}
+
+	t.Run("超过节点数上限", func(t *testing.T) {
+		r, calls := newGraphQLRouter(t)
+		// 100个云服务商 × 100个云产品 × 100个配置项
+		w := postGraphQL(r, `{ providers(pageSize: 100) { nodes { products(first: 100) { configItems(first: 100) { name } } } } }`)
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("查询返回%d，期望400: %s", w.Code, w.Body.String())
+		}
+		var resp response
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || len(resp.Errors) != 1 ||
+			resp.Errors[0].Extensions["code"] != "GRAPHQL_VALIDATION_FAILED" {
+			t.Fatalf("响应为%s", w.Body.String())
+		}
+		if len(calls.count) > 0 {
+			t.Fatalf("超过上限时仍调用了服务层: %v", calls.count)
+		}
+	})
+}
@@ -0,0 +1,370 @@
+package handler
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/yourusername/cloud-eye/internal/models"
+	"github.com/yourusername/cloud-eye/internal/pkg/graphql"
+	"github.com/yourusername/cloud-eye/internal/repository"
+)
+
+// graphQLInclude GraphQL查询加载的关联，云服务商、云产品等关联通过数据加载器批量获取
+var graphQLInclude = []string{"tags"}
+
+// buildSchema 定义云服务商 → 云产品 → 配置项的GraphQL Schema
+func (h *GraphQLHandler) buildSchema() *graphql.Schema {
+	tag := graphql.NewObject("Tag", "标签").
+		AddField(prop("id", "ID!", "", func(t *models.Tag) interface{} { return formatGraphQLID(t.ID) })).
+		AddField(prop("name", "String!", "", func(t *models.Tag) interface{} { return t.Name }))
+
+	pageInfo := graphql.NewObject("PageInfo", "分页信息，游标分页时total为-1、page为0").
+		AddField(prop("total", "Int!", "总记录数", func(p *repository.PageResult) interface{} { return int(p.Total) })).
+		AddField(prop("page", "Int!", "当前页码", func(p *repository.PageResult) interface{} { return p.Page })).
+		AddField(prop("pageSize", "Int!", "每页大小", func(p *repository.PageResult) interface{} { return p.PageSize })).
+		AddField(prop("nextCursor", "String", "下一页游标", func(p *repository.PageResult) interface{} { return optionalString(p.NextCursor) })).
+		AddField(prop("prevCursor", "String", "上一页游标", func(p *repository.PageResult) interface{} { return optionalString(p.PrevCursor) }))
+
+	provider := graphql.NewObject("Provider", "云服务商")
+	product := graphql.NewObject("Product", "云产品")
+	item := graphql.NewObject("ConfigItem", "安全配置基线项")
+
+	provider.
+		AddField(prop("id", "ID!", "", func(p *models.CloudProvider) interface{} { return formatGraphQLID(p.ID) })).
+		AddField(prop("name", "String!", "", func(p *models.CloudProvider) interface{} { return p.Name })).
+		AddField(prop("code", "String!", "", func(p *models.CloudProvider) interface{} { return p.Code })).
+		AddField(prop("description", "String!", "", func(p *models.CloudProvider) interface{} { return p.Description })).
+		AddField(prop("createdAt", "Time!", "", func(p *models.CloudProvider) interface{} { return p.CreatedAt })).
+		AddField(prop("updatedAt", "Time!", "", func(p *models.CloudProvider) interface{} { return p.UpdatedAt })).
+		AddField(prop("tags", "[Tag!]!", "", func(p *models.CloudProvider) interface{} { return p.Tags })).
+		AddField(&graphql.FieldDef{
+			Name:        "products",
+			Type:        "[Product!]!",
+			Description: "云服务商的云产品",
+			Args: []*graphql.ArgDef{
+				{Name: "keyword", Type: "String", Description: "在名称、代码和描述中匹配"},
+				firstArgDef(),
+			},
+			Resolve:  h.resolveProviderProducts,
+			ListSize: firstListSize,
+		})
+
+	product.
+		AddField(prop("id", "ID!", "", func(p *models.CloudProduct) interface{} { return formatGraphQLID(p.ID) })).
+		AddField(prop("providerId", "ID!", "", func(p *models.CloudProduct) interface{} { return formatGraphQLID(p.CloudProviderID) })).
+		AddField(prop("name", "String!", "", func(p *models.CloudProduct) interface{} { return p.Name })).
+		AddField(prop("code", "String!", "", func(p *models.CloudProduct) interface{} { return p.Code })).
+		AddField(prop("description", "String!", "", func(p *models.CloudProduct) interface{} { return p.Description })).
+		AddField(prop("categoryId", "ID", "产品类别", func(p *models.CloudProduct) interface{} { return formatOptionalGraphQLID(p.CategoryID) })).
+		AddField(prop("createdAt", "Time!", "", func(p *models.CloudProduct) interface{} { return p.CreatedAt })).
+		AddField(prop("updatedAt", "Time!", "", func(p *models.CloudProduct) interface{} { return p.UpdatedAt })).
+		AddField(prop("tags", "[Tag!]!", "", func(p *models.CloudProduct) interface{} { return p.Tags })).
+		AddField(&graphql.FieldDef{Name: "provider", Type: "Provider!", Description: "所属云服务商", Resolve: h.resolveProductProvider}).
+		AddField(&graphql.FieldDef{
+			Name:        "configItems",
+			Type:        "[ConfigItem!]!",
+			Description: "云产品的配置项",
+			Args: []*graphql.ArgDef{
+				{Name: "severity", Type: "[String!]", Description: "风险等级，任一匹配即可"},
+				{Name: "status", Type: "[String!]", Description: "状态，任一匹配即可"},
+				{Name: "keyword", Type: "String", Description: "在名称、推荐值和风险描述中匹配"},
+				firstArgDef(),
+			},
+			Resolve:  h.resolveProductConfigItems,
+			ListSize: firstListSize,
+		})
+
+	item.
+		AddField(prop("id", "ID!", "", func(i *models.ConfigurationItem) interface{} { return formatGraphQLID(i.ID) })).
+		AddField(prop("providerId", "ID!", "", func(i *models.ConfigurationItem) interface{} { return formatGraphQLID(i.CloudProviderID) })).
+		AddField(prop("productId", "ID!", "", func(i *models.ConfigurationItem) interface{} { return formatGraphQLID(i.ProductID) })).
+		AddField(prop("name", "String!", "", func(i *models.ConfigurationItem) interface{} { return i.Name })).
+		AddField(prop("recommendedValue", "String!", "", func(i *models.ConfigurationItem) interface{} { return i.RecommendedValue })).
+		AddField(prop("riskDescription", "String!", "", func(i *models.ConfigurationItem) interface{} { return i.RiskDescription })).
+		AddField(prop("checkMethod", "String!", "", func(i *models.ConfigurationItem) interface{} { return i.CheckMethod })).
+		AddField(prop("configurationMethod", "String!", "", func(i *models.ConfigurationItem) interface{} { return i.ConfigurationMethod })).
+		AddField(prop("reference", "String!", "", func(i *models.ConfigurationItem) interface{} { return i.Reference })).
+		AddField(prop("severity", "String!", "critical、high、medium、low或info", func(i *models.ConfigurationItem) interface{} { return i.Severity })).
+		AddField(prop("status", "String!", "draft、active或deprecated", func(i *models.ConfigurationItem) interface{} { return i.Status })).
+		AddField(prop("controlFamilyId", "ID", "所属控制族", func(i *models.ConfigurationItem) interface{} { return formatOptionalGraphQLID(i.ControlFamilyID) })).
+		AddField(prop("createdAt", "Time!", "", func(i *models.ConfigurationItem) interface{} { return i.CreatedAt })).
+		AddField(prop("updatedAt", "Time!", "", func(i *models.ConfigurationItem) interface{} { return i.UpdatedAt })).
+		AddField(prop("tags", "[Tag!]!", "", func(i *models.ConfigurationItem) interface{} { return i.Tags })).
+		AddField(&graphql.FieldDef{Name: "provider", Type: "Provider!", Description: "所属云服务商", Resolve: h.resolveItemProvider}).
+		AddField(&graphql.FieldDef{Name: "product", Type: "Product!", Description: "所属云产品", Resolve: h.resolveItemProduct})
+
+	query := graphql.NewObject("Query", "").
+		AddField(&graphql.FieldDef{
+			Name: "provider", Type: "Provider", Description: "按ID获取云服务商",
+			Args:    []*graphql.ArgDef{{Name: "id", Type: "ID!"}},
+			Resolve: root(h.provider),
+		}).
+		AddField(&graphql.FieldDef{
+			Name: "providers", Type: "ProviderPage!", Description: "分页获取云服务商",
+			Args: append([]*graphql.ArgDef{
+				{Name: "codes", Type: "[String!]", Description: "服务商代码，任一匹配即可"},
+			}, listArgs()...),
+			Resolve:  root(h.providers),
+			ListSize: pageListSize,
+		}).
+		AddField(&graphql.FieldDef{
+			Name: "product", Type: "Product", Description: "按ID获取云产品",
+			Args:    []*graphql.ArgDef{{Name: "id", Type: "ID!"}},
+			Resolve: root(h.product),
+		}).
+		AddField(&graphql.FieldDef{
+			Name: "products", Type: "ProductPage!", Description: "分页获取云产品",
+			Args: append([]*graphql.ArgDef{
+				{Name: "providerId", Type: "[ID!]", Description: "云服务商，任一匹配即可"},
+				{Name: "categoryId", Type: "[ID!]", Description: "产品类别，包含子类别"},
+				{Name: "codes", Type: "[String!]", Description: "产品代码，任一匹配即可"},
+			}, listArgs()...),
+			Resolve:  root(h.products),
+			ListSize: pageListSize,
+		}).
+		AddField(&graphql.FieldDef{
+			Name: "configItem", Type: "ConfigItem", Description: "按ID获取配置项",
+			Args:    []*graphql.ArgDef{{Name: "id", Type: "ID!"}},
+			Resolve: root(h.configItem),
+		}).
+		AddField(&graphql.FieldDef{
+			Name: "configItems", Type: "ConfigItemPage!", Description: "分页获取配置项",
+			Args: append([]*graphql.ArgDef{
+				{Name: "providerId", Type: "[ID!]", Description: "云服务商，任一匹配即可"},
+				{Name: "productId", Type: "[ID!]", Description: "云产品，任一匹配即可"},
+				{Name: "categoryId", Type: "[ID!]", Description: "产品类别，包含子类别"},
+				{Name: "tags", Type: "[String!]", Description: "标签名称"},
+				{Name: "tagMatch", Type: "String", Description: "多个标签的匹配方式：or（默认）或and"},
+			}, listArgs()...),
+			Resolve:  root(h.configItems),
+			ListSize: pageListSize,
+		})
+
+	mutation := graphql.NewObject("Mutation", "")
+	for _, f := range h.mutations() {
+		mutation.AddField(f)
+	}
+
+	return graphql.NewSchema(graphql.SchemaConfig{
+		Query:    query,
+		Mutation: mutation,
+		Types: []*graphql.Object{
+			provider, product, item, tag, pageInfo,
+			pageType("ProviderPage", "Provider"),
+			pageType("ProductPage", "Product"),
+			pageType("ConfigItemPage", "ConfigItem"),
+		},
+		Inputs: []*graphql.InputObject{
+			{Name: "ProviderInput", Description: "创建或整体更新云服务商", Fields: []*graphql.ArgDef{
+				{Name: "name", Type: "String!"},
+				{Name: "code", Type: "String!"},
+				{Name: "description", Type: "String"},
+			}},
+			{Name: "ProductInput", Description: "创建或整体更新云产品", Fields: []*graphql.ArgDef{
+				{Name: "cloudProviderId", Type: "ID!"},
+				{Name: "name", Type: "String!"},
+				{Name: "code", Type: "String!"},
+				{Name: "description", Type: "String"},
+				{Name: "categoryId", Type: "ID"},
+			}},
+			{Name: "ConfigItemInput", Description: "创建或整体更新配置项", Fields: []*graphql.ArgDef{
+				{Name: "cloudProviderId", Type: "ID!"},
+				{Name: "productId", Type: "ID!"},
+				{Name: "name", Type: "String!"},
+				{Name: "recommendedValue", Type: "String!"},
+				{Name: "riskDescription", Type: "String"},
+				{Name: "checkMethod", Type: "String"},
+				{Name: "configurationMethod", Type: "String"},
+				{Name: "reference", Type: "String"},
+				{Name: "severity", Type: "String"},
+				{Name: "status", Type: "String"},
+			}},
+		},
+	})
+}
+
+// mutations 与REST接口对应的变更操作
+func (h *GraphQLHandler) mutations() []*graphql.FieldDef {
+	id := &graphql.ArgDef{Name: "id", Type: "ID!"}
+	patch := &graphql.ArgDef{Name: "patch", Type: "JSON!", Description: "JSON合并补丁，字段名可使用camelCase或snake_case"}
+	return []*graphql.FieldDef{
+		{Name: "createProvider", Type: "Provider!", Description: "创建云服务商",
+			Args: []*graphql.ArgDef{{Name: "input", Type: "ProviderInput!"}}, Resolve: root(h.createProvider)},
+		{Name: "updateProvider", Type: "Provider!", Description: "整体更新云服务商，未提供的字段将被清空",
+			Args: []*graphql.ArgDef{id, {Name: "input", Type: "ProviderInput!"}}, Resolve: root(h.updateProvider)},
+		{Name: "patchProvider", Type: "Provider!", Description: "部分更新云服务商",
+			Args: []*graphql.ArgDef{id, patch}, Resolve: root(h.patchProvider)},
+		{Name: "deleteProvider", Type: "Boolean!", Description: "删除云服务商，cascade为true时同时删除其云产品和配置项",
+			Args: []*graphql.ArgDef{id, {Name: "cascade", Type: "Boolean", Default: false}}, Resolve: root(h.deleteProvider)},
+		{Name: "createProduct", Type: "Product!", Description: "创建云产品",
+			Args: []*graphql.ArgDef{{Name: "input", Type: "ProductInput!"}}, Resolve: root(h.createProduct)},
+		{Name: "updateProduct", Type: "Product!", Description: "整体更新云产品，未提供的字段将被清空",
+			Args: []*graphql.ArgDef{id, {Name: "input", Type: "ProductInput!"}}, Resolve: root(h.updateProduct)},
+		{Name: "patchProduct", Type: "Product!", Description: "部分更新云产品",
+			Args: []*graphql.ArgDef{id, patch}, Resolve: root(h.patchProduct)},
+		{Name: "deleteProduct", Type: "Boolean!", Description: "删除云产品",
+			Args: []*graphql.ArgDef{id}, Resolve: root(h.deleteProduct)},
+		{Name: "createConfigItem", Type: "ConfigItem!", Description: "创建配置项",
+			Args: []*graphql.ArgDef{{Name: "input", Type: "ConfigItemInput!"}}, Resolve: root(h.createConfigItem)},
+		{Name: "updateConfigItem", Type: "ConfigItem!", Description: "整体更新配置项，未提供的字段将被清空",
+			Args: []*graphql.ArgDef{id, {Name: "input", Type: "ConfigItemInput!"}}, Resolve: root(h.updateConfigItem)},
+		{Name: "patchConfigItem", Type: "ConfigItem!", Description: "部分更新配置项",
+			Args: []*graphql.ArgDef{id, patch}, Resolve: root(h.patchConfigItem)},
+		{Name: "deleteConfigItem", Type: "Boolean!", Description: "删除配置项（移入回收站）",
+			Args: []*graphql.ArgDef{id}, Resolve: root(h.deleteConfigItem)},
+	}
+}
+
+// listArgs 列表查询的通用参数
+func listArgs() []*graphql.ArgDef {
+	return []*graphql.ArgDef{
+		{Name: "keyword", Type: "String", Description: "关键字"},
+		{Name: "sort", Type: "[String!]", Description: "排序字段，前缀-表示降序"},
+		{Name: "page", Type: "Int", Description: "页码，默认1"},
+		{Name: "pageSize", Type: "Int", Description: "每页大小，默认10，最大100"},
+		{Name: "cursor", Type: "String", Description: "游标分页位置，传空字符串表示第一页，此时忽略page"},
+	}
+}
+
+// 嵌套列表first参数的默认值和最大值
+const (
+	defaultFirst = 20
+	maxFirst     = 100
+)
+
+// firstArgDef 嵌套列表的first参数
+func firstArgDef() *graphql.ArgDef {
+	return &graphql.ArgDef{Name: "first", Type: "Int", Default: defaultFirst,
+		Description: "每个父对象最多返回的数量，默认20，最大100"}
+}
+
+// pageType 分页结果类型，来源为repository.PageResult
+func pageType(name, node string) *graphql.Object {
+	return graphql.NewObject(name, "").
+		AddField(&graphql.FieldDef{Name: "pageInfo", Type: "PageInfo!",
+			Resolve: graphql.Property(func(s interface{}) interface{} { return s })}).
+		AddField(prop("nodes", "["+node+"!]!", "", func(p *repository.PageResult) interface{} { return p.Data }))
+}
+
+// prop 读取模型属性的字段
+func prop[T any](name, typ, description string, fn func(*T) interface{}) *graphql.FieldDef {
+	return &graphql.FieldDef{
+		Name:        name,
+		Type:        typ,
+		Description: description,
+		Resolve: graphql.Property(func(source interface{}) interface{} {
+			return fn(source.(*T))
+		}),
+	}
+}
+
+// root 顶层字段的解析函数，顶层只有一个父对象
+func root(fn func(ctx context.Context, p graphql.ResolveParams) (interface{}, error)) graphql.ResolveFunc {
+	return func(ctx context.Context, p graphql.ResolveParams) ([]interface{}, error) {
+		v, err := fn(ctx, p)
+		if err != nil {
+			return nil, graphQLError(err)
+		}
+		return []interface{}{v}, nil
+	}
+}
+
+// listOptions 由通用列表参数生成查询选项
+func listOptions(p graphql.ResolveParams) repository.ListOptions {
+	opts := repository.ListOptions{
+		Sort:    ParseSortFields(p.Strings("sort")),
+		Include: graphQLInclude,
+	}
+	if cursor, ok := p.String("cursor"); ok {
+		opts.Cursor = &cursor
+	}
+	return opts
+}
+
+// pagination 读取页码参数，默认第1页、每页10条，每页最多100条
+func pagination(p graphql.ResolveParams) (page, pageSize int) {
+	page, pageSize = 1, 10
+	if n, ok := p.Int("page"); ok && n > 0 {
+		page = n
+	}
+	if n, ok := p.Int("pageSize"); ok && n > 0 {
+		pageSize = n
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+	return page, pageSize
+}
+
+// keywordArg 读取关键字参数，未提供时返回nil
+func keywordArg(p graphql.ResolveParams) *string {
+	if keyword, ok := p.String("keyword"); ok && keyword != "" {
+		return &keyword
+	}
+	return nil
+}
+
+// idArg 读取ID参数
+func idArg(p graphql.ResolveParams, name string) (uint, error) {
+	s, _ := p.String(name)
+	return parseGraphQLID(name, s)
+}
+
+// idListArg 读取ID列表参数
+func idListArg(p graphql.ResolveParams, name string) ([]uint, error) {
+	var ids []uint
+	for _, s := range p.Strings(name) {
+		id, err := parseGraphQLID(name, s)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func parseGraphQLID(name, s string) (uint, error) {
+	id, err := strconv.ParseUint(s, 10, 64)
+	if err != nil || id == 0 {
+		return 0, graphql.NewError(graphql.CodeBadUserInput, "%s中的ID %q 无效", name, s)
+	}
+	return uint(id), nil
+}
+
+func formatGraphQLID(id uint) string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+func formatOptionalGraphQLID(id *uint) interface{} {
+	if id == nil {
+		return nil
+	}
+	return formatGraphQLID(*id)
+}
+
+func optionalString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// firstArg 读取first参数，未提供或小于0时使用默认值，最大100
+func firstArg(p graphql.ResolveParams) int {
+	first := defaultFirst
+	if n, ok := p.Int("first"); ok && n >= 0 {
+		first = n
+	}
+	return min(first, maxFirst)
+}
+
+// firstListSize 嵌套列表每个父对象最多返回的数量，用于估算查询的节点数
+func firstListSize(args map[string]interface{}) int {
+	return firstArg(graphql.ResolveParams{Args: args})
+}
+
+// pageListSize 分页字段每页最多返回的数量，用于估算查询的节点数
+func pageListSize(args map[string]interface{}) int {
+	_, pageSize := pagination(graphql.ResolveParams{Args: args})
+	return pageSize
+}
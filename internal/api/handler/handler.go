@@ -11,9 +11,10 @@ import (
 
 // Response 通用响应结构
 type Response struct {
-	Code    int         `json:"code"`              // 状态码，0表示成功
-	Message string      `json:"message"`           // 消息
-	Data    interface{} `json:"data,omitempty"`    // 响应数据
+	Code    int          `json:"code"`             // 状态码，0表示成功
+	Message string       `json:"message"`          // 消息
+	Data    interface{}  `json:"data,omitempty"`   // 响应数据
+	Errors  []FieldError `json:"errors,omitempty"` // 字段级校验错误，仅请求参数校验失败时返回
 }
 
 // BaseHandler 基础处理器
@@ -55,8 +56,10 @@ func (h *BaseHandler) HandleServiceError(c *gin.Context, err error) {
 		h.Error(c, http.StatusNotFound, 4004, serviceErr.Message)
 	case service.ErrCodeInvalidData:
 		h.Error(c, http.StatusBadRequest, 4000, serviceErr.Message)
-	case service.ErrCodeDuplicate:
+	case service.ErrCodeDuplicate, service.ErrCodeConflict:
 		h.Error(c, http.StatusConflict, 4009, serviceErr.Message)
+	case service.ErrCodeForbidden:
+		h.Error(c, http.StatusForbidden, 4003, serviceErr.Message)
 	default:
 		h.Error(c, http.StatusInternalServerError, 5000, serviceErr.Message)
 	}
@@ -108,12 +111,53 @@ func (h *BaseHandler) GetUintQueryParam(c *gin.Context, paramName string) (uint,
 	return uint(value), true
 }
 
+// GetBoolQueryParam 获取布尔查询参数，支持true/false/1/0等写法
+func (h *BaseHandler) GetBoolQueryParam(c *gin.Context, paramName string, defaultValue bool) bool {
+	valueStr := c.Query(paramName)
+	if valueStr == "" {
+		return defaultValue
+	}
+
+	value, err := strconv.ParseBool(valueStr)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
 // BindJSON 绑定JSON请求体
 func (h *BaseHandler) BindJSON(c *gin.Context, obj interface{}) bool {
 	if err := c.ShouldBindJSON(obj); err != nil {
-		h.Error(c, http.StatusBadRequest, 4000, "无效的请求参数: "+err.Error())
 		logger.Error("Invalid request body", err)
+		if fields := fieldErrors(err); len(fields) > 0 {
+			c.JSON(http.StatusBadRequest, Response{
+				Code:    4000,
+				Message: "请求参数校验失败",
+				Errors:  fields,
+			})
+			return false
+		}
+		h.Error(c, http.StatusBadRequest, 4000, "无效的请求参数: "+err.Error())
 		return false
 	}
 	return true
-}
\ No newline at end of file
+}
+
+// MergePatchContentType JSON合并补丁（RFC 7396）的媒体类型
+const MergePatchContentType = "application/merge-patch+json"
+
+// BindMergePatch 读取JSON合并补丁请求体，支持application/merge-patch+json和application/json
+func (h *BaseHandler) BindMergePatch(c *gin.Context) ([]byte, bool) {
+	contentType := c.ContentType()
+	if contentType != MergePatchContentType && contentType != "application/json" {
+		h.Error(c, http.StatusUnsupportedMediaType, 4015, "不支持的Content-Type，请使用"+MergePatchContentType)
+		return nil, false
+	}
+
+	patch, err := c.GetRawData()
+	if err != nil || len(patch) == 0 {
+		h.Error(c, http.StatusBadRequest, 4000, "无效的请求参数: 请求体不能为空")
+		return nil, false
+	}
+	return patch, true
+}
@@ -0,0 +1,290 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cloud-eye/internal/models"
+	"github.com/yourusername/cloud-eye/internal/pkg/excel"
+	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+	"github.com/yourusername/cloud-eye/internal/repository"
+	"github.com/yourusername/cloud-eye/internal/service"
+	"go.uber.org/zap"
+)
+
+// JobHandler 后台任务API处理器
+type JobHandler struct {
+	BaseHandler
+	service  service.JobService
+	files    service.FileService
+	importer *excel.ConfigItemImporter
+}
+
+// NewJobHandler 创建后台任务处理器
+func NewJobHandler(service service.JobService, files service.FileService) *JobHandler {
+	return &JobHandler{
+		service:  service,
+		files:    files,
+		importer: excel.NewConfigItemImporter(),
+	}
+}
+
+// SubmitImport 提交导入任务
+// @Summary 提交导入任务
+// @Description 上传Excel文件并在后台导入配置项，立即返回任务；跳过的行在任务的warnings中说明。文件大小、解压后大小、工作表数和行数受配置限制
+// @Tags 后台任务
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "Excel文件"
+// @Success 202 {object} Response{data=JobResponse} "任务已提交"
+// @Failure 400 {object} Response "无效的文件"
+// @Failure 413 {object} Response "文件超过大小限制"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/jobs/imports [post]
+func (h *JobHandler) SubmitImport(c *gin.Context) {
+	filePath, name, ok := h.receiveExcelUpload(c, h.importer)
+	if !ok {
+		return
+	}
+
+	// 上传文件保存在文件存储中，多实例部署时任何实例都能执行该任务
+	stored, err := h.files.SaveLocal(c, models.FileKindImport, filePath, name)
+	if err != nil {
+		logger.Error("Failed to save uploaded file", err)
+		if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+			logger.Error("Failed to remove uploaded file", err, zap.String("path", filePath))
+		}
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	job, err := h.service.SubmitImport(c, stored.ID, name)
+	if err != nil {
+		logger.Error("Failed to submit import job", err)
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.accepted(c, job)
+}
+
+// SubmitExport 提交导出任务
+// @Summary 提交导出任务
+// @Description 在后台导出全部符合条件的配置项到Excel，不受同步导出接口的数量限制；任务成功后通过结果接口下载
+// @Tags 后台任务
+// @Produce json
+// @Param cloud_provider_id query []int false "云服务商ID，可传多个" collectionFormat(csv)
+// @Param product_id query []int false "产品ID，可传多个" collectionFormat(csv)
+// @Param category_id query []int false "产品类别ID，可传多个，包含子类别" collectionFormat(csv)
+// @Param tag query []string false "标签名称，可传多个" collectionFormat(csv)
+// @Param tag_match query string false "多个标签的匹配方式：or包含任一标签（默认），and包含全部标签"
+// @Param keyword query string false "关键词搜索"
+// @Param sort query string false "排序字段，逗号分隔，前缀-表示降序"
+// @Param group_by query string false "分组维度：category按产品类别分组排列，未分类的排在最后"
+// @Success 202 {object} Response{data=JobResponse} "任务已提交"
+// @Failure 400 {object} Response "无效的请求参数"
+// @Failure 404 {object} Response "云服务商或产品不存在"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/jobs/exports [post]
+func (h *JobHandler) SubmitExport(c *gin.Context) {
+	filter, ok := h.bindConfigItemFilter(c)
+	if !ok {
+		return
+	}
+	groupBy, _ := h.GetQueryParam(c, "group_by")
+
+	job, err := h.service.SubmitExport(c, filter, groupBy)
+	if err != nil {
+		logger.Error("Failed to submit export job", err)
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.accepted(c, job)
+}
+
+// SubmitEvaluation 提交评估任务
+// @Summary 提交评估任务
+// @Description 在后台评估资源配置，单个任务最多50000个资源；任务结果为评估概览，完整的JSON评估报告通过结果接口下载
+// @Tags 后台任务
+// @Accept json
+// @Produce json
+// @Param request body EvaluationRequest true "待评估的资源"
+// @Success 202 {object} Response{data=JobResponse} "任务已提交"
+// @Failure 400 {object} Response "无效的请求参数"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/jobs/evaluations [post]
+func (h *JobHandler) SubmitEvaluation(c *gin.Context) {
+	var req EvaluationRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	job, err := h.service.SubmitEvaluation(c, req.Model())
+	if err != nil {
+		logger.Error("Failed to submit evaluation job", err, zap.Int("count", len(req.Resources)))
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.accepted(c, job)
+}
+
+// SubmitReport 提交评估报告任务
+// @Summary 提交评估报告任务
+// @Description 在后台评估资源配置并生成Excel评估报告，单个任务最多50000个资源；任务成功后通过结果接口下载
+// @Tags 后台任务
+// @Accept json
+// @Produce json
+// @Param request body EvaluationRequest true "待评估的资源"
+// @Success 202 {object} Response{data=JobResponse} "任务已提交"
+// @Failure 400 {object} Response "无效的请求参数"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/jobs/reports [post]
+func (h *JobHandler) SubmitReport(c *gin.Context) {
+	var req EvaluationRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	job, err := h.service.SubmitReport(c, req.Model())
+	if err != nil {
+		logger.Error("Failed to submit report job", err, zap.Int("count", len(req.Resources)))
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.accepted(c, job)
+}
+
+// accepted 返回已提交的任务
+func (h *JobHandler) accepted(c *gin.Context, job *models.Job) {
+	c.Header("Location", fmt.Sprintf("/api/v1/jobs/%d", job.ID))
+	c.JSON(http.StatusAccepted, Response{
+		Code:    0,
+		Message: "success",
+		Data:    NewJobResponse(job),
+	})
+}
+
+// List 分页查询后台任务
+// @Summary 获取后台任务列表
+// @Description 按创建时间倒序分页查询后台任务
+// @Tags 后台任务
+// @Produce json
+// @Param type query string false "任务类型：import, export, evaluation, report"
+// @Param status query string false "任务状态：queued, running, succeeded, failed, canceled"
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页记录数，默认10"
+// @Success 200 {object} Response{data=repository.PageResult{data=[]JobResponse}} "成功"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/jobs [get]
+func (h *JobHandler) List(c *gin.Context) {
+	filter := repository.JobFilter{
+		Page:     h.GetIntQueryParam(c, "page", 1),
+		PageSize: h.GetIntQueryParam(c, "page_size", 10),
+	}
+	filter.Type, _ = h.GetQueryParam(c, "type")
+	filter.Status, _ = h.GetQueryParam(c, "status")
+
+	result, err := h.service.ListJobs(c, filter)
+	if err != nil {
+		logger.Error("Failed to list jobs", err)
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	jobs, _ := result.Data.([]models.Job)
+	resp := make([]JobResponse, 0, len(jobs))
+	for i := range jobs {
+		resp = append(resp, *NewJobResponse(&jobs[i]))
+	}
+	result.Data = resp
+	h.Success(c, result)
+}
+
+// GetByID 获取后台任务
+// @Summary 获取后台任务详情
+// @Description 获取任务的状态、进度、错误信息和结果摘要
+// @Tags 后台任务
+// @Produce json
+// @Param id path int true "任务ID"
+// @Success 200 {object} Response{data=JobResponse} "成功"
+// @Failure 400 {object} Response "无效的ID参数"
+// @Failure 404 {object} Response "任务不存在"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/jobs/{id} [get]
+func (h *JobHandler) GetByID(c *gin.Context) {
+	id, ok := h.GetIDFromPath(c, "id")
+	if !ok {
+		return
+	}
+
+	job, err := h.service.GetJob(c, id)
+	if err != nil {
+		logger.Error("Failed to get job", err, zap.Uint("id", id))
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.Success(c, NewJobResponse(job))
+}
+
+// Cancel 取消后台任务
+// @Summary 取消后台任务
+// @Description 等待执行的任务立即取消；执行中的任务标记为需要取消，在当前步骤结束后取消
+// @Tags 后台任务
+// @Produce json
+// @Param id path int true "任务ID"
+// @Success 200 {object} Response{data=JobResponse} "成功"
+// @Failure 400 {object} Response "无效的ID参数"
+// @Failure 404 {object} Response "任务不存在"
+// @Failure 409 {object} Response "任务已结束"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/jobs/{id}/cancel [post]
+func (h *JobHandler) Cancel(c *gin.Context) {
+	id, ok := h.GetIDFromPath(c, "id")
+	if !ok {
+		return
+	}
+
+	job, err := h.service.CancelJob(c, id)
+	if err != nil {
+		logger.Error("Failed to cancel job", err, zap.Uint("id", id))
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.Success(c, NewJobResponse(job))
+}
+
+// DownloadResult 下载任务的结果文件
+// @Summary 下载任务结果
+// @Description 下载执行成功的任务的结果文件：导出任务为配置项Excel，评估任务为JSON评估报告，评估报告任务为Excel评估报告
+// @Tags 后台任务
+// @Produce application/octet-stream
+// @Param id path int true "任务ID"
+// @Success 200 {file} file "结果文件"
+// @Failure 400 {object} Response "无效的ID参数"
+// @Failure 404 {object} Response "任务不存在，或结果文件不存在或已超过保留时长"
+// @Failure 409 {object} Response "任务尚未成功完成"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/jobs/{id}/result [get]
+func (h *JobHandler) DownloadResult(c *gin.Context) {
+	id, ok := h.GetIDFromPath(c, "id")
+	if !ok {
+		return
+	}
+
+	file, content, err := h.service.ResultFile(c, id)
+	if err != nil {
+		logger.Error("Failed to get job result file", err, zap.Uint("id", id))
+		h.HandleServiceError(c, err)
+		return
+	}
+	defer content.Close()
+
+	serveFile(c, file, content)
+}
@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cloud-eye/internal/repository"
+)
+
+// 时间查询参数支持的格式
+const (
+	dateTimeLayout = time.RFC3339
+	dateLayout     = "2006-01-02"
+)
+
+// GetListQueryParam 获取多值查询参数，支持重复参数（a=1&a=2）和逗号分隔（a=1,2）两种写法
+func (h *BaseHandler) GetListQueryParam(c *gin.Context, paramName string) []string {
+	var values []string
+	for _, raw := range c.QueryArray(paramName) {
+		for _, v := range strings.Split(raw, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+	}
+	return values
+}
+
+// GetUintListQueryParam 获取多值无符号整型查询参数，存在无效值时返回错误响应
+func (h *BaseHandler) GetUintListQueryParam(c *gin.Context, paramName string) ([]uint, bool) {
+	var ids []uint
+	for _, v := range h.GetListQueryParam(c, paramName) {
+		id, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			h.Error(c, http.StatusBadRequest, 4000, "无效的参数"+paramName+": "+v)
+			return nil, false
+		}
+		ids = append(ids, uint(id))
+	}
+	return ids, true
+}
+
+// getTimeQueryParam 获取时间查询参数，支持RFC3339和日期格式；
+// endOfDay为true时，日期格式解析为当天最后时刻，用于区间上界
+func (h *BaseHandler) getTimeQueryParam(c *gin.Context, paramName string, endOfDay bool) (*time.Time, bool) {
+	value, ok := h.GetQueryParam(c, paramName)
+	if !ok {
+		return nil, true
+	}
+
+	if t, err := time.Parse(dateTimeLayout, value); err == nil {
+		return &t, true
+	}
+
+	t, err := time.ParseInLocation(dateLayout, value, time.Local)
+	if err != nil {
+		h.Error(c, http.StatusBadRequest, 4000, "无效的时间参数"+paramName+"，应为RFC3339或YYYY-MM-DD格式")
+		return nil, false
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return &t, true
+}
+
+// BindListOptions 解析列表查询的通用参数
+//   - created_from/created_to/updated_from/updated_to：时间范围
+//   - sort：排序字段，逗号分隔，前缀"-"表示降序，例如 sort=-updated_at,name
+//   - fields：仅返回的字段，例如 fields=name,recommended_value
+//   - include：需要加载的关联，传空值表示不加载任何关联
+//   - with_counts：是否返回统计列
+//   - cursor：游标分页位置，传空值表示从第一页开始，此时忽略page参数
+func (h *BaseHandler) BindListOptions(c *gin.Context) (repository.ListOptions, bool) {
+	var opts repository.ListOptions
+	var ok bool
+
+	if opts.CreatedFrom, ok = h.getTimeQueryParam(c, "created_from", false); !ok {
+		return opts, false
+	}
+	if opts.CreatedTo, ok = h.getTimeQueryParam(c, "created_to", true); !ok {
+		return opts, false
+	}
+	if opts.UpdatedFrom, ok = h.getTimeQueryParam(c, "updated_from", false); !ok {
+		return opts, false
+	}
+	if opts.UpdatedTo, ok = h.getTimeQueryParam(c, "updated_to", true); !ok {
+		return opts, false
+	}
+
+	opts.Sort = ParseSortFields(h.GetListQueryParam(c, "sort"))
+
+	opts.Fields = h.GetListQueryParam(c, "fields")
+
+	if _, present := c.GetQuery("include"); present {
+		opts.Include = append([]string{}, h.GetListQueryParam(c, "include")...)
+	}
+
+	opts.WithCounts = h.GetBoolQueryParam(c, "with_counts", false)
+
+	if cursor, present := c.GetQuery("cursor"); present {
+		opts.Cursor = &cursor
+	}
+
+	return opts, true
+}
+
+// ParseSortFields 解析排序字段，前缀-表示降序，+或无前缀表示升序
+func ParseSortFields(fields []string) []repository.SortField {
+	var sort []repository.SortField
+	for _, s := range fields {
+		sf := repository.SortField{Column: s}
+		if strings.HasPrefix(s, "-") {
+			sf = repository.SortField{Column: s[1:], Desc: true}
+		} else if strings.HasPrefix(s, "+") {
+			sf.Column = s[1:]
+		}
+		sort = append(sort, sf)
+	}
+	return sort
+}
+
+// SelectFields 按字段选择裁剪响应数据，仅保留id、请求的字段以及keep中的键（已加载的关联、统计列）
+// data可以是模型列表或数据为模型列表的PageResult，fields为空时原样返回
+func (h *BaseHandler) SelectFields(data interface{}, fields []string, keep []string) interface{} {
+	if len(fields) == 0 {
+		return data
+	}
+
+	if page, ok := data.(*repository.PageResult); ok {
+		projected := *page
+		projected.Data = h.SelectFields(page.Data, fields, keep)
+		return &projected
+	}
+
+	kept := map[string]bool{"id": true}
+	for _, f := range fields {
+		kept[f] = true
+	}
+	for _, k := range keep {
+		kept[k] = true
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return data
+	}
+	var rows []map[string]interface{}
+	if err := json.Unmarshal(raw, &rows); err != nil {
+		return data
+	}
+
+	for _, row := range rows {
+		for k := range row {
+			if !kept[k] {
+				delete(row, k)
+			}
+		}
+	}
+	return rows
+}
@@ -0,0 +1,212 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cloud-eye/internal/models"
+	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+	"github.com/yourusername/cloud-eye/internal/service"
+	"go.uber.org/zap"
+)
+
+// ProductCategoryHandler 产品类别API处理器
+type ProductCategoryHandler struct {
+	BaseHandler
+	service service.ProductCategoryService
+}
+
+// NewProductCategoryHandler 创建产品类别处理器
+func NewProductCategoryHandler(service service.ProductCategoryService) *ProductCategoryHandler {
+	return &ProductCategoryHandler{
+		service: service,
+	}
+}
+
+// AssignProductsRequest 产品归类请求
+type AssignProductsRequest struct {
+	ProductIDs []uint `json:"product_ids" binding:"required"`
+}
+
+// GetAll 获取产品类别
+// @Summary 获取产品类别
+// @Description 默认返回类别树，flat=true时返回平铺列表
+// @Tags 产品类别
+// @Produce json
+// @Param flat query bool false "是否返回平铺列表"
+// @Success 200 {object} Response{data=[]models.ProductCategory} "成功"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/product-categories [get]
+func (h *ProductCategoryHandler) GetAll(c *gin.Context) {
+	var categories []models.ProductCategory
+	var err error
+	if h.GetBoolQueryParam(c, "flat", false) {
+		categories, err = h.service.GetAllCategories(c)
+	} else {
+		categories, err = h.service.GetCategoryTree(c)
+	}
+	if err != nil {
+		logger.Error("Failed to get product categories", err)
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	if categories == nil {
+		categories = []models.ProductCategory{}
+	}
+	h.Success(c, categories)
+}
+
+// GetByID 根据ID获取产品类别
+// @Summary 获取产品类别详情
+// @Description 根据ID获取产品类别及其子类别树
+// @Tags 产品类别
+// @Produce json
+// @Param id path int true "类别ID"
+// @Success 200 {object} Response{data=models.ProductCategory} "成功"
+// @Failure 400 {object} Response "无效的ID参数"
+// @Failure 404 {object} Response "产品类别不存在"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/product-categories/{id} [get]
+func (h *ProductCategoryHandler) GetByID(c *gin.Context) {
+	id, ok := h.GetIDFromPath(c, "id")
+	if !ok {
+		return
+	}
+
+	category, err := h.service.GetCategoryByID(c, id)
+	if err != nil {
+		logger.Error("Failed to get product category by ID", err, zap.Uint("id", id))
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.Success(c, category)
+}
+
+// Create 创建产品类别
+// @Summary 创建产品类别
+// @Description 创建新的产品类别，parent_id为空时为顶级类别
+// @Tags 产品类别
+// @Accept json
+// @Produce json
+// @Param category body CategoryRequest true "类别信息"
+// @Success 200 {object} Response{data=models.ProductCategory} "成功"
+// @Failure 400 {object} Response "无效的请求参数"
+// @Failure 404 {object} Response "上级类别不存在"
+// @Failure 409 {object} Response "类别代码已存在"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/product-categories [post]
+func (h *ProductCategoryHandler) Create(c *gin.Context) {
+	var req CategoryRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	category := req.Model()
+	err := h.service.CreateCategory(c, category)
+	if err != nil {
+		logger.Error("Failed to create product category", err)
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.Success(c, category)
+}
+
+// Update 更新产品类别
+// @Summary 更新产品类别
+// @Description 更新类别信息，可通过parent_id移动类别，不能移动到自身或其子类别之下
+// @Tags 产品类别
+// @Accept json
+// @Produce json
+// @Param id path int true "类别ID"
+// @Param category body CategoryRequest true "类别信息"
+// @Success 200 {object} Response "成功"
+// @Failure 400 {object} Response "无效的请求参数"
+// @Failure 404 {object} Response "类别不存在"
+// @Failure 409 {object} Response "类别代码已存在"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/product-categories/{id} [put]
+func (h *ProductCategoryHandler) Update(c *gin.Context) {
+	id, ok := h.GetIDFromPath(c, "id")
+	if !ok {
+		return
+	}
+
+	var req CategoryRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	category := req.Model()
+	category.ID = id
+
+	err := h.service.UpdateCategory(c, category)
+	if err != nil {
+		logger.Error("Failed to update product category", err, zap.Uint("id", id))
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.Success(c, gin.H{"message": "产品类别更新成功"})
+}
+
+// Delete 删除产品类别
+// @Summary 删除产品类别
+// @Description 删除没有子类别的类别，该类别下的产品变为未分类
+// @Tags 产品类别
+// @Produce json
+// @Param id path int true "类别ID"
+// @Success 200 {object} Response "成功"
+// @Failure 400 {object} Response "存在子类别"
+// @Failure 404 {object} Response "类别不存在"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/product-categories/{id} [delete]
+func (h *ProductCategoryHandler) Delete(c *gin.Context) {
+	id, ok := h.GetIDFromPath(c, "id")
+	if !ok {
+		return
+	}
+
+	err := h.service.DeleteCategory(c, id)
+	if err != nil {
+		logger.Error("Failed to delete product category", err, zap.Uint("id", id))
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.Success(c, gin.H{"message": "产品类别删除成功"})
+}
+
+// AssignProducts 将产品归入类别
+// @Summary 产品归类
+// @Description 将一个或多个云产品归入类别，替换产品原有的类别
+// @Tags 产品类别
+// @Accept json
+// @Produce json
+// @Param id path int true "类别ID"
+// @Param request body AssignProductsRequest true "产品ID列表"
+// @Success 200 {object} Response "成功"
+// @Failure 400 {object} Response "无效的请求参数"
+// @Failure 404 {object} Response "类别或产品不存在"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/product-categories/{id}/products [post]
+func (h *ProductCategoryHandler) AssignProducts(c *gin.Context) {
+	id, ok := h.GetIDFromPath(c, "id")
+	if !ok {
+		return
+	}
+
+	var req AssignProductsRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	err := h.service.AssignProducts(c, id, req.ProductIDs)
+	if err != nil {
+		logger.Error("Failed to assign products to category", err, zap.Uint("id", id))
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.Success(c, gin.H{"message": "产品归类成功"})
+}
@@ -0,0 +1,65 @@
+package handler_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/cloud-eye/internal/apptest"
+	"github.com/yourusername/cloud-eye/internal/models"
+)
+
+func TestProductCategoryRejectsInvalidInput(t *testing.T) {
+	app := apptest.New(t)
+	// 演示数据中STORAGE(2)是OBJECT_STORAGE(3)的上级类别
+	valid := map[string]interface{}{"name": "容器", "code": "CONTAINER"}
+	with := func(key string, value interface{}) map[string]interface{} {
+		body := map[string]interface{}{}
+		for k, v := range valid {
+			body[k] = v
+		}
+		body[key] = value
+		return body
+	}
+
+	runErrorCases(t, app, []errorCase{
+		{"无效的ID", http.MethodGet, "/api/v1/product-categories/abc", nil, http.StatusBadRequest, "无效的ID参数", nil},
+		{"类别不存在", http.MethodGet, "/api/v1/product-categories/999", nil, http.StatusNotFound, "产品类别不存在", nil},
+		{"无效的JSON", http.MethodPost, "/api/v1/product-categories", `{"name":`, http.StatusBadRequest, "无效的请求参数", nil},
+		{"缺少名称和代码", http.MethodPost, "/api/v1/product-categories", map[string]interface{}{"description": "说明"}, http.StatusBadRequest, "请求参数校验失败", []string{"name", "code"}},
+		{"代码格式无效", http.MethodPost, "/api/v1/product-categories", with("code", "对象 存储"), http.StatusBadRequest, "请求参数校验失败", []string{"code"}},
+		{"名称过长", http.MethodPost, "/api/v1/product-categories", with("name", strings.Repeat("类", 101)), http.StatusBadRequest, "请求参数校验失败", []string{"name"}},
+		{"上级类别ID为0", http.MethodPost, "/api/v1/product-categories", with("parent_id", 0), http.StatusBadRequest, "请求参数校验失败", []string{"parent_id"}},
+		{"上级类别不存在", http.MethodPost, "/api/v1/product-categories", with("parent_id", 999), http.StatusNotFound, "产品类别不存在", nil},
+		{"代码重复", http.MethodPost, "/api/v1/product-categories", with("code", "STORAGE"), http.StatusConflict, "产品类别代码已存在", nil},
+		{"更新不存在的类别", http.MethodPut, "/api/v1/product-categories/999", valid, http.StatusNotFound, "产品类别不存在", nil},
+		{"更新缺少代码", http.MethodPut, "/api/v1/product-categories/2", map[string]interface{}{"name": "存储"}, http.StatusBadRequest, "请求参数校验失败", []string{"code"}},
+		{"更新为其他类别的代码", http.MethodPut, "/api/v1/product-categories/2", map[string]interface{}{"name": "存储", "code": "COMPUTE"}, http.StatusConflict, "产品类别代码已存在", nil},
+		{"移动到子类别之下", http.MethodPut, "/api/v1/product-categories/2", map[string]interface{}{"name": "存储", "code": "STORAGE", "parent_id": 3}, http.StatusBadRequest, "不能将类别移动到自身或其子类别之下", nil},
+		{"移动到自身之下", http.MethodPut, "/api/v1/product-categories/2", map[string]interface{}{"name": "存储", "code": "STORAGE", "parent_id": 2}, http.StatusBadRequest, "不能将类别移动到自身或其子类别之下", nil},
+		{"删除有子类别的类别", http.MethodDelete, "/api/v1/product-categories/2", nil, http.StatusBadRequest, "存在子类别", nil},
+		{"删除不存在的类别", http.MethodDelete, "/api/v1/product-categories/999", nil, http.StatusNotFound, "产品类别不存在", nil},
+		{"归类缺少产品ID", http.MethodPost, "/api/v1/product-categories/3/products", map[string]interface{}{}, http.StatusBadRequest, "请求参数校验失败", []string{"product_ids"}},
+		{"归类的产品ID为空", http.MethodPost, "/api/v1/product-categories/3/products", map[string]interface{}{"product_ids": []uint{}}, http.StatusBadRequest, "产品ID列表不能为空", nil},
+		{"归类的产品ID类型错误", http.MethodPost, "/api/v1/product-categories/3/products", map[string]interface{}{"product_ids": "1"}, http.StatusBadRequest, "请求参数校验失败", []string{"product_ids"}},
+		{"归类的产品不存在", http.MethodPost, "/api/v1/product-categories/3/products", map[string]interface{}{"product_ids": []uint{1, 999}}, http.StatusNotFound, "云产品不存在", nil},
+		{"归类到不存在的类别", http.MethodPost, "/api/v1/product-categories/999/products", map[string]interface{}{"product_ids": []uint{1}}, http.StatusNotFound, "产品类别不存在", nil},
+		{"按无效的类别筛选产品", http.MethodGet, "/api/v1/cloud-products?category_id=1,abc", nil, http.StatusBadRequest, "无效的参数category_id: abc", nil},
+		{"按无效的类别筛选配置项", http.MethodGet, "/api/v1/config-items?category_id=-1", nil, http.StatusBadRequest, "无效的参数category_id: -1", nil},
+		{"不支持的导出分组", http.MethodGet, "/api/v1/config-items/export?group_by=provider", nil, http.StatusBadRequest, "不支持的分组维度: provider", nil},
+	})
+
+	// 校验失败的请求没有修改类别
+	var storage models.ProductCategory
+	if status, _ := doJSON(t, http.MethodGet, app.URL("/api/v1/product-categories/2"), nil, &storage); status != http.StatusOK {
+		t.Fatalf("读取类别返回%d", status)
+	}
+	if storage.Code != "STORAGE" || storage.ParentID != nil {
+		t.Fatalf("校验失败的请求修改了类别: %+v", storage)
+	}
+	var product models.CloudProduct
+	doJSON(t, http.MethodGet, app.URL("/api/v1/cloud-products/1"), nil, &product)
+	if product.CategoryID != nil && *product.CategoryID == 3 {
+		t.Fatal("部分产品不存在时仍归类了其他产品")
+	}
+}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+	"github.com/yourusername/cloud-eye/internal/repository"
+	"github.com/yourusername/cloud-eye/internal/service"
+	"go.uber.org/zap"
+)
+
+// SearchHandler 全文检索API处理器
+type SearchHandler struct {
+	BaseHandler
+	service service.SearchService
+}
+
+// NewSearchHandler 创建全文检索处理器
+func NewSearchHandler(service service.SearchService) *SearchHandler {
+	return &SearchHandler{
+		service: service,
+	}
+}
+
+// SearchConfigItems 全文检索配置项
+// @Summary 全文检索配置项
+// @Description 在配置项的所有文本字段及服务商、产品名称中检索，按相关度排序并返回高亮摘要。
+// @Description 支持短语（"公共 访问"）、字段作用域（name: value: risk: check: config: ref: provider: product:）和排除（-词），例如 risk:加密 -日志
+// @Tags 配置项
+// @Produce json
+// @Param q query string true "检索语句"
+// @Param cloud_provider_id query int false "云服务商ID"
+// @Param product_id query int false "产品ID"
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页记录数，默认10"
+// @Success 200 {object} Response{data=repository.PageResult{data=[]repository.ConfigItemSearchHit}} "成功"
+// @Failure 400 {object} Response "检索关键词不能为空"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/search/config-items [get]
+func (h *SearchHandler) SearchConfigItems(c *gin.Context) {
+	query, _ := h.GetQueryParam(c, "q")
+
+	filter := repository.ConfigItemSearchFilter{
+		Page:     h.GetIntQueryParam(c, "page", 1),
+		PageSize: h.GetIntQueryParam(c, "page_size", 10),
+	}
+
+	// 获取可选过滤参数
+	if providerID, ok := h.GetUintQueryParam(c, "cloud_provider_id"); ok {
+		filter.CloudProviderID = &providerID
+	}
+
+	if productID, ok := h.GetUintQueryParam(c, "product_id"); ok {
+		filter.ProductID = &productID
+	}
+
+	result, err := h.service.SearchConfigItems(c, query, filter)
+	if err != nil {
+		logger.Error("Failed to search config items", err, zap.String("query", query))
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.Success(c, result)
+}
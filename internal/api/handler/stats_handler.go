@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+	"github.com/yourusername/cloud-eye/internal/service"
+)
+
+// StatsHandler 统计分析API处理器
+type StatsHandler struct {
+	BaseHandler
+	service service.StatsService
+}
+
+// NewStatsHandler 创建统计分析处理器
+func NewStatsHandler(service service.StatsService) *StatsHandler {
+	return &StatsHandler{
+		service: service,
+	}
+}
+
+// GetOverview 获取仪表盘概览
+// @Summary 获取仪表盘概览
+// @Description 返回总数、各服务商与产品的统计、按风险等级和状态的配置项分布、最近12周的变更趋势以及尚无配置基线的产品
+// @Tags 统计分析
+// @Produce json
+// @Success 200 {object} Response{data=service.StatsOverview} "成功"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/stats [get]
+func (h *StatsHandler) GetOverview(c *gin.Context) {
+	overview, err := h.service.GetOverview(c)
+	if err != nil {
+		logger.Error("Failed to get stats overview", err)
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.Success(c, overview)
+}
+
+// GetProviderStats 获取云服务商统计
+// @Summary 获取云服务商统计
+// @Description 统计每个云服务商的产品数和配置项数
+// @Tags 统计分析
+// @Produce json
+// @Success 200 {object} Response{data=[]repository.ProviderStat} "成功"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/stats/providers [get]
+func (h *StatsHandler) GetProviderStats(c *gin.Context) {
+	stats, err := h.service.GetProviderStats(c)
+	if err != nil {
+		logger.Error("Failed to get provider stats", err)
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.Success(c, stats)
+}
+
+// GetProductStats 获取云产品统计
+// @Summary 获取云产品统计
+// @Description 统计每个云产品的配置项数
+// @Tags 统计分析
+// @Produce json
+// @Param cloud_provider_id query int false "云服务商ID"
+// @Success 200 {object} Response{data=[]repository.ProductStat} "成功"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/stats/products [get]
+func (h *StatsHandler) GetProductStats(c *gin.Context) {
+	var providerID *uint
+	if id, ok := h.GetUintQueryParam(c, "cloud_provider_id"); ok {
+		providerID = &id
+	}
+
+	stats, err := h.service.GetProductStats(c, providerID)
+	if err != nil {
+		logger.Error("Failed to get product stats", err)
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.Success(c, stats)
+}
+
+// GetCategoryStats 获取产品类别统计
+// @Summary 获取产品类别统计
+// @Description 统计每个产品类别直接归属的产品数和配置项数，以及包含子类别的合计
+// @Tags 统计分析
+// @Produce json
+// @Success 200 {object} Response{data=[]repository.CategoryStat} "成功"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/stats/categories [get]
+func (h *StatsHandler) GetCategoryStats(c *gin.Context) {
+	stats, err := h.service.GetCategoryStats(c)
+	if err != nil {
+		logger.Error("Failed to get category stats", err)
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.Success(c, stats)
+}
+
+// GetConfigItemBreakdown 获取配置项分布
+// @Summary 获取配置项分布
+// @Description 按风险等级或状态统计配置项数量
+// @Tags 统计分析
+// @Produce json
+// @Param by query string false "统计维度：severity（默认）或status"
+// @Success 200 {object} Response{data=[]repository.GroupCount} "成功"
+// @Failure 400 {object} Response "不支持的统计维度"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/stats/config-items [get]
+func (h *StatsHandler) GetConfigItemBreakdown(c *gin.Context) {
+	dimension, ok := h.GetQueryParam(c, "by")
+	if !ok {
+		dimension = service.DimensionSeverity
+	}
+
+	counts, err := h.service.GetConfigItemBreakdown(c, dimension)
+	if err != nil {
+		logger.Error("Failed to get configuration item breakdown", err)
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.Success(c, counts)
+}
+
+// GetWeeklyActivity 获取配置项变更趋势
+// @Summary 获取配置项变更趋势
+// @Description 按周统计新增和更新的配置项数量，默认统计最近12周，最多104周
+// @Tags 统计分析
+// @Produce json
+// @Param from query string false "开始时间（RFC3339或YYYY-MM-DD），按所在周的周一对齐"
+// @Param to query string false "结束时间（RFC3339或YYYY-MM-DD），默认当前时间"
+// @Success 200 {object} Response{data=[]repository.WeeklyActivity} "成功"
+// @Failure 400 {object} Response "无效的时间范围"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/stats/activity [get]
+func (h *StatsHandler) GetWeeklyActivity(c *gin.Context) {
+	from, ok := h.getTimeQueryParam(c, "from", false)
+	if !ok {
+		return
+	}
+	to, ok := h.getTimeQueryParam(c, "to", true)
+	if !ok {
+		return
+	}
+
+	activity, err := h.service.GetWeeklyActivity(c, from, to)
+	if err != nil {
+		logger.Error("Failed to get weekly activity", err)
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.Success(c, activity)
+}
+
+// GetCoverageGaps 获取覆盖缺口
+// @Summary 获取覆盖缺口
+// @Description 列出尚无任何配置基线的云产品
+// @Tags 统计分析
+// @Produce json
+// @Success 200 {object} Response{data=[]repository.ProductStat} "成功"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/stats/coverage-gaps [get]
+func (h *StatsHandler) GetCoverageGaps(c *gin.Context) {
+	products, err := h.service.GetUncoveredProducts(c)
+	if err != nil {
+		logger.Error("Failed to get coverage gaps", err)
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.Success(c, products)
+}
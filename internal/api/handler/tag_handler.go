@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cloud-eye/internal/models"
+	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+	"github.com/yourusername/cloud-eye/internal/repository"
+	"github.com/yourusername/cloud-eye/internal/service"
+	"go.uber.org/zap"
+)
+
+// TagHandler 标签API处理器
+type TagHandler struct {
+	BaseHandler
+	service service.TagService
+}
+
+// NewTagHandler 创建标签处理器
+func NewTagHandler(service service.TagService) *TagHandler {
+	return &TagHandler{
+		service: service,
+	}
+}
+
+// TagBulkRequest 批量标签操作请求
+type TagBulkRequest struct {
+	Target string   `json:"target" binding:"required"` // provider, product 或 config_item
+	IDs    []uint   `json:"ids" binding:"required"`
+	Tags   []string `json:"tags" binding:"required"`
+}
+
+// GetAll 获取所有标签
+// @Summary 获取所有标签
+// @Description 获取所有标签及其在云服务商、产品和配置项上的使用次数
+// @Tags 标签
+// @Produce json
+// @Success 200 {object} Response{data=[]models.Tag} "成功"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/tags [get]
+func (h *TagHandler) GetAll(c *gin.Context) {
+	tags, err := h.service.GetAllTags(c)
+	if err != nil {
+		logger.Error("Failed to get all tags", err)
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	if tags == nil {
+		tags = []models.Tag{}
+	}
+	h.Success(c, tags)
+}
+
+// Suggest 标签自动补全
+// @Summary 标签自动补全
+// @Description 按前缀匹配标签名称，按使用次数降序返回
+// @Tags 标签
+// @Produce json
+// @Param q query string false "标签名称前缀"
+// @Param limit query int false "返回数量，默认10，最大50"
+// @Success 200 {object} Response{data=[]models.Tag} "成功"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/tags/suggest [get]
+func (h *TagHandler) Suggest(c *gin.Context) {
+	prefix, _ := h.GetQueryParam(c, "q")
+	limit := h.GetIntQueryParam(c, "limit", service.DefaultSuggestSize)
+
+	tags, err := h.service.SuggestTags(c, prefix, limit)
+	if err != nil {
+		logger.Error("Failed to suggest tags", err, zap.String("prefix", prefix))
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	if tags == nil {
+		tags = []models.Tag{}
+	}
+	h.Success(c, tags)
+}
+
+// Delete 删除标签
+// @Summary 删除标签
+// @Description 删除标签，同时移除其在所有实体上的关联
+// @Tags 标签
+// @Produce json
+// @Param id path int true "标签ID"
+// @Success 200 {object} Response "成功"
+// @Failure 400 {object} Response "无效的ID参数"
+// @Failure 404 {object} Response "标签不存在"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/tags/{id} [delete]
+func (h *TagHandler) Delete(c *gin.Context) {
+	id, ok := h.GetIDFromPath(c, "id")
+	if !ok {
+		return
+	}
+
+	err := h.service.DeleteTag(c, id)
+	if err != nil {
+		logger.Error("Failed to delete tag", err, zap.Uint("id", id))
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.Success(c, gin.H{"message": "标签删除成功"})
+}
+
+// Attach 批量添加标签
+// @Summary 批量添加标签
+// @Description 为多个云服务商、产品或配置项添加标签，不存在的标签自动创建，已有的标签保持不变
+// @Tags 标签
+// @Accept json
+// @Produce json
+// @Param request body TagBulkRequest true "实体类型、实体ID列表和标签名称列表"
+// @Success 200 {object} Response "成功"
+// @Failure 400 {object} Response "无效的请求参数"
+// @Failure 404 {object} Response "实体不存在"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/tags/attach [post]
+func (h *TagHandler) Attach(c *gin.Context) {
+	var req TagBulkRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	err := h.service.AttachTags(c, repository.TagTarget(req.Target), req.IDs, req.Tags)
+	if err != nil {
+		logger.Error("Failed to attach tags", err, zap.String("target", req.Target))
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.Success(c, gin.H{"message": "标签添加成功"})
+}
+
+// Detach 批量移除标签
+// @Summary 批量移除标签
+// @Description 移除多个云服务商、产品或配置项上的标签，标签本身保留
+// @Tags 标签
+// @Accept json
+// @Produce json
+// @Param request body TagBulkRequest true "实体类型、实体ID列表和标签名称列表"
+// @Success 200 {object} Response "成功"
+// @Failure 400 {object} Response "无效的请求参数"
+// @Failure 404 {object} Response "实体不存在"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/tags/detach [post]
+func (h *TagHandler) Detach(c *gin.Context) {
+	var req TagBulkRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	err := h.service.DetachTags(c, repository.TagTarget(req.Target), req.IDs, req.Tags)
+	if err != nil {
+		logger.Error("Failed to detach tags", err, zap.String("target", req.Target))
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.Success(c, gin.H{"message": "标签移除成功"})
+}
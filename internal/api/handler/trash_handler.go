@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+	"github.com/yourusername/cloud-eye/internal/repository"
+	"github.com/yourusername/cloud-eye/internal/service"
+	"go.uber.org/zap"
+)
+
+// TrashHandler 回收站API处理器
+type TrashHandler struct {
+	BaseHandler
+	service service.TrashService
+}
+
+// NewTrashHandler 创建回收站处理器
+func NewTrashHandler(service service.TrashService) *TrashHandler {
+	return &TrashHandler{
+		service: service,
+	}
+}
+
+// List 获取回收站列表
+// @Summary 获取回收站列表
+// @Description 按删除时间倒序列出回收站中的云服务商、云产品或配置项
+// @Tags 回收站
+// @Produce json
+// @Param type query string true "实体类型：provider, product, config_item"
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页记录数，默认10"
+// @Success 200 {object} Response{data=repository.PageResult} "成功"
+// @Failure 400 {object} Response "无效的请求参数"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/trash [get]
+func (h *TrashHandler) List(c *gin.Context) {
+	target, _ := h.GetQueryParam(c, "type")
+	filter := repository.TrashFilter{
+		Target:   repository.TrashTarget(target),
+		Page:     h.GetIntQueryParam(c, "page", 1),
+		PageSize: h.GetIntQueryParam(c, "page_size", 10),
+	}
+
+	result, err := h.service.ListTrash(c, filter)
+	if err != nil {
+		logger.Error("Failed to list trash", err, zap.String("type", target))
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.Success(c, result)
+}
+
+// Restore 从回收站恢复
+// @Summary 从回收站恢复
+// @Description 恢复回收站中的实体，与其在同一次删除中被级联删除的产品和配置项一同恢复；所属云服务商或云产品仍在回收站中时需先恢复上级
+// @Tags 回收站
+// @Produce json
+// @Param type path string true "实体类型：provider, product, config_item"
+// @Param id path int true "实体ID"
+// @Success 200 {object} Response "成功"
+// @Failure 400 {object} Response "无效的请求参数"
+// @Failure 404 {object} Response "回收站中不存在该实体"
+// @Failure 409 {object} Response "上级仍在回收站中"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/trash/{type}/{id}/restore [post]
+func (h *TrashHandler) Restore(c *gin.Context) {
+	id, ok := h.GetIDFromPath(c, "id")
+	if !ok {
+		return
+	}
+	target := c.Param("type")
+
+	err := h.service.Restore(c, repository.TrashTarget(target), id)
+	if err != nil {
+		logger.Error("Failed to restore from trash", err, zap.String("type", target), zap.Uint("id", id))
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.Success(c, gin.H{"message": "恢复成功"})
+}
+
+// Purge 彻底删除
+// @Summary 彻底删除
+// @Description 彻底删除回收站中的实体及其下级记录，不可恢复，需在请求头X-Admin-Token中提供管理员令牌
+// @Tags 回收站
+// @Produce json
+// @Param type path string true "实体类型：provider, product, config_item"
+// @Param id path int true "实体ID"
+// @Param X-Admin-Token header string true "管理员令牌"
+// @Success 200 {object} Response "成功"
+// @Failure 400 {object} Response "无效的请求参数"
+// @Failure 403 {object} Response "管理员令牌无效"
+// @Failure 404 {object} Response "回收站中不存在该实体"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/trash/{type}/{id} [delete]
+func (h *TrashHandler) Purge(c *gin.Context) {
+	id, ok := h.GetIDFromPath(c, "id")
+	if !ok {
+		return
+	}
+	target := c.Param("type")
+
+	err := h.service.Purge(c, repository.TrashTarget(target), id)
+	if err != nil {
+		logger.Error("Failed to purge from trash", err, zap.String("type", target), zap.Uint("id", id))
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.Success(c, gin.H{"message": "彻底删除成功"})
+}
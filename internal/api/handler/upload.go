@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cloud-eye/internal/pkg/excel"
+	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// multipartOverhead multipart请求中文件以外内容的大小上限
+const multipartOverhead = 1 << 20
+
+// maxUploadNameLength 上传文件名保留的最大字符数
+const maxUploadNameLength = 100
+
+// receiveExcelUpload 接收表单字段file中的Excel文件：限制请求大小，以随机文件名暂存并校验文件结构。
+// 返回暂存路径和清理后的原文件名；校验失败的文件被隔离或删除，并已返回错误响应
+func (h *BaseHandler) receiveExcelUpload(c *gin.Context, importer *excel.ConfigItemImporter) (string, string, bool) {
+	limit := importer.MaxUploadSize()
+	tooLarge := fmt.Sprintf("文件超过大小限制（最大%.1fMB）", float64(limit)/(1<<20))
+
+	// 超过大小限制的请求在解析表单时即中断，不会写入临时文件
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit+multipartOverhead)
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			h.Error(c, http.StatusRequestEntityTooLarge, 4013, tooLarge)
+			return "", "", false
+		}
+		h.Error(c, http.StatusBadRequest, 4000, "请选择要导入的Excel文件")
+		return "", "", false
+	}
+	defer file.Close()
+
+	name := sanitizeUploadName(header.Filename)
+	if !strings.HasSuffix(strings.ToLower(name), ".xlsx") {
+		h.Error(c, http.StatusBadRequest, 4000, "只支持.xlsx格式的Excel文件")
+		return "", "", false
+	}
+	if header.Size > limit {
+		h.Error(c, http.StatusRequestEntityTooLarge, 4013, tooLarge)
+		return "", "", false
+	}
+
+	filePath, err := importer.SaveUploadedFile(file)
+	if err != nil {
+		if errors.Is(err, excel.ErrFileTooLarge) {
+			h.Error(c, http.StatusRequestEntityTooLarge, 4013, tooLarge)
+			return "", "", false
+		}
+		logger.Error("Failed to save uploaded file", err)
+		h.Error(c, http.StatusInternalServerError, 5000, "保存文件失败")
+		return "", "", false
+	}
+
+	if err := importer.ValidateUpload(filePath); err != nil {
+		logger.Warn("Rejected uploaded file", zap.String("name", name), zap.String("filepath", filePath), zap.Error(err))
+		importer.Discard(filePath)
+		if errors.Is(err, excel.ErrFileTooLarge) {
+			h.Error(c, http.StatusRequestEntityTooLarge, 4013, err.Error())
+			return "", "", false
+		}
+		h.Error(c, http.StatusBadRequest, 4000, err.Error())
+		return "", "", false
+	}
+
+	return filePath, name, true
+}
+
+// sanitizeUploadName 清理客户端提供的文件名，只保留文件名部分并去除控制字符，用于记录和下载时的显示名称
+func sanitizeUploadName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+
+	if r := []rune(name); len(r) > maxUploadNameLength {
+		ext := []rune(path.Ext(name))
+		if len(ext) > 10 {
+			ext = nil
+		}
+		name = string(r[:maxUploadNameLength-len(ext)]) + string(ext)
+	}
+	if name == "" || name == "." || name == "/" {
+		return "upload.xlsx"
+	}
+	return name
+}
@@ -0,0 +1,203 @@
+package handler_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+	"github.com/yourusername/cloud-eye/internal/api/handler"
+	"github.com/yourusername/cloud-eye/internal/apptest"
+	"github.com/yourusername/cloud-eye/internal/pkg/excel"
+)
+
+// uploadEndpoints 接收Excel上传的接口，共用同一套校验
+var uploadEndpoints = []string{"/api/v1/config-items/import", "/api/v1/jobs/imports"}
+
+// upload 以multipart表单上传文件，field为空时使用file字段，data解析到out
+func upload(t *testing.T, url, field, name string, content []byte, out interface{}) (int, handler.Response) {
+	t.Helper()
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	if field == "" {
+		field = "file"
+	}
+	part, err := w.CreateFormFile(field, name)
+	if err != nil {
+		t.Fatalf("创建表单失败: %v", err)
+	}
+	part.Write(content)
+	w.Close()
+	return doRequest(t, http.MethodPost, url, w.FormDataContentType(), &body, out)
+}
+
+// workbook 生成包含sheets个工作表的xlsx文件，第一个工作表写入rows行数据
+func workbook(t *testing.T, sheets int, rows [][]interface{}) []byte {
+	t.Helper()
+	f := excelize.NewFile()
+	defer f.Close()
+	for i := 2; i <= sheets; i++ {
+		if _, err := f.NewSheet(fmt.Sprintf("Sheet%d", i)); err != nil {
+			t.Fatalf("创建工作表失败: %v", err)
+		}
+	}
+	for i, row := range rows {
+		cell, _ := excelize.CoordinatesToCellName(1, i+1)
+		if err := f.SetSheetRow("Sheet1", cell, &row); err != nil {
+			t.Fatalf("写入行失败: %v", err)
+		}
+	}
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		t.Fatalf("生成xlsx失败: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// zipFile 生成包含指定条目的zip文件
+func zipFile(t *testing.T, entries map[string][]byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range entries {
+		entry, err := w.Create(name)
+		if err != nil {
+			t.Fatalf("创建zip条目失败: %v", err)
+		}
+		entry.Write(content)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("生成zip失败: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// dirEntries 返回目录中的文件名，目录不存在时为空
+func dirEntries(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatalf("读取目录失败: %v", err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	return names
+}
+
+func TestUploadRejectsInvalidFiles(t *testing.T) {
+	app := apptest.NewWithOptions(t, apptest.Options{Upload: excel.UploadLimits{MaxUploadSize: 64 << 10, MaxSheets: 2}})
+	valid := workbook(t, 1, [][]interface{}{{"a"}, {"b"}})
+	bomb := zipFile(t, map[string][]byte{
+		"[Content_Types].xml":      []byte("<Types/>"),
+		"xl/workbook.xml":          []byte("<workbook/>"),
+		"xl/worksheets/sheet1.xml": bytes.Repeat([]byte{0}, 2<<20),
+	})
+
+	tests := []struct {
+		name    string
+		field   string
+		file    string
+		content []byte
+		status  int
+		code    int
+		message string
+	}{
+		{"缺少file字段", "upload", "a.xlsx", valid, 400, 4000, "请选择要导入的Excel文件"},
+		{"扩展名不是xlsx", "", "items.csv", []byte("a,b\n"), 400, 4000, "只支持.xlsx格式的Excel文件"},
+		{"旧版xls", "", "items.xls", valid, 400, 4000, "只支持.xlsx格式的Excel文件"},
+		{"内容不是zip", "", "items.xlsx", []byte("name,code\n"), 400, 4000, "文件不是有效的.xlsx格式"},
+		{"空文件", "", "items.xlsx", nil, 400, 4000, "文件不是有效的.xlsx格式"},
+		{"zip中没有工作簿", "", "items.xlsx", zipFile(t, map[string][]byte{"readme.txt": []byte("x")}), 400, 4000, "文件不是有效的.xlsx格式"},
+		{"压缩炸弹", "", "items.xlsx", bomb, 400, 4000, "压缩比异常"},
+		{"工作表过多", "", "items.xlsx", workbook(t, 3, nil), 400, 4000, "工作表数量超过限制：最多2个工作表"},
+		{"文件过大", "", "items.xlsx", bytes.Repeat([]byte("x"), 65<<10), 413, 4013, "文件超过大小限制（最大0.1MB）"},
+	}
+	for _, endpoint := range uploadEndpoints {
+		for _, tt := range tests {
+			t.Run(endpoint+"/"+tt.name, func(t *testing.T) {
+				status, resp := upload(t, app.URL(endpoint), tt.field, tt.file, tt.content, nil)
+				if status != tt.status || resp.Code != tt.code || !strings.Contains(resp.Message, tt.message) {
+					t.Fatalf("返回%d %d %q，期望%d %d %q", status, resp.Code, resp.Message, tt.status, tt.code, tt.message)
+				}
+				// 未通过校验的文件不保留在暂存目录中
+				if files := dirEntries(t, app.ImportPath); len(files) != 0 {
+					t.Fatalf("暂存目录中残留文件%v", files)
+				}
+			})
+		}
+	}
+
+	// 不是multipart表单的请求
+	status, resp := doJSON(t, http.MethodPost, app.URL(uploadEndpoints[0]), map[string]string{"file": "a.xlsx"}, nil)
+	if status != http.StatusBadRequest || resp.Message != "请选择要导入的Excel文件" {
+		t.Fatalf("JSON请求返回%d %q", status, resp.Message)
+	}
+}
+
+func TestUploadRejectsOversizedRequest(t *testing.T) {
+	app := apptest.NewWithOptions(t, apptest.Options{Upload: excel.UploadLimits{MaxUploadSize: 1 << 10}})
+
+	// 超过大小限制和表单开销的请求在读取请求体时即中断
+	status, resp := upload(t, app.URL(uploadEndpoints[0]), "", "items.xlsx", bytes.Repeat([]byte("x"), 2<<20), nil)
+	if status != http.StatusRequestEntityTooLarge || resp.Code != 4013 {
+		t.Fatalf("返回%d %d %q，期望413", status, resp.Code, resp.Message)
+	}
+	if files := dirEntries(t, app.ImportPath); len(files) != 0 {
+		t.Fatalf("暂存目录中残留文件%v", files)
+	}
+}
+
+func TestUploadQuarantinesWithRandomName(t *testing.T) {
+	app := apptest.NewWithOptions(t, apptest.Options{Quarantine: true, Upload: excel.UploadLimits{MaxRows: 2}})
+
+	// 客户端文件名中的路径不影响保存位置，文件以随机名称暂存后隔离
+	status, resp := upload(t, app.URL(uploadEndpoints[0]), "", `..\..\..\etc/../../cron.xlsx`, []byte("not a zip"), nil)
+	if status != http.StatusBadRequest || resp.Message != excel.ErrNotXLSX.Error() {
+		t.Fatalf("返回%d %q", status, resp.Message)
+	}
+	quarantined := dirEntries(t, app.QuarantinePath)
+	if len(quarantined) != 1 || !regexp.MustCompile(`^upload_[0-9a-f]{32}\.xlsx$`).MatchString(quarantined[0]) {
+		t.Fatalf("隔离目录中的文件为%v，期望一个随机命名的文件", quarantined)
+	}
+	raw, err := os.ReadFile(app.QuarantinePath + "/" + quarantined[0])
+	if err != nil || string(raw) != "not a zip" {
+		t.Fatalf("隔离的文件内容为%q, %v", raw, err)
+	}
+
+	// 结构有效但无法解析的文件在解析失败后同样被隔离
+	rows := [][]interface{}{{"名称"}, {"a"}, {"b"}, {"c"}}
+	status, resp = upload(t, app.URL(uploadEndpoints[0]), "", "items.xlsx", workbook(t, 1, rows), nil)
+	if status != http.StatusBadRequest || !strings.Contains(resp.Message, "解析Excel文件失败") ||
+		!strings.Contains(resp.Message, "数据行数超过限制：最多2行") {
+		t.Fatalf("行数超过限制时返回%d %q", status, resp.Message)
+	}
+	if files := dirEntries(t, app.QuarantinePath); len(files) != 2 {
+		t.Fatalf("隔离目录中的文件为%v，期望2个", files)
+	}
+	if files := dirEntries(t, app.ImportPath); len(files) != 0 {
+		t.Fatalf("暂存目录中残留文件%v", files)
+	}
+}
+
+func TestUploadAcceptsValidFile(t *testing.T) {
+	app := apptest.New(t)
+	content := workbook(t, 1, [][]interface{}{{"名称"}, {"a"}})
+
+	// 后台导入在提交时只校验文件结构，文件移入文件存储后暂存目录为空
+	var job handler.JobResponse
+	status, resp := upload(t, app.URL(uploadEndpoints[1]), "", "../../items.XLSX", content, &job)
+	if status != http.StatusAccepted || job.ID == 0 {
+		t.Fatalf("提交导入任务返回%d %q", status, resp.Message)
+	}
+	if files := dirEntries(t, app.ImportPath); len(files) != 0 {
+		t.Fatalf("暂存目录中残留文件%v", files)
+	}
+}
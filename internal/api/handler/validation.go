@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/yourusername/cloud-eye/internal/pkg/mergepatch"
+)
+
+// codePattern 云服务商和云产品代码格式：字母或数字开头，可包含字母、数字、下划线、连字符和点
+var codePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// FieldError 单个字段的校验错误
+type FieldError struct {
+	Field   string `json:"field"`           // 字段的JSON名称
+	Reason  string `json:"reason"`          // 机器可读的原因，例如required、max、oneof、code、reference、type
+	Param   string `json:"param,omitempty"` // 校验规则的参数，例如max的上限
+	Message string `json:"message"`         // 错误说明
+}
+
+func init() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+
+	// 校验错误中使用JSON字段名
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+	_ = v.RegisterValidation("code", func(fl validator.FieldLevel) bool {
+		return codePattern.MatchString(fl.Field().String())
+	})
+	_ = v.RegisterValidation("reference", func(fl validator.FieldLevel) bool {
+		return validReference(fl.Field().String())
+	})
+}
+
+// validReference 校验参考资料中的链接：以空白分隔的内容中，包含"://"的部分必须是有效的http或https地址
+func validReference(reference string) bool {
+	for _, token := range strings.Fields(reference) {
+		if !strings.Contains(token, "://") {
+			continue
+		}
+		u, err := url.ParseRequestURI(token)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateMergePatch 将合并补丁应用到current对应的请求DTO，结果写入dst并按请求DTO的规则校验，
+// 校验失败时返回字段级错误响应；补丁格式错误和不允许修改的字段由服务层处理
+func (h *BaseHandler) ValidateMergePatch(c *gin.Context, current interface{}, patch []byte, dst interface{}) bool {
+	if fields := MergePatchErrors(current, patch, dst); len(fields) > 0 {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    4000,
+			Message: "请求参数校验失败",
+			Errors:  fields,
+		})
+		return false
+	}
+	return true
+}
+
+// ValidateRequest 按请求DTO的binding规则校验，返回字段级错误，供非HTTP接口复用
+func ValidateRequest(req interface{}) []FieldError {
+	return fieldErrors(binding.Validator.ValidateStruct(req))
+}
+
+// MergePatchErrors 将合并补丁应用到current后写入dst并校验，返回字段级错误
+func MergePatchErrors(current interface{}, patch []byte, dst interface{}) []FieldError {
+	doc, err := json.Marshal(current)
+	if err != nil {
+		return nil
+	}
+	merged, err := mergepatch.Apply(doc, patch)
+	if err != nil {
+		return nil
+	}
+
+	err = json.Unmarshal(merged, dst)
+	if err == nil {
+		err = binding.Validator.ValidateStruct(dst)
+	}
+	return fieldErrors(err)
+}
+
+// fieldErrors 将请求体绑定错误转换为字段级错误，无法定位到字段时返回nil
+func fieldErrors(err error) []FieldError {
+	if err == nil {
+		return nil
+	}
+
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		fields := make([]FieldError, 0, len(validationErrs))
+		for _, fe := range validationErrs {
+			fields = append(fields, FieldError{
+				Field:   fieldPath(fe.Namespace()),
+				Reason:  fe.Tag(),
+				Param:   fe.Param(),
+				Message: fieldErrorMessage(fe),
+			})
+		}
+		return fields
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return []FieldError{{
+			Field:   typeErr.Field,
+			Reason:  "type",
+			Param:   typeErr.Type.String(),
+			Message: "类型错误，不能将" + typeErr.Value + "转换为" + typeErr.Type.String(),
+		}}
+	}
+	return nil
+}
+
+// fieldPath 去掉校验错误命名空间中的结构体名，例如 ProviderRequest.code -> code
+func fieldPath(namespace string) string {
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return namespace
+}
+
+// fieldErrorMessage 返回校验规则对应的错误说明
+func fieldErrorMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "不能为空"
+	case "max":
+		return fmt.Sprintf("长度不能超过%s个字符", fe.Param())
+	case "min":
+		return fmt.Sprintf("不能小于%s", fe.Param())
+	case "oneof":
+		return "取值必须为以下之一: " + strings.ReplaceAll(fe.Param(), " ", ", ")
+	case "code":
+		return "只能包含字母、数字、下划线、连字符和点，且以字母或数字开头"
+	case "reference":
+		return "包含无效的链接，链接必须是http或https地址"
+	default:
+		return "校验失败: " + fe.Tag()
+	}
+}
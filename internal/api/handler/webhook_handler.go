@@ -0,0 +1,271 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+	"github.com/yourusername/cloud-eye/internal/repository"
+	"github.com/yourusername/cloud-eye/internal/service"
+	"go.uber.org/zap"
+)
+
+// WebhookHandler Webhook订阅API处理器
+type WebhookHandler struct {
+	BaseHandler
+	service service.WebhookService
+}
+
+// NewWebhookHandler 创建Webhook处理器
+func NewWebhookHandler(service service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		service: service,
+	}
+}
+
+// GetAll 获取所有Webhook订阅
+// @Summary 获取所有Webhook订阅
+// @Description 获取所有Webhook订阅，不返回签名密钥
+// @Tags Webhook
+// @Produce json
+// @Success 200 {object} Response{data=[]WebhookResponse} "成功"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/webhooks [get]
+func (h *WebhookHandler) GetAll(c *gin.Context) {
+	subscriptions, err := h.service.GetAllWebhooks(c)
+	if err != nil {
+		logger.Error("Failed to get all webhook subscriptions", err)
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	resp := make([]WebhookResponse, 0, len(subscriptions))
+	for i := range subscriptions {
+		resp = append(resp, *NewWebhookResponse(&subscriptions[i]))
+	}
+	h.Success(c, resp)
+}
+
+// GetByID 根据ID获取Webhook订阅
+// @Summary 获取Webhook订阅详情
+// @Description 根据ID获取Webhook订阅，不返回签名密钥
+// @Tags Webhook
+// @Produce json
+// @Param id path int true "订阅ID"
+// @Success 200 {object} Response{data=WebhookResponse} "成功"
+// @Failure 400 {object} Response "无效的ID参数"
+// @Failure 404 {object} Response "订阅不存在"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/webhooks/{id} [get]
+func (h *WebhookHandler) GetByID(c *gin.Context) {
+	id, ok := h.GetIDFromPath(c, "id")
+	if !ok {
+		return
+	}
+
+	subscription, err := h.service.GetWebhookByID(c, id)
+	if err != nil {
+		logger.Error("Failed to get webhook subscription by ID", err, zap.Uint("id", id))
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.Success(c, NewWebhookResponse(subscription))
+}
+
+// Create 创建Webhook订阅
+// @Summary 创建Webhook订阅
+// @Description 创建Webhook订阅，未提供签名密钥时自动生成；签名密钥只在创建时返回
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Param webhook body WebhookRequest true "订阅信息"
+// @Success 200 {object} Response{data=WebhookResponse} "成功"
+// @Failure 400 {object} Response "无效的请求参数"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/webhooks [post]
+func (h *WebhookHandler) Create(c *gin.Context) {
+	var req WebhookRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	subscription := req.Model()
+	err := h.service.CreateWebhook(c, subscription)
+	if err != nil {
+		logger.Error("Failed to create webhook subscription", err)
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	resp := NewWebhookResponse(subscription)
+	resp.Secret = subscription.Secret
+	h.Success(c, resp)
+}
+
+// Update 更新Webhook订阅
+// @Summary 更新Webhook订阅
+// @Description 整体更新Webhook订阅，secret为空时保留原签名密钥
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Param id path int true "订阅ID"
+// @Param webhook body WebhookRequest true "订阅信息"
+// @Success 200 {object} Response{data=WebhookResponse} "成功"
+// @Failure 400 {object} Response "无效的请求参数"
+// @Failure 404 {object} Response "订阅不存在"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/webhooks/{id} [put]
+func (h *WebhookHandler) Update(c *gin.Context) {
+	id, ok := h.GetIDFromPath(c, "id")
+	if !ok {
+		return
+	}
+
+	var req WebhookRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	subscription := req.Model()
+	subscription.ID = id
+	err := h.service.UpdateWebhook(c, subscription)
+	if err != nil {
+		logger.Error("Failed to update webhook subscription", err, zap.Uint("id", id))
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.Success(c, NewWebhookResponse(subscription))
+}
+
+// Delete 删除Webhook订阅
+// @Summary 删除Webhook订阅
+// @Description 删除Webhook订阅及其投递记录，未完成的投递不再发送
+// @Tags Webhook
+// @Produce json
+// @Param id path int true "订阅ID"
+// @Success 200 {object} Response "成功"
+// @Failure 400 {object} Response "无效的ID参数"
+// @Failure 404 {object} Response "订阅不存在"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/webhooks/{id} [delete]
+func (h *WebhookHandler) Delete(c *gin.Context) {
+	id, ok := h.GetIDFromPath(c, "id")
+	if !ok {
+		return
+	}
+
+	err := h.service.DeleteWebhook(c, id)
+	if err != nil {
+		logger.Error("Failed to delete webhook subscription", err, zap.Uint("id", id))
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.Success(c, gin.H{"message": "Webhook订阅删除成功"})
+}
+
+// ListDeliveries 获取投递记录
+// @Summary 获取投递记录
+// @Description 按时间倒序分页获取订阅的投递记录，不返回请求体
+// @Tags Webhook
+// @Produce json
+// @Param id path int true "订阅ID"
+// @Param status query string false "投递状态：pending, succeeded, failed"
+// @Param event query string false "事件类型"
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页记录数，默认10"
+// @Success 200 {object} Response{data=repository.PageResult{data=[]models.WebhookDelivery}} "成功"
+// @Failure 400 {object} Response "无效的请求参数"
+// @Failure 404 {object} Response "订阅不存在"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	id, ok := h.GetIDFromPath(c, "id")
+	if !ok {
+		return
+	}
+
+	status, _ := h.GetQueryParam(c, "status")
+	event, _ := h.GetQueryParam(c, "event")
+	filter := repository.DeliveryFilter{
+		SubscriptionID: id,
+		Status:         status,
+		Event:          event,
+		Page:           h.GetIntQueryParam(c, "page", 1),
+		PageSize:       h.GetIntQueryParam(c, "page_size", 10),
+	}
+
+	result, err := h.service.ListDeliveries(c, filter)
+	if err != nil {
+		logger.Error("Failed to list webhook deliveries", err, zap.Uint("id", id))
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.Success(c, result)
+}
+
+// GetDelivery 获取投递记录详情
+// @Summary 获取投递记录详情
+// @Description 获取投递记录，包含请求体和最近一次投递的响应
+// @Tags Webhook
+// @Produce json
+// @Param id path int true "订阅ID"
+// @Param delivery_id path int true "投递记录ID"
+// @Success 200 {object} Response{data=models.WebhookDelivery} "成功"
+// @Failure 400 {object} Response "无效的ID参数"
+// @Failure 404 {object} Response "投递记录不存在"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/webhooks/{id}/deliveries/{delivery_id} [get]
+func (h *WebhookHandler) GetDelivery(c *gin.Context) {
+	id, ok := h.GetIDFromPath(c, "id")
+	if !ok {
+		return
+	}
+	deliveryID, ok := h.GetIDFromPath(c, "delivery_id")
+	if !ok {
+		return
+	}
+
+	delivery, err := h.service.GetDelivery(c, id, deliveryID)
+	if err != nil {
+		logger.Error("Failed to get webhook delivery", err, zap.Uint("id", id), zap.Uint("deliveryId", deliveryID))
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.Success(c, delivery)
+}
+
+// Redeliver 重新投递
+// @Summary 重新投递
+// @Description 以原请求体和事件ID创建新的投递记录并立即投递，原记录保持不变
+// @Tags Webhook
+// @Produce json
+// @Param id path int true "订阅ID"
+// @Param delivery_id path int true "投递记录ID"
+// @Success 200 {object} Response{data=models.WebhookDelivery} "成功"
+// @Failure 400 {object} Response "无效的ID参数"
+// @Failure 404 {object} Response "投递记录不存在"
+// @Failure 409 {object} Response "订阅已停用"
+// @Failure 500 {object} Response "服务器内部错误"
+// @Router /api/v1/webhooks/{id}/deliveries/{delivery_id}/redeliver [post]
+func (h *WebhookHandler) Redeliver(c *gin.Context) {
+	id, ok := h.GetIDFromPath(c, "id")
+	if !ok {
+		return
+	}
+	deliveryID, ok := h.GetIDFromPath(c, "delivery_id")
+	if !ok {
+		return
+	}
+
+	delivery, err := h.service.Redeliver(c, id, deliveryID)
+	if err != nil {
+		logger.Error("Failed to redeliver webhook", err, zap.Uint("id", id), zap.Uint("deliveryId", deliveryID))
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.Success(c, delivery)
+}
@@ -0,0 +1,151 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yourusername/cloud-eye/internal/apptest"
+	"github.com/yourusername/cloud-eye/internal/pkg/webhook"
+	"github.com/yourusername/cloud-eye/internal/service"
+)
+
+const testWebhookSecret = "0123456789abcdef"
+
+// webhookReceiver 记录收到的Webhook事件，格式为"事件类型:配置项ID"
+type webhookReceiver struct {
+	mu       sync.Mutex
+	received []string
+	latestOf map[uint]service.ConfigItemEventData
+	errs     []string
+}
+
+func newWebhookReceiver(t *testing.T) (*webhookReceiver, *httptest.Server) {
+	r := &webhookReceiver{latestOf: make(map[uint]service.ConfigItemEventData)}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		if !webhook.Verify(testWebhookSecret, req.Header.Get(webhook.HeaderTimestamp), body, req.Header.Get(webhook.HeaderSignature), time.Minute) {
+			r.errs = append(r.errs, "签名校验失败")
+		}
+		var event struct {
+			Event string                      `json:"event"`
+			Data  service.ConfigItemEventData `json:"data"`
+		}
+		if err := json.Unmarshal(body, &event); err != nil {
+			r.errs = append(r.errs, "解析事件失败: "+err.Error())
+		}
+		r.received = append(r.received, fmt.Sprintf("%s:%d", event.Event, event.Data.ID))
+		r.latestOf[event.Data.ID] = event.Data
+	}))
+	t.Cleanup(server.Close)
+	return r, server
+}
+
+// latest 返回最后收到的配置项事件数据
+func (r *webhookReceiver) latest(id uint) service.ConfigItemEventData {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.latestOf[id]
+}
+
+// expect 等待收到want中的全部事件（不要求顺序），随后清空已收到的事件
+func (r *webhookReceiver) expect(t *testing.T, step string, want ...string) {
+	t.Helper()
+	sort.Strings(want)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		r.mu.Lock()
+		got := append([]string(nil), r.received...)
+		errs := r.errs
+		r.mu.Unlock()
+
+		if len(errs) > 0 {
+			t.Fatalf("%s: %s", step, strings.Join(errs, "; "))
+		}
+		if len(got) >= len(want) || time.Now().After(deadline) {
+			// 多出的事件可能稍后到达，稍等后再比较
+			time.Sleep(50 * time.Millisecond)
+			r.mu.Lock()
+			got = append([]string(nil), r.received...)
+			r.received = nil
+			r.mu.Unlock()
+
+			sort.Strings(got)
+			if strings.Join(got, ",") != strings.Join(want, ",") {
+				t.Fatalf("%s: 收到的事件为%v，期望%v", step, got, want)
+			}
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestWebhookDeliversEventsForCascadesAndAssociationChanges(t *testing.T) {
+	receiver, hook := newWebhookReceiver(t)
+	app := apptest.NewWithOptions(t, apptest.Options{
+		Webhook: service.WebhookOptions{PollInterval: 10 * time.Millisecond, RetryBackoff: 10 * time.Millisecond},
+	})
+
+	status, resp := doJSON(t, http.MethodPost, app.URL("/api/v1/webhooks"), map[string]interface{}{
+		"name": "测试接收方", "url": hook.URL, "secret": testWebhookSecret, "events": []string{"*"},
+	}, nil)
+	if status != http.StatusOK {
+		t.Fatalf("创建订阅返回%d: %s", status, resp.Message)
+	}
+
+	// 演示数据中Azure（ID为2）的产品下有配置项7至10
+	mustRequest(t, app, http.MethodDelete, "/api/v1/cloud-providers/2?cascade=true", nil)
+	receiver.expect(t, "级联删除云服务商",
+		"config_item.deleted:7", "config_item.deleted:8", "config_item.deleted:9", "config_item.deleted:10")
+
+	mustRequest(t, app, http.MethodPost, "/api/v1/trash/provider/2/restore", nil)
+	receiver.expect(t, "恢复云服务商",
+		"config_item.created:7", "config_item.created:8", "config_item.created:9", "config_item.created:10")
+
+	mustRequest(t, app, http.MethodDelete, "/api/v1/cloud-products/4", nil)
+	receiver.expect(t, "删除云产品", "config_item.deleted:7", "config_item.deleted:8")
+
+	mustRequest(t, app, http.MethodPost, "/api/v1/tags/attach", map[string]interface{}{"target": "config_item", "ids": []uint{1, 2}, "tags": []string{"加密"}})
+	receiver.expect(t, "添加标签", "config_item.updated:1", "config_item.updated:2")
+	if tags := receiver.latest(1).Tags; !containsString(tags, "加密") {
+		t.Fatalf("添加标签后事件中的标签为%v", tags)
+	}
+
+	// 配置项3没有该标签，不产生事件
+	mustRequest(t, app, http.MethodPost, "/api/v1/tags/detach", map[string]interface{}{"target": "config_item", "ids": []uint{1, 3}, "tags": []string{"加密"}})
+	receiver.expect(t, "移除标签", "config_item.updated:1")
+	if tags := receiver.latest(1).Tags; containsString(tags, "加密") {
+		t.Fatalf("移除标签后事件中的标签为%v", tags)
+	}
+
+	mustRequest(t, app, http.MethodPost, "/api/v1/control-families/2/config-items", map[string]interface{}{"config_item_ids": []uint{1, 5}})
+	receiver.expect(t, "加入控制族", "config_item.updated:1")
+	if family := receiver.latest(1).ControlFamilyID; family == nil || *family != 2 {
+		t.Fatalf("加入控制族后事件中的控制族为%v", family)
+	}
+
+	mustRequest(t, app, http.MethodDelete, "/api/v1/control-families/2", nil)
+	receiver.expect(t, "删除控制族", "config_item.updated:1", "config_item.updated:5")
+	if family := receiver.latest(5).ControlFamilyID; family != nil {
+		t.Fatalf("删除控制族后事件中的控制族为%v", *family)
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,212 @@
+// Package openapi 由路由表和请求/响应DTO类型生成OpenAPI 3文档，并校验文档与已注册的路由一致
+package openapi
+
+import (
+	"embed"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Version OpenAPI规范版本
+const Version = "3.0.3"
+
+//go:embed docs.html swagger-ui/swagger-ui-bundle.js swagger-ui/swagger-ui.css
+var docsFS embed.FS
+
+// docsAssets 文档页面引用的静态资源及其类型
+var docsAssets = map[string]string{
+	"swagger-ui-bundle.js": "application/javascript; charset=utf-8",
+	"swagger-ui.css":       "text/css; charset=utf-8",
+}
+
+// Document OpenAPI文档
+type Document struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       Info                             `json:"info"`
+	Tags       []Tag                            `json:"tags,omitempty"`
+	Paths      map[string]map[string]*Operation `json:"paths"`
+	Components Components                       `json:"components"`
+}
+
+// Info 文档基本信息
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Tag 接口分组
+type Tag struct {
+	Name string `json:"name"`
+}
+
+// Components 可复用的Schema定义
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// Operation 单个接口
+type Operation struct {
+	Tags        []string             `json:"tags,omitempty"`
+	Summary     string               `json:"summary,omitempty"`
+	Description string               `json:"description,omitempty"`
+	OperationID string               `json:"operationId,omitempty"`
+	Parameters  []*Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// Parameter 路径、查询或请求头参数
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+	Style       string  `json:"style,omitempty"`
+	Explode     *bool   `json:"explode,omitempty"`
+}
+
+// RequestBody 请求体
+type RequestBody struct {
+	Required bool                  `json:"required,omitempty"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+// Response 响应
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// MediaType 某种内容类型的Schema
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+var (
+	specOnce sync.Once
+	spec     *Document
+)
+
+// Spec 返回API文档，首次调用时生成
+func Spec() *Document {
+	specOnce.Do(func() {
+		spec = build(operations())
+	})
+	return spec
+}
+
+// build 由接口定义生成文档
+func build(ops []operation) *Document {
+	reg := newSchemaRegistry()
+	doc := &Document{
+		OpenAPI: Version,
+		Info: Info{
+			Title:       "CloudEye API",
+			Description: "云服务商安全配置基线管理接口。除文档和健康检查外，所有接口返回统一的Response结构，业务数据在data字段中",
+			Version:     "1.0.0",
+		},
+		Paths: make(map[string]map[string]*Operation),
+	}
+	// 统一响应结构始终登记，便于客户端生成代码
+	envelope := reg.schemaOf(responseType)
+
+	seenTags := make(map[string]bool)
+	for _, op := range ops {
+		path := openAPIPath(op.path)
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = make(map[string]*Operation)
+		}
+		doc.Paths[path][strings.ToLower(op.method)] = op.build(reg, envelope)
+
+		if op.tag != "" && !seenTags[op.tag] {
+			seenTags[op.tag] = true
+			doc.Tags = append(doc.Tags, Tag{Name: op.tag})
+		}
+	}
+
+	doc.Components.Schemas = reg.schemas
+	return doc
+}
+
+// Verify 比较已注册的路由与文档，返回缺少文档的路由和没有对应路由的文档条目，格式为"METHOD /path"
+func Verify(routes gin.RoutesInfo) (undocumented, unrouted []string) {
+	doc := Spec()
+
+	routed := make(map[string]bool, len(routes))
+	for _, route := range routes {
+		path := openAPIPath(route.Path)
+		key := route.Method + " " + path
+		routed[key] = true
+		if _, ok := doc.Paths[path][strings.ToLower(route.Method)]; !ok {
+			undocumented = append(undocumented, key)
+		}
+	}
+
+	for path, item := range doc.Paths {
+		for method := range item {
+			key := strings.ToUpper(method) + " " + path
+			if !routed[key] {
+				unrouted = append(unrouted, key)
+			}
+		}
+	}
+
+	sort.Strings(undocumented)
+	sort.Strings(unrouted)
+	return undocumented, unrouted
+}
+
+// openAPIPath 将Gin路径参数转换为OpenAPI格式，例如 /items/:id -> /items/{id}
+func openAPIPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, seg := range segments {
+		if strings.HasPrefix(seg, ":") || strings.HasPrefix(seg, "*") {
+			segments[i] = "{" + seg[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// SpecHandler 返回OpenAPI文档
+func SpecHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, Spec())
+}
+
+// DocsHandler 返回内嵌的API文档页面
+func DocsHandler(c *gin.Context) {
+	page, err := docsFS.ReadFile("docs.html")
+	if err != nil {
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+	c.Data(http.StatusOK, "text/html; charset=utf-8", page)
+}
+
+// DocsAssetHandler 返回内嵌的Swagger UI静态资源
+func DocsAssetHandler(c *gin.Context) {
+	file := c.Param("file")
+	contentType, ok := docsAssets[file]
+	if !ok {
+		c.Status(http.StatusNotFound)
+		return
+	}
+	asset, err := docsFS.ReadFile("swagger-ui/" + file)
+	if err != nil {
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+	c.Header("Cache-Control", "public, max-age=86400")
+	c.Data(http.StatusOK, contentType, asset)
+}
+
+// typeOf 返回值的反射类型，用于在接口定义中引用DTO类型
+func typeOf(v interface{}) reflect.Type {
+	return reflect.TypeOf(v)
+}
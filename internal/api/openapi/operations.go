@@ -0,0 +1,610 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+
+	"github.com/yourusername/cloud-eye/internal/api/handler"
+	"github.com/yourusername/cloud-eye/internal/models"
+	"github.com/yourusername/cloud-eye/internal/pkg/evaluation"
+	"github.com/yourusername/cloud-eye/internal/pkg/graphql"
+	"github.com/yourusername/cloud-eye/internal/repository"
+	"github.com/yourusername/cloud-eye/internal/service"
+)
+
+// 接口分组名称
+const (
+	tagProvider   = "云服务商"
+	tagProduct    = "云产品"
+	tagConfigItem = "配置项"
+	tagFamily     = "控制族"
+	tagCategory   = "产品类别"
+	tagTag        = "标签"
+	tagTrash      = "回收站"
+	tagStats      = "统计分析"
+	tagWebhook    = "Webhook"
+	tagEvents     = "变更推送"
+	tagJobs       = "后台任务"
+	tagFiles      = "文件"
+	tagGraphQL    = "GraphQL"
+	tagSystem     = "系统"
+)
+
+var responseType = typeOf(handler.Response{})
+
+// errorDescriptions 错误状态码的说明
+var errorDescriptions = map[int]string{
+	http.StatusBadRequest:            "无效的请求参数，参数校验失败时errors列出每个无效字段",
+	http.StatusForbidden:             "管理员令牌无效，或下载链接无效或已过期",
+	http.StatusNotFound:              "资源不存在",
+	http.StatusConflict:              "数据冲突",
+	http.StatusRequestEntityTooLarge: "上传文件超过大小限制",
+	http.StatusUnsupportedMediaType:  "不支持的Content-Type",
+	http.StatusUnprocessableEntity:   "批量操作存在失败项，已全部回滚",
+	http.StatusInternalServerError:   "服务器内部错误",
+}
+
+// operation 接口定义，path使用Gin路由格式
+type operation struct {
+	method      string
+	path        string
+	tag         string
+	id          string
+	summary     string
+	description string
+	params      []*Parameter
+	bodyType    reflect.Type
+	bodyMedia   string
+	bodySchema  *Schema
+	data        func(r *schemaRegistry) *Schema
+	plain       bool   // 响应不使用统一的Response结构
+	media       string // 成功响应的内容类型，为空时为application/json
+	status      int    // 成功响应的状态码，为0时为200
+	errors      []int
+}
+
+func op(method, path, tag, id, summary string) *operation {
+	return &operation{method: method, path: path, tag: tag, id: id, summary: summary}
+}
+
+// describe 设置接口说明
+func (o *operation) describe(description string) *operation {
+	o.description = description
+	return o
+}
+
+// with 添加参数
+func (o *operation) with(params ...*Parameter) *operation {
+	o.params = append(o.params, params...)
+	return o
+}
+
+// body 设置JSON请求体类型
+func (o *operation) body(v interface{}) *operation {
+	o.bodyType = typeOf(v)
+	o.bodyMedia = "application/json"
+	return o
+}
+
+// rawBody 设置指定内容类型的请求体
+func (o *operation) rawBody(media string, schema *Schema) *operation {
+	o.bodyMedia = media
+	o.bodySchema = schema
+	return o
+}
+
+// returns 设置Response.data的类型
+func (o *operation) returns(v interface{}) *operation {
+	t := typeOf(v)
+	o.data = func(r *schemaRegistry) *Schema { return r.schemaOf(t) }
+	return o
+}
+
+// returnsList 设置Response.data为列表，分页查询时为分页结果
+func (o *operation) returnsList(v interface{}) *operation {
+	t := typeOf(v)
+	o.data = func(r *schemaRegistry) *Schema {
+		return &Schema{OneOf: []*Schema{r.schemaOf(t), pageOf(r, t.Elem())}}
+	}
+	return o
+}
+
+// returnsPage 设置Response.data为分页结果
+func (o *operation) returnsPage(item interface{}) *operation {
+	t := typeOf(item)
+	o.data = func(r *schemaRegistry) *Schema { return pageOf(r, t) }
+	return o
+}
+
+// accepted 成功响应的状态码为202，用于提交后台任务的接口
+func (o *operation) accepted() *operation {
+	o.status = http.StatusAccepted
+	return o
+}
+
+// download 成功响应为下载文件
+func (o *operation) download() *operation {
+	o.plain = true
+	o.media = "application/octet-stream"
+	return o
+}
+
+// fails 设置可能返回的错误状态码
+func (o *operation) fails(codes ...int) *operation {
+	o.errors = codes
+	return o
+}
+
+// build 生成OpenAPI接口对象
+func (o *operation) build(r *schemaRegistry, envelope *Schema) *Operation {
+	result := &Operation{
+		Summary:     o.summary,
+		Description: o.description,
+		OperationID: o.id,
+		Parameters:  o.params,
+		Responses:   make(map[string]*Response),
+	}
+	if o.tag != "" {
+		result.Tags = []string{o.tag}
+	}
+
+	if o.bodyMedia != "" {
+		schema := o.bodySchema
+		if schema == nil {
+			schema = r.schemaOf(o.bodyType)
+		}
+		result.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]*MediaType{o.bodyMedia: {Schema: schema}},
+		}
+	}
+
+	success := &Response{Description: "成功"}
+	switch {
+	case o.media != "":
+		success.Content = map[string]*MediaType{o.media: {Schema: &Schema{Type: "string"}}}
+	case o.plain && o.data != nil:
+		success.Content = jsonContent(o.data(r))
+	case o.plain:
+	case o.data != nil:
+		data := &Schema{Type: "object", Properties: map[string]*Schema{"data": o.data(r)}}
+		success.Content = jsonContent(&Schema{AllOf: []*Schema{envelope, data}})
+	default:
+		success.Content = jsonContent(envelope)
+	}
+	status := o.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	result.Responses[strconv.Itoa(status)] = success
+
+	for _, code := range o.errors {
+		result.Responses[strconv.Itoa(code)] = &Response{
+			Description: errorDescriptions[code],
+			Content:     jsonContent(envelope),
+		}
+	}
+	return result
+}
+
+func jsonContent(schema *Schema) map[string]*MediaType {
+	return map[string]*MediaType{"application/json": {Schema: schema}}
+}
+
+// pageOf 数据为指定类型列表的分页结果
+func pageOf(r *schemaRegistry, item reflect.Type) *Schema {
+	data := &Schema{Type: "object", Properties: map[string]*Schema{
+		"data": {Type: "array", Items: r.schemaOf(item)},
+	}}
+	return &Schema{AllOf: []*Schema{r.schemaOf(typeOf(repository.PageResult{})), data}}
+}
+
+// 参数构造函数
+
+func pathParam(name, typ, description string) *Parameter {
+	return &Parameter{Name: name, In: "path", Description: description, Required: true, Schema: &Schema{Type: typ}}
+}
+
+func idParam(description string) *Parameter {
+	return pathParam("id", "integer", description)
+}
+
+func queryParam(name, typ, description string) *Parameter {
+	return &Parameter{Name: name, In: "query", Description: description, Schema: &Schema{Type: typ}}
+}
+
+// queryList 逗号分隔或重复传递的多值查询参数
+func queryList(name, itemType, description string) *Parameter {
+	explode := false
+	return &Parameter{
+		Name:        name,
+		In:          "query",
+		Description: description,
+		Schema:      &Schema{Type: "array", Items: &Schema{Type: itemType}},
+		Style:       "form",
+		Explode:     &explode,
+	}
+}
+
+// listParams 列表查询的通用参数：时间范围、排序、字段选择和分页
+func listParams(include string) []*Parameter {
+	params := []*Parameter{
+		queryParam("created_from", "string", "创建时间起（RFC3339或YYYY-MM-DD）"),
+		queryParam("created_to", "string", "创建时间止（RFC3339或YYYY-MM-DD）"),
+		queryParam("updated_from", "string", "更新时间起（RFC3339或YYYY-MM-DD）"),
+		queryParam("updated_to", "string", "更新时间止（RFC3339或YYYY-MM-DD）"),
+		queryParam("sort", "string", "排序字段，逗号分隔，前缀-表示降序，例如 -updated_at,name"),
+		queryParam("fields", "string", "仅返回的字段，逗号分隔"),
+		queryParam("cursor", "string", "游标分页位置，传空值从第一页开始；使用游标时忽略page且不统计总数"),
+	}
+	params = append(params, pageParams()...)
+	if include != "" {
+		params = append(params, queryParam("include", "string", include))
+	}
+	return params
+}
+
+func pageParams() []*Parameter {
+	return []*Parameter{
+		queryParam("page", "integer", "页码，默认1"),
+		queryParam("page_size", "integer", "每页记录数，默认10"),
+	}
+}
+
+// configItemFilterParams 配置项列表和导出共用的筛选参数
+func configItemFilterParams() []*Parameter {
+	return []*Parameter{
+		queryList("cloud_provider_id", "integer", "云服务商ID，可传多个"),
+		queryList("product_id", "integer", "产品ID，可传多个"),
+		queryList("category_id", "integer", "产品类别ID，可传多个，包含子类别"),
+		queryList("tag", "string", "标签名称，可传多个"),
+		queryParam("tag_match", "string", "多个标签的匹配方式：or包含任一标签（默认），and包含全部标签"),
+		queryParam("keyword", "string", "关键词搜索"),
+	}
+}
+
+// mergePatchBody JSON合并补丁请求体
+func mergePatchBody(fields string) *Schema {
+	return &Schema{
+		Type:        "object",
+		Description: "JSON合并补丁（RFC 7396），可包含" + fields + "中的字段，值为null表示清空",
+	}
+}
+
+// operations 所有接口定义，新增路由时需在此补充，router初始化时会校验两者一致
+func operations() []operation {
+	trashType := pathParam("type", "string", "实体类型：provider, product, config_item")
+	fail := []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError}
+	write := []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusInternalServerError}
+	patch := []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusUnsupportedMediaType, http.StatusInternalServerError}
+	query := []int{http.StatusBadRequest, http.StatusInternalServerError}
+	internal := []int{http.StatusInternalServerError}
+	upload := []int{http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusInternalServerError}
+
+	ops := []*operation{
+		// 云服务商
+		op("GET", "/api/v1/cloud-providers", tagProvider, "listCloudProviders", "获取所有云服务商").
+			describe("获取所有云服务商，支持按代码、关键字和时间范围筛选，传page、page_size或cursor时返回分页结果").
+			with(queryList("code", "string", "云服务商代码，可传多个"),
+				queryParam("keyword", "string", "关键字，匹配名称、代码和描述"),
+				queryParam("with_counts", "boolean", "是否返回每个服务商的产品数product_count")).
+			with(listParams("加载的关联：products，默认不加载")...).
+			returnsList([]handler.ProviderResponse{}).fails(query...),
+		op("GET", "/api/v1/cloud-providers/:id", tagProvider, "getCloudProvider", "获取云服务商详情").
+			with(idParam("云服务商ID")).returns(handler.ProviderResponse{}).fails(fail...),
+		op("POST", "/api/v1/cloud-providers", tagProvider, "createCloudProvider", "创建云服务商").
+			body(handler.ProviderRequest{}).returns(handler.ProviderResponse{}).
+			fails(http.StatusBadRequest, http.StatusConflict, http.StatusInternalServerError),
+		op("PUT", "/api/v1/cloud-providers/:id", tagProvider, "updateCloudProvider", "更新云服务商").
+			describe("整体替换云服务商，未提供的字段将被清空").
+			with(idParam("云服务商ID")).body(handler.ProviderRequest{}).fails(write...),
+		op("PATCH", "/api/v1/cloud-providers/:id", tagProvider, "patchCloudProvider", "部分更新云服务商").
+			with(idParam("云服务商ID")).rawBody(handler.MergePatchContentType, mergePatchBody("ProviderRequest")).
+			returns(handler.ProviderResponse{}).fails(patch...),
+		op("DELETE", "/api/v1/cloud-providers/:id", tagProvider, "deleteCloudProvider", "删除云服务商").
+			describe("删除云服务商并移入回收站，存在产品时需传cascade=true一并删除产品和配置项").
+			with(idParam("云服务商ID"), queryParam("cascade", "boolean", "是否一并删除产品和配置项")).fails(write...),
+		op("GET", "/api/v1/cloud-providers/:id/products", tagProduct, "listProviderProducts", "获取指定云服务商的产品列表").
+			with(pathParam("id", "integer", "云服务商ID")).returns([]handler.ProductResponse{}).fails(fail...),
+		op("GET", "/api/v1/cloud-providers/code/:provider_code/products", tagProduct, "listProviderProductsByCode", "获取指定云服务商代码的产品列表").
+			with(pathParam("provider_code", "string", "云服务商代码")).returns([]handler.ProductResponse{}).
+			fails(http.StatusNotFound, http.StatusInternalServerError),
+		op("GET", "/api/v1/cloud-providers/:id/products/:product_id/config-items", tagConfigItem, "listProviderProductConfigItems", "获取指定云服务商和产品的配置项列表").
+			with(pathParam("id", "integer", "云服务商ID"), pathParam("product_id", "integer", "产品ID")).
+			returns([]handler.ConfigItemResponse{}).fails(fail...),
+
+		// 云产品
+		op("GET", "/api/v1/cloud-products", tagProduct, "listCloudProducts", "获取所有云产品").
+			describe("获取所有云产品，支持按服务商、代码、类别、关键字和时间范围筛选，传page、page_size或cursor时返回分页结果").
+			with(queryList("cloud_provider_id", "integer", "云服务商ID，可传多个"),
+				queryList("code", "string", "产品代码，可传多个"),
+				queryList("category_id", "integer", "产品类别ID，可传多个，包含子类别"),
+				queryParam("keyword", "string", "关键字，匹配名称、代码和描述"),
+				queryParam("with_counts", "boolean", "是否返回每个产品的配置项数config_item_count")).
+			with(listParams("加载的关联：provider,category,config_items，默认不加载")...).
+			returnsList([]handler.ProductResponse{}).fails(query...),
+		op("GET", "/api/v1/cloud-products/:id", tagProduct, "getCloudProduct", "获取云产品详情").
+			with(idParam("云产品ID")).returns(handler.ProductResponse{}).fails(fail...),
+		op("POST", "/api/v1/cloud-products", tagProduct, "createCloudProduct", "创建云产品").
+			body(handler.ProductRequest{}).returns(handler.ProductResponse{}).fails(write...),
+		op("PUT", "/api/v1/cloud-products/:id", tagProduct, "updateCloudProduct", "更新云产品").
+			describe("整体替换云产品，未提供的字段将被清空").
+			with(idParam("云产品ID")).body(handler.ProductRequest{}).fails(write...),
+		op("PATCH", "/api/v1/cloud-products/:id", tagProduct, "patchCloudProduct", "部分更新云产品").
+			with(idParam("云产品ID")).rawBody(handler.MergePatchContentType, mergePatchBody("ProductRequest")).
+			returns(handler.ProductResponse{}).fails(patch...),
+		op("DELETE", "/api/v1/cloud-products/:id", tagProduct, "deleteCloudProduct", "删除云产品").
+			with(idParam("云产品ID")).fails(fail...),
+
+		// 配置项
+		op("GET", "/api/v1/config-items", tagConfigItem, "listConfigItems", "获取配置项列表").
+			with(configItemFilterParams()...).
+			with(listParams("加载的关联：provider,product,product.category,tags，默认加载provider,product，传空值不加载")...).
+			returnsPage(handler.ConfigItemResponse{}).fails(query...),
+		op("GET", "/api/v1/config-items/:id", tagConfigItem, "getConfigItem", "获取配置项详情").
+			with(idParam("配置项ID")).returns(handler.ConfigItemResponse{}).fails(fail...),
+		op("POST", "/api/v1/config-items", tagConfigItem, "createConfigItem", "创建配置项").
+			body(handler.ConfigItemRequest{}).returns(handler.ConfigItemResponse{}).fails(fail...),
+		op("PUT", "/api/v1/config-items/:id", tagConfigItem, "updateConfigItem", "更新配置项").
+			describe("整体替换配置项，未提供的字段将被清空").
+			with(idParam("配置项ID")).body(handler.ConfigItemRequest{}).fails(fail...),
+		op("PATCH", "/api/v1/config-items/:id", tagConfigItem, "patchConfigItem", "部分更新配置项").
+			with(idParam("配置项ID")).rawBody(handler.MergePatchContentType, mergePatchBody("ConfigItemRequest")).
+			returns(handler.ConfigItemResponse{}).
+			fails(http.StatusBadRequest, http.StatusNotFound, http.StatusUnsupportedMediaType, http.StatusInternalServerError),
+		op("DELETE", "/api/v1/config-items/:id", tagConfigItem, "deleteConfigItem", "删除配置项").
+			with(idParam("配置项ID")).fails(fail...),
+		op("POST", "/api/v1/config-items/bulk", tagConfigItem, "bulkConfigItems", "批量操作配置项").
+			describe("在一个事务中执行最多500项创建、更新、删除、移动和标签操作，atomic模式下任一失败则全部回滚").
+			body(handler.ConfigItemBulkRequest{}).returns(service.ConfigItemBulkResponse{}).
+			fails(http.StatusBadRequest, http.StatusUnprocessableEntity, http.StatusInternalServerError),
+		op("POST", "/api/v1/config-items/evaluate", tagConfigItem, "evaluateResources", "评估资源配置").
+			describe("使用全部生效中的配置项评估资源配置，检查方法中以check:开头的行为可自动执行的规则").
+			body(handler.EvaluationRequest{}).returns(evaluation.Report{}).
+			fails(http.StatusBadRequest, http.StatusInternalServerError),
+		op("GET", "/api/v1/config-items/export", tagConfigItem, "exportConfigItems", "导出配置项到Excel").
+			describe("根据过滤条件导出配置项到Excel文件，返回带签名的下载链接，链接过期前可通过下载文件接口下载").
+			with(configItemFilterParams()...).
+			with(queryParam("sort", "string", "排序字段，逗号分隔，前缀-表示降序"),
+				queryParam("group_by", "string", "分组维度：category按产品类别分组排列，未分类的排在最后")).
+			returns(handler.FileResponse{}).fails(query...),
+		op("POST", "/api/v1/config-items/import", tagConfigItem, "importConfigItems", "从Excel导入配置项").
+			rawBody("multipart/form-data", &Schema{
+				Type:       "object",
+				Properties: map[string]*Schema{"file": {Type: "string", Format: "binary", Description: "Excel文件"}},
+				Required:   []string{"file"},
+			}).
+			fails(upload...),
+		op("GET", "/api/v1/config-items/import-template", tagConfigItem, "downloadImportTemplate", "下载配置项导入模板").
+			describe("生成配置项导入模板，云服务商和云产品列提供现有数据的下拉列表，填写说明工作表包含填写规则和示例").
+			download().fails(http.StatusInternalServerError),
+		op("GET", "/api/v1/search/config-items", tagConfigItem, "searchConfigItems", "全文检索配置项").
+			with(&Parameter{Name: "q", In: "query", Description: "检索语句", Required: true, Schema: &Schema{Type: "string"}},
+				queryParam("cloud_provider_id", "integer", "云服务商ID"),
+				queryParam("product_id", "integer", "产品ID")).
+			with(pageParams()...).
+			returnsPage(repository.ConfigItemSearchHit{}).fails(query...),
+
+		// 控制族
+		op("GET", "/api/v1/control-families", tagFamily, "listControlFamilies", "获取所有控制族").
+			returns([]models.ControlFamily{}).fails(internal...),
+		op("GET", "/api/v1/control-families/:id", tagFamily, "getControlFamily", "获取控制族详情").
+			with(idParam("控制族ID")).returns(models.ControlFamily{}).fails(fail...),
+		op("POST", "/api/v1/control-families", tagFamily, "createControlFamily", "创建控制族").
+			body(models.ControlFamily{}).returns(models.ControlFamily{}).
+			fails(http.StatusBadRequest, http.StatusConflict, http.StatusInternalServerError),
+		op("PUT", "/api/v1/control-families/:id", tagFamily, "updateControlFamily", "更新控制族").
+			with(idParam("控制族ID")).body(models.ControlFamily{}).fails(write...),
+		op("DELETE", "/api/v1/control-families/:id", tagFamily, "deleteControlFamily", "删除控制族").
+			with(idParam("控制族ID")).fails(fail...),
+		op("POST", "/api/v1/control-families/:id/config-items", tagFamily, "linkControlFamilyConfigItems", "关联配置项").
+			with(idParam("控制族ID")).body(handler.LinkConfigItemsRequest{}).fails(fail...),
+		op("DELETE", "/api/v1/control-families/:id/config-items", tagFamily, "unlinkControlFamilyConfigItems", "解除配置项关联").
+			with(idParam("控制族ID"), queryList("config_item_id", "integer", "配置项ID，可传多个")).fails(fail...),
+		op("GET", "/api/v1/control-families/:id/comparison", tagFamily, "compareControlFamily", "控制族跨云对比").
+			with(idParam("控制族ID")).returns(service.FamilyComparison{}).fails(fail...),
+
+		// 产品类别
+		op("GET", "/api/v1/product-categories", tagCategory, "listProductCategories", "获取产品类别").
+			describe("默认返回类别树，flat=true时返回平铺列表").
+			with(queryParam("flat", "boolean", "是否返回平铺列表")).
+			returns([]models.ProductCategory{}).fails(internal...),
+		op("GET", "/api/v1/product-categories/:id", tagCategory, "getProductCategory", "获取产品类别详情").
+			with(idParam("类别ID")).returns(models.ProductCategory{}).fails(fail...),
+		op("POST", "/api/v1/product-categories", tagCategory, "createProductCategory", "创建产品类别").
+			body(models.ProductCategory{}).returns(models.ProductCategory{}).fails(write...),
+		op("PUT", "/api/v1/product-categories/:id", tagCategory, "updateProductCategory", "更新产品类别").
+			with(idParam("类别ID")).body(models.ProductCategory{}).fails(write...),
+		op("DELETE", "/api/v1/product-categories/:id", tagCategory, "deleteProductCategory", "删除产品类别").
+			with(idParam("类别ID")).fails(fail...),
+		op("POST", "/api/v1/product-categories/:id/products", tagCategory, "assignCategoryProducts", "产品归类").
+			with(idParam("类别ID")).body(handler.AssignProductsRequest{}).fails(fail...),
+
+		// 标签
+		op("GET", "/api/v1/tags", tagTag, "listTags", "获取所有标签").
+			returns([]models.Tag{}).fails(internal...),
+		op("GET", "/api/v1/tags/suggest", tagTag, "suggestTags", "标签自动补全").
+			with(queryParam("q", "string", "标签名称前缀"), queryParam("limit", "integer", "返回数量，默认10，最大50")).
+			returns([]models.Tag{}).fails(internal...),
+		op("DELETE", "/api/v1/tags/:id", tagTag, "deleteTag", "删除标签").
+			with(idParam("标签ID")).fails(fail...),
+		op("POST", "/api/v1/tags/attach", tagTag, "attachTags", "批量添加标签").
+			body(handler.TagBulkRequest{}).fails(fail...),
+		op("POST", "/api/v1/tags/detach", tagTag, "detachTags", "批量移除标签").
+			body(handler.TagBulkRequest{}).fails(fail...),
+
+		// 回收站
+		op("GET", "/api/v1/trash", tagTrash, "listTrash", "获取回收站列表").
+			with(&Parameter{Name: "type", In: "query", Description: "实体类型：provider, product, config_item", Required: true, Schema: &Schema{Type: "string"}}).
+			with(pageParams()...).
+			returns(repository.PageResult{}).fails(query...),
+		op("POST", "/api/v1/trash/:type/:id/restore", tagTrash, "restoreTrash", "从回收站恢复").
+			with(trashType, idParam("实体ID")).fails(write...),
+		op("DELETE", "/api/v1/trash/:type/:id", tagTrash, "purgeTrash", "彻底删除").
+			describe("彻底删除回收站中的实体及其下级记录，不可恢复").
+			with(trashType, idParam("实体ID"),
+				&Parameter{Name: "X-Admin-Token", In: "header", Description: "管理员令牌", Required: true, Schema: &Schema{Type: "string"}}).
+			fails(http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError),
+
+		// 统计分析
+		op("GET", "/api/v1/stats", tagStats, "getStatsOverview", "获取仪表盘概览").
+			returns(service.StatsOverview{}).fails(internal...),
+		op("GET", "/api/v1/stats/providers", tagStats, "getProviderStats", "获取云服务商统计").
+			returns([]repository.ProviderStat{}).fails(internal...),
+		op("GET", "/api/v1/stats/products", tagStats, "getProductStats", "获取云产品统计").
+			with(queryParam("cloud_provider_id", "integer", "云服务商ID")).
+			returns([]repository.ProductStat{}).fails(internal...),
+		op("GET", "/api/v1/stats/categories", tagStats, "getCategoryStats", "获取产品类别统计").
+			returns([]repository.CategoryStat{}).fails(internal...),
+		op("GET", "/api/v1/stats/config-items", tagStats, "getConfigItemBreakdown", "获取配置项分布").
+			with(queryParam("by", "string", "统计维度：severity（默认）或status")).
+			returns([]repository.GroupCount{}).fails(query...),
+		op("GET", "/api/v1/stats/activity", tagStats, "getWeeklyActivity", "获取配置项变更趋势").
+			with(queryParam("from", "string", "开始时间（RFC3339或YYYY-MM-DD），按所在周的周一对齐"),
+				queryParam("to", "string", "结束时间（RFC3339或YYYY-MM-DD），默认当前时间")).
+			returns([]repository.WeeklyActivity{}).fails(query...),
+		op("GET", "/api/v1/stats/coverage-gaps", tagStats, "getCoverageGaps", "获取覆盖缺口").
+			returns([]repository.ProductStat{}).fails(internal...),
+
+		// Webhook
+		op("GET", "/api/v1/webhooks", tagWebhook, "listWebhooks", "获取所有Webhook订阅").
+			returns([]handler.WebhookResponse{}).fails(internal...),
+		op("GET", "/api/v1/webhooks/:id", tagWebhook, "getWebhook", "获取Webhook订阅详情").
+			with(idParam("订阅ID")).returns(handler.WebhookResponse{}).fails(fail...),
+		op("POST", "/api/v1/webhooks", tagWebhook, "createWebhook", "创建Webhook订阅").
+			describe("未提供签名密钥时自动生成，签名密钥只在创建时返回。事件类型：config_item.created, config_item.updated, config_item.deleted, import.completed，*表示全部事件").
+			body(handler.WebhookRequest{}).returns(handler.WebhookResponse{}).
+			fails(http.StatusBadRequest, http.StatusInternalServerError),
+		op("PUT", "/api/v1/webhooks/:id", tagWebhook, "updateWebhook", "更新Webhook订阅").
+			describe("整体更新订阅，secret为空时保留原签名密钥").
+			with(idParam("订阅ID")).body(handler.WebhookRequest{}).returns(handler.WebhookResponse{}).fails(fail...),
+		op("DELETE", "/api/v1/webhooks/:id", tagWebhook, "deleteWebhook", "删除Webhook订阅").
+			with(idParam("订阅ID")).fails(fail...),
+		op("GET", "/api/v1/webhooks/:id/deliveries", tagWebhook, "listWebhookDeliveries", "获取投递记录").
+			describe("按时间倒序分页获取订阅的投递记录，不返回请求体").
+			with(idParam("订阅ID"),
+				queryParam("status", "string", "投递状态：pending, succeeded, failed"),
+				queryParam("event", "string", "事件类型")).
+			with(pageParams()...).
+			returnsPage(models.WebhookDelivery{}).fails(fail...),
+		op("GET", "/api/v1/webhooks/:id/deliveries/:delivery_id", tagWebhook, "getWebhookDelivery", "获取投递记录详情").
+			with(idParam("订阅ID"), pathParam("delivery_id", "integer", "投递记录ID")).
+			returns(models.WebhookDelivery{}).fails(fail...),
+		op("POST", "/api/v1/webhooks/:id/deliveries/:delivery_id/redeliver", tagWebhook, "redeliverWebhook", "重新投递").
+			describe("以原请求体和事件ID创建新的投递记录并立即投递").
+			with(idParam("订阅ID"), pathParam("delivery_id", "integer", "投递记录ID")).
+			returns(models.WebhookDelivery{}).fails(write...),
+
+		// 变更推送
+		streamOp("GET", "/api/v1/events/stream", tagEvents, "streamEvents", "订阅数据变更").
+			describe("以Server-Sent Events推送云服务商、云产品和配置项的创建、更新和删除事件，事件ID为全局递增的序号。"+
+				"重连时通过Last-Event-ID请求头或last_event_id参数从断点续传；续传点已过期时先推送reset事件，客户端需重新加载数据").
+			with(&Parameter{Name: "Last-Event-ID", In: "header", Description: "最后收到的事件ID", Schema: &Schema{Type: "integer"}},
+				queryParam("last_event_id", "integer", "最后收到的事件ID，未提供请求头时使用"),
+				queryParam("cloud_provider_id", "integer", "只推送该云服务商及其下属产品和配置项的事件"),
+				queryParam("product_id", "integer", "只推送该云产品及其配置项的事件"),
+				queryList("types", "string", "实体类型：provider, product, config_item")).
+			fails(query...),
+
+		// 后台任务
+		op("POST", "/api/v1/jobs/imports", tagJobs, "submitImportJob", "提交导入任务").
+			describe("上传Excel文件并在后台导入配置项，立即返回任务；跳过的行在任务的warnings中说明").
+			rawBody("multipart/form-data", &Schema{
+				Type:       "object",
+				Properties: map[string]*Schema{"file": {Type: "string", Format: "binary", Description: "Excel文件"}},
+				Required:   []string{"file"},
+			}).
+			returns(handler.JobResponse{}).accepted().fails(upload...),
+		op("POST", "/api/v1/jobs/exports", tagJobs, "submitExportJob", "提交导出任务").
+			describe("在后台导出全部符合条件的配置项到Excel，不受同步导出接口的数量限制；任务成功后通过结果接口下载").
+			with(configItemFilterParams()...).
+			with(queryParam("sort", "string", "排序字段，逗号分隔，前缀-表示降序"),
+				queryParam("group_by", "string", "分组维度：category按产品类别分组排列，未分类的排在最后")).
+			returns(handler.JobResponse{}).accepted().fails(fail...),
+		op("POST", "/api/v1/jobs/evaluations", tagJobs, "submitEvaluationJob", "提交评估任务").
+			describe("在后台评估资源配置，单个任务最多50000个资源；任务结果为评估概览，完整的JSON评估报告通过结果接口下载").
+			body(handler.EvaluationRequest{}).returns(handler.JobResponse{}).accepted().fails(query...),
+		op("POST", "/api/v1/jobs/reports", tagJobs, "submitReportJob", "提交评估报告任务").
+			describe("在后台评估资源配置并生成Excel评估报告，单个任务最多50000个资源；任务成功后通过结果接口下载").
+			body(handler.EvaluationRequest{}).returns(handler.JobResponse{}).accepted().fails(query...),
+		op("GET", "/api/v1/jobs", tagJobs, "listJobs", "获取后台任务列表").
+			describe("按创建时间倒序分页查询后台任务").
+			with(queryParam("type", "string", "任务类型：import, export, evaluation, report"),
+				queryParam("status", "string", "任务状态：queued, running, succeeded, failed, canceled")).
+			with(pageParams()...).
+			returnsPage(handler.JobResponse{}).fails(internal...),
+		op("GET", "/api/v1/jobs/:id", tagJobs, "getJob", "获取后台任务详情").
+			describe("获取任务的状态、进度、错误信息和结果摘要").
+			with(idParam("任务ID")).returns(handler.JobResponse{}).fails(fail...),
+		op("POST", "/api/v1/jobs/:id/cancel", tagJobs, "cancelJob", "取消后台任务").
+			describe("等待执行的任务立即取消；执行中的任务标记为需要取消，在当前步骤结束后取消").
+			with(idParam("任务ID")).returns(handler.JobResponse{}).fails(write...),
+		op("GET", "/api/v1/jobs/:id/result", tagJobs, "downloadJobResult", "下载任务结果").
+			describe("下载执行成功的任务的结果文件：导出任务为配置项Excel，评估任务为JSON评估报告，评估报告任务为Excel评估报告").
+			with(idParam("任务ID")).download().fails(write...),
+
+		// 文件
+		op("GET", "/api/v1/files/:id", tagFiles, "downloadFile", "下载文件").
+			describe("通过导出等接口返回的downloadUrl下载文件，链接包含过期时间和签名，过期后需重新导出；文件超过保留时长后被删除").
+			with(idParam("文件ID"),
+				&Parameter{Name: "expires", In: "query", Description: "链接过期时间，Unix时间戳（秒）", Required: true, Schema: &Schema{Type: "integer"}},
+				&Parameter{Name: "signature", In: "query", Description: "链接签名", Required: true, Schema: &Schema{Type: "string"}}).
+			download().fails(http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError),
+
+		// GraphQL
+		graphQLOp("POST", "/api/v1/graphql", "graphqlQuery", "执行GraphQL请求").
+			describe("执行GraphQL查询或变更，Schema见/api/v1/graphql/schema；查询无法解析或校验失败时返回400，执行中的错误与部分结果一起在errors中返回").
+			body(graphql.Request{}).returns(graphql.Response{}),
+		graphQLOp("GET", "/api/v1/graphql", "graphqlQueryGet", "通过GET执行GraphQL查询").
+			describe("只能执行查询，变更请求返回405").
+			with(&Parameter{Name: "query", In: "query", Description: "GraphQL查询文档", Required: true, Schema: &Schema{Type: "string"}},
+				queryParam("operationName", "string", "文档包含多个操作时要执行的操作"),
+				queryParam("variables", "string", "JSON编码的变量对象")).
+			returns(graphql.Response{}),
+		graphQLOp("GET", "/api/v1/graphql/schema", "getGraphQLSchema", "获取GraphQL Schema").
+			describe("以SDL格式返回GraphQL Schema"),
+
+		// 系统
+		plainOp("GET", "/api/v1/openapi.json", "getOpenAPISpec", "获取OpenAPI文档", &Schema{Type: "object"}),
+		plainOp("GET", "/api/v1/docs", "getAPIDocs", "API文档页面", nil),
+		plainOp("GET", "/api/v1/docs/assets/:file", "getAPIDocsAsset", "API文档页面的静态资源", nil).
+			describe("内嵌的Swagger UI资源：swagger-ui-bundle.js、swagger-ui.css").
+			with(pathParam("file", "string", "资源文件名")),
+		plainOp("GET", "/health", "healthCheck", "健康检查", &Schema{
+			Type:       "object",
+			Properties: map[string]*Schema{"status": {Type: "string"}},
+		}),
+	}
+
+	result := make([]operation, 0, len(ops))
+	for _, o := range ops {
+		result = append(result, *o)
+	}
+	return result
+}
+
+// graphQLOp GraphQL接口，响应不使用统一响应结构
+func graphQLOp(method, path, id, summary string) *operation {
+	o := op(method, path, tagGraphQL, id, summary)
+	o.plain = true
+	return o
+}
+
+// streamOp 以事件流响应的接口
+func streamOp(method, path, tag, id, summary string) *operation {
+	o := op(method, path, tag, id, summary)
+	o.plain = true
+	o.media = "text/event-stream"
+	return o
+}
+
+// plainOp 不使用统一响应结构的系统接口
+func plainOp(method, path, id, summary string, schema *Schema) *operation {
+	o := op(method, path, tagSystem, id, summary)
+	o.plain = true
+	if schema != nil {
+		o.data = func(*schemaRegistry) *Schema { return schema }
+	}
+	return o
+}
@@ -0,0 +1,197 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Schema OpenAPI 3 Schema对象，只包含本项目用到的字段
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+}
+
+// codePattern 与请求校验中code规则一致的正则表达式
+const codePattern = `^[A-Za-z0-9][A-Za-z0-9_.-]*$`
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	deletedAtType = reflect.TypeOf(gorm.DeletedAt{})
+	rawJSONType   = reflect.TypeOf(json.RawMessage{})
+)
+
+// schemaRegistry 由Go类型生成Schema，具名结构体登记到components.schemas中并以$ref引用
+type schemaRegistry struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{
+		schemas: make(map[string]*Schema),
+		names:   make(map[reflect.Type]string),
+	}
+}
+
+// schemaOf 返回类型对应的Schema
+func (r *schemaRegistry) schemaOf(t reflect.Type) *Schema {
+	nullable := false
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		nullable = true
+	}
+
+	s := r.baseSchema(t)
+	if nullable && s.Ref == "" {
+		s.Nullable = true
+	}
+	return s
+}
+
+func (r *schemaRegistry) baseSchema(t reflect.Type) *Schema {
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case deletedAtType:
+		return &Schema{Type: "string", Format: "date-time", Nullable: true}
+	case rawJSONType:
+		return &Schema{Type: "object"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer", Minimum: floatPtr(0)}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: r.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.schemaOf(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return r.structSchema(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + r.register(t)}
+	default:
+		// interface{}等任意类型
+		return &Schema{}
+	}
+}
+
+// register 登记具名结构体，返回其在components.schemas中的名称
+func (r *schemaRegistry) register(t reflect.Type) string {
+	if name, ok := r.names[t]; ok {
+		return name
+	}
+
+	name := t.Name()
+	if _, ok := r.schemas[name]; ok {
+		// 不同包中的同名类型以包名区分
+		pkg := t.PkgPath()
+		name = pkg[strings.LastIndex(pkg, "/")+1:] + "." + name
+	}
+	// 先登记再生成属性，以支持ProductCategory.Children这样的递归类型
+	r.names[t] = name
+	r.schemas[name] = &Schema{}
+	*r.schemas[name] = *r.structSchema(t)
+	return name
+}
+
+// structSchema 按json标签生成结构体的属性，并把binding校验规则转换为Schema约束
+func (r *schemaRegistry) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	r.addFields(s, t)
+	return s
+}
+
+func (r *schemaRegistry) addFields(s *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.SplitN(tag, ",", 2)[0]
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			// 嵌入的BaseModel等结构体字段提升到外层
+			r.addFields(s, field.Type)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		prop := r.schemaOf(field.Type)
+		if applyBinding(prop, field.Tag.Get("binding")) {
+			s.Required = append(s.Required, name)
+		}
+		s.Properties[name] = prop
+	}
+}
+
+// applyBinding 将binding标签中的校验规则写入Schema，返回字段是否必填
+func applyBinding(s *Schema, binding string) bool {
+	if binding == "" {
+		return false
+	}
+
+	required := false
+	for _, rule := range strings.Split(binding, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required":
+			required = true
+		case "max":
+			if n, err := strconv.Atoi(param); err == nil && s.Type == "string" {
+				s.MaxLength = &n
+			}
+		case "min":
+			if n, err := strconv.ParseFloat(param, 64); err == nil && s.Type == "integer" {
+				s.Minimum = &n
+			}
+		case "oneof":
+			s.Enum = strings.Fields(param)
+		case "code":
+			s.Pattern = codePattern
+		case "reference":
+			s.Description = "包含\"://\"的链接必须是http或https地址"
+		}
+	}
+	return required
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
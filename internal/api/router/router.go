@@ -1,9 +1,15 @@
 package router
 
 import (
+	"crypto/subtle"
+	"net/http"
+
 	"github.com/gin-gonic/gin"
 	"github.com/yourusername/cloud-eye/internal/api/handler"
+	"github.com/yourusername/cloud-eye/internal/api/openapi"
+	"github.com/yourusername/cloud-eye/internal/pkg/config"
 	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+	"go.uber.org/zap"
 )
 
 // InitRouter 初始化路由
@@ -11,6 +17,17 @@ func InitRouter(
 	cloudProviderHandler *handler.CloudProviderHandler,
 	cloudProductHandler *handler.CloudProductHandler,
 	configItemHandler *handler.ConfigurationItemHandler,
+	searchHandler *handler.SearchHandler,
+	statsHandler *handler.StatsHandler,
+	controlFamilyHandler *handler.ControlFamilyHandler,
+	productCategoryHandler *handler.ProductCategoryHandler,
+	tagHandler *handler.TagHandler,
+	trashHandler *handler.TrashHandler,
+	graphqlHandler *handler.GraphQLHandler,
+	webhookHandler *handler.WebhookHandler,
+	eventHandler *handler.EventHandler,
+	jobHandler *handler.JobHandler,
+	fileHandler *handler.FileHandler,
 ) *gin.Engine {
 	r := gin.New()
 
@@ -22,6 +39,11 @@ func InitRouter(
 	// API路由组
 	api := r.Group("/api/v1")
 	{
+		// API文档
+		api.GET("/openapi.json", openapi.SpecHandler)
+		api.GET("/docs", openapi.DocsHandler)
+		api.GET("/docs/assets/:file", openapi.DocsAssetHandler)
+
 		// 云服务商相关路由
 		providers := api.Group("/cloud-providers")
 		{
@@ -29,16 +51,17 @@ func InitRouter(
 			providers.GET("/:id", cloudProviderHandler.GetByID)
 			providers.POST("", cloudProviderHandler.Create)
 			providers.PUT("/:id", cloudProviderHandler.Update)
+			providers.PATCH("/:id", cloudProviderHandler.Patch)
 			providers.DELETE("/:id", cloudProviderHandler.Delete)
 
 			// 获取指定云服务商的产品列表
-			providers.GET("/:provider_id/products", cloudProductHandler.GetByProviderID)
+			providers.GET("/:id/products", cloudProductHandler.GetByProviderID)
 			
 			// 根据云服务商代码获取产品列表
 			providers.GET("/code/:provider_code/products", cloudProductHandler.GetByProviderCode)
 			
 			// 获取指定云服务商和产品的配置项列表
-			providers.GET("/:provider_id/products/:product_id/config-items", configItemHandler.GetByProviderAndProduct)
+			providers.GET("/:id/products/:product_id/config-items", configItemHandler.GetByProviderAndProduct)
 		}
 
 		// 云产品相关路由
@@ -48,6 +71,7 @@ func InitRouter(
 			products.GET("/:id", cloudProductHandler.GetByID)
 			products.POST("", cloudProductHandler.Create)
 			products.PUT("/:id", cloudProductHandler.Update)
+			products.PATCH("/:id", cloudProductHandler.Patch)
 			products.DELETE("/:id", cloudProductHandler.Delete)
 		}
 
@@ -58,11 +82,112 @@ func InitRouter(
 			configItems.GET("/:id", configItemHandler.GetByID)
 			configItems.POST("", configItemHandler.Create)
 			configItems.PUT("/:id", configItemHandler.Update)
+			configItems.PATCH("/:id", configItemHandler.Patch)
 			configItems.DELETE("/:id", configItemHandler.Delete)
+			configItems.POST("/bulk", configItemHandler.Bulk)
+			configItems.POST("/evaluate", configItemHandler.Evaluate)
 			
 			// Excel导入导出
 			configItems.GET("/export", configItemHandler.ExportExcel)
 			configItems.POST("/import", configItemHandler.ImportExcel)
+			configItems.GET("/import-template", configItemHandler.ImportTemplate)
+		}
+
+		// 全文检索相关路由
+		search := api.Group("/search")
+		{
+			search.GET("/config-items", searchHandler.SearchConfigItems)
+		}
+
+		// 控制族相关路由
+		families := api.Group("/control-families")
+		{
+			families.GET("", controlFamilyHandler.GetAll)
+			families.GET("/:id", controlFamilyHandler.GetByID)
+			families.POST("", controlFamilyHandler.Create)
+			families.PUT("/:id", controlFamilyHandler.Update)
+			families.DELETE("/:id", controlFamilyHandler.Delete)
+			families.POST("/:id/config-items", controlFamilyHandler.LinkConfigItems)
+			families.DELETE("/:id/config-items", controlFamilyHandler.UnlinkConfigItems)
+			families.GET("/:id/comparison", controlFamilyHandler.Compare)
+		}
+
+		// 产品类别相关路由
+		categories := api.Group("/product-categories")
+		{
+			categories.GET("", productCategoryHandler.GetAll)
+			categories.GET("/:id", productCategoryHandler.GetByID)
+			categories.POST("", productCategoryHandler.Create)
+			categories.PUT("/:id", productCategoryHandler.Update)
+			categories.DELETE("/:id", productCategoryHandler.Delete)
+			categories.POST("/:id/products", productCategoryHandler.AssignProducts)
+		}
+
+		// 标签相关路由
+		tags := api.Group("/tags")
+		{
+			tags.GET("", tagHandler.GetAll)
+			tags.GET("/suggest", tagHandler.Suggest)
+			tags.DELETE("/:id", tagHandler.Delete)
+			tags.POST("/attach", tagHandler.Attach)
+			tags.POST("/detach", tagHandler.Detach)
+		}
+
+		// 回收站相关路由
+		trash := api.Group("/trash")
+		{
+			trash.GET("", trashHandler.List)
+			trash.POST("/:type/:id/restore", trashHandler.Restore)
+			trash.DELETE("/:type/:id", AdminMiddleware(), trashHandler.Purge)
+		}
+
+		// Webhook相关路由
+		webhooks := api.Group("/webhooks")
+		{
+			webhooks.GET("", webhookHandler.GetAll)
+			webhooks.GET("/:id", webhookHandler.GetByID)
+			webhooks.POST("", webhookHandler.Create)
+			webhooks.PUT("/:id", webhookHandler.Update)
+			webhooks.DELETE("/:id", webhookHandler.Delete)
+			webhooks.GET("/:id/deliveries", webhookHandler.ListDeliveries)
+			webhooks.GET("/:id/deliveries/:delivery_id", webhookHandler.GetDelivery)
+			webhooks.POST("/:id/deliveries/:delivery_id/redeliver", webhookHandler.Redeliver)
+		}
+
+		// 变更推送
+		api.GET("/events/stream", eventHandler.Stream)
+
+		// 后台任务相关路由
+		jobs := api.Group("/jobs")
+		{
+			jobs.GET("", jobHandler.List)
+			jobs.GET("/:id", jobHandler.GetByID)
+			jobs.POST("/imports", jobHandler.SubmitImport)
+			jobs.POST("/exports", jobHandler.SubmitExport)
+			jobs.POST("/evaluations", jobHandler.SubmitEvaluation)
+			jobs.POST("/reports", jobHandler.SubmitReport)
+			jobs.POST("/:id/cancel", jobHandler.Cancel)
+			jobs.GET("/:id/result", jobHandler.DownloadResult)
+		}
+
+		// 文件下载，通过签名链接访问
+		api.GET("/files/:id", fileHandler.Download)
+
+		// GraphQL相关路由
+		api.POST("/graphql", graphqlHandler.Query)
+		api.GET("/graphql", graphqlHandler.Query)
+		api.GET("/graphql/schema", graphqlHandler.Schema)
+
+		// 统计分析相关路由
+		stats := api.Group("/stats")
+		{
+			stats.GET("", statsHandler.GetOverview)
+			stats.GET("/providers", statsHandler.GetProviderStats)
+			stats.GET("/products", statsHandler.GetProductStats)
+			stats.GET("/categories", statsHandler.GetCategoryStats)
+			stats.GET("/config-items", statsHandler.GetConfigItemBreakdown)
+			stats.GET("/activity", statsHandler.GetWeeklyActivity)
+			stats.GET("/coverage-gaps", statsHandler.GetCoverageGaps)
 		}
 	}
 
@@ -89,23 +214,39 @@ func LoggerMiddleware() gin.HandlerFunc {
 		// 请求结束后
 		statusCode := c.Writer.Status()
 		logger.Info("API Request",
-			map[string]interface{}{
-				"path":       path,
-				"method":     method,
-				"status":     statusCode,
-				"client_ip":  c.ClientIP(),
-				"user_agent": c.Request.UserAgent(),
-			},
+			zap.String("path", path),
+			zap.String("method", method),
+			zap.Int("status", statusCode),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_agent", c.Request.UserAgent()),
 		)
 	}
 }
 
+// AdminMiddleware 管理员令牌校验中间件，未配置令牌时拒绝所有管理操作
+func AdminMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := config.GetConfig().Admin.Token
+		if token == "" {
+			c.AbortWithStatusJSON(http.StatusForbidden, handler.Response{Code: 4003, Message: "未配置管理员令牌，该操作已禁用"})
+			return
+		}
+
+		if subtle.ConstantTimeCompare([]byte(c.GetHeader("X-Admin-Token")), []byte(token)) != 1 {
+			c.AbortWithStatusJSON(http.StatusForbidden, handler.Response{Code: 4003, Message: "管理员令牌无效"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
 // CORSMiddleware 跨域中间件
 func CORSMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Admin-Token, Last-Event-ID")
 		
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
@@ -0,0 +1,65 @@
+package router_test
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cloud-eye/internal/api/openapi"
+	"github.com/yourusername/cloud-eye/internal/apptest"
+)
+
+func TestEveryRouteIsDocumented(t *testing.T) {
+	app := apptest.New(t)
+	engine, ok := app.Server.Config.Handler.(*gin.Engine)
+	if !ok {
+		t.Fatalf("测试服务的Handler为%T，期望*gin.Engine", app.Server.Config.Handler)
+	}
+
+	undocumented, unrouted := openapi.Verify(engine.Routes())
+	if len(undocumented) > 0 {
+		t.Errorf("缺少API文档的路由: %v", undocumented)
+	}
+	if len(unrouted) > 0 {
+		t.Errorf("没有对应路由的API文档: %v", unrouted)
+	}
+}
+
+func TestDocsPageServesEmbeddedAssets(t *testing.T) {
+	app := apptest.New(t)
+
+	page := get(t, app.URL("/api/v1/docs"), http.StatusOK)
+	if strings.Contains(page, "://") {
+		t.Fatalf("文档页面引用了外部资源:\n%s", page)
+	}
+
+	for path, want := range map[string]string{
+		"/api/v1/docs/assets/swagger-ui-bundle.js": "SwaggerUIBundle",
+		"/api/v1/docs/assets/swagger-ui.css":       ".swagger-ui",
+	} {
+		if body := get(t, app.URL(path), http.StatusOK); !strings.Contains(body, want) {
+			t.Errorf("%s的内容不包含%q", path, want)
+		}
+	}
+	get(t, app.URL("/api/v1/docs/assets/docs.html"), http.StatusNotFound)
+}
+
+// get 发送GET请求，校验状态码并返回响应体
+func get(t *testing.T, url string, wantStatus int) string {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("请求%s失败: %v", url, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("读取%s的响应失败: %v", url, err)
+	}
+	if resp.StatusCode != wantStatus {
+		t.Fatalf("%s返回%d，期望%d", url, resp.StatusCode, wantStatus)
+	}
+	return string(body)
+}
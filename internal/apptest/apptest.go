@@ -0,0 +1,172 @@
+// Package apptest 在内存存储和演示数据上组装与main.go一致的完整服务，供接口层和客户端的测试使用
+package apptest
+
+import (
+	"context"
+	"net"
+	"net/http/httptest"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/cloud-eye/internal/api/grpcapi"
+	"github.com/yourusername/cloud-eye/internal/api/handler"
+	"github.com/yourusername/cloud-eye/internal/api/router"
+	"github.com/yourusername/cloud-eye/internal/pkg/config"
+	"github.com/yourusername/cloud-eye/internal/pkg/excel"
+	"github.com/yourusername/cloud-eye/internal/pkg/filestore"
+	"github.com/yourusername/cloud-eye/internal/repository"
+	"github.com/yourusername/cloud-eye/internal/service"
+)
+
+// App 运行中的测试服务
+type App struct {
+	Server         *httptest.Server
+	ImportPath     string // 上传文件的暂存目录
+	QuarantinePath string // 未通过校验的上传文件的隔离目录，未启用隔离时为空
+	GRPCAddr       string // gRPC服务的监听地址，未启用TLS
+	Store          *repository.MemoryStore
+	Webhooks       service.WebhookService
+	ChangeFeed     service.ChangeFeedService
+}
+
+// URL 返回服务下的完整地址，例如 app.URL("/api/v1/config-items")
+func (a *App) URL(path string) string {
+	return a.Server.URL + path
+}
+
+// Options 测试服务的选项
+type Options struct {
+	Webhook    service.WebhookOptions
+	ChangeFeed service.ChangeFeedOptions
+	Upload     excel.UploadLimits // 上传的Excel文件的限制，零值使用配置文件中的值
+	Quarantine bool               // 是否将未通过校验的上传文件移入隔离目录，目录为App.QuarantinePath
+}
+
+// New 启动测试服务，测试结束时关闭服务并停止后台worker
+func New(t testing.TB) *App {
+	return NewWithOptions(t, Options{})
+}
+
+// NewWithOptions 按选项启动测试服务
+func NewWithOptions(t testing.TB, opts Options) *App {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	// Excel导入导出等组件读取全局配置，使用仓库中的默认配置
+	_, file, _, _ := runtime.Caller(0)
+	cfg, err := config.LoadConfig(filepath.Join(filepath.Dir(file), "..", "..", "configs", "config.yaml"))
+	if err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+	// 上传和导出的暂存文件写入临时目录，不留在源码目录中
+	excelDir := t.TempDir()
+	cfg.Excel.ImportPath = filepath.Join(excelDir, "import")
+	cfg.Excel.ExportPath = filepath.Join(excelDir, "export")
+	cfg.Excel.QuarantinePath = ""
+	if opts.Quarantine {
+		cfg.Excel.QuarantinePath = filepath.Join(excelDir, "quarantine")
+	}
+	if opts.Upload.MaxUploadSize > 0 {
+		cfg.Excel.MaxUploadSize = opts.Upload.MaxUploadSize
+	}
+	if opts.Upload.MaxUncompressedSize > 0 {
+		cfg.Excel.MaxUncompressedSize = opts.Upload.MaxUncompressedSize
+	}
+	if opts.Upload.MaxRows > 0 {
+		cfg.Excel.MaxRows = opts.Upload.MaxRows
+	}
+	if opts.Upload.MaxSheets > 0 {
+		cfg.Excel.MaxSheets = opts.Upload.MaxSheets
+	}
+
+	store := repository.NewMemoryStore()
+	if err := repository.SeedDemoData(context.Background(), store); err != nil {
+		t.Fatalf("写入演示数据失败: %v", err)
+	}
+	fileStore, err := filestore.New(filestore.Options{LocalPath: t.TempDir()})
+	if err != nil {
+		t.Fatalf("创建文件存储失败: %v", err)
+	}
+
+	configItemRepo := repository.NewMemoryConfigurationItemRepository(store)
+	providerRepo := repository.NewMemoryCloudProviderRepository(store)
+	productRepo := repository.NewMemoryCloudProductRepository(store)
+	categoryRepo := repository.NewMemoryProductCategoryRepository(store)
+	familyRepo := repository.NewMemoryControlFamilyRepository(store)
+
+	fileService := service.NewFileService(repository.NewMemoryFileRepository(store), fileStore, service.FileOptions{})
+	webhookService := service.NewWebhookService(repository.NewMemoryWebhookRepository(store), opts.Webhook)
+	changeFeedService := service.NewChangeFeedService(repository.NewMemoryChangeEventRepository(store), opts.ChangeFeed)
+	events := service.NewMultiPublisher(webhookService, changeFeedService)
+	providerService := service.NewCloudProviderService(providerRepo, events)
+	productService := service.NewCloudProductService(productRepo, providerRepo, categoryRepo, events)
+	configItemService := service.NewConfigurationItemService(configItemRepo, providerRepo, productRepo, events)
+	jobService := service.NewJobService(repository.NewMemoryJobRepository(store), configItemService, fileService, service.JobOptions{
+		ResultPath: t.TempDir(),
+	})
+
+	r := router.InitRouter(
+		handler.NewCloudProviderHandler(providerService),
+		handler.NewCloudProductHandler(productService),
+		handler.NewConfigurationItemHandler(configItemService, fileService),
+		handler.NewSearchHandler(service.NewSearchService(repository.NewMemorySearchRepository(store))),
+		handler.NewStatsHandler(service.NewStatsService(repository.NewMemoryStatsRepository(store))),
+		handler.NewControlFamilyHandler(service.NewControlFamilyService(familyRepo, configItemRepo, providerRepo, events)),
+		handler.NewProductCategoryHandler(service.NewProductCategoryService(categoryRepo, productRepo, events)),
+		handler.NewTagHandler(service.NewTagService(repository.NewMemoryTagRepository(store), events)),
+		handler.NewTrashHandler(service.NewTrashService(repository.NewMemoryTrashRepository(store), events)),
+		handler.NewGraphQLHandler(providerService, productService, configItemService),
+		handler.NewWebhookHandler(webhookService),
+		handler.NewEventHandler(changeFeedService),
+		handler.NewJobHandler(jobService, fileService),
+		handler.NewFileHandler(fileService),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{}, 3)
+	for _, run := range []func(context.Context){webhookService.Run, jobService.Run, changeFeedService.Run} {
+		run := run
+		go func() {
+			run(ctx)
+			done <- struct{}{}
+		}()
+	}
+
+	server := httptest.NewUnstartedServer(r)
+	// 与main.go一致，关闭时先断开SSE长连接
+	server.Config.RegisterOnShutdown(changeFeedService.Close)
+	server.Start()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("监听gRPC端口失败: %v", err)
+	}
+	grpcServer := grpcapi.NewServer(providerService, productService, configItemService)
+	go grpcServer.Serve(lis)
+	t.Cleanup(func() {
+		changeFeedService.Close()
+		server.Close()
+		grpcServer.Stop()
+		cancel()
+		for i := 0; i < 3; i++ {
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				t.Errorf("后台worker未在5秒内退出")
+				return
+			}
+		}
+	})
+
+	return &App{
+		Server:         server,
+		ImportPath:     cfg.Excel.ImportPath,
+		QuarantinePath: cfg.Excel.QuarantinePath,
+		GRPCAddr:       lis.Addr().String(),
+		Store:          store,
+		Webhooks:       webhookService,
+		ChangeFeed:     changeFeedService,
+	}
+}
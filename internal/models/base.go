@@ -1,10 +1,19 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 // BaseModel 基础模型定义，其他模型都可以嵌入该结构体
 type BaseModel struct {
 	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id"`
 	CreatedAt time.Time `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
 	UpdatedAt time.Time `gorm:"column:updated_at;not null;default:CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP" json:"updated_at"`
-}
\ No newline at end of file
+}
+
+// SoftDelete 软删除字段，嵌入后删除操作只记录删除时间，记录进入回收站，可恢复或彻底清除
+type SoftDelete struct {
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index" json:"deleted_at,omitempty"`
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// 云服务商和云产品的变更事件类型，配置项事件见webhook.go
+const (
+	EventProviderCreated = "provider.created"
+	EventProviderUpdated = "provider.updated"
+	EventProviderDeleted = "provider.deleted"
+	EventProductCreated  = "product.created"
+	EventProductUpdated  = "product.updated"
+	EventProductDeleted  = "product.deleted"
+)
+
+// 变更事件的实体类型
+const (
+	EntityProvider   = "provider"
+	EntityProduct    = "product"
+	EntityConfigItem = "config_item"
+)
+
+// ChangeEntities 支持订阅的实体类型
+var ChangeEntities = []string{EntityProvider, EntityProduct, EntityConfigItem}
+
+// ChangeEvent 数据变更事件，ID为全局递增的事件序号，作为SSE事件ID用于断线续传
+type ChangeEvent struct {
+	ID              uint            `gorm:"primarykey" json:"id"`
+	Event           string          `gorm:"column:event;type:varchar(50);not null" json:"event"`
+	EntityType      string          `gorm:"column:entity_type;type:varchar(20);not null" json:"entity_type"`
+	EntityID        uint            `gorm:"column:entity_id;not null" json:"entity_id,omitempty"`                       // 导入完成事件为0
+	CloudProviderID uint            `gorm:"column:cloud_provider_id;not null;index" json:"cloud_provider_id,omitempty"` // 云服务商事件为自身ID
+	ProductID       uint            `gorm:"column:product_id;not null;index" json:"product_id,omitempty"`               // 云产品事件为自身ID，云服务商事件为0
+	Data            json.RawMessage `gorm:"column:data;type:mediumtext;not null" json:"data"`
+	CreatedAt       time.Time       `gorm:"column:created_at;index" json:"created_at"`
+}
+
+// TableName 表名
+func (ChangeEvent) TableName() string {
+	return "change_events"
+}
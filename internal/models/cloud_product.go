@@ -3,13 +3,21 @@ package models
 // CloudProduct 云产品模型
 type CloudProduct struct {
 	BaseModel
+	SoftDelete
 	CloudProviderID uint          `gorm:"column:cloud_provider_id;not null;index:idx_provider" json:"cloud_provider_id"`
 	Name            string        `gorm:"column:name;type:varchar(100);not null" json:"name"`
 	Code            string        `gorm:"column:code;type:varchar(50);not null;uniqueIndex:uk_provider_code,priority:2" json:"code"`
 	Description     string        `gorm:"column:description;type:text" json:"description"`
+	CategoryID      *uint         `gorm:"column:category_id;index" json:"category_id"`
 	Provider        CloudProvider `gorm:"foreignKey:CloudProviderID" json:"provider,omitempty"`
+	// 所属类别
+	Category *ProductCategory `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
+	// 标签
+	Tags []Tag `gorm:"many2many:cloud_product_tags" json:"tags,omitempty"`
 	// 关联配置项
 	ConfigItems []ConfigurationItem `gorm:"foreignKey:ProductID" json:"config_items,omitempty"`
+	// 统计字段（只读，仅在列表查询要求统计时填充）
+	ConfigItemCount *int64 `gorm:"column:config_item_count;->;-:migration" json:"config_item_count,omitempty"`
 }
 
 // TableName 表名
@@ -3,11 +3,16 @@ package models
 // CloudProvider 云服务商模型
 type CloudProvider struct {
 	BaseModel
+	SoftDelete
 	Name        string `gorm:"column:name;type:varchar(100);not null" json:"name"`
 	Code        string `gorm:"column:code;type:varchar(50);not null;uniqueIndex:uk_code" json:"code"`
 	Description string `gorm:"column:description;type:text" json:"description"`
 	// 关联产品
 	Products []CloudProduct `gorm:"foreignKey:CloudProviderID" json:"products,omitempty"`
+	// 标签
+	Tags []Tag `gorm:"many2many:cloud_provider_tags" json:"tags,omitempty"`
+	// 统计字段（只读，仅在列表查询要求统计时填充）
+	ProductCount *int64 `gorm:"column:product_count;->;-:migration" json:"product_count,omitempty"`
 }
 
 // TableName 表名
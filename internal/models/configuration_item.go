@@ -1,8 +1,31 @@
 package models
 
+// 配置项风险等级
+const (
+	SeverityCritical = "critical"
+	SeverityHigh     = "high"
+	SeverityMedium   = "medium"
+	SeverityLow      = "low"
+	SeverityInfo     = "info"
+)
+
+// 配置项状态
+const (
+	StatusDraft      = "draft"      // 草稿，尚未生效
+	StatusActive     = "active"     // 生效中
+	StatusDeprecated = "deprecated" // 已废弃
+)
+
+// Severities 按风险从高到低排列的风险等级
+var Severities = []string{SeverityCritical, SeverityHigh, SeverityMedium, SeverityLow, SeverityInfo}
+
+// Statuses 配置项的全部状态
+var Statuses = []string{StatusDraft, StatusActive, StatusDeprecated}
+
 // ConfigurationItem 安全配置基线项模型
 type ConfigurationItem struct {
 	BaseModel
+	SoftDelete
 	CloudProviderID    uint          `gorm:"column:cloud_provider_id;not null;index:idx_provider_product,priority:1" json:"cloud_provider_id"`
 	ProductID          uint          `gorm:"column:product_id;not null;index:idx_provider_product,priority:2" json:"product_id"`
 	Name               string        `gorm:"column:name;type:varchar(200);not null" json:"name"`
@@ -11,11 +34,45 @@ type ConfigurationItem struct {
 	CheckMethod        string        `gorm:"column:check_method;type:text" json:"check_method"`
 	ConfigurationMethod string        `gorm:"column:configuration_method;type:text" json:"configuration_method"`
 	Reference          string        `gorm:"column:reference;type:text" json:"reference"`
+	Severity           string        `gorm:"column:severity;type:varchar(20);not null;default:medium;index" json:"severity"`
+	Status             string        `gorm:"column:status;type:varchar(20);not null;default:active;index" json:"status"`
+	ControlFamilyID    *uint         `gorm:"column:control_family_id;index" json:"control_family_id"`
 	Provider           CloudProvider `gorm:"foreignKey:CloudProviderID" json:"provider,omitempty"`
 	Product            CloudProduct  `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+	// 标签
+	Tags []Tag `gorm:"many2many:configuration_item_tags" json:"tags,omitempty"`
 }
 
 // TableName 表名
 func (ConfigurationItem) TableName() string {
 	return "configuration_items"
-}
\ No newline at end of file
+}
+// ApplyDefaults 为未设置的风险等级和状态填充默认值，与数据库列默认值一致
+func (i *ConfigurationItem) ApplyDefaults() {
+	if i.Severity == "" {
+		i.Severity = SeverityMedium
+	}
+	if i.Status == "" {
+		i.Status = StatusActive
+	}
+}
+
+// ValidSeverity 判断风险等级是否有效
+func ValidSeverity(severity string) bool {
+	for _, s := range Severities {
+		if s == severity {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidStatus 判断状态是否有效
+func ValidStatus(status string) bool {
+	for _, s := range Statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,17 @@
+package models
+
+// ControlFamily 控制族模型，将不同云服务商中语义等价的配置项归为一组
+// 例如"对象存储公共访问"控制族包含AWS S3、Azure Blob和阿里云OSS的公共访问配置项
+type ControlFamily struct {
+	BaseModel
+	Name        string `gorm:"column:name;type:varchar(200);not null" json:"name"`
+	Code        string `gorm:"column:code;type:varchar(50);not null;uniqueIndex:uk_family_code" json:"code"`
+	Description string `gorm:"column:description;type:text" json:"description"`
+	// 关联配置项
+	ConfigItems []ConfigurationItem `gorm:"foreignKey:ControlFamilyID" json:"config_items,omitempty"`
+}
+
+// TableName 表名
+func (ControlFamily) TableName() string {
+	return "control_families"
+}
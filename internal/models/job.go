@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// 后台任务类型
+const (
+	JobTypeImport     = "import"     // 从Excel导入配置项
+	JobTypeExport     = "export"     // 导出配置项到Excel
+	JobTypeEvaluation = "evaluation" // 评估资源配置，结果为JSON评估报告
+	JobTypeReport     = "report"     // 评估资源配置并生成Excel评估报告
+)
+
+// 后台任务状态
+const (
+	JobStatusQueued    = "queued"    // 等待执行，执行租约过期或实例退出时中断的任务也回到该状态
+	JobStatusRunning   = "running"   // 执行中
+	JobStatusSucceeded = "succeeded" // 执行成功
+	JobStatusFailed    = "failed"    // 执行失败
+	JobStatusCanceled  = "canceled"  // 已取消
+)
+
+// Job 后台任务，参数和结果以JSON保存，结果文件保存在文件存储中
+type Job struct {
+	BaseModel
+	Type            string     `gorm:"column:type;type:varchar(20);not null" json:"type"`
+	Status          string     `gorm:"column:status;type:varchar(20);not null;index" json:"status"`
+	Params          string     `gorm:"column:params;type:mediumtext;not null" json:"-"`
+	Progress        int        `gorm:"column:progress;not null;default:0" json:"progress"`        // 已处理的记录数
+	Total           int        `gorm:"column:total;not null;default:0" json:"total"`              // 待处理的记录总数，未知时为0
+	Message         string     `gorm:"column:message;type:varchar(200)" json:"message,omitempty"` // 当前步骤
+	Error           string     `gorm:"column:error;type:varchar(1000)" json:"error,omitempty"`
+	Warnings        StringList `gorm:"column:warnings;type:text" json:"warnings,omitempty"` // 执行中跳过的记录等非致命问题
+	Result          string     `gorm:"column:result;type:text" json:"-"`                    // 结果摘要
+	ResultFileID    uint       `gorm:"column:result_file_id" json:"-"`
+	ResultName      string     `gorm:"column:result_name;type:varchar(200)" json:"result_name,omitempty"` // 结果文件的下载名称
+	CancelRequested bool       `gorm:"column:cancel_requested;not null;default:false" json:"cancel_requested,omitempty"`
+	Attempts        int        `gorm:"column:attempts;not null;default:0" json:"attempts"`
+	Owner           string     `gorm:"column:owner;type:varchar(100)" json:"owner,omitempty"`     // 领取任务的实例
+	LeaseExpiresAt  *time.Time `gorm:"column:lease_expires_at" json:"lease_expires_at,omitempty"` // 执行租约到期时间，执行中由心跳续期
+	StartedAt       *time.Time `gorm:"column:started_at" json:"started_at,omitempty"`
+	FinishedAt      *time.Time `gorm:"column:finished_at" json:"finished_at,omitempty"`
+}
+
+// TableName 表名
+func (Job) TableName() string {
+	return "jobs"
+}
+
+// Finished 判断任务是否已结束
+func (j *Job) Finished() bool {
+	return j.Status == JobStatusSucceeded || j.Status == JobStatusFailed || j.Status == JobStatusCanceled
+}
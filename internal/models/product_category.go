@@ -0,0 +1,17 @@
+package models
+
+// ProductCategory 产品类别模型，通过ParentID组成层级结构，例如 存储 > 对象存储
+type ProductCategory struct {
+	BaseModel
+	ParentID    *uint  `gorm:"column:parent_id;index" json:"parent_id"`
+	Name        string `gorm:"column:name;type:varchar(100);not null" json:"name"`
+	Code        string `gorm:"column:code;type:varchar(50);not null;uniqueIndex:uk_category_code" json:"code"`
+	Description string `gorm:"column:description;type:text" json:"description"`
+	// 子类别，仅在返回类别树时填充
+	Children []ProductCategory `gorm:"-" json:"children,omitempty"`
+}
+
+// TableName 表名
+func (ProductCategory) TableName() string {
+	return "product_categories"
+}
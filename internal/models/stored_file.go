@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// 托管文件类型，不同类型的保留时长分别配置
+const (
+	FileKindExport = "export" // 导出文件、评估结果和评估报告等服务端生成的文件
+	FileKindImport = "import" // 上传的导入文件
+)
+
+// StoredFile 保存在文件存储中的文件，内容由存储后端保存，过期后由清理任务删除
+type StoredFile struct {
+	BaseModel
+	Kind        string    `gorm:"column:kind;type:varchar(20);not null" json:"kind"`
+	Name        string    `gorm:"column:name;type:varchar(255);not null" json:"name"` // 下载时使用的文件名
+	StorageKey  string    `gorm:"column:storage_key;type:varchar(500);not null" json:"-"`
+	Size        int64     `gorm:"column:size;not null" json:"size"`
+	ContentType string    `gorm:"column:content_type;type:varchar(100);not null" json:"content_type"`
+	ExpiresAt   time.Time `gorm:"column:expires_at;not null;index" json:"expires_at"`
+}
+
+// TableName 表名
+func (StoredFile) TableName() string {
+	return "files"
+}
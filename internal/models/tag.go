@@ -0,0 +1,23 @@
+package models
+
+// Tag 标签模型，可同时标记云服务商、云产品和配置项
+type Tag struct {
+	BaseModel
+	Name string `gorm:"column:name;type:varchar(50);not null;uniqueIndex:uk_tag_name" json:"name"`
+	// 统计字段（只读，仅在标签列表和自动补全时填充）
+	UsageCount *int64 `gorm:"column:usage_count;->;-:migration" json:"usage_count,omitempty"`
+}
+
+// TableName 表名
+func (Tag) TableName() string {
+	return "tags"
+}
+
+// TagNames 返回标签名称列表
+func TagNames(tags []Tag) []string {
+	names := make([]string, 0, len(tags))
+	for _, t := range tags {
+		names = append(names, t.Name)
+	}
+	return names
+}
@@ -0,0 +1,125 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Webhook事件类型
+const (
+	EventConfigItemCreated = "config_item.created"
+	EventConfigItemUpdated = "config_item.updated"
+	EventConfigItemDeleted = "config_item.deleted"
+	EventImportCompleted   = "import.completed"
+	EventAll               = "*" // 订阅全部事件
+)
+
+// WebhookEvents 支持订阅的事件类型
+var WebhookEvents = []string{
+	EventConfigItemCreated,
+	EventConfigItemUpdated,
+	EventConfigItemDeleted,
+	EventImportCompleted,
+}
+
+// ValidWebhookEvent 判断是否为支持订阅的事件类型
+func ValidWebhookEvent(event string) bool {
+	if event == EventAll {
+		return true
+	}
+	for _, e := range WebhookEvents {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// StringList 以JSON数组形式存储在单个列中的字符串列表
+type StringList []string
+
+// Value 实现driver.Valuer接口
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(l)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan 实现sql.Scanner接口
+func (l *StringList) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*l = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return errors.New("无法将数据库值转换为StringList")
+	}
+	return json.Unmarshal(data, l)
+}
+
+// WebhookSubscription Webhook订阅，数据变更时向URL推送签名后的事件
+type WebhookSubscription struct {
+	BaseModel
+	Name   string     `gorm:"column:name;type:varchar(100);not null" json:"name"`
+	URL    string     `gorm:"column:url;type:varchar(500);not null" json:"url"`
+	Secret string     `gorm:"column:secret;type:varchar(100);not null" json:"-"` // 签名密钥，只在创建时返回
+	Events StringList `gorm:"column:events;type:varchar(500);not null" json:"events"`
+	Active bool       `gorm:"column:active;not null;default:true" json:"active"`
+}
+
+// TableName 表名
+func (WebhookSubscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// Subscribes 判断订阅是否包含指定事件
+func (s *WebhookSubscription) Subscribes(event string) bool {
+	for _, e := range s.Events {
+		if e == EventAll || e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// Webhook投递状态
+const (
+	DeliveryStatusPending   = "pending"   // 等待投递或等待重试
+	DeliveryStatusSucceeded = "succeeded" // 接收方返回2xx
+	DeliveryStatusFailed    = "failed"    // 达到最大投递次数或订阅已停用
+)
+
+// WebhookDelivery Webhook投递记录，每个事件对每个订阅生成一条，重新投递时生成新记录
+type WebhookDelivery struct {
+	BaseModel
+	SubscriptionID uint       `gorm:"column:subscription_id;not null;index:idx_delivery_subscription" json:"subscription_id"`
+	EventID        string     `gorm:"column:event_id;type:varchar(64);not null" json:"event_id"`
+	Event          string     `gorm:"column:event;type:varchar(50);not null" json:"event"`
+	Payload        string     `gorm:"column:payload;type:mediumtext;not null" json:"payload,omitempty"` // 请求体，列表中不返回
+	Status         string     `gorm:"column:status;type:varchar(20);not null;index:idx_delivery_due" json:"status"`
+	Attempts       int        `gorm:"column:attempts;not null;default:0" json:"attempts"`
+	ResponseStatus int        `gorm:"column:response_status" json:"response_status,omitempty"` // 最近一次投递的响应状态码
+	ResponseBody   string     `gorm:"column:response_body;type:text" json:"response_body,omitempty"`
+	Error          string     `gorm:"column:error;type:varchar(500)" json:"error,omitempty"`
+	DurationMs     int64      `gorm:"column:duration_ms" json:"duration_ms"`
+	LastAttemptAt  *time.Time `gorm:"column:last_attempt_at" json:"last_attempt_at,omitempty"`
+	NextAttemptAt  *time.Time `gorm:"column:next_attempt_at;index:idx_delivery_due" json:"next_attempt_at,omitempty"`
+	RedeliveryOf   *uint      `gorm:"column:redelivery_of" json:"redelivery_of,omitempty"` // 手动重新投递时为原投递记录ID
+}
+
+// TableName 表名
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
@@ -14,12 +14,18 @@ type Config struct {
 	Database DatabaseConfig
 	Log      LogConfig
 	Excel    ExcelConfig
+	Admin    AdminConfig
+	Webhook  WebhookConfig
+	Events   EventsConfig
+	Jobs     JobsConfig
+	Files    FilesConfig
 }
 
 // ServerConfig 服务器配置
 type ServerConfig struct {
-	Port int
-	Mode string
+	Port     int
+	Mode     string
+	GRPCPort int    // gRPC端口，为0时不启动gRPC服务
 }
 
 // DatabaseConfig 数据库配置
@@ -44,10 +50,63 @@ type LogConfig struct {
 	Filename string
 }
 
-// ExcelConfig Excel导入导出配置
+// ExcelConfig Excel导入导出配置，上传限制未设置时使用默认值
 type ExcelConfig struct {
 	ImportPath string
 	ExportPath string
+	QuarantinePath      string // 未通过校验的上传文件的隔离目录，为空时直接删除
+	MaxUploadSize       int64  // 上传文件的最大字节数，默认10MB
+	MaxUncompressedSize int64  // 解压后的最大字节数，默认100MB
+	MaxRows             int    // 最大数据行数，默认10000
+	MaxSheets           int    // 最大工作表数，默认10
+}
+
+// AdminConfig 管理员配置
+type AdminConfig struct {
+	Token string // 管理员令牌，用于彻底删除等管理操作，为空时禁用这些操作
+}
+
+// WebhookConfig Webhook投递配置，未设置的项使用默认值
+type WebhookConfig struct {
+	MaxAttempts  int           // 最大投递次数（含首次），默认6
+	RetryBackoff time.Duration // 首次重试间隔，之后每次翻倍，默认30s
+	MaxBackoff   time.Duration // 重试间隔上限，默认1h
+	Timeout      time.Duration // 单次请求超时时间，默认10s
+	Workers      int           // 并发投递数，默认4
+}
+
+// EventsConfig 变更推送配置，未设置的项使用默认值
+type EventsConfig struct {
+	Heartbeat time.Duration // SSE心跳间隔，默认15s
+	Retention time.Duration // 事件保留时长，超过后无法断点续传，默认168h
+}
+
+// JobsConfig 后台任务配置，未设置的项使用默认值
+type JobsConfig struct {
+	Workers    int    // 并发执行的任务数，默认2
+	ResultPath string        // 任务结果文件目录，默认./uploads/jobs
+	Lease      time.Duration // 执行租约时长，实例失联超过该时长后任务由其他实例重新执行，默认30s
+}
+
+// FilesConfig 托管文件存储配置，未设置的项使用默认值
+type FilesConfig struct {
+	Driver          string        // 存储后端：local或s3，默认local
+	LocalPath       string        // 本地存储目录，默认./uploads/files
+	S3              S3Config      // S3兼容对象存储配置，Driver为s3时使用
+	LinkSecret      string        // 下载链接签名密钥，为空时每次启动随机生成，重启后已签发的链接失效
+	LinkTTL         time.Duration // 下载链接有效期，默认1h
+	ExportRetention time.Duration // 导出文件保留时长，默认168h
+	ImportRetention time.Duration // 导入文件保留时长，默认24h
+}
+
+// S3Config S3兼容对象存储配置
+type S3Config struct {
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	PathStyle       bool // 使用路径形式的地址，MinIO需设置为true
 }
 
 var config *Config
@@ -82,18 +141,3 @@ func GetConfig() *Config {
 	}
 	return config
 }
-
-// 自定义GORM日志器
-func NewGormLogger() GormLogger {
-	return GormLogger{
-		LogLevel: GetConfig().Database.LogLevel,
-	}
-}
-
-// GormLogger 是一个GORM日志适配器
-type GormLogger struct {
-	LogLevel string
-}
-
-// 实现GORM日志接口
-// ...实际实现略（将在logger包完成后集成）
\ No newline at end of file
@@ -2,6 +2,7 @@ package database
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/yourusername/cloud-eye/internal/pkg/config"
@@ -9,6 +10,7 @@ import (
 
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
 	"gorm.io/gorm/schema"
 )
 
@@ -33,7 +35,9 @@ func InitDB() error {
 		NamingStrategy: schema.NamingStrategy{
 			SingularTable: true, // 使用单数表名
 		},
-		Logger: config.NewGormLogger(), // 使用自定义日志器
+		Logger: gormlogger.Default.LogMode(gormLogLevel(cfg.LogLevel)),
+		// 将唯一约束冲突等数据库错误转换为gorm.ErrDuplicatedKey等通用错误
+		TranslateError: true,
 	})
 	if err != nil {
 		logger.Error("Failed to connect to database", err)
@@ -53,4 +57,18 @@ func InitDB() error {
 
 	logger.Info("Database connection established successfully")
 	return nil
-}
\ No newline at end of file
+}
+
+// gormLogLevel 将配置的日志级别转换为GORM日志级别，默认只记录警告和错误
+func gormLogLevel(level string) gormlogger.LogLevel {
+	switch strings.ToLower(level) {
+	case "silent":
+		return gormlogger.Silent
+	case "error":
+		return gormlogger.Error
+	case "info", "debug":
+		return gormlogger.Info
+	default:
+		return gormlogger.Warn
+	}
+}
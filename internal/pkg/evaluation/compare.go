@@ -0,0 +1,228 @@
+package evaluation
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// lookup 按字段路径查找资源配置中的值，第二个返回值表示字段是否存在
+func lookup(config map[string]interface{}, path string) (interface{}, bool) {
+	var current interface{} = config
+	for _, key := range strings.Split(path, ".") {
+		switch v := current.(type) {
+		case map[string]interface{}:
+			next, ok := v[key]
+			if !ok {
+				return nil, false
+			}
+			current = next
+		case []interface{}:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+			current = v[i]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// apply 对实际值执行规则，返回是否满足；规则无法应用于该值（如类型不匹配）时返回错误
+func (r Rule) apply(actual interface{}, exists bool) (bool, error) {
+	switch r.Op {
+	case OpExists:
+		return exists, nil
+	case OpNotExists:
+		return !exists, nil
+	}
+	if !exists {
+		return false, nil
+	}
+
+	switch r.Op {
+	case OpEqual:
+		return equal(actual, r.Expected), nil
+	case OpNotEqual:
+		return !equal(actual, r.Expected), nil
+	case OpGreater, OpGreaterEqual, OpLess, OpLessEqual:
+		return r.compare(actual)
+	case OpIn, OpNotIn:
+		found := false
+		for _, v := range r.Expected.([]interface{}) {
+			if equal(actual, v) {
+				found = true
+				break
+			}
+		}
+		return found == (r.Op == OpIn), nil
+	case OpContains:
+		switch v := actual.(type) {
+		case string:
+			s, ok := r.Expected.(string)
+			if !ok {
+				return false, fmt.Errorf("字符串只能包含字符串，期望值为 %s", formatValue(r.Expected))
+			}
+			return strings.Contains(v, s), nil
+		case []interface{}:
+			for _, elem := range v {
+				if equal(elem, r.Expected) {
+					return true, nil
+				}
+			}
+			return false, nil
+		default:
+			return false, fmt.Errorf("contains只能用于字符串或数组，实际值为 %s", formatValue(actual))
+		}
+	case OpMatches:
+		s, ok := actual.(string)
+		if !ok {
+			return false, fmt.Errorf("matches只能用于字符串，实际值为 %s", formatValue(actual))
+		}
+		re, err := regexp.Compile(r.Expected.(string))
+		if err != nil {
+			return false, fmt.Errorf("无效的正则表达式: %w", err)
+		}
+		return re.MatchString(s), nil
+	}
+	return false, fmt.Errorf("不支持的操作符 %s", r.Op)
+}
+
+// compare 比较数值大小，数值字符串按数值比较，带单位的时长和容量换算后比较
+func (r Rule) compare(actual interface{}) (bool, error) {
+	a, unitA, ok := toQuantity(actual)
+	if !ok {
+		return false, fmt.Errorf("%s只能用于数值，实际值为 %s", r.Op, formatValue(actual))
+	}
+	b, unitB, ok := toQuantity(r.Expected)
+	if !ok {
+		return false, fmt.Errorf("%s只能用于数值，期望值为 %s", r.Op, formatValue(r.Expected))
+	}
+	if unitA != unitB {
+		return false, fmt.Errorf("%s无法比较，实际值 %s 与期望值 %s 的单位不一致", r.Op, formatValue(actual), formatValue(r.Expected))
+	}
+
+	switch r.Op {
+	case OpGreater:
+		return a > b, nil
+	case OpGreaterEqual:
+		return a >= b, nil
+	case OpLess:
+		return a < b, nil
+	default:
+		return a <= b, nil
+	}
+}
+
+// equal 比较两个JSON值是否相等，数值按大小比较
+func equal(a, b interface{}) bool {
+	if x, ok := toNumber(a); ok {
+		y, ok := toNumber(b)
+		return ok && x == y
+	}
+	return reflect.DeepEqual(normalize(a), normalize(b))
+}
+
+// toNumber 将JSON数值转换为float64
+func toNumber(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	default:
+		return 0, false
+	}
+}
+
+// 量纲
+const (
+	dimensionDuration = "duration" // 时长，换算为秒
+	dimensionSize     = "size"     // 容量，换算为字节
+)
+
+// units 支持的单位（小写）及其量纲和换算倍数
+var units = map[string]struct {
+	dimension string
+	factor    float64
+}{
+	"s":   {dimensionDuration, 1},
+	"m":   {dimensionDuration, 60},
+	"h":   {dimensionDuration, 3600},
+	"d":   {dimensionDuration, 86400},
+	"b":   {dimensionSize, 1},
+	"kb":  {dimensionSize, 1e3},
+	"mb":  {dimensionSize, 1e6},
+	"gb":  {dimensionSize, 1e9},
+	"tb":  {dimensionSize, 1e12},
+	"kib": {dimensionSize, 1 << 10},
+	"mib": {dimensionSize, 1 << 20},
+	"gib": {dimensionSize, 1 << 30},
+	"tib": {dimensionSize, 1 << 40},
+}
+
+// quantityPattern 数值和可选的单位，例如14、"14"、"90d"、"1.5 GiB"
+var quantityPattern = regexp.MustCompile(`^([+-]?(?:\d+\.?\d*|\.\d+)(?:[eE][+-]?\d+)?)\s*([A-Za-z]*)$`)
+
+// toQuantity 将数值或字符串转换为换算后的数值和量纲，无单位时量纲为空；不支持的单位返回false
+func toQuantity(v interface{}) (float64, string, bool) {
+	if n, ok := toNumber(v); ok {
+		return n, "", true
+	}
+	s, ok := v.(string)
+	if !ok {
+		return 0, "", false
+	}
+	m := quantityPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return 0, "", false
+	}
+	n, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, "", false
+	}
+	if m[2] == "" {
+		return n, "", true
+	}
+	unit, ok := units[strings.ToLower(m[2])]
+	if !ok {
+		return 0, "", false
+	}
+	return n * unit.factor, unit.dimension, true
+}
+
+// normalize 经JSON转换统一值的类型，使来自不同来源的map和切片可以比较
+func normalize(v interface{}) interface{} {
+	switch v.(type) {
+	case map[string]interface{}, []interface{}, string, bool, nil:
+		return v
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var out interface{}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return v
+	}
+	return out
+}
+
+// formatValue 以JSON格式展示值
+func formatValue(v interface{}) string {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(raw)
+}
@@ -0,0 +1,120 @@
+package evaluation
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRuleApply(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    string
+		actual  interface{}
+		missing bool
+		want    bool
+		wantErr string
+	}{
+		// 数值
+		{"数值相等", "n == 14", 14.0, false, true, ""},
+		{"整数与浮点数相等", "n == 14", 14, false, true, ""},
+		{"json.Number相等", "n == 14", json.Number("14"), false, true, ""},
+		{"数值不等", "n != 14", 15.0, false, true, ""},
+		{"大于等于", "n >= 14", 14.0, false, true, ""},
+		{"大于", "n > 14", 14.0, false, false, ""},
+		{"小于", "n < 14", 13.5, false, true, ""},
+		{"小于等于", "n <= 14", 15.0, false, false, ""},
+
+		// 数值与字符串
+		{"数值字符串不等于数值", "n == 14", "14", false, false, ""},
+		{"数值字符串按数值比较大小", "n >= 14", "16", false, true, ""},
+		{"数值字符串按数值而非字典序比较", "n > 9", "10", false, true, ""},
+		{"期望值为数值字符串", `n < "10"`, 9.0, false, true, ""},
+		{"非数值字符串无法比较大小", "n >= 14", "abc", false, false, "只能用于数值"},
+		{"布尔值无法比较大小", "n >= 14", true, false, false, "只能用于数值"},
+		{"期望值无法比较大小", `n >= [1]`, 1.0, false, false, "期望值为 [1]"},
+
+		// 单位
+		{"天数比较", "retention >= 90d", "180d", false, true, ""},
+		{"天与小时换算", "retention >= 1d", "23h", false, false, ""},
+		{"分钟与秒换算", "timeout <= 5m", "300s", false, true, ""},
+		{"单位不区分大小写", "retention >= 90d", "90D", false, true, ""},
+		{"数值与单位间可以有空格", `disk <= "1 TiB"`, "512 GiB", false, true, ""},
+		{"1024进制", "disk >= 1GiB", "1024MiB", false, true, ""},
+		{"1000进制与1024进制", "disk >= 1GiB", "1GB", false, false, ""},
+		{"小数", "disk > 1.5GB", "1600MB", false, true, ""},
+		{"时长与容量不能比较", "retention >= 90d", "90GB", false, false, "单位不一致"},
+		{"无单位数值与带单位值不能比较", "retention >= 90d", 90.0, false, false, "单位不一致"},
+		{"不支持的单位", "retention >= 90d", "3 months", false, false, "只能用于数值"},
+		{"带单位的值严格相等", "retention == 90d", "90d", false, true, ""},
+		{"==不换算单位", "retention == 1d", "24h", false, false, ""},
+
+		// 集合和字符串
+		{"in", `acl in ["private", "authenticated-read"]`, "private", false, true, ""},
+		{"in数值", "port in [80, 443]", 443, false, true, ""},
+		{"not_in", `acl not_in ["public-read"]`, "public-read", false, false, ""},
+		{"字符串contains", "desc contains 生产", "生产环境", false, true, ""},
+		{"数组contains", `tags contains "prod"`, []interface{}{"dev", "prod"}, false, true, ""},
+		{"数组contains数值", "ports contains 22", []interface{}{22.0}, false, true, ""},
+		{"字符串contains数值", "desc contains 1", "a1", false, false, "字符串只能包含字符串"},
+		{"数值contains", "n contains 1", 1.0, false, false, "只能用于字符串或数组"},
+		{"matches", "prefix matches ^logs/", "logs/app", false, true, ""},
+		{"matches非字符串", "prefix matches ^1", 1.0, false, false, "只能用于字符串"},
+		{"无效的正则表达式", "prefix matches [", "x", false, false, "无效的正则表达式"},
+		{"对象相等", `policy == {"a": [1, 2]}`, map[string]interface{}{"a": []interface{}{1.0, 2.0}}, false, true, ""},
+
+		// 字段不存在
+		{"exists", "kms exists", "key", false, true, ""},
+		{"exists缺失", "kms exists", nil, true, false, ""},
+		{"not_exists缺失", "ip not_exists", nil, true, true, ""},
+		{"not_exists存在null", "ip not_exists", nil, false, false, ""},
+		{"缺失字段不满足比较", "n >= 14", nil, true, false, ""},
+		{"缺失字段不满足!=", "n != 14", nil, true, false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRule(tt.rule)
+			if err != nil {
+				t.Fatalf("解析规则失败: %v", err)
+			}
+			got, err := rule.apply(tt.actual, !tt.missing)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("错误为%v，期望包含%q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("结果为%v, %v，期望%v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	config := map[string]interface{}{
+		"logging": map[string]interface{}{"enabled": true, "targets": []interface{}{"s3", map[string]interface{}{"type": "cls"}}},
+		"ip":      nil,
+	}
+	tests := []struct {
+		path   string
+		want   interface{}
+		exists bool
+	}{
+		{"logging.enabled", true, true},
+		{"logging.targets.0", "s3", true},
+		{"logging.targets.1.type", "cls", true},
+		{"ip", nil, true},
+		{"logging.targets.2", nil, false},
+		{"logging.targets.-1", nil, false},
+		{"logging.targets.x", nil, false},
+		{"logging.enabled.value", nil, false},
+		{"missing", nil, false},
+	}
+	for _, tt := range tests {
+		got, exists := lookup(config, tt.path)
+		if exists != tt.exists || got != tt.want {
+			t.Errorf("lookup(%s)返回%v, %v，期望%v, %v", tt.path, got, exists, tt.want, tt.exists)
+		}
+	}
+}
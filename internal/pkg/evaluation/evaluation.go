@@ -0,0 +1,241 @@
+// Package evaluation 按安全配置基线评估云资源配置，服务端和离线的cloudeye check共用
+package evaluation
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/yourusername/cloud-eye/internal/models"
+)
+
+// 评估结果状态
+const (
+	StatusPass   = "pass"   // 满足全部规则
+	StatusFail   = "fail"   // 存在不满足的规则
+	StatusError  = "error"  // 规则无效或无法应用于资源配置
+	StatusManual = "manual" // 配置项没有可自动执行的规则，需要人工检查
+)
+
+// Resource 待评估的云资源及其配置
+type Resource struct {
+	ID       string                 `json:"id"`
+	Name     string                 `json:"name,omitempty"`
+	Provider string                 `json:"provider"` // 云服务商编码
+	Product  string                 `json:"product"`  // 云产品编码
+	Config   map[string]interface{} `json:"config"`
+}
+
+// Validate 检查资源的必填字段
+func (r Resource) Validate() error {
+	switch {
+	case r.ID == "":
+		return errors.New("资源缺少id")
+	case r.Provider == "":
+		return fmt.Errorf("资源 %s 缺少provider", r.ID)
+	case r.Product == "":
+		return fmt.Errorf("资源 %s 缺少product", r.ID)
+	}
+	return nil
+}
+
+// Finding 一个配置项对一个资源的评估结果
+type Finding struct {
+	ResourceID   string      `json:"resource_id"`
+	ResourceName string      `json:"resource_name,omitempty"`
+	Provider     string      `json:"provider"`
+	Product      string      `json:"product"`
+	ItemID       uint        `json:"item_id"`
+	ItemName     string      `json:"item_name"`
+	Severity     string      `json:"severity"`
+	Status       string      `json:"status"`
+	Rule         string      `json:"rule,omitempty"`     // 未通过的规则
+	Expected     interface{} `json:"expected,omitempty"` // 规则的期望值
+	Actual       interface{} `json:"actual,omitempty"`   // 资源配置中的实际值
+	Message      string      `json:"message,omitempty"`
+}
+
+// Summary 评估结果统计
+type Summary struct {
+	Resources int            `json:"resources"`
+	Findings  int            `json:"findings"`
+	Passed    int            `json:"passed"`
+	Failed    int            `json:"failed"`
+	Errors    int            `json:"errors"`
+	Manual    int            `json:"manual"`
+	FailedBy  map[string]int `json:"failed_by_severity"`            // 未通过的评估结果按风险等级统计
+	Unmatched []string       `json:"unmatched_resources,omitempty"` // 没有适用配置项的资源
+}
+
+// Report 评估报告
+type Report struct {
+	Summary  Summary   `json:"summary"`
+	Findings []Finding `json:"findings"`
+}
+
+// Evaluate 使用配置项评估资源。配置项按所属云服务商和产品的编码（不区分大小写）匹配资源，
+// 只评估生效中的配置项，配置项需加载Provider和Product关联
+func Evaluate(items []models.ConfigurationItem, resources []Resource) *Report {
+	report := &Report{
+		Summary:  Summary{Resources: len(resources), FailedBy: make(map[string]int)},
+		Findings: []Finding{},
+	}
+
+	for _, res := range resources {
+		matched := false
+		for i := range items {
+			item := &items[i]
+			if item.Status != models.StatusActive ||
+				!strings.EqualFold(item.Provider.Code, res.Provider) ||
+				!strings.EqualFold(item.Product.Code, res.Product) {
+				continue
+			}
+			matched = true
+			report.add(evaluateItem(item, res))
+		}
+		if !matched {
+			report.Summary.Unmatched = append(report.Summary.Unmatched, res.ID)
+		}
+	}
+
+	sortFindings(report.Findings)
+	return report
+}
+
+// Merge 合并分批评估的报告，评估结果按与Evaluate相同的顺序排列
+func Merge(reports ...*Report) *Report {
+	merged := &Report{
+		Summary:  Summary{FailedBy: make(map[string]int)},
+		Findings: []Finding{},
+	}
+	for _, r := range reports {
+		merged.Summary.Resources += r.Summary.Resources
+		merged.Summary.Unmatched = append(merged.Summary.Unmatched, r.Summary.Unmatched...)
+		for _, f := range r.Findings {
+			merged.add(f)
+		}
+	}
+	sortFindings(merged.Findings)
+	return merged
+}
+
+// sortFindings 未通过的结果排在最前，同一状态内按风险等级从高到低、资源ID和配置项ID排列
+func sortFindings(findings []Finding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Status != b.Status {
+			return statusRank(a.Status) < statusRank(b.Status)
+		}
+		if a.Severity != b.Severity {
+			return SeverityRank(a.Severity) > SeverityRank(b.Severity)
+		}
+		if a.ResourceID != b.ResourceID {
+			return a.ResourceID < b.ResourceID
+		}
+		return a.ItemID < b.ItemID
+	})
+}
+
+// evaluateItem 评估单个配置项，在第一条未通过的规则处停止
+func evaluateItem(item *models.ConfigurationItem, res Resource) Finding {
+	finding := Finding{
+		ResourceID:   res.ID,
+		ResourceName: res.Name,
+		Provider:     res.Provider,
+		Product:      res.Product,
+		ItemID:       item.ID,
+		ItemName:     item.Name,
+		Severity:     item.Severity,
+		Status:       StatusPass,
+	}
+
+	rules, err := ParseRules(item.CheckMethod)
+	if err != nil {
+		finding.Status = StatusError
+		finding.Message = err.Error()
+		return finding
+	}
+	if len(rules) == 0 {
+		finding.Status = StatusManual
+		finding.Message = "没有可自动执行的检查规则，请按检查方法人工确认"
+		return finding
+	}
+
+	for _, rule := range rules {
+		actual, exists := lookup(res.Config, rule.Path)
+		ok, err := rule.apply(actual, exists)
+		if ok && err == nil {
+			continue
+		}
+
+		finding.Rule = rule.Source
+		finding.Expected = rule.Expected
+		finding.Actual = actual
+		switch {
+		case err != nil:
+			finding.Status = StatusError
+			finding.Message = err.Error()
+		case !exists && rule.Op != OpNotExists:
+			finding.Status = StatusFail
+			finding.Message = fmt.Sprintf("缺少配置 %s", rule.Path)
+		default:
+			finding.Status = StatusFail
+			finding.Message = fmt.Sprintf("%s 的值为 %s，不满足 %s", rule.Path, formatValue(actual), rule.Source)
+		}
+		return finding
+	}
+	return finding
+}
+
+// add 记录评估结果并更新统计
+func (r *Report) add(f Finding) {
+	r.Findings = append(r.Findings, f)
+	r.Summary.Findings++
+	switch f.Status {
+	case StatusPass:
+		r.Summary.Passed++
+	case StatusFail:
+		r.Summary.Failed++
+		r.Summary.FailedBy[f.Severity]++
+	case StatusError:
+		r.Summary.Errors++
+	case StatusManual:
+		r.Summary.Manual++
+	}
+}
+
+// Exceeds 判断是否存在风险等级不低于threshold的未通过结果
+func (r *Report) Exceeds(threshold string) bool {
+	rank := SeverityRank(threshold)
+	for _, f := range r.Findings {
+		if f.Status == StatusFail && SeverityRank(f.Severity) >= rank {
+			return true
+		}
+	}
+	return false
+}
+
+// SeverityRank 风险等级的高低，info为0，critical最高；无效的等级返回-1
+func SeverityRank(severity string) int {
+	for i, s := range models.Severities {
+		if s == severity {
+			return len(models.Severities) - 1 - i
+		}
+	}
+	return -1
+}
+
+// statusRank 评估结果的排列顺序，未通过的排在最前
+func statusRank(status string) int {
+	switch status {
+	case StatusFail:
+		return 0
+	case StatusError:
+		return 1
+	case StatusManual:
+		return 2
+	default:
+		return 3
+	}
+}
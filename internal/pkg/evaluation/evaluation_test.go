@@ -0,0 +1,141 @@
+package evaluation
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/cloud-eye/internal/models"
+)
+
+func testItem(id uint, severity, checkMethod string) models.ConfigurationItem {
+	return models.ConfigurationItem{
+		BaseModel:   models.BaseModel{ID: id},
+		Name:        "配置项",
+		Severity:    severity,
+		Status:      models.StatusActive,
+		CheckMethod: checkMethod,
+		Provider:    models.CloudProvider{Code: "AWS"},
+		Product:     models.CloudProduct{Code: "S3"},
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	inactive := testItem(6, models.SeverityCritical, "check: a == 1")
+	inactive.Status = models.StatusDeprecated
+	items := []models.ConfigurationItem{
+		testItem(1, models.SeverityHigh, "check: versioning.enabled == true\ncheck: retention >= 90d"),
+		testItem(2, models.SeverityLow, "人工检查"),
+		testItem(3, models.SeverityMedium, "check: acl = private"),
+		testItem(4, models.SeverityCritical, "check: kms exists"),
+		testItem(5, models.SeverityMedium, "check: retention >= 90d"),
+		inactive,
+	}
+	resources := []Resource{
+		{ID: "bucket-a", Provider: "aws", Product: "s3", Config: map[string]interface{}{
+			"versioning": map[string]interface{}{"enabled": true}, "retention": "30d", "kms": "key",
+		}},
+		{ID: "vm-1", Provider: "aws", Product: "ec2"},
+	}
+
+	report := Evaluate(items, resources)
+	s := report.Summary
+	if s.Resources != 2 || s.Findings != 5 || s.Passed != 1 || s.Failed != 2 || s.Errors != 1 || s.Manual != 1 {
+		t.Fatalf("统计为%+v", s)
+	}
+	if len(s.Unmatched) != 1 || s.Unmatched[0] != "vm-1" {
+		t.Fatalf("没有适用配置项的资源为%v", s.Unmatched)
+	}
+	if s.FailedBy[models.SeverityHigh] != 1 || s.FailedBy[models.SeverityMedium] != 1 {
+		t.Fatalf("按风险等级统计为%v", s.FailedBy)
+	}
+
+	// 未通过的排在最前并按风险等级从高到低，之后依次为错误、人工检查和通过
+	var order []uint
+	for _, f := range report.Findings {
+		order = append(order, f.ItemID)
+	}
+	if want := []uint{1, 5, 3, 2, 4}; !equalIDs(order, want) {
+		t.Fatalf("评估结果顺序为%v，期望%v", order, want)
+	}
+
+	first := report.Findings[0]
+	if first.Rule != "retention >= 90d" || first.Actual != "30d" || !strings.Contains(first.Message, "不满足") {
+		t.Fatalf("未通过的结果为%+v", first)
+	}
+	if e := report.Findings[2]; e.Status != StatusError || !strings.Contains(e.Message, "不支持的操作符") {
+		t.Fatalf("规则无效的结果为%+v", e)
+	}
+}
+
+func TestEvaluateMissingField(t *testing.T) {
+	items := []models.ConfigurationItem{testItem(1, models.SeverityHigh, "check: logging.enabled == true")}
+	report := Evaluate(items, []Resource{{ID: "b", Provider: "AWS", Product: "S3", Config: map[string]interface{}{}}})
+	f := report.Findings[0]
+	if f.Status != StatusFail || f.Message != "缺少配置 logging.enabled" {
+		t.Fatalf("缺少字段的结果为%+v", f)
+	}
+}
+
+func TestExceeds(t *testing.T) {
+	report := &Report{Findings: []Finding{
+		{Status: StatusFail, Severity: models.SeverityMedium},
+		{Status: StatusError, Severity: models.SeverityCritical},
+		{Status: StatusManual, Severity: models.SeverityCritical},
+		{Status: StatusPass, Severity: models.SeverityCritical},
+	}}
+	tests := []struct {
+		threshold string
+		want      bool
+	}{
+		{models.SeverityInfo, true},
+		{models.SeverityLow, true},
+		{models.SeverityMedium, true},
+		// 错误、人工检查和通过的结果不计入
+		{models.SeverityHigh, false},
+		{models.SeverityCritical, false},
+	}
+	for _, tt := range tests {
+		if got := report.Exceeds(tt.threshold); got != tt.want {
+			t.Errorf("Exceeds(%s)返回%v，期望%v", tt.threshold, got, tt.want)
+		}
+	}
+
+	if (&Report{}).Exceeds(models.SeverityInfo) {
+		t.Error("没有评估结果时超过阈值")
+	}
+}
+
+func TestMergeMatchesEvaluate(t *testing.T) {
+	items := []models.ConfigurationItem{
+		testItem(1, models.SeverityHigh, "check: n >= 10"),
+		testItem(2, models.SeverityLow, "check: n >= 5"),
+	}
+	var resources []Resource
+	for _, n := range []float64{1, 7, 12} {
+		resources = append(resources, Resource{ID: fmt.Sprintf("r%02.0f", n), Provider: "AWS", Product: "S3", Config: map[string]interface{}{"n": n}})
+	}
+
+	whole := Evaluate(items, resources)
+	merged := Merge(Evaluate(items, resources[:1]), Evaluate(items, resources[1:]))
+	if merged.Summary.Findings != whole.Summary.Findings || merged.Summary.Failed != whole.Summary.Failed {
+		t.Fatalf("合并后的统计为%+v，期望%+v", merged.Summary, whole.Summary)
+	}
+	for i := range whole.Findings {
+		if merged.Findings[i].ResourceID != whole.Findings[i].ResourceID || merged.Findings[i].ItemID != whole.Findings[i].ItemID {
+			t.Fatalf("合并后第%d个结果为%+v，期望%+v", i, merged.Findings[i], whole.Findings[i])
+		}
+	}
+}
+
+func equalIDs(a, b []uint) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,125 @@
+package evaluation
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// RulePrefix 检查方法中可自动执行的规则行前缀，例如
+//
+//	check: logging.enabled == true
+//	check: password_policy.min_length >= 14
+//	check: acl in ["private", "authenticated-read"]
+//	check: log_retention >= 90d
+//
+// 大小比较（>、>=、<、<=）也接受数值字符串和带单位的字符串：时长s、m、h、d，容量B、KB、MB、GB、TB、KiB、MiB、GiB、TiB
+// （不区分大小写），两边的单位需属于同一类，无单位的数值只能与无单位的数值比较；==和!=严格比较，"14"不等于14。
+// 一个配置项可以有多行规则，全部满足时通过；没有规则行的配置项需要人工检查
+const RulePrefix = "check:"
+
+// 规则操作符
+const (
+	OpEqual        = "=="
+	OpNotEqual     = "!="
+	OpGreater      = ">"
+	OpGreaterEqual = ">="
+	OpLess         = "<"
+	OpLessEqual    = "<="
+	OpIn           = "in"
+	OpNotIn        = "not_in"
+	OpContains     = "contains"
+	OpMatches      = "matches"
+	OpExists       = "exists"
+	OpNotExists    = "not_exists"
+)
+
+// operators 支持的操作符，是否需要期望值
+var operators = map[string]bool{
+	OpEqual:        true,
+	OpNotEqual:     true,
+	OpGreater:      true,
+	OpGreaterEqual: true,
+	OpLess:         true,
+	OpLessEqual:    true,
+	OpIn:           true,
+	OpNotIn:        true,
+	OpContains:     true,
+	OpMatches:      true,
+	OpExists:       false,
+	OpNotExists:    false,
+}
+
+// Rule 一条检查规则
+type Rule struct {
+	Path     string      `json:"path"`               // 资源配置中的字段路径，以.分隔，数组元素使用下标
+	Op       string      `json:"op"`                 // 操作符
+	Expected interface{} `json:"expected,omitempty"` // 期望值
+	Source   string      `json:"source"`             // 规则原文
+}
+
+// ParseRules 从配置项的检查方法中解析规则，忽略非规则行
+func ParseRules(checkMethod string) ([]Rule, error) {
+	var rules []Rule
+	for _, line := range strings.Split(checkMethod, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) < len(RulePrefix) || !strings.EqualFold(line[:len(RulePrefix)], RulePrefix) {
+			continue
+		}
+		rule, err := ParseRule(line[len(RulePrefix):])
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// ParseRule 解析一条规则：<字段路径> <操作符> [期望值]，期望值按JSON解析，不是合法JSON时作为字符串
+func ParseRule(text string) (Rule, error) {
+	source := strings.TrimSpace(text)
+	fields := strings.Fields(source)
+	if len(fields) < 2 {
+		return Rule{}, fmt.Errorf("无效的规则 %q：格式应为 <字段路径> <操作符> [期望值]", source)
+	}
+
+	rule := Rule{Path: fields[0], Op: fields[1], Source: source}
+	needsValue, ok := operators[rule.Op]
+	if !ok {
+		return Rule{}, fmt.Errorf("无效的规则 %q：不支持的操作符 %s", source, rule.Op)
+	}
+
+	// 期望值取操作符之后的剩余部分，保留其中的空格
+	rest := strings.TrimSpace(source[len(fields[0]):])
+	rest = strings.TrimSpace(rest[len(rule.Op):])
+	switch {
+	case needsValue && rest == "":
+		return Rule{}, fmt.Errorf("无效的规则 %q：操作符 %s 需要期望值", source, rule.Op)
+	case !needsValue && rest != "":
+		return Rule{}, fmt.Errorf("无效的规则 %q：操作符 %s 不需要期望值", source, rule.Op)
+	case needsValue:
+		rule.Expected = parseValue(rest)
+	}
+
+	if (rule.Op == OpIn || rule.Op == OpNotIn) && !isList(rule.Expected) {
+		return Rule{}, fmt.Errorf("无效的规则 %q：操作符 %s 的期望值必须是JSON数组", source, rule.Op)
+	}
+	if _, ok := rule.Expected.(string); rule.Op == OpMatches && !ok {
+		return Rule{}, fmt.Errorf("无效的规则 %q：操作符 %s 的期望值必须是正则表达式字符串", source, rule.Op)
+	}
+	return rule, nil
+}
+
+// parseValue 按JSON解析期望值，失败时作为字符串
+func parseValue(text string) interface{} {
+	var v interface{}
+	if err := json.Unmarshal([]byte(text), &v); err != nil {
+		return text
+	}
+	return v
+}
+
+func isList(v interface{}) bool {
+	_, ok := v.([]interface{})
+	return ok
+}
@@ -0,0 +1,82 @@
+package evaluation
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseRule(t *testing.T) {
+	tests := []struct {
+		text string
+		want Rule
+	}{
+		{"logging.enabled == true", Rule{Path: "logging.enabled", Op: OpEqual, Expected: true}},
+		{"  password_policy.min_length >= 14  ", Rule{Path: "password_policy.min_length", Op: OpGreaterEqual, Expected: 14.0}},
+		{`acl in ["private", "authenticated-read"]`, Rule{Path: "acl", Op: OpIn, Expected: []interface{}{"private", "authenticated-read"}}},
+		{`region not_in ["cn-north-1"]`, Rule{Path: "region", Op: OpNotIn, Expected: []interface{}{"cn-north-1"}}},
+		{"logging.prefix matches ^logs/", Rule{Path: "logging.prefix", Op: OpMatches, Expected: "^logs/"}},
+		{"description contains 生产 环境", Rule{Path: "description", Op: OpContains, Expected: "生产 环境"}},
+		{`name == "a  b"`, Rule{Path: "name", Op: OpEqual, Expected: "a  b"}},
+		{"kms_key_id exists", Rule{Path: "kms_key_id", Op: OpExists}},
+		{"public_ip not_exists", Rule{Path: "public_ip", Op: OpNotExists}},
+		{"log_retention >= 90d", Rule{Path: "log_retention", Op: OpGreaterEqual, Expected: "90d"}},
+		{"tags != null", Rule{Path: "tags", Op: OpNotEqual, Expected: nil}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, err := ParseRule(tt.text)
+			if err != nil {
+				t.Fatalf("解析失败: %v", err)
+			}
+			tt.want.Source = strings.TrimSpace(tt.text)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("解析结果为%#v，期望%#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRuleErrors(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"", "格式应为"},
+		{"logging.enabled", "格式应为"},
+		{"logging.enabled = true", "不支持的操作符 ="},
+		{"logging.enabled === true", "不支持的操作符 ==="},
+		{"logging.enabled IN [1]", "不支持的操作符 IN"},
+		{"size between [1, 2]", "不支持的操作符 between"},
+		{"logging.enabled ==", "需要期望值"},
+		{"kms_key_id exists true", "不需要期望值"},
+		{`acl in "private"`, "必须是JSON数组"},
+		{`acl not_in {"a": 1}`, "必须是JSON数组"},
+		{"name matches 123", "必须是正则表达式字符串"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			_, err := ParseRule(tt.text)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("错误为%v，期望包含%q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRules(t *testing.T) {
+	rules, err := ParseRules("登录控制台检查日志设置。\n  check: logging.enabled == true\nCHECK: logging.retention >= 90d\nchecked manually\n")
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	if len(rules) != 2 || rules[0].Path != "logging.enabled" || rules[1].Path != "logging.retention" {
+		t.Fatalf("解析出的规则为%+v", rules)
+	}
+
+	if rules, err := ParseRules("按控制台说明人工检查"); err != nil || len(rules) != 0 {
+		t.Fatalf("没有规则行时返回%v, %v", rules, err)
+	}
+	if _, err := ParseRules("check: a == 1\ncheck: b ~= 2"); err == nil || !strings.Contains(err.Error(), "~=") {
+		t.Fatalf("任一规则无效时返回%v，期望错误", err)
+	}
+}
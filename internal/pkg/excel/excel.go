@@ -7,8 +7,10 @@ import (
 	"io"
 	"os"
 	"path/filepath"
-	"strconv"
+	"sort"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/yourusername/cloud-eye/internal/models"
 	"github.com/yourusername/cloud-eye/internal/pkg/config"
@@ -59,7 +61,7 @@ func (e *ConfigItemExporter) Export(ctx context.Context, items []models.Configur
 	headers := []string{
 		"ID", "云服务商", "云产品", "配置项名称", "推荐配置值", 
 		"风险说明", "检查方法", "配置方式", "参考资料",
-		"创建时间", "更新时间",
+		"创建时间", "更新时间", "产品类别", "标签",
 	}
 	for i, header := range headers {
 		cell := fmt.Sprintf("%c1", 'A'+i)
@@ -96,8 +98,7 @@ func (e *ConfigItemExporter) Export(ctx context.Context, items []models.Configur
 	}
 
 	// 设置表头样式
-	headerRange := fmt.Sprintf("A1:K1")
-	if err := f.SetCellStyle(sheetName, "A1", "K1", headerStyle); err != nil {
+	if err := f.SetCellStyle(sheetName, "A1", "M1", headerStyle); err != nil {
 		logger.Error("Failed to set header style", err)
 		return "", err
 	}
@@ -117,6 +118,8 @@ func (e *ConfigItemExporter) Export(ctx context.Context, items []models.Configur
 			item.Reference,
 			item.CreatedAt.Format("2006-01-02 15:04:05"),
 			item.UpdatedAt.Format("2006-01-02 15:04:05"),
+			categoryName(item.Product),
+			strings.Join(models.TagNames(item.Tags), ","),
 		}
 
 		for j, cellData := range rowData {
@@ -126,9 +129,9 @@ func (e *ConfigItemExporter) Export(ctx context.Context, items []models.Configur
 	}
 
 	// 设置列宽
-	colWidths := []float64{8, 15, 20, 40, 40, 30, 30, 30, 30, 20, 20}
+	colWidths := []float64{8, 15, 20, 40, 40, 30, 30, 30, 30, 20, 20, 20, 30}
 	for i, width := range colWidths {
-		col := string('A' + i)
+		col, _ := excelize.ColumnNumberToName(i + 1)
 		f.SetColWidth(sheetName, col, col, width)
 	}
 
@@ -152,20 +155,85 @@ func (e *ConfigItemExporter) Export(ctx context.Context, items []models.Configur
 	return filepath, nil
 }
 
+// GroupByCategory 按产品类别稳定排序配置项，同一类别内保持原有顺序，未分类的排在最后
+func GroupByCategory(items []models.ConfigurationItem) {
+	sort.SliceStable(items, func(i, j int) bool {
+		ci, cj := items[i].Product.Category, items[j].Product.Category
+		if ci == nil || cj == nil {
+			return ci != nil && cj == nil
+		}
+		return ci.Name < cj.Name
+	})
+}
+
+// categoryName 返回产品所属类别名称，未分类时返回空字符串
+func categoryName(product models.CloudProduct) string {
+	if product.Category == nil {
+		return ""
+	}
+	return product.Category.Name
+}
+
+// parseTagCell 解析标签列，支持中英文逗号分隔
+func parseTagCell(value string) []models.Tag {
+	var tags []models.Tag
+	for _, name := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == '，' }) {
+		if name = strings.TrimSpace(name); name != "" {
+			tags = append(tags, models.Tag{Name: name})
+		}
+	}
+	return tags
+}
+
+// 导入文件中文本列的长度上限（字符数），与REST接口创建配置项的校验规则一致
+const (
+	maxNameLength = 200
+	maxTextLength = 16000
+)
+
+// textColumns 导入文件中推荐配置值至参考资料的列名，按列顺序排列
+var textColumns = []string{"推荐配置值", "风险说明", "检查方法", "配置方式", "参考资料"}
+
+// validateRowText 校验一行的配置项名称和文本列：推荐配置值必填，各列不超过长度上限，校验失败时返回原因
+func validateRowText(row []string) string {
+	if n := utf8.RuneCountInString(strings.TrimSpace(row[3])); n > maxNameLength {
+		return fmt.Sprintf("配置项名称超过%d个字符", maxNameLength)
+	}
+	if strings.TrimSpace(row[4]) == "" {
+		return "推荐配置值为空"
+	}
+	for j, name := range textColumns {
+		if utf8.RuneCountInString(row[4+j]) > maxTextLength {
+			return fmt.Sprintf("%s超过%d个字符", name, maxTextLength)
+		}
+	}
+	return ""
+}
+
 // ConfigItemImporter 配置项导入器
 type ConfigItemImporter struct {
-	ImportPath string
+	ImportPath     string
+	QuarantinePath string // 未通过校验的上传文件的隔离目录，为空时直接删除
+	Limits         UploadLimits
 }
 
 // NewConfigItemImporter 创建配置项导入器
 func NewConfigItemImporter() *ConfigItemImporter {
+	cfg := config.GetConfig().Excel
 	return &ConfigItemImporter{
-		ImportPath: config.GetConfig().Excel.ImportPath,
+		ImportPath:     cfg.ImportPath,
+		QuarantinePath: cfg.QuarantinePath,
+		Limits: UploadLimits{
+			MaxUploadSize:       cfg.MaxUploadSize,
+			MaxUncompressedSize: cfg.MaxUncompressedSize,
+			MaxRows:             cfg.MaxRows,
+			MaxSheets:           cfg.MaxSheets,
+		},
 	}
 }
 
-// SaveUploadedFile 保存上传的Excel文件
-func (i *ConfigItemImporter) SaveUploadedFile(file io.Reader, filename string) (string, error) {
+// SaveUploadedFile 以随机文件名保存上传的Excel文件，超过大小限制时删除已写入的内容并返回ErrFileTooLarge
+func (i *ConfigItemImporter) SaveUploadedFile(file io.Reader) (string, error) {
 	// 确保导入目录存在
 	if err := os.MkdirAll(i.ImportPath, 0755); err != nil {
 		logger.Error("Failed to create import directory", err)
@@ -173,6 +241,10 @@ func (i *ConfigItemImporter) SaveUploadedFile(file io.Reader, filename string) (
 	}
 
 	// 生成文件路径
+	filename, err := uploadFileName()
+	if err != nil {
+		return "", err
+	}
 	filePath := filepath.Join(i.ImportPath, filename)
 
 	// 创建目标文件
@@ -183,10 +255,16 @@ func (i *ConfigItemImporter) SaveUploadedFile(file io.Reader, filename string) (
 	}
 	defer out.Close()
 
-	// 写入文件
-	_, err = io.Copy(out, file)
+	// 写入文件，多读取一个字节以判断是否超过大小限制
+	limit := i.Limits.withDefaults().MaxUploadSize
+	written, err := io.Copy(out, io.LimitReader(file, limit+1))
+	if err == nil && written > limit {
+		err = ErrFileTooLarge
+	}
 	if err != nil {
 		logger.Error("Failed to copy file content", err)
+		out.Close()
+		os.Remove(filePath)
 		return "", err
 	}
 
@@ -196,11 +274,18 @@ func (i *ConfigItemImporter) SaveUploadedFile(file io.Reader, filename string) (
 
 // ImportConfigItems 从Excel文件导入配置项
 func (i *ConfigItemImporter) ImportConfigItems(ctx context.Context, filePath string) ([]models.ConfigurationItem, error) {
-	// 打开Excel文件
-	f, err := excelize.OpenFile(filePath)
+	items, _, err := i.ParseConfigItems(ctx, filePath)
+	return items, err
+}
+
+// ParseConfigItems 从Excel文件解析配置项，无法解析的行被跳过并在warnings中说明原因
+func (i *ConfigItemImporter) ParseConfigItems(ctx context.Context, filePath string) ([]models.ConfigurationItem, []string, error) {
+	// 打开Excel文件，限制解压后的大小
+	limits := i.Limits.withDefaults()
+	f, err := excelize.OpenFile(filePath, excelize.Options{UnzipSizeLimit: limits.MaxUncompressedSize})
 	if err != nil {
 		logger.Error("Failed to open Excel file", err, zap.String("filepath", filePath))
-		return nil, ErrInvalidFile
+		return nil, nil, ErrInvalidFile
 	}
 	defer func() {
 		if err := f.Close(); err != nil {
@@ -211,67 +296,111 @@ func (i *ConfigItemImporter) ImportConfigItems(ctx context.Context, filePath str
 	// 获取所有工作表
 	sheets := f.GetSheetList()
 	if len(sheets) == 0 {
-		return nil, ErrInvalidSheetName
+		return nil, nil, ErrInvalidSheetName
 	}
 
 	// 使用第一个工作表
 	sheetName := sheets[0]
 
 	// 获取所有行
-	rows, err := f.GetRows(sheetName)
+	rows, err := readRows(f, sheetName, limits.MaxRows)
 	if err != nil {
 		logger.Error("Failed to get rows from Excel", err)
-		return nil, err
+		return nil, nil, err
 	}
 
 	if len(rows) < 2 { // 至少需要表头和一行数据
-		return nil, ErrInvalidData
+		return nil, nil, ErrInvalidData
 	}
 
 	// 解析表头（第一行）
 	headers := rows[0]
 	// 验证表头...（这里简化处理，实际应用中可以更严格地验证表头）
 
+	// 标签列为可选列，按表头名称定位
+	tagCol := -1
+	for j, header := range headers {
+		if strings.TrimSpace(header) == "标签" {
+			tagCol = j
+			break
+		}
+	}
+
 	// 解析数据
 	var items []models.ConfigurationItem
+	var warnings []string
 	for i := 1; i < len(rows); i++ {
 		row := rows[i]
-		if len(row) < 9 { // 至少需要9列数据
-			logger.Warn("Skipping row due to insufficient columns", zap.Int("rowIndex", i+1))
+		if len(row) == 0 { // 忽略中间的空行
 			continue
 		}
+		if len(row) < 9 { // 末尾未填写的单元格不会被读取，补齐到9列
+			row = append(row, make([]string, 9-len(row))...)
+		}
 
-		// 提取数据
-		// 注意：这里假设Excel中存储的是ID而不是名称，实际使用时可能需要转换
-		providerID, err := strconv.ParseUint(row[1], 10, 32)
-		if err != nil {
-			logger.Warn("Invalid provider ID", zap.String("value", row[1]), zap.Int("rowIndex", i+1))
+		// 云服务商和云产品可以填写ID或代码，代码在导入时解析为ID
+		providerID, providerCode, ok := parseRef(strings.TrimSpace(row[1]))
+		if !ok {
+			logger.Warn("Invalid provider", zap.String("value", row[1]), zap.Int("rowIndex", i+1))
+			warnings = append(warnings, fmt.Sprintf("第%d行：无效的云服务商 %q", i+1, row[1]))
 			continue
 		}
 
-		productID, err := strconv.ParseUint(row[2], 10, 32)
-		if err != nil {
-			logger.Warn("Invalid product ID", zap.String("value", row[2]), zap.Int("rowIndex", i+1))
+		// 模板中的云产品格式为“云服务商/云产品代码”，其余按ID或代码解析
+		var productID uint
+		var productCode string
+		productCell := strings.TrimSpace(row[2])
+		if providerRef, code, found := strings.Cut(productCell, "/"); found {
+			if providerRef != strings.TrimSpace(row[1]) {
+				logger.Warn("Product does not match provider", zap.String("value", row[2]), zap.Int("rowIndex", i+1))
+				warnings = append(warnings, fmt.Sprintf("第%d行：云产品%q不属于云服务商%q", i+1, row[2], row[1]))
+				continue
+			}
+			productCode, ok = code, code != ""
+		} else {
+			productID, productCode, ok = parseRef(productCell)
+		}
+		if !ok {
+			logger.Warn("Invalid product", zap.String("value", row[2]), zap.Int("rowIndex", i+1))
+			warnings = append(warnings, fmt.Sprintf("第%d行：无效的云产品 %q", i+1, row[2]))
+			continue
+		}
+
+		if strings.TrimSpace(row[3]) == "" {
+			logger.Warn("Skipping row without name", zap.Int("rowIndex", i+1))
+			warnings = append(warnings, fmt.Sprintf("第%d行：配置项名称为空", i+1))
+			continue
+		}
+		if msg := validateRowText(row); msg != "" {
+			logger.Warn("Skipping invalid row", zap.String("reason", msg), zap.Int("rowIndex", i+1))
+			warnings = append(warnings, fmt.Sprintf("第%d行：%s", i+1, msg))
 			continue
 		}
 
 		item := models.ConfigurationItem{
-			CloudProviderID:    uint(providerID),
-			ProductID:          uint(productID),
-			Name:               row[3],
+			CloudProviderID:    providerID,
+			ProductID:          productID,
+			Name:               strings.TrimSpace(row[3]),
 			RecommendedValue:   row[4],
 			RiskDescription:    row[5],
 			CheckMethod:        row[6],
 			ConfigurationMethod: row[7],
 			Reference:          row[8],
 		}
+		// 按代码引用时记录代码，由服务层解析为ID
+		item.Provider.Code = providerCode
+		item.Product.Code = productCode
+
+		if tagCol >= 0 && tagCol < len(row) {
+			item.Tags = parseTagCell(row[tagCol])
+		}
 
 		items = append(items, item)
 	}
 
 	if len(items) == 0 {
-		return nil, ErrInvalidData
+		return nil, warnings, ErrInvalidData
 	}
 
-	return items, nil
+	return items, warnings, nil
 }
\ No newline at end of file
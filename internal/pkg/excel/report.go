@@ -0,0 +1,198 @@
+package excel
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+	"github.com/yourusername/cloud-eye/internal/models"
+	"github.com/yourusername/cloud-eye/internal/pkg/config"
+	"github.com/yourusername/cloud-eye/internal/pkg/evaluation"
+	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// 评估报告Excel生成
+
+// 评估结果的中文名称
+var findingStatusNames = map[string]string{
+	evaluation.StatusPass:   "通过",
+	evaluation.StatusFail:   "未通过",
+	evaluation.StatusError:  "错误",
+	evaluation.StatusManual: "需人工检查",
+}
+
+// ReportWriter 评估报告生成器
+type ReportWriter struct {
+	ReportPath string
+}
+
+// NewReportWriter 创建评估报告生成器，报告与导出文件保存在同一目录
+func NewReportWriter() *ReportWriter {
+	return &ReportWriter{
+		ReportPath: config.GetConfig().Excel.ExportPath,
+	}
+}
+
+// Write 将评估报告写入Excel，包含概览和评估结果两个工作表
+func (w *ReportWriter) Write(ctx context.Context, report *evaluation.Report) (string, error) {
+	if report == nil {
+		return "", ErrInvalidData
+	}
+
+	f := excelize.NewFile()
+	defer func() {
+		if err := f.Close(); err != nil {
+			logger.Error("Failed to close Excel file", err)
+		}
+	}()
+
+	headerStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true, Family: "微软雅黑", Size: 11, Color: "#FFFFFF"},
+		Fill: excelize.Fill{Type: "pattern", Color: []string{"#4472C4"}, Pattern: 1},
+		Alignment: &excelize.Alignment{
+			Horizontal: "center",
+			Vertical:   "center",
+		},
+	})
+	if err != nil {
+		logger.Error("Failed to create header style", err)
+		return "", err
+	}
+
+	if err := writeSummarySheet(f, "概览", &report.Summary, headerStyle); err != nil {
+		logger.Error("Failed to write report summary", err)
+		return "", err
+	}
+	if err := writeFindingSheet(f, "评估结果", report.Findings, headerStyle); err != nil {
+		logger.Error("Failed to write report findings", err)
+		return "", err
+	}
+
+	if err := os.MkdirAll(w.ReportPath, 0755); err != nil {
+		logger.Error("Failed to create report directory", err)
+		return "", err
+	}
+
+	filename := fmt.Sprintf("评估报告_%s.xlsx", time.Now().Format("20060102150405"))
+	path := filepath.Join(w.ReportPath, filename)
+	if err := f.SaveAs(path); err != nil {
+		logger.Error("Failed to save Excel file", err)
+		return "", err
+	}
+
+	logger.Info("Evaluation report generated", zap.String("filepath", path), zap.Int("findings", len(report.Findings)))
+	return path, nil
+}
+
+// writeSummarySheet 写入评估概览，使用新建文件的默认工作表
+func writeSummarySheet(f *excelize.File, sheet string, summary *evaluation.Summary, headerStyle int) error {
+	if err := f.SetSheetName("Sheet1", sheet); err != nil {
+		return err
+	}
+
+	rows := [][]interface{}{
+		{"项目", "数量"},
+		{"评估资源数", summary.Resources},
+		{"评估结果数", summary.Findings},
+		{"通过", summary.Passed},
+		{"未通过", summary.Failed},
+		{"错误", summary.Errors},
+		{"需人工检查", summary.Manual},
+	}
+	for _, severity := range models.Severities {
+		if n := summary.FailedBy[severity]; n > 0 {
+			rows = append(rows, []interface{}{"未通过（" + severity + "）", n})
+		}
+	}
+	if len(summary.Unmatched) > 0 {
+		unmatched, _ := json.Marshal(summary.Unmatched)
+		rows = append(rows, []interface{}{"没有适用配置项的资源", string(unmatched)})
+	}
+
+	for i, row := range rows {
+		cell, _ := excelize.CoordinatesToCellName(1, i+1)
+		if err := f.SetSheetRow(sheet, cell, &row); err != nil {
+			return err
+		}
+	}
+	if err := f.SetCellStyle(sheet, "A1", "B1", headerStyle); err != nil {
+		return err
+	}
+	return f.SetColWidth(sheet, "A", "B", 24)
+}
+
+// writeFindingSheet 写入评估结果明细
+func writeFindingSheet(f *excelize.File, sheet string, findings []evaluation.Finding, headerStyle int) error {
+	if _, err := f.NewSheet(sheet); err != nil {
+		return err
+	}
+
+	headers := []interface{}{
+		"资源ID", "资源名称", "云服务商", "云产品", "配置项ID", "配置项名称",
+		"风险等级", "结果", "规则", "期望值", "实际值", "说明",
+	}
+	if err := f.SetSheetRow(sheet, "A1", &headers); err != nil {
+		return err
+	}
+	if err := f.SetCellStyle(sheet, "A1", "L1", headerStyle); err != nil {
+		return err
+	}
+
+	for i, finding := range findings {
+		row := []interface{}{
+			finding.ResourceID,
+			finding.ResourceName,
+			finding.Provider,
+			finding.Product,
+			finding.ItemID,
+			finding.ItemName,
+			finding.Severity,
+			findingStatusName(finding.Status),
+			finding.Rule,
+			cellValue(finding.Expected),
+			cellValue(finding.Actual),
+			finding.Message,
+		}
+		cell, _ := excelize.CoordinatesToCellName(1, i+2)
+		if err := f.SetSheetRow(sheet, cell, &row); err != nil {
+			return err
+		}
+	}
+
+	colWidths := []float64{20, 20, 12, 12, 10, 40, 10, 12, 30, 20, 20, 40}
+	for i, width := range colWidths {
+		col, _ := excelize.ColumnNumberToName(i + 1)
+		if err := f.SetColWidth(sheet, col, col, width); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findingStatusName 返回评估结果的中文名称
+func findingStatusName(status string) string {
+	if name, ok := findingStatusNames[status]; ok {
+		return name
+	}
+	return status
+}
+
+// cellValue 将期望值或实际值转换为单元格内容，非字符串的值以JSON表示
+func cellValue(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
@@ -0,0 +1,296 @@
+package excel
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/xuri/excelize/v2"
+	"github.com/yourusername/cloud-eye/internal/models"
+	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+)
+
+// 导入模板的工作表，导入时读取第一个工作表，填写数据的工作表必须排在第一个
+const (
+	templateSheet     = "配置项"
+	instructionsSheet = "填写说明"
+	lookupSheet       = "lookup"
+)
+
+// templateHeaders 导入模板的表头，与ParseConfigItems读取的列顺序一致
+var templateHeaders = []string{
+	"序号", "云服务商", "云产品", "配置项名称", "推荐配置值", "风险说明", "检查方法", "配置方式", "参考资料", "标签",
+}
+
+// templateColWidths 导入模板各列的宽度
+var templateColWidths = []float64{8, 16, 28, 30, 30, 40, 40, 40, 30, 20}
+
+// ProviderRef 返回导入文件中引用云服务商的值：使用代码，代码为空或是纯数字时使用ID，避免与ID混淆
+func ProviderRef(provider models.CloudProvider) string {
+	if provider.Code == "" || isDigits(provider.Code) {
+		return strconv.FormatUint(uint64(provider.ID), 10)
+	}
+	return provider.Code
+}
+
+// ProductRef 返回导入文件中引用云产品的值，格式为“云服务商引用/云产品代码”，代码为空时使用ID
+func ProductRef(providerRef string, product models.CloudProduct) string {
+	if product.Code == "" {
+		return strconv.FormatUint(uint64(product.ID), 10)
+	}
+	return providerRef + "/" + product.Code
+}
+
+// parseRef 解析云服务商或云产品的引用，纯数字为ID，否则为代码
+func parseRef(value string) (id uint, code string, ok bool) {
+	if value == "" {
+		return 0, "", false
+	}
+	if isDigits(value) {
+		n, err := strconv.ParseUint(value, 10, 32)
+		if err != nil || n == 0 {
+			return 0, "", false
+		}
+		return uint(n), "", true
+	}
+	return 0, value, true
+}
+
+// isDigits 判断字符串是否只包含数字
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// WriteTemplate 生成配置项导入模板：表头与导入列一致，云服务商和云产品列提供来自隐藏工作表的下拉列表，
+// 填写说明工作表包含填写规则和示例
+func (i *ConfigItemImporter) WriteTemplate(w io.Writer, providers []models.CloudProvider, products []models.CloudProduct) error {
+	limits := i.Limits.withDefaults()
+
+	f := excelize.NewFile()
+	defer func() {
+		if err := f.Close(); err != nil {
+			logger.Error("Failed to close Excel file", err)
+		}
+	}()
+
+	if err := f.SetSheetName("Sheet1", templateSheet); err != nil {
+		return err
+	}
+	if _, err := f.NewSheet(instructionsSheet); err != nil {
+		return err
+	}
+	if _, err := f.NewSheet(lookupSheet); err != nil {
+		return err
+	}
+
+	providerRefs, productRefs, err := writeLookupSheet(f, providers, products)
+	if err != nil {
+		return err
+	}
+	if err := writeTemplateSheet(f, limits.MaxRows, len(providerRefs), len(productRefs)); err != nil {
+		return err
+	}
+	if err := writeInstructionsSheet(f, limits, productRefs); err != nil {
+		return err
+	}
+
+	// 隐藏查找表，避免被误改
+	if err := f.SetSheetVisible(lookupSheet, false, true); err != nil {
+		return err
+	}
+	f.SetActiveSheet(0)
+
+	return f.Write(w)
+}
+
+// lookupProduct 下拉列表中的云产品引用及其所属云服务商的引用
+type lookupProduct struct {
+	providerRef string
+	ref         string
+}
+
+// writeLookupSheet 写入下拉列表的数据来源：A、B列为云服务商引用和名称，C、D列为云产品引用和名称
+func writeLookupSheet(f *excelize.File, providers []models.CloudProvider, products []models.CloudProduct) ([]string, []lookupProduct, error) {
+	if err := f.SetSheetRow(lookupSheet, "A1", &[]interface{}{"云服务商", "云服务商名称", "云产品", "云产品名称"}); err != nil {
+		return nil, nil, err
+	}
+
+	refByProvider := make(map[uint]string, len(providers))
+	providerRefs := make([]string, 0, len(providers))
+	for _, provider := range providers {
+		ref := ProviderRef(provider)
+		refByProvider[provider.ID] = ref
+		providerRefs = append(providerRefs, ref)
+		cell, _ := excelize.CoordinatesToCellName(1, len(providerRefs)+1)
+		if err := f.SetSheetRow(lookupSheet, cell, &[]interface{}{ref, provider.Name}); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	productRefs := make([]lookupProduct, 0, len(products))
+	for _, product := range products {
+		providerRef, ok := refByProvider[product.CloudProviderID]
+		if !ok {
+			continue
+		}
+		ref := ProductRef(providerRef, product)
+		productRefs = append(productRefs, lookupProduct{providerRef: providerRef, ref: ref})
+		cell, _ := excelize.CoordinatesToCellName(3, len(productRefs)+1)
+		if err := f.SetSheetRow(lookupSheet, cell, &[]interface{}{ref, product.Name}); err != nil {
+			return nil, nil, err
+		}
+	}
+	return providerRefs, productRefs, nil
+}
+
+// writeTemplateSheet 写入填写数据的工作表：表头、列宽、冻结首行以及数据校验
+func writeTemplateSheet(f *excelize.File, maxRows, providerCount, productCount int) error {
+	headers := toRow(templateHeaders)
+	if err := f.SetSheetRow(templateSheet, "A1", &headers); err != nil {
+		return err
+	}
+
+	style, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true},
+		Fill: excelize.Fill{Type: "pattern", Color: []string{"#DDEBF7"}, Pattern: 1},
+	})
+	if err != nil {
+		return err
+	}
+	lastCol, _ := excelize.ColumnNumberToName(len(templateHeaders))
+	if err := f.SetCellStyle(templateSheet, "A1", lastCol+"1", style); err != nil {
+		return err
+	}
+	for j, width := range templateColWidths {
+		col, _ := excelize.ColumnNumberToName(j + 1)
+		if err := f.SetColWidth(templateSheet, col, col, width); err != nil {
+			return err
+		}
+	}
+	if err := f.SetPanes(templateSheet, &excelize.Panes{
+		Freeze:      true,
+		YSplit:      1,
+		TopLeftCell: "A2",
+		ActivePane:  "bottomLeft",
+	}); err != nil {
+		return err
+	}
+
+	// 数据校验覆盖允许导入的全部行
+	lastRow := maxRows + 1
+	if providerCount > 0 {
+		dv := excelize.NewDataValidation(false)
+		dv.Sqref = fmt.Sprintf("B2:B%d", lastRow)
+		dv.SetSqrefDropList(fmt.Sprintf("%s!$A$2:$A$%d", lookupSheet, providerCount+1))
+		dv.SetError(excelize.DataValidationErrorStyleStop, "无效的云服务商", "请从下拉列表中选择云服务商")
+		if err := f.AddDataValidation(templateSheet, dv); err != nil {
+			return err
+		}
+	}
+	if productCount > 0 {
+		dv := excelize.NewDataValidation(false)
+		dv.Sqref = fmt.Sprintf("C2:C%d", lastRow)
+		dv.SetSqrefDropList(fmt.Sprintf("%s!$C$2:$C$%d", lookupSheet, productCount+1))
+		dv.SetError(excelize.DataValidationErrorStyleStop, "无效的云产品", "请从下拉列表中选择云产品，云产品需属于所选的云服务商")
+		if err := f.AddDataValidation(templateSheet, dv); err != nil {
+			return err
+		}
+	}
+
+	// 配置项名称和推荐配置值的长度与导入时的校验一致
+	textRules := []struct {
+		col, name string
+		max       int
+	}{
+		{"D", "配置项名称", maxNameLength},
+		{"E", "推荐配置值", maxTextLength},
+	}
+	for _, rule := range textRules {
+		dv := excelize.NewDataValidation(false)
+		dv.Sqref = fmt.Sprintf("%s2:%s%d", rule.col, rule.col, lastRow)
+		if err := dv.SetRange(1, rule.max, excelize.DataValidationTypeTextLength, excelize.DataValidationOperatorBetween); err != nil {
+			return err
+		}
+		dv.SetError(excelize.DataValidationErrorStyleStop, "无效的"+rule.name, fmt.Sprintf("%s必填，最多%d个字符", rule.name, rule.max))
+		dv.SetInput(rule.name, fmt.Sprintf("必填，最多%d个字符", rule.max))
+		if err := f.AddDataValidation(templateSheet, dv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeInstructionsSheet 写入填写说明和示例，示例不在数据工作表中，避免被误导入；
+// 说明中的规则即ParseConfigItems对每行的校验
+func writeInstructionsSheet(f *excelize.File, limits UploadLimits, productRefs []lookupProduct) error {
+	rules := []string{
+		"填写说明",
+		fmt.Sprintf("1. 在“%s”工作表中从第2行开始填写，每行一个配置项，不要修改表头和工作表顺序。", templateSheet),
+		"2. 序号列仅用于标识，导入时忽略，可以留空。",
+		"3. 云服务商：从下拉列表中选择。",
+		"4. 云产品：从下拉列表中选择，格式为“云服务商代码/云产品代码”，必须属于同一行的云服务商。",
+		fmt.Sprintf("5. 配置项名称和推荐配置值必填，配置项名称最多%d个字符，推荐配置值最多%d个字符。", maxNameLength, maxTextLength),
+		fmt.Sprintf("6. 风险说明、检查方法、配置方式、参考资料选填，每项最多%d个字符。", maxTextLength),
+		"7. 标签选填，多个标签用逗号分隔。",
+		"8. 不符合以上规则的行在导入时被跳过，后台导入任务的warnings中列出跳过的行。",
+		fmt.Sprintf("9. 最多%d行数据，文件不超过%dMB，需保存为.xlsx格式。", limits.MaxRows, limits.MaxUploadSize>>20),
+		"10. 下拉列表为下载模板时已有的云服务商和云产品，新增后请重新下载模板。",
+	}
+	for j, rule := range rules {
+		if err := f.SetCellValue(instructionsSheet, fmt.Sprintf("A%d", j+1), rule); err != nil {
+			return err
+		}
+	}
+
+	bold, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return err
+	}
+	if err := f.SetCellStyle(instructionsSheet, "A1", "A1", bold); err != nil {
+		return err
+	}
+
+	// 示例使用第一个已有的云产品及其云服务商，没有时使用占位值；
+	// 云产品可能是任意类型，示例内容因此选用适用于所有云产品的配置项
+	example := lookupProduct{providerRef: "aliyun", ref: "aliyun/ecs"}
+	if len(productRefs) > 0 {
+		example = productRefs[0]
+	}
+	examples := [][]interface{}{
+		{"示例"},
+		toRow(templateHeaders),
+		{1, example.providerRef, example.ref, "记录管理操作审计日志", "已开启，日志保留不少于180天",
+			"未记录管理操作时无法追溯配置变更和安全事件", "在操作审计中确认该产品的管理操作均被记录", "开启操作审计并将日志投递到专用的存储",
+			"https://example.com/docs/audit-log", "日志,审计"},
+		// 只填写必填列
+		{2, example.providerRef, example.ref, "资源设置负责人标签", "owner标签不为空", "", "", "", "", ""},
+	}
+	start := len(rules) + 2
+	for j, row := range examples {
+		row := row
+		if err := f.SetSheetRow(instructionsSheet, fmt.Sprintf("A%d", start+j), &row); err != nil {
+			return err
+		}
+	}
+	if err := f.SetCellStyle(instructionsSheet, fmt.Sprintf("A%d", start), fmt.Sprintf("A%d", start), bold); err != nil {
+		return err
+	}
+	return f.SetColWidth(instructionsSheet, "A", "A", 16)
+}
+
+// toRow 将字符串切片转换为工作表的一行
+func toRow(values []string) []interface{} {
+	row := make([]interface{}, len(values))
+	for j, v := range values {
+		row[j] = v
+	}
+	return row
+}
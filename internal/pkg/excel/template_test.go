@@ -0,0 +1,203 @@
+package excel
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+	"github.com/yourusername/cloud-eye/internal/models"
+)
+
+func TestWriteTemplateRoundTrip(t *testing.T) {
+	aws := models.CloudProvider{BaseModel: models.BaseModel{ID: 1}, Name: "Amazon Web Services", Code: "AWS"}
+	numeric := models.CloudProvider{BaseModel: models.BaseModel{ID: 2}, Name: "代码为数字的服务商", Code: "123"}
+
+	tests := []struct {
+		name         string
+		providers    []models.CloudProvider
+		products     []models.CloudProduct
+		providerRefs []string
+		productRefs  []string
+		providerID   uint
+		providerCode string
+		productID    uint
+		productCode  string
+	}{
+		{
+			name:      "按代码引用",
+			providers: []models.CloudProvider{aws, numeric},
+			products: []models.CloudProduct{
+				{BaseModel: models.BaseModel{ID: 10}, CloudProviderID: 1, Name: "S3", Code: "S3"},
+				{BaseModel: models.BaseModel{ID: 11}, CloudProviderID: 2, Name: "没有代码的产品"},
+				{BaseModel: models.BaseModel{ID: 12}, CloudProviderID: 9, Name: "云服务商不存在", Code: "X"},
+			},
+			providerRefs: []string{"AWS", "2"},
+			productRefs:  []string{"AWS/S3", "11"},
+			providerCode: "AWS",
+			productCode:  "S3",
+		},
+		{
+			// 代码为纯数字的云服务商和没有代码的云产品使用ID
+			name:         "按ID引用",
+			providers:    []models.CloudProvider{numeric},
+			products:     []models.CloudProduct{{BaseModel: models.BaseModel{ID: 11}, CloudProviderID: 2, Name: "没有代码的产品"}},
+			providerRefs: []string{"2"},
+			productRefs:  []string{"11"},
+			providerID:   2,
+			productID:    11,
+		},
+		{
+			name:         "没有云产品时使用占位示例",
+			providerRefs: []string{},
+			productRefs:  []string{},
+			providerCode: "aliyun",
+			productCode:  "ecs",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			importer := &ConfigItemImporter{Limits: UploadLimits{MaxRows: 500}}
+			var buf bytes.Buffer
+			if err := importer.WriteTemplate(&buf, tt.providers, tt.products); err != nil {
+				t.Fatalf("生成模板失败: %v", err)
+			}
+			f, err := excelize.OpenReader(&buf)
+			if err != nil {
+				t.Fatalf("打开模板失败: %v", err)
+			}
+			defer f.Close()
+
+			// 导入读取第一个工作表，数据工作表必须排在第一个且为活动工作表
+			if sheets := f.GetSheetList(); !reflect.DeepEqual(sheets, []string{templateSheet, instructionsSheet, lookupSheet}) {
+				t.Fatalf("工作表顺序为%v", sheets)
+			}
+			if f.GetActiveSheetIndex() != 0 {
+				t.Fatalf("活动工作表为%d，期望0", f.GetActiveSheetIndex())
+			}
+			if visible, _ := f.GetSheetVisible(lookupSheet); visible {
+				t.Fatal("查找表未隐藏")
+			}
+			rows, _ := f.GetRows(templateSheet)
+			if len(rows) != 1 || !reflect.DeepEqual(rows[0], templateHeaders) {
+				t.Fatalf("数据工作表的内容为%v，期望只有表头", rows)
+			}
+
+			// 下拉列表的数据来源
+			lookup, _ := f.GetCols(lookupSheet)
+			if got := column(lookup, 0); !reflect.DeepEqual(got, tt.providerRefs) {
+				t.Fatalf("云服务商下拉列表为%v，期望%v", got, tt.providerRefs)
+			}
+			if got := column(lookup, 2); !reflect.DeepEqual(got, tt.productRefs) {
+				t.Fatalf("云产品下拉列表为%v，期望%v", got, tt.productRefs)
+			}
+
+			// 将填写说明中的示例行复制到数据工作表后导入
+			examples := exampleRows(t, f)
+			for j, row := range examples {
+				values := toRow(row)
+				cell, _ := excelize.CoordinatesToCellName(1, j+2)
+				if err := f.SetSheetRow(templateSheet, cell, &values); err != nil {
+					t.Fatalf("写入示例失败: %v", err)
+				}
+			}
+			path := filepath.Join(t.TempDir(), "filled.xlsx")
+			if err := f.SaveAs(path); err != nil {
+				t.Fatalf("保存文件失败: %v", err)
+			}
+
+			items, warnings, err := importer.ParseConfigItems(context.Background(), path)
+			if err != nil || len(warnings) != 0 {
+				t.Fatalf("导入示例返回%v，警告%v", err, warnings)
+			}
+			if len(items) != len(examples) {
+				t.Fatalf("导入了%d个配置项，期望%d个", len(items), len(examples))
+			}
+			for j, item := range items {
+				if item.CloudProviderID != tt.providerID || item.Provider.Code != tt.providerCode ||
+					item.ProductID != tt.productID || item.Product.Code != tt.productCode {
+					t.Fatalf("第%d个示例的云服务商和云产品为%d/%q、%d/%q", j+1,
+						item.CloudProviderID, item.Provider.Code, item.ProductID, item.Product.Code)
+				}
+				if item.Name != examples[j][3] || item.RecommendedValue != examples[j][4] {
+					t.Fatalf("第%d个示例导入为%+v", j+1, item)
+				}
+			}
+			if len(items[0].Tags) != 2 || items[0].Tags[0].Name != "日志" || len(items[1].Tags) != 0 {
+				t.Fatalf("示例的标签为%v、%v", items[0].Tags, items[1].Tags)
+			}
+		})
+	}
+}
+
+func TestWriteTemplateInstructionsMatchLimits(t *testing.T) {
+	importer := &ConfigItemImporter{Limits: UploadLimits{MaxRows: 500, MaxUploadSize: 5 << 20}}
+	var buf bytes.Buffer
+	if err := importer.WriteTemplate(&buf, nil, nil); err != nil {
+		t.Fatalf("生成模板失败: %v", err)
+	}
+	f, err := excelize.OpenReader(&buf)
+	if err != nil {
+		t.Fatalf("打开模板失败: %v", err)
+	}
+	defer f.Close()
+
+	rows, _ := f.GetRows(instructionsSheet)
+	var text []string
+	for _, row := range rows {
+		text = append(text, strings.Join(row, " "))
+	}
+	all := strings.Join(text, "\n")
+	for _, want := range []string{"最多500行数据，文件不超过5MB", "配置项名称最多200个字符", "每项最多16000个字符"} {
+		if !strings.Contains(all, want) {
+			t.Errorf("填写说明中缺少%q", want)
+		}
+	}
+
+	// 名称和推荐配置值的数据校验覆盖允许导入的全部行
+	validations, err := f.GetDataValidations(templateSheet)
+	if err != nil {
+		t.Fatalf("读取数据校验失败: %v", err)
+	}
+	var sqrefs []string
+	for _, dv := range validations {
+		sqrefs = append(sqrefs, dv.Sqref)
+	}
+	if !reflect.DeepEqual(sqrefs, []string{"D2:D501", "E2:E501"}) {
+		t.Fatalf("数据校验范围为%v", sqrefs)
+	}
+}
+
+// column 返回查找表中一列除表头外的值
+func column(cols [][]string, j int) []string {
+	values := []string{}
+	if j < len(cols) && len(cols[j]) > 1 {
+		values = append(values, cols[j][1:]...)
+	}
+	return values
+}
+
+// exampleRows 返回填写说明中“示例”标题和表头之后的示例行，并检查示例表头与数据工作表一致
+func exampleRows(t *testing.T, f *excelize.File) [][]string {
+	t.Helper()
+	rows, err := f.GetRows(instructionsSheet)
+	if err != nil {
+		t.Fatalf("读取填写说明失败: %v", err)
+	}
+	for j, row := range rows {
+		if len(row) == 1 && row[0] == "示例" {
+			if j+1 >= len(rows) || !reflect.DeepEqual(rows[j+1], templateHeaders) {
+				t.Fatalf("示例的表头与数据工作表不一致")
+			}
+			if len(rows) <= j+2 {
+				t.Fatal("填写说明中没有示例行")
+			}
+			return rows[j+2:]
+		}
+	}
+	t.Fatal("填写说明中没有示例")
+	return nil
+}
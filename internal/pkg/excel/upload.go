@@ -0,0 +1,199 @@
+package excel
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// 上传文件的默认限制
+const (
+	DefaultMaxUploadSize       = 10 << 20  // 上传文件最大10MB
+	DefaultMaxUncompressedSize = 100 << 20 // 解压后最大100MB
+	DefaultMaxRows             = 10000     // 最多10000行数据
+	DefaultMaxSheets           = 10        // 最多10个工作表
+)
+
+// maxCompressionRatio 单个压缩条目的最大压缩比，正常的xlsx通常在20倍以内，过高的压缩比视为压缩炸弹
+const maxCompressionRatio = 100
+
+// minRatioCheckSize 解压后小于该大小的条目不检查压缩比，空白内容的小文件压缩比可能很高
+const minRatioCheckSize = 1 << 20
+
+// zipMagic zip文件头
+var zipMagic = []byte("PK\x03\x04")
+
+var (
+	ErrFileTooLarge  = errors.New("文件超过大小限制")
+	ErrNotXLSX       = errors.New("文件不是有效的.xlsx格式")
+	ErrTooManySheets = errors.New("工作表数量超过限制")
+	ErrTooManyRows   = errors.New("数据行数超过限制")
+)
+
+// UploadLimits 上传的Excel文件的限制，零值使用默认值
+type UploadLimits struct {
+	MaxUploadSize       int64 // 上传文件的最大字节数
+	MaxUncompressedSize int64 // 解压后的最大字节数
+	MaxRows             int   // 第一个工作表除表头外的最大行数
+	MaxSheets           int   // 最大工作表数
+}
+
+// withDefaults 填充未设置的限制
+func (l UploadLimits) withDefaults() UploadLimits {
+	if l.MaxUploadSize <= 0 {
+		l.MaxUploadSize = DefaultMaxUploadSize
+	}
+	if l.MaxUncompressedSize <= 0 {
+		l.MaxUncompressedSize = DefaultMaxUncompressedSize
+	}
+	if l.MaxRows <= 0 {
+		l.MaxRows = DefaultMaxRows
+	}
+	if l.MaxSheets <= 0 {
+		l.MaxSheets = DefaultMaxSheets
+	}
+	return l
+}
+
+// uploadFileName 生成随机的上传文件名，不使用客户端提供的文件名，避免路径穿越和文件名冲突
+func uploadFileName() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "upload_" + hex.EncodeToString(b) + ".xlsx", nil
+}
+
+// ValidateUpload 在解析前校验上传的文件：必须是包含工作簿的zip结构，解压后大小、压缩比和工作表数量不超过限制
+func (i *ConfigItemImporter) ValidateUpload(filePath string) error {
+	limits := i.Limits.withDefaults()
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if info.Size() > limits.MaxUploadSize {
+		return ErrFileTooLarge
+	}
+
+	// 按文件内容而不是扩展名判断类型
+	magic := make([]byte, len(zipMagic))
+	if _, err := io.ReadFull(f, magic); err != nil || !bytes.Equal(magic, zipMagic) {
+		return ErrNotXLSX
+	}
+
+	r, err := zip.NewReader(f, info.Size())
+	if err != nil {
+		return ErrNotXLSX
+	}
+
+	var (
+		total       uint64
+		sheets      int
+		hasTypes    bool
+		hasWorkbook bool
+	)
+	for _, entry := range r.File {
+		switch entry.Name {
+		case "[Content_Types].xml":
+			hasTypes = true
+		case "xl/workbook.xml":
+			hasWorkbook = true
+		}
+		if path.Dir(entry.Name) == "xl/worksheets" && strings.HasSuffix(entry.Name, ".xml") {
+			sheets++
+		}
+
+		total += entry.UncompressedSize64
+		if total > uint64(limits.MaxUncompressedSize) {
+			return fmt.Errorf("%w：解压后超过%dMB", ErrFileTooLarge, limits.MaxUncompressedSize>>20)
+		}
+		if entry.UncompressedSize64 >= minRatioCheckSize &&
+			entry.UncompressedSize64 > entry.CompressedSize64*maxCompressionRatio {
+			logger.Warn("Rejected upload with suspicious compression ratio",
+				zap.String("filepath", filePath), zap.String("entry", entry.Name))
+			return fmt.Errorf("%w：压缩比异常", ErrNotXLSX)
+		}
+	}
+	if !hasTypes || !hasWorkbook {
+		return ErrNotXLSX
+	}
+	if sheets > limits.MaxSheets {
+		return fmt.Errorf("%w：最多%d个工作表", ErrTooManySheets, limits.MaxSheets)
+	}
+	return nil
+}
+
+// Discard 处理未通过校验的上传文件：配置了隔离目录时移入隔离目录供排查，否则删除
+func (i *ConfigItemImporter) Discard(filePath string) {
+	if i.QuarantinePath != "" {
+		err := os.MkdirAll(i.QuarantinePath, 0700)
+		if err == nil {
+			target := filepath.Join(i.QuarantinePath, filepath.Base(filePath))
+			if err = os.Rename(filePath, target); err == nil {
+				logger.Info("Upload quarantined", zap.String("filepath", target))
+				return
+			}
+		}
+		logger.Error("Failed to quarantine upload", err, zap.String("filepath", filePath))
+	}
+	if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+		logger.Error("Failed to remove upload", err, zap.String("filepath", filePath))
+	}
+}
+
+// readRows 逐行读取工作表并忽略末尾的空行，除表头外超过maxRows行时返回ErrTooManyRows
+func readRows(f *excelize.File, sheet string, maxRows int) ([][]string, error) {
+	iter, err := f.Rows(sheet)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	var rows [][]string
+	blank := 0 // 尚未确定是否位于末尾的空行数
+	for iter.Next() {
+		row, err := iter.Columns()
+		if err != nil {
+			return nil, err
+		}
+		if len(row) == 0 {
+			blank++
+			continue
+		}
+		if len(rows)+blank+1 > maxRows+1 {
+			return nil, fmt.Errorf("%w：最多%d行", ErrTooManyRows, maxRows)
+		}
+		for ; blank > 0; blank-- {
+			rows = append(rows, nil)
+		}
+		rows = append(rows, row)
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// MaxUploadSize 返回上传文件的最大字节数
+func (i *ConfigItemImporter) MaxUploadSize() int64 {
+	return i.Limits.withDefaults().MaxUploadSize
+}
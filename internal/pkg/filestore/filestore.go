@@ -0,0 +1,119 @@
+// Package filestore 导出、导入等文件的存储后端和下载链接签名，文件元数据的持久化由服务层负责
+package filestore
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 存储后端类型
+const (
+	DriverLocal = "local" // 本地文件系统
+	DriverS3    = "s3"    // S3兼容的对象存储，例如MinIO
+)
+
+// DefaultLocalPath 本地存储的默认根目录
+const DefaultLocalPath = "./uploads/files"
+
+// ErrNotFound 文件不存在
+var ErrNotFound = errors.New("文件不存在")
+
+// ErrInvalidKey 无效的存储键
+var ErrInvalidKey = errors.New("无效的存储键")
+
+// Store 文件存储后端，key为以/分隔的相对路径
+type Store interface {
+	// Put 保存文件，size为内容长度，未知时为-1
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Open 打开文件，不存在时返回ErrNotFound
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete 删除文件，文件不存在时不返回错误
+	Delete(ctx context.Context, key string) error
+}
+
+// Options 存储后端选项
+type Options struct {
+	Driver    string // local或s3，为空时为local
+	LocalPath string // 本地存储根目录，默认DefaultLocalPath
+	S3        S3Options
+}
+
+// New 根据选项创建存储后端
+func New(opts Options) (Store, error) {
+	switch opts.Driver {
+	case "", DriverLocal:
+		if opts.LocalPath == "" {
+			opts.LocalPath = DefaultLocalPath
+		}
+		return NewLocalStore(opts.LocalPath), nil
+	case DriverS3:
+		return NewS3Store(opts.S3)
+	default:
+		return nil, fmt.Errorf("不支持的文件存储类型: %s", opts.Driver)
+	}
+}
+
+// NewKey 生成存储键：类型/日期/随机串，保留原文件的扩展名
+func NewKey(kind, name string, now time.Time) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	ext := strings.ToLower(path.Ext(name))
+	if !validExt(ext) {
+		ext = ""
+	}
+	return path.Join(kind, now.Format("2006/01/02"), hex.EncodeToString(b)+ext), nil
+}
+
+// validExt 扩展名只允许字母和数字，以免存储键包含特殊字符
+func validExt(ext string) bool {
+	if len(ext) < 2 || len(ext) > 10 {
+		return false
+	}
+	for _, r := range ext[1:] {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// cleanKey 校验存储键，拒绝绝对路径和上级目录
+func cleanKey(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return "", ErrInvalidKey
+	}
+	cleaned := path.Clean(key)
+	if cleaned != key || cleaned == "." || strings.HasPrefix(cleaned, "../") || cleaned == ".." {
+		return "", ErrInvalidKey
+	}
+	return cleaned, nil
+}
+
+// Sign 计算下载链接签名，签名内容为"文件ID.过期时间"
+func Sign(secret string, id uint, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatUint(uint64(id), 10)))
+	mac.Write([]byte("."))
+	mac.Write([]byte(strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify 校验下载链接签名和过期时间
+func Verify(secret string, id uint, expires int64, signature string, now time.Time) bool {
+	if now.Unix() > expires {
+		return false
+	}
+	return hmac.Equal([]byte(Sign(secret, id, expires)), []byte(signature))
+}
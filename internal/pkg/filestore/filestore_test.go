@@ -0,0 +1,174 @@
+package filestore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewKey(t *testing.T) {
+	now := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name string
+		file string
+		ext  string
+	}{
+		{"保留扩展名", "配置项.xlsx", ".xlsx"},
+		{"扩展名转小写", "REPORT.JSON", ".json"},
+		{"无扩展名", "README", ""},
+		{"扩展名含特殊字符", "a.x/y", ""},
+		{"扩展名过长", "a.abcdefghijk", ""},
+		{"路径中的上级目录", "../../etc/passwd", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := NewKey("exports", tt.file, now)
+			if err != nil {
+				t.Fatalf("生成存储键失败: %v", err)
+			}
+			pattern := regexp.MustCompile(`^exports/2024/01/02/[0-9a-f]{32}` + regexp.QuoteMeta(tt.ext) + `$`)
+			if !pattern.MatchString(key) {
+				t.Fatalf("存储键为%s", key)
+			}
+			if _, err := cleanKey(key); err != nil {
+				t.Fatalf("生成的存储键%s未通过校验: %v", key, err)
+			}
+		})
+	}
+}
+
+func TestLinkSignVerify(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	expires := now.Add(time.Hour).Unix()
+	signature := Sign("link-secret", 42, expires)
+
+	tests := []struct {
+		name      string
+		secret    string
+		id        uint
+		expires   int64
+		signature string
+		now       time.Time
+		want      bool
+	}{
+		{"有效链接", "link-secret", 42, expires, signature, now, true},
+		{"到期时刻仍有效", "link-secret", 42, expires, signature, time.Unix(expires, 0), true},
+		{"已过期", "link-secret", 42, expires, signature, time.Unix(expires+1, 0), false},
+		{"延长过期时间", "link-secret", 42, expires + 3600, signature, now, false},
+		{"其他文件", "link-secret", 43, expires, signature, now, false},
+		{"密钥不同", "other-secret", 42, expires, signature, now, false},
+		{"签名为空", "link-secret", 42, expires, "", now, false},
+		{"签名大写", "link-secret", 42, expires, strings.ToUpper(signature), now, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Verify(tt.secret, tt.id, tt.expires, tt.signature, tt.now); got != tt.want {
+				t.Fatalf("Verify返回%v，期望%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalStoreRejectsPathTraversal(t *testing.T) {
+	parent := t.TempDir()
+	root := filepath.Join(parent, "files")
+	store := NewLocalStore(root)
+	ctx := context.Background()
+
+	keys := []string{
+		"",
+		".",
+		"..",
+		"../outside.txt",
+		"a/../../outside.txt",
+		"a/../b.txt",
+		"/etc/passwd",
+		"a//b.txt",
+		"./a.txt",
+		"a/",
+		`a\..\..\outside.txt`,
+	}
+	for _, key := range keys {
+		if err := store.Put(ctx, key, strings.NewReader("x"), 1, ""); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Put(%q)返回%v，期望ErrInvalidKey", key, err)
+		}
+		if _, err := store.Open(ctx, key); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Open(%q)返回%v，期望ErrInvalidKey", key, err)
+		}
+		if err := store.Delete(ctx, key); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Delete(%q)返回%v，期望ErrInvalidKey", key, err)
+		}
+	}
+
+	entries, _ := os.ReadDir(parent)
+	for _, e := range entries {
+		if e.Name() != "files" {
+			t.Fatalf("根目录外出现了文件%s", e.Name())
+		}
+	}
+}
+
+func TestLocalStorePutOpenDelete(t *testing.T) {
+	root := t.TempDir()
+	store := NewLocalStore(root)
+	ctx := context.Background()
+	key := "exports/2024/01/02/报告.xlsx"
+
+	if _, err := store.Open(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("打开不存在的文件返回%v，期望ErrNotFound", err)
+	}
+	if err := store.Put(ctx, key, strings.NewReader("旧内容"), -1, ""); err != nil {
+		t.Fatalf("保存失败: %v", err)
+	}
+	if err := store.Put(ctx, key, strings.NewReader("新内容"), -1, ""); err != nil {
+		t.Fatalf("覆盖失败: %v", err)
+	}
+
+	rc, err := store.Open(ctx, key)
+	if err != nil {
+		t.Fatalf("打开失败: %v", err)
+	}
+	data, _ := io.ReadAll(rc)
+	rc.Close()
+	if string(data) != "新内容" {
+		t.Fatalf("文件内容为%q", data)
+	}
+
+	// 写入使用的临时文件不应留在目录中
+	entries, _ := os.ReadDir(filepath.Join(root, "exports", "2024", "01", "02"))
+	if len(entries) != 1 {
+		t.Fatalf("目录中有%d个文件，期望1个", len(entries))
+	}
+
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("删除失败: %v", err)
+	}
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("删除不存在的文件返回%v", err)
+	}
+}
+
+func TestNewStore(t *testing.T) {
+	if store, err := New(Options{}); err != nil || store.(*LocalStore).Root != DefaultLocalPath {
+		t.Fatalf("默认存储为%+v, %v", store, err)
+	}
+	if _, err := New(Options{Driver: "ftp"}); err == nil {
+		t.Fatal("不支持的存储类型未返回错误")
+	}
+	for _, opts := range []S3Options{
+		{Bucket: "b", AccessKeyID: "id", SecretAccessKey: "secret"},
+		{Endpoint: "http://localhost:9000", AccessKeyID: "id", SecretAccessKey: "secret"},
+		{Endpoint: "http://localhost:9000", Bucket: "b"},
+		{Endpoint: "ftp://localhost", Bucket: "b", AccessKeyID: "id", SecretAccessKey: "secret"},
+	} {
+		if _, err := New(Options{Driver: DriverS3, S3: opts}); err == nil {
+			t.Errorf("无效的对象存储选项%+v未返回错误", opts)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package filestore
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// unsignedPayload 不对请求体计算摘要，以便流式上传
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+// maxErrorBody 错误信息中保留的响应体长度
+const maxErrorBody = 512
+
+// S3Options S3兼容对象存储选项
+type S3Options struct {
+	Endpoint        string        // 服务地址，例如https://s3.amazonaws.com或http://localhost:9000
+	Region          string        // 区域，默认us-east-1
+	Bucket          string        // 存储桶，需预先创建
+	AccessKeyID     string        // 访问密钥ID
+	SecretAccessKey string        // 访问密钥
+	PathStyle       bool          // 使用路径形式的地址（MinIO等需要），否则使用虚拟主机形式
+	Timeout         time.Duration // 单次请求超时时间，默认60s
+}
+
+// S3Store S3兼容的对象存储，使用签名V4认证
+type S3Store struct {
+	opts     S3Options
+	endpoint *url.URL
+	client   *http.Client
+}
+
+// NewS3Store 创建S3兼容的对象存储
+func NewS3Store(opts S3Options) (*S3Store, error) {
+	if opts.Endpoint == "" || opts.Bucket == "" {
+		return nil, errors.New("未配置对象存储的地址或存储桶")
+	}
+	if opts.AccessKeyID == "" || opts.SecretAccessKey == "" {
+		return nil, errors.New("未配置对象存储的访问密钥")
+	}
+	endpoint, err := url.Parse(opts.Endpoint)
+	if err != nil || endpoint.Host == "" || (endpoint.Scheme != "http" && endpoint.Scheme != "https") {
+		return nil, fmt.Errorf("无效的对象存储地址: %s", opts.Endpoint)
+	}
+	if opts.Region == "" {
+		opts.Region = "us-east-1"
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 60 * time.Second
+	}
+	return &S3Store{
+		opts:     opts,
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: opts.Timeout},
+	}, nil
+}
+
+// Put 上传对象
+func (s *S3Store) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	if size < 0 {
+		// 签名V4的流式上传需要内容长度，长度未知时读入内存
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		r, size = bytes.NewReader(data), int64(len(data))
+	}
+	resp, err := s.do(ctx, http.MethodPut, key, r, size, contentType)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return checkResponse(resp)
+}
+
+// Open 下载对象
+func (s *S3Store) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := s.do(ctx, http.MethodGet, key, nil, 0, "")
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, ErrNotFound
+	}
+	// 成功时由调用方读取内容，不能经过checkResponse，否则内容会被丢弃
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		err := checkResponse(resp)
+		resp.Body.Close()
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// Delete 删除对象，S3删除不存在的对象时同样返回成功
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, key, nil, 0, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	return checkResponse(resp)
+}
+
+// checkResponse 将非2xx响应转换为错误
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	return fmt.Errorf("对象存储返回%d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+}
+
+// objectURL 返回对象地址
+func (s *S3Store) objectURL(key string) *url.URL {
+	u := *s.endpoint
+	escaped := escapePath(key)
+	base := strings.TrimSuffix(u.Path, "/")
+	if s.opts.PathStyle {
+		u.Path = base + "/" + s.opts.Bucket + "/" + key
+		u.RawPath = base + "/" + escapePath(s.opts.Bucket) + "/" + escaped
+	} else {
+		u.Host = s.opts.Bucket + "." + u.Host
+		u.Path = base + "/" + key
+		u.RawPath = base + "/" + escaped
+	}
+	return &u
+}
+
+// do 发送签名后的请求
+func (s *S3Store) do(ctx context.Context, method, key string, body io.Reader, size int64, contentType string) (*http.Response, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	u := s.objectURL(key)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.ContentLength = size
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	s.sign(req, time.Now().UTC())
+
+	return s.client.Do(req)
+}
+
+// sign 按签名V4计算Authorization请求头
+func (s *S3Store) sign(req *http.Request, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+
+	headers := map[string]string{
+		"host":                 req.URL.Host,
+		"x-amz-content-sha256": unsignedPayload,
+		"x-amz-date":           amzDate,
+	}
+	if ct := req.Header.Get("Content-Type"); ct != "" {
+		headers["content-type"] = ct
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		unsignedPayload,
+	}, "\n")
+
+	scope := date + "/" + s.opts.Region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex(canonicalRequest),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.opts.SecretAccessKey), date)
+	key = hmacSHA256(key, s.opts.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.opts.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func sha256Hex(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
+
+// escapePath 按S3规范编码路径，除/和非保留字符外全部编码
+func escapePath(p string) string {
+	var b strings.Builder
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		if c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || c >= '0' && c <= '9' ||
+			c == '-' || c == '_' || c == '.' || c == '~' || c == '/' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
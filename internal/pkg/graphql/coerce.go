@@ -0,0 +1,279 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// coerce 按类型转换输入值（变量值、参数字面量或默认值），path用于错误说明
+func (s *Schema) coerce(v interface{}, t *TypeRef, path string) (interface{}, error) {
+	if v == nil {
+		if t.NonNull {
+			return nil, fmt.Errorf("%s 不能为null", path)
+		}
+		return nil, nil
+	}
+
+	if t.Elem != nil {
+		list, ok := v.([]interface{})
+		if !ok {
+			// 按规范，单个值可以作为只有一个元素的列表
+			list = []interface{}{v}
+		}
+		result := make([]interface{}, len(list))
+		for i, elem := range list {
+			coerced, err := s.coerce(elem, t.Elem, fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return nil, err
+			}
+			result[i] = coerced
+		}
+		return result, nil
+	}
+
+	if in, ok := s.types[t.Name].(*InputObject); ok {
+		return s.coerceInput(v, in, path)
+	}
+	return coerceScalar(v, t.Name, path)
+}
+
+// coerceInput 转换输入对象，不允许未定义的字段
+func (s *Schema) coerceInput(v interface{}, in *InputObject, path string) (interface{}, error) {
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s 必须是%s类型的对象", path, in.Name)
+	}
+
+	result := make(map[string]interface{}, len(obj))
+	for _, f := range in.Fields {
+		fieldPath := path + "." + f.Name
+		value, present := obj[f.Name]
+		if !present {
+			if f.Default != nil {
+				result[f.Name] = f.Default
+			} else if f.typ.NonNull {
+				return nil, fmt.Errorf("缺少必填字段 %s", fieldPath)
+			}
+			continue
+		}
+		coerced, err := s.coerce(value, f.typ, fieldPath)
+		if err != nil {
+			return nil, err
+		}
+		result[f.Name] = coerced
+	}
+
+	for name := range obj {
+		if _, ok := result[name]; !ok && !hasArg(in.Fields, name) {
+			return nil, fmt.Errorf("%s 中的字段 %s 未在%s中定义", path, name, in.Name)
+		}
+	}
+	return result, nil
+}
+
+// coerceScalar 转换标量值
+func coerceScalar(v interface{}, name, path string) (interface{}, error) {
+	switch name {
+	case "Int":
+		if f, ok := toFloat(v); ok && f == math.Trunc(f) && f >= math.MinInt32 && f <= math.MaxInt32 {
+			return int(f), nil
+		}
+	case "Float":
+		if f, ok := toFloat(v); ok {
+			return f, nil
+		}
+	case "String":
+		if s, ok := v.(string); ok {
+			return s, nil
+		}
+	case "Boolean":
+		if b, ok := v.(bool); ok {
+			return b, nil
+		}
+	case "ID":
+		if s, ok := v.(string); ok {
+			return s, nil
+		}
+		if f, ok := toFloat(v); ok && f == math.Trunc(f) {
+			return strconv.FormatFloat(f, 'f', -1, 64), nil
+		}
+	case "Time":
+		switch t := v.(type) {
+		case time.Time:
+			return t, nil
+		case string:
+			if parsed, err := time.Parse(time.RFC3339, t); err == nil {
+				return parsed, nil
+			}
+			return nil, fmt.Errorf("%s 必须是RFC 3339格式的时间", path)
+		}
+	case "JSON":
+		return plainJSON(v), nil
+	}
+	return nil, fmt.Errorf("%s 的值 %s 不是有效的%s", path, formatLiteral(v), name)
+}
+
+// toFloat 读取数值，兼容字面量的int64、变量的json.Number和已转换的int
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// plainJSON 将字面量中的枚举值和json.Number转换为普通JSON值
+func plainJSON(v interface{}) interface{} {
+	switch x := v.(type) {
+	case EnumValue:
+		return string(x)
+	case json.Number:
+		if n, err := x.Int64(); err == nil {
+			return n
+		}
+		f, _ := x.Float64()
+		return f
+	case []interface{}:
+		result := make([]interface{}, len(x))
+		for i, elem := range x {
+			result[i] = plainJSON(elem)
+		}
+		return result
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(x))
+		for k, elem := range x {
+			result[k] = plainJSON(elem)
+		}
+		return result
+	}
+	return v
+}
+
+// valueFromAST 将参数字面量中的变量替换为变量值，第二个返回值为false表示引用的变量未提供
+func valueFromAST(v interface{}, vars map[string]interface{}) (interface{}, bool) {
+	switch x := v.(type) {
+	case Variable:
+		value, ok := vars[string(x)]
+		return value, ok
+	case []interface{}:
+		result := make([]interface{}, len(x))
+		for i, elem := range x {
+			result[i], _ = valueFromAST(elem, vars)
+		}
+		return result, true
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(x))
+		for k, elem := range x {
+			if value, ok := valueFromAST(elem, vars); ok {
+				result[k] = value
+			}
+		}
+		return result, true
+	}
+	return v, true
+}
+
+// coerceArgs 转换字段或指令的参数，未提供的参数使用默认值
+func (s *Schema) coerceArgs(defs []*ArgDef, args []*Argument, vars map[string]interface{}) (map[string]interface{}, error) {
+	result := make(map[string]interface{}, len(defs))
+	for _, def := range defs {
+		var value interface{}
+		present := false
+		for _, arg := range args {
+			if arg.Name == def.Name {
+				value, present = valueFromAST(arg.Value, vars)
+			}
+		}
+		if !present {
+			if def.Default != nil {
+				result[def.Name] = def.Default
+			} else if def.typ.NonNull {
+				return nil, fmt.Errorf("缺少必填参数 %s", def.Name)
+			}
+			continue
+		}
+
+		coerced, err := s.coerce(value, def.typ, "参数"+def.Name)
+		if err != nil {
+			return nil, err
+		}
+		result[def.Name] = coerced
+	}
+	return result, nil
+}
+
+// coerceVariables 按操作的变量定义转换请求中的变量值
+func (s *Schema) coerceVariables(op *Operation, values map[string]interface{}) (map[string]interface{}, error) {
+	vars := make(map[string]interface{}, len(op.Variables))
+	for _, def := range op.Variables {
+		value, present := values[def.Name]
+		if !present && def.HasDefault {
+			value, present = def.Default, true
+		}
+		if !present {
+			if def.Type.NonNull {
+				return nil, fmt.Errorf("缺少必填变量 $%s", def.Name)
+			}
+			continue
+		}
+		coerced, err := s.coerce(value, def.Type, "变量$"+def.Name)
+		if err != nil {
+			return nil, err
+		}
+		vars[def.Name] = coerced
+	}
+	return vars, nil
+}
+
+func hasArg(defs []*ArgDef, name string) bool {
+	for _, def := range defs {
+		if def.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// formatLiteral 以GraphQL字面量格式输出值，用于SDL和错误说明
+func formatLiteral(v interface{}) string {
+	switch x := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return strconv.Quote(x)
+	case EnumValue:
+		return string(x)
+	case Variable:
+		return "$" + string(x)
+	case []interface{}:
+		parts := make([]string, len(x))
+		for i, elem := range x {
+			parts[i] = formatLiteral(elem)
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	case map[string]interface{}:
+		keys := make([]string, 0, len(x))
+		for k := range x {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		parts := make([]string, len(keys))
+		for i, k := range keys {
+			parts[i] = k + ": " + formatLiteral(x[k])
+		}
+		return "{" + strings.Join(parts, ", ") + "}"
+	}
+	return fmt.Sprint(v)
+}
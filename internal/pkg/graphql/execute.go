@@ -0,0 +1,326 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+)
+
+// Request GraphQL请求
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// Response GraphQL响应，执行前出错时没有data
+type Response struct {
+	Data   interface{} `json:"data,omitempty"`
+	Errors []*Error    `json:"errors,omitempty"`
+}
+
+// Execute 解析、校验并执行请求
+func (s *Schema) Execute(ctx context.Context, req Request) *Response {
+	doc, err := Parse(req.Query)
+	if err != nil {
+		return errorResponse(err)
+	}
+	op, err := doc.Operation(req.OperationName)
+	if err != nil {
+		return errorResponse(err)
+	}
+	return s.ExecuteOperation(ctx, doc, op, req.Variables)
+}
+
+// ExecuteOperation 校验并执行已解析的操作，变更操作的顶层字段按顺序执行
+func (s *Schema) ExecuteOperation(ctx context.Context, doc *Document, op *Operation, variables map[string]interface{}) *Response {
+	if errs := s.Validate(doc, op); len(errs) > 0 {
+		return &Response{Errors: errs}
+	}
+	vars, err := s.coerceVariables(op, variables)
+	if err != nil {
+		return errorResponse(NewError(CodeBadUserInput, "%s", err.Error()))
+	}
+
+	root, _ := s.rootType(op)
+	e := &executor{schema: s, doc: doc, vars: vars}
+	groups := e.collectFields(root, op.SelectionSet, nil, nil, nil)
+	if e.countNodes(root, groups, 1, s.maxNodes) > s.maxNodes {
+		return errorResponse(NewError(CodeValidationFailed,
+			"查询最多可能返回的对象数超过%d，请减小first或pageSize，或减少嵌套的列表字段", s.maxNodes))
+	}
+	data := e.executeSet(ctx, root, []interface{}{nil}, groups, nil)
+	return &Response{Data: data[0], Errors: e.errors}
+}
+
+func errorResponse(err error) *Response {
+	gqlErr, ok := err.(*Error)
+	if !ok {
+		gqlErr = NewError(CodeInternal, "%s", err.Error())
+	}
+	return &Response{Errors: []*Error{gqlErr}}
+}
+
+// executor 一次操作的执行状态
+type executor struct {
+	schema *Schema
+	doc    *Document
+	vars   map[string]interface{}
+	errors []*Error
+}
+
+// fieldGroup 响应中同一个键对应的字段，多个相同字段的子选择集合并执行
+type fieldGroup struct {
+	key    string
+	fields []*Field
+}
+
+// collectFields 展开片段并按响应键分组，跳过@skip和@include排除的选择
+func (e *executor) collectFields(obj *Object, set []Selection, groups []*fieldGroup, index map[string]*fieldGroup, visited map[string]bool) []*fieldGroup {
+	if index == nil {
+		index = make(map[string]*fieldGroup)
+		visited = make(map[string]bool)
+	}
+
+	for _, sel := range set {
+		switch sel := sel.(type) {
+		case *Field:
+			if !e.included(sel.Directives) {
+				continue
+			}
+			key := sel.ResponseKey()
+			if g, ok := index[key]; ok {
+				g.fields = append(g.fields, sel)
+				continue
+			}
+			g := &fieldGroup{key: key, fields: []*Field{sel}}
+			index[key] = g
+			groups = append(groups, g)
+		case *FragmentSpread:
+			if visited[sel.Name] || !e.included(sel.Directives) {
+				continue
+			}
+			visited[sel.Name] = true
+			frag := e.doc.Fragments[sel.Name]
+			groups = e.collectFields(obj, frag.SelectionSet, groups, index, visited)
+		case *InlineFragment:
+			if !e.included(sel.Directives) {
+				continue
+			}
+			groups = e.collectFields(obj, sel.SelectionSet, groups, index, visited)
+		}
+	}
+	return groups
+}
+
+// included 计算@skip和@include指令
+func (e *executor) included(directives []*Directive) bool {
+	for _, d := range directives {
+		args, err := e.schema.coerceArgs(directiveArgs, d.Arguments, e.vars)
+		if err != nil {
+			continue
+		}
+		value, _ := args["if"].(bool)
+		if (d.Name == "skip" && value) || (d.Name == "include" && !value) {
+			return false
+		}
+	}
+	return true
+}
+
+// executeSet 对同一层的所有父对象执行选择集，每个字段只调用一次解析函数
+func (e *executor) executeSet(ctx context.Context, obj *Object, sources []interface{}, groups []*fieldGroup, path []interface{}) []*orderedObject {
+	results := make([]*orderedObject, len(sources))
+	for i := range results {
+		results[i] = &orderedObject{}
+	}
+
+	for _, g := range groups {
+		field := g.fields[0]
+		fieldPath := append(append([]interface{}{}, path...), g.key)
+		if field.Name == "__typename" {
+			for _, r := range results {
+				r.set(g.key, obj.Name)
+			}
+			continue
+		}
+
+		def := obj.fields[field.Name]
+		values, err := e.resolve(ctx, def, field, sources)
+		if err != nil {
+			e.addError(err, field, fieldPath)
+			for _, r := range results {
+				r.set(g.key, nil)
+			}
+			continue
+		}
+
+		if def.object == nil {
+			for i, r := range results {
+				r.set(g.key, values[i])
+			}
+			continue
+		}
+
+		var subSet []Selection
+		for _, f := range g.fields {
+			subSet = append(subSet, f.SelectionSet...)
+		}
+		subGroups := e.collectFields(def.object, subSet, nil, nil, nil)
+		for i, v := range e.executeChildren(ctx, def, values, subGroups, fieldPath) {
+			results[i].set(g.key, v)
+		}
+	}
+	return results
+}
+
+// resolve 转换参数并调用解析函数
+func (e *executor) resolve(ctx context.Context, def *FieldDef, field *Field, sources []interface{}) ([]interface{}, error) {
+	args, err := e.schema.coerceArgs(def.Args, field.Arguments, e.vars)
+	if err != nil {
+		return nil, NewError(CodeBadUserInput, "%s", err.Error())
+	}
+	values, err := def.Resolve(ctx, ResolveParams{Sources: sources, Args: args})
+	if err != nil {
+		return nil, err
+	}
+	if len(values) != len(sources) {
+		return nil, NewError(CodeInternal, "字段 %s 的解析结果数量与父对象不一致", def.Name)
+	}
+	return values, nil
+}
+
+// executeChildren 将所有父对象的字段值展开后一起执行子选择集，再按父对象组装结果
+func (e *executor) executeChildren(ctx context.Context, def *FieldDef, values []interface{}, groups []*fieldGroup, path []interface{}) []interface{} {
+	type span struct {
+		list       bool
+		start, end int   // 列表字段在children中的范围
+		elems      []int // 列表元素对应的children下标，null元素为-1
+	}
+
+	var children []interface{}
+	spans := make([]span, len(values))
+	for i, v := range values {
+		if def.typ.Elem == nil {
+			spans[i].start = -1
+			if !isNil(v) {
+				spans[i].start = len(children)
+				children = append(children, v)
+			}
+			continue
+		}
+
+		spans[i].list = true
+		for _, elem := range listElems(v) {
+			if isNil(elem) {
+				spans[i].elems = append(spans[i].elems, -1)
+				continue
+			}
+			spans[i].elems = append(spans[i].elems, len(children))
+			children = append(children, elem)
+		}
+	}
+
+	childResults := e.executeSet(ctx, def.object, children, groups, path)
+	results := make([]interface{}, len(values))
+	for i, s := range spans {
+		if !s.list {
+			if s.start >= 0 {
+				results[i] = childResults[s.start]
+			}
+			continue
+		}
+		list := make([]interface{}, len(s.elems))
+		for j, idx := range s.elems {
+			if idx >= 0 {
+				list[j] = childResults[idx]
+			}
+		}
+		results[i] = list
+	}
+	return results
+}
+
+func (e *executor) addError(err error, field *Field, path []interface{}) {
+	gqlErr, ok := err.(*Error)
+	if !ok {
+		gqlErr = NewError(CodeInternal, "%s", err.Error())
+	}
+	located := *gqlErr
+	located.Locations = []Location{field.Location}
+	located.Path = path
+	e.errors = append(e.errors, &located)
+}
+
+// listElems 展开任意切片，结构体元素取地址，使解析函数总是收到指针
+func listElems(v interface{}) []interface{} {
+	if list, ok := v.([]interface{}); ok {
+		return list
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice {
+		return nil
+	}
+	elems := make([]interface{}, rv.Len())
+	for i := range elems {
+		elem := rv.Index(i)
+		if elem.Kind() == reflect.Struct && elem.CanAddr() {
+			elem = elem.Addr()
+		}
+		elems[i] = elem.Interface()
+	}
+	return elems
+}
+
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Interface:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// orderedObject 按选择顺序输出字段的JSON对象
+type orderedObject struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func (o *orderedObject) set(key string, value interface{}) {
+	if o.values == nil {
+		o.values = make(map[string]interface{})
+	}
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+// MarshalJSON 按字段顺序输出
+func (o *orderedObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		v, err := json.Marshal(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,515 @@
+package graphql_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/yourusername/cloud-eye/internal/pkg/graphql"
+)
+
+type author struct {
+	ID   int
+	Name string
+}
+
+type book struct {
+	ID       int
+	Title    string
+	AuthorID int
+}
+
+// library 测试用的Schema和数据，记录解析函数和批量加载的调用
+type library struct {
+	authors []author
+	books   []book
+
+	mu          sync.Mutex
+	bookCalls   []int   // Author.books每次调用的父对象数量
+	authorLoads [][]int // 作者加载器每次批量加载的键
+	counter     int
+	maxNodes    int
+}
+
+type loaderKey struct{}
+
+func newLibrary() *library {
+	l := &library{}
+	for i := 1; i <= 3; i++ {
+		l.authors = append(l.authors, author{ID: i, Name: "作者" + strconv.Itoa(i)})
+		for j := 0; j < 2; j++ {
+			l.books = append(l.books, book{ID: i*10 + j, Title: "书" + strconv.Itoa(i*10+j), AuthorID: i})
+		}
+	}
+	return l
+}
+
+// context 创建请求级的作者加载器
+func (l *library) context() context.Context {
+	loader := graphql.NewLoader(func(_ context.Context, ids []int) (map[int]*author, error) {
+		l.mu.Lock()
+		l.authorLoads = append(l.authorLoads, append([]int(nil), ids...))
+		l.mu.Unlock()
+		result := make(map[int]*author)
+		for _, id := range ids {
+			if id <= len(l.authors) {
+				result[id] = &l.authors[id-1]
+			}
+		}
+		return result, nil
+	})
+	return context.WithValue(context.Background(), loaderKey{}, loader)
+}
+
+func (l *library) schema() *graphql.Schema {
+	authorType := graphql.NewObject("Author", "作者")
+	bookType := graphql.NewObject("Book", "")
+
+	authorType.
+		AddField(&graphql.FieldDef{Name: "id", Type: "ID!", Resolve: graphql.Property(func(s interface{}) interface{} {
+			return strconv.Itoa(s.(*author).ID)
+		})}).
+		AddField(&graphql.FieldDef{Name: "name", Type: "String!", Resolve: graphql.Property(func(s interface{}) interface{} {
+			return s.(*author).Name
+		})}).
+		AddField(&graphql.FieldDef{
+			Name: "books", Type: "[Book!]!",
+			Args: []*graphql.ArgDef{{Name: "first", Type: "Int", Default: 10}},
+			Resolve: func(_ context.Context, p graphql.ResolveParams) ([]interface{}, error) {
+				l.mu.Lock()
+				l.bookCalls = append(l.bookCalls, len(p.Sources))
+				l.mu.Unlock()
+				first, _ := p.Int("first")
+				values := make([]interface{}, len(p.Sources))
+				for i, s := range p.Sources {
+					var books []book
+					for _, b := range l.books {
+						if b.AuthorID == s.(*author).ID && len(books) < first {
+							books = append(books, b)
+						}
+					}
+					values[i] = books
+				}
+				return values, nil
+			},
+			ListSize: func(args map[string]interface{}) int { return args["first"].(int) },
+		})
+
+	bookType.
+		AddField(&graphql.FieldDef{Name: "id", Type: "ID!", Resolve: graphql.Property(func(s interface{}) interface{} {
+			return strconv.Itoa(s.(*book).ID)
+		})}).
+		AddField(&graphql.FieldDef{Name: "title", Type: "String!", Resolve: graphql.Property(func(s interface{}) interface{} {
+			return s.(*book).Title
+		})}).
+		AddField(&graphql.FieldDef{Name: "author", Type: "Author!", Resolve: func(ctx context.Context, p graphql.ResolveParams) ([]interface{}, error) {
+			ids := make([]int, len(p.Sources))
+			for i, s := range p.Sources {
+				ids[i] = s.(*book).AuthorID
+			}
+			authors, err := ctx.Value(loaderKey{}).(*graphql.Loader[int, *author]).LoadMany(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			values := make([]interface{}, len(authors))
+			for i, a := range authors {
+				values[i] = a
+			}
+			return values, nil
+		}})
+
+	query := graphql.NewObject("Query", "").
+		AddField(&graphql.FieldDef{Name: "authors", Type: "[Author!]!", Resolve: graphql.Property(func(interface{}) interface{} {
+			return l.authors
+		}), ListSize: func(map[string]interface{}) int { return len(l.authors) }}).
+		AddField(&graphql.FieldDef{
+			Name: "author", Type: "Author",
+			Args: []*graphql.ArgDef{{Name: "id", Type: "ID!"}},
+			Resolve: graphql.Each(func(_ context.Context, _ interface{}, args map[string]interface{}) (interface{}, error) {
+				id, _ := strconv.Atoi(args["id"].(string))
+				if id < 1 || id > len(l.authors) {
+					return nil, nil
+				}
+				return &l.authors[id-1], nil
+			}),
+		}).
+		AddField(&graphql.FieldDef{
+			Name: "echo", Type: "JSON",
+			Args: []*graphql.ArgDef{
+				{Name: "input", Type: "EchoInput!"},
+				{Name: "times", Type: "Int", Default: 2},
+				{Name: "ids", Type: "[ID!]"},
+			},
+			Resolve: graphql.Each(func(_ context.Context, _ interface{}, args map[string]interface{}) (interface{}, error) {
+				return args, nil
+			}),
+		}).
+		AddField(&graphql.FieldDef{Name: "fail", Type: "String", Resolve: func(context.Context, graphql.ResolveParams) ([]interface{}, error) {
+			return nil, graphql.NewError("NOT_FOUND", "资源不存在")
+		}}).
+		AddField(&graphql.FieldDef{Name: "broken", Type: "String", Resolve: func(context.Context, graphql.ResolveParams) ([]interface{}, error) {
+			return nil, errors.New("连接断开")
+		}})
+
+	mutation := graphql.NewObject("Mutation", "").
+		AddField(&graphql.FieldDef{
+			Name: "increment", Type: "Int!",
+			Args: []*graphql.ArgDef{{Name: "by", Type: "Int!"}},
+			Resolve: graphql.Each(func(_ context.Context, _ interface{}, args map[string]interface{}) (interface{}, error) {
+				l.counter += args["by"].(int)
+				return l.counter, nil
+			}),
+		})
+
+	return graphql.NewSchema(graphql.SchemaConfig{
+		Query:    query,
+		Mutation: mutation,
+		Types:    []*graphql.Object{authorType, bookType},
+		MaxNodes: l.maxNodes,
+		Inputs: []*graphql.InputObject{{Name: "EchoInput", Fields: []*graphql.ArgDef{
+			{Name: "text", Type: "String!"},
+			{Name: "tags", Type: "[String!]"},
+			{Name: "at", Type: "Time"},
+			{Name: "level", Type: "String", Default: "info"},
+		}}},
+	})
+}
+
+// execute 执行请求并返回JSON格式的响应，variables按HTTP请求体的方式解码
+func (l *library) execute(t *testing.T, query, variables string) (string, *graphql.Response) {
+	t.Helper()
+	req := graphql.Request{Query: query}
+	if variables != "" {
+		dec := json.NewDecoder(strings.NewReader(variables))
+		dec.UseNumber()
+		if err := dec.Decode(&req.Variables); err != nil {
+			t.Fatalf("解析变量失败: %v", err)
+		}
+	}
+	resp := l.schema().Execute(l.context(), req)
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(resp.Data); err != nil {
+		t.Fatalf("编码响应失败: %v", err)
+	}
+	return strings.TrimSpace(buf.String()), resp
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		msg   string
+		loc   graphql.Location
+	}{
+		{"未知字段", "{ authors { age } }", "类型 Author 没有字段 age", graphql.Location{Line: 1, Column: 13}},
+		{"对象字段缺少子字段", "{ authors }", "字段 authors 的类型 [Author!]! 需要选择子字段", graphql.Location{Line: 1, Column: 3}},
+		{"标量字段选择子字段", "{ authors { name { x } } }", "字段 name 的类型 String! 是标量，不能选择子字段", graphql.Location{Line: 1, Column: 13}},
+		{"未知参数", "{ authors { books(last: 1) { id } } }", "Author.books 没有参数 last", graphql.Location{Line: 1, Column: 19}},
+		{"重复参数", "{ author(id: 1, id: 2) { id } }", "Query.author 的参数 id 重复", graphql.Location{Line: 1, Column: 17}},
+		{"缺少必填参数", "{ author { id } }", "Query.author 缺少必填参数 id", graphql.Location{Line: 1, Column: 3}},
+		{"未定义的变量", "{ author(id: $id) { id } }", "未定义的变量 $id", graphql.Location{Line: 1, Column: 10}},
+		{"输入对象中未定义的变量", "{ echo(input: {text: $t}) }", "未定义的变量 $t", graphql.Location{Line: 1, Column: 8}},
+		{"重复的变量", "query($a: Int, $a: Int) { authors { id } }", "变量 $a 重复定义", graphql.Location{Line: 1, Column: 16}},
+		{"变量类型不是输入类型", "query($a: Author) { authors { id } }", "变量 $a 的类型 Author 不是输入类型", graphql.Location{Line: 1, Column: 7}},
+		{"未定义的片段", "{ authors { ...f } }", "未定义的片段 f", graphql.Location{Line: 1, Column: 13}},
+		{"片段类型不匹配", "{ authors { ...f } } fragment f on Book { id }", "片段 f 的类型 Book 不能用于 Author", graphql.Location{Line: 1, Column: 13}},
+		{"片段循环引用", "{ authors { ...a } } fragment a on Author { ...b } fragment b on Author { ...a }", "片段 a 循环引用", graphql.Location{Line: 1, Column: 75}},
+		{"内联片段类型不匹配", "{ authors { ... on Book { id } } }", "内联片段的类型 Book 不能用于 Author", graphql.Location{Line: 1, Column: 13}},
+		{"不支持的指令", "{ authors @cached { id } }", "不支持的指令 @cached", graphql.Location{Line: 1, Column: 11}},
+		{"指令缺少参数", "{ authors @skip { id } }", "@skip 缺少必填参数 if", graphql.Location{Line: 1, Column: 11}},
+		{"__typename带子字段", "{ __typename { x } }", "__typename 不接受参数和子字段", graphql.Location{Line: 1, Column: 3}},
+		{"订阅", "subscription { authors { id } }", "不支持subscription操作", graphql.Location{Line: 1, Column: 1}},
+		{"嵌套过深", "{ authors {" + strings.Repeat(" books { author {", 5) + " name" + strings.Repeat(" } }", 5) + " } }",
+			"查询嵌套超过10层", graphql.Location{Line: 1, Column: 89}},
+	}
+	l := newLibrary()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, resp := l.execute(t, tt.query, "")
+			if resp.Data != nil || len(resp.Errors) != 1 {
+				t.Fatalf("响应为%+v，期望只有一个校验错误", resp)
+			}
+			err := resp.Errors[0]
+			if err.Message != tt.msg || err.Extensions["code"] != graphql.CodeValidationFailed {
+				t.Errorf("错误为%q（%v），期望%q", err.Message, err.Extensions["code"], tt.msg)
+			}
+			if len(err.Locations) != 1 || err.Locations[0] != tt.loc {
+				t.Errorf("错误位置为%+v，期望%+v", err.Locations, tt.loc)
+			}
+		})
+	}
+}
+
+func TestNodeLimit(t *testing.T) {
+	nested := "{ authors { books(first: $n) { author { books(first: $n) { id } } } } }"
+	tests := []struct {
+		name      string
+		query     string
+		variables string
+		ok        bool
+	}{
+		// 3个作者 + 30本书 + 30个作者
+		{"未超过上限", "{ authors { books(first: 10) { author { name } } } }", "", true},
+		// 3 + 30 + 30 + 300
+		{"嵌套列表逐层相乘", "query($n: Int) " + nested, `{"n": 10}`, false},
+		{"按变量计算", "query($n: Int) " + nested, `{"n": 1}`, true},
+		{"使用默认值", "{ authors { books { author { books { id } } } } }", "", false},
+		{"跳过的字段不计算", "{ authors { books(first: 10) { author { books(first: 10) @skip(if: true) { id } } } } }", "", true},
+		{"片段展开后计算", "{ authors { books(first: 10) { ...b } } } fragment b on Book { author { books(first: 10) { id } } }", "", false},
+		{"别名分别计算", "{ a: authors { books(first: 10) { id } } b: authors { books(first: 10) { id } } }", "", true},
+		{"负数按0计算", "{ authors { books(first: -5) { author { books(first: 10) { id } } } } }", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newLibrary()
+			l.maxNodes = 100
+			_, resp := l.execute(t, tt.query, tt.variables)
+			if tt.ok {
+				if resp.Data == nil || len(resp.Errors) > 0 {
+					t.Fatalf("响应为%+v，期望执行成功", resp)
+				}
+				return
+			}
+			if resp.Data != nil || len(resp.Errors) != 1 {
+				t.Fatalf("响应为%+v，期望只有一个校验错误", resp)
+			}
+			if err := resp.Errors[0]; err.Extensions["code"] != graphql.CodeValidationFailed || !strings.Contains(err.Message, "超过100") {
+				t.Errorf("错误为%q（%v）", err.Message, err.Extensions["code"])
+			}
+			if len(l.bookCalls) > 0 {
+				t.Errorf("超过上限时仍调用了解析函数: %v", l.bookCalls)
+			}
+		})
+	}
+}
+
+func TestValidateCollectsAllErrors(t *testing.T) {
+	_, resp := newLibrary().execute(t, "{ authors { age } author { id } nope }", "")
+	var msgs []string
+	for _, err := range resp.Errors {
+		msgs = append(msgs, err.Message)
+	}
+	want := []string{"类型 Author 没有字段 age", "Query.author 缺少必填参数 id", "类型 Query 没有字段 nope"}
+	if !reflect.DeepEqual(msgs, want) {
+		t.Fatalf("错误为%v，期望%v", msgs, want)
+	}
+}
+
+func TestExecuteSelections(t *testing.T) {
+	query := `
+		query Books($withTitle: Boolean!, $hideId: Boolean = false) {
+			first: author(id: 1) { ...names books(first: 1) { title @include(if: $withTitle) } }
+			missing: author(id: "9") { name }
+			authors {
+				__typename
+				id @skip(if: $hideId)
+				... on Author { name }
+				...names
+			}
+		}
+		fragment names on Author { name }
+	`
+	got, resp := newLibrary().execute(t, query, `{"withTitle": true, "hideId": true}`)
+	if len(resp.Errors) > 0 {
+		t.Fatalf("执行出错: %v", resp.Errors[0])
+	}
+	// 字段按选择顺序输出，重复的字段合并，不存在的对象为null
+	want := `{"first":{"name":"作者1","books":[{"title":"书10"}]},"missing":null,` +
+		`"authors":[{"__typename":"Author","name":"作者1"},{"__typename":"Author","name":"作者2"},{"__typename":"Author","name":"作者3"}]}`
+	if got != want {
+		t.Fatalf("响应为\n%s\n期望\n%s", got, want)
+	}
+
+	got, _ = newLibrary().execute(t, query, `{"withTitle": false}`)
+	if !strings.Contains(got, `"books":[{}]`) || !strings.Contains(got, `"id":"1"`) {
+		t.Fatalf("@include为false、@skip使用默认值时响应为%s", got)
+	}
+}
+
+func TestArgumentCoercion(t *testing.T) {
+	l := newLibrary()
+
+	// 变量中的数字为json.Number，单个值可以作为列表，未提供的参数和输入字段使用默认值
+	got, resp := l.execute(t, `query($n: Int!, $at: Time) {
+		echo(input: {text: "a", tags: "x", at: $at}, ids: [$n, "b"])
+	}`, `{"n": 7, "at": "2024-01-02T03:04:05Z"}`)
+	if len(resp.Errors) > 0 {
+		t.Fatalf("执行出错: %v", resp.Errors[0])
+	}
+	want := `{"echo":{"ids":["7","b"],"input":{"at":"2024-01-02T03:04:05Z","level":"info","tags":["x"],"text":"a"},"times":2}}`
+	if got != want {
+		t.Fatalf("响应为\n%s\n期望\n%s", got, want)
+	}
+
+	tests := []struct {
+		name      string
+		query     string
+		variables string
+		msg       string
+	}{
+		{"变量类型错误", `query($n: Int!) { author(id: $n) { id } }`, `{"n": 1.5}`, "$n 的值 1.5 不是有效的Int"},
+		{"缺少非空变量", `query($n: Int!) { author(id: $n) { id } }`, ``, "缺少必填变量 $n"},
+		{"Int超出范围", `{ echo(input: {text: "a"}, times: 3000000000) }`, ``, "times 的值 3000000000 不是有效的Int"},
+		{"输入对象缺少必填字段", `{ echo(input: {tags: []}) }`, ``, "缺少必填字段 参数input.text"},
+		{"输入对象中未定义的字段", `{ echo(input: {text: "a", color: RED}) }`, ``, "input 中的字段 color 未在EchoInput中定义"},
+		{"无效的时间", `{ echo(input: {text: "a", at: "yesterday"}) }`, ``, "input.at 必须是RFC 3339格式的时间"},
+		{"列表元素为null", `{ echo(input: {text: "a"}, ids: ["1", null]) }`, ``, "ids[1] 不能为null"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, resp := l.execute(t, tt.query, tt.variables)
+			if len(resp.Errors) != 1 || resp.Errors[0].Extensions["code"] != graphql.CodeBadUserInput {
+				t.Fatalf("响应为%+v，期望一个BAD_USER_INPUT错误", resp)
+			}
+			if !strings.Contains(resp.Errors[0].Message, tt.msg) {
+				t.Errorf("错误为%q，期望包含%q", resp.Errors[0].Message, tt.msg)
+			}
+		})
+	}
+}
+
+func TestResolverErrorsReturnPartialData(t *testing.T) {
+	got, resp := newLibrary().execute(t, "{\n  authors { name }\n  fail\n  broken\n}", "")
+	if got != `{"authors":[{"name":"作者1"},{"name":"作者2"},{"name":"作者3"}],"fail":null,"broken":null}` {
+		t.Fatalf("响应为%s", got)
+	}
+	if len(resp.Errors) != 2 {
+		t.Fatalf("错误为%+v，期望2个", resp.Errors)
+	}
+	fail, broken := resp.Errors[0], resp.Errors[1]
+	if fail.Message != "资源不存在" || fail.Extensions["code"] != "NOT_FOUND" ||
+		!reflect.DeepEqual(fail.Path, []interface{}{"fail"}) || fail.Locations[0] != (graphql.Location{Line: 3, Column: 3}) {
+		t.Errorf("fail的错误为%+v", fail)
+	}
+	// 非graphql.Error的错误按内部错误返回
+	if broken.Message != "连接断开" || broken.Extensions["code"] != graphql.CodeInternal {
+		t.Errorf("broken的错误为%+v", broken)
+	}
+}
+
+func TestMutationFieldsRunInOrder(t *testing.T) {
+	got, resp := newLibrary().execute(t, `mutation { a: increment(by: 1) b: increment(by: 10) c: increment(by: 100) }`, "")
+	if len(resp.Errors) > 0 || got != `{"a":1,"b":11,"c":111}` {
+		t.Fatalf("响应为%s, %v", got, resp.Errors)
+	}
+}
+
+func TestExecuteBatchesNestedFields(t *testing.T) {
+	l := newLibrary()
+	got, resp := l.execute(t, `{
+		authors {
+			books {
+				author { name }
+				again: author { books { id } }
+			}
+		}
+	}`, "")
+	if len(resp.Errors) > 0 {
+		t.Fatalf("执行出错: %v", resp.Errors[0])
+	}
+	if !strings.HasPrefix(got, `{"authors":[{"books":[{"author":{"name":"作者1"},"again":{"books":[{"id":"10"},{"id":"11"}]}}`) {
+		t.Fatalf("响应为%s", got)
+	}
+
+	// 3个作者共6本书：同一层的Author.books只调用一次，第一次收到3个作者，第二次收到6本书对应的作者
+	if !reflect.DeepEqual(l.bookCalls, []int{3, 6}) {
+		t.Fatalf("Author.books的调用为%v，期望[3 6]", l.bookCalls)
+	}
+	// 6本书的作者只批量加载一次，重复的键合并，再次访问时使用缓存
+	if !reflect.DeepEqual(l.authorLoads, [][]int{{1, 2, 3}}) {
+		t.Fatalf("作者的批量加载为%v，期望只加载一次[1 2 3]", l.authorLoads)
+	}
+}
+
+func TestLoader(t *testing.T) {
+	var batches [][]string
+	loader := graphql.NewLoader(func(_ context.Context, keys []string) (map[string]int, error) {
+		batches = append(batches, keys)
+		if len(keys) > 0 && keys[0] == "error" {
+			return nil, errors.New("加载失败")
+		}
+		result := make(map[string]int)
+		for _, k := range keys {
+			if k != "missing" {
+				result[k] = len(k)
+			}
+		}
+		return result, nil
+	})
+	ctx := context.Background()
+
+	values, err := loader.LoadMany(ctx, []string{"a", "bb", "a", "missing"})
+	if err != nil || !reflect.DeepEqual(values, []int{1, 2, 1, 0}) {
+		t.Fatalf("LoadMany = %v, %v", values, err)
+	}
+	// 已缓存的键（包括不存在的键）不再加载
+	values, _ = loader.LoadMany(ctx, []string{"missing", "bb", "ccc"})
+	if !reflect.DeepEqual(values, []int{0, 2, 3}) {
+		t.Fatalf("LoadMany = %v", values)
+	}
+	if want := [][]string{{"a", "bb", "missing"}, {"ccc"}}; !reflect.DeepEqual(batches, want) {
+		t.Fatalf("批量加载为%v，期望%v", batches, want)
+	}
+
+	loader.Prime("dd", 42)
+	loader.Clear("a")
+	values, _ = loader.LoadMany(ctx, []string{"dd", "a"})
+	if !reflect.DeepEqual(values, []int{42, 1}) || !reflect.DeepEqual(batches[len(batches)-1], []string{"a"}) {
+		t.Fatalf("Prime和Clear后LoadMany = %v，最后一次加载%v", values, batches[len(batches)-1])
+	}
+
+	if _, err := loader.LoadMany(ctx, []string{"error"}); err == nil {
+		t.Fatal("批量加载失败时未返回错误")
+	}
+	if _, err := loader.LoadMany(ctx, []string{"error"}); err == nil || len(batches) != 5 {
+		t.Fatalf("加载失败的键被缓存了: %v，共加载%d次", err, len(batches))
+	}
+}
+
+func TestNewSchemaRejectsInvalidDefinitions(t *testing.T) {
+	noop := graphql.Property(func(interface{}) interface{} { return nil })
+	tests := []struct {
+		name  string
+		field *graphql.FieldDef
+		msg   string
+	}{
+		{"未定义的类型", &graphql.FieldDef{Name: "a", Type: "Missing", Resolve: noop}, "引用了未定义的类型 Missing"},
+		{"无效的类型", &graphql.FieldDef{Name: "a", Type: "[Int", Resolve: noop}, "的类型 \"[Int\" 无效"},
+		{"缺少解析函数", &graphql.FieldDef{Name: "a", Type: "Int"}, "缺少解析函数"},
+		{"参数为对象类型", &graphql.FieldDef{Name: "a", Type: "Int", Resolve: noop, Args: []*graphql.ArgDef{{Name: "q", Type: "Query"}}}, "的类型不能是对象类型"},
+		{"无效的默认值", &graphql.FieldDef{Name: "a", Type: "Int", Resolve: noop, Args: []*graphql.ArgDef{{Name: "n", Type: "Int", Default: "x"}}}, "的默认值无效"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				r := recover()
+				if msg, _ := r.(string); !strings.Contains(msg, tt.msg) {
+					t.Fatalf("panic为%v，期望包含%q", r, tt.msg)
+				}
+			}()
+			graphql.NewSchema(graphql.SchemaConfig{Query: graphql.NewObject("Query", "").AddField(tt.field)})
+		})
+	}
+}
+
+func TestSDL(t *testing.T) {
+	sdl := newLibrary().schema().SDL()
+	for _, want := range []string{
+		"scalar JSON\n",
+		"\"作者\"\ntype Author {\n  id: ID!\n  name: String!\n  books(first: Int = 10): [Book!]!\n}",
+		"echo(input: EchoInput!, times: Int = 2, ids: [ID!]): JSON",
+		"input EchoInput {\n  text: String!\n  tags: [String!]\n  at: Time\n  level: String = \"info\"\n}",
+	} {
+		if !strings.Contains(sdl, want) {
+			t.Errorf("SDL中缺少\n%s\n完整SDL:\n%s", want, sdl)
+		}
+	}
+}
@@ -0,0 +1,291 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// tokenKind 词法单元类型
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunct
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+// token 词法单元
+type token struct {
+	kind   tokenKind
+	value  string
+	line   int
+	column int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "文档结尾"
+	case tokenString:
+		return strconv.Quote(t.value)
+	default:
+		return t.value
+	}
+}
+
+// lexer 按GraphQL规范切分查询文档，忽略空白、逗号和注释
+type lexer struct {
+	src    string
+	pos    int
+	line   int
+	column int
+}
+
+func newLexer(src string) *lexer {
+	return &lexer{src: strings.TrimPrefix(src, "\ufeff"), line: 1, column: 1}
+}
+
+// next 读取下一个词法单元
+func (l *lexer) next() (token, error) {
+	l.skipIgnored()
+	if l.pos >= len(l.src) {
+		return token{kind: tokenEOF, line: l.line, column: l.column}, nil
+	}
+
+	start := token{line: l.line, column: l.column}
+	c := l.src[l.pos]
+	switch {
+	case strings.IndexByte("!$&()+:=@[]{}|", c) >= 0:
+		l.advance(1)
+		start.kind, start.value = tokenPunct, string(c)
+		return start, nil
+	case strings.HasPrefix(l.src[l.pos:], "..."):
+		l.advance(3)
+		start.kind, start.value = tokenPunct, "..."
+		return start, nil
+	case c == '_' || isLetter(c):
+		end := l.pos + 1
+		for end < len(l.src) && (l.src[end] == '_' || isLetter(l.src[end]) || isDigit(l.src[end])) {
+			end++
+		}
+		start.kind, start.value = tokenName, l.src[l.pos:end]
+		l.advance(end - l.pos)
+		return start, nil
+	case c == '-' || isDigit(c):
+		return l.number(start)
+	case c == '"':
+		if strings.HasPrefix(l.src[l.pos:], `"""`) {
+			return l.blockString(start)
+		}
+		return l.string(start)
+	}
+	return token{}, l.errorf(start, "无法识别的字符 %q", c)
+}
+
+// skipIgnored 跳过空白、逗号和注释
+func (l *lexer) skipIgnored() {
+	for l.pos < len(l.src) {
+		switch c := l.src[l.pos]; c {
+		case ' ', '\t', ',', '\r':
+			l.advance(1)
+		case '\n':
+			l.pos++
+			l.line++
+			l.column = 1
+		case '#':
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				l.advance(1)
+			}
+		default:
+			return
+		}
+	}
+}
+
+// advance 前进n个字节，列号按字符计算，注释可能逐字节跳过，因此不计UTF-8的后续字节
+func (l *lexer) advance(n int) {
+	for _, c := range []byte(l.src[l.pos : l.pos+n]) {
+		if c&0xC0 != 0x80 {
+			l.column++
+		}
+	}
+	l.pos += n
+}
+
+// number 读取整数或浮点数
+func (l *lexer) number(start token) (token, error) {
+	end := l.pos
+	if l.src[end] == '-' {
+		end++
+	}
+	digits := end
+	for end < len(l.src) && isDigit(l.src[end]) {
+		end++
+	}
+	if end == digits {
+		return token{}, l.errorf(start, "无效的数字")
+	}
+
+	start.kind = tokenInt
+	if end < len(l.src) && l.src[end] == '.' {
+		start.kind = tokenFloat
+		end++
+		frac := end
+		for end < len(l.src) && isDigit(l.src[end]) {
+			end++
+		}
+		if end == frac {
+			return token{}, l.errorf(start, "无效的数字")
+		}
+	}
+	if end < len(l.src) && (l.src[end] == 'e' || l.src[end] == 'E') {
+		start.kind = tokenFloat
+		end++
+		if end < len(l.src) && (l.src[end] == '+' || l.src[end] == '-') {
+			end++
+		}
+		exp := end
+		for end < len(l.src) && isDigit(l.src[end]) {
+			end++
+		}
+		if end == exp {
+			return token{}, l.errorf(start, "无效的数字")
+		}
+	}
+
+	start.value = l.src[l.pos:end]
+	l.advance(end - l.pos)
+	return start, nil
+}
+
+// string 读取带转义的单行字符串
+func (l *lexer) string(start token) (token, error) {
+	var b strings.Builder
+	l.advance(1)
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case c == '"':
+			l.advance(1)
+			start.kind, start.value = tokenString, b.String()
+			return start, nil
+		case c == '\n':
+			return token{}, l.errorf(start, "字符串未结束")
+		case c == '\\':
+			if l.pos+1 >= len(l.src) {
+				return token{}, l.errorf(start, "字符串未结束")
+			}
+			esc := l.src[l.pos+1]
+			l.advance(2)
+			switch esc {
+			case '"', '\\', '/':
+				b.WriteByte(esc)
+			case 'b':
+				b.WriteByte('\b')
+			case 'f':
+				b.WriteByte('\f')
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case 'u':
+				if l.pos+4 > len(l.src) {
+					return token{}, l.errorf(start, "无效的Unicode转义")
+				}
+				code, err := strconv.ParseUint(l.src[l.pos:l.pos+4], 16, 32)
+				if err != nil {
+					return token{}, l.errorf(start, "无效的Unicode转义")
+				}
+				b.WriteRune(rune(code))
+				l.advance(4)
+			default:
+				return token{}, l.errorf(start, "无效的转义字符 \\%c", esc)
+			}
+		default:
+			r, size := utf8.DecodeRuneInString(l.src[l.pos:])
+			b.WriteRune(r)
+			l.advance(size)
+		}
+	}
+	return token{}, l.errorf(start, "字符串未结束")
+}
+
+// blockString 读取"""包围的多行字符串，去掉公共缩进和首尾空行
+func (l *lexer) blockString(start token) (token, error) {
+	l.advance(3)
+	var b strings.Builder
+	for l.pos < len(l.src) {
+		switch {
+		case strings.HasPrefix(l.src[l.pos:], `\"""`):
+			b.WriteString(`"""`)
+			l.advance(4)
+		case strings.HasPrefix(l.src[l.pos:], `"""`):
+			l.advance(3)
+			start.kind, start.value = tokenString, dedentBlock(b.String())
+			return start, nil
+		case l.src[l.pos] == '\n':
+			b.WriteByte('\n')
+			l.pos++
+			l.line++
+			l.column = 1
+		default:
+			b.WriteByte(l.src[l.pos])
+			l.advance(1)
+		}
+	}
+	return token{}, l.errorf(start, "字符串未结束")
+}
+
+// dedentBlock 按规范处理块字符串的缩进
+func dedentBlock(raw string) string {
+	lines := strings.Split(strings.ReplaceAll(raw, "\r\n", "\n"), "\n")
+	indent := -1
+	for _, line := range lines[1:] {
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed == "" {
+			continue
+		}
+		if n := len(line) - len(trimmed); indent < 0 || n < indent {
+			indent = n
+		}
+	}
+	if indent > 0 {
+		for i := 1; i < len(lines); i++ {
+			if len(lines[i]) >= indent {
+				lines[i] = lines[i][indent:]
+			} else {
+				lines[i] = strings.TrimLeft(lines[i], " \t")
+			}
+		}
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (l *lexer) errorf(at token, format string, args ...interface{}) *Error {
+	return &Error{
+		Message:    "语法错误: " + fmt.Sprintf(format, args...),
+		Locations:  []Location{{Line: at.line, Column: at.column}},
+		Extensions: map[string]interface{}{"code": CodeParseFailed},
+	}
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,68 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+)
+
+// BatchFunc 按一组键批量加载数据，返回结果中缺少的键视为不存在
+type BatchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// Loader 请求级的数据加载器：合并同一层字段的键为一次批量查询，并缓存已加载的结果，
+// 每个请求应创建新的Loader，避免跨请求读取过期数据
+type Loader[K comparable, V any] struct {
+	batch BatchFunc[K, V]
+	mu    sync.Mutex
+	cache map[K]V
+}
+
+// NewLoader 创建数据加载器
+func NewLoader[K comparable, V any](batch BatchFunc[K, V]) *Loader[K, V] {
+	return &Loader[K, V]{batch: batch, cache: make(map[K]V)}
+}
+
+// LoadMany 按顺序返回各键对应的值，只对未缓存的键调用一次批量加载，不存在的键返回零值
+func (l *Loader[K, V]) LoadMany(ctx context.Context, keys []K) ([]V, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var missing []K
+	seen := make(map[K]bool)
+	for _, key := range keys {
+		if _, ok := l.cache[key]; !ok && !seen[key] {
+			seen[key] = true
+			missing = append(missing, key)
+		}
+	}
+
+	if len(missing) > 0 {
+		loaded, err := l.batch(ctx, missing)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range missing {
+			// 不存在的键也缓存零值，避免重复查询
+			l.cache[key] = loaded[key]
+		}
+	}
+
+	values := make([]V, len(keys))
+	for i, key := range keys {
+		values[i] = l.cache[key]
+	}
+	return values, nil
+}
+
+// Prime 缓存已通过其他查询得到的值
+func (l *Loader[K, V]) Prime(key K, value V) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.cache[key] = value
+}
+
+// Clear 删除缓存的值，用于变更后重新加载
+func (l *Loader[K, V]) Clear(key K) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.cache, key)
+}
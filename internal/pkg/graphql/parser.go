@@ -0,0 +1,592 @@
+package graphql
+
+import (
+	"strconv"
+)
+
+// Document 解析后的查询文档
+type Document struct {
+	Operations []*Operation
+	Fragments  map[string]*Fragment
+}
+
+// Operation 查询或变更操作
+type Operation struct {
+	Type         string // query或mutation
+	Name         string
+	Variables    []*VariableDef
+	SelectionSet []Selection
+	Location     Location
+}
+
+// VariableDef 变量定义
+type VariableDef struct {
+	Name       string
+	Type       *TypeRef
+	Default    interface{}
+	HasDefault bool
+	Location   Location
+}
+
+// Fragment 具名片段
+type Fragment struct {
+	Name          string
+	TypeCondition string
+	SelectionSet  []Selection
+	Location      Location
+}
+
+// Selection 选择集中的字段、片段展开或内联片段
+type Selection interface {
+	location() Location
+}
+
+// Field 选择的字段
+type Field struct {
+	Alias        string
+	Name         string
+	Arguments    []*Argument
+	Directives   []*Directive
+	SelectionSet []Selection
+	Location     Location
+}
+
+// ResponseKey 响应中使用的键，有别名时为别名
+func (f *Field) ResponseKey() string {
+	if f.Alias != "" {
+		return f.Alias
+	}
+	return f.Name
+}
+
+// FragmentSpread 片段展开 ...Name
+type FragmentSpread struct {
+	Name       string
+	Directives []*Directive
+	Location   Location
+}
+
+// InlineFragment 内联片段 ... on Type { }
+type InlineFragment struct {
+	TypeCondition string
+	Directives    []*Directive
+	SelectionSet  []Selection
+	Location      Location
+}
+
+func (f *Field) location() Location          { return f.Location }
+func (f *FragmentSpread) location() Location { return f.Location }
+func (f *InlineFragment) location() Location { return f.Location }
+
+// Argument 字段或指令的参数
+type Argument struct {
+	Name     string
+	Value    interface{}
+	Location Location
+}
+
+// Directive 指令，支持@skip和@include
+type Directive struct {
+	Name      string
+	Arguments []*Argument
+	Location  Location
+}
+
+// 参数值中的变量和枚举值，其余字面量使用int64、float64、string、bool、nil、[]interface{}和map[string]interface{}表示
+type (
+	Variable  string
+	EnumValue string
+)
+
+// TypeRef 类型引用，例如[ID!]!
+type TypeRef struct {
+	Name    string   // 具名类型，列表类型时为空
+	Elem    *TypeRef // 列表元素类型
+	NonNull bool
+}
+
+func (t *TypeRef) String() string {
+	s := t.Name
+	if t.Elem != nil {
+		s = "[" + t.Elem.String() + "]"
+	}
+	if t.NonNull {
+		s += "!"
+	}
+	return s
+}
+
+// NamedType 去掉列表和非空修饰后的类型名
+func (t *TypeRef) NamedType() string {
+	for t.Elem != nil {
+		t = t.Elem
+	}
+	return t.Name
+}
+
+// ParseType 解析类型字符串，例如"[Product!]!"
+func ParseType(s string) (*TypeRef, error) {
+	p := &parser{lex: newLexer(s)}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	t, err := p.parseType()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokenEOF {
+		return nil, p.unexpected()
+	}
+	return t, nil
+}
+
+// Parse 解析查询文档，只支持可执行定义（操作和片段）
+func Parse(src string) (*Document, error) {
+	p := &parser{lex: newLexer(src)}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	doc := &Document{Fragments: make(map[string]*Fragment)}
+	for p.tok.kind != tokenEOF {
+		switch {
+		case p.peek(tokenPunct, "{"):
+			op := &Operation{Type: "query", Location: p.location()}
+			set, err := p.parseSelectionSet()
+			if err != nil {
+				return nil, err
+			}
+			op.SelectionSet = set
+			doc.Operations = append(doc.Operations, op)
+		case p.peek(tokenName, "query"), p.peek(tokenName, "mutation"), p.peek(tokenName, "subscription"):
+			op, err := p.parseOperation()
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, op)
+		case p.peek(tokenName, "fragment"):
+			frag, err := p.parseFragment()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := doc.Fragments[frag.Name]; ok {
+				return nil, p.errorAt(frag.Location, "片段 %s 重复定义", frag.Name)
+			}
+			doc.Fragments[frag.Name] = frag
+		default:
+			return nil, p.unexpected()
+		}
+	}
+	if len(doc.Operations) == 0 {
+		return nil, p.errorAt(p.location(), "文档中没有操作")
+	}
+	return doc, nil
+}
+
+// maxNesting 选择集、列表值、输入对象和列表类型的最大嵌套层数，防止深度嵌套的文档耗尽栈空间；
+// 选择集的深度在校验时另按MaxDepth限制
+const maxNesting = 64
+
+// parser 递归下降解析器
+type parser struct {
+	lex   *lexer
+	tok   token
+	depth int // 当前嵌套层数
+}
+
+// enter 进入一层嵌套，超过maxNesting时返回错误，调用方需在返回时调用leave
+func (p *parser) enter() error {
+	p.depth++
+	if p.depth > maxNesting {
+		return p.errorAt(p.location(), "嵌套超过%d层", maxNesting)
+	}
+	return nil
+}
+
+func (p *parser) leave() {
+	p.depth--
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *parser) peek(kind tokenKind, value string) bool {
+	return p.tok.kind == kind && p.tok.value == value
+}
+
+// skip 当前词法单元匹配时前进一步
+func (p *parser) skip(kind tokenKind, value string) (bool, error) {
+	if !p.peek(kind, value) {
+		return false, nil
+	}
+	return true, p.advance()
+}
+
+func (p *parser) expect(kind tokenKind, value string) error {
+	if !p.peek(kind, value) {
+		return p.unexpected()
+	}
+	return p.advance()
+}
+
+func (p *parser) expectName() (string, error) {
+	if p.tok.kind != tokenName {
+		return "", p.unexpected()
+	}
+	name := p.tok.value
+	return name, p.advance()
+}
+
+func (p *parser) location() Location {
+	return Location{Line: p.tok.line, Column: p.tok.column}
+}
+
+func (p *parser) unexpected() error {
+	return p.errorAt(p.location(), "意外的 %s", p.tok)
+}
+
+func (p *parser) errorAt(loc Location, format string, args ...interface{}) error {
+	return p.lex.errorf(token{line: loc.Line, column: loc.Column}, format, args...)
+}
+
+func (p *parser) parseOperation() (*Operation, error) {
+	op := &Operation{Type: p.tok.value, Location: p.location()}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokenName {
+		op.Name = p.tok.value
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+
+	if ok, err := p.skip(tokenPunct, "("); err != nil {
+		return nil, err
+	} else if ok {
+		for !p.peek(tokenPunct, ")") {
+			def, err := p.parseVariableDef()
+			if err != nil {
+				return nil, err
+			}
+			op.Variables = append(op.Variables, def)
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := p.parseDirectives(); err != nil {
+		return nil, err
+	}
+	set, err := p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+	op.SelectionSet = set
+	return op, nil
+}
+
+func (p *parser) parseVariableDef() (*VariableDef, error) {
+	def := &VariableDef{Location: p.location()}
+	if err := p.expect(tokenPunct, "$"); err != nil {
+		return nil, err
+	}
+	name, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+	def.Name = name
+	if err := p.expect(tokenPunct, ":"); err != nil {
+		return nil, err
+	}
+	if def.Type, err = p.parseType(); err != nil {
+		return nil, err
+	}
+	if ok, err := p.skip(tokenPunct, "="); err != nil {
+		return nil, err
+	} else if ok {
+		if def.Default, err = p.parseValue(true); err != nil {
+			return nil, err
+		}
+		def.HasDefault = true
+	}
+	return def, nil
+}
+
+func (p *parser) parseType() (*TypeRef, error) {
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+
+	var t *TypeRef
+	if ok, err := p.skip(tokenPunct, "["); err != nil {
+		return nil, err
+	} else if ok {
+		elem, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenPunct, "]"); err != nil {
+			return nil, err
+		}
+		t = &TypeRef{Elem: elem}
+	} else {
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		t = &TypeRef{Name: name}
+	}
+
+	ok, err := p.skip(tokenPunct, "!")
+	if err != nil {
+		return nil, err
+	}
+	t.NonNull = ok
+	return t, nil
+}
+
+func (p *parser) parseFragment() (*Fragment, error) {
+	frag := &Fragment{Location: p.location()}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	name, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+	if name == "on" {
+		return nil, p.errorAt(frag.Location, "片段名不能为on")
+	}
+	frag.Name = name
+	if err := p.expect(tokenName, "on"); err != nil {
+		return nil, err
+	}
+	if frag.TypeCondition, err = p.expectName(); err != nil {
+		return nil, err
+	}
+	if _, err := p.parseDirectives(); err != nil {
+		return nil, err
+	}
+	if frag.SelectionSet, err = p.parseSelectionSet(); err != nil {
+		return nil, err
+	}
+	return frag, nil
+}
+
+func (p *parser) parseSelectionSet() ([]Selection, error) {
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+
+	if err := p.expect(tokenPunct, "{"); err != nil {
+		return nil, err
+	}
+	var set []Selection
+	for !p.peek(tokenPunct, "}") {
+		sel, err := p.parseSelection()
+		if err != nil {
+			return nil, err
+		}
+		set = append(set, sel)
+	}
+	if len(set) == 0 {
+		return nil, p.errorAt(p.location(), "选择集不能为空")
+	}
+	return set, p.advance()
+}
+
+func (p *parser) parseSelection() (Selection, error) {
+	loc := p.location()
+	if ok, err := p.skip(tokenPunct, "..."); err != nil {
+		return nil, err
+	} else if ok {
+		if p.tok.kind == tokenName && p.tok.value != "on" {
+			spread := &FragmentSpread{Name: p.tok.value, Location: loc}
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			spread.Directives, err = p.parseDirectives()
+			return spread, err
+		}
+
+		inline := &InlineFragment{Location: loc}
+		if ok, err := p.skip(tokenName, "on"); err != nil {
+			return nil, err
+		} else if ok {
+			if inline.TypeCondition, err = p.expectName(); err != nil {
+				return nil, err
+			}
+		}
+		if inline.Directives, err = p.parseDirectives(); err != nil {
+			return nil, err
+		}
+		inline.SelectionSet, err = p.parseSelectionSet()
+		return inline, err
+	}
+
+	field := &Field{Location: loc}
+	name, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+	field.Name = name
+	if ok, err := p.skip(tokenPunct, ":"); err != nil {
+		return nil, err
+	} else if ok {
+		field.Alias = name
+		if field.Name, err = p.expectName(); err != nil {
+			return nil, err
+		}
+	}
+	if field.Arguments, err = p.parseArguments(); err != nil {
+		return nil, err
+	}
+	if field.Directives, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+	if p.peek(tokenPunct, "{") {
+		if field.SelectionSet, err = p.parseSelectionSet(); err != nil {
+			return nil, err
+		}
+	}
+	return field, nil
+}
+
+func (p *parser) parseArguments() ([]*Argument, error) {
+	if ok, err := p.skip(tokenPunct, "("); err != nil || !ok {
+		return nil, err
+	}
+	var args []*Argument
+	for !p.peek(tokenPunct, ")") {
+		arg := &Argument{Location: p.location()}
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		arg.Name = name
+		if err := p.expect(tokenPunct, ":"); err != nil {
+			return nil, err
+		}
+		if arg.Value, err = p.parseValue(false); err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	if len(args) == 0 {
+		return nil, p.unexpected()
+	}
+	return args, p.advance()
+}
+
+func (p *parser) parseDirectives() ([]*Directive, error) {
+	var directives []*Directive
+	for p.peek(tokenPunct, "@") {
+		d := &Directive{Location: p.location()}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		d.Name = name
+		if d.Arguments, err = p.parseArguments(); err != nil {
+			return nil, err
+		}
+		directives = append(directives, d)
+	}
+	return directives, nil
+}
+
+// parseValue 解析参数值，constant为true时不允许出现变量（用于变量默认值）
+func (p *parser) parseValue(constant bool) (interface{}, error) {
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+
+	tok := p.tok
+	switch tok.kind {
+	case tokenPunct:
+		switch tok.value {
+		case "$":
+			if constant {
+				return nil, p.unexpected()
+			}
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			name, err := p.expectName()
+			return Variable(name), err
+		case "[":
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			list := []interface{}{}
+			for !p.peek(tokenPunct, "]") {
+				v, err := p.parseValue(constant)
+				if err != nil {
+					return nil, err
+				}
+				list = append(list, v)
+			}
+			return list, p.advance()
+		case "{":
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			obj := map[string]interface{}{}
+			for !p.peek(tokenPunct, "}") {
+				name, err := p.expectName()
+				if err != nil {
+					return nil, err
+				}
+				if err := p.expect(tokenPunct, ":"); err != nil {
+					return nil, err
+				}
+				if obj[name], err = p.parseValue(constant); err != nil {
+					return nil, err
+				}
+			}
+			return obj, p.advance()
+		}
+	case tokenInt:
+		n, err := strconv.ParseInt(tok.value, 10, 64)
+		if err != nil {
+			return nil, p.errorAt(p.location(), "整数超出范围: %s", tok.value)
+		}
+		return n, p.advance()
+	case tokenFloat:
+		f, err := strconv.ParseFloat(tok.value, 64)
+		if err != nil {
+			return nil, p.errorAt(p.location(), "无效的浮点数: %s", tok.value)
+		}
+		return f, p.advance()
+	case tokenString:
+		return tok.value, p.advance()
+	case tokenName:
+		var v interface{}
+		switch tok.value {
+		case "true":
+			v = true
+		case "false":
+			v = false
+		case "null":
+			v = nil
+		default:
+			v = EnumValue(tok.value)
+		}
+		return v, p.advance()
+	}
+	return nil, p.unexpected()
+}
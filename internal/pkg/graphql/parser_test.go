@@ -0,0 +1,226 @@
+package graphql_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/cloud-eye/internal/pkg/graphql"
+)
+
+func TestParseDocument(t *testing.T) {
+	doc, err := graphql.Parse(`
+		# 注释和逗号被忽略
+		query Items($ids: [ID!]! = ["1"], $skip: Boolean) {
+			list: configItems(productId: $ids, first: 10, ratio: -1.5e2, tags: ["加密", "网络"], filter: {status: ACTIVE, note: null}) @skip(if: $skip) {
+				...itemFields
+				... on ConfigItem { severity }
+				... @include(if: true) { status }
+			}
+			desc: echo(text: """
+				第一行
+				  缩进行
+			""")
+		}
+
+		fragment itemFields on ConfigItem { id, name }
+
+		mutation { deleteConfigItem(id: "1\n") }
+	`)
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	if len(doc.Operations) != 2 || len(doc.Fragments) != 1 {
+		t.Fatalf("解析出%d个操作、%d个片段", len(doc.Operations), len(doc.Fragments))
+	}
+
+	op := doc.Operations[0]
+	if op.Type != "query" || op.Name != "Items" || op.Location != (graphql.Location{Line: 3, Column: 3}) {
+		t.Fatalf("操作为%s %s，位置%+v", op.Type, op.Name, op.Location)
+	}
+	if len(op.Variables) != 2 || op.Variables[0].Type.String() != "[ID!]!" || !op.Variables[0].HasDefault ||
+		!reflect.DeepEqual(op.Variables[0].Default, []interface{}{"1"}) || op.Variables[1].HasDefault {
+		t.Fatalf("变量定义为%+v %+v", op.Variables[0], op.Variables[1])
+	}
+
+	list := op.SelectionSet[0].(*graphql.Field)
+	if list.Alias != "list" || list.Name != "configItems" || list.ResponseKey() != "list" {
+		t.Fatalf("字段为%+v", list)
+	}
+	args := map[string]interface{}{}
+	for _, arg := range list.Arguments {
+		args[arg.Name] = arg.Value
+	}
+	wantArgs := map[string]interface{}{
+		"productId": graphql.Variable("ids"),
+		"first":     int64(10),
+		"ratio":     -150.0,
+		"tags":      []interface{}{"加密", "网络"},
+		"filter":    map[string]interface{}{"status": graphql.EnumValue("ACTIVE"), "note": nil},
+	}
+	if !reflect.DeepEqual(args, wantArgs) {
+		t.Fatalf("参数为%#v", args)
+	}
+	if len(list.Directives) != 1 || list.Directives[0].Name != "skip" || list.Directives[0].Arguments[0].Value != graphql.Variable("skip") {
+		t.Fatalf("指令为%+v", list.Directives)
+	}
+
+	if spread, ok := list.SelectionSet[0].(*graphql.FragmentSpread); !ok || spread.Name != "itemFields" {
+		t.Fatalf("第1个选择为%#v，期望片段展开", list.SelectionSet[0])
+	}
+	if inline, ok := list.SelectionSet[1].(*graphql.InlineFragment); !ok || inline.TypeCondition != "ConfigItem" {
+		t.Fatalf("第2个选择为%#v，期望带类型条件的内联片段", list.SelectionSet[1])
+	}
+	if inline, ok := list.SelectionSet[2].(*graphql.InlineFragment); !ok || inline.TypeCondition != "" || len(inline.Directives) != 1 {
+		t.Fatalf("第3个选择为%#v，期望带指令的内联片段", list.SelectionSet[2])
+	}
+
+	desc := op.SelectionSet[1].(*graphql.Field)
+	if got := desc.Arguments[0].Value; got != "第一行\n  缩进行" {
+		t.Fatalf("块字符串为%q", got)
+	}
+
+	frag := doc.Fragments["itemFields"]
+	if frag.TypeCondition != "ConfigItem" || len(frag.SelectionSet) != 2 {
+		t.Fatalf("片段为%+v", frag)
+	}
+
+	// 省略操作名的变更和字符串转义
+	mutation := doc.Operations[1]
+	if mutation.Type != "mutation" || mutation.Name != "" {
+		t.Fatalf("第二个操作为%s %q", mutation.Type, mutation.Name)
+	}
+	if got := mutation.SelectionSet[0].(*graphql.Field).Arguments[0].Value; got != "1\n" {
+		t.Fatalf("转义后的字符串为%q", got)
+	}
+}
+
+func TestParseShorthandQuery(t *testing.T) {
+	doc, err := graphql.Parse(`{ providers { nodes { id } } }`)
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	op, err := doc.Operation("")
+	if err != nil || op.Type != "query" || op.Name != "" {
+		t.Fatalf("Operation(\"\") = %+v, %v", op, err)
+	}
+}
+
+func TestOperationSelection(t *testing.T) {
+	doc, err := graphql.Parse(`query A { a } query B { b }`)
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	if op, err := doc.Operation("B"); err != nil || op.Name != "B" {
+		t.Fatalf("Operation(B) = %+v, %v", op, err)
+	}
+	if _, err := doc.Operation(""); err == nil || !strings.Contains(err.Error(), "operationName") {
+		t.Fatalf("多个操作未指定名称返回%v", err)
+	}
+	if _, err := doc.Operation("C"); err == nil {
+		t.Fatal("不存在的操作名未返回错误")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		msg  string
+		loc  graphql.Location
+	}{
+		{"空文档", "  # 只有注释", "文档中没有操作", graphql.Location{Line: 1, Column: 9}},
+		{"未闭合的选择集", "{ a", "意外的", graphql.Location{Line: 1, Column: 4}},
+		{"未闭合的字符串", "{ a(s: \"abc) }", "", graphql.Location{Line: 1, Column: 8}},
+		{"字符串中的换行", "{ a(s: \"a\nb\") }", "", graphql.Location{Line: 1, Column: 8}},
+		{"非法字符", "{ a ^ }", "", graphql.Location{Line: 1, Column: 5}},
+		{"整数溢出", "{ a(n: 99999999999999999999) }", "整数超出范围", graphql.Location{Line: 1, Column: 8}},
+		{"无效的数字", "{ a(n: 1.) }", "", graphql.Location{Line: 1, Column: 8}},
+		{"变量默认值引用变量", "query($a: Int = $b) { a }", "意外的", graphql.Location{Line: 1, Column: 17}},
+		{"片段重复定义", "{ ...f } fragment f on Q { a }\nfragment f on Q { b }", "片段 f 重复定义", graphql.Location{Line: 2, Column: 1}},
+		{"片段缺少类型条件", "{ ...f } fragment f { a }", "意外的", graphql.Location{Line: 1, Column: 21}},
+		{"类型系统定义", "type Query { a: Int }", "意外的", graphql.Location{Line: 1, Column: 1}},
+		{"空选择集", "{ a { } }", "选择集不能为空", graphql.Location{Line: 1, Column: 7}},
+		{"选择集嵌套过深", strings.Repeat("{ a ", 100000), "嵌套超过64层", graphql.Location{Line: 1, Column: 257}},
+		{"列表值嵌套过深", "{ a(x: " + strings.Repeat("[", 100000) + ") }", "嵌套超过64层", graphql.Location{Line: 1, Column: 71}},
+		{"输入对象嵌套过深", "{ a(x: " + strings.Repeat("{b: ", 100000) + ") }", "嵌套超过64层", graphql.Location{Line: 1, Column: 260}},
+		{"列表类型嵌套过深", "query($a: " + strings.Repeat("[", 100000) + "Int) { a }", "嵌套超过64层", graphql.Location{Line: 1, Column: 75}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := graphql.Parse(tt.src)
+			gqlErr, ok := err.(*graphql.Error)
+			if !ok {
+				t.Fatalf("Parse返回%v，期望*graphql.Error", err)
+			}
+			if gqlErr.Extensions["code"] != graphql.CodeParseFailed || !strings.HasPrefix(gqlErr.Message, "语法错误: ") {
+				t.Fatalf("错误为%+v", gqlErr)
+			}
+			if !strings.Contains(gqlErr.Message, tt.msg) {
+				t.Errorf("错误信息为%q，期望包含%q", gqlErr.Message, tt.msg)
+			}
+			if len(gqlErr.Locations) != 1 || gqlErr.Locations[0] != tt.loc {
+				t.Errorf("错误位置为%+v，期望%+v", gqlErr.Locations, tt.loc)
+			}
+		})
+	}
+}
+
+func TestParseType(t *testing.T) {
+	for _, s := range []string{"Int", "ID!", "[String]", "[Product!]!", "[[Int!]]"} {
+		typ, err := graphql.ParseType(s)
+		if err != nil || typ.String() != s {
+			t.Errorf("ParseType(%q) = %v, %v", s, typ, err)
+		}
+	}
+	if typ, _ := graphql.ParseType("[[Tag!]!]"); typ.NamedType() != "Tag" {
+		t.Errorf("NamedType() = %q", typ.NamedType())
+	}
+	for _, s := range []string{"", "[Int", "Int!!", "Int Int", "!"} {
+		if _, err := graphql.ParseType(s); err == nil {
+			t.Errorf("ParseType(%q)成功，期望返回错误", s)
+		}
+	}
+}
+
+// FuzzParse 任意输入都不能导致解析器panic，解析失败时返回带位置的语法错误
+func FuzzParse(f *testing.F) {
+	for _, seed := range []string{
+		"{ a }",
+		`query Q($id: ID!, $n: Int = 10) { provider(id: $id) { id products(first: $n) @skip(if: false) { ...p } } }
+		fragment p on Product { name ... on Product { code } }`,
+		`mutation { patch(id: "1", patch: {a: [1, 2.5, -3e2, true, null, ENUM], b: "中\n"}) }`,
+		`{ a(s: """块
+		字符串""") }`,
+		"# 注释\n{ a(s: \"abc) }",
+		"{ a(n: 99999999999999999999) }",
+		"query($a: [Int!]! = [1]) { a }",
+		"{ ...f } fragment f on Q { a }\nfragment f on Q { b }",
+		"{ a { } }",
+		"\ufeff{ 名称 }",
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, src string) {
+		doc, err := graphql.Parse(src)
+		if err != nil {
+			gqlErr, ok := err.(*graphql.Error)
+			if !ok {
+				t.Fatalf("Parse返回%T，期望*graphql.Error", err)
+			}
+			if gqlErr.Extensions["code"] != graphql.CodeParseFailed || len(gqlErr.Locations) != 1 ||
+				gqlErr.Locations[0].Line < 1 || gqlErr.Locations[0].Column < 1 {
+				t.Fatalf("错误为%+v，期望带位置的语法错误", gqlErr)
+			}
+			return
+		}
+		if len(doc.Operations) == 0 {
+			t.Fatal("解析成功但没有操作")
+		}
+		for _, op := range doc.Operations {
+			if len(op.SelectionSet) == 0 {
+				t.Fatal("解析成功但操作的选择集为空")
+			}
+		}
+	})
+}
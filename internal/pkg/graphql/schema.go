@@ -0,0 +1,324 @@
+// Package graphql 实现GraphQL查询语言的一个子集：查询、变更、变量、别名、片段以及@skip和@include指令。
+// 执行器按字段逐层批量解析，同一层所有父对象的同名字段只调用一次解析函数，配合Loader消除N+1查询
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// 错误码，写入错误的extensions.code
+const (
+	CodeParseFailed      = "GRAPHQL_PARSE_FAILED"
+	CodeValidationFailed = "GRAPHQL_VALIDATION_FAILED"
+	CodeBadUserInput     = "BAD_USER_INPUT"
+	CodeInternal         = "INTERNAL_SERVER_ERROR"
+)
+
+// 内置标量类型，JSON为任意JSON值，Time为RFC 3339格式的时间
+var builtinScalars = []string{"Int", "Float", "String", "Boolean", "ID", "JSON", "Time"}
+
+// customScalars 需要在SDL中声明的标量
+var customScalars = []string{"JSON", "Time"}
+
+// Location 错误在查询文档中的位置
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// Error GraphQL错误
+type Error struct {
+	Message    string                 `json:"message"`
+	Locations  []Location             `json:"locations,omitempty"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// NewError 创建带错误码的错误
+func NewError(code, format string, args ...interface{}) *Error {
+	return &Error{
+		Message:    fmt.Sprintf(format, args...),
+		Extensions: map[string]interface{}{"code": code},
+	}
+}
+
+// ResolveFunc 批量解析函数，Sources为同一层所有父对象，返回值与Sources一一对应
+type ResolveFunc func(ctx context.Context, p ResolveParams) ([]interface{}, error)
+
+// ResolveParams 解析函数的参数
+type ResolveParams struct {
+	Sources []interface{}
+	Args    map[string]interface{} // 已按参数类型转换，Int为int，ID为string，列表为[]interface{}，输入对象为map[string]interface{}
+}
+
+// Has 判断请求中是否提供了参数（包括显式的null）
+func (p ResolveParams) Has(name string) bool {
+	_, ok := p.Args[name]
+	return ok
+}
+
+// String 读取字符串或ID参数
+func (p ResolveParams) String(name string) (string, bool) {
+	s, ok := p.Args[name].(string)
+	return s, ok
+}
+
+// Int 读取整数参数
+func (p ResolveParams) Int(name string) (int, bool) {
+	n, ok := p.Args[name].(int)
+	return n, ok
+}
+
+// Bool 读取布尔参数，未提供时返回false
+func (p ResolveParams) Bool(name string) bool {
+	b, _ := p.Args[name].(bool)
+	return b
+}
+
+// Strings 读取字符串或ID列表参数
+func (p ResolveParams) Strings(name string) []string {
+	list, _ := p.Args[name].([]interface{})
+	result := make([]string, 0, len(list))
+	for _, v := range list {
+		if s, ok := v.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// Each 将逐个父对象解析的函数转换为批量解析函数，适用于不需要访问数据库的字段
+func Each(fn func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error)) ResolveFunc {
+	return func(ctx context.Context, p ResolveParams) ([]interface{}, error) {
+		values := make([]interface{}, len(p.Sources))
+		for i, source := range p.Sources {
+			v, err := fn(ctx, source, p.Args)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = v
+		}
+		return values, nil
+	}
+}
+
+// Property 读取父对象属性的解析函数
+func Property(fn func(source interface{}) interface{}) ResolveFunc {
+	return Each(func(_ context.Context, source interface{}, _ map[string]interface{}) (interface{}, error) {
+		return fn(source), nil
+	})
+}
+
+// Object 对象类型
+type Object struct {
+	Name        string
+	Description string
+	Fields      []*FieldDef
+	fields      map[string]*FieldDef
+}
+
+// NewObject 创建对象类型，字段通过AddField添加，以便定义相互引用的类型
+func NewObject(name, description string) *Object {
+	return &Object{Name: name, Description: description, fields: make(map[string]*FieldDef)}
+}
+
+// AddField 添加字段
+func (o *Object) AddField(f *FieldDef) *Object {
+	o.Fields = append(o.Fields, f)
+	o.fields[f.Name] = f
+	return o
+}
+
+// FieldDef 对象的字段定义
+type FieldDef struct {
+	Name        string
+	Description string
+	Type        string // SDL类型，例如"[Product!]!"
+	Args        []*ArgDef
+	Resolve     ResolveFunc
+	// ListSize 每个父对象最多返回的对象数，用于估算查询的节点数；
+	// 列表字段应按分页参数返回上限，未设置时按1计算
+	ListSize func(args map[string]interface{}) int
+
+	typ    *TypeRef
+	object *Object // 返回对象类型时的类型定义，标量字段为nil
+}
+
+// ArgDef 参数或输入对象字段的定义
+type ArgDef struct {
+	Name        string
+	Description string
+	Type        string
+	Default     interface{} // 默认值，nil表示没有默认值
+
+	typ *TypeRef
+}
+
+// InputObject 输入对象类型
+type InputObject struct {
+	Name        string
+	Description string
+	Fields      []*ArgDef
+}
+
+// SchemaConfig Schema定义
+type SchemaConfig struct {
+	Query    *Object
+	Mutation *Object
+	Types    []*Object // 查询和变更之外的对象类型
+	Inputs   []*InputObject
+	MaxNodes int // 一次操作最多可能返回的对象数，为0时使用DefaultMaxNodes
+}
+
+// Schema 可执行的GraphQL Schema
+type Schema struct {
+	query    *Object
+	mutation *Object
+	objects  []*Object
+	inputs   []*InputObject
+	types    map[string]interface{} // 类型名到*Object、*InputObject或标量名的映射
+	maxNodes int
+}
+
+// NewSchema 校验类型引用并创建Schema，定义错误属于程序错误，直接panic
+func NewSchema(cfg SchemaConfig) *Schema {
+	s := &Schema{query: cfg.Query, mutation: cfg.Mutation, inputs: cfg.Inputs, types: make(map[string]interface{}), maxNodes: cfg.MaxNodes}
+	if s.maxNodes <= 0 {
+		s.maxNodes = DefaultMaxNodes
+	}
+	for _, name := range builtinScalars {
+		s.types[name] = name
+	}
+	for _, obj := range append([]*Object{cfg.Query, cfg.Mutation}, cfg.Types...) {
+		if obj == nil {
+			continue
+		}
+		s.register(obj.Name, obj)
+		s.objects = append(s.objects, obj)
+	}
+	for _, in := range cfg.Inputs {
+		s.register(in.Name, in)
+	}
+
+	for _, obj := range s.objects {
+		for _, f := range obj.Fields {
+			f.typ = s.mustType(obj.Name+"."+f.Name, f.Type)
+			switch t := s.types[f.typ.NamedType()].(type) {
+			case *Object:
+				f.object = t
+			case *InputObject:
+				panic(fmt.Sprintf("graphql: %s.%s 不能返回输入类型 %s", obj.Name, f.Name, t.Name))
+			}
+			if f.Resolve == nil {
+				panic(fmt.Sprintf("graphql: %s.%s 缺少解析函数", obj.Name, f.Name))
+			}
+			s.prepareArgs(obj.Name+"."+f.Name, f.Args)
+		}
+	}
+	for _, in := range cfg.Inputs {
+		s.prepareArgs(in.Name, in.Fields)
+	}
+	return s
+}
+
+func (s *Schema) register(name string, t interface{}) {
+	if _, ok := s.types[name]; ok {
+		panic("graphql: 类型重复定义: " + name)
+	}
+	s.types[name] = t
+}
+
+func (s *Schema) mustType(owner, typ string) *TypeRef {
+	t, err := ParseType(typ)
+	if err != nil {
+		panic(fmt.Sprintf("graphql: %s 的类型 %q 无效", owner, typ))
+	}
+	if _, ok := s.types[t.NamedType()]; !ok {
+		panic(fmt.Sprintf("graphql: %s 引用了未定义的类型 %s", owner, t.NamedType()))
+	}
+	return t
+}
+
+// prepareArgs 解析参数类型，参数只能是标量或输入对象
+func (s *Schema) prepareArgs(owner string, args []*ArgDef) {
+	for _, arg := range args {
+		arg.typ = s.mustType(owner+"."+arg.Name, arg.Type)
+		if _, ok := s.types[arg.typ.NamedType()].(*Object); ok {
+			panic(fmt.Sprintf("graphql: %s.%s 的类型不能是对象类型", owner, arg.Name))
+		}
+		if arg.Default != nil {
+			v, err := s.coerce(arg.Default, arg.typ, arg.Name)
+			if err != nil {
+				panic(fmt.Sprintf("graphql: %s.%s 的默认值无效: %v", owner, arg.Name, err))
+			}
+			arg.Default = v
+		}
+	}
+}
+
+// isInputType 判断类型名是否可以用于变量
+func (s *Schema) isInputType(name string) bool {
+	switch s.types[name].(type) {
+	case string, *InputObject:
+		return true
+	}
+	return false
+}
+
+// SDL 以GraphQL Schema定义语言输出Schema
+func (s *Schema) SDL() string {
+	var b strings.Builder
+	for _, name := range customScalars {
+		fmt.Fprintf(&b, "scalar %s\n\n", name)
+	}
+	for _, obj := range s.objects {
+		writeDescription(&b, "", obj.Description)
+		fmt.Fprintf(&b, "type %s {\n", obj.Name)
+		for _, f := range obj.Fields {
+			writeDescription(&b, "  ", f.Description)
+			fmt.Fprintf(&b, "  %s%s: %s\n", f.Name, formatArgs(f.Args), f.Type)
+		}
+		b.WriteString("}\n\n")
+	}
+	for _, in := range s.inputs {
+		writeDescription(&b, "", in.Description)
+		fmt.Fprintf(&b, "input %s {\n", in.Name)
+		for _, f := range in.Fields {
+			writeDescription(&b, "  ", f.Description)
+			fmt.Fprintf(&b, "  %s\n", formatArg(f))
+		}
+		b.WriteString("}\n\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func writeDescription(b *strings.Builder, indent, description string) {
+	if description != "" {
+		fmt.Fprintf(b, "%s%s\n", indent, formatLiteral(description))
+	}
+}
+
+func formatArgs(args []*ArgDef) string {
+	if len(args) == 0 {
+		return ""
+	}
+	parts := make([]string, len(args))
+	for i, arg := range args {
+		parts[i] = formatArg(arg)
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+func formatArg(arg *ArgDef) string {
+	s := arg.Name + ": " + arg.Type
+	if arg.Default != nil {
+		s += " = " + formatLiteral(arg.Default)
+	}
+	return s
+}
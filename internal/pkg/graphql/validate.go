@@ -0,0 +1,241 @@
+package graphql
+
+import (
+	"fmt"
+)
+
+// MaxDepth 选择集的最大嵌套深度，防止构造过深的查询
+const MaxDepth = 10
+
+// DefaultMaxNodes 一次操作默认最多可能返回的对象数。Schema中的类型相互引用，
+// 仅限制深度时每层列表的数量会逐层相乘，需要按字段的ListSize估算总数
+const DefaultMaxNodes = 10000
+
+// Operation 按名称选择要执行的操作，文档只有一个操作时name可以为空
+func (d *Document) Operation(name string) (*Operation, error) {
+	if name == "" {
+		if len(d.Operations) > 1 {
+			return nil, NewError(CodeValidationFailed, "文档包含多个操作，需要指定operationName")
+		}
+		return d.Operations[0], nil
+	}
+	for _, op := range d.Operations {
+		if op.Name == name {
+			return op, nil
+		}
+	}
+	return nil, NewError(CodeValidationFailed, "未找到操作 %s", name)
+}
+
+// validator 执行前检查查询文档与Schema是否一致
+type validator struct {
+	schema *Schema
+	doc    *Document
+	vars   map[string]bool
+	errors []*Error
+}
+
+// Validate 检查操作引用的字段、参数、片段和变量是否有效
+func (s *Schema) Validate(doc *Document, op *Operation) []*Error {
+	v := &validator{schema: s, doc: doc, vars: make(map[string]bool)}
+
+	root, err := s.rootType(op)
+	if err != nil {
+		v.addError(op.Location, "%s", err.Error())
+		return v.errors
+	}
+
+	for _, def := range op.Variables {
+		if v.vars[def.Name] {
+			v.addError(def.Location, "变量 $%s 重复定义", def.Name)
+		}
+		v.vars[def.Name] = true
+		if !s.isInputType(def.Type.NamedType()) {
+			v.addError(def.Location, "变量 $%s 的类型 %s 不是输入类型", def.Name, def.Type)
+		}
+	}
+
+	v.validateSet(root, op.SelectionSet, 1, nil)
+	return v.errors
+}
+
+// rootType 操作对应的根类型
+func (s *Schema) rootType(op *Operation) (*Object, error) {
+	switch op.Type {
+	case "query":
+		return s.query, nil
+	case "mutation":
+		if s.mutation != nil {
+			return s.mutation, nil
+		}
+		return nil, fmt.Errorf("不支持变更操作")
+	default:
+		return nil, fmt.Errorf("不支持%s操作", op.Type)
+	}
+}
+
+func (v *validator) addError(loc Location, format string, args ...interface{}) {
+	err := NewError(CodeValidationFailed, format, args...)
+	err.Locations = []Location{loc}
+	v.errors = append(v.errors, err)
+}
+
+func (v *validator) validateSet(obj *Object, set []Selection, depth int, fragments []string) {
+	if depth > MaxDepth {
+		v.addError(set[0].location(), "查询嵌套超过%d层", MaxDepth)
+		return
+	}
+
+	for _, sel := range set {
+		switch sel := sel.(type) {
+		case *Field:
+			v.validateDirectives(sel.Directives)
+			v.validateField(obj, sel, depth, fragments)
+		case *FragmentSpread:
+			v.validateDirectives(sel.Directives)
+			frag, ok := v.doc.Fragments[sel.Name]
+			if !ok {
+				v.addError(sel.Location, "未定义的片段 %s", sel.Name)
+				continue
+			}
+			if frag.TypeCondition != obj.Name {
+				v.addError(sel.Location, "片段 %s 的类型 %s 不能用于 %s", sel.Name, frag.TypeCondition, obj.Name)
+				continue
+			}
+			if contains(fragments, sel.Name) {
+				v.addError(sel.Location, "片段 %s 循环引用", sel.Name)
+				continue
+			}
+			v.validateSet(obj, frag.SelectionSet, depth, append(fragments, sel.Name))
+		case *InlineFragment:
+			v.validateDirectives(sel.Directives)
+			if sel.TypeCondition != "" && sel.TypeCondition != obj.Name {
+				v.addError(sel.Location, "内联片段的类型 %s 不能用于 %s", sel.TypeCondition, obj.Name)
+				continue
+			}
+			v.validateSet(obj, sel.SelectionSet, depth, fragments)
+		}
+	}
+}
+
+func (v *validator) validateField(obj *Object, field *Field, depth int, fragments []string) {
+	if field.Name == "__typename" {
+		if len(field.Arguments) > 0 || field.SelectionSet != nil {
+			v.addError(field.Location, "__typename 不接受参数和子字段")
+		}
+		return
+	}
+
+	def, ok := obj.fields[field.Name]
+	if !ok {
+		v.addError(field.Location, "类型 %s 没有字段 %s", obj.Name, field.Name)
+		return
+	}
+	v.validateArgs(fmt.Sprintf("%s.%s", obj.Name, field.Name), field.Location, def.Args, field.Arguments)
+
+	switch {
+	case def.object == nil && field.SelectionSet != nil:
+		v.addError(field.Location, "字段 %s 的类型 %s 是标量，不能选择子字段", field.Name, def.Type)
+	case def.object != nil && field.SelectionSet == nil:
+		v.addError(field.Location, "字段 %s 的类型 %s 需要选择子字段", field.Name, def.Type)
+	case def.object != nil:
+		v.validateSet(def.object, field.SelectionSet, depth+1, fragments)
+	}
+}
+
+// validateArgs 检查参数名和必填参数，参数值在执行时按类型转换
+func (v *validator) validateArgs(owner string, loc Location, defs []*ArgDef, args []*Argument) {
+	seen := make(map[string]bool, len(args))
+	for _, arg := range args {
+		if seen[arg.Name] {
+			v.addError(arg.Location, "%s 的参数 %s 重复", owner, arg.Name)
+		}
+		seen[arg.Name] = true
+		if !hasArg(defs, arg.Name) {
+			v.addError(arg.Location, "%s 没有参数 %s", owner, arg.Name)
+		}
+		v.validateVariables(arg.Location, arg.Value)
+	}
+	for _, def := range defs {
+		if def.typ.NonNull && def.Default == nil && !seen[def.Name] {
+			v.addError(loc, "%s 缺少必填参数 %s", owner, def.Name)
+		}
+	}
+}
+
+// validateVariables 检查参数值中引用的变量均已定义
+func (v *validator) validateVariables(loc Location, value interface{}) {
+	switch x := value.(type) {
+	case Variable:
+		if !v.vars[string(x)] {
+			v.addError(loc, "未定义的变量 $%s", string(x))
+		}
+	case []interface{}:
+		for _, elem := range x {
+			v.validateVariables(loc, elem)
+		}
+	case map[string]interface{}:
+		for _, elem := range x {
+			v.validateVariables(loc, elem)
+		}
+	}
+}
+
+func (v *validator) validateDirectives(directives []*Directive) {
+	for _, d := range directives {
+		if d.Name != "skip" && d.Name != "include" {
+			v.addError(d.Location, "不支持的指令 @%s", d.Name)
+			continue
+		}
+		v.validateArgs("@"+d.Name, d.Location, directiveArgs, d.Arguments)
+	}
+}
+
+// directiveArgs @skip和@include的参数
+var directiveArgs = []*ArgDef{{Name: "if", Type: "Boolean!", typ: &TypeRef{Name: "Boolean", NonNull: true}}}
+
+// countNodes 估算选择集最多返回的对象数：每个对象字段的数量为父对象数乘以字段的ListSize，
+// 片段和指令按执行时的规则展开，超过limit后不再继续计算
+func (e *executor) countNodes(obj *Object, groups []*fieldGroup, parents, limit int) int {
+	total := 0
+	for _, g := range groups {
+		field := g.fields[0]
+		def, ok := obj.fields[field.Name]
+		if !ok || def.object == nil {
+			continue
+		}
+
+		size := 1
+		if def.ListSize != nil {
+			// 参数无效时由解析阶段报告错误，这里按0计算
+			size = 0
+			if args, err := e.schema.coerceArgs(def.Args, field.Arguments, e.vars); err == nil {
+				size = max(0, min(def.ListSize(args), limit+1))
+			}
+		}
+		n := parents * size
+		total += n
+		if total > limit {
+			return total
+		}
+
+		var subSet []Selection
+		for _, f := range g.fields {
+			subSet = append(subSet, f.SelectionSet...)
+		}
+		total += e.countNodes(def.object, e.collectFields(def.object, subSet, nil, nil, nil), n, limit-total)
+		if total > limit {
+			return total
+		}
+	}
+	return total
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package mergepatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"sort"
+)
+
+// ErrNotObject 补丁不是JSON对象
+var ErrNotObject = errors.New("合并补丁必须是JSON对象")
+
+// Apply 将JSON合并补丁（RFC 7396）应用到文档，返回合并后的文档。
+// 补丁中值为null的字段从文档中删除，对象递归合并，其他值直接替换
+func Apply(doc, patch []byte) ([]byte, error) {
+	target, err := decode(doc)
+	if err != nil {
+		return nil, err
+	}
+	p, err := decode(patch)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(merge(target, p))
+}
+
+// Keys 返回补丁顶层对象中的字段名，按字母排序
+func Keys(patch []byte) ([]string, error) {
+	p, err := decode(patch)
+	if err != nil {
+		return nil, err
+	}
+	obj, ok := p.(map[string]interface{})
+	if !ok {
+		return nil, ErrNotObject
+	}
+
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// merge RFC 7396中的MergePatch算法
+func merge(target, patch interface{}) interface{} {
+	patchObj, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObj, ok := target.(map[string]interface{})
+	if !ok {
+		targetObj = make(map[string]interface{}, len(patchObj))
+	}
+	for k, v := range patchObj {
+		if v == nil {
+			delete(targetObj, k)
+			continue
+		}
+		targetObj[k] = merge(targetObj[k], v)
+	}
+	return targetObj
+}
+
+// decode 解析JSON，数字保留原始文本以免精度丢失
+func decode(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
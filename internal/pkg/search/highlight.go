@@ -0,0 +1,134 @@
+package search
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// 高亮标记
+const (
+	HighlightPre  = "<em>"
+	HighlightPost = "</em>"
+)
+
+// DefaultSnippetLength 默认摘要长度（字符数）
+const DefaultSnippetLength = 80
+
+// span 匹配区间，以rune为单位
+type span struct {
+	start, end int
+}
+
+// findSpans 查找所有查询词在文本中的出现位置（大小写不敏感），结果按起始位置排序且互不重叠
+func findSpans(text []rune, terms []Term) []span {
+	lower := make([]rune, len(text))
+	for i, r := range text {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	marked := make([]bool, len(text))
+	for _, t := range terms {
+		needle := []rune(strings.ToLower(t.Text))
+		if len(needle) == 0 || len(needle) > len(lower) {
+			continue
+		}
+		for i := 0; i+len(needle) <= len(lower); i++ {
+			if runesEqual(lower[i:i+len(needle)], needle) {
+				for j := i; j < i+len(needle); j++ {
+					marked[j] = true
+				}
+			}
+		}
+	}
+
+	var spans []span
+	for i := 0; i < len(marked); i++ {
+		if !marked[i] {
+			continue
+		}
+		start := i
+		for i < len(marked) && marked[i] {
+			i++
+		}
+		spans = append(spans, span{start: start, end: i})
+	}
+	return spans
+}
+
+// runesEqual 比较两个rune切片是否相等
+func runesEqual(a, b []rune) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Count 统计查询词在文本中的出现次数（大小写不敏感）
+func Count(text string, term Term) int {
+	if term.Text == "" {
+		return 0
+	}
+	return strings.Count(strings.ToLower(text), strings.ToLower(term.Text))
+}
+
+// Highlight 生成带高亮标记的摘要
+// 摘要以第一个命中位置为中心截取maxRunes个字符，被截断的一端以省略号表示；
+// 文本中的HTML特殊字符会被转义，以便前端直接渲染高亮标记。未命中时返回false
+func Highlight(text string, terms []Term, maxRunes int) (string, bool) {
+	runes := []rune(text)
+	spans := findSpans(runes, terms)
+	if len(spans) == 0 {
+		return "", false
+	}
+
+	if maxRunes <= 0 {
+		maxRunes = DefaultSnippetLength
+	}
+
+	// 计算摘要窗口
+	start, end := 0, len(runes)
+	if len(runes) > maxRunes {
+		first := spans[0]
+		start = first.start - (maxRunes-(first.end-first.start))/2
+		if start < 0 {
+			start = 0
+		}
+		end = start + maxRunes
+		if end > len(runes) {
+			end = len(runes)
+			start = end - maxRunes
+		}
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	pos := start
+	for _, s := range spans {
+		if s.end <= start || s.start >= end {
+			continue
+		}
+		from, to := s.start, s.end
+		if from < start {
+			from = start
+		}
+		if to > end {
+			to = end
+		}
+		b.WriteString(html.EscapeString(string(runes[pos:from])))
+		b.WriteString(HighlightPre)
+		b.WriteString(html.EscapeString(string(runes[from:to])))
+		b.WriteString(HighlightPost)
+		pos = to
+	}
+	b.WriteString(html.EscapeString(string(runes[pos:end])))
+	if end < len(runes) {
+		b.WriteString("…")
+	}
+
+	return b.String(), true
+}
@@ -0,0 +1,66 @@
+package search
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHighlight(t *testing.T) {
+	long := strings.Repeat("甲", 20) + "加密" + strings.Repeat("乙", 20)
+	tests := []struct {
+		name     string
+		text     string
+		terms    []string
+		maxRunes int
+		want     string
+	}{
+		{"未截断", "存储桶加密", []string{"加密"}, 0, "存储桶<em>加密</em>"},
+		{"大小写不敏感且保留原文", "启用TLS与tls", []string{"Tls"}, 0, "启用<em>TLS</em>与<em>tls</em>"},
+		{"多字节文本以命中位置为中心截取", long, []string{"加密"}, 10, "…甲甲甲甲<em>加密</em>乙乙乙乙…"},
+		{"命中位置靠近开头", "加密" + strings.Repeat("乙", 20), []string{"加密"}, 6, "<em>加密</em>乙乙乙乙…"},
+		{"命中位置靠近结尾", strings.Repeat("甲", 20) + "加密", []string{"加密"}, 6, "…甲甲甲甲<em>加密</em>"},
+		{"重叠的命中合并", "存储桶", []string{"存储", "储桶"}, 0, "<em>存储桶</em>"},
+		{"相邻的命中合并", "加密加密", []string{"加密"}, 0, "<em>加密加密</em>"},
+		{"包含关系的命中合并", "服务端加密", []string{"加密", "服务端加密"}, 0, "<em>服务端加密</em>"},
+		{"窗口外的命中不标记", "加密" + strings.Repeat("乙", 20) + "加密", []string{"加密"}, 4, "<em>加密</em>乙乙…"},
+		{"跨越窗口边界的命中截断", strings.Repeat("乙", 10) + "加密", []string{"乙加"}, 4, "…乙<em>乙加</em>密"},
+		{"转义HTML", "<script>加密&", []string{"加密"}, 0, "&lt;script&gt;<em>加密</em>&amp;"},
+		{"转义命中的HTML", "a<b>c", []string{"<b>"}, 0, "a<em>&lt;b&gt;</em>c"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var terms []Term
+			for _, text := range tt.terms {
+				terms = append(terms, Term{Text: text})
+			}
+			got, ok := Highlight(tt.text, terms, tt.maxRunes)
+			if !ok || got != tt.want {
+				t.Fatalf("Highlight返回%q, %v，期望%q", got, ok, tt.want)
+			}
+		})
+	}
+
+	for _, terms := range [][]Term{nil, {{Text: ""}}, {{Text: "日志"}}, {{Text: "很长的查询词超过文本"}}} {
+		if got, ok := Highlight("加密", terms, 0); ok {
+			t.Errorf("查询词%+v未命中时返回%q", terms, got)
+		}
+	}
+}
+
+func TestCount(t *testing.T) {
+	tests := []struct {
+		text, term string
+		want       int
+	}{
+		{"加密加密", "加密", 2},
+		{"AES aes Aes", "aes", 3},
+		{"aaa", "aa", 1}, // 与SQL实现的REPLACE一致，不计重叠出现
+		{"加密", "", 0},
+		{"", "加密", 0},
+	}
+	for _, tt := range tests {
+		if got := Count(tt.text, Term{Text: tt.term}); got != tt.want {
+			t.Errorf("Count(%q, %q)返回%d，期望%d", tt.text, tt.term, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Field 可检索字段
+type Field string
+
+// 支持的字段作用域，查询中以"字段:词"的形式使用，例如 risk:加密
+const (
+	FieldAll                 Field = ""
+	FieldName                Field = "name"
+	FieldRecommendedValue    Field = "value"
+	FieldRiskDescription     Field = "risk"
+	FieldCheckMethod         Field = "check"
+	FieldConfigurationMethod Field = "config"
+	FieldReference           Field = "ref"
+	FieldProvider            Field = "provider"
+	FieldProduct             Field = "product"
+)
+
+// ContentFields 配置项自身的文本字段，按权重从高到低排列
+var ContentFields = []Field{
+	FieldName,
+	FieldRecommendedValue,
+	FieldRiskDescription,
+	FieldCheckMethod,
+	FieldConfigurationMethod,
+	FieldReference,
+}
+
+// AllFields 所有可检索字段
+var AllFields = append(append([]Field{}, ContentFields...), FieldProvider, FieldProduct)
+
+// fieldAliases 字段别名，允许使用数据库列名作为作用域
+var fieldAliases = map[string]Field{
+	"name":                 FieldName,
+	"value":                FieldRecommendedValue,
+	"recommended_value":    FieldRecommendedValue,
+	"risk":                 FieldRiskDescription,
+	"risk_description":     FieldRiskDescription,
+	"check":                FieldCheckMethod,
+	"check_method":         FieldCheckMethod,
+	"config":               FieldConfigurationMethod,
+	"configuration_method": FieldConfigurationMethod,
+	"ref":                  FieldReference,
+	"reference":            FieldReference,
+	"provider":             FieldProvider,
+	"product":              FieldProduct,
+}
+
+// Column 返回字段对应的数据库列名，服务商和产品字段返回关联表的名称列
+func (f Field) Column() string {
+	switch f {
+	case FieldName:
+		return "configuration_items.name"
+	case FieldRecommendedValue:
+		return "configuration_items.recommended_value"
+	case FieldRiskDescription:
+		return "configuration_items.risk_description"
+	case FieldCheckMethod:
+		return "configuration_items.check_method"
+	case FieldConfigurationMethod:
+		return "configuration_items.configuration_method"
+	case FieldReference:
+		return "configuration_items.reference"
+	case FieldProvider:
+		return "cloud_providers.name"
+	case FieldProduct:
+		return "cloud_products.name"
+	}
+	return ""
+}
+
+// Weight 返回字段在相关度计算中的权重
+func (f Field) Weight() float64 {
+	switch f {
+	case FieldName:
+		return 3
+	case FieldRecommendedValue, FieldProvider, FieldProduct:
+		return 2
+	}
+	return 1
+}
+
+// Term 查询词
+type Term struct {
+	Text    string `json:"text"`
+	Field   Field  `json:"field,omitempty"`
+	Phrase  bool   `json:"phrase,omitempty"`
+	Negated bool   `json:"negated,omitempty"` // 排除命中该词的文档
+}
+
+// Fields 返回查询词检索的字段，未限定字段时为全部字段
+func (t Term) Fields() []Field {
+	if t.Field == FieldAll {
+		return AllFields
+	}
+	return []Field{t.Field}
+}
+
+// Query 解析后的查询
+type Query struct {
+	Raw   string `json:"raw"`
+	Terms []Term `json:"terms"`
+}
+
+// Empty 判断查询是否为空
+func (q Query) Empty() bool {
+	return len(q.Terms) == 0
+}
+
+// Unscoped 返回未限定字段的查询词
+func (q Query) Unscoped() []Term {
+	var terms []Term
+	for _, t := range q.Terms {
+		if t.Field == FieldAll {
+			terms = append(terms, t)
+		}
+	}
+	return terms
+}
+
+// Scoped 返回限定了字段的查询词
+func (q Query) Scoped() []Term {
+	var terms []Term
+	for _, t := range q.Terms {
+		if t.Field != FieldAll {
+			terms = append(terms, t)
+		}
+	}
+	return terms
+}
+
+// TermsFor 返回会命中指定字段的查询词（包括未限定字段的词），不包括排除词
+func (q Query) TermsFor(field Field) []Term {
+	var terms []Term
+	for _, t := range q.Terms {
+		if !t.Negated && (t.Field == FieldAll || t.Field == field) {
+			terms = append(terms, t)
+		}
+	}
+	return terms
+}
+
+// Parse 解析查询字符串
+// 支持的语法：
+//   - 普通词：多个词之间为AND关系，例如 存储 加密
+//   - 短语："公共 访问"，要求原样连续出现
+//   - 字段作用域：risk:加密、name:"安全组 规则"
+//   - 排除：-加密、-risk:"公共 访问"，排除命中该词的文档
+//
+// 未知的字段前缀（例如URL中的 https:）按普通词处理，单独的"-"和词中间的"-"（例如TLS-1.2）不表示排除
+func Parse(raw string) Query {
+	q := Query{Raw: raw}
+	runes := []rune(strings.TrimSpace(raw))
+
+	for i := 0; i < len(runes); {
+		if unicode.IsSpace(runes[i]) {
+			i++
+			continue
+		}
+
+		// 读取排除标记
+		negated := false
+		if runes[i] == '-' && i+1 < len(runes) && !unicode.IsSpace(runes[i+1]) {
+			negated = true
+			i++
+		}
+
+		// 读取可能的字段前缀
+		field := FieldAll
+		start := i
+		for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != ':' && runes[i] != '"' {
+			i++
+		}
+		if i < len(runes) && runes[i] == ':' {
+			if f, ok := fieldAliases[strings.ToLower(string(runes[start:i]))]; ok {
+				field = f
+				i++
+			} else {
+				i = start
+			}
+		} else {
+			i = start
+		}
+
+		// 读取短语或普通词
+		if i < len(runes) && runes[i] == '"' {
+			i++
+			phraseStart := i
+			for i < len(runes) && runes[i] != '"' {
+				i++
+			}
+			text := strings.Join(strings.Fields(string(runes[phraseStart:i])), " ")
+			if i < len(runes) {
+				i++ // 跳过结束引号
+			}
+			if text != "" {
+				q.Terms = append(q.Terms, Term{Text: text, Field: field, Phrase: true, Negated: negated})
+			}
+			continue
+		}
+
+		wordStart := i
+		for i < len(runes) && !unicode.IsSpace(runes[i]) {
+			i++
+		}
+		if text := string(runes[wordStart:i]); text != "" {
+			q.Terms = append(q.Terms, Term{Text: text, Field: field, Negated: negated})
+		}
+	}
+
+	return q
+}
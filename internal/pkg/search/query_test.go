@@ -0,0 +1,62 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want []Term
+	}{
+		{"空查询", "", nil},
+		{"只有空白", " \t\n", nil},
+		{"空短语", `""`, nil},
+		{"作用域后没有词", "risk:", nil},
+		{"多个词", "存储  加密", []Term{{Text: "存储"}, {Text: "加密"}}},
+		{"短语合并空白", `"公共   访问"`, []Term{{Text: "公共 访问", Phrase: true}}},
+		{"未闭合的短语", `"公共 访问`, []Term{{Text: "公共 访问", Phrase: true}}},
+		{"短语后紧跟词", `"公共 访问"加密`, []Term{{Text: "公共 访问", Phrase: true}, {Text: "加密"}}},
+		{"字段作用域", "risk:加密", []Term{{Text: "加密", Field: FieldRiskDescription}}},
+		{"字段别名不区分大小写", "RISK_DESCRIPTION:加密", []Term{{Text: "加密", Field: FieldRiskDescription}}},
+		{"字段作用域短语", `name:"安全组 规则"`, []Term{{Text: "安全组 规则", Field: FieldName, Phrase: true}}},
+		{"未知前缀按普通词处理", "https://example.com/a", []Term{{Text: "https://example.com/a"}}},
+		{"排除词", "存储 -加密", []Term{{Text: "存储"}, {Text: "加密", Negated: true}}},
+		{"排除字段作用域短语", `-risk:"公共 访问"`, []Term{{Text: "公共 访问", Field: FieldRiskDescription, Phrase: true, Negated: true}}},
+		{"单独的减号", "存储 - 加密", []Term{{Text: "存储"}, {Text: "-"}, {Text: "加密"}}},
+		{"词中间的减号", "TLS-1.2", []Term{{Text: "TLS-1.2"}}},
+		{"排除以减号开头的词", "--force", []Term{{Text: "-force", Negated: true}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := Parse(tt.raw)
+			if q.Raw != tt.raw {
+				t.Fatalf("Raw为%q，期望%q", q.Raw, tt.raw)
+			}
+			if !reflect.DeepEqual(q.Terms, tt.want) {
+				t.Fatalf("解析结果为%+v，期望%+v", q.Terms, tt.want)
+			}
+			if q.Empty() != (len(tt.want) == 0) {
+				t.Fatalf("Empty()返回%v", q.Empty())
+			}
+		})
+	}
+}
+
+func TestQueryTermsFor(t *testing.T) {
+	q := Parse(`存储 risk:加密 -name:日志 name:桶`)
+	if got := q.TermsFor(FieldName); len(got) != 2 || got[0].Text != "存储" || got[1].Text != "桶" {
+		t.Fatalf("name字段的查询词为%+v，期望不包括排除词", got)
+	}
+	if got := q.TermsFor(FieldReference); len(got) != 1 || got[0].Text != "存储" {
+		t.Fatalf("ref字段的查询词为%+v", got)
+	}
+	if got := q.Scoped(); len(got) != 3 {
+		t.Fatalf("限定字段的查询词为%+v", got)
+	}
+	if got := q.Unscoped(); len(got) != 1 {
+		t.Fatalf("未限定字段的查询词为%+v", got)
+	}
+}
@@ -0,0 +1,44 @@
+package search
+
+// Document 待检索文档，按字段保存文本
+type Document map[Field]string
+
+// Score 计算文档与查询的相关度
+// 所有查询词均需命中（未限定字段的词命中任一字段即可），且不能命中排除词，否则返回false；
+// 相关度为各词在各字段中出现次数乘以字段权重之和，排除词不计入。
+// 数据库实现按相同的公式在SQL中计算相关度，修改时需同步修改search_repository.go
+func Score(doc Document, q Query) (float64, bool) {
+	if q.Empty() {
+		return 0, true
+	}
+
+	var score float64
+	for _, t := range q.Terms {
+		matched := false
+		var termScore float64
+		for _, f := range t.Fields() {
+			if n := Count(doc[f], t); n > 0 {
+				matched = true
+				termScore += float64(n) * f.Weight()
+			}
+		}
+		if matched == t.Negated {
+			return 0, false
+		}
+		if !t.Negated {
+			score += termScore
+		}
+	}
+	return score, true
+}
+
+// Highlights 为文档中命中查询的字段生成高亮摘要
+func Highlights(doc Document, q Query, maxRunes int) map[string]string {
+	highlights := make(map[string]string)
+	for _, f := range AllFields {
+		if snippet, ok := Highlight(doc[f], q.TermsFor(f), maxRunes); ok {
+			highlights[string(f)] = snippet
+		}
+	}
+	return highlights
+}
@@ -0,0 +1,62 @@
+package search
+
+import "testing"
+
+func TestScore(t *testing.T) {
+	doc := Document{
+		FieldName:             "S3存储桶加密",
+		FieldRecommendedValue: "启用SSE-KMS加密，加密密钥定期轮换",
+		FieldRiskDescription:  "未加密的数据可能泄露",
+		FieldReference:        "https://docs.aws.amazon.com/AmazonS3/latest/userguide/UsingKMSEncryption.html",
+		FieldProvider:         "Amazon Web Services",
+		FieldProduct:          "S3",
+	}
+	tests := []struct {
+		name  string
+		raw   string
+		score float64
+		ok    bool
+	}{
+		{"空查询命中全部文档", "", 0, true},
+		// 名称3 + 推荐配置值2*2 + 风险说明1
+		{"按字段权重累加出现次数", "加密", 8, true},
+		{"字段作用域", "name:加密", 3, true},
+		{"字段作用域未命中", "check:加密", 0, false},
+		{"大小写不敏感", "sse-kms", 2, true},
+		// 名称3 + 参考资料1 + 产品2
+		{"服务商和产品名称", "s3", 6, true},
+		{"所有词都需命中", "加密 日志", 0, false},
+		{"多个词相加", "加密 轮换", 10, true},
+		{"短语", `"定期 轮换"`, 0, false},
+		{"排除词命中时不返回", "加密 -泄露", 0, false},
+		{"排除词不计入相关度", "加密 -日志", 8, true},
+		{"排除词只检查限定的字段", "加密 -name:泄露", 8, true},
+		{"只有排除词", "-日志", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, ok := Score(doc, Parse(tt.raw))
+			if score != tt.score || ok != tt.ok {
+				t.Fatalf("Score返回%v, %v，期望%v, %v", score, ok, tt.score, tt.ok)
+			}
+		})
+	}
+}
+
+func TestHighlights(t *testing.T) {
+	doc := Document{FieldName: "S3存储桶加密", FieldRiskDescription: "数据泄露", FieldProduct: "S3"}
+	got := Highlights(doc, Parse("s3 risk:泄露 -加密"), 0)
+	want := map[string]string{
+		"name":    "<em>S3</em>存储桶加密",
+		"risk":    "数据<em>泄露</em>",
+		"product": "<em>S3</em>",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("高亮字段为%v，期望%v", got, want)
+	}
+	for field, snippet := range want {
+		if got[field] != snippet {
+			t.Errorf("%s字段的高亮为%q，期望%q", field, got[field], snippet)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+// Package webhook 出站Webhook的签名、发送和重试间隔计算，投递记录的持久化由服务层负责
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 请求头
+const (
+	HeaderEvent     = "X-CloudEye-Event"     // 事件类型，例如config_item.updated
+	HeaderDelivery  = "X-CloudEye-Delivery"  // 投递记录ID，重新投递时不同
+	HeaderEventID   = "X-CloudEye-Event-Id"  // 事件ID，同一事件的所有投递相同，可用于去重
+	HeaderTimestamp = "X-CloudEye-Timestamp" // 签名时间，Unix秒
+	HeaderSignature = "X-CloudEye-Signature" // sha256=HMAC-SHA256(secret, timestamp + "." + body)的十六进制
+)
+
+// UserAgent 投递请求的User-Agent
+const UserAgent = "CloudEye-Webhook/1.0"
+
+// maxResponseBody 投递记录中保存的响应体长度上限（字节）
+const maxResponseBody = 2048
+
+// Sign 计算请求签名，签名内容为"时间戳.请求体"
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify 校验请求签名，供接收方使用；tolerance大于0时拒绝时间戳偏差超过该值的请求以防止重放
+func Verify(secret, timestamp string, body []byte, signature string, tolerance time.Duration) bool {
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+	if tolerance > 0 {
+		diff := time.Since(time.Unix(ts, 0))
+		if diff > tolerance || diff < -tolerance {
+			return false
+		}
+	}
+	return hmac.Equal([]byte(Sign(secret, ts, body)), []byte(signature))
+}
+
+// GenerateSecret 生成随机签名密钥
+func GenerateSecret() (string, error) {
+	return randomHex(32)
+}
+
+// NewEventID 生成事件ID
+func NewEventID() (string, error) {
+	id, err := randomHex(16)
+	if err != nil {
+		return "", err
+	}
+	return "evt_" + id, nil
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Backoff 返回第attempt次投递失败后到下一次投递的间隔：base * 2^(attempt-1)，不超过max
+func Backoff(attempt int, base, max time.Duration) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	d := base
+	for i := 1; i < attempt; i++ {
+		d *= 2
+		if d >= max {
+			return max
+		}
+	}
+	if d > max {
+		return max
+	}
+	return d
+}
+
+// Request 一次投递请求
+type Request struct {
+	URL        string
+	Secret     string
+	Event      string
+	EventID    string
+	DeliveryID uint
+	Body       []byte
+}
+
+// Result 一次投递的结果，响应状态码为2xx时视为成功
+type Result struct {
+	StatusCode int
+	Body       string // 响应体，最多保留2048字节，不含无效的UTF-8字节
+	Duration   time.Duration
+	Err        error // 网络错误或非2xx响应
+}
+
+// Sender 发送投递请求
+type Sender struct {
+	Client *http.Client
+}
+
+// NewSender 创建发送器，timeout为单次请求超时时间
+func NewSender(client *http.Client, timeout time.Duration) *Sender {
+	if client == nil {
+		client = &http.Client{Timeout: timeout}
+	}
+	return &Sender{Client: client}
+}
+
+// Send 签名并发送请求
+func (s *Sender) Send(ctx context.Context, req Request) Result {
+	start := time.Now()
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		return Result{Err: err}
+	}
+
+	timestamp := time.Now().Unix()
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", UserAgent)
+	httpReq.Header.Set(HeaderEvent, req.Event)
+	httpReq.Header.Set(HeaderEventID, req.EventID)
+	httpReq.Header.Set(HeaderDelivery, strconv.FormatUint(uint64(req.DeliveryID), 10))
+	httpReq.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	httpReq.Header.Set(HeaderSignature, Sign(req.Secret, timestamp, req.Body))
+
+	resp, err := s.Client.Do(httpReq)
+	if err != nil {
+		return Result{Duration: time.Since(start), Err: err}
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	result := Result{
+		StatusCode: resp.StatusCode,
+		Body:       responseText(body),
+		Duration:   time.Since(start),
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		result.Err = fmt.Errorf("接收方返回状态码%d", resp.StatusCode)
+	}
+	return result
+}
+
+// responseText 将截断的响应体转换为可保存到数据库的文本，删除截断处不完整的字符和其他无效的UTF-8字节
+func responseText(body []byte) string {
+	return strings.ToValidUTF8(string(body), "")
+}
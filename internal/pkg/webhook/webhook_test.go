@@ -0,0 +1,154 @@
+package webhook_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/yourusername/cloud-eye/internal/pkg/webhook"
+)
+
+const testSecret = "secret-0123456789"
+
+func TestSign(t *testing.T) {
+	// 期望值由 printf '<时间戳>.<请求体>' | openssl dgst -sha256 -hmac '<密钥>' 计算
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp int64
+		body      string
+		want      string
+	}{
+		{"请求体", testSecret, 1700000000, `{"id":"evt_1"}`, "sha256=b1accefc074165b2a52f71ac1c5251fbef7220d7c57cb8501c12cb6323c81020"},
+		{"空请求体", testSecret, 1700000000, "", "sha256=3518214f17ea86534c06c82486b778634be1115d76b837c3e816a514b4fbb608"},
+		{"时间戳参与签名", testSecret, 1700000001, `{"id":"evt_1"}`, "sha256=b64f543a4fdfab5bc7f9c78dd8610fd2d37684a05c1bd83513a3845408f1485d"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := webhook.Sign(tt.secret, tt.timestamp, []byte(tt.body)); got != tt.want {
+				t.Fatalf("签名为%s，期望%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"id":"evt_1"}`)
+	now := time.Now().Unix()
+	sign := func(ts int64) string { return webhook.Sign(testSecret, ts, body) }
+
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		body      []byte
+		signature string
+		tolerance time.Duration
+		want      bool
+	}{
+		{"有效签名", testSecret, strconv.FormatInt(now, 10), body, sign(now), 5 * time.Minute, true},
+		{"密钥不同", "another-secret-0000", strconv.FormatInt(now, 10), body, sign(now), 5 * time.Minute, false},
+		{"请求体被修改", testSecret, strconv.FormatInt(now, 10), []byte(`{"id":"evt_2"}`), sign(now), 5 * time.Minute, false},
+		{"时间戳被修改", testSecret, strconv.FormatInt(now+1, 10), body, sign(now), 5 * time.Minute, false},
+		{"签名缺少前缀", testSecret, strconv.FormatInt(now, 10), body, strings.TrimPrefix(sign(now), "sha256="), 5 * time.Minute, false},
+		{"无效时间戳", testSecret, "abc", body, sign(now), 5 * time.Minute, false},
+		{"窗口内的旧请求", testSecret, strconv.FormatInt(now-240, 10), body, sign(now - 240), 5 * time.Minute, true},
+		{"超出窗口的旧请求被视为重放", testSecret, strconv.FormatInt(now-600, 10), body, sign(now - 600), 5 * time.Minute, false},
+		{"超出窗口的未来请求", testSecret, strconv.FormatInt(now+600, 10), body, sign(now + 600), 5 * time.Minute, false},
+		{"不检查时间窗口", testSecret, strconv.FormatInt(now-86400, 10), body, sign(now - 86400), 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := webhook.Verify(tt.secret, tt.timestamp, tt.body, tt.signature, tt.tolerance); got != tt.want {
+				t.Fatalf("Verify返回%v，期望%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	base, max := 30*time.Second, time.Hour
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{0, 30 * time.Second},
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{4, 4 * time.Minute},
+		{5, 8 * time.Minute},
+		{7, 32 * time.Minute},
+		{8, time.Hour},
+		{100, time.Hour},
+	}
+	for _, tt := range tests {
+		if got := webhook.Backoff(tt.attempt, base, max); got != tt.want {
+			t.Errorf("第%d次失败后的间隔为%v，期望%v", tt.attempt, got, tt.want)
+		}
+	}
+
+	if got := webhook.Backoff(1, 2*time.Hour, max); got != max {
+		t.Errorf("首次间隔超过上限时为%v，期望%v", got, max)
+	}
+}
+
+func TestSendSignsRequest(t *testing.T) {
+	body := []byte(`{"id":"evt_1"}`)
+	var verified bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		verified = webhook.Verify(testSecret, r.Header.Get(webhook.HeaderTimestamp), body,
+			r.Header.Get(webhook.HeaderSignature), time.Minute) &&
+			r.Header.Get(webhook.HeaderEventID) == "evt_1" && r.Header.Get(webhook.HeaderDelivery) == "7"
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	result := webhook.NewSender(nil, time.Second).Send(context.Background(), webhook.Request{
+		URL: server.URL, Secret: testSecret, Event: "config_item.created", EventID: "evt_1", DeliveryID: 7, Body: body,
+	})
+	if !verified {
+		t.Fatal("接收方校验签名或请求头失败")
+	}
+	if result.StatusCode != http.StatusServiceUnavailable || result.Err == nil {
+		t.Fatalf("非2xx响应的结果为%+v，期望返回错误", result)
+	}
+}
+
+func TestSendTruncatesResponseOnRuneBoundary(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		// 2047字节的ASCII后是3字节的汉字，在2048字节处截断时该字符不完整
+		{"截断多字节字符", strings.Repeat("a", 2047) + "错误详情", strings.Repeat("a", 2047)},
+		{"截断4字节字符", strings.Repeat("a", 2046) + "😀", strings.Repeat("a", 2046)},
+		{"未超过上限", "服务暂时不可用", "服务暂时不可用"},
+		{"删除无效字节", "ok\xff\xfe完成", "ok完成"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			result := webhook.NewSender(nil, time.Second).Send(context.Background(), webhook.Request{
+				URL: server.URL, Secret: testSecret, Body: []byte("{}"),
+			})
+			if !utf8.ValidString(result.Body) {
+				t.Fatalf("响应体不是有效的UTF-8: %q", result.Body[len(result.Body)-4:])
+			}
+			if result.Body != tt.want {
+				t.Fatalf("响应体为%d字节，期望%d字节", len(result.Body), len(tt.want))
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/yourusername/cloud-eye/internal/models"
+	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// ChangeEventFilter 变更事件过滤条件
+type ChangeEventFilter struct {
+	CloudProviderID uint     `json:"cloud_provider_id,omitempty"`
+	ProductID       uint     `json:"product_id,omitempty"`
+	EntityTypes     []string `json:"entity_types,omitempty"`
+}
+
+// Match 判断事件是否满足过滤条件；导入完成事件不属于单个云服务商或云产品，不受这两个条件限制
+func (f ChangeEventFilter) Match(event *models.ChangeEvent) bool {
+	if len(f.EntityTypes) > 0 && !containsString(f.EntityTypes, event.EntityType) {
+		return false
+	}
+	if event.Event == models.EventImportCompleted {
+		return true
+	}
+	if f.CloudProviderID != 0 && event.CloudProviderID != f.CloudProviderID {
+		return false
+	}
+	if f.ProductID != 0 && event.ProductID != f.ProductID {
+		return false
+	}
+	return true
+}
+
+// ChangeEventRepository 变更事件仓库接口
+type ChangeEventRepository interface {
+	Repository
+	// Create 保存事件并分配事件序号
+	Create(ctx context.Context, event *models.ChangeEvent) error
+	// ListAfter 按序号升序获取afterID之后满足条件的事件
+	ListAfter(ctx context.Context, afterID uint, filter ChangeEventFilter, limit int) ([]models.ChangeEvent, error)
+	// Bounds 获取保留的最早和最新事件序号，没有事件时均为0
+	Bounds(ctx context.Context) (first, last uint, err error)
+	// DeleteBefore 删除早于before的事件
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// changeEventRepository 变更事件仓库实现
+type changeEventRepository struct {
+	BaseRepository
+}
+
+// NewChangeEventRepository 创建变更事件仓库
+func NewChangeEventRepository(db *gorm.DB) ChangeEventRepository {
+	return &changeEventRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// Create 保存事件
+func (r *changeEventRepository) Create(ctx context.Context, event *models.ChangeEvent) error {
+	err := r.DB.WithContext(ctx).Create(event).Error
+	if err != nil {
+		logger.Error("Failed to create change event", err)
+		return err
+	}
+	return nil
+}
+
+// ListAfter 按序号升序获取afterID之后的事件
+func (r *changeEventRepository) ListAfter(ctx context.Context, afterID uint, filter ChangeEventFilter, limit int) ([]models.ChangeEvent, error) {
+	query := r.DB.WithContext(ctx).Where("id > ?", afterID)
+	if len(filter.EntityTypes) > 0 {
+		query = query.Where("entity_type IN ?", filter.EntityTypes)
+	}
+	if filter.CloudProviderID != 0 {
+		query = query.Where("(cloud_provider_id = ? OR event = ?)", filter.CloudProviderID, models.EventImportCompleted)
+	}
+	if filter.ProductID != 0 {
+		query = query.Where("(product_id = ? OR event = ?)", filter.ProductID, models.EventImportCompleted)
+	}
+
+	var events []models.ChangeEvent
+	err := query.Order("id").Limit(limit).Find(&events).Error
+	if err != nil {
+		logger.Error("Failed to list change events", err)
+		return nil, err
+	}
+	return events, nil
+}
+
+// Bounds 获取保留的最早和最新事件序号
+func (r *changeEventRepository) Bounds(ctx context.Context) (uint, uint, error) {
+	var bounds struct {
+		First uint
+		Last  uint
+	}
+	err := r.DB.WithContext(ctx).Model(&models.ChangeEvent{}).
+		Select("COALESCE(MIN(id), 0) AS first, COALESCE(MAX(id), 0) AS last").
+		Scan(&bounds).Error
+	if err != nil {
+		logger.Error("Failed to get change event bounds", err)
+		return 0, 0, err
+	}
+	return bounds.First, bounds.Last, nil
+}
+
+// DeleteBefore 删除早于before的事件
+func (r *changeEventRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := r.DB.WithContext(ctx).Where("created_at < ?", before).Delete(&models.ChangeEvent{})
+	if result.Error != nil {
+		logger.Error("Failed to delete change events", result.Error)
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
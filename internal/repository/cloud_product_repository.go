@@ -9,17 +9,36 @@ import (
 	"gorm.io/gorm"
 )
 
+// CloudProductFilter 云产品列表查询过滤条件
+type CloudProductFilter struct {
+	IDs              []uint   `json:"ids,omitempty"`                // 多个产品ID，任一匹配即可
+	CloudProviderIDs []uint   `json:"cloud_provider_ids,omitempty"` // 多个云服务商，任一匹配即可
+	Codes            []string `json:"codes,omitempty"`              // 多个产品代码，任一匹配即可
+	CategoryIDs      []uint   `json:"category_ids,omitempty"`       // 多个类别，包含其子类别
+	Keyword          *string  `json:"keyword,omitempty"`            // 在名称、代码和描述中模糊匹配
+	// PerProviderLimit 每个云服务商最多返回的产品数，按ID取前N个，0表示不限制；用于一次加载多个服务商的产品
+	PerProviderLimit int `json:"per_provider_limit,omitempty"`
+	Page             int `json:"page"`
+	PageSize         int `json:"page_size"`
+	ListOptions
+}
+
 // CloudProductRepository 云产品仓库接口
 type CloudProductRepository interface {
 	Repository
 	GetAll(ctx context.Context) ([]models.CloudProduct, error)
+	List(ctx context.Context, filter CloudProductFilter) ([]models.CloudProduct, error)
+	ListPage(ctx context.Context, filter CloudProductFilter) (*PageResult, error)
 	GetByID(ctx context.Context, id uint) (*models.CloudProduct, error)
 	GetByProviderID(ctx context.Context, providerID uint) ([]models.CloudProduct, error)
 	GetByProviderCode(ctx context.Context, providerCode string) ([]models.CloudProduct, error)
 	GetByCode(ctx context.Context, providerID uint, code string) (*models.CloudProduct, error)
 	Create(ctx context.Context, product *models.CloudProduct) error
 	Update(ctx context.Context, product *models.CloudProduct) error
-	Delete(ctx context.Context, id uint) error
+	// Patch 只更新指定列，columns为数据库列名
+	Patch(ctx context.Context, product *models.CloudProduct, columns []string) error
+	// Delete 软删除云产品，其配置项一同移入回收站
+	Delete(ctx context.Context, id uint) (*CascadeRecords, error)
 }
 
 // cloudProductRepository 云产品仓库实现
@@ -45,10 +64,101 @@ func (r *cloudProductRepository) GetAll(ctx context.Context) ([]models.CloudProd
 	return products, nil
 }
 
+// List 根据过滤条件获取云产品列表
+func (r *cloudProductRepository) List(ctx context.Context, filter CloudProductFilter) ([]models.CloudProduct, error) {
+	var products []models.CloudProduct
+
+	err := r.listQuery(ctx, filter).
+		Scopes(filter.QueryScope(CloudProductListSchema)).
+		Find(&products).Error
+	if err != nil {
+		logger.Error("Failed to list cloud products", err)
+		return nil, err
+	}
+	return products, nil
+}
+
+// ListPage 根据过滤条件分页获取云产品列表，指定游标时使用游标分页，否则按页码分页
+func (r *cloudProductRepository) ListPage(ctx context.Context, filter CloudProductFilter) (*PageResult, error) {
+	if filter.CursorMode() {
+		products, next, prev, err := CursorPage[models.CloudProduct](
+			r.listQuery(ctx, filter).Scopes(filter.SelectScope(CloudProductListSchema)),
+			CloudProductListSchema, filter.ListOptions, filter.PageSize)
+		if err != nil {
+			logger.Error("Failed to list cloud products by cursor", err)
+			return nil, err
+		}
+		return NewCursorPageResult(products, filter.PageSize, next, prev), nil
+	}
+
+	var products []models.CloudProduct
+	var total int64
+
+	err := r.listQuery(ctx, filter).Count(&total).Error
+	if err != nil {
+		logger.Error("Failed to count cloud products", err)
+		return nil, err
+	}
+
+	err = r.listQuery(ctx, filter).
+		Scopes(filter.QueryScope(CloudProductListSchema), Paginate(filter.Page, filter.PageSize)).
+		Find(&products).Error
+	if err != nil {
+		logger.Error("Failed to list cloud products by page", err)
+		return nil, err
+	}
+
+	return &PageResult{
+		Total:    total,
+		Page:     filter.Page,
+		PageSize: filter.PageSize,
+		Data:     products,
+	}, nil
+}
+
+// listQuery 构造云产品列表的过滤条件
+func (r *cloudProductRepository) listQuery(ctx context.Context, filter CloudProductFilter) *gorm.DB {
+	query := r.DB.WithContext(ctx).Model(&models.CloudProduct{})
+
+	if len(filter.IDs) > 0 {
+		query = query.Where("cloud_products.id IN ?", filter.IDs)
+	}
+
+	if len(filter.CloudProviderIDs) > 0 {
+		query = query.Where("cloud_provider_id IN ?", filter.CloudProviderIDs)
+	}
+
+	if len(filter.Codes) > 0 {
+		query = query.Where("code IN ?", filter.Codes)
+	}
+
+	if len(filter.CategoryIDs) > 0 {
+		categoryIDs, err := categorySubtreeIDs(r.DB.WithContext(ctx), filter.CategoryIDs)
+		if err != nil {
+			logger.Error("Failed to expand product categories", err)
+			_ = query.AddError(err)
+		}
+		query = query.Where("cloud_products.category_id IN ?", categoryIDs)
+	}
+
+	if filter.Keyword != nil && *filter.Keyword != "" {
+		like := "%" + escapeLike(*filter.Keyword) + "%"
+		query = query.Where("(cloud_products.name LIKE ? OR cloud_products.code LIKE ? OR cloud_products.description LIKE ?)",
+			like, like, like)
+	}
+
+	query = query.Scopes(filter.FilterScope(CloudProductListSchema))
+	if filter.PerProviderLimit > 0 && query.Error == nil {
+		query = limitPerParent(r.DB.WithContext(ctx), query, &models.CloudProduct{},
+			CloudProductListSchema, "cloud_provider_id", filter.PerProviderLimit)
+	}
+	return query
+}
+
 // GetByID 根据ID获取云产品
 func (r *cloudProductRepository) GetByID(ctx context.Context, id uint) (*models.CloudProduct, error) {
 	var product models.CloudProduct
-	err := r.DB.WithContext(ctx).First(&product, id).Error
+	err := r.DB.WithContext(ctx).Preload("Tags").First(&product, id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -73,7 +183,9 @@ func (r *cloudProductRepository) GetByProviderID(ctx context.Context, providerID
 // GetByProviderCode 根据云服务商代码获取云产品
 func (r *cloudProductRepository) GetByProviderCode(ctx context.Context, providerCode string) ([]models.CloudProduct, error) {
 	var products []models.CloudProduct
+	// 关联查询时GORM会逐列展开字段，只读的统计列不在表中，需显式只选产品表的列
 	err := r.DB.WithContext(ctx).
+		Select("cloud_products.*").
 		Joins("JOIN cloud_providers ON cloud_products.cloud_provider_id = cloud_providers.id").
 		Where("cloud_providers.code = ?", providerCode).
 		Find(&products).Error
@@ -102,7 +214,7 @@ func (r *cloudProductRepository) GetByCode(ctx context.Context, providerID uint,
 
 // Create 创建云产品
 func (r *cloudProductRepository) Create(ctx context.Context, product *models.CloudProduct) error {
-	err := r.DB.WithContext(ctx).Create(product).Error
+	err := r.DB.WithContext(ctx).Omit("Category", "Tags").Create(product).Error
 	if err != nil {
 		logger.Error("Failed to create cloud product", err)
 		return err
@@ -112,7 +224,7 @@ func (r *cloudProductRepository) Create(ctx context.Context, product *models.Clo
 
 // Update 更新云产品
 func (r *cloudProductRepository) Update(ctx context.Context, product *models.CloudProduct) error {
-	err := r.DB.WithContext(ctx).Save(product).Error
+	err := r.DB.WithContext(ctx).Omit("Category", "Tags").Save(product).Error
 	if err != nil {
 		logger.Error("Failed to update cloud product", err)
 		return err
@@ -120,12 +232,27 @@ func (r *cloudProductRepository) Update(ctx context.Context, product *models.Clo
 	return nil
 }
 
-// Delete 删除云产品
-func (r *cloudProductRepository) Delete(ctx context.Context, id uint) error {
-	err := r.DB.WithContext(ctx).Delete(&models.CloudProduct{}, id).Error
+// Patch 只更新指定列，未指定的列保持不变
+func (r *cloudProductRepository) Patch(ctx context.Context, product *models.CloudProduct, columns []string) error {
+	err := r.DB.WithContext(ctx).Model(product).Select(columns).Updates(product).Error
 	if err != nil {
-		logger.Error("Failed to delete cloud product", err)
+		logger.Error("Failed to patch cloud product", err)
 		return err
 	}
 	return nil
+}
+
+// Delete 软删除云产品，其配置项一同移入回收站
+func (r *cloudProductRepository) Delete(ctx context.Context, id uint) (*CascadeRecords, error) {
+	var records *CascadeRecords
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		records, err = softDeleteCascade(tx, TrashTargetProduct, id)
+		return err
+	})
+	if err != nil {
+		logger.Error("Failed to delete cloud product", err)
+		return nil, err
+	}
+	return records, nil
 }
\ No newline at end of file
@@ -9,15 +9,32 @@ import (
 	"gorm.io/gorm"
 )
 
+// CloudProviderFilter 云服务商列表查询过滤条件
+type CloudProviderFilter struct {
+	IDs      []uint   `json:"ids,omitempty"`     // 多个服务商ID，任一匹配即可
+	Codes    []string `json:"codes,omitempty"`   // 多个服务商代码，任一匹配即可
+	Keyword  *string  `json:"keyword,omitempty"` // 在名称、代码和描述中模糊匹配
+	Page     int      `json:"page"`
+	PageSize int      `json:"page_size"`
+	ListOptions
+}
+
 // CloudProviderRepository 云服务商仓库接口
 type CloudProviderRepository interface {
 	Repository
 	GetAll(ctx context.Context) ([]models.CloudProvider, error)
+	List(ctx context.Context, filter CloudProviderFilter) ([]models.CloudProvider, error)
+	ListPage(ctx context.Context, filter CloudProviderFilter) (*PageResult, error)
 	GetByID(ctx context.Context, id uint) (*models.CloudProvider, error)
 	GetByCode(ctx context.Context, code string) (*models.CloudProvider, error)
 	Create(ctx context.Context, provider *models.CloudProvider) error
 	Update(ctx context.Context, provider *models.CloudProvider) error
-	Delete(ctx context.Context, id uint) error
+	// Patch 只更新指定列，columns为数据库列名
+	Patch(ctx context.Context, provider *models.CloudProvider, columns []string) error
+	// Delete 软删除云服务商，其产品和配置项一同移入回收站
+	Delete(ctx context.Context, id uint) (*CascadeRecords, error)
+	// HasDependants 判断云服务商下是否存在未删除的产品或配置项
+	HasDependants(ctx context.Context, id uint) (bool, error)
 }
 
 // cloudProviderRepository 云服务商仓库实现
@@ -43,10 +60,83 @@ func (r *cloudProviderRepository) GetAll(ctx context.Context) ([]models.CloudPro
 	return providers, nil
 }
 
+// List 根据过滤条件获取云服务商列表
+func (r *cloudProviderRepository) List(ctx context.Context, filter CloudProviderFilter) ([]models.CloudProvider, error) {
+	var providers []models.CloudProvider
+
+	err := r.listQuery(ctx, filter).
+		Scopes(filter.QueryScope(CloudProviderListSchema)).
+		Find(&providers).Error
+	if err != nil {
+		logger.Error("Failed to list cloud providers", err)
+		return nil, err
+	}
+	return providers, nil
+}
+
+// ListPage 根据过滤条件分页获取云服务商列表，指定游标时使用游标分页，否则按页码分页
+func (r *cloudProviderRepository) ListPage(ctx context.Context, filter CloudProviderFilter) (*PageResult, error) {
+	if filter.CursorMode() {
+		providers, next, prev, err := CursorPage[models.CloudProvider](
+			r.listQuery(ctx, filter).Scopes(filter.SelectScope(CloudProviderListSchema)),
+			CloudProviderListSchema, filter.ListOptions, filter.PageSize)
+		if err != nil {
+			logger.Error("Failed to list cloud providers by cursor", err)
+			return nil, err
+		}
+		return NewCursorPageResult(providers, filter.PageSize, next, prev), nil
+	}
+
+	var providers []models.CloudProvider
+	var total int64
+
+	err := r.listQuery(ctx, filter).Count(&total).Error
+	if err != nil {
+		logger.Error("Failed to count cloud providers", err)
+		return nil, err
+	}
+
+	err = r.listQuery(ctx, filter).
+		Scopes(filter.QueryScope(CloudProviderListSchema), Paginate(filter.Page, filter.PageSize)).
+		Find(&providers).Error
+	if err != nil {
+		logger.Error("Failed to list cloud providers by page", err)
+		return nil, err
+	}
+
+	return &PageResult{
+		Total:    total,
+		Page:     filter.Page,
+		PageSize: filter.PageSize,
+		Data:     providers,
+	}, nil
+}
+
+// listQuery 构造云服务商列表的过滤条件
+func (r *cloudProviderRepository) listQuery(ctx context.Context, filter CloudProviderFilter) *gorm.DB {
+	query := r.DB.WithContext(ctx).Model(&models.CloudProvider{})
+
+	if len(filter.IDs) > 0 {
+		query = query.Where("cloud_providers.id IN ?", filter.IDs)
+	}
+
+	if len(filter.Codes) > 0 {
+		query = query.Where("code IN ?", filter.Codes)
+	}
+
+	if filter.Keyword != nil && *filter.Keyword != "" {
+		like := "%" + escapeLike(*filter.Keyword) + "%"
+		query = query.Where("(cloud_providers.name LIKE ? OR cloud_providers.code LIKE ? OR cloud_providers.description LIKE ?)",
+			like, like, like)
+	}
+
+	return query.Scopes(filter.FilterScope(CloudProviderListSchema))
+}
+
 // GetByID 根据ID获取云服务商
 func (r *cloudProviderRepository) GetByID(ctx context.Context, id uint) (*models.CloudProvider, error) {
 	var provider models.CloudProvider
-	err := r.DB.WithContext(ctx).First(&provider, id).Error
+	err := r.DB.WithContext(ctx).Preload("Tags").First(&provider, id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -73,7 +163,7 @@ func (r *cloudProviderRepository) GetByCode(ctx context.Context, code string) (*
 
 // Create 创建云服务商
 func (r *cloudProviderRepository) Create(ctx context.Context, provider *models.CloudProvider) error {
-	err := r.DB.WithContext(ctx).Create(provider).Error
+	err := r.DB.WithContext(ctx).Omit("Tags").Create(provider).Error
 	if err != nil {
 		logger.Error("Failed to create cloud provider", err)
 		return err
@@ -83,7 +173,7 @@ func (r *cloudProviderRepository) Create(ctx context.Context, provider *models.C
 
 // Update 更新云服务商
 func (r *cloudProviderRepository) Update(ctx context.Context, provider *models.CloudProvider) error {
-	err := r.DB.WithContext(ctx).Save(provider).Error
+	err := r.DB.WithContext(ctx).Omit("Tags").Save(provider).Error
 	if err != nil {
 		logger.Error("Failed to update cloud provider", err)
 		return err
@@ -91,12 +181,51 @@ func (r *cloudProviderRepository) Update(ctx context.Context, provider *models.C
 	return nil
 }
 
-// Delete 删除云服务商
-func (r *cloudProviderRepository) Delete(ctx context.Context, id uint) error {
-	err := r.DB.WithContext(ctx).Delete(&models.CloudProvider{}, id).Error
+// Patch 只更新指定列，未指定的列保持不变
+func (r *cloudProviderRepository) Patch(ctx context.Context, provider *models.CloudProvider, columns []string) error {
+	err := r.DB.WithContext(ctx).Model(provider).Select(columns).Updates(provider).Error
 	if err != nil {
-		logger.Error("Failed to delete cloud provider", err)
+		logger.Error("Failed to patch cloud provider", err)
 		return err
 	}
 	return nil
-}
\ No newline at end of file
+}
+
+// Delete 软删除云服务商，其产品和配置项一同移入回收站
+func (r *cloudProviderRepository) Delete(ctx context.Context, id uint) (*CascadeRecords, error) {
+	var records *CascadeRecords
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		records, err = softDeleteCascade(tx, TrashTargetProvider, id)
+		return err
+	})
+	if err != nil {
+		logger.Error("Failed to delete cloud provider", err)
+		return nil, err
+	}
+	return records, nil
+}
+
+// HasDependants 判断云服务商下是否存在未删除的产品或配置项
+func (r *cloudProviderRepository) HasDependants(ctx context.Context, id uint) (bool, error) {
+	var count int64
+	err := r.DB.WithContext(ctx).Model(&models.CloudProduct{}).
+		Where("cloud_provider_id = ?", id).
+		Count(&count).Error
+	if err != nil {
+		logger.Error("Failed to count cloud provider products", err)
+		return false, err
+	}
+	if count > 0 {
+		return true, nil
+	}
+
+	err = r.DB.WithContext(ctx).Model(&models.ConfigurationItem{}).
+		Where("cloud_provider_id = ?", id).
+		Count(&count).Error
+	if err != nil {
+		logger.Error("Failed to count cloud provider configuration items", err)
+		return false, err
+	}
+	return count > 0, nil
+}
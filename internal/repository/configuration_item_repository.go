@@ -7,15 +7,44 @@ import (
 	"github.com/yourusername/cloud-eye/internal/models"
 	"github.com/yourusername/cloud-eye/internal/pkg/logger"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // ConfigItemFilter 配置项查询过滤条件
 type ConfigItemFilter struct {
-	CloudProviderID *uint   `json:"cloud_provider_id,omitempty"`
-	ProductID       *uint   `json:"product_id,omitempty"`
-	Keyword         *string `json:"keyword,omitempty"`
-	Page            int     `json:"page"`
-	PageSize        int     `json:"page_size"`
+	CloudProviderID  *uint    `json:"cloud_provider_id,omitempty"`
+	ProductID        *uint    `json:"product_id,omitempty"`
+	CloudProviderIDs []uint   `json:"cloud_provider_ids,omitempty"` // 多个云服务商，任一匹配即可
+	ProductIDs       []uint   `json:"product_ids,omitempty"`        // 多个产品，任一匹配即可
+	CategoryIDs      []uint   `json:"category_ids,omitempty"`       // 产品所属类别，包含子类别
+	Tags             []string `json:"tags,omitempty"`               // 标签名称
+	TagMatch         string   `json:"tag_match,omitempty"`          // 多个标签的匹配方式：or（默认，任一）或and（全部）
+	Keyword          *string  `json:"keyword,omitempty"`
+	Severities       []string `json:"severities,omitempty"` // 多个风险等级，任一匹配即可
+	Statuses         []string `json:"statuses,omitempty"`   // 多个状态，任一匹配即可
+	// PerProductLimit 每个产品最多返回的配置项数，按ID取前N个，0表示不限制；用于一次加载多个产品的配置项
+	PerProductLimit int `json:"per_product_limit,omitempty"`
+	Page            int `json:"page"`
+	PageSize        int `json:"page_size"`
+	ListOptions
+}
+
+// 配置项批量操作类型
+const (
+	BulkOpCreate = "create" // 创建配置项
+	BulkOpUpdate = "update" // 部分更新配置项
+	BulkOpDelete = "delete" // 删除配置项（移入回收站）
+	BulkOpMove   = "move"   // 移动到其他云产品
+	BulkOpTag    = "tag"    // 添加或移除标签
+)
+
+// ConfigItemChange 批量操作中的单项变更，由服务层校验后生成
+type ConfigItemChange struct {
+	Op         string
+	ID         uint
+	Item       *models.ConfigurationItem // create、update、move时为变更后的完整配置项，create时包含标签；delete时为删除前的配置项
+	AddTags    []string                  // tag时添加的标签
+	RemoveTags []string                  // tag时移除的标签
 }
 
 // ConfigurationItemRepository 配置项仓库接口
@@ -26,8 +55,13 @@ type ConfigurationItemRepository interface {
 	GetByProviderAndProduct(ctx context.Context, providerID, productID uint) ([]models.ConfigurationItem, error)
 	Create(ctx context.Context, item *models.ConfigurationItem) error
 	Update(ctx context.Context, item *models.ConfigurationItem) error
+	// Patch 只更新指定列，columns为数据库列名
+	Patch(ctx context.Context, item *models.ConfigurationItem, columns []string) error
 	Delete(ctx context.Context, id uint) error
 	BatchInsert(ctx context.Context, items []models.ConfigurationItem) error
+	// ApplyBulk 在一个事务中依次执行批量变更，返回每项变更的错误；
+	// atomic为true时任一变更失败则全部回滚，否则仅回滚失败的变更
+	ApplyBulk(ctx context.Context, changes []ConfigItemChange, atomic bool) ([]error, error)
 }
 
 // configurationItemRepository 配置项仓库实现
@@ -42,10 +76,10 @@ func NewConfigurationItemRepository(db *gorm.DB) ConfigurationItemRepository {
 	}
 }
 
-// GetByID 根据ID获取配置项
+// GetByID 根据ID获取配置项，包含标签
 func (r *configurationItemRepository) GetByID(ctx context.Context, id uint) (*models.ConfigurationItem, error) {
 	var item models.ConfigurationItem
-	err := r.DB.WithContext(ctx).First(&item, id).Error
+	err := r.DB.WithContext(ctx).Preload("Tags").First(&item, id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -72,11 +106,59 @@ func (r *configurationItemRepository) GetByFilter(ctx context.Context, filter Co
 		query = query.Where("product_id = ?", *filter.ProductID)
 	}
 
+	if len(filter.CloudProviderIDs) > 0 {
+		query = query.Where("cloud_provider_id IN ?", filter.CloudProviderIDs)
+	}
+
+	if len(filter.ProductIDs) > 0 {
+		query = query.Where("product_id IN ?", filter.ProductIDs)
+	}
+
+	if len(filter.CategoryIDs) > 0 {
+		categoryIDs, err := categorySubtreeIDs(r.DB.WithContext(ctx), filter.CategoryIDs)
+		if err != nil {
+			logger.Error("Failed to expand product categories", err)
+			return nil, err
+		}
+		query = query.Where("product_id IN (?)",
+			r.DB.Model(&models.CloudProduct{}).Select("id").Where("category_id IN ?", categoryIDs))
+	}
+
+	if len(filter.Tags) > 0 {
+		query = query.Scopes(tagFilterScope(TagTargetConfigItem, filter.Tags, filter.TagMatch))
+	}
+
 	if filter.Keyword != nil && *filter.Keyword != "" {
 		query = query.Where("name LIKE ? OR recommended_value LIKE ? OR risk_description LIKE ?",
 			"%"+*filter.Keyword+"%", "%"+*filter.Keyword+"%", "%"+*filter.Keyword+"%")
 	}
 
+	if len(filter.Severities) > 0 {
+		query = query.Where("severity IN ?", filter.Severities)
+	}
+
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
+
+	query = query.Scopes(filter.FilterScope(ConfigItemListSchema))
+
+	if filter.PerProductLimit > 0 {
+		query = limitPerParent(r.DB.WithContext(ctx), query, &models.ConfigurationItem{},
+			ConfigItemListSchema, "product_id", filter.PerProductLimit)
+	}
+
+	// 游标分页：不统计总数，按排序键定位
+	if filter.CursorMode() {
+		items, next, prev, err := CursorPage[models.ConfigurationItem](
+			query.Scopes(filter.SelectScope(ConfigItemListSchema)), ConfigItemListSchema, filter.ListOptions, filter.PageSize)
+		if err != nil {
+			logger.Error("Failed to get configuration items by cursor", err)
+			return nil, err
+		}
+		return NewCursorPageResult(items, filter.PageSize, next, prev), nil
+	}
+
 	// 计算总数
 	err := query.Count(&total).Error
 	if err != nil {
@@ -84,10 +166,8 @@ func (r *configurationItemRepository) GetByFilter(ctx context.Context, filter Co
 		return nil, err
 	}
 
-	// 应用分页并查询数据
-	err = query.Scopes(Paginate(filter.Page, filter.PageSize)).
-		Preload("Provider").
-		Preload("Product").
+	// 应用排序、分页和关联加载并查询数据
+	err = query.Scopes(filter.QueryScope(ConfigItemListSchema), Paginate(filter.Page, filter.PageSize)).
 		Find(&items).Error
 	if err != nil {
 		logger.Error("Failed to get configuration items by filter", err)
@@ -117,7 +197,7 @@ func (r *configurationItemRepository) GetByProviderAndProduct(ctx context.Contex
 
 // Create 创建配置项
 func (r *configurationItemRepository) Create(ctx context.Context, item *models.ConfigurationItem) error {
-	err := r.DB.WithContext(ctx).Create(item).Error
+	err := r.DB.WithContext(ctx).Omit(clause.Associations).Create(item).Error
 	if err != nil {
 		logger.Error("Failed to create configuration item", err)
 		return err
@@ -127,7 +207,7 @@ func (r *configurationItemRepository) Create(ctx context.Context, item *models.C
 
 // Update 更新配置项
 func (r *configurationItemRepository) Update(ctx context.Context, item *models.ConfigurationItem) error {
-	err := r.DB.WithContext(ctx).Save(item).Error
+	err := r.DB.WithContext(ctx).Omit(clause.Associations).Save(item).Error
 	if err != nil {
 		logger.Error("Failed to update configuration item", err)
 		return err
@@ -135,9 +215,22 @@ func (r *configurationItemRepository) Update(ctx context.Context, item *models.C
 	return nil
 }
 
-// Delete 删除配置项
+// Patch 只更新指定列，未指定的列保持不变
+func (r *configurationItemRepository) Patch(ctx context.Context, item *models.ConfigurationItem, columns []string) error {
+	err := r.DB.WithContext(ctx).Model(item).Select(columns).Updates(item).Error
+	if err != nil {
+		logger.Error("Failed to patch configuration item", err)
+		return err
+	}
+	return nil
+}
+
+// Delete 软删除配置项
 func (r *configurationItemRepository) Delete(ctx context.Context, id uint) error {
-	err := r.DB.WithContext(ctx).Delete(&models.ConfigurationItem{}, id).Error
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		_, err := softDeleteCascade(tx, TrashTargetConfigItem, id)
+		return err
+	})
 	if err != nil {
 		logger.Error("Failed to delete configuration item", err)
 		return err
@@ -145,15 +238,92 @@ func (r *configurationItemRepository) Delete(ctx context.Context, id uint) error
 	return nil
 }
 
-// BatchInsert 批量插入配置项（用于Excel导入）
+// BatchInsert 批量插入配置项（用于Excel导入），同时按名称关联配置项的标签
 func (r *configurationItemRepository) BatchInsert(ctx context.Context, items []models.ConfigurationItem) error {
 	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		for _, item := range items {
-			if err := tx.Create(&item).Error; err != nil {
+		for i := range items {
+			item := &items[i]
+			if err := tx.Omit(clause.Associations).Create(item).Error; err != nil {
 				logger.Error("Failed to batch insert configuration item", err)
 				return err
 			}
+			if len(item.Tags) == 0 {
+				continue
+			}
+			tags, err := ensureTags(tx, models.TagNames(item.Tags))
+			if err != nil {
+				logger.Error("Failed to create tags for configuration item", err)
+				return err
+			}
+			if err := linkTags(tx, TagTargetConfigItem, []uint{item.ID}, tags); err != nil {
+				logger.Error("Failed to link tags to configuration item", err)
+				return err
+			}
 		}
 		return nil
 	})
-}
\ No newline at end of file
+}
+
+// ApplyBulk 在一个事务中依次执行批量变更，非atomic模式下每项变更使用保存点
+func (r *configurationItemRepository) ApplyBulk(ctx context.Context, changes []ConfigItemChange, atomic bool) ([]error, error) {
+	errs := make([]error, len(changes))
+	failed := false
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i := range changes {
+			change := &changes[i]
+			if atomic {
+				if err := applyConfigItemChange(tx, change); err != nil {
+					errs[i], failed = err, true
+					return err
+				}
+				continue
+			}
+			errs[i] = tx.Transaction(func(sp *gorm.DB) error {
+				return applyConfigItemChange(sp, change)
+			})
+		}
+		return nil
+	})
+	if err != nil && !failed {
+		logger.Error("Failed to apply bulk configuration item changes", err)
+		return nil, err
+	}
+	return errs, nil
+}
+
+// applyConfigItemChange 执行单项批量变更，关联的云服务商和产品只通过外键引用，不随配置项写入
+func applyConfigItemChange(tx *gorm.DB, change *ConfigItemChange) error {
+	switch change.Op {
+	case BulkOpCreate:
+		if err := tx.Omit(clause.Associations).Create(change.Item).Error; err != nil {
+			return err
+		}
+		change.ID = change.Item.ID
+		if len(change.Item.Tags) == 0 {
+			return nil
+		}
+		tags, err := ensureTags(tx, models.TagNames(change.Item.Tags))
+		if err != nil {
+			return err
+		}
+		return linkTags(tx, TagTargetConfigItem, []uint{change.ID}, tags)
+	case BulkOpUpdate, BulkOpMove:
+		return tx.Omit(clause.Associations).Save(change.Item).Error
+	case BulkOpDelete:
+		_, err := softDeleteCascade(tx, TrashTargetConfigItem, change.ID)
+		return err
+	case BulkOpTag:
+		if len(change.AddTags) > 0 {
+			tags, err := ensureTags(tx, change.AddTags)
+			if err != nil {
+				return err
+			}
+			if err := linkTags(tx, TagTargetConfigItem, []uint{change.ID}, tags); err != nil {
+				return err
+			}
+		}
+		return unlinkTags(tx, TagTargetConfigItem, []uint{change.ID}, change.RemoveTags)
+	default:
+		return errors.New("unsupported bulk operation: " + change.Op)
+	}
+}
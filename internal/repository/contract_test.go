@@ -0,0 +1,1030 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/cloud-eye/internal/models"
+	"github.com/yourusername/cloud-eye/internal/pkg/search"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+)
+
+// testMySQLDSNEnv 指定契约测试使用的MySQL数据库，未设置时跳过SQL实现的测试。
+// 测试会重建库中的表，例如：root:secret@tcp(127.0.0.1:3306)/cloud_eye_test?charset=utf8mb4&parseTime=True&loc=Local
+const testMySQLDSNEnv = "CLOUDEYE_TEST_MYSQL_DSN"
+
+// contractRepos 契约测试使用的一组仓库
+type contractRepos struct {
+	providers CloudProviderRepository
+	products  CloudProductRepository
+	items     ConfigurationItemRepository
+	families  ControlFamilyRepository
+	trash     TrashRepository
+	tags      TagRepository
+	jobs      JobRepository
+	webhooks  WebhookRepository
+	search    SearchRepository
+}
+
+// TestMemoryRepositoryContract 在内存实现上运行契约测试
+func TestMemoryRepositoryContract(t *testing.T) {
+	runRepositoryContract(t, func(t *testing.T) contractRepos {
+		store := NewMemoryStore()
+		return contractRepos{
+			providers: NewMemoryCloudProviderRepository(store),
+			products:  NewMemoryCloudProductRepository(store),
+			items:     NewMemoryConfigurationItemRepository(store),
+			families:  NewMemoryControlFamilyRepository(store),
+			trash:     NewMemoryTrashRepository(store),
+			tags:      NewMemoryTagRepository(store),
+			jobs:      NewMemoryJobRepository(store),
+			webhooks:  NewMemoryWebhookRepository(store),
+			search:    NewMemorySearchRepository(store),
+		}
+	})
+}
+
+// TestSQLRepositoryContract 在GORM实现上运行契约测试
+func TestSQLRepositoryContract(t *testing.T) {
+	dsn := os.Getenv(testMySQLDSNEnv)
+	if dsn == "" {
+		t.Skipf("未设置%s，跳过SQL仓库契约测试", testMySQLDSNEnv)
+	}
+
+	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{
+		NamingStrategy: schema.NamingStrategy{SingularTable: true},
+		Logger:         gormlogger.Default.LogMode(gormlogger.Silent),
+		TranslateError: true,
+	})
+	if err != nil {
+		t.Fatalf("连接数据库失败: %v", err)
+	}
+	statements := schemaStatements(t)
+
+	runRepositoryContract(t, func(t *testing.T) contractRepos {
+		// 每个用例重建表，保证自增ID和数据与内存实现一样从空库开始；
+		// FOREIGN_KEY_CHECKS是会话变量，建表需在同一连接上执行
+		err := db.Connection(func(conn *gorm.DB) error {
+			if err := conn.Exec("SET FOREIGN_KEY_CHECKS = 0").Error; err != nil {
+				return err
+			}
+			defer conn.Exec("SET FOREIGN_KEY_CHECKS = 1")
+			for _, stmt := range statements {
+				if err := conn.Exec(stmt).Error; err != nil {
+					return fmt.Errorf("%w\n%s", err, stmt)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("执行建表语句失败: %v", err)
+		}
+		return contractRepos{
+			providers: NewCloudProviderRepository(db),
+			products:  NewCloudProductRepository(db),
+			items:     NewConfigurationItemRepository(db),
+			families:  NewControlFamilyRepository(db),
+			trash:     NewTrashRepository(db),
+			tags:      NewTagRepository(db),
+			jobs:      NewJobRepository(db),
+			webhooks:  NewWebhookRepository(db),
+			search:    NewSearchRepository(db),
+		}
+	})
+}
+
+// schemaStatements 读取init_database.sql中的建表语句，不包含建库和初始化数据
+func schemaStatements(t *testing.T) []string {
+	t.Helper()
+
+	raw, err := os.ReadFile("../../init_database.sql")
+	if err != nil {
+		t.Fatalf("读取init_database.sql失败: %v", err)
+	}
+	comment := regexp.MustCompile(`(?m)^\s*--.*$`)
+	var statements []string
+	for _, stmt := range strings.Split(comment.ReplaceAllString(string(raw), ""), ";") {
+		stmt = strings.TrimSpace(stmt)
+		if strings.HasPrefix(stmt, "DROP TABLE") || strings.HasPrefix(stmt, "CREATE TABLE") {
+			statements = append(statements, stmt)
+		}
+	}
+	return statements
+}
+
+// runRepositoryContract 运行仓库契约测试，newRepos为每个用例创建基于空存储的仓库
+func runRepositoryContract(t *testing.T, newRepos func(t *testing.T) contractRepos) {
+	ctx := context.Background()
+
+	t.Run("ProviderCodeIsUnique", func(t *testing.T) {
+		r := newRepos(t)
+		aws := mustCreateProvider(t, r, "AWS")
+
+		got, err := r.providers.GetByCode(ctx, "AWS")
+		if err != nil || got == nil || got.ID != aws.ID {
+			t.Fatalf("GetByCode(AWS) = %v, %v，期望ID %d", got, err, aws.ID)
+		}
+		if got, err := r.providers.GetByCode(ctx, "NONE"); err != nil || got != nil {
+			t.Fatalf("GetByCode(NONE) = %v, %v，期望nil, nil", got, err)
+		}
+
+		err = r.providers.Create(ctx, &models.CloudProvider{Name: "重复", Code: "AWS"})
+		if !IsDuplicateKey(err) {
+			t.Fatalf("重复代码创建返回%v，期望唯一约束冲突", err)
+		}
+
+		other := mustCreateProvider(t, r, "GCP")
+		other.Code = "AWS"
+		if err := r.providers.Update(ctx, other); !IsDuplicateKey(err) {
+			t.Fatalf("更新为重复代码返回%v，期望唯一约束冲突", err)
+		}
+	})
+
+	t.Run("ProductCodeIsUniquePerProvider", func(t *testing.T) {
+		r := newRepos(t)
+		aws := mustCreateProvider(t, r, "AWS")
+		gcp := mustCreateProvider(t, r, "GCP")
+		mustCreateProduct(t, r, aws.ID, "VM")
+
+		if err := r.products.Create(ctx, &models.CloudProduct{CloudProviderID: aws.ID, Name: "重复", Code: "VM"}); !IsDuplicateKey(err) {
+			t.Fatalf("同一服务商下重复代码返回%v，期望唯一约束冲突", err)
+		}
+		vm := mustCreateProduct(t, r, gcp.ID, "VM")
+
+		got, err := r.products.GetByCode(ctx, gcp.ID, "VM")
+		if err != nil || got == nil || got.ID != vm.ID {
+			t.Fatalf("GetByCode(GCP, VM) = %v, %v，期望ID %d", got, err, vm.ID)
+		}
+		byCode, err := r.products.GetByProviderCode(ctx, "GCP")
+		if err != nil || len(byCode) != 1 || byCode[0].ID != vm.ID {
+			t.Fatalf("GetByProviderCode(GCP) = %v, %v", byCode, err)
+		}
+	})
+
+	t.Run("ProductRequiresProvider", func(t *testing.T) {
+		r := newRepos(t)
+		err := r.products.Create(ctx, &models.CloudProduct{CloudProviderID: 999, Name: "孤立", Code: "ORPHAN"})
+		if err == nil {
+			t.Fatal("服务商不存在时创建云产品成功，期望外键约束错误")
+		}
+	})
+
+	t.Run("DeleteProviderCascades", func(t *testing.T) {
+		r := newRepos(t)
+		aws := mustCreateProvider(t, r, "AWS")
+		gcp := mustCreateProvider(t, r, "GCP")
+		vm := mustCreateProduct(t, r, aws.ID, "VM")
+		keep := mustCreateProduct(t, r, gcp.ID, "VM")
+		item := mustCreateItem(t, r, vm, "删除的配置项")
+		kept := mustCreateItem(t, r, keep, "保留的配置项")
+
+		if has, err := r.providers.HasDependants(ctx, aws.ID); err != nil || !has {
+			t.Fatalf("HasDependants = %v, %v，期望true", has, err)
+		}
+		records, err := r.providers.Delete(ctx, aws.ID)
+		if err != nil {
+			t.Fatalf("删除云服务商失败: %v", err)
+		}
+		if got := cascadeIDs(records); got != fmt.Sprintf("providers=[%d] products=[%d] items=[%d]", aws.ID, vm.ID, item.ID) {
+			t.Fatalf("删除返回的记录为%s", got)
+		}
+
+		if got, err := r.providers.GetByID(ctx, aws.ID); err != nil || got != nil {
+			t.Fatalf("删除后GetByID = %v, %v，期望nil", got, err)
+		}
+		if got, err := r.products.GetByID(ctx, vm.ID); err != nil || got != nil {
+			t.Fatalf("级联删除后云产品仍存在: %v, %v", got, err)
+		}
+		if got, err := r.items.GetByID(ctx, item.ID); err != nil || got != nil {
+			t.Fatalf("级联删除后配置项仍存在: %v, %v", got, err)
+		}
+		if got, err := r.items.GetByID(ctx, kept.ID); err != nil || got == nil {
+			t.Fatalf("其他服务商的配置项被删除: %v, %v", got, err)
+		}
+		// 回收站中的记录仍占用代码
+		if err := r.providers.Create(ctx, &models.CloudProvider{Name: "AWS", Code: "AWS"}); !IsDuplicateKey(err) {
+			t.Fatalf("使用回收站中的代码创建返回%v，期望唯一约束冲突", err)
+		}
+	})
+
+	t.Run("DeleteProductCascades", func(t *testing.T) {
+		r := newRepos(t)
+		aws := mustCreateProvider(t, r, "AWS")
+		vm := mustCreateProduct(t, r, aws.ID, "VM")
+		db := mustCreateProduct(t, r, aws.ID, "DB")
+		item := mustCreateItem(t, r, vm, "删除的配置项")
+		kept := mustCreateItem(t, r, db, "保留的配置项")
+
+		records, err := r.products.Delete(ctx, vm.ID)
+		if err != nil {
+			t.Fatalf("删除云产品失败: %v", err)
+		}
+		if got := cascadeIDs(records); got != fmt.Sprintf("providers=[] products=[%d] items=[%d]", vm.ID, item.ID) {
+			t.Fatalf("删除返回的记录为%s", got)
+		}
+		if got, err := r.items.GetByID(ctx, item.ID); err != nil || got != nil {
+			t.Fatalf("级联删除后配置项仍存在: %v, %v", got, err)
+		}
+		if got, err := r.items.GetByID(ctx, kept.ID); err != nil || got == nil {
+			t.Fatalf("其他产品的配置项被删除: %v, %v", got, err)
+		}
+		products, err := r.products.GetByProviderID(ctx, aws.ID)
+		if err != nil || len(products) != 1 || products[0].ID != db.ID {
+			t.Fatalf("GetByProviderID = %v, %v，期望只剩DB", products, err)
+		}
+	})
+
+	t.Run("RestoreReturnsCascadedRecords", func(t *testing.T) {
+		r := newRepos(t)
+		aws := mustCreateProvider(t, r, "AWS")
+		vm := mustCreateProduct(t, r, aws.ID, "VM")
+		db := mustCreateProduct(t, r, aws.ID, "DB")
+		mustCreateItem(t, r, mustCreateProduct(t, r, mustCreateProvider(t, r, "GCP").ID, "VM"), "其他服务商的配置项")
+		item := mustCreateItem(t, r, vm, "级联删除的配置项")
+		if _, err := r.tags.Attach(ctx, TagTargetConfigItem, []uint{item.ID}, []string{"加密"}); err != nil {
+			t.Fatalf("添加标签失败: %v", err)
+		}
+		if _, err := r.providers.Delete(ctx, aws.ID); err != nil {
+			t.Fatalf("删除云服务商失败: %v", err)
+		}
+
+		records, err := r.trash.Restore(ctx, TrashTargetProvider, aws.ID)
+		if err != nil {
+			t.Fatalf("恢复云服务商失败: %v", err)
+		}
+		if got := cascadeIDs(records); got != fmt.Sprintf("providers=[%d] products=[%d %d] items=[%d]", aws.ID, vm.ID, db.ID, item.ID) {
+			t.Fatalf("恢复返回的记录为%s", got)
+		}
+		if restored := records.ConfigItems[0]; restored.DeletedAt.Valid || len(restored.Tags) != 1 || restored.Tags[0].Name != "加密" {
+			t.Fatalf("恢复的配置项为%+v，期望未删除且带有标签", restored)
+		}
+	})
+
+	t.Run("TagChangesReturnChangedTargets", func(t *testing.T) {
+		r := newRepos(t)
+		aws := mustCreateProvider(t, r, "AWS")
+		vm := mustCreateProduct(t, r, aws.ID, "VM")
+		tagged := mustCreateItem(t, r, vm, "已有标签")
+		plain := mustCreateItem(t, r, vm, "没有标签")
+		deleted := mustCreateItem(t, r, vm, "已删除")
+		if _, err := r.tags.Attach(ctx, TagTargetConfigItem, []uint{tagged.ID, deleted.ID}, []string{"加密"}); err != nil {
+			t.Fatalf("添加标签失败: %v", err)
+		}
+		if err := r.items.Delete(ctx, deleted.ID); err != nil {
+			t.Fatalf("删除配置项失败: %v", err)
+		}
+
+		records, err := r.tags.Attach(ctx, TagTargetConfigItem, []uint{tagged.ID, plain.ID}, []string{"加密"})
+		if err != nil {
+			t.Fatalf("添加标签失败: %v", err)
+		}
+		if got := cascadeIDs(records); got != fmt.Sprintf("providers=[] products=[] items=[%d]", plain.ID) {
+			t.Fatalf("添加已有标签时返回%s，期望只返回新增标签的配置项", got)
+		}
+		if tags := records.ConfigItems[0].Tags; len(tags) != 1 || tags[0].Name != "加密" {
+			t.Fatalf("返回的配置项标签为%v", tags)
+		}
+
+		records, err = r.tags.Detach(ctx, TagTargetConfigItem, []uint{plain.ID, deleted.ID}, []string{"加密", "不存在"})
+		if err != nil {
+			t.Fatalf("移除标签失败: %v", err)
+		}
+		if got := cascadeIDs(records); got != fmt.Sprintf("providers=[] products=[] items=[%d]", plain.ID) {
+			t.Fatalf("移除标签返回%s，期望不包含回收站中的配置项", got)
+		}
+
+		all, err := r.tags.GetAll(ctx)
+		if err != nil || len(all) != 1 {
+			t.Fatalf("GetAll = %v, %v", all, err)
+		}
+		records, err = r.tags.Delete(ctx, all[0].ID)
+		if err != nil {
+			t.Fatalf("删除标签失败: %v", err)
+		}
+		if got := cascadeIDs(records); got != fmt.Sprintf("providers=[] products=[] items=[%d]", tagged.ID) {
+			t.Fatalf("删除标签返回%s", got)
+		}
+	})
+
+	t.Run("ConfigItemFilterAndPagination", func(t *testing.T) {
+		r := newRepos(t)
+		aws := mustCreateProvider(t, r, "AWS")
+		gcp := mustCreateProvider(t, r, "GCP")
+		vm := mustCreateProduct(t, r, aws.ID, "VM")
+		db := mustCreateProduct(t, r, aws.ID, "DB")
+		gvm := mustCreateProduct(t, r, gcp.ID, "VM")
+		for _, name := range []string{"开启加密", "关闭公网访问", "开启日志"} {
+			mustCreateItem(t, r, vm, name)
+		}
+		mustCreateItem(t, r, db, "开启备份")
+		mustCreateItem(t, r, gvm, "开启加密")
+
+		cases := []struct {
+			name   string
+			filter ConfigItemFilter
+			want   []string
+		}{
+			{"全部", ConfigItemFilter{}, []string{"开启加密", "关闭公网访问", "开启日志", "开启备份", "开启加密"}},
+			{"按服务商", ConfigItemFilter{CloudProviderIDs: []uint{gcp.ID}}, []string{"开启加密"}},
+			{"按多个产品", ConfigItemFilter{ProductIDs: []uint{db.ID, gvm.ID}}, []string{"开启备份", "开启加密"}},
+			{"按关键词", ConfigItemFilter{CloudProviderID: &aws.ID, Keyword: strPtr("开启")}, []string{"开启加密", "开启日志", "开启备份"}},
+			{"按名称排序", ConfigItemFilter{ProductID: &vm.ID, ListOptions: ListOptions{Sort: []SortField{{Column: "name", Desc: true}}}},
+				[]string{"开启日志", "开启加密", "关闭公网访问"}},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				tc.filter.PageSize = 100
+				result, err := r.items.GetByFilter(ctx, tc.filter)
+				if err != nil {
+					t.Fatalf("GetByFilter失败: %v", err)
+				}
+				got := itemNames(result)
+				if tc.filter.Sort == nil {
+					// 未指定排序时只比较集合
+					got, tc.want = sortedCopy(got), sortedCopy(tc.want)
+				}
+				if strings.Join(got, ",") != strings.Join(tc.want, ",") {
+					t.Fatalf("结果为%v，期望%v", got, tc.want)
+				}
+				if result.Total != int64(len(tc.want)) {
+					t.Fatalf("Total为%d，期望%d", result.Total, len(tc.want))
+				}
+			})
+		}
+
+		page, err := r.items.GetByFilter(ctx, ConfigItemFilter{Page: 2, PageSize: 2,
+			ListOptions: ListOptions{Sort: []SortField{{Column: "id"}}}})
+		if err != nil {
+			t.Fatalf("分页查询失败: %v", err)
+		}
+		if page.Total != 5 || len(itemNames(page)) != 2 {
+			t.Fatalf("第2页Total=%d，记录数%d，期望5和2", page.Total, len(itemNames(page)))
+		}
+	})
+
+	t.Run("ListPerParentLimit", func(t *testing.T) {
+		r := newRepos(t)
+		aws := mustCreateProvider(t, r, "AWS")
+		gcp := mustCreateProvider(t, r, "GCP")
+		vm := mustCreateProduct(t, r, aws.ID, "VM")
+		db := mustCreateProduct(t, r, aws.ID, "DB")
+		gvm := mustCreateProduct(t, r, gcp.ID, "VM")
+		for _, name := range []string{"开启加密", "关闭公网访问", "开启日志"} {
+			mustCreateItem(t, r, vm, name)
+		}
+		mustCreateItem(t, r, db, "开启备份")
+		high := newItem(gvm, "开启审计")
+		high.Severity = models.SeverityHigh
+		if err := r.items.Create(ctx, &high); err != nil {
+			t.Fatalf("创建配置项失败: %v", err)
+		}
+
+		cases := []struct {
+			name   string
+			filter ConfigItemFilter
+			want   []string
+		}{
+			{"每个产品前2项", ConfigItemFilter{ProductIDs: []uint{vm.ID, db.ID, gvm.ID}, PerProductLimit: 2},
+				[]string{"开启加密", "关闭公网访问", "开启备份", "开启审计"}},
+			// 限制按ID取前N项，与返回顺序无关
+			{"排序不影响限制", ConfigItemFilter{ProductIDs: []uint{vm.ID}, PerProductLimit: 2,
+				ListOptions: ListOptions{Sort: []SortField{{Column: "name", Desc: true}}}},
+				[]string{"开启加密", "关闭公网访问"}},
+			// 先过滤再限制
+			{"先过滤再限制", ConfigItemFilter{ProductIDs: []uint{vm.ID}, Keyword: strPtr("开启"), PerProductLimit: 1},
+				[]string{"开启加密"}},
+			{"按风险等级", ConfigItemFilter{Severities: []string{models.SeverityHigh}, PerProductLimit: 2},
+				[]string{"开启审计"}},
+			{"按状态", ConfigItemFilter{Statuses: []string{models.StatusDraft}}, nil},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				tc.filter.PageSize = 100
+				result, err := r.items.GetByFilter(ctx, tc.filter)
+				if err != nil {
+					t.Fatalf("GetByFilter失败: %v", err)
+				}
+				if got, want := sortedCopy(itemNames(result)), sortedCopy(tc.want); strings.Join(got, ",") != strings.Join(want, ",") {
+					t.Fatalf("结果为%v，期望%v", got, want)
+				}
+				if result.Total != int64(len(tc.want)) {
+					t.Fatalf("Total为%d，期望%d", result.Total, len(tc.want))
+				}
+			})
+		}
+
+		products, err := r.products.List(ctx, CloudProductFilter{
+			CloudProviderIDs: []uint{aws.ID, gcp.ID}, PerProviderLimit: 1,
+			ListOptions: ListOptions{Include: []string{}},
+		})
+		if err != nil {
+			t.Fatalf("List失败: %v", err)
+		}
+		var got []uint
+		for _, p := range products {
+			got = append(got, p.ID)
+		}
+		if fmt.Sprint(got) != fmt.Sprint([]uint{vm.ID, gvm.ID}) {
+			t.Fatalf("每个服务商前1个产品为%v，期望%v", got, []uint{vm.ID, gvm.ID})
+		}
+	})
+
+	t.Run("ConfigItemCursorPagination", func(t *testing.T) {
+		r := newRepos(t)
+		aws := mustCreateProvider(t, r, "AWS")
+		vm := mustCreateProduct(t, r, aws.ID, "VM")
+		for _, name := range []string{"e", "a", "d", "b", "c", "a"} {
+			mustCreateItem(t, r, vm, name)
+		}
+
+		opts := ListOptions{Sort: []SortField{{Column: "name"}}}
+		var seen []string
+		cursor := ""
+		var last *PageResult
+		for {
+			opts.Cursor = &cursor
+			result, err := r.items.GetByFilter(ctx, ConfigItemFilter{PageSize: 4, ListOptions: opts})
+			if err != nil {
+				t.Fatalf("游标分页失败: %v", err)
+			}
+			seen = append(seen, itemNames(result)...)
+			last = result
+			if result.NextCursor == "" {
+				break
+			}
+			cursor = result.NextCursor
+		}
+		if got := strings.Join(seen, ","); got != "a,a,b,c,d,e" {
+			t.Fatalf("向后翻页结果为%s", got)
+		}
+
+		// 从最后一页向前翻页回到第一页
+		cursor = last.PrevCursor
+		opts.Cursor = &cursor
+		result, err := r.items.GetByFilter(ctx, ConfigItemFilter{PageSize: 4, ListOptions: opts})
+		if err != nil {
+			t.Fatalf("向前翻页失败: %v", err)
+		}
+		if got := strings.Join(itemNames(result), ","); got != "a,a,b,c" {
+			t.Fatalf("向前翻页结果为%s", got)
+		}
+		if result.PrevCursor != "" {
+			t.Fatalf("第一页不应有上一页游标")
+		}
+
+		bad := "invalid"
+		opts.Cursor = &bad
+		if _, err := r.items.GetByFilter(ctx, ConfigItemFilter{ListOptions: opts}); !errors.Is(err, ErrInvalidCursor) {
+			t.Fatalf("无效游标返回%v，期望ErrInvalidCursor", err)
+		}
+	})
+
+	t.Run("ConfigItemCursorNullableSort", func(t *testing.T) {
+		r := newRepos(t)
+		aws := mustCreateProvider(t, r, "AWS")
+		vm := mustCreateProduct(t, r, aws.ID, "VM")
+		family := &models.ControlFamily{Name: "访问控制", Code: "AC"}
+		if err := r.families.Create(ctx, family); err != nil {
+			t.Fatalf("创建控制族失败: %v", err)
+		}
+		for _, name := range []string{"n1", "f1", "n2", "f2", "n3"} {
+			item := newItem(vm, name)
+			if strings.HasPrefix(name, "f") {
+				item.ControlFamilyID = &family.ID
+			}
+			if err := r.items.Create(ctx, &item); err != nil {
+				t.Fatalf("创建配置项失败: %v", err)
+			}
+		}
+
+		// 与MySQL一致，升序时NULL在前，降序时NULL在后
+		tests := []struct {
+			name string
+			sort []SortField
+			want string
+		}{
+			{"升序", []SortField{{Column: "control_family_id"}}, "n1,n2,n3,f1,f2"},
+			{"降序", []SortField{{Column: "control_family_id", Desc: true}}, "f1,f2,n1,n2,n3"},
+		}
+		for _, tt := range tests {
+			opts := ListOptions{Sort: tt.sort}
+			var pages []*PageResult
+			var seen []string
+			cursor := ""
+			for len(pages) < 10 {
+				opts.Cursor = &cursor
+				result, err := r.items.GetByFilter(ctx, ConfigItemFilter{PageSize: 2, ListOptions: opts})
+				if err != nil {
+					t.Fatalf("%s：游标分页失败: %v", tt.name, err)
+				}
+				pages = append(pages, result)
+				seen = append(seen, itemNames(result)...)
+				if result.NextCursor == "" {
+					break
+				}
+				cursor = result.NextCursor
+			}
+			if got := strings.Join(seen, ","); got != tt.want {
+				t.Fatalf("%s：向后翻页结果为%s，期望%s", tt.name, got, tt.want)
+			}
+
+			// 从最后一页逐页向前翻回第一页
+			for i := len(pages) - 1; i > 0; i-- {
+				cursor = pages[i].PrevCursor
+				opts.Cursor = &cursor
+				result, err := r.items.GetByFilter(ctx, ConfigItemFilter{PageSize: 2, ListOptions: opts})
+				if err != nil {
+					t.Fatalf("%s：向前翻页失败: %v", tt.name, err)
+				}
+				got, want := strings.Join(itemNames(result), ","), strings.Join(itemNames(pages[i-1]), ",")
+				if got != want {
+					t.Fatalf("%s：向前翻页结果为%s，期望%s", tt.name, got, want)
+				}
+			}
+		}
+	})
+
+	t.Run("BatchInsertAssignsIDs", func(t *testing.T) {
+		r := newRepos(t)
+		aws := mustCreateProvider(t, r, "AWS")
+		vm := mustCreateProduct(t, r, aws.ID, "VM")
+		items := []models.ConfigurationItem{
+			newItem(vm, "批量一"),
+			newItem(vm, "批量二"),
+		}
+		if err := r.items.BatchInsert(ctx, items); err != nil {
+			t.Fatalf("批量插入失败: %v", err)
+		}
+		for _, item := range items {
+			if item.ID == 0 {
+				t.Fatalf("批量插入后配置项%q没有ID", item.Name)
+			}
+			got, err := r.items.GetByID(ctx, item.ID)
+			if err != nil || got == nil || got.Name != item.Name {
+				t.Fatalf("GetByID(%d) = %v, %v", item.ID, got, err)
+			}
+		}
+		list, err := r.items.GetByProviderAndProduct(ctx, aws.ID, vm.ID)
+		if err != nil || len(list) != 2 {
+			t.Fatalf("GetByProviderAndProduct = %d条, %v，期望2条", len(list), err)
+		}
+	})
+
+	t.Run("PatchUpdatesOnlyGivenColumns", func(t *testing.T) {
+		r := newRepos(t)
+		aws := mustCreateProvider(t, r, "AWS")
+		vm := mustCreateProduct(t, r, aws.ID, "VM")
+		item := mustCreateItem(t, r, vm, "原名称")
+
+		item.Name = "新名称"
+		if err := r.items.Patch(ctx, item, []string{"name"}); err != nil {
+			t.Fatalf("Patch失败: %v", err)
+		}
+		got, err := r.items.GetByID(ctx, item.ID)
+		if err != nil || got == nil || got.Name != "新名称" || got.RecommendedValue != item.RecommendedValue {
+			t.Fatalf("Patch后记录为%v, %v", got, err)
+		}
+	})
+
+	t.Run("ApplyBulkAtomicRollsBack", func(t *testing.T) {
+		r := newRepos(t)
+		aws := mustCreateProvider(t, r, "AWS")
+		vm := mustCreateProduct(t, r, aws.ID, "VM")
+		item := mustCreateItem(t, r, vm, "保留")
+
+		created := newItem(vm, "回滚")
+		moved := *item
+		moved.ProductID = 999
+		changes := []ConfigItemChange{
+			{Op: BulkOpCreate, Item: &created},
+			{Op: BulkOpDelete, ID: item.ID, Item: item},
+			{Op: BulkOpMove, ID: item.ID, Item: &moved},
+		}
+		errs, err := r.items.ApplyBulk(ctx, changes, true)
+		if err != nil {
+			t.Fatalf("ApplyBulk失败: %v", err)
+		}
+		if errs[2] == nil {
+			t.Fatal("移动到不存在的产品应失败")
+		}
+		if got, _ := r.items.GetByID(ctx, item.ID); got == nil {
+			t.Fatal("原子模式下失败后删除未回滚")
+		}
+		result, err := r.items.GetByFilter(ctx, ConfigItemFilter{PageSize: 100})
+		if err != nil || result.Total != 1 {
+			t.Fatalf("原子模式下失败后创建未回滚: Total=%d, %v", result.Total, err)
+		}
+	})
+
+	t.Run("ApplyBulkIgnoresAssociations", func(t *testing.T) {
+		r := newRepos(t)
+		aws := mustCreateProvider(t, r, "AWS")
+		gcp := mustCreateProvider(t, r, "GCP")
+		vm := mustCreateProduct(t, r, aws.ID, "VM")
+		item := mustCreateItem(t, r, vm, "已有")
+
+		// 关联对象只通过外键引用，其中的内容不能写入云服务商和产品表，也不能改变外键
+		created := newItem(vm, "新建")
+		created.Provider = models.CloudProvider{BaseModel: models.BaseModel{ID: gcp.ID}, Name: "HACKED", Code: "GCP"}
+		updated := *item
+		updated.Name = "已更新"
+		updated.Product = models.CloudProduct{BaseModel: models.BaseModel{ID: vm.ID}, CloudProviderID: gcp.ID, Name: "HACKED", Code: "VM"}
+		errs, err := r.items.ApplyBulk(ctx, []ConfigItemChange{
+			{Op: BulkOpCreate, Item: &created},
+			{Op: BulkOpUpdate, ID: item.ID, Item: &updated},
+		}, true)
+		if err != nil || errs[0] != nil || errs[1] != nil {
+			t.Fatalf("ApplyBulk失败: %v, %v", err, errs)
+		}
+
+		if got, _ := r.providers.GetByID(ctx, gcp.ID); got == nil || got.Name != "GCP名称" {
+			t.Fatalf("批量创建修改了关联的云服务商: %+v", got)
+		}
+		if got, _ := r.products.GetByID(ctx, vm.ID); got == nil || got.Name != "VM名称" || got.CloudProviderID != aws.ID {
+			t.Fatalf("批量更新修改了关联的云产品: %+v", got)
+		}
+		if got, _ := r.items.GetByID(ctx, created.ID); got == nil || got.CloudProviderID != aws.ID {
+			t.Fatalf("批量创建的配置项为%+v，期望属于AWS", got)
+		}
+		if got, _ := r.items.GetByID(ctx, item.ID); got == nil || got.Name != "已更新" {
+			t.Fatalf("批量更新的配置项为%+v", got)
+		}
+	})
+
+	t.Run("JobClaimIsExclusive", func(t *testing.T) {
+		r := newRepos(t)
+		job := mustCreateJob(t, r)
+
+		claimed, err := r.jobs.Claim(ctx, job.ID, "a", time.Minute)
+		if err != nil || !claimed {
+			t.Fatalf("领取等待执行的任务失败: %v, %v", claimed, err)
+		}
+		if claimed, err := r.jobs.Claim(ctx, job.ID, "b", time.Minute); err != nil || claimed {
+			t.Fatalf("已领取的任务被其他实例再次领取: %v, %v", claimed, err)
+		}
+
+		got, _ := r.jobs.GetByID(ctx, job.ID)
+		if got.Status != models.JobStatusRunning || got.Owner != "a" || got.Attempts != 1 || got.StartedAt == nil {
+			t.Fatalf("领取后的任务为%+v", got)
+		}
+		if got.LeaseExpiresAt == nil || !got.LeaseExpiresAt.After(time.Now()) {
+			t.Fatalf("领取后的租约到期时间为%v，期望在当前时间之后", got.LeaseExpiresAt)
+		}
+		if queued, _ := r.jobs.Queued(ctx, 10); len(queued) != 0 {
+			t.Fatalf("执行中的任务仍在等待队列中: %d", len(queued))
+		}
+	})
+
+	t.Run("JobHeartbeatOnlyByOwner", func(t *testing.T) {
+		r := newRepos(t)
+		job := mustCreateJob(t, r)
+		if claimed, err := r.jobs.Claim(ctx, job.ID, "a", -time.Minute); err != nil || !claimed {
+			t.Fatalf("领取任务失败: %v, %v", claimed, err)
+		}
+
+		if renewed, err := r.jobs.Heartbeat(ctx, job.ID, "b", time.Minute); err != nil || renewed {
+			t.Fatalf("其他实例续期了任务的租约: %v, %v", renewed, err)
+		}
+		if renewed, err := r.jobs.Heartbeat(ctx, job.ID, "a", time.Minute); err != nil || !renewed {
+			t.Fatalf("领取任务的实例续期失败: %v, %v", renewed, err)
+		}
+		// 续期后租约未过期，不会被放回队列
+		if _, err := r.jobs.Requeue(ctx); err != nil {
+			t.Fatalf("Requeue失败: %v", err)
+		}
+		if got, _ := r.jobs.GetByID(ctx, job.ID); got.Status != models.JobStatusRunning {
+			t.Fatalf("续期后的任务状态为%s，期望running", got.Status)
+		}
+	})
+
+	t.Run("JobRequeueOnlyExpiredLeases", func(t *testing.T) {
+		r := newRepos(t)
+		live := mustCreateJob(t, r)
+		expired := mustCreateJob(t, r)
+		canceled := mustCreateJob(t, r)
+		// SQL实现的时间精度为秒，过期租约使用-1分钟
+		for _, claim := range []struct {
+			id    uint
+			lease time.Duration
+		}{{live.ID, time.Minute}, {expired.ID, -time.Minute}, {canceled.ID, -time.Minute}} {
+			if claimed, err := r.jobs.Claim(ctx, claim.id, "a", claim.lease); err != nil || !claimed {
+				t.Fatalf("领取任务%d失败: %v, %v", claim.id, claimed, err)
+			}
+		}
+		if _, err := r.jobs.RequestCancel(ctx, canceled.ID); err != nil {
+			t.Fatalf("取消任务失败: %v", err)
+		}
+
+		requeued, err := r.jobs.Requeue(ctx)
+		if err != nil || requeued != 1 {
+			t.Fatalf("Requeue返回%d, %v，期望1", requeued, err)
+		}
+		if got, _ := r.jobs.GetByID(ctx, live.ID); got.Status != models.JobStatusRunning || got.LeaseExpiresAt == nil {
+			t.Fatalf("租约未过期的任务被放回队列: %+v", got)
+		}
+		if got, _ := r.jobs.GetByID(ctx, expired.ID); got.Status != models.JobStatusQueued || got.LeaseExpiresAt != nil {
+			t.Fatalf("租约过期的任务为%+v，期望放回队列并清除租约", got)
+		}
+		if got, _ := r.jobs.GetByID(ctx, canceled.ID); got.Status != models.JobStatusCanceled || got.FinishedAt == nil {
+			t.Fatalf("已请求取消的过期任务为%+v，期望直接取消", got)
+		}
+		// 放回队列的任务不再属于原实例，原实例的心跳失败
+		if renewed, _ := r.jobs.Heartbeat(ctx, expired.ID, "a", time.Minute); renewed {
+			t.Fatal("放回队列的任务仍能被原实例续期")
+		}
+	})
+
+	t.Run("JobRetryCountsAttempts", func(t *testing.T) {
+		r := newRepos(t)
+		job := mustCreateJob(t, r)
+		if claimed, err := r.jobs.Claim(ctx, job.ID, "a", -time.Minute); err != nil || !claimed {
+			t.Fatalf("领取任务失败: %v, %v", claimed, err)
+		}
+		if _, err := r.jobs.Requeue(ctx); err != nil {
+			t.Fatalf("Requeue失败: %v", err)
+		}
+
+		queued, err := r.jobs.Queued(ctx, 10)
+		if err != nil || len(queued) != 1 || queued[0].ID != job.ID {
+			t.Fatalf("放回队列的任务未被重新获取: %v, %v", queued, err)
+		}
+		if claimed, err := r.jobs.Claim(ctx, job.ID, "b", time.Minute); err != nil || !claimed {
+			t.Fatalf("其他实例重新领取任务失败: %v, %v", claimed, err)
+		}
+		got, _ := r.jobs.GetByID(ctx, job.ID)
+		if got.Attempts != 2 || got.Owner != "b" {
+			t.Fatalf("重新领取后执行次数为%d、实例为%s，期望2和b", got.Attempts, got.Owner)
+		}
+	})
+
+	t.Run("JobReleaseOnlyByOwner", func(t *testing.T) {
+		r := newRepos(t)
+		job := mustCreateJob(t, r)
+		if claimed, err := r.jobs.Claim(ctx, job.ID, "a", time.Minute); err != nil || !claimed {
+			t.Fatalf("领取任务失败: %v, %v", claimed, err)
+		}
+
+		if err := r.jobs.Release(ctx, job.ID, "b"); err != nil {
+			t.Fatalf("Release失败: %v", err)
+		}
+		if got, _ := r.jobs.GetByID(ctx, job.ID); got.Status != models.JobStatusRunning {
+			t.Fatalf("其他实例放回了任务: %s", got.Status)
+		}
+		if err := r.jobs.Release(ctx, job.ID, "a"); err != nil {
+			t.Fatalf("Release失败: %v", err)
+		}
+		if got, _ := r.jobs.GetByID(ctx, job.ID); got.Status != models.JobStatusQueued || got.LeaseExpiresAt != nil || got.Attempts != 1 {
+			t.Fatalf("放回队列的任务为%+v", got)
+		}
+	})
+
+	t.Run("JobCancellation", func(t *testing.T) {
+		r := newRepos(t)
+		queued := mustCreateJob(t, r)
+		running := mustCreateJob(t, r)
+		if claimed, err := r.jobs.Claim(ctx, running.ID, "a", time.Minute); err != nil || !claimed {
+			t.Fatalf("领取任务失败: %v, %v", claimed, err)
+		}
+
+		// 等待执行的任务立即取消，之后不能再被领取
+		got, err := r.jobs.RequestCancel(ctx, queued.ID)
+		if err != nil || got.Status != models.JobStatusCanceled || !got.CancelRequested || got.FinishedAt == nil {
+			t.Fatalf("取消等待执行的任务返回%+v, %v", got, err)
+		}
+		if claimed, _ := r.jobs.Claim(ctx, queued.ID, "a", time.Minute); claimed {
+			t.Fatal("已取消的任务仍能被领取")
+		}
+
+		// 执行中的任务只标记取消，由执行的实例结束
+		got, err = r.jobs.RequestCancel(ctx, running.ID)
+		if err != nil || got.Status != models.JobStatusRunning || !got.CancelRequested {
+			t.Fatalf("取消执行中的任务返回%+v, %v", got, err)
+		}
+		// 执行中保存任务不能覆盖取消标记
+		got.CancelRequested = false
+		got.Message = "处理中"
+		if err := r.jobs.Update(ctx, got); err != nil {
+			t.Fatalf("保存任务失败: %v", err)
+		}
+		if got, _ := r.jobs.GetByID(ctx, running.ID); !got.CancelRequested || got.Message != "处理中" {
+			t.Fatalf("保存任务后为%+v，期望保留取消标记", got)
+		}
+
+		// 已结束的任务不受取消影响
+		got.Status = models.JobStatusSucceeded
+		if err := r.jobs.Update(ctx, got); err != nil {
+			t.Fatalf("保存任务失败: %v", err)
+		}
+		if got, err := r.jobs.RequestCancel(ctx, running.ID); err != nil || got.Status != models.JobStatusSucceeded {
+			t.Fatalf("取消已结束的任务返回%+v, %v", got, err)
+		}
+	})
+
+	t.Run("DeliveryClaimIsFenced", func(t *testing.T) {
+		r := newRepos(t)
+		subscription := &models.WebhookSubscription{URL: "https://example.com/hook", Secret: "secret-0123456789",
+			Events: models.StringList{models.EventConfigItemCreated}, Active: true}
+		if err := r.webhooks.Create(ctx, subscription); err != nil {
+			t.Fatalf("创建订阅失败: %v", err)
+		}
+		past := time.Now().Add(-time.Minute)
+		deliveries := []models.WebhookDelivery{{SubscriptionID: subscription.ID, EventID: "evt_1", Event: models.EventConfigItemCreated,
+			Payload: "{}", Status: models.DeliveryStatusPending, NextAttemptAt: &past}}
+		if err := r.webhooks.CreateDeliveries(ctx, deliveries); err != nil {
+			t.Fatalf("创建投递记录失败: %v", err)
+		}
+		id := deliveries[0].ID
+
+		if claimed, err := r.webhooks.ClaimDelivery(ctx, id, 0, time.Minute); err != nil || !claimed {
+			t.Fatalf("领取投递记录失败: %v, %v", claimed, err)
+		}
+		if claimed, _ := r.webhooks.ClaimDelivery(ctx, id, 0, time.Minute); claimed {
+			t.Fatal("同一投递记录被领取两次")
+		}
+		if due, _ := r.webhooks.DueDeliveries(ctx, time.Now(), 10); len(due) != 0 {
+			t.Fatalf("租约内的投递记录仍然到期: %d", len(due))
+		}
+		claimed, _ := r.webhooks.GetDelivery(ctx, id)
+		if claimed.Attempts != 1 || claimed.LastAttemptAt == nil {
+			t.Fatalf("领取后投递次数为%d、投递时间为%v，期望在发送前记录", claimed.Attempts, claimed.LastAttemptAt)
+		}
+
+		// 模拟租约过期后被其他worker以第2次投递重新领取，第1次投递的结果不能覆盖
+		if _, err := r.webhooks.ClaimDelivery(ctx, id, 1, -time.Minute); err != nil {
+			t.Fatalf("重新领取失败: %v", err)
+		}
+		stale := *claimed
+		stale.Status = models.DeliveryStatusSucceeded
+		stale.NextAttemptAt = nil
+		if saved, err := r.webhooks.UpdateDelivery(ctx, &stale); err != nil || saved {
+			t.Fatalf("过期领取的结果被保存: %v, %v", saved, err)
+		}
+
+		current, _ := r.webhooks.GetDelivery(ctx, id)
+		current.Status = models.DeliveryStatusFailed
+		current.ResponseStatus = 500
+		current.ResponseBody = "服务不可用"
+		current.NextAttemptAt = nil
+		if saved, err := r.webhooks.UpdateDelivery(ctx, current); err != nil || !saved {
+			t.Fatalf("保存投递结果失败: %v, %v", saved, err)
+		}
+		got, _ := r.webhooks.GetDelivery(ctx, id)
+		if got.Status != models.DeliveryStatusFailed || got.Attempts != 2 || got.ResponseBody != "服务不可用" || got.NextAttemptAt != nil {
+			t.Fatalf("保存后的投递记录为%+v", got)
+		}
+	})
+
+	t.Run("SearchRanksByWeightedOccurrences", func(t *testing.T) {
+		r := newRepos(t)
+		aws := mustCreateProvider(t, r, "AWS")
+		s3 := mustCreateProduct(t, r, aws.ID, "S3")
+
+		// 词之间以空格分隔，使ngram和按空格分词的全文索引都能命中
+		create := func(name, value, risk, check string) uint {
+			item := newItem(s3, name)
+			item.RecommendedValue, item.RiskDescription, item.CheckMethod = value, risk, check
+			if err := r.items.Create(ctx, &item); err != nil {
+				t.Fatalf("创建配置项%s失败: %v", name, err)
+			}
+			return item.ID
+		}
+		nameAndValue := create("存储 加密", "启用 加密", "", "")         // 3 + 2
+		valueTwice := create("日志 审计", "加密 加密", "", "")           // 2 * 2
+		riskAndCheck := create("访问 控制", "启用", "数据 未加密", "检查 加密") // 1 + 1
+		nameTwice := create("加密 传输 加密", "启用", "", "")            // 2 * 3
+		riskTwice := create("备份", "启用", "加密 加密", "")             // 2 * 1
+		create("公共 访问", "禁止", "", "")
+
+		find := func(raw string) ([]uint, []float64) {
+			t.Helper()
+			page, err := r.search.SearchConfigItems(ctx, ConfigItemSearchFilter{Query: search.Parse(raw), Page: 1, PageSize: 20})
+			if err != nil {
+				t.Fatalf("检索%q失败: %v", raw, err)
+			}
+			hits := page.Data.([]ConfigItemSearchHit)
+			if page.Total != int64(len(hits)) {
+				t.Fatalf("检索%q的总数为%d，返回%d条", raw, page.Total, len(hits))
+			}
+			ids, scores := make([]uint, len(hits)), make([]float64, len(hits))
+			for i, hit := range hits {
+				ids[i], scores[i] = hit.Item.ID, hit.Score
+			}
+			return ids, scores
+		}
+
+		// 相关度相同时按ID排列
+		ids, scores := find("加密")
+		wantIDs := []uint{nameTwice, nameAndValue, valueTwice, riskAndCheck, riskTwice}
+		if fmt.Sprint(ids) != fmt.Sprint(wantIDs) || fmt.Sprint(scores) != fmt.Sprint([]float64{6, 5, 4, 2, 2}) {
+			t.Fatalf("检索\"加密\"的结果为%v，相关度%v，期望%v", ids, scores, wantIDs)
+		}
+
+		ids, scores = find("name:加密")
+		if fmt.Sprint(ids) != fmt.Sprint([]uint{nameTwice, nameAndValue}) || fmt.Sprint(scores) != fmt.Sprint([]float64{6, 3}) {
+			t.Fatalf("检索name:加密的结果为%v，相关度%v", ids, scores)
+		}
+
+		// 排除词不计入相关度
+		ids, scores = find("加密 -risk:加密 -传输")
+		if fmt.Sprint(ids) != fmt.Sprint([]uint{nameAndValue, valueTwice}) || fmt.Sprint(scores) != fmt.Sprint([]float64{5, 4}) {
+			t.Fatalf("检索\"加密 -risk:加密 -传输\"的结果为%v，相关度%v", ids, scores)
+		}
+
+		// 服务商和产品名称按权重2计入
+		ids, scores = find("S3名称 启用")
+		if len(ids) != 4 || ids[0] != nameAndValue || scores[0] != 4 {
+			t.Fatalf("检索\"S3名称 启用\"的结果为%v，相关度%v", ids, scores)
+		}
+	})
+}
+
+func mustCreateJob(t *testing.T, r contractRepos) *models.Job {
+	t.Helper()
+	job := &models.Job{Type: models.JobTypeExport, Status: models.JobStatusQueued, Params: "{}"}
+	if err := r.jobs.Create(context.Background(), job); err != nil {
+		t.Fatalf("创建任务失败: %v", err)
+	}
+	return job
+}
+
+func mustCreateProvider(t *testing.T, r contractRepos, code string) *models.CloudProvider {
+	t.Helper()
+	provider := &models.CloudProvider{Name: code + "名称", Code: code}
+	if err := r.providers.Create(context.Background(), provider); err != nil {
+		t.Fatalf("创建云服务商%s失败: %v", code, err)
+	}
+	return provider
+}
+
+func mustCreateProduct(t *testing.T, r contractRepos, providerID uint, code string) *models.CloudProduct {
+	t.Helper()
+	product := &models.CloudProduct{CloudProviderID: providerID, Name: code + "名称", Code: code}
+	if err := r.products.Create(context.Background(), product); err != nil {
+		t.Fatalf("创建云产品%s失败: %v", code, err)
+	}
+	return product
+}
+
+func mustCreateItem(t *testing.T, r contractRepos, product *models.CloudProduct, name string) *models.ConfigurationItem {
+	t.Helper()
+	item := newItem(product, name)
+	if err := r.items.Create(context.Background(), &item); err != nil {
+		t.Fatalf("创建配置项%s失败: %v", name, err)
+	}
+	return &item
+}
+
+func newItem(product *models.CloudProduct, name string) models.ConfigurationItem {
+	return models.ConfigurationItem{
+		CloudProviderID:  product.CloudProviderID,
+		ProductID:        product.ID,
+		Name:             name,
+		RecommendedValue: name + "的推荐值",
+		Severity:         models.SeverityMedium,
+		Status:           models.StatusActive,
+	}
+}
+
+// cascadeIDs 按类型列出记录的ID，便于比较
+func cascadeIDs(records *CascadeRecords) string {
+	var providers, products, items []uint
+	for _, p := range records.Providers {
+		providers = append(providers, p.ID)
+	}
+	for _, p := range records.Products {
+		products = append(products, p.ID)
+	}
+	for _, item := range records.ConfigItems {
+		items = append(items, item.ID)
+	}
+	return fmt.Sprintf("providers=%v products=%v items=%v", providers, products, items)
+}
+
+func itemNames(result *PageResult) []string {
+	items, _ := result.Data.([]models.ConfigurationItem)
+	names := make([]string, len(items))
+	for i, item := range items {
+		names[i] = item.Name
+	}
+	return names
+}
+
+func sortedCopy(s []string) []string {
+	c := append([]string{}, s...)
+	sort.Strings(c)
+	return c
+}
+
+func strPtr(s string) *string {
+	return &s
+}
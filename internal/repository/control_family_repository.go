@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/yourusername/cloud-eye/internal/models"
+	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// ControlFamilyRepository 控制族仓库接口
+type ControlFamilyRepository interface {
+	Repository
+	GetAll(ctx context.Context) ([]models.ControlFamily, error)
+	GetByID(ctx context.Context, id uint) (*models.ControlFamily, error)
+	GetByCode(ctx context.Context, code string) (*models.ControlFamily, error)
+	Create(ctx context.Context, family *models.ControlFamily) error
+	Update(ctx context.Context, family *models.ControlFamily) error
+	// Delete 删除控制族，成员配置项保留并解除关联
+	Delete(ctx context.Context, id uint) error
+	// GetConfigItems 获取控制族的成员配置项，预加载服务商和产品
+	GetConfigItems(ctx context.Context, familyID uint) ([]models.ConfigurationItem, error)
+	// SetConfigItemsFamily 设置配置项所属控制族，familyID为nil时解除关联
+	SetConfigItemsFamily(ctx context.Context, itemIDs []uint, familyID *uint) error
+}
+
+// controlFamilyRepository 控制族仓库实现
+type controlFamilyRepository struct {
+	BaseRepository
+}
+
+// NewControlFamilyRepository 创建控制族仓库
+func NewControlFamilyRepository(db *gorm.DB) ControlFamilyRepository {
+	return &controlFamilyRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// GetAll 获取所有控制族
+func (r *controlFamilyRepository) GetAll(ctx context.Context) ([]models.ControlFamily, error) {
+	var families []models.ControlFamily
+	err := r.DB.WithContext(ctx).Order("id").Find(&families).Error
+	if err != nil {
+		logger.Error("Failed to get all control families", err)
+		return nil, err
+	}
+	return families, nil
+}
+
+// GetByID 根据ID获取控制族
+func (r *controlFamilyRepository) GetByID(ctx context.Context, id uint) (*models.ControlFamily, error) {
+	var family models.ControlFamily
+	err := r.DB.WithContext(ctx).First(&family, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.Error("Failed to get control family by ID", err)
+		return nil, err
+	}
+	return &family, nil
+}
+
+// GetByCode 根据代码获取控制族
+func (r *controlFamilyRepository) GetByCode(ctx context.Context, code string) (*models.ControlFamily, error) {
+	var family models.ControlFamily
+	err := r.DB.WithContext(ctx).Where("code = ?", code).First(&family).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.Error("Failed to get control family by code", err)
+		return nil, err
+	}
+	return &family, nil
+}
+
+// Create 创建控制族
+func (r *controlFamilyRepository) Create(ctx context.Context, family *models.ControlFamily) error {
+	err := r.DB.WithContext(ctx).Omit("ConfigItems").Create(family).Error
+	if err != nil {
+		logger.Error("Failed to create control family", err)
+		return err
+	}
+	return nil
+}
+
+// Update 更新控制族
+func (r *controlFamilyRepository) Update(ctx context.Context, family *models.ControlFamily) error {
+	err := r.DB.WithContext(ctx).Omit("ConfigItems").Save(family).Error
+	if err != nil {
+		logger.Error("Failed to update control family", err)
+		return err
+	}
+	return nil
+}
+
+// Delete 删除控制族，外键ON DELETE SET NULL会解除成员配置项的关联
+func (r *controlFamilyRepository) Delete(ctx context.Context, id uint) error {
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 显式解除关联，不依赖外键是否已创建
+		if err := tx.Model(&models.ConfigurationItem{}).
+			Where("control_family_id = ?", id).
+			Update("control_family_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.ControlFamily{}, id).Error
+	})
+	if err != nil {
+		logger.Error("Failed to delete control family", err)
+		return err
+	}
+	return nil
+}
+
+// GetConfigItems 获取控制族的成员配置项
+func (r *controlFamilyRepository) GetConfigItems(ctx context.Context, familyID uint) ([]models.ConfigurationItem, error) {
+	var items []models.ConfigurationItem
+	err := r.DB.WithContext(ctx).
+		Preload("Provider").
+		Preload("Product").
+		Where("control_family_id = ?", familyID).
+		Order("cloud_provider_id, id").
+		Find(&items).Error
+	if err != nil {
+		logger.Error("Failed to get control family config items", err)
+		return nil, err
+	}
+	return items, nil
+}
+
+// SetConfigItemsFamily 设置配置项所属控制族
+func (r *controlFamilyRepository) SetConfigItemsFamily(ctx context.Context, itemIDs []uint, familyID *uint) error {
+	err := r.DB.WithContext(ctx).Model(&models.ConfigurationItem{}).
+		Where("id IN ?", itemIDs).
+		Update("control_family_id", familyID).Error
+	if err != nil {
+		logger.Error("Failed to set config items family", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,303 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrInvalidCursor 无效的分页游标
+var ErrInvalidCursor = errors.New("无效的分页游标")
+
+// cursorToken 游标内容，编码后对客户端不透明
+type cursorToken struct {
+	Sort   string        `json:"s"`           // 排序签名，防止游标与排序参数不一致
+	Values []interface{} `json:"v"`           // 边界记录的排序键值
+	Before bool          `json:"b,omitempty"` // 为true时表示向前翻页
+}
+
+// sortKeys 返回游标分页使用的排序键，以主键兜底保证唯一
+func (o ListOptions) sortKeys() []SortField {
+	keys := make([]SortField, 0, len(o.Sort)+1)
+	for _, sf := range o.Sort {
+		keys = append(keys, sf)
+		if sf.Column == "id" {
+			return keys
+		}
+	}
+	return append(keys, SortField{Column: "id"})
+}
+
+// sortSignature 生成排序签名
+func sortSignature(keys []SortField) string {
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = k.Column
+		if k.Desc {
+			parts[i] = "-" + k.Column
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// encodeCursor 根据边界记录生成游标
+func encodeCursor(row reflect.Value, keys []SortField, before bool) string {
+	token := cursorToken{Sort: sortSignature(keys), Before: before}
+	for _, k := range keys {
+		v, _ := columnValue(row, k.Column)
+		// 可空列为指针字段，NULL编码为null
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				token.Values = append(token.Values, nil)
+				continue
+			}
+			v = v.Elem()
+		}
+		if t, ok := v.Interface().(time.Time); ok {
+			token.Values = append(token.Values, t.Format(time.RFC3339Nano))
+			continue
+		}
+		token.Values = append(token.Values, v.Interface())
+	}
+
+	raw, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodeCursor 解析游标，并按模型字段类型还原排序键值
+func decodeCursor(cursor string, keys []SortField, model reflect.Type) (*cursorToken, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var token cursorToken
+	if err := json.Unmarshal(raw, &token); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if token.Sort != sortSignature(keys) || len(token.Values) != len(keys) {
+		return nil, ErrInvalidCursor
+	}
+
+	zero := reflect.New(model).Elem()
+	for i, k := range keys {
+		field, ok := columnValue(zero, k.Column)
+		if !ok {
+			return nil, ErrInvalidCursor
+		}
+		if field.Kind() == reflect.Ptr {
+			if token.Values[i] == nil {
+				continue
+			}
+			field = reflect.New(field.Type().Elem()).Elem()
+		}
+
+		switch field.Interface().(type) {
+		case time.Time:
+			s, ok := token.Values[i].(string)
+			if !ok {
+				return nil, ErrInvalidCursor
+			}
+			t, err := time.Parse(time.RFC3339Nano, s)
+			if err != nil {
+				return nil, ErrInvalidCursor
+			}
+			token.Values[i] = t
+		case string:
+			if _, ok := token.Values[i].(string); !ok {
+				return nil, ErrInvalidCursor
+			}
+		default:
+			n, ok := token.Values[i].(float64)
+			if !ok || n < 0 {
+				return nil, ErrInvalidCursor
+			}
+			token.Values[i] = uint(n)
+		}
+	}
+	return &token, nil
+}
+
+// keysetCondition 生成键集分页条件：(k1 > v1) OR (k1 = v1 AND k2 > v2) ...
+// 可空列按MySQL的排序规则处理NULL：NULL小于所有非NULL值，升序时排在最前，降序时排在最后
+func keysetCondition(table string, keys []SortField, values []interface{}, before bool, model reflect.Type) (string, []interface{}) {
+	zero := reflect.New(model).Elem()
+	var clauses []string
+	var args []interface{}
+	for i, k := range keys {
+		var parts []string
+		var partArgs []interface{}
+		for j := 0; j < i; j++ {
+			if values[j] == nil {
+				parts = append(parts, table+"."+keys[j].Column+" IS NULL")
+				continue
+			}
+			parts = append(parts, table+"."+keys[j].Column+" = ?")
+			partArgs = append(partArgs, values[j])
+		}
+
+		column := table + "." + k.Column
+		field, _ := columnValue(zero, k.Column)
+		greater := k.Desc == before
+		switch {
+		case values[i] == nil && greater:
+			parts = append(parts, column+" IS NOT NULL")
+		case values[i] == nil:
+			// 没有比NULL更小的值
+			continue
+		case greater:
+			parts = append(parts, column+" > ?")
+			partArgs = append(partArgs, values[i])
+		case field.Kind() == reflect.Ptr:
+			parts = append(parts, "("+column+" < ? OR "+column+" IS NULL)")
+			partArgs = append(partArgs, values[i])
+		default:
+			parts = append(parts, column+" < ?")
+			partArgs = append(partArgs, values[i])
+		}
+		clauses = append(clauses, "("+strings.Join(parts, " AND ")+")")
+		args = append(args, partArgs...)
+	}
+	return "(" + strings.Join(clauses, " OR ") + ")", args
+}
+
+// orderScope 按排序键排序，reverse为true时反向排序
+func orderScope(table string, keys []SortField, reverse bool) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		for _, k := range keys {
+			direction := " ASC"
+			if k.Desc != reverse {
+				direction = " DESC"
+			}
+			db = db.Order(table + "." + k.Column + direction)
+		}
+		return db
+	}
+}
+
+// cursorPageSize 游标分页的每页大小，规则与Paginate一致
+func cursorPageSize(pageSize int) int {
+	if pageSize <= 0 {
+		return 10
+	}
+	if pageSize > 100 {
+		return 100
+	}
+	return pageSize
+}
+
+// reverseSlice 原地反转切片
+func reverseSlice[T any](s []T) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+// finishCursorPage 根据多取的一条记录判断是否还有数据，截断结果并生成前后游标
+// 结果按请求的排序方向返回
+func finishCursorPage[T any](rows []T, keys []SortField, token *cursorToken, pageSize int) ([]T, string, string) {
+	before := token != nil && token.Before
+	hasMore := len(rows) > pageSize
+	if hasMore {
+		rows = rows[:pageSize]
+	}
+	if before {
+		reverseSlice(rows)
+	}
+	if len(rows) == 0 {
+		return rows, "", ""
+	}
+
+	var next, prev string
+	first, last := reflect.ValueOf(rows[0]), reflect.ValueOf(rows[len(rows)-1])
+	// 向后翻页时，是否有下一页取决于是否多取到记录；向前翻页时总有下一页
+	if hasMore || before {
+		next = encodeCursor(last, keys, false)
+	}
+	// 向前翻页时，是否有上一页取决于是否多取到记录；从游标处向后翻页时总有上一页
+	if (before && hasMore) || (!before && token != nil) {
+		prev = encodeCursor(first, keys, true)
+	}
+	return rows, next, prev
+}
+
+// CursorPage 在查询上执行键集分页，query需已应用过滤条件、字段选择和关联加载
+func CursorPage[T any](query *gorm.DB, schema ListSchema, opts ListOptions, pageSize int) ([]T, string, string, error) {
+	keys := opts.sortKeys()
+	pageSize = cursorPageSize(pageSize)
+
+	var token *cursorToken
+	if opts.Cursor != nil && *opts.Cursor != "" {
+		var err error
+		model := reflect.TypeOf((*T)(nil)).Elem()
+		token, err = decodeCursor(*opts.Cursor, keys, model)
+		if err != nil {
+			return nil, "", "", err
+		}
+		cond, args := keysetCondition(schema.Table, keys, token.Values, token.Before, model)
+		query = query.Where(cond, args...)
+	}
+
+	var rows []T
+	err := query.Scopes(orderScope(schema.Table, keys, token != nil && token.Before)).
+		Limit(pageSize + 1).
+		Find(&rows).Error
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	rows, next, prev := finishCursorPage(rows, keys, token, pageSize)
+	return rows, next, prev, nil
+}
+
+// memoryCursorPage 在已按opts排序的内存数据上执行键集分页
+func memoryCursorPage[T any](sorted []T, opts ListOptions, pageSize int) ([]T, string, string, error) {
+	keys := opts.sortKeys()
+	pageSize = cursorPageSize(pageSize)
+
+	var token *cursorToken
+	candidates := sorted
+	if opts.Cursor != nil && *opts.Cursor != "" {
+		var err error
+		token, err = decodeCursor(*opts.Cursor, keys, reflect.TypeOf((*T)(nil)).Elem())
+		if err != nil {
+			return nil, "", "", err
+		}
+
+		candidates = nil
+		for _, row := range sorted {
+			if keysetMatches(reflect.ValueOf(row), keys, token) {
+				candidates = append(candidates, row)
+			}
+		}
+		if token.Before {
+			candidates = append([]T{}, candidates...)
+			reverseSlice(candidates)
+		}
+	}
+
+	if len(candidates) > pageSize+1 {
+		candidates = candidates[:pageSize+1]
+	}
+	rows, next, prev := finishCursorPage(append([]T{}, candidates...), keys, token, pageSize)
+	return rows, next, prev, nil
+}
+
+// keysetMatches 判断记录是否位于游标之后（或之前）
+func keysetMatches(row reflect.Value, keys []SortField, token *cursorToken) bool {
+	for i, k := range keys {
+		v, _ := columnValue(row, k.Column)
+		c := compareValues(v, reflect.ValueOf(token.Values[i]))
+		if c == 0 {
+			continue
+		}
+		after := (c > 0) != k.Desc
+		return after != token.Before
+	}
+	return false
+}
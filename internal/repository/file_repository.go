@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/yourusername/cloud-eye/internal/models"
+	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// FileRepository 托管文件仓库接口
+type FileRepository interface {
+	Repository
+	Create(ctx context.Context, file *models.StoredFile) error
+	GetByID(ctx context.Context, id uint) (*models.StoredFile, error)
+	Delete(ctx context.Context, id uint) error
+	// Expired 按ID顺序获取在before之前过期的文件
+	Expired(ctx context.Context, before time.Time, limit int) ([]models.StoredFile, error)
+}
+
+// fileRepository 托管文件仓库实现
+type fileRepository struct {
+	BaseRepository
+}
+
+// NewFileRepository 创建托管文件仓库
+func NewFileRepository(db *gorm.DB) FileRepository {
+	return &fileRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// Create 保存文件记录
+func (r *fileRepository) Create(ctx context.Context, file *models.StoredFile) error {
+	err := r.DB.WithContext(ctx).Create(file).Error
+	if err != nil {
+		logger.Error("Failed to create stored file", err)
+		return err
+	}
+	return nil
+}
+
+// GetByID 根据ID获取文件记录
+func (r *fileRepository) GetByID(ctx context.Context, id uint) (*models.StoredFile, error) {
+	var file models.StoredFile
+	err := r.DB.WithContext(ctx).First(&file, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.Error("Failed to get stored file by ID", err)
+		return nil, err
+	}
+	return &file, nil
+}
+
+// Delete 删除文件记录
+func (r *fileRepository) Delete(ctx context.Context, id uint) error {
+	err := r.DB.WithContext(ctx).Delete(&models.StoredFile{}, id).Error
+	if err != nil {
+		logger.Error("Failed to delete stored file", err)
+		return err
+	}
+	return nil
+}
+
+// Expired 获取已过期的文件记录
+func (r *fileRepository) Expired(ctx context.Context, before time.Time, limit int) ([]models.StoredFile, error) {
+	var files []models.StoredFile
+	err := r.DB.WithContext(ctx).
+		Where("expires_at < ?", before).
+		Order("id").
+		Limit(limit).
+		Find(&files).Error
+	if err != nil {
+		logger.Error("Failed to get expired stored files", err)
+		return nil, err
+	}
+	return files, nil
+}
@@ -0,0 +1,245 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/yourusername/cloud-eye/internal/models"
+	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// JobFilter 后台任务查询条件
+type JobFilter struct {
+	Type     string `json:"type,omitempty"`
+	Status   string `json:"status,omitempty"`
+	Page     int    `json:"page"`
+	PageSize int    `json:"page_size"`
+}
+
+// JobRepository 后台任务仓库接口
+type JobRepository interface {
+	Repository
+	Create(ctx context.Context, job *models.Job) error
+	GetByID(ctx context.Context, id uint) (*models.Job, error)
+	// List 按创建时间倒序分页查询任务
+	List(ctx context.Context, filter JobFilter) (*PageResult, error)
+	// Queued 按创建顺序获取等待执行的任务
+	Queued(ctx context.Context, limit int) ([]models.Job, error)
+	// Claim 将等待执行的任务标记为执行中，记录领取的实例和租约到期时间并增加执行次数，任务已被取消或领取时返回false
+	Claim(ctx context.Context, id uint, owner string, lease time.Duration) (bool, error)
+	// Heartbeat 续期owner执行中的任务的租约，任务已不属于owner（租约过期后被放回队列）时返回false
+	Heartbeat(ctx context.Context, id uint, owner string, lease time.Duration) (bool, error)
+	// Release 将owner执行中的任务放回队列，用于实例退出时交还中断的任务
+	Release(ctx context.Context, id uint, owner string) error
+	// Update 保存任务，不覆盖取消标记
+	Update(ctx context.Context, job *models.Job) error
+	// UpdateProgress 只更新任务进度
+	UpdateProgress(ctx context.Context, id uint, progress, total int, message string) error
+	// RequestCancel 标记任务需要取消，返回更新后的任务
+	RequestCancel(ctx context.Context, id uint) (*models.Job, error)
+	// Requeue 将租约已过期的执行中任务重新放回队列，已请求取消的直接取消，用于恢复实例崩溃或失联时中断的任务；
+	// 租约未过期的任务可能仍在其他实例上执行，不做处理
+	Requeue(ctx context.Context) (int64, error)
+}
+
+// jobRepository 后台任务仓库实现
+type jobRepository struct {
+	BaseRepository
+}
+
+// NewJobRepository 创建后台任务仓库
+func NewJobRepository(db *gorm.DB) JobRepository {
+	return &jobRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// Create 创建任务
+func (r *jobRepository) Create(ctx context.Context, job *models.Job) error {
+	err := r.DB.WithContext(ctx).Create(job).Error
+	if err != nil {
+		logger.Error("Failed to create job", err)
+		return err
+	}
+	return nil
+}
+
+// GetByID 根据ID获取任务
+func (r *jobRepository) GetByID(ctx context.Context, id uint) (*models.Job, error) {
+	var job models.Job
+	err := r.DB.WithContext(ctx).First(&job, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.Error("Failed to get job by ID", err)
+		return nil, err
+	}
+	return &job, nil
+}
+
+// List 分页查询任务
+func (r *jobRepository) List(ctx context.Context, filter JobFilter) (*PageResult, error) {
+	query := r.DB.WithContext(ctx).Model(&models.Job{})
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		logger.Error("Failed to count jobs", err)
+		return nil, err
+	}
+
+	var jobs []models.Job
+	err := query.Omit("params").
+		Order("id DESC").
+		Scopes(Paginate(filter.Page, filter.PageSize)).
+		Find(&jobs).Error
+	if err != nil {
+		logger.Error("Failed to list jobs", err)
+		return nil, err
+	}
+
+	return &PageResult{
+		Total:    total,
+		Page:     filter.Page,
+		PageSize: filter.PageSize,
+		Data:     jobs,
+	}, nil
+}
+
+// Queued 获取等待执行的任务
+func (r *jobRepository) Queued(ctx context.Context, limit int) ([]models.Job, error) {
+	var jobs []models.Job
+	err := r.DB.WithContext(ctx).
+		Where("status = ?", models.JobStatusQueued).
+		Order("id").
+		Limit(limit).
+		Find(&jobs).Error
+	if err != nil {
+		logger.Error("Failed to get queued jobs", err)
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// Claim 领取等待执行的任务
+func (r *jobRepository) Claim(ctx context.Context, id uint, owner string, lease time.Duration) (bool, error) {
+	now := time.Now()
+	result := r.DB.WithContext(ctx).Model(&models.Job{}).
+		Where("id = ? AND status = ?", id, models.JobStatusQueued).
+		Updates(map[string]interface{}{
+			"status":           models.JobStatusRunning,
+			"attempts":         gorm.Expr("attempts + 1"),
+			"owner":            owner,
+			"lease_expires_at": now.Add(lease),
+			"started_at":       now,
+		})
+	if result.Error != nil {
+		logger.Error("Failed to claim job", result.Error)
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// Heartbeat 续期执行中任务的租约
+func (r *jobRepository) Heartbeat(ctx context.Context, id uint, owner string, lease time.Duration) (bool, error) {
+	result := r.DB.WithContext(ctx).Model(&models.Job{}).
+		Where("id = ? AND status = ? AND owner = ?", id, models.JobStatusRunning, owner).
+		Update("lease_expires_at", time.Now().Add(lease))
+	if result.Error != nil {
+		logger.Error("Failed to renew job lease", result.Error)
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// Release 将执行中的任务放回队列
+func (r *jobRepository) Release(ctx context.Context, id uint, owner string) error {
+	err := r.DB.WithContext(ctx).Model(&models.Job{}).
+		Where("id = ? AND status = ? AND owner = ?", id, models.JobStatusRunning, owner).
+		Updates(map[string]interface{}{"status": models.JobStatusQueued, "lease_expires_at": nil}).Error
+	if err != nil {
+		logger.Error("Failed to release job", err)
+		return err
+	}
+	return nil
+}
+
+// Update 保存任务，取消标记只由RequestCancel修改
+func (r *jobRepository) Update(ctx context.Context, job *models.Job) error {
+	err := r.DB.WithContext(ctx).Omit("cancel_requested").Save(job).Error
+	if err != nil {
+		logger.Error("Failed to update job", err)
+		return err
+	}
+	return nil
+}
+
+// UpdateProgress 更新任务进度
+func (r *jobRepository) UpdateProgress(ctx context.Context, id uint, progress, total int, message string) error {
+	err := r.DB.WithContext(ctx).Model(&models.Job{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"progress": progress, "total": total, "message": message}).Error
+	if err != nil {
+		logger.Error("Failed to update job progress", err)
+		return err
+	}
+	return nil
+}
+
+// RequestCancel 标记任务需要取消，等待执行的任务直接取消
+func (r *jobRepository) RequestCancel(ctx context.Context, id uint) (*models.Job, error) {
+	now := time.Now()
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.Job{}).
+			Where("id = ? AND status = ?", id, models.JobStatusQueued).
+			Updates(map[string]interface{}{
+				"status":           models.JobStatusCanceled,
+				"cancel_requested": true,
+				"finished_at":      now,
+			}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&models.Job{}).
+			Where("id = ? AND status = ?", id, models.JobStatusRunning).
+			Update("cancel_requested", true).Error
+	})
+	if err != nil {
+		logger.Error("Failed to cancel job", err)
+		return nil, err
+	}
+	return r.GetByID(ctx, id)
+}
+
+// Requeue 将租约已过期的执行中任务放回队列，没有租约的执行中任务视为已过期
+func (r *jobRepository) Requeue(ctx context.Context) (int64, error) {
+	var requeued int64
+	now := time.Now()
+	expired := func(tx *gorm.DB) *gorm.DB {
+		return tx.Model(&models.Job{}).
+			Where("status = ? AND (lease_expires_at IS NULL OR lease_expires_at < ?)", models.JobStatusRunning, now)
+	}
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := expired(tx).Where("cancel_requested = ?", true).
+			Updates(map[string]interface{}{"status": models.JobStatusCanceled, "lease_expires_at": nil, "finished_at": now}).Error
+		if err != nil {
+			return err
+		}
+		result := expired(tx).
+			Updates(map[string]interface{}{"status": models.JobStatusQueued, "lease_expires_at": nil})
+		requeued = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		logger.Error("Failed to requeue interrupted jobs", err)
+		return 0, err
+	}
+	return requeued, nil
+}
@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SortField 排序字段
+type SortField struct {
+	Column string `json:"column"`
+	Desc   bool   `json:"desc,omitempty"`
+}
+
+// ListOptions 列表查询的通用选项：时间范围、排序、字段选择和关联加载
+type ListOptions struct {
+	CreatedFrom *time.Time  `json:"created_from,omitempty"`
+	CreatedTo   *time.Time  `json:"created_to,omitempty"`
+	UpdatedFrom *time.Time  `json:"updated_from,omitempty"`
+	UpdatedTo   *time.Time  `json:"updated_to,omitempty"`
+	Sort        []SortField `json:"sort,omitempty"`
+	// Fields 仅返回的列，为空时返回全部列
+	Fields []string `json:"fields,omitempty"`
+	// Include 需要加载的关联，为nil时使用各列表的默认关联，为空切片时不加载任何关联
+	Include []string `json:"include,omitempty"`
+	// WithCounts 是否返回统计列（例如服务商的产品数、产品的配置项数）
+	WithCounts bool `json:"with_counts,omitempty"`
+	// Cursor 游标分页位置，非nil时使用键集分页代替页码分页，空字符串表示第一页
+	Cursor *string `json:"cursor,omitempty"`
+}
+
+// CursorMode 判断是否使用游标分页
+func (o ListOptions) CursorMode() bool {
+	return o.Cursor != nil
+}
+
+// ListSchema 列表可用的列和关联定义
+type ListSchema struct {
+	Table string
+	// Columns 可用于排序和字段选择的列
+	Columns []string
+	// Required 字段选择时始终查询的列（主键和加载关联所需的外键）
+	Required []string
+	// Relations 可加载的关联，键为查询参数中的名称，值为GORM关联名
+	Relations map[string]string
+	// DefaultInclude 未指定Include时加载的关联
+	DefaultInclude []string
+	// Counts 可选的统计列，键为列别名，值为计算该列的相关子查询
+	Counts map[string]string
+}
+
+// 各列表的字段定义
+var (
+	CloudProviderListSchema = ListSchema{
+		Table:     "cloud_providers",
+		Columns:   []string{"id", "name", "code", "description", "created_at", "updated_at"},
+		Required:  []string{"id"},
+		Relations: map[string]string{"products": "Products", "tags": "Tags"},
+		Counts: map[string]string{
+			"product_count": "SELECT COUNT(*) FROM cloud_products WHERE cloud_products.cloud_provider_id = cloud_providers.id AND cloud_products.deleted_at IS NULL",
+		},
+	}
+
+	CloudProductListSchema = ListSchema{
+		Table:    "cloud_products",
+		Columns:  []string{"id", "cloud_provider_id", "name", "code", "description", "category_id", "created_at", "updated_at"},
+		Required: []string{"id", "cloud_provider_id", "category_id"},
+		Relations: map[string]string{
+			"provider":     "Provider",
+			"category":     "Category",
+			"tags":         "Tags",
+			"config_items": "ConfigItems",
+		},
+		Counts: map[string]string{
+			"config_item_count": "SELECT COUNT(*) FROM configuration_items WHERE configuration_items.product_id = cloud_products.id AND configuration_items.deleted_at IS NULL",
+		},
+	}
+
+	ConfigItemListSchema = ListSchema{
+		Table: "configuration_items",
+		Columns: []string{
+			"id", "cloud_provider_id", "product_id", "name", "recommended_value", "risk_description",
+			"check_method", "configuration_method", "reference", "severity", "status", "control_family_id",
+			"created_at", "updated_at",
+		},
+		Required: []string{"id", "cloud_provider_id", "product_id"},
+		Relations: map[string]string{
+			"provider":         "Provider",
+			"product":          "Product",
+			"product.category": "Product.Category",
+			"tags":             "Tags",
+		},
+		DefaultInclude: []string{"provider", "product"},
+	}
+)
+
+// hasColumn 判断列是否可用
+func (s ListSchema) hasColumn(column string) bool {
+	for _, c := range s.Columns {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate 校验列表选项中的列和关联是否可用
+func (o ListOptions) Validate(schema ListSchema) error {
+	for _, sf := range o.Sort {
+		if !schema.hasColumn(sf.Column) {
+			return fmt.Errorf("不支持的排序字段: %s", sf.Column)
+		}
+	}
+	for _, f := range o.Fields {
+		if !schema.hasColumn(f) {
+			return fmt.Errorf("不支持的返回字段: %s", f)
+		}
+	}
+	for _, inc := range o.Include {
+		if _, ok := schema.Relations[inc]; !ok {
+			return fmt.Errorf("不支持的关联: %s", inc)
+		}
+	}
+	return nil
+}
+
+// Includes 返回实际需要加载的关联
+func (o ListOptions) Includes(schema ListSchema) []string {
+	if o.Include == nil {
+		return schema.DefaultInclude
+	}
+	return o.Include
+}
+
+// ResponseKeys 返回字段选择时除请求字段外需保留的响应键：已加载的关联和统计列
+func (o ListOptions) ResponseKeys(schema ListSchema) []string {
+	keys := make([]string, 0, len(o.Includes(schema)))
+	for _, inc := range o.Includes(schema) {
+		// 嵌套关联（如product.category）挂在第一级关联的键下
+		keys = append(keys, strings.SplitN(inc, ".", 2)[0])
+	}
+	if o.WithCounts {
+		for alias := range schema.Counts {
+			keys = append(keys, alias)
+		}
+	}
+	return keys
+}
+
+// includes 判断是否需要加载指定关联
+func (o ListOptions) includes(schema ListSchema, relation string) bool {
+	for _, inc := range o.Includes(schema) {
+		if inc == relation {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterScope 返回时间范围过滤条件
+func (o ListOptions) FilterScope(schema ListSchema) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if o.CreatedFrom != nil {
+			db = db.Where(schema.Table+".created_at >= ?", *o.CreatedFrom)
+		}
+		if o.CreatedTo != nil {
+			db = db.Where(schema.Table+".created_at <= ?", *o.CreatedTo)
+		}
+		if o.UpdatedFrom != nil {
+			db = db.Where(schema.Table+".updated_at >= ?", *o.UpdatedFrom)
+		}
+		if o.UpdatedTo != nil {
+			db = db.Where(schema.Table+".updated_at <= ?", *o.UpdatedTo)
+		}
+		return db
+	}
+}
+
+// QueryScope 返回排序、字段选择和关联加载，需在Count之后应用
+func (o ListOptions) QueryScope(schema ListSchema) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		// 以主键兜底，保证排序稳定
+		return db.Scopes(o.SelectScope(schema), orderScope(schema.Table, o.sortKeys(), false))
+	}
+}
+
+// SelectScope 返回字段选择和关联加载
+func (o ListOptions) SelectScope(schema ListSchema) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if len(o.Fields) > 0 {
+			// 排序列用于生成分页游标，需一并查询
+			wanted := append([]string{}, schema.Required...)
+			for _, k := range o.sortKeys() {
+				wanted = append(wanted, k.Column)
+			}
+			wanted = append(wanted, o.Fields...)
+
+			columns := make([]string, 0, len(wanted))
+			seen := make(map[string]bool)
+			for _, c := range wanted {
+				if !seen[c] {
+					seen[c] = true
+					columns = append(columns, schema.Table+"."+c)
+				}
+			}
+			if o.WithCounts {
+				columns = append(columns, countColumns(schema)...)
+			}
+			db = db.Select(columns)
+		} else if o.WithCounts && len(schema.Counts) > 0 {
+			db = db.Select(append([]string{schema.Table + ".*"}, countColumns(schema)...))
+		}
+
+		for _, inc := range o.Includes(schema) {
+			if relation, ok := schema.Relations[inc]; ok {
+				db = db.Preload(relation)
+			}
+		}
+		return db
+	}
+}
+
+// countColumns 返回统计列的查询表达式
+func countColumns(schema ListSchema) []string {
+	columns := make([]string, 0, len(schema.Counts))
+	for alias, subquery := range schema.Counts {
+		columns = append(columns, "("+subquery+") AS "+alias)
+	}
+	return columns
+}
+
+// limitPerParent 将已过滤的查询作为子查询，每个父对象只保留ID最小的limit行；
+// 子查询沿用原表名作为别名，外层查询仍可应用排序、分页和关联加载
+func limitPerParent(db, query *gorm.DB, model interface{}, schema ListSchema, parentColumn string, limit int) *gorm.DB {
+	ranked := query.Select(fmt.Sprintf("%[1]s.*, ROW_NUMBER() OVER (PARTITION BY %[1]s.%[2]s ORDER BY %[1]s.id) AS parent_rank",
+		schema.Table, parentColumn))
+	return db.Model(model).Table("(?) AS "+schema.Table, ranked).Where("parent_rank <= ?", limit)
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/yourusername/cloud-eye/internal/models"
+)
+
+// memoryChangeEventRepository 变更事件仓库内存实现
+type memoryChangeEventRepository struct {
+	memoryBaseRepository
+}
+
+// NewMemoryChangeEventRepository 创建变更事件仓库内存实现
+func NewMemoryChangeEventRepository(store *MemoryStore) ChangeEventRepository {
+	return &memoryChangeEventRepository{
+		memoryBaseRepository: memoryBaseRepository{store: store},
+	}
+}
+
+// Create 保存事件
+func (r *memoryChangeEventRepository) Create(ctx context.Context, event *models.ChangeEvent) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	event.ID = r.store.allocID("change_events")
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+	r.store.changeEvents = append(r.store.changeEvents, *event)
+	return nil
+}
+
+// ListAfter 按序号升序获取afterID之后的事件
+func (r *memoryChangeEventRepository) ListAfter(ctx context.Context, afterID uint, filter ChangeEventFilter, limit int) ([]models.ChangeEvent, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	events := r.store.changeEvents
+	start := sort.Search(len(events), func(i int) bool { return events[i].ID > afterID })
+
+	var result []models.ChangeEvent
+	for i := start; i < len(events) && len(result) < limit; i++ {
+		if filter.Match(&events[i]) {
+			result = append(result, events[i])
+		}
+	}
+	return result, nil
+}
+
+// Bounds 获取保留的最早和最新事件序号
+func (r *memoryChangeEventRepository) Bounds(ctx context.Context) (uint, uint, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	events := r.store.changeEvents
+	if len(events) == 0 {
+		return 0, 0, nil
+	}
+	return events[0].ID, events[len(events)-1].ID, nil
+}
+
+// DeleteBefore 删除早于before的事件
+func (r *memoryChangeEventRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	events := r.store.changeEvents
+	n := sort.Search(len(events), func(i int) bool { return !events[i].CreatedAt.Before(before) })
+	r.store.changeEvents = append([]models.ChangeEvent(nil), events[n:]...)
+	return int64(n), nil
+}
@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/yourusername/cloud-eye/internal/models"
+	"gorm.io/gorm"
+)
+
+// memoryCloudProductRepository 云产品仓库内存实现
+type memoryCloudProductRepository struct {
+	memoryBaseRepository
+}
+
+// NewMemoryCloudProductRepository 创建云产品仓库内存实现
+func NewMemoryCloudProductRepository(store *MemoryStore) CloudProductRepository {
+	return &memoryCloudProductRepository{
+		memoryBaseRepository: memoryBaseRepository{store: store},
+	}
+}
+
+// GetAll 获取所有云产品
+func (r *memoryCloudProductRepository) GetAll(ctx context.Context) ([]models.CloudProduct, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return r.store.sortedProducts(nil), nil
+}
+
+// List 根据过滤条件获取云产品列表
+func (r *memoryCloudProductRepository) List(ctx context.Context, filter CloudProductFilter) ([]models.CloudProduct, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return r.withIncludes(r.list(filter), filter), nil
+}
+
+// ListPage 根据过滤条件分页获取云产品列表，指定游标时使用游标分页，否则按页码分页
+func (r *memoryCloudProductRepository) ListPage(ctx context.Context, filter CloudProductFilter) (*PageResult, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	products := r.list(filter)
+	if filter.CursorMode() {
+		page, next, prev, err := memoryCursorPage(products, filter.ListOptions, filter.PageSize)
+		if err != nil {
+			return nil, err
+		}
+		return NewCursorPageResult(r.withIncludes(page, filter), filter.PageSize, next, prev), nil
+	}
+
+	start, end := paginateSlice(len(products), filter.Page, filter.PageSize)
+	return &PageResult{
+		Total:    int64(len(products)),
+		Page:     filter.Page,
+		PageSize: filter.PageSize,
+		Data:     r.withIncludes(products[start:end], filter),
+	}, nil
+}
+
+// list 过滤并排序云产品，调用方需持有锁
+func (r *memoryCloudProductRepository) list(filter CloudProductFilter) []models.CloudProduct {
+	var categoryIDs []uint
+	if len(filter.CategoryIDs) > 0 {
+		categoryIDs = r.store.categorySubtree(filter.CategoryIDs)
+	}
+	products := applyMemoryListOptions(r.store.sortedProducts(func(p models.CloudProduct) bool {
+		if len(filter.IDs) > 0 && !containsID(filter.IDs, p.ID) {
+			return false
+		}
+		if len(filter.CloudProviderIDs) > 0 && !containsID(filter.CloudProviderIDs, p.CloudProviderID) {
+			return false
+		}
+		if len(filter.Codes) > 0 && !containsString(filter.Codes, p.Code) {
+			return false
+		}
+		if len(categoryIDs) > 0 && (p.CategoryID == nil || !containsID(categoryIDs, *p.CategoryID)) {
+			return false
+		}
+		return filter.Keyword == nil || *filter.Keyword == "" ||
+			containsFold(p.Name, *filter.Keyword) || containsFold(p.Code, *filter.Keyword) || containsFold(p.Description, *filter.Keyword)
+	}), filter.ListOptions)
+	if filter.PerProviderLimit > 0 {
+		products = limitPerParentSlice(products, func(p models.CloudProduct) (uint, uint) {
+			return p.CloudProviderID, p.ID
+		}, filter.PerProviderLimit)
+	}
+	return products
+}
+
+// withIncludes 加载请求的关联和统计列，调用方需持有锁
+func (r *memoryCloudProductRepository) withIncludes(products []models.CloudProduct, filter CloudProductFilter) []models.CloudProduct {
+	for i := range products {
+		if filter.includes(CloudProductListSchema, "provider") {
+			products[i].Provider = r.store.providers[products[i].CloudProviderID]
+		}
+		if filter.includes(CloudProductListSchema, "category") {
+			products[i].Category = r.store.productCategory(products[i])
+		}
+		if filter.includes(CloudProductListSchema, "tags") {
+			products[i].Tags = r.store.entityTags(TagTargetProduct, products[i].ID)
+		}
+		if filter.includes(CloudProductListSchema, "config_items") {
+			products[i].ConfigItems = r.store.sortedConfigItems(func(item models.ConfigurationItem) bool {
+				return item.ProductID == products[i].ID
+			})
+		}
+		if filter.WithCounts {
+			var count int64
+			for _, item := range r.store.configItems {
+				if item.ProductID == products[i].ID {
+					count++
+				}
+			}
+			products[i].ConfigItemCount = &count
+		}
+	}
+	return products
+}
+
+// GetByID 根据ID获取云产品
+func (r *memoryCloudProductRepository) GetByID(ctx context.Context, id uint) (*models.CloudProduct, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	product, ok := r.store.products[id]
+	if !ok {
+		return nil, nil
+	}
+	product.Tags = r.store.entityTags(TagTargetProduct, product.ID)
+	return &product, nil
+}
+
+// GetByProviderID 根据云服务商ID获取云产品
+func (r *memoryCloudProductRepository) GetByProviderID(ctx context.Context, providerID uint) ([]models.CloudProduct, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return r.store.sortedProducts(func(p models.CloudProduct) bool {
+		return p.CloudProviderID == providerID
+	}), nil
+}
+
+// GetByProviderCode 根据云服务商代码获取云产品
+func (r *memoryCloudProductRepository) GetByProviderCode(ctx context.Context, providerCode string) ([]models.CloudProduct, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return r.store.sortedProducts(func(p models.CloudProduct) bool {
+		provider, ok := r.store.providers[p.CloudProviderID]
+		return ok && provider.Code == providerCode
+	}), nil
+}
+
+// GetByCode 根据代码和云服务商ID获取云产品
+func (r *memoryCloudProductRepository) GetByCode(ctx context.Context, providerID uint, code string) (*models.CloudProduct, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, product := range r.store.products {
+		if product.CloudProviderID == providerID && product.Code == code {
+			return &product, nil
+		}
+	}
+	return nil, nil
+}
+
+// Create 创建云产品
+func (r *memoryCloudProductRepository) Create(ctx context.Context, product *models.CloudProduct) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if err := r.store.checkProductRefs(product); err != nil {
+		return err
+	}
+	if r.store.productCodeTaken(product.CloudProviderID, product.Code, product.ID) {
+		return gorm.ErrDuplicatedKey
+	}
+	if _, ok := r.store.products[product.ID]; ok && product.ID != 0 {
+		return gorm.ErrDuplicatedKey
+	}
+
+	r.store.touchCreate("cloud_products", &product.BaseModel)
+	r.store.products[product.ID] = stripProduct(*product)
+	return nil
+}
+
+// Update 更新云产品，记录不存在时按Save语义插入
+func (r *memoryCloudProductRepository) Update(ctx context.Context, product *models.CloudProduct) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if err := r.store.checkProductRefs(product); err != nil {
+		return err
+	}
+	if r.store.productCodeTaken(product.CloudProviderID, product.Code, product.ID) {
+		return gorm.ErrDuplicatedKey
+	}
+
+	if existing, ok := r.store.products[product.ID]; ok {
+		product.CreatedAt = existing.CreatedAt
+	}
+	r.store.touchCreate("cloud_products", &product.BaseModel)
+	r.store.products[product.ID] = stripProduct(*product)
+	return nil
+}
+
+// Patch 只更新指定列；服务层合并补丁后的记录已包含其余列的原值，内存实现直接整体保存
+func (r *memoryCloudProductRepository) Patch(ctx context.Context, product *models.CloudProduct, columns []string) error {
+	r.store.mu.RLock()
+	_, ok := r.store.products[product.ID]
+	r.store.mu.RUnlock()
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	return r.Update(ctx, product)
+}
+
+// Delete 软删除云产品，其配置项一同移入回收站
+func (r *memoryCloudProductRepository) Delete(ctx context.Context, id uint) (*CascadeRecords, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	records := &CascadeRecords{}
+	r.store.trashProduct(id, time.Now(), records)
+	records.sortByID()
+	return records, nil
+}
+
+// stripProduct 去除关联对象，存储中只保留外键
+func stripProduct(product models.CloudProduct) models.CloudProduct {
+	product.Provider = models.CloudProvider{}
+	product.Category = nil
+	product.Tags = nil
+	product.ConfigItems = nil
+	product.ConfigItemCount = nil
+	return product
+}
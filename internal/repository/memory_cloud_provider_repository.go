@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/yourusername/cloud-eye/internal/models"
+	"gorm.io/gorm"
+)
+
+// memoryCloudProviderRepository 云服务商仓库内存实现
+type memoryCloudProviderRepository struct {
+	memoryBaseRepository
+}
+
+// NewMemoryCloudProviderRepository 创建云服务商仓库内存实现
+func NewMemoryCloudProviderRepository(store *MemoryStore) CloudProviderRepository {
+	return &memoryCloudProviderRepository{
+		memoryBaseRepository: memoryBaseRepository{store: store},
+	}
+}
+
+// GetAll 获取所有云服务商
+func (r *memoryCloudProviderRepository) GetAll(ctx context.Context) ([]models.CloudProvider, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return r.store.sortedProviders(nil), nil
+}
+
+// List 根据过滤条件获取云服务商列表
+func (r *memoryCloudProviderRepository) List(ctx context.Context, filter CloudProviderFilter) ([]models.CloudProvider, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return r.withIncludes(r.list(filter), filter), nil
+}
+
+// ListPage 根据过滤条件分页获取云服务商列表，指定游标时使用游标分页，否则按页码分页
+func (r *memoryCloudProviderRepository) ListPage(ctx context.Context, filter CloudProviderFilter) (*PageResult, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	providers := r.list(filter)
+	if filter.CursorMode() {
+		page, next, prev, err := memoryCursorPage(providers, filter.ListOptions, filter.PageSize)
+		if err != nil {
+			return nil, err
+		}
+		return NewCursorPageResult(r.withIncludes(page, filter), filter.PageSize, next, prev), nil
+	}
+
+	start, end := paginateSlice(len(providers), filter.Page, filter.PageSize)
+	return &PageResult{
+		Total:    int64(len(providers)),
+		Page:     filter.Page,
+		PageSize: filter.PageSize,
+		Data:     r.withIncludes(providers[start:end], filter),
+	}, nil
+}
+
+// list 过滤并排序云服务商，调用方需持有锁
+func (r *memoryCloudProviderRepository) list(filter CloudProviderFilter) []models.CloudProvider {
+	return applyMemoryListOptions(r.store.sortedProviders(func(p models.CloudProvider) bool {
+		if len(filter.IDs) > 0 && !containsID(filter.IDs, p.ID) {
+			return false
+		}
+		if len(filter.Codes) > 0 && !containsString(filter.Codes, p.Code) {
+			return false
+		}
+		return filter.Keyword == nil || *filter.Keyword == "" ||
+			containsFold(p.Name, *filter.Keyword) || containsFold(p.Code, *filter.Keyword) || containsFold(p.Description, *filter.Keyword)
+	}), filter.ListOptions)
+}
+
+// withIncludes 加载请求的关联和统计列，调用方需持有锁
+func (r *memoryCloudProviderRepository) withIncludes(providers []models.CloudProvider, filter CloudProviderFilter) []models.CloudProvider {
+	for i := range providers {
+		if filter.includes(CloudProviderListSchema, "tags") {
+			providers[i].Tags = r.store.entityTags(TagTargetProvider, providers[i].ID)
+		}
+		if !filter.includes(CloudProviderListSchema, "products") && !filter.WithCounts {
+			continue
+		}
+		products := r.store.sortedProducts(func(p models.CloudProduct) bool {
+			return p.CloudProviderID == providers[i].ID
+		})
+		if filter.includes(CloudProviderListSchema, "products") {
+			providers[i].Products = products
+		}
+		if filter.WithCounts {
+			count := int64(len(products))
+			providers[i].ProductCount = &count
+		}
+	}
+	return providers
+}
+
+// GetByID 根据ID获取云服务商
+func (r *memoryCloudProviderRepository) GetByID(ctx context.Context, id uint) (*models.CloudProvider, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	provider, ok := r.store.providers[id]
+	if !ok {
+		return nil, nil
+	}
+	provider.Tags = r.store.entityTags(TagTargetProvider, provider.ID)
+	return &provider, nil
+}
+
+// GetByCode 根据代码获取云服务商
+func (r *memoryCloudProviderRepository) GetByCode(ctx context.Context, code string) (*models.CloudProvider, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, provider := range r.store.providers {
+		if provider.Code == code {
+			return &provider, nil
+		}
+	}
+	return nil, nil
+}
+
+// Create 创建云服务商
+func (r *memoryCloudProviderRepository) Create(ctx context.Context, provider *models.CloudProvider) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if r.store.providerCodeTaken(provider.Code, provider.ID) {
+		return gorm.ErrDuplicatedKey
+	}
+	if _, ok := r.store.providers[provider.ID]; ok && provider.ID != 0 {
+		return gorm.ErrDuplicatedKey
+	}
+
+	r.store.touchCreate("cloud_providers", &provider.BaseModel)
+	stored := *provider
+	stored.Products = nil
+	stored.Tags = nil
+	stored.ProductCount = nil
+	r.store.providers[stored.ID] = stored
+	return nil
+}
+
+// Update 更新云服务商，记录不存在时按Save语义插入
+func (r *memoryCloudProviderRepository) Update(ctx context.Context, provider *models.CloudProvider) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if r.store.providerCodeTaken(provider.Code, provider.ID) {
+		return gorm.ErrDuplicatedKey
+	}
+
+	if existing, ok := r.store.providers[provider.ID]; ok {
+		provider.CreatedAt = existing.CreatedAt
+	}
+	r.store.touchCreate("cloud_providers", &provider.BaseModel)
+	stored := *provider
+	stored.Products = nil
+	stored.Tags = nil
+	stored.ProductCount = nil
+	r.store.providers[stored.ID] = stored
+	return nil
+}
+
+// Patch 只更新指定列；服务层合并补丁后的记录已包含其余列的原值，内存实现直接整体保存
+func (r *memoryCloudProviderRepository) Patch(ctx context.Context, provider *models.CloudProvider, columns []string) error {
+	r.store.mu.RLock()
+	_, ok := r.store.providers[provider.ID]
+	r.store.mu.RUnlock()
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	return r.Update(ctx, provider)
+}
+
+// Delete 软删除云服务商，其产品和配置项一同移入回收站
+func (r *memoryCloudProviderRepository) Delete(ctx context.Context, id uint) (*CascadeRecords, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	records := &CascadeRecords{}
+	r.store.trashProvider(id, time.Now(), records)
+	records.sortByID()
+	return records, nil
+}
+
+// HasDependants 判断云服务商下是否存在未删除的产品或配置项
+func (r *memoryCloudProviderRepository) HasDependants(ctx context.Context, id uint) (bool, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, p := range r.store.products {
+		if p.CloudProviderID == id {
+			return true, nil
+		}
+	}
+	for _, item := range r.store.configItems {
+		if item.CloudProviderID == id {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,301 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/yourusername/cloud-eye/internal/models"
+	"gorm.io/gorm"
+)
+
+// memoryConfigurationItemRepository 配置项仓库内存实现
+type memoryConfigurationItemRepository struct {
+	memoryBaseRepository
+}
+
+// NewMemoryConfigurationItemRepository 创建配置项仓库内存实现
+func NewMemoryConfigurationItemRepository(store *MemoryStore) ConfigurationItemRepository {
+	return &memoryConfigurationItemRepository{
+		memoryBaseRepository: memoryBaseRepository{store: store},
+	}
+}
+
+// GetByID 根据ID获取配置项
+func (r *memoryConfigurationItemRepository) GetByID(ctx context.Context, id uint) (*models.ConfigurationItem, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	item, ok := r.store.configItems[id]
+	if !ok {
+		return nil, nil
+	}
+	item.Tags = r.store.entityTags(TagTargetConfigItem, item.ID)
+	return &item, nil
+}
+
+// GetByFilter 根据过滤条件获取配置项，支持分页
+func (r *memoryConfigurationItemRepository) GetByFilter(ctx context.Context, filter ConfigItemFilter) (*PageResult, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var categoryIDs []uint
+	if len(filter.CategoryIDs) > 0 {
+		categoryIDs = r.store.categorySubtree(filter.CategoryIDs)
+	}
+
+	matched := r.store.sortedConfigItems(func(item models.ConfigurationItem) bool {
+		if filter.CloudProviderID != nil && item.CloudProviderID != *filter.CloudProviderID {
+			return false
+		}
+		if filter.ProductID != nil && item.ProductID != *filter.ProductID {
+			return false
+		}
+		if len(filter.CloudProviderIDs) > 0 && !containsID(filter.CloudProviderIDs, item.CloudProviderID) {
+			return false
+		}
+		if len(filter.ProductIDs) > 0 && !containsID(filter.ProductIDs, item.ProductID) {
+			return false
+		}
+		if len(categoryIDs) > 0 {
+			product := r.store.products[item.ProductID]
+			if product.CategoryID == nil || !containsID(categoryIDs, *product.CategoryID) {
+				return false
+			}
+		}
+		if len(filter.Tags) > 0 && !r.store.matchTags(TagTargetConfigItem, item.ID, filter.Tags, filter.TagMatch) {
+			return false
+		}
+		if filter.Keyword != nil && *filter.Keyword != "" {
+			kw := *filter.Keyword
+			if !containsFold(item.Name, kw) && !containsFold(item.RecommendedValue, kw) && !containsFold(item.RiskDescription, kw) {
+				return false
+			}
+		}
+		if len(filter.Severities) > 0 && !containsString(filter.Severities, item.Severity) {
+			return false
+		}
+		if len(filter.Statuses) > 0 && !containsString(filter.Statuses, item.Status) {
+			return false
+		}
+		return true
+	})
+	matched = applyMemoryListOptions(matched, filter.ListOptions)
+	if filter.PerProductLimit > 0 {
+		matched = limitPerParentSlice(matched, func(item models.ConfigurationItem) (uint, uint) {
+			return item.ProductID, item.ID
+		}, filter.PerProductLimit)
+	}
+
+	var page []models.ConfigurationItem
+	var next, prev string
+	if filter.CursorMode() {
+		var err error
+		page, next, prev, err = memoryCursorPage(matched, filter.ListOptions, filter.PageSize)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		start, end := paginateSlice(len(matched), filter.Page, filter.PageSize)
+		page = matched[start:end]
+	}
+
+	items := make([]models.ConfigurationItem, 0, len(page))
+	for _, item := range page {
+		if filter.includes(ConfigItemListSchema, "provider") {
+			item.Provider = r.store.providers[item.CloudProviderID]
+		}
+		if filter.includes(ConfigItemListSchema, "product") {
+			item.Product = r.store.products[item.ProductID]
+		}
+		if filter.includes(ConfigItemListSchema, "product.category") {
+			item.Product = r.store.products[item.ProductID]
+			item.Product.Category = r.store.productCategory(item.Product)
+		}
+		if filter.includes(ConfigItemListSchema, "tags") {
+			item.Tags = r.store.entityTags(TagTargetConfigItem, item.ID)
+		}
+		items = append(items, item)
+	}
+
+	if filter.CursorMode() {
+		return NewCursorPageResult(items, filter.PageSize, next, prev), nil
+	}
+	return &PageResult{
+		Total:    int64(len(matched)),
+		Page:     filter.Page,
+		PageSize: filter.PageSize,
+		Data:     items,
+	}, nil
+}
+
+// GetByProviderAndProduct 根据云服务商ID和产品ID获取配置项
+func (r *memoryConfigurationItemRepository) GetByProviderAndProduct(ctx context.Context, providerID, productID uint) ([]models.ConfigurationItem, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return r.store.sortedConfigItems(func(item models.ConfigurationItem) bool {
+		return item.CloudProviderID == providerID && item.ProductID == productID
+	}), nil
+}
+
+// Create 创建配置项
+func (r *memoryConfigurationItemRepository) Create(ctx context.Context, item *models.ConfigurationItem) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.insert(item)
+}
+
+// Update 更新配置项，记录不存在时按Save语义插入
+func (r *memoryConfigurationItemRepository) Update(ctx context.Context, item *models.ConfigurationItem) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if err := r.store.checkItemRefs(item); err != nil {
+		return err
+	}
+
+	if existing, ok := r.store.configItems[item.ID]; ok {
+		item.CreatedAt = existing.CreatedAt
+	}
+	item.ApplyDefaults()
+	r.store.touchCreate("configuration_items", &item.BaseModel)
+	r.store.configItems[item.ID] = stripConfigItem(*item)
+	return nil
+}
+
+// Patch 只更新指定列；服务层合并补丁后的记录已包含其余列的原值，内存实现直接整体保存
+func (r *memoryConfigurationItemRepository) Patch(ctx context.Context, item *models.ConfigurationItem, columns []string) error {
+	r.store.mu.RLock()
+	_, ok := r.store.configItems[item.ID]
+	r.store.mu.RUnlock()
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	return r.Update(ctx, item)
+}
+
+// Delete 软删除配置项
+func (r *memoryConfigurationItemRepository) Delete(ctx context.Context, id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.store.trashConfigItem(id, time.Now(), nil)
+	return nil
+}
+
+// BatchInsert 批量插入配置项，任一记录失败时整体回滚
+func (r *memoryConfigurationItemRepository) BatchInsert(ctx context.Context, items []models.ConfigurationItem) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for i := range items {
+		if err := r.store.checkItemRefs(&items[i]); err != nil {
+			return err
+		}
+	}
+
+	for i := range items {
+		if err := r.insert(&items[i]); err != nil {
+			return err
+		}
+		if len(items[i].Tags) > 0 {
+			r.store.linkTags(TagTargetConfigItem, items[i].ID, r.store.ensureTags(models.TagNames(items[i].Tags)))
+		}
+	}
+	return nil
+}
+
+// insert 插入单条配置项，调用方需持有写锁
+func (r *memoryConfigurationItemRepository) insert(item *models.ConfigurationItem) error {
+	if err := r.store.checkItemRefs(item); err != nil {
+		return err
+	}
+	if _, ok := r.store.configItems[item.ID]; ok && item.ID != 0 {
+		return gorm.ErrDuplicatedKey
+	}
+
+	item.ApplyDefaults()
+	r.store.touchCreate("configuration_items", &item.BaseModel)
+	r.store.configItems[item.ID] = stripConfigItem(*item)
+	return nil
+}
+
+// stripConfigItem 去除关联对象，存储中只保留外键
+func stripConfigItem(item models.ConfigurationItem) models.ConfigurationItem {
+	item.Provider = models.CloudProvider{}
+	item.Product = models.CloudProduct{}
+	item.Tags = nil
+	return item
+}
+
+// ApplyBulk 依次执行批量变更，atomic为true时先校验全部变更，任一失败则不做任何修改
+func (r *memoryConfigurationItemRepository) ApplyBulk(ctx context.Context, changes []ConfigItemChange, atomic bool) ([]error, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	errs := make([]error, len(changes))
+	if atomic {
+		for i := range changes {
+			if err := r.checkChange(&changes[i]); err != nil {
+				errs[i] = err
+				return errs, nil
+			}
+		}
+	}
+
+	for i := range changes {
+		change := &changes[i]
+		if err := r.checkChange(change); err != nil {
+			errs[i] = err
+			continue
+		}
+
+		switch change.Op {
+		case BulkOpCreate:
+			errs[i] = r.insert(change.Item)
+			change.ID = change.Item.ID
+			if errs[i] == nil && len(change.Item.Tags) > 0 {
+				r.store.linkTags(TagTargetConfigItem, change.ID, r.store.ensureTags(models.TagNames(change.Item.Tags)))
+			}
+		case BulkOpUpdate, BulkOpMove:
+			item := change.Item
+			item.CreatedAt = r.store.configItems[item.ID].CreatedAt
+			item.ApplyDefaults()
+			r.store.touchCreate("configuration_items", &item.BaseModel)
+			r.store.configItems[item.ID] = stripConfigItem(*item)
+		case BulkOpDelete:
+			r.store.trashConfigItem(change.ID, time.Now(), nil)
+		case BulkOpTag:
+			r.store.linkTags(TagTargetConfigItem, change.ID, r.store.ensureTags(change.AddTags))
+			links := r.store.taggings[TagTargetConfigItem][change.ID]
+			for tid := range links {
+				if containsString(change.RemoveTags, r.store.tags[tid].Name) {
+					delete(links, tid)
+				}
+			}
+		}
+	}
+	return errs, nil
+}
+
+// checkChange 检查批量变更引用的配置项、服务商和产品是否存在，调用方需持有锁
+func (r *memoryConfigurationItemRepository) checkChange(change *ConfigItemChange) error {
+	switch change.Op {
+	case BulkOpCreate:
+		return r.store.checkItemRefs(change.Item)
+	case BulkOpUpdate, BulkOpMove:
+		if _, ok := r.store.configItems[change.Item.ID]; !ok {
+			return gorm.ErrRecordNotFound
+		}
+		return r.store.checkItemRefs(change.Item)
+	case BulkOpDelete, BulkOpTag:
+		if _, ok := r.store.configItems[change.ID]; !ok {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	default:
+		return errors.New("unsupported bulk operation: " + change.Op)
+	}
+}
@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"sort"
+
+	"github.com/yourusername/cloud-eye/internal/models"
+	"gorm.io/gorm"
+)
+
+// memoryControlFamilyRepository 控制族仓库内存实现
+type memoryControlFamilyRepository struct {
+	memoryBaseRepository
+}
+
+// NewMemoryControlFamilyRepository 创建控制族仓库内存实现
+func NewMemoryControlFamilyRepository(store *MemoryStore) ControlFamilyRepository {
+	return &memoryControlFamilyRepository{
+		memoryBaseRepository: memoryBaseRepository{store: store},
+	}
+}
+
+// GetAll 获取所有控制族
+func (r *memoryControlFamilyRepository) GetAll(ctx context.Context) ([]models.ControlFamily, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	families := make([]models.ControlFamily, 0, len(r.store.families))
+	for _, f := range r.store.families {
+		families = append(families, f)
+	}
+	sort.Slice(families, func(i, j int) bool { return families[i].ID < families[j].ID })
+	return families, nil
+}
+
+// GetByID 根据ID获取控制族
+func (r *memoryControlFamilyRepository) GetByID(ctx context.Context, id uint) (*models.ControlFamily, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	family, ok := r.store.families[id]
+	if !ok {
+		return nil, nil
+	}
+	return &family, nil
+}
+
+// GetByCode 根据代码获取控制族
+func (r *memoryControlFamilyRepository) GetByCode(ctx context.Context, code string) (*models.ControlFamily, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, family := range r.store.families {
+		if family.Code == code {
+			return &family, nil
+		}
+	}
+	return nil, nil
+}
+
+// Create 创建控制族
+func (r *memoryControlFamilyRepository) Create(ctx context.Context, family *models.ControlFamily) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.families[family.ID]; ok && family.ID != 0 {
+		return gorm.ErrDuplicatedKey
+	}
+	return r.save(family)
+}
+
+// Update 更新控制族，记录不存在时按Save语义插入
+func (r *memoryControlFamilyRepository) Update(ctx context.Context, family *models.ControlFamily) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if existing, ok := r.store.families[family.ID]; ok {
+		family.CreatedAt = existing.CreatedAt
+	}
+	return r.save(family)
+}
+
+// save 校验代码唯一性并保存控制族，调用方需持有写锁
+func (r *memoryControlFamilyRepository) save(family *models.ControlFamily) error {
+	for id, f := range r.store.families {
+		if id != family.ID && f.Code == family.Code {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+
+	r.store.touchCreate("control_families", &family.BaseModel)
+	stored := *family
+	stored.ConfigItems = nil
+	r.store.families[stored.ID] = stored
+	return nil
+}
+
+// Delete 删除控制族，成员配置项解除关联
+func (r *memoryControlFamilyRepository) Delete(ctx context.Context, id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, items := range []map[uint]models.ConfigurationItem{r.store.configItems, r.store.deletedConfigItems} {
+		for iid, item := range items {
+			if item.ControlFamilyID != nil && *item.ControlFamilyID == id {
+				item.ControlFamilyID = nil
+				items[iid] = item
+			}
+		}
+	}
+	delete(r.store.families, id)
+	return nil
+}
+
+// GetConfigItems 获取控制族的成员配置项
+func (r *memoryControlFamilyRepository) GetConfigItems(ctx context.Context, familyID uint) ([]models.ConfigurationItem, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	items := r.store.sortedConfigItems(func(item models.ConfigurationItem) bool {
+		return item.ControlFamilyID != nil && *item.ControlFamilyID == familyID
+	})
+	sort.SliceStable(items, func(i, j int) bool { return items[i].CloudProviderID < items[j].CloudProviderID })
+	for i := range items {
+		r.store.preloadConfigItem(&items[i])
+	}
+	return items, nil
+}
+
+// SetConfigItemsFamily 设置配置项所属控制族
+func (r *memoryControlFamilyRepository) SetConfigItemsFamily(ctx context.Context, itemIDs []uint, familyID *uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if familyID != nil {
+		if _, ok := r.store.families[*familyID]; !ok {
+			return gorm.ErrForeignKeyViolated
+		}
+	}
+
+	for _, id := range itemIDs {
+		item, ok := r.store.configItems[id]
+		if !ok {
+			continue
+		}
+		if familyID != nil {
+			fid := *familyID
+			item.ControlFamilyID = &fid
+		} else {
+			item.ControlFamilyID = nil
+		}
+		r.store.configItems[id] = item
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/yourusername/cloud-eye/internal/models"
+)
+
+// memoryFileRepository 托管文件仓库内存实现
+type memoryFileRepository struct {
+	memoryBaseRepository
+}
+
+// NewMemoryFileRepository 创建托管文件仓库内存实现
+func NewMemoryFileRepository(store *MemoryStore) FileRepository {
+	return &memoryFileRepository{
+		memoryBaseRepository: memoryBaseRepository{store: store},
+	}
+}
+
+// Create 保存文件记录
+func (r *memoryFileRepository) Create(ctx context.Context, file *models.StoredFile) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	file.ID = 0
+	r.store.touchCreate("files", &file.BaseModel)
+	r.store.files[file.ID] = *file
+	return nil
+}
+
+// GetByID 根据ID获取文件记录
+func (r *memoryFileRepository) GetByID(ctx context.Context, id uint) (*models.StoredFile, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	file, ok := r.store.files[id]
+	if !ok {
+		return nil, nil
+	}
+	return &file, nil
+}
+
+// Delete 删除文件记录
+func (r *memoryFileRepository) Delete(ctx context.Context, id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.files, id)
+	return nil
+}
+
+// Expired 按ID顺序获取已过期的文件记录
+func (r *memoryFileRepository) Expired(ctx context.Context, before time.Time, limit int) ([]models.StoredFile, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var files []models.StoredFile
+	for _, file := range r.store.files {
+		if file.ExpiresAt.Before(before) {
+			files = append(files, file)
+		}
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].ID < files[j].ID })
+	if len(files) > limit {
+		files = files[:limit]
+	}
+	return files, nil
+}
@@ -0,0 +1,226 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/yourusername/cloud-eye/internal/models"
+)
+
+// memoryJobRepository 后台任务仓库内存实现
+type memoryJobRepository struct {
+	memoryBaseRepository
+}
+
+// NewMemoryJobRepository 创建后台任务仓库内存实现
+func NewMemoryJobRepository(store *MemoryStore) JobRepository {
+	return &memoryJobRepository{
+		memoryBaseRepository: memoryBaseRepository{store: store},
+	}
+}
+
+// Create 创建任务
+func (r *memoryJobRepository) Create(ctx context.Context, job *models.Job) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	job.ID = 0
+	r.store.touchCreate("jobs", &job.BaseModel)
+	r.store.jobs[job.ID] = *job
+	return nil
+}
+
+// GetByID 根据ID获取任务
+func (r *memoryJobRepository) GetByID(ctx context.Context, id uint) (*models.Job, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	job, ok := r.store.jobs[id]
+	if !ok {
+		return nil, nil
+	}
+	return &job, nil
+}
+
+// List 按创建时间倒序分页查询任务
+func (r *memoryJobRepository) List(ctx context.Context, filter JobFilter) (*PageResult, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	jobs := make([]models.Job, 0)
+	for _, job := range r.store.jobs {
+		if filter.Type != "" && job.Type != filter.Type {
+			continue
+		}
+		if filter.Status != "" && job.Status != filter.Status {
+			continue
+		}
+		job.Params = ""
+		jobs = append(jobs, job)
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].ID > jobs[j].ID })
+
+	start, end := paginateSlice(len(jobs), filter.Page, filter.PageSize)
+	return &PageResult{
+		Total:    int64(len(jobs)),
+		Page:     filter.Page,
+		PageSize: filter.PageSize,
+		Data:     jobs[start:end],
+	}, nil
+}
+
+// Queued 按创建顺序获取等待执行的任务
+func (r *memoryJobRepository) Queued(ctx context.Context, limit int) ([]models.Job, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var jobs []models.Job
+	for _, job := range r.store.jobs {
+		if job.Status == models.JobStatusQueued {
+			jobs = append(jobs, job)
+		}
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].ID < jobs[j].ID })
+	if len(jobs) > limit {
+		jobs = jobs[:limit]
+	}
+	return jobs, nil
+}
+
+// Claim 领取等待执行的任务
+func (r *memoryJobRepository) Claim(ctx context.Context, id uint, owner string, lease time.Duration) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	job, ok := r.store.jobs[id]
+	if !ok || job.Status != models.JobStatusQueued {
+		return false, nil
+	}
+	now := time.Now()
+	expires := now.Add(lease)
+	job.Status = models.JobStatusRunning
+	job.Attempts++
+	job.Owner = owner
+	job.LeaseExpiresAt = &expires
+	job.StartedAt = &now
+	job.UpdatedAt = now
+	r.store.jobs[id] = job
+	return true, nil
+}
+
+// Heartbeat 续期执行中任务的租约
+func (r *memoryJobRepository) Heartbeat(ctx context.Context, id uint, owner string, lease time.Duration) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	job, ok := r.store.jobs[id]
+	if !ok || job.Status != models.JobStatusRunning || job.Owner != owner {
+		return false, nil
+	}
+	now := time.Now()
+	expires := now.Add(lease)
+	job.LeaseExpiresAt = &expires
+	job.UpdatedAt = now
+	r.store.jobs[id] = job
+	return true, nil
+}
+
+// Release 将执行中的任务放回队列
+func (r *memoryJobRepository) Release(ctx context.Context, id uint, owner string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	job, ok := r.store.jobs[id]
+	if !ok || job.Status != models.JobStatusRunning || job.Owner != owner {
+		return nil
+	}
+	job.Status = models.JobStatusQueued
+	job.LeaseExpiresAt = nil
+	job.UpdatedAt = time.Now()
+	r.store.jobs[id] = job
+	return nil
+}
+
+// Update 保存任务，不覆盖取消标记
+func (r *memoryJobRepository) Update(ctx context.Context, job *models.Job) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	existing, ok := r.store.jobs[job.ID]
+	if !ok {
+		return nil
+	}
+	updated := *job
+	updated.CancelRequested = existing.CancelRequested
+	updated.UpdatedAt = time.Now()
+	r.store.jobs[job.ID] = updated
+	return nil
+}
+
+// UpdateProgress 更新任务进度
+func (r *memoryJobRepository) UpdateProgress(ctx context.Context, id uint, progress, total int, message string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	job, ok := r.store.jobs[id]
+	if !ok {
+		return nil
+	}
+	job.Progress = progress
+	job.Total = total
+	job.Message = message
+	job.UpdatedAt = time.Now()
+	r.store.jobs[id] = job
+	return nil
+}
+
+// RequestCancel 标记任务需要取消，等待执行的任务直接取消
+func (r *memoryJobRepository) RequestCancel(ctx context.Context, id uint) (*models.Job, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	job, ok := r.store.jobs[id]
+	if !ok {
+		return nil, nil
+	}
+	now := time.Now()
+	switch job.Status {
+	case models.JobStatusQueued:
+		job.Status = models.JobStatusCanceled
+		job.CancelRequested = true
+		job.FinishedAt = &now
+	case models.JobStatusRunning:
+		job.CancelRequested = true
+	default:
+		return &job, nil
+	}
+	job.UpdatedAt = now
+	r.store.jobs[id] = job
+	return &job, nil
+}
+
+// Requeue 将租约已过期的执行中任务放回队列，没有租约的执行中任务视为已过期
+func (r *memoryJobRepository) Requeue(ctx context.Context) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var requeued int64
+	now := time.Now()
+	for id, job := range r.store.jobs {
+		if job.Status != models.JobStatusRunning || (job.LeaseExpiresAt != nil && !job.LeaseExpiresAt.Before(now)) {
+			continue
+		}
+		job.LeaseExpiresAt = nil
+		job.UpdatedAt = now
+		if job.CancelRequested {
+			job.Status = models.JobStatusCanceled
+			job.FinishedAt = &now
+		} else {
+			job.Status = models.JobStatusQueued
+			requeued++
+		}
+		r.store.jobs[id] = job
+	}
+	return requeued, nil
+}
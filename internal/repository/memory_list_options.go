@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// columnValue 按gorm列名读取模型字段值，支持嵌入的BaseModel
+func columnValue(v reflect.Value, column string) (reflect.Value, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if fv, ok := columnValue(v.Field(i), column); ok {
+				return fv, true
+			}
+			continue
+		}
+		// 主键字段未声明column标签
+		if field.Name == "ID" && column == "id" {
+			return v.Field(i), true
+		}
+		for _, part := range strings.Split(field.Tag.Get("gorm"), ";") {
+			if part == "column:"+column {
+				return v.Field(i), true
+			}
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// compareValues 比较两个字段值，返回-1、0或1
+// 可空列为指针字段，与MySQL一致，NULL小于所有非NULL值
+func compareValues(a, b reflect.Value) int {
+	aNull, bNull := isNullValue(a), isNullValue(b)
+	switch {
+	case aNull && bNull:
+		return 0
+	case aNull:
+		return -1
+	case bNull:
+		return 1
+	}
+	a, b = reflect.Indirect(a), reflect.Indirect(b)
+
+	switch av := a.Interface().(type) {
+	case time.Time:
+		bv := b.Interface().(time.Time)
+		switch {
+		case av.Before(bv):
+			return -1
+		case av.After(bv):
+			return 1
+		}
+		return 0
+	case string:
+		return strings.Compare(av, b.Interface().(string))
+	}
+
+	switch a.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		switch {
+		case a.Uint() < b.Uint():
+			return -1
+		case a.Uint() > b.Uint():
+			return 1
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch {
+		case a.Int() < b.Int():
+			return -1
+		case a.Int() > b.Int():
+			return 1
+		}
+	}
+	return 0
+}
+
+// isNullValue 判断字段值是否为NULL：空指针，或游标中解码得到的nil
+func isNullValue(v reflect.Value) bool {
+	if !v.IsValid() {
+		return true
+	}
+	return (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil()
+}
+
+// inTimeRange 判断时间是否在[from, to]范围内
+func inTimeRange(t time.Time, from, to *time.Time) bool {
+	if from != nil && t.Before(*from) {
+		return false
+	}
+	if to != nil && t.After(*to) {
+		return false
+	}
+	return true
+}
+
+// limitPerParentSlice 每个父对象只保留ID最小的limit个元素并保持原有顺序，与limitPerParent的窗口函数一致
+func limitPerParentSlice[T any](items []T, key func(T) (parentID, id uint), limit int) []T {
+	ids := make(map[uint][]uint)
+	for _, item := range items {
+		parentID, id := key(item)
+		ids[parentID] = append(ids[parentID], id)
+	}
+	// 每个父对象保留的最大ID
+	maxIDs := make(map[uint]uint, len(ids))
+	for parentID, list := range ids {
+		sort.Slice(list, func(i, j int) bool { return list[i] < list[j] })
+		maxIDs[parentID] = list[min(limit, len(list))-1]
+	}
+
+	result := make([]T, 0, len(items))
+	for _, item := range items {
+		if parentID, id := key(item); id <= maxIDs[parentID] {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// applyMemoryListOptions 在内存中应用时间范围过滤和排序，返回新切片
+func applyMemoryListOptions[T any](items []T, opts ListOptions) []T {
+	result := make([]T, 0, len(items))
+	for _, item := range items {
+		v := reflect.ValueOf(item)
+		createdAt, _ := columnValue(v, "created_at")
+		updatedAt, _ := columnValue(v, "updated_at")
+		if !inTimeRange(createdAt.Interface().(time.Time), opts.CreatedFrom, opts.CreatedTo) ||
+			!inTimeRange(updatedAt.Interface().(time.Time), opts.UpdatedFrom, opts.UpdatedTo) {
+			continue
+		}
+		result = append(result, item)
+	}
+
+	sorts := append(append([]SortField{}, opts.Sort...), SortField{Column: "id"})
+	sort.SliceStable(result, func(i, j int) bool {
+		vi, vj := reflect.ValueOf(result[i]), reflect.ValueOf(result[j])
+		for _, sf := range sorts {
+			a, ok := columnValue(vi, sf.Column)
+			if !ok {
+				continue
+			}
+			b, _ := columnValue(vj, sf.Column)
+			if c := compareValues(a, b); c != 0 {
+				return (c < 0) != sf.Desc
+			}
+		}
+		return false
+	})
+	return result
+}
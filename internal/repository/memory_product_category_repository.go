@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"sort"
+
+	"github.com/yourusername/cloud-eye/internal/models"
+	"gorm.io/gorm"
+)
+
+// memoryProductCategoryRepository 产品类别仓库内存实现
+type memoryProductCategoryRepository struct {
+	memoryBaseRepository
+}
+
+// NewMemoryProductCategoryRepository 创建产品类别仓库内存实现
+func NewMemoryProductCategoryRepository(store *MemoryStore) ProductCategoryRepository {
+	return &memoryProductCategoryRepository{
+		memoryBaseRepository: memoryBaseRepository{store: store},
+	}
+}
+
+// GetAll 获取所有产品类别
+func (r *memoryProductCategoryRepository) GetAll(ctx context.Context) ([]models.ProductCategory, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	categories := make([]models.ProductCategory, 0, len(r.store.categories))
+	for _, c := range r.store.categories {
+		categories = append(categories, c)
+	}
+	sort.Slice(categories, func(i, j int) bool { return categories[i].ID < categories[j].ID })
+	return categories, nil
+}
+
+// GetByID 根据ID获取产品类别
+func (r *memoryProductCategoryRepository) GetByID(ctx context.Context, id uint) (*models.ProductCategory, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	category, ok := r.store.categories[id]
+	if !ok {
+		return nil, nil
+	}
+	return &category, nil
+}
+
+// GetByCode 根据代码获取产品类别
+func (r *memoryProductCategoryRepository) GetByCode(ctx context.Context, code string) (*models.ProductCategory, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, category := range r.store.categories {
+		if category.Code == code {
+			return &category, nil
+		}
+	}
+	return nil, nil
+}
+
+// Create 创建产品类别
+func (r *memoryProductCategoryRepository) Create(ctx context.Context, category *models.ProductCategory) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.categories[category.ID]; ok && category.ID != 0 {
+		return gorm.ErrDuplicatedKey
+	}
+	return r.save(category)
+}
+
+// Update 更新产品类别，记录不存在时按Save语义插入
+func (r *memoryProductCategoryRepository) Update(ctx context.Context, category *models.ProductCategory) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if existing, ok := r.store.categories[category.ID]; ok {
+		category.CreatedAt = existing.CreatedAt
+	}
+	return r.save(category)
+}
+
+// save 校验代码唯一性和上级类别并保存，调用方需持有写锁
+func (r *memoryProductCategoryRepository) save(category *models.ProductCategory) error {
+	for id, c := range r.store.categories {
+		if id != category.ID && c.Code == category.Code {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+	if category.ParentID != nil {
+		if _, ok := r.store.categories[*category.ParentID]; !ok {
+			return gorm.ErrForeignKeyViolated
+		}
+	}
+
+	r.store.touchCreate("product_categories", &category.BaseModel)
+	stored := *category
+	stored.Children = nil
+	r.store.categories[stored.ID] = stored
+	return nil
+}
+
+// Delete 删除产品类别，该类别下的产品变为未分类
+func (r *memoryProductCategoryRepository) Delete(ctx context.Context, id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, c := range r.store.categories {
+		if c.ParentID != nil && *c.ParentID == id {
+			return gorm.ErrForeignKeyViolated
+		}
+	}
+	for _, products := range []map[uint]models.CloudProduct{r.store.products, r.store.deletedProducts} {
+		for pid, p := range products {
+			if p.CategoryID != nil && *p.CategoryID == id {
+				p.CategoryID = nil
+				products[pid] = p
+			}
+		}
+	}
+	delete(r.store.categories, id)
+	return nil
+}
+
+// AssignProducts 将产品归入类别
+func (r *memoryProductCategoryRepository) AssignProducts(ctx context.Context, productIDs []uint, categoryID *uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if categoryID != nil {
+		if _, ok := r.store.categories[*categoryID]; !ok {
+			return gorm.ErrForeignKeyViolated
+		}
+	}
+
+	for _, id := range productIDs {
+		product, ok := r.store.products[id]
+		if !ok {
+			continue
+		}
+		if categoryID != nil {
+			cid := *categoryID
+			product.CategoryID = &cid
+		} else {
+			product.CategoryID = nil
+		}
+		r.store.products[id] = product
+	}
+	return nil
+}
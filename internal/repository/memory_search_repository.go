@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"sort"
+
+	"github.com/yourusername/cloud-eye/internal/models"
+	"github.com/yourusername/cloud-eye/internal/pkg/search"
+)
+
+// memorySearchRepository 全文检索仓库内存实现
+type memorySearchRepository struct {
+	memoryBaseRepository
+}
+
+// NewMemorySearchRepository 创建全文检索仓库内存实现
+func NewMemorySearchRepository(store *MemoryStore) SearchRepository {
+	return &memorySearchRepository{
+		memoryBaseRepository: memoryBaseRepository{store: store},
+	}
+}
+
+// SearchConfigItems 检索配置项，按相关度降序返回并生成高亮摘要
+func (r *memorySearchRepository) SearchConfigItems(ctx context.Context, filter ConfigItemSearchFilter) (*PageResult, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var hits []ConfigItemSearchHit
+	for _, item := range r.store.sortedConfigItems(func(item models.ConfigurationItem) bool {
+		if filter.CloudProviderID != nil && item.CloudProviderID != *filter.CloudProviderID {
+			return false
+		}
+		return filter.ProductID == nil || item.ProductID == *filter.ProductID
+	}) {
+		r.store.preloadConfigItem(&item)
+		score, ok := search.Score(configItemDocument(item), filter.Query)
+		if !ok {
+			continue
+		}
+		hits = append(hits, newConfigItemSearchHit(item, filter.Query, score))
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		return hits[i].Score > hits[j].Score
+	})
+
+	start, end := paginateSlice(len(hits), filter.Page, filter.PageSize)
+	return &PageResult{
+		Total:    int64(len(hits)),
+		Page:     filter.Page,
+		PageSize: filter.PageSize,
+		Data:     append([]ConfigItemSearchHit{}, hits[start:end]...),
+	}, nil
+}
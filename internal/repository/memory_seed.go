@@ -0,0 +1,268 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/yourusername/cloud-eye/internal/models"
+)
+
+// SeedDemoData 向内存存储写入演示数据，内容与init_database.sql中的初始化数据一致
+func SeedDemoData(ctx context.Context, store *MemoryStore) error {
+	providerRepo := NewMemoryCloudProviderRepository(store)
+	productRepo := NewMemoryCloudProductRepository(store)
+	configItemRepo := NewMemoryConfigurationItemRepository(store)
+	familyRepo := NewMemoryControlFamilyRepository(store)
+	categoryRepo := NewMemoryProductCategoryRepository(store)
+
+	for i := range demoProviders {
+		provider := demoProviders[i]
+		if err := providerRepo.Create(ctx, &provider); err != nil {
+			return err
+		}
+	}
+
+	for i := range demoCategories {
+		category := demoCategories[i]
+		if err := categoryRepo.Create(ctx, &category); err != nil {
+			return err
+		}
+	}
+
+	for i := range demoProducts {
+		product := demoProducts[i]
+		if err := productRepo.Create(ctx, &product); err != nil {
+			return err
+		}
+	}
+
+	for i := range demoControlFamilies {
+		family := demoControlFamilies[i]
+		if err := familyRepo.Create(ctx, &family); err != nil {
+			return err
+		}
+	}
+
+	items := make([]models.ConfigurationItem, len(demoConfigItems))
+	copy(items, demoConfigItems)
+	return configItemRepo.BatchInsert(ctx, items)
+}
+
+// demoProviders 演示用云服务商数据
+var demoProviders = []models.CloudProvider{
+	{Name: "Amazon Web Services", Code: "AWS", Description: "Amazon Web Services (AWS) 是亚马逊（Amazon）公司旗下云计算服务平台，提供包括弹性计算、存储、数据库、机器学习等在内的一系列云服务。"},
+	{Name: "Microsoft Azure", Code: "AZURE", Description: "Microsoft Azure 是微软公司的云计算服务，为开发人员和IT专业人员构建、部署和管理应用程序提供SaaS、PaaS和IaaS等多种解决方案。"},
+	{Name: "Google Cloud Platform", Code: "GCP", Description: "Google Cloud Platform (GCP) 是由Google提供的云计算服务，包括计算、数据存储、数据分析和机器学习等一系列模块化云服务。"},
+	{Name: "阿里云", Code: "ALICLOUD", Description: "阿里云是阿里巴巴集团旗下的云计算品牌，为全球企业、开发者和政府机构提供安全、可靠的计算和数据处理能力。"},
+	{Name: "腾讯云", Code: "TENCENTCLOUD", Description: "腾讯云是腾讯推出的云计算品牌，提供云服务器、云存储、云数据库和大数据处理等基础云计算服务。"},
+}
+
+// demoCategories 演示用产品类别数据
+var demoCategories = []models.ProductCategory{
+	{Name: "计算", Code: "COMPUTE", Description: "虚拟机、容器等计算资源"},
+	{Name: "存储", Code: "STORAGE", Description: "对象、块和文件存储"},
+	{Name: "对象存储", Code: "OBJECT_STORAGE", ParentID: demoCategory(2), Description: "海量非结构化数据的对象存储服务"},
+	{Name: "数据库", Code: "DATABASE", Description: "托管数据库服务"},
+	{Name: "关系型数据库", Code: "RELATIONAL_DATABASE", ParentID: demoCategory(4), Description: "托管关系型数据库服务"},
+	{Name: "网络", Code: "NETWORK", Description: "虚拟网络、负载均衡和CDN等网络服务"},
+	{Name: "身份与访问管理", Code: "IDENTITY", Description: "账号、权限和密钥管理服务"},
+}
+
+// demoCategory 返回演示数据中产品类别ID的指针
+func demoCategory(id uint) *uint {
+	return &id
+}
+
+// demoProducts 演示用云产品数据
+var demoProducts = []models.CloudProduct{
+	{CloudProviderID: 1, Name: "Amazon Elastic Compute Cloud", Code: "EC2", Description: "Amazon EC2 是一种提供可伸缩计算容量的Web服务，让开发人员能够更轻松地进行云端计算。", CategoryID: demoCategory(1)},
+	{CloudProviderID: 1, Name: "Amazon Simple Storage Service", Code: "S3", Description: "Amazon S3 是一种对象存储服务，提供行业领先的可扩展性、数据可用性、安全性和性能。", CategoryID: demoCategory(3)},
+	{CloudProviderID: 1, Name: "Amazon Relational Database Service", Code: "RDS", Description: "Amazon RDS 让用户可在云中轻松设置、操作和扩展关系数据库。", CategoryID: demoCategory(5)},
+	{CloudProviderID: 2, Name: "Azure Virtual Machines", Code: "AVM", Description: "Azure Virtual Machines 提供可缩放的计算资源，让用户能够灵活地运行应用程序。", CategoryID: demoCategory(1)},
+	{CloudProviderID: 2, Name: "Azure Blob Storage", Code: "BLOB", Description: "Azure Blob Storage 是适用于云的对象存储解决方案，用于存储大量非结构化数据。", CategoryID: demoCategory(3)},
+	{CloudProviderID: 2, Name: "Azure SQL Database", Code: "ASQL", Description: "Azure SQL Database 是基于最新稳定版Microsoft SQL Server数据库引擎的智能关系云数据库服务。", CategoryID: demoCategory(5)},
+	{CloudProviderID: 3, Name: "Google Compute Engine", Code: "GCE", Description: "Google Compute Engine 提供可配置的虚拟机，在Google基础设施上运行。", CategoryID: demoCategory(1)},
+	{CloudProviderID: 3, Name: "Google Cloud Storage", Code: "GCS", Description: "Google Cloud Storage 是一种持久、高可用且安全的对象存储服务。", CategoryID: demoCategory(3)},
+	{CloudProviderID: 3, Name: "Google Cloud SQL", Code: "GSQL", Description: "Google Cloud SQL 是一种托管关系型数据库服务，用于MySQL、PostgreSQL和SQL Server。", CategoryID: demoCategory(5)},
+	{CloudProviderID: 4, Name: "阿里云弹性计算服务", Code: "ECS", Description: "阿里云ECS是一种提供弹性可伸缩计算能力的服务，帮助用户快速构建更稳定、安全的应用。", CategoryID: demoCategory(1)},
+	{CloudProviderID: 4, Name: "阿里云对象存储服务", Code: "OSS", Description: "阿里云OSS提供海量、安全、低成本、高可靠的云存储服务，适合存储各种文件类型。", CategoryID: demoCategory(3)},
+	{CloudProviderID: 4, Name: "阿里云关系型数据库", Code: "RDS", Description: "阿里云RDS是一种稳定可靠、可弹性伸缩的在线数据库服务，提供多种数据库引擎选择。", CategoryID: demoCategory(5)},
+	{CloudProviderID: 5, Name: "腾讯云服务器", Code: "CVM", Description: "腾讯云CVM提供安全可靠的弹性计算服务，支持Linux、Windows等操作系统，适合承载各类应用。", CategoryID: demoCategory(1)},
+	{CloudProviderID: 5, Name: "腾讯云对象存储", Code: "COS", Description: "腾讯云COS是腾讯云提供的一种存储海量文件的分布式存储服务，具有高扩展性、低成本等优点。", CategoryID: demoCategory(3)},
+	{CloudProviderID: 5, Name: "腾讯云数据库", Code: "TencentDB", Description: "腾讯云数据库是腾讯云提供的高性能、高可靠、高安全、可弹性伸缩的数据库托管服务。", CategoryID: demoCategory(5)},
+}
+
+// demoControlFamilies 演示用控制族数据
+var demoControlFamilies = []models.ControlFamily{
+	{Name: "对象存储公共访问", Code: "STORAGE_PUBLIC_ACCESS", Description: "禁止对象存储被匿名或公共访问"},
+	{Name: "数据库加密", Code: "DATABASE_ENCRYPTION", Description: "数据库存储层启用静态加密"},
+}
+
+// demoFamily 返回演示数据中控制族ID的指针
+func demoFamily(id uint) *uint {
+	return &id
+}
+
+// demoTags 按名称构造演示数据中配置项的标签
+func demoTags(names ...string) []models.Tag {
+	tags := make([]models.Tag, 0, len(names))
+	for _, name := range names {
+		tags = append(tags, models.Tag{Name: name})
+	}
+	return tags
+}
+
+// demoConfigItems 演示用配置项数据
+var demoConfigItems = []models.ConfigurationItem{
+	{
+		CloudProviderID:     1,
+		ProductID:           1,
+		Name:                "EC2实例安全组入站规则限制",
+		RecommendedValue:    "仅开放必要的端口和IP范围",
+		RiskDescription:     "不恰当的安全组规则可能导致未授权访问EC2实例上的服务。",
+		CheckMethod:         "通过AWS控制台或CLI检查安全组规则，确保仅允许必要的入站流量。",
+		ConfigurationMethod: "在AWS控制台或使用CLI修改EC2安全组规则，移除非必要的端口开放。",
+		Reference:           "AWS安全最佳实践文档 https://docs.aws.amazon.com/security/",
+		Tags:                demoTags("网络访问控制"),
+	},
+	{
+		CloudProviderID:     1,
+		ProductID:           1,
+		Name:                "EC2实例AMI更新状态",
+		RecommendedValue:    "使用最新的安全补丁AMI",
+		RiskDescription:     "过时的AMI可能包含已知漏洞，增加系统被攻击的风险。",
+		CheckMethod:         "检查AMI的创建日期和补丁级别，确保使用最新的安全补丁版本。",
+		ConfigurationMethod: "定期更新EC2实例使用的AMI，或为现有实例应用安全补丁。",
+		Reference:           "AWS AMI安全指南 https://docs.aws.amazon.com/security/ami-security/",
+		Severity:            models.SeverityLow,
+		Tags:                demoTags("补丁管理"),
+	},
+	{
+		CloudProviderID:     1,
+		ProductID:           2,
+		Name:                "S3存储桶公共访问设置",
+		RecommendedValue:    "禁用所有公共访问选项",
+		RiskDescription:     "允许公共访问可能导致敏感数据泄露。",
+		CheckMethod:         "使用AWS控制台或CLI检查存储桶的\"阻止公共访问\"设置。",
+		ConfigurationMethod: "在S3存储桶配置中启用\"阻止所有公共访问\"选项。",
+		Reference:           "AWS S3安全最佳实践 https://docs.aws.amazon.com/AmazonS3/latest/userguide/security-best-practices.html",
+		Severity:            models.SeverityHigh,
+		ControlFamilyID:     demoFamily(1),
+		Tags:                demoTags("公共访问"),
+	},
+	{
+		CloudProviderID:     1,
+		ProductID:           2,
+		Name:                "S3存储桶加密设置",
+		RecommendedValue:    "启用默认加密（AES-256或AWS KMS）",
+		RiskDescription:     "未加密的数据存在被未授权访问的风险。",
+		CheckMethod:         "检查S3存储桶的默认加密设置。",
+		ConfigurationMethod: "在S3存储桶属性中启用默认加密，选择AES-256或AWS KMS。",
+		Reference:           "AWS S3加密指南 https://docs.aws.amazon.com/AmazonS3/latest/userguide/bucket-encryption.html",
+		Tags:                demoTags("加密"),
+	},
+	{
+		CloudProviderID:     1,
+		ProductID:           3,
+		Name:                "RDS数据库加密设置",
+		RecommendedValue:    "启用存储加密",
+		RiskDescription:     "未加密的数据库存储可能导致敏感信息泄露。",
+		CheckMethod:         "检查RDS实例是否启用了存储加密。",
+		ConfigurationMethod: "创建新的RDS实例时启用加密选项，或加密现有数据库的快照并从该快照恢复。",
+		Reference:           "AWS RDS加密指南 https://docs.aws.amazon.com/AmazonRDS/latest/UserGuide/Overview.Encryption.html",
+		ControlFamilyID:     demoFamily(2),
+		Tags:                demoTags("加密"),
+	},
+	{
+		CloudProviderID:     1,
+		ProductID:           3,
+		Name:                "RDS数据库公共可访问性",
+		RecommendedValue:    "禁用公共可访问性",
+		RiskDescription:     "允许公共访问数据库增加了未授权访问的风险。",
+		CheckMethod:         "检查RDS实例的\"公共可访问性\"设置。",
+		ConfigurationMethod: "修改RDS实例，将\"公共可访问性\"设置为\"否\"。",
+		Reference:           "AWS RDS安全最佳实践 https://docs.aws.amazon.com/AmazonRDS/latest/UserGuide/CHAP_BestPractices.Security.html",
+		Severity:            models.SeverityHigh,
+		Tags:                demoTags("公共访问"),
+	},
+	{
+		CloudProviderID:     2,
+		ProductID:           4,
+		Name:                "Azure VM网络安全组设置",
+		RecommendedValue:    "仅允许必要的入站规则",
+		RiskDescription:     "过于宽松的NSG规则可能导致VM被未授权访问。",
+		CheckMethod:         "在Azure门户或使用Azure CLI检查NSG规则。",
+		ConfigurationMethod: "修改NSG规则，删除非必要的入站规则，限制IP范围和端口。",
+		Reference:           "Azure NSG安全最佳实践 https://docs.microsoft.com/azure/security/fundamentals/network-best-practices",
+		Tags:                demoTags("网络访问控制"),
+	},
+	{
+		CloudProviderID:     2,
+		ProductID:           4,
+		Name:                "Azure VM磁盘加密",
+		RecommendedValue:    "启用Azure磁盘加密",
+		RiskDescription:     "未加密的VM磁盘可能导致数据泄露。",
+		CheckMethod:         "检查VM是否启用了Azure磁盘加密。",
+		ConfigurationMethod: "为新VM启用磁盘加密，或对现有VM启用Azure磁盘加密。",
+		Reference:           "Azure磁盘加密指南 https://docs.microsoft.com/azure/security/fundamentals/azure-disk-encryption-vms-vmss",
+		Tags:                demoTags("加密"),
+	},
+	{
+		CloudProviderID:     2,
+		ProductID:           5,
+		Name:                "存储账户公共访问级别",
+		RecommendedValue:    "禁用公共访问",
+		RiskDescription:     "允许公共访问可能导致数据泄露。",
+		CheckMethod:         "检查存储账户的公共访问级别设置。",
+		ConfigurationMethod: "在Azure门户中修改存储账户的\"允许Blob公共访问\"设置为\"禁用\"。",
+		Reference:           "Azure Storage安全指南 https://docs.microsoft.com/azure/storage/blobs/security-recommendations",
+		Severity:            models.SeverityHigh,
+		ControlFamilyID:     demoFamily(1),
+		Tags:                demoTags("公共访问"),
+	},
+	{
+		CloudProviderID:     2,
+		ProductID:           5,
+		Name:                "存储账户加密设置",
+		RecommendedValue:    "启用默认加密",
+		RiskDescription:     "未加密的数据存储增加了敏感信息泄露的风险。",
+		CheckMethod:         "检查存储账户的加密设置。",
+		ConfigurationMethod: "Azure存储账户默认启用加密，确保使用CMK（客户管理的密钥）以获得更高的安全性。",
+		Reference:           "Azure存储加密指南 https://docs.microsoft.com/azure/storage/common/storage-service-encryption",
+		Tags:                demoTags("加密"),
+	},
+	{
+		CloudProviderID:     4,
+		ProductID:           10,
+		Name:                "ECS安全组规则配置",
+		RecommendedValue:    "仅开放必要的端口和授权对象",
+		RiskDescription:     "过于宽松的安全组规则增加了被攻击的风险。",
+		CheckMethod:         "在阿里云控制台检查安全组规则配置。",
+		ConfigurationMethod: "修改安全组规则，移除不必要的入方向规则，限制端口范围和授权对象。",
+		Reference:           "阿里云安全组最佳实践 https://help.aliyun.com/document_detail/25475.html",
+		Tags:                demoTags("网络访问控制"),
+	},
+	{
+		CloudProviderID:     4,
+		ProductID:           10,
+		Name:                "ECS实例密码复杂度",
+		RecommendedValue:    "使用高强度密码且定期更换",
+		RiskDescription:     "弱密码容易被暴力破解，导致系统被入侵。",
+		CheckMethod:         "检查密码策略是否符合复杂度要求。",
+		ConfigurationMethod: "设置包含大小写字母、数字和特殊字符的复杂密码，定期更换。",
+		Reference:           "阿里云ECS安全最佳实践 https://help.aliyun.com/document_detail/51701.html",
+		Tags:                demoTags("身份认证"),
+	},
+	{
+		CloudProviderID:     4,
+		ProductID:           11,
+		Name:                "OSS存储桶访问控制",
+		RecommendedValue:    "使用Bucket ACL和IAM权限控制访问",
+		RiskDescription:     "不当的访问控制可能导致数据被未授权访问。",
+		CheckMethod:         "检查OSS Bucket的访问控制设置。",
+		ConfigurationMethod: "通过OSS控制台设置合适的Bucket ACL，结合RAM权限策略控制访问。",
+		Reference:           "阿里云OSS访问控制最佳实践 https://help.aliyun.com/document_detail/31952.html",
+		Severity:            models.SeverityHigh,
+		ControlFamilyID:     demoFamily(1),
+		Tags:                demoTags("公共访问"),
+	},
+}
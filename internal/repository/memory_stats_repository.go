@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/yourusername/cloud-eye/internal/models"
+)
+
+// memoryStatsRepository 统计仓库内存实现
+type memoryStatsRepository struct {
+	memoryBaseRepository
+}
+
+// NewMemoryStatsRepository 创建统计仓库内存实现
+func NewMemoryStatsRepository(store *MemoryStore) StatsRepository {
+	return &memoryStatsRepository{
+		memoryBaseRepository: memoryBaseRepository{store: store},
+	}
+}
+
+// Totals 统计各类实体的总数
+func (r *memoryStatsRepository) Totals(ctx context.Context) (*StatsTotals, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return &StatsTotals{
+		Providers:   int64(len(r.store.providers)),
+		Products:    int64(len(r.store.products)),
+		ConfigItems: int64(len(r.store.configItems)),
+	}, nil
+}
+
+// ProviderStats 统计各云服务商的产品数和配置项数
+func (r *memoryStatsRepository) ProviderStats(ctx context.Context) ([]ProviderStat, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	stats := make([]ProviderStat, 0, len(r.store.providers))
+	for _, p := range r.store.sortedProviders(nil) {
+		stat := ProviderStat{ProviderID: p.ID, ProviderCode: p.Code, ProviderName: p.Name}
+		for _, product := range r.store.products {
+			if product.CloudProviderID == p.ID {
+				stat.ProductCount++
+			}
+		}
+		for _, item := range r.store.configItems {
+			if item.CloudProviderID == p.ID {
+				stat.ConfigItemCount++
+			}
+		}
+		stats = append(stats, stat)
+	}
+	return stats, nil
+}
+
+// ProductStats 统计各产品的配置项数
+func (r *memoryStatsRepository) ProductStats(ctx context.Context, providerID *uint) ([]ProductStat, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return r.productStats(func(p models.CloudProduct) bool {
+		return providerID == nil || p.CloudProviderID == *providerID
+	}, false), nil
+}
+
+// CategoryStats 统计直接归属各类别的产品数和配置项数
+func (r *memoryStatsRepository) CategoryStats(ctx context.Context) ([]CategoryStat, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	byCategory := make(map[uint]*CategoryStat, len(r.store.categories))
+	stats := make([]CategoryStat, 0, len(r.store.categories))
+	for _, c := range r.store.categories {
+		stats = append(stats, CategoryStat{CategoryID: c.ID, ParentID: c.ParentID, CategoryCode: c.Code, CategoryName: c.Name})
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].CategoryID < stats[j].CategoryID })
+	for i := range stats {
+		byCategory[stats[i].CategoryID] = &stats[i]
+	}
+
+	for _, p := range r.store.products {
+		if p.CategoryID != nil {
+			if stat, ok := byCategory[*p.CategoryID]; ok {
+				stat.ProductCount++
+			}
+		}
+	}
+	for _, item := range r.store.configItems {
+		product := r.store.products[item.ProductID]
+		if product.CategoryID != nil {
+			if stat, ok := byCategory[*product.CategoryID]; ok {
+				stat.ConfigItemCount++
+			}
+		}
+	}
+	return stats, nil
+}
+
+// ConfigItemCounts 按配置项列分组计数
+func (r *memoryStatsRepository) ConfigItemCounts(ctx context.Context, column string) ([]GroupCount, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	counts := make(map[string]int64)
+	for _, item := range r.store.configItems {
+		v, ok := columnValue(reflect.ValueOf(item), column)
+		if !ok {
+			return nil, fmt.Errorf("不支持的统计列: %s", column)
+		}
+		counts[fmt.Sprint(v.Interface())]++
+	}
+
+	groups := make([]GroupCount, 0, len(counts))
+	for k, c := range counts {
+		groups = append(groups, GroupCount{Key: k, Count: c})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Count != groups[j].Count {
+			return groups[i].Count > groups[j].Count
+		}
+		return groups[i].Key < groups[j].Key
+	})
+	return groups, nil
+}
+
+// WeeklyActivity 按周统计新增和更新的配置项数量
+func (r *memoryStatsRepository) WeeklyActivity(ctx context.Context, from, to time.Time) ([]WeeklyActivity, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	weeks := make(map[time.Time]*WeeklyActivity)
+	get := func(week time.Time) *WeeklyActivity {
+		if w, ok := weeks[week]; ok {
+			return w
+		}
+		w := &WeeklyActivity{WeekStart: week}
+		weeks[week] = w
+		return w
+	}
+	inWindow := func(t time.Time) bool {
+		return !t.Before(from) && t.Before(to)
+	}
+
+	for _, item := range r.store.configItems {
+		if inWindow(item.CreatedAt) {
+			get(WeekStart(item.CreatedAt)).Created++
+		}
+		if inWindow(item.UpdatedAt) && item.UpdatedAt.After(item.CreatedAt) {
+			get(WeekStart(item.UpdatedAt)).Updated++
+		}
+	}
+
+	activity := make([]WeeklyActivity, 0, len(weeks))
+	for _, w := range weeks {
+		activity = append(activity, *w)
+	}
+	return activity, nil
+}
+
+// UncoveredProducts 获取尚无任何配置项的产品
+func (r *memoryStatsRepository) UncoveredProducts(ctx context.Context) ([]ProductStat, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return r.productStats(nil, true), nil
+}
+
+// productStats 统计匹配产品的配置项数，uncoveredOnly为true时仅返回没有配置项的产品，调用方需持有锁
+func (r *memoryStatsRepository) productStats(match func(models.CloudProduct) bool, uncoveredOnly bool) []ProductStat {
+	counts := make(map[uint]int64)
+	for _, item := range r.store.configItems {
+		counts[item.ProductID]++
+	}
+
+	stats := make([]ProductStat, 0)
+	for _, p := range r.store.sortedProducts(match) {
+		if uncoveredOnly && counts[p.ID] > 0 {
+			continue
+		}
+		provider := r.store.providers[p.CloudProviderID]
+		stats = append(stats, ProductStat{
+			ProductID:       p.ID,
+			ProductCode:     p.Code,
+			ProductName:     p.Name,
+			ProviderID:      provider.ID,
+			ProviderCode:    provider.Code,
+			ProviderName:    provider.Name,
+			ConfigItemCount: counts[p.ID],
+		})
+	}
+	return stats
+}
@@ -0,0 +1,510 @@
+package repository
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yourusername/cloud-eye/internal/models"
+	"gorm.io/gorm"
+)
+
+// MemoryStore 内存数据存储，供内存仓库实现共享使用
+// 三类实体保存在同一个存储中，以便实现与数据库一致的唯一约束和级联删除语义；
+// 软删除的实体移入deleted*中，其余仓库方法只访问未删除的实体
+type MemoryStore struct {
+	mu                 sync.RWMutex
+	providers          map[uint]models.CloudProvider
+	products           map[uint]models.CloudProduct
+	configItems        map[uint]models.ConfigurationItem
+	deletedProviders   map[uint]models.CloudProvider
+	deletedProducts    map[uint]models.CloudProduct
+	deletedConfigItems map[uint]models.ConfigurationItem
+	families           map[uint]models.ControlFamily
+	categories         map[uint]models.ProductCategory
+	tags               map[uint]models.Tag
+	taggings           map[TagTarget]map[uint]map[uint]bool // 实体类型 -> 实体ID -> 标签ID集合
+	webhooks           map[uint]models.WebhookSubscription
+	webhookDeliveries  map[uint]models.WebhookDelivery
+	changeEvents       []models.ChangeEvent // 按序号升序
+	jobs               map[uint]models.Job
+	files              map[uint]models.StoredFile
+	nextID             map[string]uint
+}
+
+// NewMemoryStore 创建内存数据存储
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		providers:          make(map[uint]models.CloudProvider),
+		products:           make(map[uint]models.CloudProduct),
+		configItems:        make(map[uint]models.ConfigurationItem),
+		deletedProviders:   make(map[uint]models.CloudProvider),
+		deletedProducts:    make(map[uint]models.CloudProduct),
+		deletedConfigItems: make(map[uint]models.ConfigurationItem),
+		families:           make(map[uint]models.ControlFamily),
+		categories:         make(map[uint]models.ProductCategory),
+		tags:               make(map[uint]models.Tag),
+		taggings: map[TagTarget]map[uint]map[uint]bool{
+			TagTargetProvider:   {},
+			TagTargetProduct:    {},
+			TagTargetConfigItem: {},
+		},
+		webhooks:          make(map[uint]models.WebhookSubscription),
+		webhookDeliveries: make(map[uint]models.WebhookDelivery),
+		jobs:              make(map[uint]models.Job),
+		files:             make(map[uint]models.StoredFile),
+		nextID:            make(map[string]uint),
+	}
+}
+
+// allocID 为指定表分配自增ID，调用方需持有写锁
+func (s *MemoryStore) allocID(table string) uint {
+	s.nextID[table]++
+	return s.nextID[table]
+}
+
+// touchCreate 设置新记录的ID和时间戳，调用方需持有写锁
+func (s *MemoryStore) touchCreate(table string, base *models.BaseModel) {
+	now := time.Now()
+	if base.ID == 0 {
+		base.ID = s.allocID(table)
+	} else if base.ID > s.nextID[table] {
+		s.nextID[table] = base.ID
+	}
+	if base.CreatedAt.IsZero() {
+		base.CreatedAt = now
+	}
+	base.UpdatedAt = now
+}
+
+// providerCodeTaken 检查云服务商代码是否被其他记录占用，与数据库唯一索引一致，回收站中的记录也占用代码，调用方需持有锁
+func (s *MemoryStore) providerCodeTaken(code string, exceptID uint) bool {
+	for _, providers := range []map[uint]models.CloudProvider{s.providers, s.deletedProviders} {
+		for id, p := range providers {
+			if id != exceptID && p.Code == code {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// productCodeTaken 检查同一服务商下云产品代码是否被其他记录占用，回收站中的记录也占用代码，调用方需持有锁
+func (s *MemoryStore) productCodeTaken(providerID uint, code string, exceptID uint) bool {
+	for _, products := range []map[uint]models.CloudProduct{s.products, s.deletedProducts} {
+		for id, p := range products {
+			if id != exceptID && p.CloudProviderID == providerID && p.Code == code {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkProductRefs 检查云产品引用的服务商和类别是否存在（模拟外键约束），调用方需持有锁
+func (s *MemoryStore) checkProductRefs(product *models.CloudProduct) error {
+	if _, ok := s.providers[product.CloudProviderID]; !ok {
+		return gorm.ErrForeignKeyViolated
+	}
+	if product.CategoryID != nil {
+		if _, ok := s.categories[*product.CategoryID]; !ok {
+			return gorm.ErrForeignKeyViolated
+		}
+	}
+	return nil
+}
+
+// categorySubtree 展开类别ID的子树，调用方需持有锁
+func (s *MemoryStore) categorySubtree(ids []uint) []uint {
+	categories := make([]models.ProductCategory, 0, len(s.categories))
+	for _, c := range s.categories {
+		categories = append(categories, c)
+	}
+	return expandCategoryTree(categories, ids)
+}
+
+// productCategory 返回云产品所属类别的副本，未分类时返回nil，调用方需持有锁
+func (s *MemoryStore) productCategory(product models.CloudProduct) *models.ProductCategory {
+	if product.CategoryID == nil {
+		return nil
+	}
+	category, ok := s.categories[*product.CategoryID]
+	if !ok {
+		return nil
+	}
+	return &category
+}
+
+// checkItemRefs 检查配置项引用的服务商和产品是否存在（模拟外键约束），调用方需持有锁
+func (s *MemoryStore) checkItemRefs(item *models.ConfigurationItem) error {
+	if _, ok := s.providers[item.CloudProviderID]; !ok {
+		return gorm.ErrForeignKeyViolated
+	}
+	if _, ok := s.products[item.ProductID]; !ok {
+		return gorm.ErrForeignKeyViolated
+	}
+	if item.ControlFamilyID != nil {
+		if _, ok := s.families[*item.ControlFamilyID]; !ok {
+			return gorm.ErrForeignKeyViolated
+		}
+	}
+	return nil
+}
+
+// trashProvider 软删除云服务商及其未删除的产品和配置项，删除的记录追加到records，调用方需持有写锁
+func (s *MemoryStore) trashProvider(id uint, deletedAt time.Time, records *CascadeRecords) {
+	for pid, p := range s.products {
+		if p.CloudProviderID == id {
+			s.trashProduct(pid, deletedAt, records)
+		}
+	}
+	for iid, item := range s.configItems {
+		if item.CloudProviderID == id {
+			s.trashConfigItem(iid, deletedAt, records)
+		}
+	}
+	if p, ok := s.providers[id]; ok {
+		p.DeletedAt = gorm.DeletedAt{Time: deletedAt, Valid: true}
+		s.deletedProviders[id] = p
+		delete(s.providers, id)
+		p.Tags = s.entityTags(TagTargetProvider, id)
+		records.addProvider(p)
+	}
+}
+
+// trashProduct 软删除云产品及其未删除的配置项，删除的记录追加到records，调用方需持有写锁
+func (s *MemoryStore) trashProduct(id uint, deletedAt time.Time, records *CascadeRecords) {
+	for iid, item := range s.configItems {
+		if item.ProductID == id {
+			s.trashConfigItem(iid, deletedAt, records)
+		}
+	}
+	if p, ok := s.products[id]; ok {
+		p.DeletedAt = gorm.DeletedAt{Time: deletedAt, Valid: true}
+		s.deletedProducts[id] = p
+		delete(s.products, id)
+		p.Tags = s.entityTags(TagTargetProduct, id)
+		records.addProduct(p)
+	}
+}
+
+// trashConfigItem 软删除配置项，删除的记录追加到records，调用方需持有写锁
+func (s *MemoryStore) trashConfigItem(id uint, deletedAt time.Time, records *CascadeRecords) {
+	if item, ok := s.configItems[id]; ok {
+		item.DeletedAt = gorm.DeletedAt{Time: deletedAt, Valid: true}
+		s.deletedConfigItems[id] = item
+		delete(s.configItems, id)
+		item.Tags = s.entityTags(TagTargetConfigItem, id)
+		records.addConfigItem(item)
+	}
+}
+
+// restoreProvider 恢复云服务商及与其同时删除的产品和配置项，恢复的记录追加到records，调用方需持有写锁
+func (s *MemoryStore) restoreProvider(id uint, records *CascadeRecords) {
+	p, ok := s.deletedProviders[id]
+	if !ok {
+		return
+	}
+	deletedAt := p.DeletedAt
+	for pid, product := range s.deletedProducts {
+		if product.CloudProviderID == id && product.DeletedAt == deletedAt {
+			s.restoreProduct(pid, records)
+		}
+	}
+	for iid, item := range s.deletedConfigItems {
+		if item.CloudProviderID == id && item.DeletedAt == deletedAt {
+			s.restoreConfigItem(iid, records)
+		}
+	}
+	p.DeletedAt = gorm.DeletedAt{}
+	s.providers[id] = p
+	delete(s.deletedProviders, id)
+	p.Tags = s.entityTags(TagTargetProvider, id)
+	records.addProvider(p)
+}
+
+// restoreProduct 恢复云产品及与其同时删除的配置项，恢复的记录追加到records，调用方需持有写锁
+func (s *MemoryStore) restoreProduct(id uint, records *CascadeRecords) {
+	p, ok := s.deletedProducts[id]
+	if !ok {
+		return
+	}
+	for iid, item := range s.deletedConfigItems {
+		if item.ProductID == id && item.DeletedAt == p.DeletedAt {
+			s.restoreConfigItem(iid, records)
+		}
+	}
+	p.DeletedAt = gorm.DeletedAt{}
+	s.products[id] = p
+	delete(s.deletedProducts, id)
+	p.Tags = s.entityTags(TagTargetProduct, id)
+	records.addProduct(p)
+}
+
+// restoreConfigItem 恢复配置项，恢复的记录追加到records，调用方需持有写锁
+func (s *MemoryStore) restoreConfigItem(id uint, records *CascadeRecords) {
+	if item, ok := s.deletedConfigItems[id]; ok {
+		item.DeletedAt = gorm.DeletedAt{}
+		s.configItems[id] = item
+		delete(s.deletedConfigItems, id)
+		item.Tags = s.entityTags(TagTargetConfigItem, id)
+		records.addConfigItem(item)
+	}
+}
+
+// purgeProvider 彻底删除云服务商并级联删除其产品和配置项（模拟外键级联），调用方需持有写锁
+func (s *MemoryStore) purgeProvider(id uint) {
+	for _, products := range []map[uint]models.CloudProduct{s.products, s.deletedProducts} {
+		for pid, p := range products {
+			if p.CloudProviderID == id {
+				s.purgeProduct(pid)
+			}
+		}
+	}
+	for _, items := range []map[uint]models.ConfigurationItem{s.configItems, s.deletedConfigItems} {
+		for iid, item := range items {
+			if item.CloudProviderID == id {
+				s.purgeConfigItem(iid)
+			}
+		}
+	}
+	delete(s.taggings[TagTargetProvider], id)
+	delete(s.providers, id)
+	delete(s.deletedProviders, id)
+}
+
+// purgeProduct 彻底删除云产品并级联删除其配置项，调用方需持有写锁
+func (s *MemoryStore) purgeProduct(id uint) {
+	for _, items := range []map[uint]models.ConfigurationItem{s.configItems, s.deletedConfigItems} {
+		for iid, item := range items {
+			if item.ProductID == id {
+				s.purgeConfigItem(iid)
+			}
+		}
+	}
+	delete(s.taggings[TagTargetProduct], id)
+	delete(s.products, id)
+	delete(s.deletedProducts, id)
+}
+
+// purgeConfigItem 彻底删除配置项及其标签关联，调用方需持有写锁
+func (s *MemoryStore) purgeConfigItem(id uint) {
+	delete(s.taggings[TagTargetConfigItem], id)
+	delete(s.configItems, id)
+	delete(s.deletedConfigItems, id)
+}
+
+// targetExists 判断可打标签的实体是否存在，调用方需持有锁
+func (s *MemoryStore) targetExists(target TagTarget, id uint) bool {
+	var ok bool
+	switch target {
+	case TagTargetProvider:
+		_, ok = s.providers[id]
+	case TagTargetProduct:
+		_, ok = s.products[id]
+	case TagTargetConfigItem:
+		_, ok = s.configItems[id]
+	}
+	return ok
+}
+
+// ensureTags 按名称获取标签ID，不存在的标签自动创建，调用方需持有写锁
+func (s *MemoryStore) ensureTags(names []string) []uint {
+	ids := make([]uint, 0, len(names))
+	for _, name := range names {
+		var id uint
+		for tid, t := range s.tags {
+			if t.Name == name {
+				id = tid
+				break
+			}
+		}
+		if id == 0 {
+			tag := models.Tag{Name: name}
+			s.touchCreate("tags", &tag.BaseModel)
+			s.tags[tag.ID] = tag
+			id = tag.ID
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// linkTags 建立实体与标签的关联，调用方需持有写锁
+func (s *MemoryStore) linkTags(target TagTarget, id uint, tagIDs []uint) {
+	if len(tagIDs) == 0 {
+		return
+	}
+	links := s.taggings[target][id]
+	if links == nil {
+		links = make(map[uint]bool)
+		s.taggings[target][id] = links
+	}
+	for _, tid := range tagIDs {
+		links[tid] = true
+	}
+}
+
+// entityTags 按名称升序返回实体的标签，调用方需持有锁
+func (s *MemoryStore) entityTags(target TagTarget, id uint) []models.Tag {
+	tags := make([]models.Tag, 0, len(s.taggings[target][id]))
+	for tid := range s.taggings[target][id] {
+		tags = append(tags, s.tags[tid])
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Name < tags[j].Name })
+	return tags
+}
+
+// collectTagTargets 将未删除的实体连同标签追加到records，调用方需持有锁
+func (s *MemoryStore) collectTagTargets(target TagTarget, ids []uint, records *CascadeRecords) {
+	for _, id := range ids {
+		switch target {
+		case TagTargetProvider:
+			if p, ok := s.providers[id]; ok {
+				p.Tags = s.entityTags(target, id)
+				records.addProvider(p)
+			}
+		case TagTargetProduct:
+			if p, ok := s.products[id]; ok {
+				p.Tags = s.entityTags(target, id)
+				records.addProduct(p)
+			}
+		default:
+			if item, ok := s.configItems[id]; ok {
+				item.Tags = s.entityTags(target, id)
+				records.addConfigItem(item)
+			}
+		}
+	}
+}
+
+// matchTags 判断实体的标签是否满足过滤条件，match含义与tagFilterScope一致，调用方需持有锁
+func (s *MemoryStore) matchTags(target TagTarget, id uint, names []string, match string) bool {
+	matched := 0
+	for tid := range s.taggings[target][id] {
+		if containsString(names, s.tags[tid].Name) {
+			matched++
+		}
+	}
+	if match == TagMatchAll {
+		return matched == len(names)
+	}
+	return matched > 0
+}
+
+// sortedProviders 按ID升序返回云服务商列表，调用方需持有锁
+func (s *MemoryStore) sortedProviders(match func(models.CloudProvider) bool) []models.CloudProvider {
+	providers := make([]models.CloudProvider, 0, len(s.providers))
+	for _, p := range s.providers {
+		if match == nil || match(p) {
+			providers = append(providers, p)
+		}
+	}
+	sort.Slice(providers, func(i, j int) bool { return providers[i].ID < providers[j].ID })
+	return providers
+}
+
+// sortedProducts 按ID升序返回云产品列表，调用方需持有锁
+func (s *MemoryStore) sortedProducts(match func(models.CloudProduct) bool) []models.CloudProduct {
+	products := make([]models.CloudProduct, 0, len(s.products))
+	for _, p := range s.products {
+		if match == nil || match(p) {
+			products = append(products, p)
+		}
+	}
+	sort.Slice(products, func(i, j int) bool { return products[i].ID < products[j].ID })
+	return products
+}
+
+// sortedConfigItems 按ID升序返回配置项列表，调用方需持有锁
+func (s *MemoryStore) sortedConfigItems(match func(models.ConfigurationItem) bool) []models.ConfigurationItem {
+	items := make([]models.ConfigurationItem, 0, len(s.configItems))
+	for _, item := range s.configItems {
+		if match == nil || match(item) {
+			items = append(items, item)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+	return items
+}
+
+// anyProvider 获取云服务商，包括回收站中的记录，调用方需持有锁
+func (s *MemoryStore) anyProvider(id uint) models.CloudProvider {
+	if p, ok := s.providers[id]; ok {
+		return p
+	}
+	return s.deletedProviders[id]
+}
+
+// anyProduct 获取云产品，包括回收站中的记录，调用方需持有锁
+func (s *MemoryStore) anyProduct(id uint) models.CloudProduct {
+	if p, ok := s.products[id]; ok {
+		return p
+	}
+	return s.deletedProducts[id]
+}
+
+// preloadConfigItem 填充配置项关联的服务商和产品，调用方需持有锁
+func (s *MemoryStore) preloadConfigItem(item *models.ConfigurationItem) {
+	item.Provider = s.providers[item.CloudProviderID]
+	item.Product = s.products[item.ProductID]
+}
+
+// containsID 判断ID是否在列表中
+func containsID(ids []uint, id uint) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+// containsString 判断字符串是否在列表中
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// containsFold 大小写不敏感的子串匹配，模拟MySQL LIKE '%kw%'
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// paginateSlice 按页码截取切片，参数规则与Paginate保持一致
+func paginateSlice(total, page, pageSize int) (int, int) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	start := (page - 1) * pageSize
+	if start > total {
+		start = total
+	}
+	end := start + pageSize
+	if end > total {
+		end = total
+	}
+	return start, end
+}
+
+// memoryBaseRepository 内存仓库基础实现
+type memoryBaseRepository struct {
+	store *MemoryStore
+}
+
+// Close 内存仓库无需释放资源
+func (r *memoryBaseRepository) Close() error {
+	return nil
+}
@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/yourusername/cloud-eye/internal/models"
+)
+
+// memoryTagRepository 标签仓库内存实现
+type memoryTagRepository struct {
+	memoryBaseRepository
+}
+
+// NewMemoryTagRepository 创建标签仓库内存实现
+func NewMemoryTagRepository(store *MemoryStore) TagRepository {
+	return &memoryTagRepository{
+		memoryBaseRepository: memoryBaseRepository{store: store},
+	}
+}
+
+// GetAll 获取所有标签及其使用次数
+func (r *memoryTagRepository) GetAll(ctx context.Context) ([]models.Tag, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	tags := r.withUsage(nil)
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Name < tags[j].Name })
+	return tags, nil
+}
+
+// GetByID 根据ID获取标签
+func (r *memoryTagRepository) GetByID(ctx context.Context, id uint) (*models.Tag, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	tag, ok := r.store.tags[id]
+	if !ok {
+		return nil, nil
+	}
+	return &tag, nil
+}
+
+// Suggest 按前缀匹配标签名称，按使用次数降序返回
+func (r *memoryTagRepository) Suggest(ctx context.Context, prefix string, limit int) ([]models.Tag, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	lower := strings.ToLower(prefix)
+	tags := r.withUsage(func(t models.Tag) bool {
+		return strings.HasPrefix(strings.ToLower(t.Name), lower)
+	})
+	sort.Slice(tags, func(i, j int) bool {
+		if *tags[i].UsageCount != *tags[j].UsageCount {
+			return *tags[i].UsageCount > *tags[j].UsageCount
+		}
+		return tags[i].Name < tags[j].Name
+	})
+	if len(tags) > limit {
+		tags = tags[:limit]
+	}
+	return tags, nil
+}
+
+// withUsage 返回匹配的标签并填充使用次数，调用方需持有锁
+func (r *memoryTagRepository) withUsage(match func(models.Tag) bool) []models.Tag {
+	usage := make(map[uint]int64)
+	for target, entities := range r.store.taggings {
+		for id, links := range entities {
+			// 回收站中的实体不计入使用次数
+			if !r.store.targetExists(target, id) {
+				continue
+			}
+			for tid := range links {
+				usage[tid]++
+			}
+		}
+	}
+
+	tags := make([]models.Tag, 0, len(r.store.tags))
+	for _, t := range r.store.tags {
+		if match != nil && !match(t) {
+			continue
+		}
+		count := usage[t.ID]
+		t.UsageCount = &count
+		tags = append(tags, t)
+	}
+	return tags
+}
+
+// Delete 删除标签及其所有关联
+func (r *memoryTagRepository) Delete(ctx context.Context, id uint) (*CascadeRecords, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	tagged := make(map[TagTarget][]uint)
+	for target, entities := range r.store.taggings {
+		for eid, links := range entities {
+			if links[id] {
+				delete(links, id)
+				tagged[target] = append(tagged[target], eid)
+			}
+		}
+	}
+	delete(r.store.tags, id)
+
+	records := &CascadeRecords{}
+	for target, ids := range tagged {
+		r.store.collectTagTargets(target, ids, records)
+	}
+	records.sortByID()
+	return records, nil
+}
+
+// MissingTargets 返回ids中不存在的实体ID
+func (r *memoryTagRepository) MissingTargets(ctx context.Context, target TagTarget, ids []uint) ([]uint, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var missing []uint
+	for _, id := range ids {
+		if !r.store.targetExists(target, id) {
+			missing = append(missing, id)
+		}
+	}
+	return missing, nil
+}
+
+// Attach 为实体批量添加标签
+func (r *memoryTagRepository) Attach(ctx context.Context, target TagTarget, ids []uint, names []string) (*CascadeRecords, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	tagIDs := r.store.ensureTags(names)
+	var changed []uint
+	for _, id := range ids {
+		if !r.store.targetExists(target, id) {
+			continue
+		}
+		for _, tid := range tagIDs {
+			if !r.store.taggings[target][id][tid] {
+				changed = append(changed, id)
+				break
+			}
+		}
+		r.store.linkTags(target, id, tagIDs)
+	}
+
+	records := &CascadeRecords{}
+	r.store.collectTagTargets(target, changed, records)
+	records.sortByID()
+	return records, nil
+}
+
+// Detach 批量移除实体的标签
+func (r *memoryTagRepository) Detach(ctx context.Context, target TagTarget, ids []uint, names []string) (*CascadeRecords, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var changed []uint
+	for _, id := range ids {
+		links := r.store.taggings[target][id]
+		removed := false
+		for tid := range links {
+			if containsString(names, r.store.tags[tid].Name) {
+				delete(links, tid)
+				removed = true
+			}
+		}
+		if removed {
+			changed = append(changed, id)
+		}
+	}
+
+	records := &CascadeRecords{}
+	r.store.collectTagTargets(target, changed, records)
+	records.sortByID()
+	return records, nil
+}
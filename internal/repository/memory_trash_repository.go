@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/yourusername/cloud-eye/internal/models"
+	"gorm.io/gorm"
+)
+
+// memoryTrashRepository 回收站仓库内存实现
+type memoryTrashRepository struct {
+	memoryBaseRepository
+}
+
+// NewMemoryTrashRepository 创建回收站仓库内存实现
+func NewMemoryTrashRepository(store *MemoryStore) TrashRepository {
+	return &memoryTrashRepository{
+		memoryBaseRepository: memoryBaseRepository{store: store},
+	}
+}
+
+// List 按删除时间倒序列出回收站中的实体
+func (r *memoryTrashRepository) List(ctx context.Context, filter TrashFilter) (*PageResult, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var data interface{}
+	var total int
+	switch filter.Target {
+	case TrashTargetProvider:
+		providers := make([]models.CloudProvider, 0, len(r.store.deletedProviders))
+		for _, p := range r.store.deletedProviders {
+			providers = append(providers, p)
+		}
+		sortTrashed(providers, func(p models.CloudProvider) (time.Time, uint) { return p.DeletedAt.Time, p.ID })
+		start, end := paginateSlice(len(providers), filter.Page, filter.PageSize)
+		data, total = providers[start:end], len(providers)
+	case TrashTargetProduct:
+		products := make([]models.CloudProduct, 0, len(r.store.deletedProducts))
+		for _, p := range r.store.deletedProducts {
+			p.Provider = r.store.anyProvider(p.CloudProviderID)
+			products = append(products, p)
+		}
+		sortTrashed(products, func(p models.CloudProduct) (time.Time, uint) { return p.DeletedAt.Time, p.ID })
+		start, end := paginateSlice(len(products), filter.Page, filter.PageSize)
+		data, total = products[start:end], len(products)
+	default:
+		items := make([]models.ConfigurationItem, 0, len(r.store.deletedConfigItems))
+		for _, item := range r.store.deletedConfigItems {
+			item.Provider = r.store.anyProvider(item.CloudProviderID)
+			item.Product = r.store.anyProduct(item.ProductID)
+			items = append(items, item)
+		}
+		sortTrashed(items, func(item models.ConfigurationItem) (time.Time, uint) { return item.DeletedAt.Time, item.ID })
+		start, end := paginateSlice(len(items), filter.Page, filter.PageSize)
+		data, total = items[start:end], len(items)
+	}
+
+	return &PageResult{
+		Total:    int64(total),
+		Page:     filter.Page,
+		PageSize: filter.PageSize,
+		Data:     data,
+	}, nil
+}
+
+// sortTrashed 按删除时间和ID倒序排列
+func sortTrashed[T any](rows []T, key func(T) (time.Time, uint)) {
+	sort.Slice(rows, func(i, j int) bool {
+		ti, idi := key(rows[i])
+		tj, idj := key(rows[j])
+		if !ti.Equal(tj) {
+			return ti.After(tj)
+		}
+		return idi > idj
+	})
+}
+
+// GetDeletedAt 获取实体的删除时间
+func (r *memoryTrashRepository) GetDeletedAt(ctx context.Context, target TrashTarget, id uint) (*time.Time, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	deletedAt, ok := r.deletedAt(target, id)
+	if !ok {
+		return nil, nil
+	}
+	return &deletedAt, nil
+}
+
+// deletedAt 获取回收站中实体的删除时间，调用方需持有锁
+func (r *memoryTrashRepository) deletedAt(target TrashTarget, id uint) (time.Time, bool) {
+	switch target {
+	case TrashTargetProvider:
+		p, ok := r.store.deletedProviders[id]
+		return p.DeletedAt.Time, ok
+	case TrashTargetProduct:
+		p, ok := r.store.deletedProducts[id]
+		return p.DeletedAt.Time, ok
+	default:
+		item, ok := r.store.deletedConfigItems[id]
+		return item.DeletedAt.Time, ok
+	}
+}
+
+// ParentDeleted 判断实体的上级是否在回收站中
+func (r *memoryTrashRepository) ParentDeleted(ctx context.Context, target TrashTarget, id uint) (bool, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	switch target {
+	case TrashTargetProduct:
+		p, ok := r.store.deletedProducts[id]
+		if !ok {
+			return false, nil
+		}
+		_, deleted := r.store.deletedProviders[p.CloudProviderID]
+		return deleted, nil
+	case TrashTargetConfigItem:
+		item, ok := r.store.deletedConfigItems[id]
+		if !ok {
+			return false, nil
+		}
+		_, deleted := r.store.deletedProducts[item.ProductID]
+		return deleted, nil
+	default:
+		return false, nil
+	}
+}
+
+// Restore 恢复实体及其在同一次删除中被级联删除的下级记录
+func (r *memoryTrashRepository) Restore(ctx context.Context, target TrashTarget, id uint) (*CascadeRecords, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.deletedAt(target, id); !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	records := &CascadeRecords{}
+	switch target {
+	case TrashTargetProvider:
+		r.store.restoreProvider(id, records)
+	case TrashTargetProduct:
+		r.store.restoreProduct(id, records)
+	default:
+		r.store.restoreConfigItem(id, records)
+	}
+	records.sortByID()
+	return records, nil
+}
+
+// Purge 彻底删除回收站中的实体及其下级记录
+func (r *memoryTrashRepository) Purge(ctx context.Context, target TrashTarget, id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.deletedAt(target, id); !ok {
+		return nil
+	}
+
+	switch target {
+	case TrashTargetProvider:
+		r.store.purgeProvider(id)
+	case TrashTargetProduct:
+		r.store.purgeProduct(id)
+	default:
+		r.store.purgeConfigItem(id)
+	}
+	return nil
+}
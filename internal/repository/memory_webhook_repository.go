@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/yourusername/cloud-eye/internal/models"
+)
+
+// memoryWebhookRepository Webhook仓库内存实现
+type memoryWebhookRepository struct {
+	memoryBaseRepository
+}
+
+// NewMemoryWebhookRepository 创建Webhook仓库内存实现
+func NewMemoryWebhookRepository(store *MemoryStore) WebhookRepository {
+	return &memoryWebhookRepository{
+		memoryBaseRepository: memoryBaseRepository{store: store},
+	}
+}
+
+// GetAll 获取所有订阅
+func (r *memoryWebhookRepository) GetAll(ctx context.Context) ([]models.WebhookSubscription, error) {
+	return r.subscriptions(func(models.WebhookSubscription) bool { return true }), nil
+}
+
+// GetByID 根据ID获取订阅
+func (r *memoryWebhookRepository) GetByID(ctx context.Context, id uint) (*models.WebhookSubscription, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	subscription, ok := r.store.webhooks[id]
+	if !ok {
+		return nil, nil
+	}
+	return &subscription, nil
+}
+
+// GetActive 获取所有启用的订阅
+func (r *memoryWebhookRepository) GetActive(ctx context.Context) ([]models.WebhookSubscription, error) {
+	return r.subscriptions(func(s models.WebhookSubscription) bool { return s.Active }), nil
+}
+
+// subscriptions 按ID顺序返回满足条件的订阅
+func (r *memoryWebhookRepository) subscriptions(match func(models.WebhookSubscription) bool) []models.WebhookSubscription {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	subscriptions := make([]models.WebhookSubscription, 0, len(r.store.webhooks))
+	for _, s := range r.store.webhooks {
+		if match(s) {
+			subscriptions = append(subscriptions, s)
+		}
+	}
+	sort.Slice(subscriptions, func(i, j int) bool { return subscriptions[i].ID < subscriptions[j].ID })
+	return subscriptions
+}
+
+// Create 创建订阅
+func (r *memoryWebhookRepository) Create(ctx context.Context, subscription *models.WebhookSubscription) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	subscription.ID = 0
+	r.store.touchCreate("webhook_subscriptions", &subscription.BaseModel)
+	r.store.webhooks[subscription.ID] = *subscription
+	return nil
+}
+
+// Update 更新订阅，记录不存在时按Save语义插入
+func (r *memoryWebhookRepository) Update(ctx context.Context, subscription *models.WebhookSubscription) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if existing, ok := r.store.webhooks[subscription.ID]; ok {
+		subscription.CreatedAt = existing.CreatedAt
+	}
+	r.store.touchCreate("webhook_subscriptions", &subscription.BaseModel)
+	r.store.webhooks[subscription.ID] = *subscription
+	return nil
+}
+
+// Delete 删除订阅及其投递记录
+func (r *memoryWebhookRepository) Delete(ctx context.Context, id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for did, d := range r.store.webhookDeliveries {
+		if d.SubscriptionID == id {
+			delete(r.store.webhookDeliveries, did)
+		}
+	}
+	delete(r.store.webhooks, id)
+	return nil
+}
+
+// CreateDeliveries 批量创建投递记录
+func (r *memoryWebhookRepository) CreateDeliveries(ctx context.Context, deliveries []models.WebhookDelivery) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for i := range deliveries {
+		deliveries[i].ID = 0
+		r.store.touchCreate("webhook_deliveries", &deliveries[i].BaseModel)
+		r.store.webhookDeliveries[deliveries[i].ID] = deliveries[i]
+	}
+	return nil
+}
+
+// GetDelivery 根据ID获取投递记录
+func (r *memoryWebhookRepository) GetDelivery(ctx context.Context, id uint) (*models.WebhookDelivery, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	delivery, ok := r.store.webhookDeliveries[id]
+	if !ok {
+		return nil, nil
+	}
+	return &delivery, nil
+}
+
+// ListDeliveries 按创建时间倒序分页查询投递记录
+func (r *memoryWebhookRepository) ListDeliveries(ctx context.Context, filter DeliveryFilter) (*PageResult, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	deliveries := make([]models.WebhookDelivery, 0)
+	for _, d := range r.store.webhookDeliveries {
+		if d.SubscriptionID != filter.SubscriptionID {
+			continue
+		}
+		if filter.Status != "" && d.Status != filter.Status {
+			continue
+		}
+		if filter.Event != "" && d.Event != filter.Event {
+			continue
+		}
+		d.Payload = ""
+		deliveries = append(deliveries, d)
+	}
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].ID > deliveries[j].ID })
+
+	start, end := paginateSlice(len(deliveries), filter.Page, filter.PageSize)
+	return &PageResult{
+		Total:    int64(len(deliveries)),
+		Page:     filter.Page,
+		PageSize: filter.PageSize,
+		Data:     deliveries[start:end],
+	}, nil
+}
+
+// DueDeliveries 获取到期的待投递记录
+func (r *memoryWebhookRepository) DueDeliveries(ctx context.Context, now time.Time, limit int) ([]models.WebhookDelivery, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var deliveries []models.WebhookDelivery
+	for _, d := range r.store.webhookDeliveries {
+		if d.Status == models.DeliveryStatusPending && d.NextAttemptAt != nil && !d.NextAttemptAt.After(now) {
+			deliveries = append(deliveries, d)
+		}
+	}
+	sort.Slice(deliveries, func(i, j int) bool {
+		if !deliveries[i].NextAttemptAt.Equal(*deliveries[j].NextAttemptAt) {
+			return deliveries[i].NextAttemptAt.Before(*deliveries[j].NextAttemptAt)
+		}
+		return deliveries[i].ID < deliveries[j].ID
+	})
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+	return deliveries, nil
+}
+
+// ClaimDelivery 领取待投递记录
+func (r *memoryWebhookRepository) ClaimDelivery(ctx context.Context, id uint, attempts int, lease time.Duration) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	d, ok := r.store.webhookDeliveries[id]
+	if !ok || d.Status != models.DeliveryStatusPending || d.Attempts != attempts {
+		return false, nil
+	}
+	now := time.Now()
+	next := now.Add(lease)
+	d.Attempts++
+	d.LastAttemptAt = &now
+	d.NextAttemptAt = &next
+	d.UpdatedAt = now
+	r.store.webhookDeliveries[id] = d
+	return true, nil
+}
+
+// UpdateDelivery 保存投递结果，订阅已删除时忽略
+func (r *memoryWebhookRepository) UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	d, ok := r.store.webhookDeliveries[delivery.ID]
+	if !ok || d.Attempts != delivery.Attempts {
+		return false, nil
+	}
+	d.Status = delivery.Status
+	d.ResponseStatus = delivery.ResponseStatus
+	d.ResponseBody = delivery.ResponseBody
+	d.Error = delivery.Error
+	d.DurationMs = delivery.DurationMs
+	d.NextAttemptAt = delivery.NextAttemptAt
+	d.UpdatedAt = time.Now()
+	r.store.webhookDeliveries[delivery.ID] = d
+	delivery.UpdatedAt = d.UpdatedAt
+	return true, nil
+}
@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/yourusername/cloud-eye/internal/models"
+	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// ProductCategoryRepository 产品类别仓库接口
+type ProductCategoryRepository interface {
+	Repository
+	GetAll(ctx context.Context) ([]models.ProductCategory, error)
+	GetByID(ctx context.Context, id uint) (*models.ProductCategory, error)
+	GetByCode(ctx context.Context, code string) (*models.ProductCategory, error)
+	Create(ctx context.Context, category *models.ProductCategory) error
+	Update(ctx context.Context, category *models.ProductCategory) error
+	// Delete 删除类别，该类别下的产品保留并变为未分类
+	Delete(ctx context.Context, id uint) error
+	// AssignProducts 将产品归入类别，categoryID为nil时变为未分类
+	AssignProducts(ctx context.Context, productIDs []uint, categoryID *uint) error
+}
+
+// productCategoryRepository 产品类别仓库实现
+type productCategoryRepository struct {
+	BaseRepository
+}
+
+// NewProductCategoryRepository 创建产品类别仓库
+func NewProductCategoryRepository(db *gorm.DB) ProductCategoryRepository {
+	return &productCategoryRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// GetAll 获取所有产品类别
+func (r *productCategoryRepository) GetAll(ctx context.Context) ([]models.ProductCategory, error) {
+	var categories []models.ProductCategory
+	err := r.DB.WithContext(ctx).Order("id").Find(&categories).Error
+	if err != nil {
+		logger.Error("Failed to get all product categories", err)
+		return nil, err
+	}
+	return categories, nil
+}
+
+// GetByID 根据ID获取产品类别
+func (r *productCategoryRepository) GetByID(ctx context.Context, id uint) (*models.ProductCategory, error) {
+	var category models.ProductCategory
+	err := r.DB.WithContext(ctx).First(&category, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.Error("Failed to get product category by ID", err)
+		return nil, err
+	}
+	return &category, nil
+}
+
+// GetByCode 根据代码获取产品类别
+func (r *productCategoryRepository) GetByCode(ctx context.Context, code string) (*models.ProductCategory, error) {
+	var category models.ProductCategory
+	err := r.DB.WithContext(ctx).Where("code = ?", code).First(&category).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.Error("Failed to get product category by code", err)
+		return nil, err
+	}
+	return &category, nil
+}
+
+// Create 创建产品类别
+func (r *productCategoryRepository) Create(ctx context.Context, category *models.ProductCategory) error {
+	err := r.DB.WithContext(ctx).Create(category).Error
+	if err != nil {
+		logger.Error("Failed to create product category", err)
+		return err
+	}
+	return nil
+}
+
+// Update 更新产品类别
+func (r *productCategoryRepository) Update(ctx context.Context, category *models.ProductCategory) error {
+	err := r.DB.WithContext(ctx).Save(category).Error
+	if err != nil {
+		logger.Error("Failed to update product category", err)
+		return err
+	}
+	return nil
+}
+
+// Delete 删除产品类别
+func (r *productCategoryRepository) Delete(ctx context.Context, id uint) error {
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.CloudProduct{}).
+			Where("category_id = ?", id).
+			Update("category_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.ProductCategory{}, id).Error
+	})
+	if err != nil {
+		logger.Error("Failed to delete product category", err)
+		return err
+	}
+	return nil
+}
+
+// AssignProducts 将产品归入类别
+func (r *productCategoryRepository) AssignProducts(ctx context.Context, productIDs []uint, categoryID *uint) error {
+	err := r.DB.WithContext(ctx).Model(&models.CloudProduct{}).
+		Where("id IN ?", productIDs).
+		Update("category_id", categoryID).Error
+	if err != nil {
+		logger.Error("Failed to assign products to category", err)
+		return err
+	}
+	return nil
+}
+
+// categorySubtreeIDs 返回类别及其所有子孙类别的ID，用于按类别过滤时包含子类别
+func categorySubtreeIDs(db *gorm.DB, ids []uint) ([]uint, error) {
+	var categories []models.ProductCategory
+	if err := db.Session(&gorm.Session{NewDB: true}).
+		Model(&models.ProductCategory{}).
+		Select("id", "parent_id").
+		Find(&categories).Error; err != nil {
+		return nil, err
+	}
+	return expandCategoryTree(categories, ids), nil
+}
+
+// expandCategoryTree 在类别列表中展开ids的子树，结果包含ids本身
+func expandCategoryTree(categories []models.ProductCategory, ids []uint) []uint {
+	children := make(map[uint][]uint)
+	for _, c := range categories {
+		if c.ParentID != nil {
+			children[*c.ParentID] = append(children[*c.ParentID], c.ID)
+		}
+	}
+
+	seen := make(map[uint]bool)
+	result := make([]uint, 0, len(ids))
+	queue := append([]uint{}, ids...)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, id)
+		queue = append(queue, children[id]...)
+	}
+	return result
+}
@@ -2,8 +2,8 @@ package repository
 
 import (
 	"context"
+	"errors"
 
-	"github.com/yourusername/cloud-eye/internal/models"
 	"gorm.io/gorm"
 )
 
@@ -36,6 +36,21 @@ func (r *BaseRepository) Transaction(ctx context.Context, fn func(tx *gorm.DB) e
 	return r.DB.WithContext(ctx).Transaction(fn)
 }
 
+// IsDuplicateKey 判断错误是否为唯一约束冲突
+func IsDuplicateKey(err error) bool {
+	return errors.Is(err, gorm.ErrDuplicatedKey)
+}
+
+// IsForeignKeyViolation 判断错误是否为外键约束冲突
+func IsForeignKeyViolation(err error) bool {
+	return errors.Is(err, gorm.ErrForeignKeyViolated)
+}
+
+// IsNotFound 判断错误是否为记录不存在
+func IsNotFound(err error) bool {
+	return errors.Is(err, gorm.ErrRecordNotFound)
+}
+
 // Paginate 分页查询辅助函数
 func Paginate(page, pageSize int) func(db *gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
@@ -58,8 +73,21 @@ func Paginate(page, pageSize int) func(db *gorm.DB) *gorm.DB {
 
 // PageResult 分页结果
 type PageResult struct {
-	Total    int64       `json:"total"`     // 总记录数
-	Page     int         `json:"page"`      // 当前页码
-	PageSize int         `json:"page_size"` // 每页大小
-	Data     interface{} `json:"data"`      // 数据列表
+	Total      int64       `json:"total"`                 // 总记录数，游标分页时不统计，值为-1
+	Page       int         `json:"page"`                  // 当前页码，游标分页时为0
+	PageSize   int         `json:"page_size"`             // 每页大小
+	Data       interface{} `json:"data"`                  // 数据列表
+	NextCursor string      `json:"next_cursor,omitempty"` // 下一页游标，仅游标分页时返回
+	PrevCursor string      `json:"prev_cursor,omitempty"` // 上一页游标，仅游标分页时返回
+}
+
+// NewCursorPageResult 创建游标分页结果
+func NewCursorPageResult(data interface{}, pageSize int, next, prev string) *PageResult {
+	return &PageResult{
+		Total:      -1,
+		PageSize:   cursorPageSize(pageSize),
+		Data:       data,
+		NextCursor: next,
+		PrevCursor: prev,
+	}
 }
\ No newline at end of file
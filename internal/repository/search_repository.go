@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/yourusername/cloud-eye/internal/models"
+	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+	"github.com/yourusername/cloud-eye/internal/pkg/search"
+	"gorm.io/gorm"
+)
+
+// fullTextColumns 配置项全文索引覆盖的列，需与init_database.sql中的ft_content索引保持一致
+const fullTextColumns = "configuration_items.name, configuration_items.recommended_value, " +
+	"configuration_items.risk_description, configuration_items.check_method, " +
+	"configuration_items.configuration_method, configuration_items.reference"
+
+// ConfigItemSearchFilter 配置项全文检索条件
+type ConfigItemSearchFilter struct {
+	Query           search.Query `json:"query"`
+	CloudProviderID *uint        `json:"cloud_provider_id,omitempty"`
+	ProductID       *uint        `json:"product_id,omitempty"`
+	Page            int          `json:"page"`
+	PageSize        int          `json:"page_size"`
+}
+
+// ConfigItemSearchHit 配置项检索命中结果
+type ConfigItemSearchHit struct {
+	Item       models.ConfigurationItem `json:"item"`
+	Score      float64                  `json:"score"`
+	Highlights map[string]string        `json:"highlights,omitempty"`
+}
+
+// SearchRepository 全文检索仓库接口
+type SearchRepository interface {
+	Repository
+	SearchConfigItems(ctx context.Context, filter ConfigItemSearchFilter) (*PageResult, error)
+}
+
+// searchRepository 基于MySQL FULLTEXT（ngram分词）的全文检索实现
+type searchRepository struct {
+	BaseRepository
+}
+
+// NewSearchRepository 创建全文检索仓库
+func NewSearchRepository(db *gorm.DB) SearchRepository {
+	return &searchRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// searchScore 检索得分
+type searchScore struct {
+	ID    uint
+	Score float64
+}
+
+// SearchConfigItems 检索配置项，按相关度降序返回并生成高亮摘要
+func (r *searchRepository) SearchConfigItems(ctx context.Context, filter ConfigItemSearchFilter) (*PageResult, error) {
+	var total int64
+
+	query := r.DB.WithContext(ctx).Model(&models.ConfigurationItem{}).
+		Joins("JOIN cloud_providers ON cloud_providers.id = configuration_items.cloud_provider_id").
+		Joins("JOIN cloud_products ON cloud_products.id = configuration_items.product_id")
+
+	if filter.CloudProviderID != nil {
+		query = query.Where("configuration_items.cloud_provider_id = ?", *filter.CloudProviderID)
+	}
+
+	if filter.ProductID != nil {
+		query = query.Where("configuration_items.product_id = ?", *filter.ProductID)
+	}
+
+	// 未限定字段的词：命中全文索引或服务商/产品名称，排除词取反
+	for _, t := range filter.Query.Unscoped() {
+		like := "%" + escapeLike(t.Text) + "%"
+		cond := "(MATCH(" + fullTextColumns + ") AGAINST (? IN BOOLEAN MODE) OR cloud_providers.name LIKE ? OR cloud_products.name LIKE ?)"
+		if t.Negated {
+			cond = "NOT " + cond
+		}
+		query = query.Where(cond, booleanPhrase(t.Text), like, like)
+	}
+
+	// 限定字段的词：在对应列上匹配，排除词需把NULL视为空字符串
+	for _, t := range filter.Query.Scoped() {
+		like := "%" + escapeLike(t.Text) + "%"
+		if t.Negated {
+			query = query.Where("COALESCE("+t.Field.Column()+", '') NOT LIKE ?", like)
+		} else {
+			query = query.Where(t.Field.Column()+" LIKE ?", like)
+		}
+	}
+
+	err := query.Count(&total).Error
+	if err != nil {
+		logger.Error("Failed to count configuration item search results", err)
+		return nil, err
+	}
+
+	// 计算相关度并分页
+	scoreExpr, scoreArgs := scoreExpression(filter.Query)
+	var scores []searchScore
+	err = query.Select("configuration_items.id AS id, "+scoreExpr+" AS score", scoreArgs...).
+		Order("score DESC").
+		Order("configuration_items.id").
+		Scopes(Paginate(filter.Page, filter.PageSize)).
+		Scan(&scores).Error
+	if err != nil {
+		logger.Error("Failed to search configuration items", err)
+		return nil, err
+	}
+
+	hits := make([]ConfigItemSearchHit, 0, len(scores))
+	if len(scores) > 0 {
+		ids := make([]uint, len(scores))
+		for i, s := range scores {
+			ids[i] = s.ID
+		}
+
+		var items []models.ConfigurationItem
+		err = r.DB.WithContext(ctx).
+			Preload("Provider").
+			Preload("Product").
+			Where("id IN ?", ids).
+			Find(&items).Error
+		if err != nil {
+			logger.Error("Failed to load configuration item search results", err)
+			return nil, err
+		}
+
+		byID := make(map[uint]models.ConfigurationItem, len(items))
+		for _, item := range items {
+			byID[item.ID] = item
+		}
+		for _, s := range scores {
+			if item, ok := byID[s.ID]; ok {
+				hits = append(hits, newConfigItemSearchHit(item, filter.Query, s.Score))
+			}
+		}
+	}
+
+	return &PageResult{
+		Total:    total,
+		Page:     filter.Page,
+		PageSize: filter.PageSize,
+		Data:     hits,
+	}, nil
+}
+
+// configItemDocument 将配置项转换为检索文档，需预先加载Provider和Product
+func configItemDocument(item models.ConfigurationItem) search.Document {
+	return search.Document{
+		search.FieldName:                item.Name,
+		search.FieldRecommendedValue:    item.RecommendedValue,
+		search.FieldRiskDescription:     item.RiskDescription,
+		search.FieldCheckMethod:         item.CheckMethod,
+		search.FieldConfigurationMethod: item.ConfigurationMethod,
+		search.FieldReference:           item.Reference,
+		search.FieldProvider:            item.Provider.Name,
+		search.FieldProduct:             item.Product.Name,
+	}
+}
+
+// newConfigItemSearchHit 构造检索命中结果
+func newConfigItemSearchHit(item models.ConfigurationItem, q search.Query, score float64) ConfigItemSearchHit {
+	return ConfigItemSearchHit{
+		Item:       item,
+		Score:      score,
+		Highlights: search.Highlights(configItemDocument(item), q, search.DefaultSnippetLength),
+	}
+}
+
+// booleanPhrase 将词转换为BOOLEAN MODE下的短语，ngram分词下短语要求n-gram连续出现
+func booleanPhrase(text string) string {
+	return `"` + strings.ReplaceAll(text, `"`, " ") + `"`
+}
+
+// scoreExpression 生成与search.Score相同的相关度表达式：各词在各字段中出现次数乘以字段权重之和。
+// 出现次数由去掉该词前后的长度差计算，REPLACE区分大小写，因此两侧都转为小写
+func scoreExpression(q search.Query) (string, []interface{}) {
+	var parts []string
+	var args []interface{}
+	for _, t := range q.Terms {
+		if t.Negated {
+			continue
+		}
+		text := strings.ToLower(t.Text)
+		for _, f := range t.Fields() {
+			col := "LOWER(COALESCE(" + f.Column() + ", ''))"
+			parts = append(parts, fmt.Sprintf("%g * ((CHAR_LENGTH(%s) - CHAR_LENGTH(REPLACE(%s, ?, ''))) DIV ?)", f.Weight(), col, col))
+			args = append(args, text, utf8.RuneCountInString(text))
+		}
+	}
+	if len(parts) == 0 {
+		return "0", nil
+	}
+	return strings.Join(parts, " + "), args
+}
+
+// escapeLike 转义LIKE模式中的特殊字符
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
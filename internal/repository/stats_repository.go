@@ -0,0 +1,261 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// StatsTotals 各类实体的总数
+type StatsTotals struct {
+	Providers   int64 `json:"providers"`
+	Products    int64 `json:"products"`
+	ConfigItems int64 `json:"config_items"`
+}
+
+// ProviderStat 云服务商维度的统计
+type ProviderStat struct {
+	ProviderID      uint   `json:"provider_id"`
+	ProviderCode    string `json:"provider_code"`
+	ProviderName    string `json:"provider_name"`
+	ProductCount    int64  `json:"product_count"`
+	ConfigItemCount int64  `json:"config_item_count"`
+}
+
+// ProductStat 云产品维度的统计
+type ProductStat struct {
+	ProductID       uint   `json:"product_id"`
+	ProductCode     string `json:"product_code"`
+	ProductName     string `json:"product_name"`
+	ProviderID      uint   `json:"provider_id"`
+	ProviderCode    string `json:"provider_code"`
+	ProviderName    string `json:"provider_name"`
+	ConfigItemCount int64  `json:"config_item_count"`
+}
+
+// CategoryStat 产品类别维度的统计，Total开头的字段包含所有子孙类别
+type CategoryStat struct {
+	CategoryID           uint   `json:"category_id"`
+	ParentID             *uint  `json:"parent_id"`
+	CategoryCode         string `json:"category_code"`
+	CategoryName         string `json:"category_name"`
+	ProductCount         int64  `json:"product_count"`
+	ConfigItemCount      int64  `json:"config_item_count"`
+	TotalProductCount    int64  `json:"total_product_count" gorm:"-"`
+	TotalConfigItemCount int64  `json:"total_config_item_count" gorm:"-"`
+}
+
+// GroupCount 按某一取值分组的计数
+type GroupCount struct {
+	Key   string `json:"key"`
+	Count int64  `json:"count"`
+}
+
+// WeeklyActivity 按周统计的配置项新增和更新数量，周一为一周的开始
+type WeeklyActivity struct {
+	WeekStart time.Time `json:"week_start"`
+	Created   int64     `json:"created"`
+	Updated   int64     `json:"updated"`
+}
+
+// StatsRepository 统计仓库接口，所有统计均通过聚合查询完成
+type StatsRepository interface {
+	Repository
+	Totals(ctx context.Context) (*StatsTotals, error)
+	ProviderStats(ctx context.Context) ([]ProviderStat, error)
+	// ProductStats 统计各产品的配置项数，providerID不为空时仅统计该服务商
+	ProductStats(ctx context.Context, providerID *uint) ([]ProductStat, error)
+	// CategoryStats 统计直接归属各类别的产品数和配置项数，不含子类别
+	CategoryStats(ctx context.Context) ([]CategoryStat, error)
+	// ConfigItemCounts 按配置项列（severity、status）分组计数
+	ConfigItemCounts(ctx context.Context, column string) ([]GroupCount, error)
+	// WeeklyActivity 统计[from, to)内每周新增和更新的配置项数量，仅返回有数据的周
+	WeeklyActivity(ctx context.Context, from, to time.Time) ([]WeeklyActivity, error)
+	// UncoveredProducts 获取尚无任何配置项的产品
+	UncoveredProducts(ctx context.Context) ([]ProductStat, error)
+}
+
+// statsRepository 统计仓库实现
+type statsRepository struct {
+	BaseRepository
+}
+
+// NewStatsRepository 创建统计仓库
+func NewStatsRepository(db *gorm.DB) StatsRepository {
+	return &statsRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// WeekStart 返回时间所在周的周一零点，与MySQL的WEEKDAY计算方式一致
+func WeekStart(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	y, m, d := t.Date()
+	return time.Date(y, m, d-offset, 0, 0, 0, 0, t.Location())
+}
+
+// weekStartExpr 计算时间列所在周的周一（MySQL）
+func weekStartExpr(column string) string {
+	return "DATE_SUB(DATE(" + column + "), INTERVAL WEEKDAY(" + column + ") DAY)"
+}
+
+// Totals 统计各类实体的总数
+func (r *statsRepository) Totals(ctx context.Context) (*StatsTotals, error) {
+	var totals StatsTotals
+	err := r.DB.WithContext(ctx).Raw(`SELECT
+		(SELECT COUNT(*) FROM cloud_providers WHERE deleted_at IS NULL) AS providers,
+		(SELECT COUNT(*) FROM cloud_products WHERE deleted_at IS NULL) AS products,
+		(SELECT COUNT(*) FROM configuration_items WHERE deleted_at IS NULL) AS config_items`).
+		Scan(&totals).Error
+	if err != nil {
+		logger.Error("Failed to count totals", err)
+		return nil, err
+	}
+	return &totals, nil
+}
+
+// ProviderStats 统计各云服务商的产品数和配置项数
+func (r *statsRepository) ProviderStats(ctx context.Context) ([]ProviderStat, error) {
+	var stats []ProviderStat
+	err := r.DB.WithContext(ctx).Table("cloud_providers").
+		Select(`cloud_providers.id AS provider_id, cloud_providers.code AS provider_code, cloud_providers.name AS provider_name,
+			(SELECT COUNT(*) FROM cloud_products WHERE cloud_products.cloud_provider_id = cloud_providers.id AND cloud_products.deleted_at IS NULL) AS product_count,
+			(SELECT COUNT(*) FROM configuration_items WHERE configuration_items.cloud_provider_id = cloud_providers.id AND configuration_items.deleted_at IS NULL) AS config_item_count`).
+		Where("cloud_providers.deleted_at IS NULL").
+		Order("cloud_providers.id").
+		Scan(&stats).Error
+	if err != nil {
+		logger.Error("Failed to get provider stats", err)
+		return nil, err
+	}
+	return stats, nil
+}
+
+// productStatsQuery 构造产品维度统计查询
+func (r *statsRepository) productStatsQuery(ctx context.Context) *gorm.DB {
+	return r.DB.WithContext(ctx).Table("cloud_products").
+		Select(`cloud_products.id AS product_id, cloud_products.code AS product_code, cloud_products.name AS product_name,
+			cloud_providers.id AS provider_id, cloud_providers.code AS provider_code, cloud_providers.name AS provider_name,
+			COUNT(configuration_items.id) AS config_item_count`).
+		Joins("JOIN cloud_providers ON cloud_providers.id = cloud_products.cloud_provider_id").
+		Joins("LEFT JOIN configuration_items ON configuration_items.product_id = cloud_products.id AND configuration_items.deleted_at IS NULL").
+		Where("cloud_products.deleted_at IS NULL").
+		Group("cloud_products.id, cloud_products.code, cloud_products.name, cloud_providers.id, cloud_providers.code, cloud_providers.name").
+		Order("cloud_products.id")
+}
+
+// ProductStats 统计各产品的配置项数
+func (r *statsRepository) ProductStats(ctx context.Context, providerID *uint) ([]ProductStat, error) {
+	var stats []ProductStat
+	query := r.productStatsQuery(ctx)
+	if providerID != nil {
+		query = query.Where("cloud_products.cloud_provider_id = ?", *providerID)
+	}
+
+	if err := query.Scan(&stats).Error; err != nil {
+		logger.Error("Failed to get product stats", err)
+		return nil, err
+	}
+	return stats, nil
+}
+
+// CategoryStats 统计直接归属各类别的产品数和配置项数
+func (r *statsRepository) CategoryStats(ctx context.Context) ([]CategoryStat, error) {
+	var stats []CategoryStat
+	err := r.DB.WithContext(ctx).Table("product_categories").
+		Select(`product_categories.id AS category_id, product_categories.parent_id AS parent_id,
+			product_categories.code AS category_code, product_categories.name AS category_name,
+			COUNT(DISTINCT cloud_products.id) AS product_count, COUNT(configuration_items.id) AS config_item_count`).
+		Joins("LEFT JOIN cloud_products ON cloud_products.category_id = product_categories.id AND cloud_products.deleted_at IS NULL").
+		Joins("LEFT JOIN configuration_items ON configuration_items.product_id = cloud_products.id AND configuration_items.deleted_at IS NULL").
+		Group("product_categories.id, product_categories.parent_id, product_categories.code, product_categories.name").
+		Order("product_categories.id").
+		Scan(&stats).Error
+	if err != nil {
+		logger.Error("Failed to get category stats", err)
+		return nil, err
+	}
+	return stats, nil
+}
+
+// ConfigItemCounts 按配置项列分组计数
+func (r *statsRepository) ConfigItemCounts(ctx context.Context, column string) ([]GroupCount, error) {
+	var counts []GroupCount
+	err := r.DB.WithContext(ctx).Table("configuration_items").
+		Select(column + " AS `key`, COUNT(*) AS count").
+		Where("deleted_at IS NULL").
+		Group(column).
+		Order("count DESC").
+		Scan(&counts).Error
+	if err != nil {
+		logger.Error("Failed to count configuration items by "+column, err)
+		return nil, err
+	}
+	return counts, nil
+}
+
+// WeeklyActivity 按周统计新增和更新的配置项数量；
+// 更新仅统计创建后被修改过的记录（updated_at > created_at）
+func (r *statsRepository) WeeklyActivity(ctx context.Context, from, to time.Time) ([]WeeklyActivity, error) {
+	type weekCount struct {
+		WeekStart time.Time
+		Count     int64
+	}
+
+	var created, updated []weekCount
+	err := r.DB.WithContext(ctx).Table("configuration_items").
+		Select(weekStartExpr("created_at")+" AS week_start, COUNT(*) AS count").
+		Where("created_at >= ? AND created_at < ? AND deleted_at IS NULL", from, to).
+		Group("week_start").
+		Scan(&created).Error
+	if err != nil {
+		logger.Error("Failed to count created configuration items by week", err)
+		return nil, err
+	}
+
+	err = r.DB.WithContext(ctx).Table("configuration_items").
+		Select(weekStartExpr("updated_at")+" AS week_start, COUNT(*) AS count").
+		Where("updated_at >= ? AND updated_at < ? AND updated_at > created_at AND deleted_at IS NULL", from, to).
+		Group("week_start").
+		Scan(&updated).Error
+	if err != nil {
+		logger.Error("Failed to count updated configuration items by week", err)
+		return nil, err
+	}
+
+	weeks := make(map[time.Time]*WeeklyActivity)
+	var activity []WeeklyActivity
+	get := func(week time.Time) *WeeklyActivity {
+		if w, ok := weeks[week]; ok {
+			return w
+		}
+		w := &WeeklyActivity{WeekStart: week}
+		weeks[week] = w
+		return w
+	}
+	for _, c := range created {
+		get(c.WeekStart).Created = c.Count
+	}
+	for _, c := range updated {
+		get(c.WeekStart).Updated = c.Count
+	}
+	for _, w := range weeks {
+		activity = append(activity, *w)
+	}
+	return activity, nil
+}
+
+// UncoveredProducts 获取尚无任何配置项的产品
+func (r *statsRepository) UncoveredProducts(ctx context.Context) ([]ProductStat, error) {
+	var stats []ProductStat
+	err := r.productStatsQuery(ctx).
+		Having("COUNT(configuration_items.id) = 0").
+		Scan(&stats).Error
+	if err != nil {
+		logger.Error("Failed to get uncovered products", err)
+		return nil, err
+	}
+	return stats, nil
+}
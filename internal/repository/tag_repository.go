@@ -0,0 +1,354 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/yourusername/cloud-eye/internal/models"
+	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TagTarget 可打标签的实体类型
+type TagTarget string
+
+// 可打标签的实体
+const (
+	TagTargetProvider   TagTarget = "provider"
+	TagTargetProduct    TagTarget = "product"
+	TagTargetConfigItem TagTarget = "config_item"
+)
+
+// 多个标签过滤时的匹配方式
+const (
+	TagMatchAny = "or"  // 包含任一标签
+	TagMatchAll = "and" // 包含全部标签
+)
+
+// tagLink 实体与标签的关联表定义
+type tagLink struct {
+	Table     string // 实体表
+	JoinTable string // 关联表
+	Column    string // 关联表中的实体外键列
+}
+
+// tagLinks 各实体的标签关联表，与模型中的many2many定义一致
+var tagLinks = map[TagTarget]tagLink{
+	TagTargetProvider:   {Table: "cloud_providers", JoinTable: "cloud_provider_tags", Column: "cloud_provider_id"},
+	TagTargetProduct:    {Table: "cloud_products", JoinTable: "cloud_product_tags", Column: "cloud_product_id"},
+	TagTargetConfigItem: {Table: "configuration_items", JoinTable: "configuration_item_tags", Column: "configuration_item_id"},
+}
+
+// Valid 判断实体类型是否可打标签
+func (t TagTarget) Valid() bool {
+	_, ok := tagLinks[t]
+	return ok
+}
+
+// TagRepository 标签仓库接口
+type TagRepository interface {
+	Repository
+	// GetAll 获取所有标签及其使用次数
+	GetAll(ctx context.Context) ([]models.Tag, error)
+	GetByID(ctx context.Context, id uint) (*models.Tag, error)
+	// Suggest 按前缀匹配标签名称，按使用次数降序返回，用于自动补全
+	Suggest(ctx context.Context, prefix string, limit int) ([]models.Tag, error)
+	// Delete 删除标签及其所有关联，返回因此失去该标签的未删除实体
+	Delete(ctx context.Context, id uint) (*CascadeRecords, error)
+	// MissingTargets 返回ids中不存在的实体ID
+	MissingTargets(ctx context.Context, target TagTarget, ids []uint) ([]uint, error)
+	// Attach 为实体批量添加标签，不存在的标签自动创建，已有的关联保持不变，返回标签有变化的实体
+	Attach(ctx context.Context, target TagTarget, ids []uint, names []string) (*CascadeRecords, error)
+	// Detach 批量移除实体的标签，返回标签有变化的实体
+	Detach(ctx context.Context, target TagTarget, ids []uint, names []string) (*CascadeRecords, error)
+}
+
+// tagRepository 标签仓库实现
+type tagRepository struct {
+	BaseRepository
+}
+
+// NewTagRepository 创建标签仓库
+func NewTagRepository(db *gorm.DB) TagRepository {
+	return &tagRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// tagUsageExpr 标签在所有实体上的使用次数，回收站中的实体不计入
+const tagUsageExpr = `(SELECT COUNT(*) FROM cloud_provider_tags JOIN cloud_providers ON cloud_providers.id = cloud_provider_tags.cloud_provider_id
+		WHERE cloud_provider_tags.tag_id = tags.id AND cloud_providers.deleted_at IS NULL) +
+	(SELECT COUNT(*) FROM cloud_product_tags JOIN cloud_products ON cloud_products.id = cloud_product_tags.cloud_product_id
+		WHERE cloud_product_tags.tag_id = tags.id AND cloud_products.deleted_at IS NULL) +
+	(SELECT COUNT(*) FROM configuration_item_tags JOIN configuration_items ON configuration_items.id = configuration_item_tags.configuration_item_id
+		WHERE configuration_item_tags.tag_id = tags.id AND configuration_items.deleted_at IS NULL)`
+
+// GetAll 获取所有标签及其使用次数
+func (r *tagRepository) GetAll(ctx context.Context) ([]models.Tag, error) {
+	var tags []models.Tag
+	err := r.DB.WithContext(ctx).
+		Select("tags.*, (" + tagUsageExpr + ") AS usage_count").
+		Order("tags.name").
+		Find(&tags).Error
+	if err != nil {
+		logger.Error("Failed to get all tags", err)
+		return nil, err
+	}
+	return tags, nil
+}
+
+// GetByID 根据ID获取标签
+func (r *tagRepository) GetByID(ctx context.Context, id uint) (*models.Tag, error) {
+	var tag models.Tag
+	err := r.DB.WithContext(ctx).First(&tag, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.Error("Failed to get tag by ID", err)
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// Suggest 按前缀匹配标签名称
+func (r *tagRepository) Suggest(ctx context.Context, prefix string, limit int) ([]models.Tag, error) {
+	var tags []models.Tag
+	err := r.DB.WithContext(ctx).
+		Select("tags.*, ("+tagUsageExpr+") AS usage_count").
+		Where("tags.name LIKE ?", escapeLike(prefix)+"%").
+		Order("usage_count DESC, tags.name").
+		Limit(limit).
+		Find(&tags).Error
+	if err != nil {
+		logger.Error("Failed to suggest tags", err)
+		return nil, err
+	}
+	return tags, nil
+}
+
+// Delete 删除标签及其所有关联
+func (r *tagRepository) Delete(ctx context.Context, id uint) (*CascadeRecords, error) {
+	records := &CascadeRecords{}
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		tagged := make(map[TagTarget][]uint, len(tagLinks))
+		for target, link := range tagLinks {
+			var ids []uint
+			if err := tx.Table(link.JoinTable).Where("tag_id = ?", id).Pluck(link.Column, &ids).Error; err != nil {
+				return err
+			}
+			tagged[target] = ids
+		}
+		for _, link := range tagLinks {
+			if err := tx.Exec("DELETE FROM "+link.JoinTable+" WHERE tag_id = ?", id).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Delete(&models.Tag{}, id).Error; err != nil {
+			return err
+		}
+		for target, ids := range tagged {
+			if err := findTagTargets(tx, target, ids, records); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error("Failed to delete tag", err)
+		return nil, err
+	}
+	return records, nil
+}
+
+// MissingTargets 返回ids中不存在的实体ID
+func (r *tagRepository) MissingTargets(ctx context.Context, target TagTarget, ids []uint) ([]uint, error) {
+	var existing []uint
+	err := r.DB.WithContext(ctx).Table(tagLinks[target].Table).
+		Where("id IN ? AND deleted_at IS NULL", ids).
+		Pluck("id", &existing).Error
+	if err != nil {
+		logger.Error("Failed to check tag targets", err)
+		return nil, err
+	}
+
+	var missing []uint
+	for _, id := range ids {
+		if !containsID(existing, id) {
+			missing = append(missing, id)
+		}
+	}
+	return missing, nil
+}
+
+// Attach 为实体批量添加标签
+func (r *tagRepository) Attach(ctx context.Context, target TagTarget, ids []uint, names []string) (*CascadeRecords, error) {
+	records := &CascadeRecords{}
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		tags, err := ensureTags(tx, names)
+		if err != nil {
+			return err
+		}
+
+		// 已有全部标签的实体不受影响
+		link := tagLinks[target]
+		tagIDs := make([]uint, 0, len(tags))
+		for _, t := range tags {
+			tagIDs = append(tagIDs, t.ID)
+		}
+		var unchanged []uint
+		err = tx.Table(link.JoinTable).
+			Where(link.Column+" IN ? AND tag_id IN ?", ids, tagIDs).
+			Group(link.Column).
+			Having("COUNT(DISTINCT tag_id) = ?", len(tagIDs)).
+			Pluck(link.Column, &unchanged).Error
+		if err != nil {
+			return err
+		}
+
+		if err := linkTags(tx, target, ids, tags); err != nil {
+			return err
+		}
+		var changed []uint
+		for _, id := range ids {
+			if !containsID(unchanged, id) {
+				changed = append(changed, id)
+			}
+		}
+		return findTagTargets(tx, target, changed, records)
+	})
+	if err != nil {
+		logger.Error("Failed to attach tags", err)
+		return nil, err
+	}
+	return records, nil
+}
+
+// Detach 批量移除实体的标签
+func (r *tagRepository) Detach(ctx context.Context, target TagTarget, ids []uint, names []string) (*CascadeRecords, error) {
+	records := &CascadeRecords{}
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		link := tagLinks[target]
+		var changed []uint
+		err := tx.Table(link.JoinTable).
+			Where(link.Column+" IN ? AND tag_id IN (SELECT id FROM tags WHERE name IN ?)", ids, names).
+			Distinct(link.Column).
+			Pluck(link.Column, &changed).Error
+		if err != nil {
+			return err
+		}
+
+		if err := unlinkTags(tx, target, ids, names); err != nil {
+			return err
+		}
+		return findTagTargets(tx, target, changed, records)
+	})
+	if err != nil {
+		logger.Error("Failed to detach tags", err)
+		return nil, err
+	}
+	return records, nil
+}
+
+// findTagTargets 读取未删除的实体并预加载标签，追加到records
+func findTagTargets(tx *gorm.DB, target TagTarget, ids []uint, records *CascadeRecords) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query := tx.Preload("Tags").Where("id IN ?", ids).Order("id")
+	switch target {
+	case TagTargetProvider:
+		var providers []models.CloudProvider
+		if err := query.Find(&providers).Error; err != nil {
+			return err
+		}
+		records.Providers = append(records.Providers, providers...)
+	case TagTargetProduct:
+		var products []models.CloudProduct
+		if err := query.Find(&products).Error; err != nil {
+			return err
+		}
+		records.Products = append(records.Products, products...)
+	default:
+		var items []models.ConfigurationItem
+		if err := query.Find(&items).Error; err != nil {
+			return err
+		}
+		records.ConfigItems = append(records.ConfigItems, items...)
+	}
+	return nil
+}
+
+// ensureTags 按名称获取标签，不存在的标签自动创建
+func ensureTags(tx *gorm.DB, names []string) ([]models.Tag, error) {
+	var tags []models.Tag
+	if err := tx.Where("name IN ?", names).Find(&tags).Error; err != nil {
+		return nil, err
+	}
+
+	for _, name := range names {
+		found := false
+		for _, t := range tags {
+			if t.Name == name {
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+
+		tag := models.Tag{Name: name}
+		if err := tx.Create(&tag).Error; err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
+// linkTags 建立实体与标签的关联，已存在的关联忽略
+func linkTags(tx *gorm.DB, target TagTarget, ids []uint, tags []models.Tag) error {
+	if len(ids) == 0 || len(tags) == 0 {
+		return nil
+	}
+
+	link := tagLinks[target]
+	rows := make([]map[string]interface{}, 0, len(ids)*len(tags))
+	for _, id := range ids {
+		for _, t := range tags {
+			rows = append(rows, map[string]interface{}{link.Column: id, "tag_id": t.ID})
+		}
+	}
+	return tx.Table(link.JoinTable).Clauses(clause.Insert{Modifier: "IGNORE"}).Create(rows).Error
+}
+
+// unlinkTags 按标签名称移除实体与标签的关联
+func unlinkTags(tx *gorm.DB, target TagTarget, ids []uint, names []string) error {
+	if len(ids) == 0 || len(names) == 0 {
+		return nil
+	}
+
+	link := tagLinks[target]
+	return tx.Exec(
+		"DELETE FROM "+link.JoinTable+" WHERE "+link.Column+" IN ? AND tag_id IN (SELECT id FROM tags WHERE name IN ?)",
+		ids, names).Error
+}
+
+// tagFilterScope 按标签名称过滤实体，match为TagMatchAll时要求包含全部标签，否则包含任一标签即可
+func tagFilterScope(target TagTarget, names []string, match string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		link := tagLinks[target]
+		sub := db.Session(&gorm.Session{NewDB: true}).Table(link.JoinTable).
+			Select(link.JoinTable+"."+link.Column).
+			Joins("JOIN tags ON tags.id = "+link.JoinTable+".tag_id").
+			Where("tags.name IN ?", names)
+		if match == TagMatchAll {
+			sub = sub.Group(link.JoinTable+"."+link.Column).
+				Having("COUNT(DISTINCT tags.id) = ?", len(names))
+		}
+		return db.Where(link.Table+".id IN (?)", sub)
+	}
+}
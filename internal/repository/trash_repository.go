@@ -0,0 +1,343 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/yourusername/cloud-eye/internal/models"
+	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// TrashTarget 支持软删除的实体类型
+type TrashTarget string
+
+// 回收站中的实体
+const (
+	TrashTargetProvider   TrashTarget = "provider"
+	TrashTargetProduct    TrashTarget = "product"
+	TrashTargetConfigItem TrashTarget = "config_item"
+)
+
+// Valid 判断实体类型是否支持回收站
+func (t TrashTarget) Valid() bool {
+	return t == TrashTargetProvider || t == TrashTargetProduct || t == TrashTargetConfigItem
+}
+
+// TrashFilter 回收站列表查询条件
+type TrashFilter struct {
+	Target   TrashTarget `json:"target"`
+	Page     int         `json:"page"`
+	PageSize int         `json:"page_size"`
+}
+
+// TrashRepository 回收站仓库接口
+type TrashRepository interface {
+	Repository
+	// List 按删除时间倒序列出回收站中的实体
+	List(ctx context.Context, filter TrashFilter) (*PageResult, error)
+	// GetDeletedAt 获取实体的删除时间，实体不存在或未删除时返回nil
+	GetDeletedAt(ctx context.Context, target TrashTarget, id uint) (*time.Time, error)
+	// ParentDeleted 判断实体的上级（产品的服务商、配置项的产品）是否在回收站中
+	ParentDeleted(ctx context.Context, target TrashTarget, id uint) (bool, error)
+	// Restore 恢复实体，并一同恢复与其在同一次删除中被级联删除的下级记录，返回恢复的全部记录
+	Restore(ctx context.Context, target TrashTarget, id uint) (*CascadeRecords, error)
+	// Purge 彻底删除回收站中的实体及其下级记录
+	Purge(ctx context.Context, target TrashTarget, id uint) error
+}
+
+// CascadeRecords 一次软删除、恢复或标签变更涉及的全部记录，预加载标签
+type CascadeRecords struct {
+	Providers   []models.CloudProvider
+	Products    []models.CloudProduct
+	ConfigItems []models.ConfigurationItem
+}
+
+// addProvider 记录云服务商，records为nil时忽略
+func (c *CascadeRecords) addProvider(provider models.CloudProvider) {
+	if c != nil {
+		c.Providers = append(c.Providers, provider)
+	}
+}
+
+// addProduct 记录云产品，records为nil时忽略
+func (c *CascadeRecords) addProduct(product models.CloudProduct) {
+	if c != nil {
+		c.Products = append(c.Products, product)
+	}
+}
+
+// addConfigItem 记录配置项，records为nil时忽略
+func (c *CascadeRecords) addConfigItem(item models.ConfigurationItem) {
+	if c != nil {
+		c.ConfigItems = append(c.ConfigItems, item)
+	}
+}
+
+// sortByID 按ID升序排列记录，与数据库按主键读取的顺序一致
+func (c *CascadeRecords) sortByID() {
+	sort.Slice(c.Providers, func(i, j int) bool { return c.Providers[i].ID < c.Providers[j].ID })
+	sort.Slice(c.Products, func(i, j int) bool { return c.Products[i].ID < c.Products[j].ID })
+	sort.Slice(c.ConfigItems, func(i, j int) bool { return c.ConfigItems[i].ID < c.ConfigItems[j].ID })
+}
+
+// trashRepository 回收站仓库实现
+type trashRepository struct {
+	BaseRepository
+}
+
+// NewTrashRepository 创建回收站仓库
+func NewTrashRepository(db *gorm.DB) TrashRepository {
+	return &trashRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// trashScope 软删除和恢复时需要一同处理的一类记录
+type trashScope struct {
+	Model interface{}
+	Where string
+}
+
+// trashCascade 返回实体自身及其下级记录，下级记录在前
+func trashCascade(target TrashTarget) []trashScope {
+	switch target {
+	case TrashTargetProvider:
+		return []trashScope{
+			{Model: &models.ConfigurationItem{}, Where: "cloud_provider_id = ?"},
+			{Model: &models.CloudProduct{}, Where: "cloud_provider_id = ?"},
+			{Model: &models.CloudProvider{}, Where: "id = ?"},
+		}
+	case TrashTargetProduct:
+		return []trashScope{
+			{Model: &models.ConfigurationItem{}, Where: "product_id = ?"},
+			{Model: &models.CloudProduct{}, Where: "id = ?"},
+		}
+	default:
+		return []trashScope{
+			{Model: &models.ConfigurationItem{}, Where: "id = ?"},
+		}
+	}
+}
+
+// softDeleteCascade 软删除实体及其未删除的下级记录，同一次删除使用相同的删除时间以便一并恢复，返回删除的全部记录
+func softDeleteCascade(tx *gorm.DB, target TrashTarget, id uint) (*CascadeRecords, error) {
+	records, err := findCascade(tx, target, id)
+	if err != nil {
+		return nil, err
+	}
+
+	deletedAt := time.Now()
+	for _, scope := range trashCascade(target) {
+		if err := tx.Model(scope.Model).Where(scope.Where, id).UpdateColumn("deleted_at", deletedAt).Error; err != nil {
+			return nil, err
+		}
+	}
+	return records, nil
+}
+
+// findCascade 按trashCascade的范围读取实体及其下级记录，db上的条件决定读取未删除或已删除的记录
+func findCascade(db *gorm.DB, target TrashTarget, id uint) (*CascadeRecords, error) {
+	records := &CascadeRecords{}
+	for _, scope := range trashCascade(target) {
+		query := db.Preload("Tags").Where(scope.Where, id).Order("id")
+		var err error
+		switch scope.Model.(type) {
+		case *models.CloudProvider:
+			err = query.Find(&records.Providers).Error
+		case *models.CloudProduct:
+			err = query.Find(&records.Products).Error
+		default:
+			err = query.Find(&records.ConfigItems).Error
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return records, nil
+}
+
+// List 列出回收站中的实体
+func (r *trashRepository) List(ctx context.Context, filter TrashFilter) (*PageResult, error) {
+	// 上级记录可能也在回收站中，预加载时不过滤已删除记录
+	unscoped := func(db *gorm.DB) *gorm.DB { return db.Unscoped() }
+
+	var data interface{}
+	var total int64
+	var err error
+	switch filter.Target {
+	case TrashTargetProvider:
+		data, total, err = listTrashed[models.CloudProvider](r.DB.WithContext(ctx), filter, nil)
+	case TrashTargetProduct:
+		data, total, err = listTrashed[models.CloudProduct](r.DB.WithContext(ctx), filter, func(db *gorm.DB) *gorm.DB {
+			return db.Preload("Provider", unscoped)
+		})
+	default:
+		data, total, err = listTrashed[models.ConfigurationItem](r.DB.WithContext(ctx), filter, func(db *gorm.DB) *gorm.DB {
+			return db.Preload("Provider", unscoped).Preload("Product", unscoped)
+		})
+	}
+	if err != nil {
+		logger.Error("Failed to list trash", err)
+		return nil, err
+	}
+
+	return &PageResult{
+		Total:    total,
+		Page:     filter.Page,
+		PageSize: filter.PageSize,
+		Data:     data,
+	}, nil
+}
+
+// listTrashed 按删除时间倒序分页查询已删除的记录，preload为空时不加载关联
+func listTrashed[T any](db *gorm.DB, filter TrashFilter, preload func(db *gorm.DB) *gorm.DB) ([]T, int64, error) {
+	var total int64
+	err := db.Unscoped().Model(new(T)).Where("deleted_at IS NOT NULL").Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := db.Unscoped().Where("deleted_at IS NOT NULL")
+	if preload != nil {
+		query = preload(query)
+	}
+
+	var rows []T
+	err = query.Scopes(Paginate(filter.Page, filter.PageSize)).
+		Order("deleted_at DESC, id DESC").
+		Find(&rows).Error
+	return rows, total, err
+}
+
+// trashModel 返回实体类型对应的模型
+func trashModel(target TrashTarget) interface{} {
+	switch target {
+	case TrashTargetProvider:
+		return &models.CloudProvider{}
+	case TrashTargetProduct:
+		return &models.CloudProduct{}
+	default:
+		return &models.ConfigurationItem{}
+	}
+}
+
+// GetDeletedAt 获取实体的删除时间
+func (r *trashRepository) GetDeletedAt(ctx context.Context, target TrashTarget, id uint) (*time.Time, error) {
+	return getDeletedAt(r.DB.WithContext(ctx), target, id)
+}
+
+// getDeletedAt 获取实体的删除时间，实体不存在或未删除时返回nil
+func getDeletedAt(db *gorm.DB, target TrashTarget, id uint) (*time.Time, error) {
+	var row struct {
+		DeletedAt gorm.DeletedAt
+	}
+	err := db.Unscoped().Model(trashModel(target)).
+		Select("deleted_at").
+		Where("id = ?", id).
+		Scan(&row).Error
+	if err != nil {
+		logger.Error("Failed to get deleted time", err)
+		return nil, err
+	}
+	if !row.DeletedAt.Valid {
+		return nil, nil
+	}
+	return &row.DeletedAt.Time, nil
+}
+
+// ParentDeleted 判断实体的上级是否在回收站中
+func (r *trashRepository) ParentDeleted(ctx context.Context, target TrashTarget, id uint) (bool, error) {
+	var parentTarget TrashTarget
+	var column string
+	switch target {
+	case TrashTargetProduct:
+		parentTarget, column = TrashTargetProvider, "cloud_provider_id"
+	case TrashTargetConfigItem:
+		parentTarget, column = TrashTargetProduct, "product_id"
+	default:
+		return false, nil
+	}
+
+	var parentIDs []uint
+	err := r.DB.WithContext(ctx).Unscoped().Model(trashModel(target)).
+		Where("id = ?", id).
+		Pluck(column, &parentIDs).Error
+	if err != nil {
+		logger.Error("Failed to get parent ID", err)
+		return false, err
+	}
+	if len(parentIDs) == 0 {
+		return false, nil
+	}
+
+	deletedAt, err := getDeletedAt(r.DB.WithContext(ctx), parentTarget, parentIDs[0])
+	if err != nil {
+		return false, err
+	}
+	return deletedAt != nil, nil
+}
+
+// Restore 恢复实体及其在同一次删除中被级联删除的下级记录
+func (r *trashRepository) Restore(ctx context.Context, target TrashTarget, id uint) (*CascadeRecords, error) {
+	var records *CascadeRecords
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		deletedAt, err := getDeletedAt(tx, target, id)
+		if err != nil {
+			return err
+		}
+		if deletedAt == nil {
+			return gorm.ErrRecordNotFound
+		}
+
+		records, err = findCascade(tx.Unscoped().Where("deleted_at = ?", *deletedAt).Session(&gorm.Session{}), target, id)
+		if err != nil {
+			return err
+		}
+		for _, scope := range trashCascade(target) {
+			err := tx.Unscoped().Model(scope.Model).
+				Where(scope.Where, id).
+				Where("deleted_at = ?", *deletedAt).
+				UpdateColumn("deleted_at", nil).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Error("Failed to restore from trash", err)
+		}
+		return nil, err
+	}
+	records.clearDeletedAt()
+	return records, nil
+}
+
+// clearDeletedAt 清除恢复前读取的删除时间
+func (c *CascadeRecords) clearDeletedAt() {
+	for i := range c.Providers {
+		c.Providers[i].DeletedAt = gorm.DeletedAt{}
+	}
+	for i := range c.Products {
+		c.Products[i].DeletedAt = gorm.DeletedAt{}
+	}
+	for i := range c.ConfigItems {
+		c.ConfigItems[i].DeletedAt = gorm.DeletedAt{}
+	}
+}
+
+// Purge 彻底删除实体，下级记录和标签关联由外键级联删除
+func (r *trashRepository) Purge(ctx context.Context, target TrashTarget, id uint) error {
+	err := r.DB.WithContext(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL").
+		Delete(trashModel(target), id).Error
+	if err != nil {
+		logger.Error("Failed to purge from trash", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/yourusername/cloud-eye/internal/models"
+	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// DeliveryFilter 投递记录查询条件
+type DeliveryFilter struct {
+	SubscriptionID uint   `json:"subscription_id"`
+	Status         string `json:"status,omitempty"`
+	Event          string `json:"event,omitempty"`
+	Page           int    `json:"page"`
+	PageSize       int    `json:"page_size"`
+}
+
+// WebhookRepository Webhook订阅和投递记录仓库接口
+type WebhookRepository interface {
+	Repository
+	GetAll(ctx context.Context) ([]models.WebhookSubscription, error)
+	GetByID(ctx context.Context, id uint) (*models.WebhookSubscription, error)
+	// GetActive 获取所有启用的订阅
+	GetActive(ctx context.Context) ([]models.WebhookSubscription, error)
+	Create(ctx context.Context, subscription *models.WebhookSubscription) error
+	Update(ctx context.Context, subscription *models.WebhookSubscription) error
+	// Delete 删除订阅及其投递记录
+	Delete(ctx context.Context, id uint) error
+	// CreateDeliveries 批量创建投递记录
+	CreateDeliveries(ctx context.Context, deliveries []models.WebhookDelivery) error
+	GetDelivery(ctx context.Context, id uint) (*models.WebhookDelivery, error)
+	// ListDeliveries 按创建时间倒序分页查询订阅的投递记录，不返回请求体
+	ListDeliveries(ctx context.Context, filter DeliveryFilter) (*PageResult, error)
+	// DueDeliveries 获取下次投递时间不晚于now的待投递记录，按下次投递时间升序
+	DueDeliveries(ctx context.Context, now time.Time, limit int) ([]models.WebhookDelivery, error)
+	// ClaimDelivery 领取待投递记录：投递次数为attempts时加1，记录投递时间，并将下次投递时间推迟lease作为租约，
+	// 租约内其他worker不会再取出该记录；记录已被领取或不再待投递时返回false
+	ClaimDelivery(ctx context.Context, id uint, attempts int, lease time.Duration) (bool, error)
+	// UpdateDelivery 保存投递结果，仅当投递次数仍为delivery.Attempts时保存；
+	// 租约过期后记录被其他worker重新领取时返回false，不覆盖新的投递
+	UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery) (bool, error)
+}
+
+// webhookRepository Webhook仓库实现
+type webhookRepository struct {
+	BaseRepository
+}
+
+// NewWebhookRepository 创建Webhook仓库
+func NewWebhookRepository(db *gorm.DB) WebhookRepository {
+	return &webhookRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// GetAll 获取所有订阅
+func (r *webhookRepository) GetAll(ctx context.Context) ([]models.WebhookSubscription, error) {
+	var subscriptions []models.WebhookSubscription
+	err := r.DB.WithContext(ctx).Order("id").Find(&subscriptions).Error
+	if err != nil {
+		logger.Error("Failed to get all webhook subscriptions", err)
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+// GetByID 根据ID获取订阅
+func (r *webhookRepository) GetByID(ctx context.Context, id uint) (*models.WebhookSubscription, error) {
+	var subscription models.WebhookSubscription
+	err := r.DB.WithContext(ctx).First(&subscription, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.Error("Failed to get webhook subscription by ID", err)
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+// GetActive 获取所有启用的订阅
+func (r *webhookRepository) GetActive(ctx context.Context) ([]models.WebhookSubscription, error) {
+	var subscriptions []models.WebhookSubscription
+	err := r.DB.WithContext(ctx).Where("active = ?", true).Order("id").Find(&subscriptions).Error
+	if err != nil {
+		logger.Error("Failed to get active webhook subscriptions", err)
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+// Create 创建订阅
+func (r *webhookRepository) Create(ctx context.Context, subscription *models.WebhookSubscription) error {
+	// active的数据库默认值为true，显式写入以支持创建停用的订阅
+	err := r.DB.WithContext(ctx).Select("*").Omit("id").Create(subscription).Error
+	if err != nil {
+		logger.Error("Failed to create webhook subscription", err)
+		return err
+	}
+	return nil
+}
+
+// Update 更新订阅
+func (r *webhookRepository) Update(ctx context.Context, subscription *models.WebhookSubscription) error {
+	err := r.DB.WithContext(ctx).Save(subscription).Error
+	if err != nil {
+		logger.Error("Failed to update webhook subscription", err)
+		return err
+	}
+	return nil
+}
+
+// Delete 删除订阅及其投递记录
+func (r *webhookRepository) Delete(ctx context.Context, id uint) error {
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("subscription_id = ?", id).Delete(&models.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.WebhookSubscription{}, id).Error
+	})
+	if err != nil {
+		logger.Error("Failed to delete webhook subscription", err)
+		return err
+	}
+	return nil
+}
+
+// CreateDeliveries 批量创建投递记录
+func (r *webhookRepository) CreateDeliveries(ctx context.Context, deliveries []models.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	err := r.DB.WithContext(ctx).Create(&deliveries).Error
+	if err != nil {
+		logger.Error("Failed to create webhook deliveries", err)
+		return err
+	}
+	return nil
+}
+
+// GetDelivery 根据ID获取投递记录
+func (r *webhookRepository) GetDelivery(ctx context.Context, id uint) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	err := r.DB.WithContext(ctx).First(&delivery, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.Error("Failed to get webhook delivery by ID", err)
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// ListDeliveries 分页查询投递记录
+func (r *webhookRepository) ListDeliveries(ctx context.Context, filter DeliveryFilter) (*PageResult, error) {
+	query := r.DB.WithContext(ctx).Model(&models.WebhookDelivery{}).
+		Where("subscription_id = ?", filter.SubscriptionID)
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Event != "" {
+		query = query.Where("event = ?", filter.Event)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		logger.Error("Failed to count webhook deliveries", err)
+		return nil, err
+	}
+
+	var deliveries []models.WebhookDelivery
+	err := query.Omit("payload").
+		Order("id DESC").
+		Scopes(Paginate(filter.Page, filter.PageSize)).
+		Find(&deliveries).Error
+	if err != nil {
+		logger.Error("Failed to list webhook deliveries", err)
+		return nil, err
+	}
+
+	return &PageResult{
+		Total:    total,
+		Page:     filter.Page,
+		PageSize: filter.PageSize,
+		Data:     deliveries,
+	}, nil
+}
+
+// DueDeliveries 获取到期的待投递记录
+func (r *webhookRepository) DueDeliveries(ctx context.Context, now time.Time, limit int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := r.DB.WithContext(ctx).
+		Where("status = ? AND next_attempt_at <= ?", models.DeliveryStatusPending, now).
+		Order("next_attempt_at, id").
+		Limit(limit).
+		Find(&deliveries).Error
+	if err != nil {
+		logger.Error("Failed to get due webhook deliveries", err)
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// ClaimDelivery 领取待投递记录
+func (r *webhookRepository) ClaimDelivery(ctx context.Context, id uint, attempts int, lease time.Duration) (bool, error) {
+	now := time.Now()
+	result := r.DB.WithContext(ctx).Model(&models.WebhookDelivery{}).
+		Where("id = ? AND status = ? AND attempts = ?", id, models.DeliveryStatusPending, attempts).
+		Updates(map[string]interface{}{
+			"attempts":        attempts + 1,
+			"last_attempt_at": now,
+			"next_attempt_at": now.Add(lease),
+		})
+	if result.Error != nil {
+		logger.Error("Failed to claim webhook delivery", result.Error)
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// UpdateDelivery 保存投递结果
+func (r *webhookRepository) UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery) (bool, error) {
+	result := r.DB.WithContext(ctx).Model(delivery).
+		Where("attempts = ?", delivery.Attempts).
+		Select("status", "response_status", "response_body", "error", "duration_ms", "next_attempt_at").
+		Updates(delivery)
+	if result.Error != nil {
+		logger.Error("Failed to update webhook delivery", result.Error)
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
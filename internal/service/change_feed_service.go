@@ -0,0 +1,358 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/yourusername/cloud-eye/internal/models"
+	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+	"github.com/yourusername/cloud-eye/internal/repository"
+	"go.uber.org/zap"
+)
+
+// 变更推送默认选项
+const (
+	DefaultChangeFeedHeartbeat = 15 * time.Second
+	DefaultChangeFeedRetention = 7 * 24 * time.Hour
+)
+
+// changeFeedBuffer 每个订阅缓存的待推送事件数，缓存满时断开订阅，客户端重连后从断点续传
+const changeFeedBuffer = 256
+
+// changeFeedPruneInterval 清理过期事件的间隔
+const changeFeedPruneInterval = time.Hour
+
+// ProviderEventData 云服务商事件的数据，标签为名称列表
+type ProviderEventData struct {
+	ID          uint      `json:"id"`
+	Name        string    `json:"name"`
+	Code        string    `json:"code"`
+	Description string    `json:"description"`
+	Tags        []string  `json:"tags"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// NewProviderEventData 由云服务商生成事件数据
+func NewProviderEventData(provider *models.CloudProvider) *ProviderEventData {
+	return &ProviderEventData{
+		ID:          provider.ID,
+		Name:        provider.Name,
+		Code:        provider.Code,
+		Description: provider.Description,
+		Tags:        models.TagNames(provider.Tags),
+		CreatedAt:   provider.CreatedAt,
+		UpdatedAt:   provider.UpdatedAt,
+	}
+}
+
+// ProductEventData 云产品事件的数据，标签为名称列表
+type ProductEventData struct {
+	ID              uint      `json:"id"`
+	CloudProviderID uint      `json:"cloud_provider_id"`
+	Name            string    `json:"name"`
+	Code            string    `json:"code"`
+	Description     string    `json:"description"`
+	CategoryID      *uint     `json:"category_id"`
+	Tags            []string  `json:"tags"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// NewProductEventData 由云产品生成事件数据
+func NewProductEventData(product *models.CloudProduct) *ProductEventData {
+	return &ProductEventData{
+		ID:              product.ID,
+		CloudProviderID: product.CloudProviderID,
+		Name:            product.Name,
+		Code:            product.Code,
+		Description:     product.Description,
+		CategoryID:      product.CategoryID,
+		Tags:            models.TagNames(product.Tags),
+		CreatedAt:       product.CreatedAt,
+		UpdatedAt:       product.UpdatedAt,
+	}
+}
+
+// publishCascadeDeleted 为级联删除的每条记录发布删除事件，先下级后上级
+func publishCascadeDeleted(ctx context.Context, events EventPublisher, records *repository.CascadeRecords) {
+	for i := range records.ConfigItems {
+		events.Publish(ctx, models.EventConfigItemDeleted, NewConfigItemEventData(&records.ConfigItems[i]))
+	}
+	for i := range records.Products {
+		events.Publish(ctx, models.EventProductDeleted, NewProductEventData(&records.Products[i]))
+	}
+	for i := range records.Providers {
+		events.Publish(ctx, models.EventProviderDeleted, NewProviderEventData(&records.Providers[i]))
+	}
+}
+
+// publishCascadeRestored 为从回收站恢复的每条记录发布创建事件，先上级后下级
+func publishCascadeRestored(ctx context.Context, events EventPublisher, records *repository.CascadeRecords) {
+	for i := range records.Providers {
+		events.Publish(ctx, models.EventProviderCreated, NewProviderEventData(&records.Providers[i]))
+	}
+	for i := range records.Products {
+		events.Publish(ctx, models.EventProductCreated, NewProductEventData(&records.Products[i]))
+	}
+	for i := range records.ConfigItems {
+		events.Publish(ctx, models.EventConfigItemCreated, NewConfigItemEventData(&records.ConfigItems[i]))
+	}
+}
+
+// ChangeFeedOptions 变更推送选项，零值使用默认值
+type ChangeFeedOptions struct {
+	Heartbeat time.Duration // 心跳间隔
+	Retention time.Duration // 事件保留时长，超过后无法续传
+}
+
+// withDefaults 填充未设置的选项
+func (o ChangeFeedOptions) withDefaults() ChangeFeedOptions {
+	if o.Heartbeat <= 0 {
+		o.Heartbeat = DefaultChangeFeedHeartbeat
+	}
+	if o.Retention <= 0 {
+		o.Retention = DefaultChangeFeedRetention
+	}
+	return o
+}
+
+// ChangeSubscription 变更事件订阅
+type ChangeSubscription struct {
+	filter repository.ChangeEventFilter
+	events chan models.ChangeEvent
+	done   chan struct{}
+	once   sync.Once
+	feed   *changeFeedService
+}
+
+// Events 订阅后发布的事件
+func (s *ChangeSubscription) Events() <-chan models.ChangeEvent {
+	return s.events
+}
+
+// Done 订阅因服务关闭或推送积压被断开时关闭
+func (s *ChangeSubscription) Done() <-chan struct{} {
+	return s.done
+}
+
+// Close 取消订阅
+func (s *ChangeSubscription) Close() {
+	s.feed.unsubscribe(s)
+}
+
+// ChangeFeedService 变更推送服务接口：持久化数据变更事件并推送给订阅者。
+// 实时推送只包含本实例发布的事件，部署多个实例时其他实例写入的事件在客户端重连续传时才能收到
+type ChangeFeedService interface {
+	Service
+	EventPublisher
+	// Subscribe 订阅满足条件的新事件，使用完毕后需调用Close
+	Subscribe(filter repository.ChangeEventFilter) *ChangeSubscription
+	// ResumePoint 根据客户端最后收到的事件序号确定回放起点；lastEventID为0时从最新事件之后开始，
+	// 续传点之后的事件已被清理或序号无效时expired为true，客户端需重新加载全部数据
+	ResumePoint(ctx context.Context, lastEventID uint) (from uint, expired bool, err error)
+	// EventsAfter 按序号升序获取afterID之后满足条件的事件
+	EventsAfter(ctx context.Context, afterID uint, filter repository.ChangeEventFilter, limit int) ([]models.ChangeEvent, error)
+	// Heartbeat 心跳间隔
+	Heartbeat() time.Duration
+	// Run 定期清理过期事件，直到ctx取消
+	Run(ctx context.Context)
+	// Close 断开所有订阅，用于服务关闭时结束长连接
+	Close()
+}
+
+// changeFeedService 变更推送服务实现
+type changeFeedService struct {
+	BaseService
+	repo      repository.ChangeEventRepository
+	opts      ChangeFeedOptions
+	publishMu sync.Mutex // 串行化事件的写入和推送，保证订阅者按序号顺序收到事件
+	mu        sync.Mutex // 保护subs和closing，不在持有期间访问数据库
+	subs      map[*ChangeSubscription]bool
+	closing   bool
+}
+
+// NewChangeFeedService 创建变更推送服务
+func NewChangeFeedService(repo repository.ChangeEventRepository, opts ChangeFeedOptions) ChangeFeedService {
+	return &changeFeedService{
+		repo: repo,
+		opts: opts.withDefaults(),
+		subs: make(map[*ChangeSubscription]bool),
+	}
+}
+
+// Publish 持久化事件并推送给满足条件的订阅者
+func (s *changeFeedService) Publish(ctx context.Context, event string, data interface{}) {
+	ctx = WithContext(ctx)
+
+	change, ok := newChangeEvent(event, data)
+	if !ok {
+		return
+	}
+	payload, err := json.Marshal(data)
+	if err != nil {
+		logger.Error("Failed to marshal change event", err, zap.String("event", event))
+		return
+	}
+	change.Data = payload
+
+	// 写入事件时只持有publishMu，订阅和取消订阅不必等待数据库
+	s.publishMu.Lock()
+	defer s.publishMu.Unlock()
+
+	if err := s.repo.Create(ctx, change); err != nil {
+		logger.Error("Failed to save change event", err, zap.String("event", event))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for sub := range s.subs {
+		if !sub.filter.Match(change) {
+			continue
+		}
+		select {
+		case sub.events <- *change:
+		default:
+			// 订阅者处理过慢，断开后由客户端从断点续传
+			logger.Info("Change feed subscriber lagged, disconnecting", zap.Uint("eventId", change.ID))
+			s.drop(sub)
+		}
+	}
+}
+
+// newChangeEvent 根据事件数据确定实体及其所属的云服务商和云产品，不支持的事件返回false
+func newChangeEvent(event string, data interface{}) (*models.ChangeEvent, bool) {
+	change := &models.ChangeEvent{Event: event, CreatedAt: time.Now()}
+	switch d := data.(type) {
+	case *ProviderEventData:
+		change.EntityType = models.EntityProvider
+		change.EntityID = d.ID
+		change.CloudProviderID = d.ID
+	case *ProductEventData:
+		change.EntityType = models.EntityProduct
+		change.EntityID = d.ID
+		change.CloudProviderID = d.CloudProviderID
+		change.ProductID = d.ID
+	case *ConfigItemEventData:
+		change.EntityType = models.EntityConfigItem
+		change.EntityID = d.ID
+		change.CloudProviderID = d.CloudProviderID
+		change.ProductID = d.ProductID
+	case ImportCompletedEvent:
+		change.EntityType = models.EntityConfigItem
+	default:
+		return nil, false
+	}
+	return change, true
+}
+
+// Subscribe 订阅新事件
+func (s *changeFeedService) Subscribe(filter repository.ChangeEventFilter) *ChangeSubscription {
+	sub := &ChangeSubscription{
+		filter: filter,
+		events: make(chan models.ChangeEvent, changeFeedBuffer),
+		done:   make(chan struct{}),
+		feed:   s,
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closing {
+		close(sub.done)
+		return sub
+	}
+	s.subs[sub] = true
+	return sub
+}
+
+// unsubscribe 取消订阅
+func (s *changeFeedService) unsubscribe(sub *ChangeSubscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.drop(sub)
+}
+
+// drop 移除订阅并通知订阅者，调用方需持有锁
+func (s *changeFeedService) drop(sub *ChangeSubscription) {
+	delete(s.subs, sub)
+	sub.once.Do(func() { close(sub.done) })
+}
+
+// ResumePoint 确定回放起点
+func (s *changeFeedService) ResumePoint(ctx context.Context, lastEventID uint) (uint, bool, error) {
+	ctx = WithContext(ctx)
+
+	first, last, err := s.repo.Bounds(ctx)
+	if err != nil {
+		logger.Error("Failed to get change event bounds", err)
+		return 0, false, NewServiceError(ErrCodeDatabase, "获取变更事件失败", err)
+	}
+
+	if lastEventID == 0 {
+		return last, false, nil
+	}
+	// 序号大于最新事件说明数据已重置，早于保留的最早事件说明中间的事件已被清理
+	if last == 0 || lastEventID > last || first > lastEventID+1 {
+		return last, true, nil
+	}
+	return lastEventID, false, nil
+}
+
+// EventsAfter 获取afterID之后的事件
+func (s *changeFeedService) EventsAfter(ctx context.Context, afterID uint, filter repository.ChangeEventFilter, limit int) ([]models.ChangeEvent, error) {
+	ctx = WithContext(ctx)
+
+	events, err := s.repo.ListAfter(ctx, afterID, filter, limit)
+	if err != nil {
+		logger.Error("Failed to list change events", err, zap.Uint("afterId", afterID))
+		return nil, NewServiceError(ErrCodeDatabase, "获取变更事件失败", err)
+	}
+	return events, nil
+}
+
+// Heartbeat 心跳间隔
+func (s *changeFeedService) Heartbeat() time.Duration {
+	return s.opts.Heartbeat
+}
+
+// Run 定期清理超过保留时长的事件
+func (s *changeFeedService) Run(ctx context.Context) {
+	ticker := time.NewTicker(changeFeedPruneInterval)
+	defer ticker.Stop()
+
+	for {
+		s.prune(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// prune 清理过期事件
+func (s *changeFeedService) prune(ctx context.Context) {
+	deleted, err := s.repo.DeleteBefore(ctx, time.Now().Add(-s.opts.Retention))
+	if err != nil {
+		logger.Error("Failed to prune change events", err)
+		return
+	}
+	if deleted > 0 {
+		logger.Info("Pruned change events", zap.Int64("count", deleted))
+	}
+}
+
+// Close 断开所有订阅，之后的订阅立即结束
+func (s *changeFeedService) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closing = true
+	for sub := range s.subs {
+		s.drop(sub)
+	}
+}
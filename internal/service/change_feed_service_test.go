@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yourusername/cloud-eye/internal/models"
+	"github.com/yourusername/cloud-eye/internal/repository"
+)
+
+// blockingChangeEventRepository 写入事件时阻塞，直到release被关闭
+type blockingChangeEventRepository struct {
+	repository.ChangeEventRepository
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (r *blockingChangeEventRepository) Create(ctx context.Context, event *models.ChangeEvent) error {
+	r.entered <- struct{}{}
+	<-r.release
+	return r.ChangeEventRepository.Create(ctx, event)
+}
+
+func newTestChangeFeed() *changeFeedService {
+	repo := repository.NewMemoryChangeEventRepository(repository.NewMemoryStore())
+	return NewChangeFeedService(repo, ChangeFeedOptions{}).(*changeFeedService)
+}
+
+func publishProviders(s *changeFeedService, n int) {
+	for i := 1; i <= n; i++ {
+		s.Publish(context.Background(), models.EventProviderUpdated, &ProviderEventData{ID: uint(i)})
+	}
+}
+
+func TestChangeFeedDisconnectsLaggingSubscriber(t *testing.T) {
+	s := newTestChangeFeed()
+	slow := s.Subscribe(repository.ChangeEventFilter{})
+	other := s.Subscribe(repository.ChangeEventFilter{CloudProviderID: 1})
+	defer other.Close()
+
+	// 缓存满后的下一个事件断开订阅，已缓存的事件仍可读取
+	publishProviders(s, changeFeedBuffer+1)
+	select {
+	case <-slow.Done():
+	default:
+		t.Fatal("积压超过缓存的订阅未断开")
+	}
+	if len(slow.Events()) != changeFeedBuffer {
+		t.Fatalf("断开时缓存了%d个事件，期望%d", len(slow.Events()), changeFeedBuffer)
+	}
+	for i := 1; i <= changeFeedBuffer; i++ {
+		if event := <-slow.Events(); event.ID != uint(i) {
+			t.Fatalf("第%d个事件的序号为%d", i, event.ID)
+		}
+	}
+
+	// 只订阅云服务商1的事件没有积压，不受影响
+	select {
+	case <-other.Done():
+		t.Fatal("没有积压的订阅被断开")
+	default:
+	}
+	if event := <-other.Events(); event.EntityID != 1 || len(other.Events()) != 0 {
+		t.Fatalf("按条件订阅收到%+v，剩余%d个事件", event, len(other.Events()))
+	}
+
+	// 断开的订阅不再接收事件，重复关闭不会出错
+	publishProviders(s, 1)
+	if len(slow.Events()) != 0 {
+		t.Fatal("断开的订阅仍收到事件")
+	}
+	slow.Close()
+	slow.Close()
+}
+
+func TestChangeFeedSubscribeDoesNotWaitForInsert(t *testing.T) {
+	s := newTestChangeFeed()
+	blocking := &blockingChangeEventRepository{
+		ChangeEventRepository: s.repo,
+		entered:               make(chan struct{}),
+		release:               make(chan struct{}),
+	}
+	s.repo = blocking
+	sub := s.Subscribe(repository.ChangeEventFilter{})
+	defer sub.Close()
+
+	published := make(chan struct{})
+	go func() {
+		publishProviders(s, 1)
+		close(published)
+	}()
+	<-blocking.entered
+
+	// 写入事件期间订阅、取消订阅和关闭服务都不阻塞
+	done := make(chan struct{})
+	go func() {
+		s.Subscribe(repository.ChangeEventFilter{}).Close()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("订阅等待了事件写入")
+	}
+
+	close(blocking.release)
+	<-published
+	if event := <-sub.Events(); event.ID != 1 {
+		t.Fatalf("写入完成后推送的事件为%+v", event)
+	}
+}
+
+func TestChangeFeedResumePoint(t *testing.T) {
+	s := newTestChangeFeed()
+	ctx := context.Background()
+
+	if from, expired, err := s.ResumePoint(ctx, 0); err != nil || from != 0 || expired {
+		t.Fatalf("没有事件时不带续传点返回%d, %v, %v", from, expired, err)
+	}
+	if from, expired, _ := s.ResumePoint(ctx, 3); from != 0 || !expired {
+		t.Fatalf("没有事件时续传点3返回%d, %v，期望已过期", from, expired)
+	}
+
+	// 清理事件1-3后再发布事件4-5
+	publishProviders(s, 3)
+	s.opts.Retention = time.Nanosecond
+	time.Sleep(time.Millisecond)
+	s.prune(ctx)
+	publishProviders(s, 2)
+
+	tests := []struct {
+		name        string
+		lastEventID uint
+		from        uint
+		expired     bool
+	}{
+		{"不带续传点从最新事件之后开始", 0, 5, false},
+		{"续传点之后的事件已被清理", 2, 5, true},
+		{"续传点紧邻保留的最早事件", 3, 3, false},
+		{"续传点在保留范围内", 4, 4, false},
+		{"续传点为最新事件", 5, 5, false},
+		{"续传点大于最新事件", 6, 5, true},
+		{"续传点远大于最新事件", 1 << 31, 5, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, expired, err := s.ResumePoint(ctx, tt.lastEventID)
+			if err != nil || from != tt.from || expired != tt.expired {
+				t.Fatalf("ResumePoint(%d)返回%d, %v, %v，期望%d, %v", tt.lastEventID, from, expired, err, tt.from, tt.expired)
+			}
+		})
+	}
+
+	events, err := s.EventsAfter(ctx, 3, repository.ChangeEventFilter{}, 10)
+	if err != nil || len(events) != 2 || events[0].ID != 4 {
+		t.Fatalf("续传点3之后的事件为%+v, %v", events, err)
+	}
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 
 	"github.com/yourusername/cloud-eye/internal/models"
 	"github.com/yourusername/cloud-eye/internal/pkg/logger"
@@ -13,11 +14,15 @@ import (
 type CloudProductService interface {
 	Service
 	GetAllProducts(ctx context.Context) ([]models.CloudProduct, error)
+	ListProducts(ctx context.Context, filter repository.CloudProductFilter) ([]models.CloudProduct, error)
+	ListProductsPage(ctx context.Context, filter repository.CloudProductFilter) (*repository.PageResult, error)
 	GetProductByID(ctx context.Context, id uint) (*models.CloudProduct, error)
 	GetProductsByProviderID(ctx context.Context, providerID uint) ([]models.CloudProduct, error)
 	GetProductsByProviderCode(ctx context.Context, providerCode string) ([]models.CloudProduct, error)
 	CreateProduct(ctx context.Context, product *models.CloudProduct) error
 	UpdateProduct(ctx context.Context, product *models.CloudProduct) error
+	// PatchProduct 按JSON合并补丁部分更新云产品，只更新补丁中出现的字段
+	PatchProduct(ctx context.Context, id uint, patch []byte) (*models.CloudProduct, error)
 	DeleteProduct(ctx context.Context, id uint) error
 }
 
@@ -26,16 +31,25 @@ type cloudProductService struct {
 	BaseService
 	repo            repository.CloudProductRepository
 	providerRepo    repository.CloudProviderRepository
+	categoryRepo    repository.ProductCategoryRepository
+	events          EventPublisher
 }
 
-// NewCloudProductService 创建云产品服务
+// NewCloudProductService 创建云产品服务，云产品变更后通过events发布事件，events为nil时不发布
 func NewCloudProductService(
 	repo repository.CloudProductRepository,
 	providerRepo repository.CloudProviderRepository,
+	categoryRepo repository.ProductCategoryRepository,
+	events EventPublisher,
 ) CloudProductService {
+	if events == nil {
+		events = noopPublisher{}
+	}
 	return &cloudProductService{
 		repo:         repo,
 		providerRepo: providerRepo,
+		categoryRepo: categoryRepo,
+		events:       events,
 	}
 }
 
@@ -53,6 +67,45 @@ func (s *cloudProductService) GetAllProducts(ctx context.Context) ([]models.Clou
 	return products, nil
 }
 
+// ListProducts 根据过滤条件获取云产品列表，支持排序、字段选择和关联加载
+func (s *cloudProductService) ListProducts(ctx context.Context, filter repository.CloudProductFilter) ([]models.CloudProduct, error) {
+	ctx = WithContext(ctx)
+	logger.Info("Listing cloud products", zap.Any("filter", filter))
+
+	if err := filter.Validate(repository.CloudProductListSchema); err != nil {
+		return nil, NewServiceError(ErrCodeInvalidData, err.Error(), nil)
+	}
+
+	products, err := s.repo.List(ctx, filter)
+	if err != nil {
+		logger.Error("Failed to list cloud products", err)
+		return nil, NewServiceError(ErrCodeDatabase, "获取云产品列表失败", err)
+	}
+
+	return products, nil
+}
+
+// ListProductsPage 根据过滤条件分页获取云产品列表
+func (s *cloudProductService) ListProductsPage(ctx context.Context, filter repository.CloudProductFilter) (*repository.PageResult, error) {
+	ctx = WithContext(ctx)
+	logger.Info("Listing cloud products by page", zap.Any("filter", filter))
+
+	if err := filter.Validate(repository.CloudProductListSchema); err != nil {
+		return nil, NewServiceError(ErrCodeInvalidData, err.Error(), nil)
+	}
+
+	result, err := s.repo.ListPage(ctx, filter)
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidCursor) {
+			return nil, NewServiceError(ErrCodeInvalidData, err.Error(), nil)
+		}
+		logger.Error("Failed to list cloud products by page", err)
+		return nil, NewServiceError(ErrCodeDatabase, "获取云产品列表失败", err)
+	}
+
+	return result, nil
+}
+
 // GetProductByID 根据ID获取云产品
 func (s *cloudProductService) GetProductByID(ctx context.Context, id uint) (*models.CloudProduct, error) {
 	ctx = WithContext(ctx)
@@ -140,6 +193,10 @@ func (s *cloudProductService) CreateProduct(ctx context.Context, product *models
 		return NewServiceError(ErrCodeNotFound, "云服务商不存在", nil)
 	}
 
+	if err := s.checkCategory(ctx, product.CategoryID, "创建云产品失败"); err != nil {
+		return err
+	}
+
 	// 检查代码是否已存在于该服务商下
 	existingProduct, err := s.repo.GetByCode(ctx, product.CloudProviderID, product.Code)
 	if err != nil {
@@ -152,10 +209,15 @@ func (s *cloudProductService) CreateProduct(ctx context.Context, product *models
 	}
 
 	if err := s.repo.Create(ctx, product); err != nil {
+		// 回收站中的记录仍占用唯一代码
+		if repository.IsDuplicateKey(err) {
+			return NewServiceError(ErrCodeDuplicate, "该云服务商下的产品代码已存在（可能位于回收站中）", err)
+		}
 		logger.Error("Failed to create cloud product", err)
 		return NewServiceError(ErrCodeDatabase, "创建云产品失败", err)
 	}
 
+	s.events.Publish(ctx, models.EventProductCreated, NewProductEventData(product))
 	return nil
 }
 
@@ -175,6 +237,42 @@ func (s *cloudProductService) UpdateProduct(ctx context.Context, product *models
 		return NewServiceError(ErrCodeNotFound, "云产品不存在", nil)
 	}
 
+	// 创建时间不随整体更新改变
+	product.CreatedAt = existingProduct.CreatedAt
+
+	return s.saveProduct(ctx, product, existingProduct, nil)
+}
+
+// PatchProduct 按JSON合并补丁（RFC 7396）部分更新云产品
+func (s *cloudProductService) PatchProduct(ctx context.Context, id uint, patch []byte) (*models.CloudProduct, error) {
+	ctx = WithContext(ctx)
+	logger.Info("Patching cloud product", zap.Uint("id", id))
+
+	existingProduct, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		logger.Error("Failed to check product existence", err, zap.Uint("id", id))
+		return nil, NewServiceError(ErrCodeDatabase, "更新云产品失败", err)
+	}
+
+	if existingProduct == nil {
+		return nil, NewServiceError(ErrCodeNotFound, "云产品不存在", nil)
+	}
+
+	var product models.CloudProduct
+	columns, err := applyMergePatch(existingProduct, patch, &product,
+		"provider", "category", "config_items", "config_item_count")
+	if err != nil {
+		return nil, NewServiceError(ErrCodeInvalidData, err.Error(), nil)
+	}
+
+	if err := s.saveProduct(ctx, &product, existingProduct, columns); err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// saveProduct 校验并保存更新后的云产品，columns为空时保存全部字段，否则只更新指定列
+func (s *cloudProductService) saveProduct(ctx context.Context, product, existingProduct *models.CloudProduct, columns []string) error {
 	// 如果更改了服务商，检查服务商是否存在
 	if product.CloudProviderID != existingProduct.CloudProviderID {
 		provider, err := s.providerRepo.GetByID(ctx, product.CloudProviderID)
@@ -188,6 +286,10 @@ func (s *cloudProductService) UpdateProduct(ctx context.Context, product *models
 		}
 	}
 
+	if err := s.checkCategory(ctx, product.CategoryID, "更新云产品失败"); err != nil {
+		return err
+	}
+
 	// 如果更改了代码或服务商，检查新代码是否已存在
 	if product.Code != existingProduct.Code || product.CloudProviderID != existingProduct.CloudProviderID {
 		codeCheck, err := s.repo.GetByCode(ctx, product.CloudProviderID, product.Code)
@@ -201,11 +303,27 @@ func (s *cloudProductService) UpdateProduct(ctx context.Context, product *models
 		}
 	}
 
-	if err := s.repo.Update(ctx, product); err != nil {
+	var err error
+	if columns == nil {
+		err = s.repo.Update(ctx, product)
+	} else {
+		err = s.repo.Patch(ctx, product, columns)
+	}
+	if err != nil {
+		// 回收站中的记录仍占用唯一代码
+		if repository.IsDuplicateKey(err) {
+			return NewServiceError(ErrCodeDuplicate, "该云服务商下的产品代码已存在（可能位于回收站中）", err)
+		}
 		logger.Error("Failed to update cloud product", err)
 		return NewServiceError(ErrCodeDatabase, "更新云产品失败", err)
 	}
 
+	// 整体更新不修改标签，事件中使用原有标签
+	event := *product
+	if event.Tags == nil {
+		event.Tags = existingProduct.Tags
+	}
+	s.events.Publish(ctx, models.EventProductUpdated, NewProductEventData(&event))
 	return nil
 }
 
@@ -225,10 +343,32 @@ func (s *cloudProductService) DeleteProduct(ctx context.Context, id uint) error
 		return NewServiceError(ErrCodeNotFound, "云产品不存在", nil)
 	}
 
-	if err := s.repo.Delete(ctx, id); err != nil {
+	records, err := s.repo.Delete(ctx, id)
+	if err != nil {
 		logger.Error("Failed to delete cloud product", err)
 		return NewServiceError(ErrCodeDatabase, "删除云产品失败", err)
 	}
 
+	// 级联删除的配置项逐条发布删除事件
+	publishCascadeDeleted(ctx, s.events, records)
+	return nil
+}
+
+// checkCategory 检查产品指定的类别是否存在，未指定类别时不检查
+func (s *cloudProductService) checkCategory(ctx context.Context, categoryID *uint, failMsg string) error {
+	if categoryID == nil {
+		return nil
+	}
+
+	category, err := s.categoryRepo.GetByID(ctx, *categoryID)
+	if err != nil {
+		logger.Error("Failed to check category existence", err, zap.Uint("categoryId", *categoryID))
+		return NewServiceError(ErrCodeDatabase, failMsg, err)
+	}
+
+	if category == nil {
+		return NewServiceError(ErrCodeNotFound, "产品类别不存在", nil)
+	}
+
 	return nil
-}
\ No newline at end of file
+}
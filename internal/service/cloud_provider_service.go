@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 
 	"github.com/yourusername/cloud-eye/internal/models"
 	"github.com/yourusername/cloud-eye/internal/pkg/logger"
@@ -13,23 +14,33 @@ import (
 type CloudProviderService interface {
 	Service
 	GetAllProviders(ctx context.Context) ([]models.CloudProvider, error)
+	ListProviders(ctx context.Context, filter repository.CloudProviderFilter) ([]models.CloudProvider, error)
+	ListProvidersPage(ctx context.Context, filter repository.CloudProviderFilter) (*repository.PageResult, error)
 	GetProviderByID(ctx context.Context, id uint) (*models.CloudProvider, error)
 	GetProviderByCode(ctx context.Context, code string) (*models.CloudProvider, error)
 	CreateProvider(ctx context.Context, provider *models.CloudProvider) error
 	UpdateProvider(ctx context.Context, provider *models.CloudProvider) error
-	DeleteProvider(ctx context.Context, id uint) error
+	// PatchProvider 按JSON合并补丁部分更新云服务商，只更新补丁中出现的字段
+	PatchProvider(ctx context.Context, id uint, patch []byte) (*models.CloudProvider, error)
+	// DeleteProvider 将云服务商移入回收站，存在产品或配置项时需指定cascade一并删除
+	DeleteProvider(ctx context.Context, id uint, cascade bool) error
 }
 
 // cloudProviderService 云服务商服务实现
 type cloudProviderService struct {
 	BaseService
-	repo repository.CloudProviderRepository
+	repo   repository.CloudProviderRepository
+	events EventPublisher
 }
 
-// NewCloudProviderService 创建云服务商服务
-func NewCloudProviderService(repo repository.CloudProviderRepository) CloudProviderService {
+// NewCloudProviderService 创建云服务商服务，云服务商变更后通过events发布事件，events为nil时不发布
+func NewCloudProviderService(repo repository.CloudProviderRepository, events EventPublisher) CloudProviderService {
+	if events == nil {
+		events = noopPublisher{}
+	}
 	return &cloudProviderService{
-		repo: repo,
+		repo:   repo,
+		events: events,
 	}
 }
 
@@ -47,6 +58,45 @@ func (s *cloudProviderService) GetAllProviders(ctx context.Context) ([]models.Cl
 	return providers, nil
 }
 
+// ListProviders 根据过滤条件获取云服务商列表，支持排序、字段选择和关联加载
+func (s *cloudProviderService) ListProviders(ctx context.Context, filter repository.CloudProviderFilter) ([]models.CloudProvider, error) {
+	ctx = WithContext(ctx)
+	logger.Info("Listing cloud providers", zap.Any("filter", filter))
+
+	if err := filter.Validate(repository.CloudProviderListSchema); err != nil {
+		return nil, NewServiceError(ErrCodeInvalidData, err.Error(), nil)
+	}
+
+	providers, err := s.repo.List(ctx, filter)
+	if err != nil {
+		logger.Error("Failed to list cloud providers", err)
+		return nil, NewServiceError(ErrCodeDatabase, "获取云服务商列表失败", err)
+	}
+
+	return providers, nil
+}
+
+// ListProvidersPage 根据过滤条件分页获取云服务商列表
+func (s *cloudProviderService) ListProvidersPage(ctx context.Context, filter repository.CloudProviderFilter) (*repository.PageResult, error) {
+	ctx = WithContext(ctx)
+	logger.Info("Listing cloud providers by page", zap.Any("filter", filter))
+
+	if err := filter.Validate(repository.CloudProviderListSchema); err != nil {
+		return nil, NewServiceError(ErrCodeInvalidData, err.Error(), nil)
+	}
+
+	result, err := s.repo.ListPage(ctx, filter)
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidCursor) {
+			return nil, NewServiceError(ErrCodeInvalidData, err.Error(), nil)
+		}
+		logger.Error("Failed to list cloud providers by page", err)
+		return nil, NewServiceError(ErrCodeDatabase, "获取云服务商列表失败", err)
+	}
+
+	return result, nil
+}
+
 // GetProviderByID 根据ID获取云服务商
 func (s *cloudProviderService) GetProviderByID(ctx context.Context, id uint) (*models.CloudProvider, error) {
 	ctx = WithContext(ctx)
@@ -100,10 +150,15 @@ func (s *cloudProviderService) CreateProvider(ctx context.Context, provider *mod
 	}
 
 	if err := s.repo.Create(ctx, provider); err != nil {
+		// 回收站中的记录仍占用唯一代码
+		if repository.IsDuplicateKey(err) {
+			return NewServiceError(ErrCodeDuplicate, "云服务商代码已存在（可能位于回收站中）", err)
+		}
 		logger.Error("Failed to create cloud provider", err)
 		return NewServiceError(ErrCodeDatabase, "创建云服务商失败", err)
 	}
 
+	s.events.Publish(ctx, models.EventProviderCreated, NewProviderEventData(provider))
 	return nil
 }
 
@@ -123,6 +178,41 @@ func (s *cloudProviderService) UpdateProvider(ctx context.Context, provider *mod
 		return NewServiceError(ErrCodeNotFound, "云服务商不存在", nil)
 	}
 
+	// 创建时间不随整体更新改变
+	provider.CreatedAt = existingProvider.CreatedAt
+
+	return s.saveProvider(ctx, provider, existingProvider, nil)
+}
+
+// PatchProvider 按JSON合并补丁（RFC 7396）部分更新云服务商
+func (s *cloudProviderService) PatchProvider(ctx context.Context, id uint, patch []byte) (*models.CloudProvider, error) {
+	ctx = WithContext(ctx)
+	logger.Info("Patching cloud provider", zap.Uint("id", id))
+
+	existingProvider, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		logger.Error("Failed to check cloud provider existence", err, zap.Uint("id", id))
+		return nil, NewServiceError(ErrCodeDatabase, "更新云服务商失败", err)
+	}
+
+	if existingProvider == nil {
+		return nil, NewServiceError(ErrCodeNotFound, "云服务商不存在", nil)
+	}
+
+	var provider models.CloudProvider
+	columns, err := applyMergePatch(existingProvider, patch, &provider, "products", "product_count")
+	if err != nil {
+		return nil, NewServiceError(ErrCodeInvalidData, err.Error(), nil)
+	}
+
+	if err := s.saveProvider(ctx, &provider, existingProvider, columns); err != nil {
+		return nil, err
+	}
+	return &provider, nil
+}
+
+// saveProvider 校验并保存更新后的云服务商，columns为空时保存全部字段，否则只更新指定列
+func (s *cloudProviderService) saveProvider(ctx context.Context, provider, existingProvider *models.CloudProvider, columns []string) error {
 	// 如果更改了代码，检查新代码是否已存在
 	if provider.Code != existingProvider.Code {
 		codeCheck, err := s.repo.GetByCode(ctx, provider.Code)
@@ -136,18 +226,34 @@ func (s *cloudProviderService) UpdateProvider(ctx context.Context, provider *mod
 		}
 	}
 
-	if err := s.repo.Update(ctx, provider); err != nil {
+	var err error
+	if columns == nil {
+		err = s.repo.Update(ctx, provider)
+	} else {
+		err = s.repo.Patch(ctx, provider, columns)
+	}
+	if err != nil {
+		// 回收站中的记录仍占用唯一代码
+		if repository.IsDuplicateKey(err) {
+			return NewServiceError(ErrCodeDuplicate, "云服务商代码已存在（可能位于回收站中）", err)
+		}
 		logger.Error("Failed to update cloud provider", err)
 		return NewServiceError(ErrCodeDatabase, "更新云服务商失败", err)
 	}
 
+	// 整体更新不修改标签，事件中使用原有标签
+	event := *provider
+	if event.Tags == nil {
+		event.Tags = existingProvider.Tags
+	}
+	s.events.Publish(ctx, models.EventProviderUpdated, NewProviderEventData(&event))
 	return nil
 }
 
 // DeleteProvider 删除云服务商
-func (s *cloudProviderService) DeleteProvider(ctx context.Context, id uint) error {
+func (s *cloudProviderService) DeleteProvider(ctx context.Context, id uint, cascade bool) error {
 	ctx = WithContext(ctx)
-	logger.Info("Deleting cloud provider", zap.Uint("id", id))
+	logger.Info("Deleting cloud provider", zap.Uint("id", id), zap.Bool("cascade", cascade))
 
 	// 检查是否存在
 	existingProvider, err := s.repo.GetByID(ctx, id)
@@ -160,10 +266,24 @@ func (s *cloudProviderService) DeleteProvider(ctx context.Context, id uint) erro
 		return NewServiceError(ErrCodeNotFound, "云服务商不存在", nil)
 	}
 
-	if err := s.repo.Delete(ctx, id); err != nil {
+	if !cascade {
+		hasDependants, err := s.repo.HasDependants(ctx, id)
+		if err != nil {
+			logger.Error("Failed to check cloud provider dependants", err, zap.Uint("id", id))
+			return NewServiceError(ErrCodeDatabase, "删除云服务商失败", err)
+		}
+		if hasDependants {
+			return NewServiceError(ErrCodeConflict, "该云服务商下存在产品或配置项，请指定cascade=true一并删除", nil)
+		}
+	}
+
+	records, err := s.repo.Delete(ctx, id)
+	if err != nil {
 		logger.Error("Failed to delete cloud provider", err)
 		return NewServiceError(ErrCodeDatabase, "删除云服务商失败", err)
 	}
 
+	// 级联删除的产品和配置项逐条发布删除事件
+	publishCascadeDeleted(ctx, s.events, records)
 	return nil
 }
\ No newline at end of file
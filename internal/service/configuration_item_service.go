@@ -2,13 +2,81 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 
 	"github.com/yourusername/cloud-eye/internal/models"
+	"github.com/yourusername/cloud-eye/internal/pkg/evaluation"
 	"github.com/yourusername/cloud-eye/internal/pkg/logger"
 	"github.com/yourusername/cloud-eye/internal/repository"
 	"go.uber.org/zap"
 )
 
+// 批量操作限制和模式
+const (
+	MaxBulkOperations  = 500
+	BulkModeAtomic     = "atomic"      // 全部成功或全部回滚（默认）
+	BulkModeBestEffort = "best_effort" // 尽力执行，失败的操作不影响其他操作
+)
+
+// MaxEvaluationResources 单次评估的最大资源数
+const MaxEvaluationResources = 1000
+
+// 批量操作结果状态
+const (
+	BulkStatusSucceeded = "succeeded"
+	BulkStatusFailed    = "failed"
+	BulkStatusSkipped   = "skipped" // atomic模式下因其他操作失败而未执行或已回滚
+)
+
+// configItemPatchForbidden 不允许通过合并补丁修改的配置项字段，控制族成员关系仅通过控制族接口维护
+var configItemPatchForbidden = []string{"control_family_id", "provider", "product"}
+
+// bulkPatchForbidden 批量update操作额外不允许修改的字段，所属产品通过move修改
+var bulkPatchForbidden = append([]string{"cloud_provider_id", "product_id"}, configItemPatchForbidden...)
+
+// ConfigItemBulkOperation 批量操作中的单项操作
+type ConfigItemBulkOperation struct {
+	Op         string                    // create, update, delete, move, tag
+	ID         uint                      // update、delete、move、tag时的配置项ID
+	Item       *models.ConfigurationItem // create时的配置项，只使用客户端可以设置的字段
+	Patch      json.RawMessage           // update时的JSON合并补丁（RFC 7396）
+	ProductID  uint                      // move时的目标云产品ID
+	AddTags    []string                  // tag时添加的标签，create时为新配置项的标签
+	RemoveTags []string                  // tag时移除的标签
+}
+
+// ConfigItemValidator 按创建和更新接口的规则校验配置项，返回的错误作为该项操作的失败原因
+type ConfigItemValidator func(item *models.ConfigurationItem) error
+
+// ConfigItemBulkRequest 配置项批量操作请求
+type ConfigItemBulkRequest struct {
+	Mode       string // atomic（默认）或best_effort
+	Operations []ConfigItemBulkOperation
+	// Validate 校验create的配置项和update应用补丁后的结果，为nil时只做服务层的校验
+	Validate ConfigItemValidator
+}
+
+// ConfigItemBulkResult 单项操作的执行结果
+type ConfigItemBulkResult struct {
+	Index  int                       `json:"index"`
+	Op     string                    `json:"op"`
+	ID     uint                      `json:"id,omitempty"`
+	Status string                    `json:"status"`
+	Error  string                    `json:"error,omitempty"`
+	Item   *models.ConfigurationItem `json:"item,omitempty"` // create、update、move成功后的配置项
+}
+
+// ConfigItemBulkResponse 配置项批量操作结果
+type ConfigItemBulkResponse struct {
+	Mode      string                 `json:"mode"`
+	Committed bool                   `json:"committed"` // 是否有变更被提交
+	Succeeded int                    `json:"succeeded"`
+	Failed    int                    `json:"failed"`
+	Results   []ConfigItemBulkResult `json:"results"`
+}
+
 // ConfigurationItemService 配置项服务接口
 type ConfigurationItemService interface {
 	Service
@@ -17,8 +85,16 @@ type ConfigurationItemService interface {
 	GetConfigItemsByProviderAndProduct(ctx context.Context, providerID, productID uint) ([]models.ConfigurationItem, error)
 	CreateConfigItem(ctx context.Context, item *models.ConfigurationItem) error
 	UpdateConfigItem(ctx context.Context, item *models.ConfigurationItem) error
+	// PatchConfigItem 按JSON合并补丁部分更新配置项，只更新补丁中出现的字段
+	PatchConfigItem(ctx context.Context, id uint, patch []byte) (*models.ConfigurationItem, error)
 	DeleteConfigItem(ctx context.Context, id uint) error
 	BatchImportConfigItems(ctx context.Context, items []models.ConfigurationItem) error
+	// GetImportCatalog 获取导入模板中可选的云服务商和云产品
+	GetImportCatalog(ctx context.Context) ([]models.CloudProvider, []models.CloudProduct, error)
+	// BulkConfigItems 在一个事务中批量创建、更新、删除、移动配置项及修改标签
+	BulkConfigItems(ctx context.Context, req ConfigItemBulkRequest) (*ConfigItemBulkResponse, error)
+	// EvaluateResources 使用全部生效中的配置项评估资源配置
+	EvaluateResources(ctx context.Context, resources []evaluation.Resource) (*evaluation.Report, error)
 }
 
 // configurationItemService 配置项服务实现
@@ -27,18 +103,24 @@ type configurationItemService struct {
 	repo         repository.ConfigurationItemRepository
 	providerRepo repository.CloudProviderRepository
 	productRepo  repository.CloudProductRepository
+	events       EventPublisher
 }
 
-// NewConfigurationItemService 创建配置项服务
+// NewConfigurationItemService 创建配置项服务，配置项变更和导入完成后通过events发布事件，events为nil时不发布
 func NewConfigurationItemService(
 	repo repository.ConfigurationItemRepository,
 	providerRepo repository.CloudProviderRepository,
 	productRepo repository.CloudProductRepository,
+	events EventPublisher,
 ) ConfigurationItemService {
+	if events == nil {
+		events = noopPublisher{}
+	}
 	return &configurationItemService{
 		repo:         repo,
 		providerRepo: providerRepo,
 		productRepo:  productRepo,
+		events:       events,
 	}
 }
 
@@ -67,6 +149,19 @@ func (s *configurationItemService) GetConfigItemsByFilter(ctx context.Context, f
 		zap.Any("filter", filter))
 
 	// 参数检查和验证
+	if err := filter.Validate(repository.ConfigItemListSchema); err != nil {
+		return nil, NewServiceError(ErrCodeInvalidData, err.Error(), nil)
+	}
+
+	if filter.TagMatch != "" && filter.TagMatch != repository.TagMatchAny && filter.TagMatch != repository.TagMatchAll {
+		return nil, NewServiceError(ErrCodeInvalidData, "无效的标签匹配方式: "+filter.TagMatch, nil)
+	}
+	tags, msg := normalizeTagNames(filter.Tags)
+	if msg != "" {
+		return nil, NewServiceError(ErrCodeInvalidData, msg, nil)
+	}
+	filter.Tags = tags
+
 	if filter.CloudProviderID != nil {
 		// 检查服务商是否存在
 		provider, err := s.providerRepo.GetByID(ctx, *filter.CloudProviderID)
@@ -105,6 +200,9 @@ func (s *configurationItemService) GetConfigItemsByFilter(ctx context.Context, f
 
 	result, err := s.repo.GetByFilter(ctx, filter)
 	if err != nil {
+		if errors.Is(err, repository.ErrInvalidCursor) {
+			return nil, NewServiceError(ErrCodeInvalidData, err.Error(), nil)
+		}
 		logger.Error("Failed to get configuration items by filter", err)
 		return nil, NewServiceError(ErrCodeDatabase, "获取配置项列表失败", err)
 	}
@@ -157,6 +255,13 @@ func (s *configurationItemService) CreateConfigItem(ctx context.Context, item *m
 	ctx = WithContext(ctx)
 	logger.Info("Creating configuration item", zap.String("name", item.Name))
 
+	if msg := validateClassification(item); msg != "" {
+		return NewServiceError(ErrCodeInvalidData, msg, nil)
+	}
+
+	// 控制族成员关系仅通过控制族接口维护
+	item.ControlFamilyID = nil
+
 	// 检查服务商是否存在
 	provider, err := s.providerRepo.GetByID(ctx, item.CloudProviderID)
 	if err != nil {
@@ -189,6 +294,7 @@ func (s *configurationItemService) CreateConfigItem(ctx context.Context, item *m
 		return NewServiceError(ErrCodeDatabase, "创建配置项失败", err)
 	}
 
+	s.publishItem(ctx, models.EventConfigItemCreated, item)
 	return nil
 }
 
@@ -208,6 +314,48 @@ func (s *configurationItemService) UpdateConfigItem(ctx context.Context, item *m
 		return NewServiceError(ErrCodeNotFound, "配置项不存在", nil)
 	}
 
+	// 控制族成员关系仅通过控制族接口维护，更新时保持不变
+	item.ControlFamilyID = existingItem.ControlFamilyID
+
+	// 创建时间不随整体更新改变
+	item.CreatedAt = existingItem.CreatedAt
+
+	return s.saveConfigItem(ctx, item, existingItem, nil)
+}
+
+// PatchConfigItem 按JSON合并补丁（RFC 7396）部分更新配置项
+func (s *configurationItemService) PatchConfigItem(ctx context.Context, id uint, patch []byte) (*models.ConfigurationItem, error) {
+	ctx = WithContext(ctx)
+	logger.Info("Patching configuration item", zap.Uint("id", id))
+
+	existingItem, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		logger.Error("Failed to check configuration item existence", err, zap.Uint("id", id))
+		return nil, NewServiceError(ErrCodeDatabase, "更新配置项失败", err)
+	}
+
+	if existingItem == nil {
+		return nil, NewServiceError(ErrCodeNotFound, "配置项不存在", nil)
+	}
+
+	var item models.ConfigurationItem
+	columns, err := applyMergePatch(existingItem, patch, &item, configItemPatchForbidden...)
+	if err != nil {
+		return nil, NewServiceError(ErrCodeInvalidData, err.Error(), nil)
+	}
+
+	if err := s.saveConfigItem(ctx, &item, existingItem, columns); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// saveConfigItem 校验并保存更新后的配置项，columns为空时保存全部字段，否则只更新指定列
+func (s *configurationItemService) saveConfigItem(ctx context.Context, item, existingItem *models.ConfigurationItem, columns []string) error {
+	if msg := validateClassification(item); msg != "" {
+		return NewServiceError(ErrCodeInvalidData, msg, nil)
+	}
+
 	// 如果云服务商ID有变更，检查新的服务商是否存在
 	if item.CloudProviderID != existingItem.CloudProviderID {
 		provider, err := s.providerRepo.GetByID(ctx, item.CloudProviderID)
@@ -221,8 +369,8 @@ func (s *configurationItemService) UpdateConfigItem(ctx context.Context, item *m
 		}
 	}
 
-	// 如果产品ID有变更，检查新的产品是否存在
-	if item.ProductID != existingItem.ProductID {
+	// 如果产品ID或服务商ID有变更，检查产品是否存在且属于该服务商
+	if item.ProductID != existingItem.ProductID || item.CloudProviderID != existingItem.CloudProviderID {
 		product, err := s.productRepo.GetByID(ctx, item.ProductID)
 		if err != nil {
 			logger.Error("Failed to check product existence", err, zap.Uint("productId", item.ProductID))
@@ -239,11 +387,23 @@ func (s *configurationItemService) UpdateConfigItem(ctx context.Context, item *m
 		}
 	}
 
-	if err := s.repo.Update(ctx, item); err != nil {
+	var err error
+	if columns == nil {
+		err = s.repo.Update(ctx, item)
+	} else {
+		err = s.repo.Patch(ctx, item, columns)
+	}
+	if err != nil {
 		logger.Error("Failed to update configuration item", err)
 		return NewServiceError(ErrCodeDatabase, "更新配置项失败", err)
 	}
 
+	// 整体更新不修改标签，事件中使用原有标签
+	event := *item
+	if event.Tags == nil {
+		event.Tags = existingItem.Tags
+	}
+	s.publishItem(ctx, models.EventConfigItemUpdated, &event)
 	return nil
 }
 
@@ -268,6 +428,7 @@ func (s *configurationItemService) DeleteConfigItem(ctx context.Context, id uint
 		return NewServiceError(ErrCodeDatabase, "删除配置项失败", err)
 	}
 
+	s.publishItem(ctx, models.EventConfigItemDeleted, existingItem)
 	return nil
 }
 
@@ -277,7 +438,30 @@ func (s *configurationItemService) BatchImportConfigItems(ctx context.Context, i
 	logger.Info("Batch importing configuration items", zap.Int("count", len(items)))
 
 	// 批量验证：确保所有服务商和产品的有效性
-	for i, item := range items {
+	refs := newImportReferences(s.providerRepo, s.productRepo)
+	for i := range items {
+		if err := refs.resolve(ctx, &items[i], i+1); err != nil {
+			return err
+		}
+		item := items[i]
+
+		if msg := validateClassification(&items[i]); msg != "" {
+			return NewServiceError(ErrCodeInvalidData,
+				fmt.Sprintf("批量导入配置项失败：第%d条记录%s", i+1, msg), nil)
+		}
+
+		if len(item.Tags) > 0 {
+			names, msg := normalizeTagNames(models.TagNames(item.Tags))
+			if msg != "" {
+				return NewServiceError(ErrCodeInvalidData,
+					fmt.Sprintf("批量导入配置项失败：第%d条记录%s", i+1, msg), nil)
+			}
+			items[i].Tags = make([]models.Tag, 0, len(names))
+			for _, name := range names {
+				items[i].Tags = append(items[i].Tags, models.Tag{Name: name})
+			}
+		}
+
 		// 检查服务商是否存在
 		provider, err := s.providerRepo.GetByID(ctx, item.CloudProviderID)
 		if err != nil {
@@ -289,7 +473,7 @@ func (s *configurationItemService) BatchImportConfigItems(ctx context.Context, i
 
 		if provider == nil {
 			return NewServiceError(ErrCodeNotFound, 
-				"批量导入配置项失败：第" + string(i+1) + "条记录的云服务商不存在", nil)
+				fmt.Sprintf("批量导入配置项失败：第%d条记录的云服务商不存在", i+1), nil)
 		}
 
 		// 检查产品是否存在
@@ -303,13 +487,13 @@ func (s *configurationItemService) BatchImportConfigItems(ctx context.Context, i
 
 		if product == nil {
 			return NewServiceError(ErrCodeNotFound, 
-				"批量导入配置项失败：第" + string(i+1) + "条记录的云产品不存在", nil)
+				fmt.Sprintf("批量导入配置项失败：第%d条记录的云产品不存在", i+1), nil)
 		}
 
 		// 检查产品是否属于指定的服务商
 		if product.CloudProviderID != item.CloudProviderID {
 			return NewServiceError(ErrCodeInvalidData, 
-				"批量导入配置项失败：第" + string(i+1) + "条记录的云产品不属于指定的云服务商", nil)
+				fmt.Sprintf("批量导入配置项失败：第%d条记录的云产品不属于指定的云服务商", i+1), nil)
 		}
 	}
 
@@ -318,5 +502,451 @@ func (s *configurationItemService) BatchImportConfigItems(ctx context.Context, i
 		return NewServiceError(ErrCodeDatabase, "批量导入配置项失败", err)
 	}
 
+	event := ImportCompletedEvent{Count: len(items), ConfigItemIDs: make([]uint, len(items))}
+	for i := range items {
+		event.ConfigItemIDs[i] = items[i].ID
+	}
+	s.events.Publish(ctx, models.EventImportCompleted, event)
+	return nil
+}
+
+// GetImportCatalog 获取全部云服务商和云产品，用于生成导入模板的下拉列表
+func (s *configurationItemService) GetImportCatalog(ctx context.Context) ([]models.CloudProvider, []models.CloudProduct, error) {
+	ctx = WithContext(ctx)
+	logger.Info("Getting import catalog")
+
+	providers, err := s.providerRepo.GetAll(ctx)
+	if err != nil {
+		logger.Error("Failed to get providers", err)
+		return nil, nil, NewServiceError(ErrCodeDatabase, "获取云服务商列表失败", err)
+	}
+	products, err := s.productRepo.GetAll(ctx)
+	if err != nil {
+		logger.Error("Failed to get products", err)
+		return nil, nil, NewServiceError(ErrCodeDatabase, "获取云产品列表失败", err)
+	}
+	return providers, products, nil
+}
+
+// importReferences 将导入文件中按代码引用的云服务商和云产品解析为ID，缓存已解析的代码
+type importReferences struct {
+	providerRepo repository.CloudProviderRepository
+	productRepo  repository.CloudProductRepository
+	providers    map[string]uint
+	products     map[string]uint
+}
+
+// newImportReferences 创建导入引用解析器
+func newImportReferences(providerRepo repository.CloudProviderRepository, productRepo repository.CloudProductRepository) *importReferences {
+	return &importReferences{
+		providerRepo: providerRepo,
+		productRepo:  productRepo,
+		providers:    make(map[string]uint),
+		products:     make(map[string]uint),
+	}
+}
+
+// resolve 解析第index条记录的云服务商和云产品代码，并清空关联对象，避免插入时写入关联表
+func (r *importReferences) resolve(ctx context.Context, item *models.ConfigurationItem, index int) error {
+	if code := item.Provider.Code; code != "" {
+		id, ok := r.providers[code]
+		if !ok {
+			provider, err := r.providerRepo.GetByCode(ctx, code)
+			if err != nil {
+				logger.Error("Failed to get provider by code", err, zap.String("code", code))
+				return NewServiceError(ErrCodeDatabase, "批量导入配置项失败：验证云服务商出错", err)
+			}
+			if provider == nil {
+				return NewServiceError(ErrCodeNotFound,
+					fmt.Sprintf("批量导入配置项失败：第%d条记录的云服务商%q不存在", index, code), nil)
+			}
+			id = provider.ID
+			r.providers[code] = id
+		}
+		item.CloudProviderID = id
+	}
+
+	if code := item.Product.Code; code != "" {
+		key := fmt.Sprintf("%d/%s", item.CloudProviderID, code)
+		id, ok := r.products[key]
+		if !ok {
+			product, err := r.productRepo.GetByCode(ctx, item.CloudProviderID, code)
+			if err != nil {
+				logger.Error("Failed to get product by code", err, zap.String("code", code))
+				return NewServiceError(ErrCodeDatabase, "批量导入配置项失败：验证云产品出错", err)
+			}
+			if product == nil {
+				return NewServiceError(ErrCodeNotFound,
+					fmt.Sprintf("批量导入配置项失败：第%d条记录的云产品%q不存在", index, code), nil)
+			}
+			id = product.ID
+			r.products[key] = id
+		}
+		item.ProductID = id
+	}
+
+	item.Provider = models.CloudProvider{}
+	item.Product = models.CloudProduct{}
 	return nil
-}
\ No newline at end of file
+}
+
+// validateClassification 校验配置项的风险等级和状态，未设置时填充默认值，校验失败时返回错误信息
+func validateClassification(item *models.ConfigurationItem) string {
+	item.ApplyDefaults()
+	if !models.ValidSeverity(item.Severity) {
+		return "无效的风险等级: " + item.Severity
+	}
+	if !models.ValidStatus(item.Status) {
+		return "无效的状态: " + item.Status
+	}
+	return ""
+}
+
+// BulkConfigItems 批量操作配置项，先逐项校验，再在一个事务中执行通过校验的操作
+func (s *configurationItemService) BulkConfigItems(ctx context.Context, req ConfigItemBulkRequest) (*ConfigItemBulkResponse, error) {
+	ctx = WithContext(ctx)
+	logger.Info("Applying bulk configuration item operations",
+		zap.String("mode", req.Mode),
+		zap.Int("count", len(req.Operations)))
+
+	if req.Mode == "" {
+		req.Mode = BulkModeAtomic
+	}
+	if req.Mode != BulkModeAtomic && req.Mode != BulkModeBestEffort {
+		return nil, NewServiceError(ErrCodeInvalidData, "无效的批量操作模式: "+req.Mode, nil)
+	}
+	if len(req.Operations) == 0 {
+		return nil, NewServiceError(ErrCodeInvalidData, "批量操作不能为空", nil)
+	}
+	if len(req.Operations) > MaxBulkOperations {
+		return nil, NewServiceError(ErrCodeInvalidData, fmt.Sprintf("单次批量操作不能超过%d项", MaxBulkOperations), nil)
+	}
+
+	atomic := req.Mode == BulkModeAtomic
+	resp := &ConfigItemBulkResponse{
+		Mode:    req.Mode,
+		Results: make([]ConfigItemBulkResult, len(req.Operations)),
+	}
+
+	// 后续操作基于前面操作的结果校验，例如先update再move同一配置项
+	pending := make(map[uint]*models.ConfigurationItem)
+	// best_effort模式下前面的操作可能在执行时单独回滚，后续操作不能基于它的结果，
+	// 因此同一配置项只允许一个操作
+	first := make(map[uint]int)
+	var changes []repository.ConfigItemChange
+	var indexes []int
+	// 删除前的配置项，用于发布删除事件
+	deleted := make(map[int]*models.ConfigurationItem)
+	for i, op := range req.Operations {
+		resp.Results[i] = ConfigItemBulkResult{Index: i, Op: op.Op, ID: op.ID}
+
+		if !atomic && op.Op != repository.BulkOpCreate && op.ID != 0 {
+			if j, ok := first[op.ID]; ok {
+				resp.Results[i].Status = BulkStatusFailed
+				resp.Results[i].Error = fmt.Sprintf("best_effort模式下同一配置项只能有一个操作，operations[%d]已操作配置项%d", j, op.ID)
+				continue
+			}
+			first[op.ID] = i
+		}
+
+		change, err := s.prepareBulkChange(ctx, op, req.Validate, pending)
+		if err != nil {
+			var serviceErr *ServiceError
+			if !errors.As(err, &serviceErr) || serviceErr.Code == ErrCodeDatabase {
+				return nil, err
+			}
+			resp.Results[i].Status = BulkStatusFailed
+			resp.Results[i].Error = serviceErr.Message
+			continue
+		}
+		changes = append(changes, change)
+		indexes = append(indexes, i)
+	}
+
+	if len(changes) > 0 && (!atomic || len(changes) == len(req.Operations)) {
+		errs, err := s.repo.ApplyBulk(ctx, changes, atomic)
+		if err != nil {
+			logger.Error("Failed to apply bulk configuration item operations", err)
+			return nil, NewServiceError(ErrCodeDatabase, "批量操作配置项失败", err)
+		}
+
+		for j, i := range indexes {
+			result := &resp.Results[i]
+			if errs[j] != nil {
+				logger.Error("Failed to apply bulk configuration item operation", errs[j], zap.Int("index", i))
+				result.Status = BulkStatusFailed
+				result.Error = bulkChangeError(errs[j])
+				continue
+			}
+			result.ID = changes[j].ID
+			result.Status = BulkStatusSucceeded
+			if changes[j].Op == repository.BulkOpDelete {
+				deleted[i] = changes[j].Item
+				continue
+			}
+			result.Item = changes[j].Item
+		}
+	}
+
+	for _, result := range resp.Results {
+		switch result.Status {
+		case BulkStatusSucceeded:
+			resp.Succeeded++
+		case BulkStatusFailed:
+			resp.Failed++
+		}
+	}
+	// atomic模式下任一操作失败时，其余操作未执行或已随事务回滚
+	if atomic && resp.Failed > 0 {
+		for i := range resp.Results {
+			if resp.Results[i].Status != BulkStatusFailed {
+				resp.Results[i].Status = BulkStatusSkipped
+				resp.Results[i].Item = nil
+			}
+		}
+		resp.Succeeded = 0
+	}
+	resp.Committed = resp.Succeeded > 0
+
+	if resp.Committed {
+		s.publishBulkEvents(ctx, resp, deleted)
+	}
+	return resp, nil
+}
+
+// publishBulkEvents 为已提交的批量操作逐项发布配置项事件
+func (s *configurationItemService) publishBulkEvents(ctx context.Context, resp *ConfigItemBulkResponse, deleted map[int]*models.ConfigurationItem) {
+	for _, result := range resp.Results {
+		if result.Status != BulkStatusSucceeded {
+			continue
+		}
+		switch result.Op {
+		case repository.BulkOpCreate:
+			s.publishItem(ctx, models.EventConfigItemCreated, result.Item)
+		case repository.BulkOpDelete:
+			s.publishItem(ctx, models.EventConfigItemDeleted, deleted[result.Index])
+		case repository.BulkOpTag:
+			// tag操作的结果不包含配置项，重新读取变更后的标签
+			item, err := s.repo.GetByID(ctx, result.ID)
+			if err != nil || item == nil {
+				logger.Error("Failed to get configuration item for event", err, zap.Uint("id", result.ID))
+				continue
+			}
+			s.publishItem(ctx, models.EventConfigItemUpdated, item)
+		default:
+			s.publishItem(ctx, models.EventConfigItemUpdated, result.Item)
+		}
+	}
+}
+
+// publishItem 发布配置项事件
+func (s *configurationItemService) publishItem(ctx context.Context, event string, item *models.ConfigurationItem) {
+	s.events.Publish(ctx, event, NewConfigItemEventData(item))
+}
+
+// prepareBulkChange 校验单项批量操作并生成对应的仓库变更，校验失败时返回服务错误
+func (s *configurationItemService) prepareBulkChange(ctx context.Context, op ConfigItemBulkOperation, validate ConfigItemValidator, pending map[uint]*models.ConfigurationItem) (repository.ConfigItemChange, error) {
+	change := repository.ConfigItemChange{Op: op.Op, ID: op.ID}
+
+	switch op.Op {
+	case repository.BulkOpCreate:
+		if op.Item == nil {
+			return change, NewServiceError(ErrCodeInvalidData, "create操作缺少item", nil)
+		}
+		item := models.ConfigurationItem{
+			CloudProviderID:     op.Item.CloudProviderID,
+			ProductID:           op.Item.ProductID,
+			Name:                op.Item.Name,
+			RecommendedValue:    op.Item.RecommendedValue,
+			RiskDescription:     op.Item.RiskDescription,
+			CheckMethod:         op.Item.CheckMethod,
+			ConfigurationMethod: op.Item.ConfigurationMethod,
+			Reference:           op.Item.Reference,
+			Severity:            op.Item.Severity,
+			Status:              op.Item.Status,
+		}
+		if err := validateBulkItem(validate, &item); err != nil {
+			return change, err
+		}
+		names, msg := normalizeTagNames(op.AddTags)
+		if msg != "" {
+			return change, NewServiceError(ErrCodeInvalidData, msg, nil)
+		}
+		item.Tags = make([]models.Tag, 0, len(names))
+		for _, name := range names {
+			item.Tags = append(item.Tags, models.Tag{Name: name})
+		}
+		if err := s.checkProviderProduct(ctx, item.CloudProviderID, item.ProductID); err != nil {
+			return change, err
+		}
+		change.Item = &item
+		return change, nil
+	case repository.BulkOpUpdate, repository.BulkOpDelete, repository.BulkOpMove, repository.BulkOpTag:
+	default:
+		return change, NewServiceError(ErrCodeInvalidData, "不支持的操作类型: "+op.Op, nil)
+	}
+
+	if op.ID == 0 {
+		return change, NewServiceError(ErrCodeInvalidData, op.Op+"操作缺少配置项ID", nil)
+	}
+
+	current, ok := pending[op.ID]
+	if !ok {
+		item, err := s.repo.GetByID(ctx, op.ID)
+		if err != nil {
+			logger.Error("Failed to check configuration item existence", err, zap.Uint("id", op.ID))
+			return change, NewServiceError(ErrCodeDatabase, "批量操作配置项失败", err)
+		}
+		current = item
+		pending[op.ID] = current
+	}
+	if current == nil {
+		return change, NewServiceError(ErrCodeNotFound, "配置项不存在", nil)
+	}
+
+	switch op.Op {
+	case repository.BulkOpUpdate:
+		if len(op.Patch) == 0 {
+			return change, NewServiceError(ErrCodeInvalidData, "update操作缺少patch", nil)
+		}
+		var updated models.ConfigurationItem
+		if _, err := applyMergePatch(current, op.Patch, &updated, bulkPatchForbidden...); err != nil {
+			return change, NewServiceError(ErrCodeInvalidData, err.Error(), nil)
+		}
+		if err := validateBulkItem(validate, &updated); err != nil {
+			return change, err
+		}
+		change.Item = &updated
+		pending[op.ID] = &updated
+	case repository.BulkOpMove:
+		if op.ProductID == 0 {
+			return change, NewServiceError(ErrCodeInvalidData, "move操作缺少product_id", nil)
+		}
+		product, err := s.productRepo.GetByID(ctx, op.ProductID)
+		if err != nil {
+			logger.Error("Failed to check product existence", err, zap.Uint("productId", op.ProductID))
+			return change, NewServiceError(ErrCodeDatabase, "批量操作配置项失败", err)
+		}
+		if product == nil {
+			return change, NewServiceError(ErrCodeNotFound, "目标云产品不存在", nil)
+		}
+		moved := *current
+		moved.ProductID = product.ID
+		moved.CloudProviderID = product.CloudProviderID
+		// 预加载的关联对象已过期
+		moved.Provider, moved.Product = models.CloudProvider{}, models.CloudProduct{}
+		change.Item = &moved
+		pending[op.ID] = &moved
+	case repository.BulkOpDelete:
+		change.Item = current
+		pending[op.ID] = nil
+	case repository.BulkOpTag:
+		add, msg := normalizeTagNames(op.AddTags)
+		if msg != "" {
+			return change, NewServiceError(ErrCodeInvalidData, msg, nil)
+		}
+		remove, msg := normalizeTagNames(op.RemoveTags)
+		if msg != "" {
+			return change, NewServiceError(ErrCodeInvalidData, msg, nil)
+		}
+		if len(add) == 0 && len(remove) == 0 {
+			return change, NewServiceError(ErrCodeInvalidData, "tag操作缺少add_tags或remove_tags", nil)
+		}
+		change.AddTags = add
+		change.RemoveTags = remove
+	}
+
+	return change, nil
+}
+
+// validateBulkItem 补全默认值并校验批量操作中的配置项
+func validateBulkItem(validate ConfigItemValidator, item *models.ConfigurationItem) error {
+	if msg := validateClassification(item); msg != "" {
+		return NewServiceError(ErrCodeInvalidData, msg, nil)
+	}
+	if validate == nil {
+		return nil
+	}
+	if err := validate(item); err != nil {
+		return NewServiceError(ErrCodeInvalidData, err.Error(), nil)
+	}
+	return nil
+}
+
+// checkProviderProduct 检查云服务商和产品是否存在，且产品属于该服务商
+func (s *configurationItemService) checkProviderProduct(ctx context.Context, providerID, productID uint) error {
+	provider, err := s.providerRepo.GetByID(ctx, providerID)
+	if err != nil {
+		logger.Error("Failed to check provider existence", err, zap.Uint("providerId", providerID))
+		return NewServiceError(ErrCodeDatabase, "验证云服务商失败", err)
+	}
+	if provider == nil {
+		return NewServiceError(ErrCodeNotFound, "云服务商不存在", nil)
+	}
+
+	product, err := s.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		logger.Error("Failed to check product existence", err, zap.Uint("productId", productID))
+		return NewServiceError(ErrCodeDatabase, "验证云产品失败", err)
+	}
+	if product == nil {
+		return NewServiceError(ErrCodeNotFound, "云产品不存在", nil)
+	}
+
+	if product.CloudProviderID != providerID {
+		return NewServiceError(ErrCodeInvalidData, "云产品不属于指定的云服务商", nil)
+	}
+	return nil
+}
+
+// EvaluateResources 使用全部生效中的配置项评估资源配置，与离线的cloudeye check使用同一评估引擎
+func (s *configurationItemService) EvaluateResources(ctx context.Context, resources []evaluation.Resource) (*evaluation.Report, error) {
+	ctx = WithContext(ctx)
+	logger.Info("Evaluating resources", zap.Int("count", len(resources)))
+
+	if len(resources) == 0 {
+		return nil, NewServiceError(ErrCodeInvalidData, "待评估的资源不能为空", nil)
+	}
+	if len(resources) > MaxEvaluationResources {
+		return nil, NewServiceError(ErrCodeInvalidData, fmt.Sprintf("单次最多评估%d个资源", MaxEvaluationResources), nil)
+	}
+	for _, res := range resources {
+		if err := res.Validate(); err != nil {
+			return nil, NewServiceError(ErrCodeInvalidData, err.Error(), nil)
+		}
+	}
+
+	// 逐页加载配置项及其所属的云服务商和产品
+	var items []models.ConfigurationItem
+	filter := repository.ConfigItemFilter{Page: 1, PageSize: 100}
+	filter.Include = []string{"provider", "product"}
+	for {
+		result, err := s.repo.GetByFilter(ctx, filter)
+		if err != nil {
+			logger.Error("Failed to load configuration items for evaluation", err)
+			return nil, NewServiceError(ErrCodeDatabase, "加载配置项失败", err)
+		}
+		page, _ := result.Data.([]models.ConfigurationItem)
+		items = append(items, page...)
+		if len(page) < filter.PageSize {
+			break
+		}
+		filter.Page++
+	}
+
+	return evaluation.Evaluate(items, resources), nil
+}
+
+// bulkChangeError 将执行批量变更时的数据库错误转换为错误信息
+func bulkChangeError(err error) string {
+	switch {
+	case repository.IsNotFound(err):
+		return "配置项不存在"
+	case repository.IsForeignKeyViolation(err):
+		return "引用的云服务商或云产品不存在"
+	case repository.IsDuplicateKey(err):
+		return "数据重复"
+	default:
+		return "执行失败"
+	}
+}
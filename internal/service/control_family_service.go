@@ -0,0 +1,363 @@
+package service
+
+import (
+	"context"
+
+	"github.com/yourusername/cloud-eye/internal/models"
+	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+	"github.com/yourusername/cloud-eye/internal/repository"
+	"go.uber.org/zap"
+)
+
+// FamilyComparisonEntry 对比视图中的单个配置项
+type FamilyComparisonEntry struct {
+	ConfigItemID     uint   `json:"config_item_id"`
+	Name             string `json:"name"`
+	ProductID        uint   `json:"product_id"`
+	ProductCode      string `json:"product_code"`
+	ProductName      string `json:"product_name"`
+	RecommendedValue string `json:"recommended_value"`
+	Severity         string `json:"severity"`
+}
+
+// FamilyProviderColumn 对比视图中一个云服务商的列
+type FamilyProviderColumn struct {
+	ProviderID   uint                    `json:"provider_id"`
+	ProviderCode string                  `json:"provider_code"`
+	ProviderName string                  `json:"provider_name"`
+	Items        []FamilyComparisonEntry `json:"items"`
+	Missing      bool                    `json:"missing"` // 该服务商没有等价的配置基线
+}
+
+// FamilyComparison 控制族跨云服务商对比视图
+type FamilyComparison struct {
+	Family           models.ControlFamily   `json:"family"`
+	Providers        []FamilyProviderColumn `json:"providers"`
+	MissingProviders []string               `json:"missing_providers"` // 缺少等价配置基线的服务商代码
+}
+
+// ControlFamilyService 控制族服务接口
+type ControlFamilyService interface {
+	Service
+	GetAllFamilies(ctx context.Context) ([]models.ControlFamily, error)
+	GetFamilyByID(ctx context.Context, id uint) (*models.ControlFamily, error)
+	CreateFamily(ctx context.Context, family *models.ControlFamily) error
+	UpdateFamily(ctx context.Context, family *models.ControlFamily) error
+	DeleteFamily(ctx context.Context, id uint) error
+	LinkConfigItems(ctx context.Context, familyID uint, itemIDs []uint) error
+	UnlinkConfigItems(ctx context.Context, familyID uint, itemIDs []uint) error
+	CompareFamily(ctx context.Context, familyID uint) (*FamilyComparison, error)
+}
+
+// controlFamilyService 控制族服务实现
+type controlFamilyService struct {
+	BaseService
+	repo         repository.ControlFamilyRepository
+	configRepo   repository.ConfigurationItemRepository
+	providerRepo repository.CloudProviderRepository
+	events       EventPublisher
+}
+
+// NewControlFamilyService 创建控制族服务，配置项加入或移出控制族后通过events发布配置项更新事件，events为nil时不发布
+func NewControlFamilyService(
+	repo repository.ControlFamilyRepository,
+	configRepo repository.ConfigurationItemRepository,
+	providerRepo repository.CloudProviderRepository,
+	events EventPublisher,
+) ControlFamilyService {
+	if events == nil {
+		events = noopPublisher{}
+	}
+	return &controlFamilyService{
+		repo:         repo,
+		configRepo:   configRepo,
+		providerRepo: providerRepo,
+		events:       events,
+	}
+}
+
+// GetAllFamilies 获取所有控制族
+func (s *controlFamilyService) GetAllFamilies(ctx context.Context) ([]models.ControlFamily, error) {
+	ctx = WithContext(ctx)
+	logger.Info("Getting all control families")
+
+	families, err := s.repo.GetAll(ctx)
+	if err != nil {
+		logger.Error("Failed to get all control families", err)
+		return nil, NewServiceError(ErrCodeDatabase, "获取控制族列表失败", err)
+	}
+
+	return families, nil
+}
+
+// GetFamilyByID 根据ID获取控制族，包含成员配置项
+func (s *controlFamilyService) GetFamilyByID(ctx context.Context, id uint) (*models.ControlFamily, error) {
+	ctx = WithContext(ctx)
+	logger.Info("Getting control family by ID", zap.Uint("id", id))
+
+	family, err := s.getFamily(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	family.ConfigItems, err = s.repo.GetConfigItems(ctx, id)
+	if err != nil {
+		logger.Error("Failed to get control family config items", err, zap.Uint("id", id))
+		return nil, NewServiceError(ErrCodeDatabase, "获取控制族失败", err)
+	}
+
+	return family, nil
+}
+
+// CreateFamily 创建控制族
+func (s *controlFamilyService) CreateFamily(ctx context.Context, family *models.ControlFamily) error {
+	ctx = WithContext(ctx)
+	logger.Info("Creating control family", zap.String("name", family.Name), zap.String("code", family.Code))
+
+	existing, err := s.repo.GetByCode(ctx, family.Code)
+	if err != nil {
+		logger.Error("Failed to check control family code", err, zap.String("code", family.Code))
+		return NewServiceError(ErrCodeDatabase, "创建控制族失败", err)
+	}
+
+	if existing != nil {
+		return NewServiceError(ErrCodeDuplicate, "控制族代码已存在", nil)
+	}
+
+	if err := s.repo.Create(ctx, family); err != nil {
+		logger.Error("Failed to create control family", err)
+		return NewServiceError(ErrCodeDatabase, "创建控制族失败", err)
+	}
+
+	return nil
+}
+
+// UpdateFamily 更新控制族
+func (s *controlFamilyService) UpdateFamily(ctx context.Context, family *models.ControlFamily) error {
+	ctx = WithContext(ctx)
+	logger.Info("Updating control family", zap.Uint("id", family.ID))
+
+	existing, err := s.getFamily(ctx, family.ID)
+	if err != nil {
+		return err
+	}
+
+	// 如果更改了代码，检查新代码是否已存在
+	if family.Code != existing.Code {
+		codeCheck, err := s.repo.GetByCode(ctx, family.Code)
+		if err != nil {
+			logger.Error("Failed to check control family code", err, zap.String("code", family.Code))
+			return NewServiceError(ErrCodeDatabase, "更新控制族失败", err)
+		}
+
+		if codeCheck != nil && codeCheck.ID != family.ID {
+			return NewServiceError(ErrCodeDuplicate, "控制族代码已存在", nil)
+		}
+	}
+
+	if err := s.repo.Update(ctx, family); err != nil {
+		logger.Error("Failed to update control family", err)
+		return NewServiceError(ErrCodeDatabase, "更新控制族失败", err)
+	}
+
+	return nil
+}
+
+// DeleteFamily 删除控制族，成员配置项保留
+func (s *controlFamilyService) DeleteFamily(ctx context.Context, id uint) error {
+	ctx = WithContext(ctx)
+	logger.Info("Deleting control family", zap.Uint("id", id))
+
+	if _, err := s.getFamily(ctx, id); err != nil {
+		return err
+	}
+
+	members, err := s.repo.GetConfigItems(ctx, id)
+	if err != nil {
+		logger.Error("Failed to get control family config items", err, zap.Uint("id", id))
+		return NewServiceError(ErrCodeDatabase, "删除控制族失败", err)
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		logger.Error("Failed to delete control family", err)
+		return NewServiceError(ErrCodeDatabase, "删除控制族失败", err)
+	}
+
+	memberIDs := make([]uint, 0, len(members))
+	for _, item := range members {
+		memberIDs = append(memberIDs, item.ID)
+	}
+	s.publishItemsUpdated(ctx, memberIDs)
+	return nil
+}
+
+// LinkConfigItems 将配置项加入控制族，已属于其他控制族的配置项需先解除关联
+func (s *controlFamilyService) LinkConfigItems(ctx context.Context, familyID uint, itemIDs []uint) error {
+	ctx = WithContext(ctx)
+	logger.Info("Linking config items to control family", zap.Uint("id", familyID), zap.Uints("itemIds", itemIDs))
+
+	if len(itemIDs) == 0 {
+		return NewServiceError(ErrCodeInvalidData, "配置项ID列表不能为空", nil)
+	}
+
+	if _, err := s.getFamily(ctx, familyID); err != nil {
+		return err
+	}
+
+	var linked []uint
+	for _, id := range itemIDs {
+		item, err := s.configRepo.GetByID(ctx, id)
+		if err != nil {
+			logger.Error("Failed to check configuration item existence", err, zap.Uint("id", id))
+			return NewServiceError(ErrCodeDatabase, "关联配置项失败", err)
+		}
+
+		if item == nil {
+			return NewServiceError(ErrCodeNotFound, "配置项不存在", nil)
+		}
+
+		if item.ControlFamilyID != nil && *item.ControlFamilyID != familyID {
+			return NewServiceError(ErrCodeInvalidData, "配置项「"+item.Name+"」已属于其他控制族", nil)
+		}
+		if item.ControlFamilyID == nil {
+			linked = append(linked, id)
+		}
+	}
+
+	if err := s.repo.SetConfigItemsFamily(ctx, itemIDs, &familyID); err != nil {
+		logger.Error("Failed to link config items to control family", err)
+		return NewServiceError(ErrCodeDatabase, "关联配置项失败", err)
+	}
+
+	// 已在该控制族中的配置项没有变化
+	s.publishItemsUpdated(ctx, linked)
+	return nil
+}
+
+// UnlinkConfigItems 将配置项移出控制族
+func (s *controlFamilyService) UnlinkConfigItems(ctx context.Context, familyID uint, itemIDs []uint) error {
+	ctx = WithContext(ctx)
+	logger.Info("Unlinking config items from control family", zap.Uint("id", familyID), zap.Uints("itemIds", itemIDs))
+
+	if len(itemIDs) == 0 {
+		return NewServiceError(ErrCodeInvalidData, "配置项ID列表不能为空", nil)
+	}
+
+	if _, err := s.getFamily(ctx, familyID); err != nil {
+		return err
+	}
+
+	for _, id := range itemIDs {
+		item, err := s.configRepo.GetByID(ctx, id)
+		if err != nil {
+			logger.Error("Failed to check configuration item existence", err, zap.Uint("id", id))
+			return NewServiceError(ErrCodeDatabase, "解除关联失败", err)
+		}
+
+		if item == nil || item.ControlFamilyID == nil || *item.ControlFamilyID != familyID {
+			return NewServiceError(ErrCodeNotFound, "配置项不属于该控制族", nil)
+		}
+	}
+
+	if err := s.repo.SetConfigItemsFamily(ctx, itemIDs, nil); err != nil {
+		logger.Error("Failed to unlink config items from control family", err)
+		return NewServiceError(ErrCodeDatabase, "解除关联失败", err)
+	}
+
+	s.publishItemsUpdated(ctx, itemIDs)
+	return nil
+}
+
+// publishItemsUpdated 重新读取所属控制族有变化的配置项并逐条发布更新事件，读取失败只记录日志
+func (s *controlFamilyService) publishItemsUpdated(ctx context.Context, itemIDs []uint) {
+	var published []uint
+	for _, id := range itemIDs {
+		if containsUint(published, id) {
+			continue
+		}
+		published = append(published, id)
+
+		item, err := s.configRepo.GetByID(ctx, id)
+		if err != nil {
+			logger.Error("Failed to load configuration item for event", err, zap.Uint("id", id))
+			continue
+		}
+		if item == nil {
+			continue
+		}
+		s.events.Publish(ctx, models.EventConfigItemUpdated, NewConfigItemEventData(item))
+	}
+}
+
+// CompareFamily 生成控制族的跨云服务商对比视图，每个服务商一列，没有等价配置基线的服务商标记为缺失
+func (s *controlFamilyService) CompareFamily(ctx context.Context, familyID uint) (*FamilyComparison, error) {
+	ctx = WithContext(ctx)
+	logger.Info("Comparing control family", zap.Uint("id", familyID))
+
+	family, err := s.getFamily(ctx, familyID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.repo.GetConfigItems(ctx, familyID)
+	if err != nil {
+		logger.Error("Failed to get control family config items", err, zap.Uint("id", familyID))
+		return nil, NewServiceError(ErrCodeDatabase, "获取控制族对比失败", err)
+	}
+
+	providers, err := s.providerRepo.GetAll(ctx)
+	if err != nil {
+		logger.Error("Failed to get all cloud providers", err)
+		return nil, NewServiceError(ErrCodeDatabase, "获取控制族对比失败", err)
+	}
+
+	byProvider := make(map[uint][]FamilyComparisonEntry)
+	for _, item := range items {
+		byProvider[item.CloudProviderID] = append(byProvider[item.CloudProviderID], FamilyComparisonEntry{
+			ConfigItemID:     item.ID,
+			Name:             item.Name,
+			ProductID:        item.ProductID,
+			ProductCode:      item.Product.Code,
+			ProductName:      item.Product.Name,
+			RecommendedValue: item.RecommendedValue,
+			Severity:         item.Severity,
+		})
+	}
+
+	comparison := &FamilyComparison{
+		Family:           *family,
+		Providers:        make([]FamilyProviderColumn, 0, len(providers)),
+		MissingProviders: []string{},
+	}
+	for _, p := range providers {
+		column := FamilyProviderColumn{
+			ProviderID:   p.ID,
+			ProviderCode: p.Code,
+			ProviderName: p.Name,
+			Items:        byProvider[p.ID],
+			Missing:      len(byProvider[p.ID]) == 0,
+		}
+		if column.Missing {
+			column.Items = []FamilyComparisonEntry{}
+			comparison.MissingProviders = append(comparison.MissingProviders, p.Code)
+		}
+		comparison.Providers = append(comparison.Providers, column)
+	}
+
+	return comparison, nil
+}
+
+// getFamily 获取控制族，不存在时返回未找到错误
+func (s *controlFamilyService) getFamily(ctx context.Context, id uint) (*models.ControlFamily, error) {
+	family, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		logger.Error("Failed to get control family by ID", err, zap.Uint("id", id))
+		return nil, NewServiceError(ErrCodeDatabase, "获取控制族失败", err)
+	}
+
+	if family == nil {
+		return nil, NewServiceError(ErrCodeNotFound, "控制族不存在", nil)
+	}
+
+	return family, nil
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/yourusername/cloud-eye/internal/models"
+	"github.com/yourusername/cloud-eye/internal/repository"
+)
+
+// familyFixture 控制族测试使用的内存仓库和服务
+type familyFixture struct {
+	t         *testing.T
+	providers repository.CloudProviderRepository
+	products  repository.CloudProductRepository
+	items     repository.ConfigurationItemRepository
+	service   ControlFamilyService
+}
+
+func newFamilyFixture(t *testing.T) *familyFixture {
+	store := repository.NewMemoryStore()
+	f := &familyFixture{
+		t:         t,
+		providers: repository.NewMemoryCloudProviderRepository(store),
+		products:  repository.NewMemoryCloudProductRepository(store),
+		items:     repository.NewMemoryConfigurationItemRepository(store),
+	}
+	f.service = NewControlFamilyService(repository.NewMemoryControlFamilyRepository(store), f.items, f.providers, nil)
+	return f
+}
+
+func (f *familyFixture) provider(code string) *models.CloudProvider {
+	provider := &models.CloudProvider{Name: code + "名称", Code: code}
+	if err := f.providers.Create(context.Background(), provider); err != nil {
+		f.t.Fatalf("创建云服务商%s失败: %v", code, err)
+	}
+	return provider
+}
+
+func (f *familyFixture) product(provider *models.CloudProvider, code string) *models.CloudProduct {
+	product := &models.CloudProduct{CloudProviderID: provider.ID, Name: code + "名称", Code: code}
+	if err := f.products.Create(context.Background(), product); err != nil {
+		f.t.Fatalf("创建云产品%s失败: %v", code, err)
+	}
+	return product
+}
+
+func (f *familyFixture) item(product *models.CloudProduct, name, value string) uint {
+	item := &models.ConfigurationItem{
+		CloudProviderID:  product.CloudProviderID,
+		ProductID:        product.ID,
+		Name:             name,
+		RecommendedValue: value,
+		Severity:         models.SeverityHigh,
+		Status:           models.StatusActive,
+	}
+	if err := f.items.Create(context.Background(), item); err != nil {
+		f.t.Fatalf("创建配置项%s失败: %v", name, err)
+	}
+	return item.ID
+}
+
+func (f *familyFixture) family(name string) uint {
+	family := &models.ControlFamily{Name: name}
+	if err := f.service.CreateFamily(context.Background(), family); err != nil {
+		f.t.Fatalf("创建控制族%s失败: %v", name, err)
+	}
+	return family.ID
+}
+
+// columnSummary 以"服务商:配置项ID列表:是否缺失"的形式概括对比结果
+func columnSummary(comparison *FamilyComparison) []string {
+	var summary []string
+	for _, column := range comparison.Providers {
+		var ids []uint
+		for _, entry := range column.Items {
+			ids = append(ids, entry.ConfigItemID)
+		}
+		summary = append(summary, fmt.Sprintf("%s:%v:%v", column.ProviderCode, ids, column.Missing))
+	}
+	return summary
+}
+
+func TestCompareFamilyPartialCoverage(t *testing.T) {
+	f := newFamilyFixture(t)
+	ctx := context.Background()
+	aws, azure, gcp := f.provider("AWS"), f.provider("AZURE"), f.provider("GCP")
+	s3, ebs := f.product(aws, "S3"), f.product(aws, "EBS")
+	storage := f.product(azure, "STORAGE")
+	gcs := f.product(gcp, "GCS")
+
+	s3Item := f.item(s3, "S3加密", "SSE-KMS")
+	ebsItem := f.item(ebs, "EBS加密", "默认加密")
+	azureItem := f.item(storage, "存储账户加密", "客户托管密钥")
+	f.item(gcs, "GCS加密", "CMEK") // 不属于控制族
+
+	family := f.family("静态数据加密")
+	if err := f.service.LinkConfigItems(ctx, family, []uint{s3Item, ebsItem, azureItem}); err != nil {
+		t.Fatalf("关联配置项失败: %v", err)
+	}
+
+	comparison, err := f.service.CompareFamily(ctx, family)
+	if err != nil {
+		t.Fatalf("对比失败: %v", err)
+	}
+	want := fmt.Sprint([]string{
+		fmt.Sprintf("AWS:%v:false", []uint{s3Item, ebsItem}),
+		fmt.Sprintf("AZURE:%v:false", []uint{azureItem}),
+		"GCP:[]:true",
+	})
+	if got := fmt.Sprint(columnSummary(comparison)); got != want {
+		t.Fatalf("对比结果为%s，期望%s", got, want)
+	}
+	if fmt.Sprint(comparison.MissingProviders) != "[GCP]" {
+		t.Fatalf("缺少基线的服务商为%v，期望[GCP]", comparison.MissingProviders)
+	}
+	if comparison.Family.ID != family || comparison.Family.Name != "静态数据加密" {
+		t.Fatalf("对比结果中的控制族为%+v", comparison.Family)
+	}
+
+	entry := comparison.Providers[0].Items[0]
+	if entry.Name != "S3加密" || entry.ProductCode != "S3" || entry.ProductName != "S3名称" ||
+		entry.RecommendedValue != "SSE-KMS" || entry.Severity != models.SeverityHigh {
+		t.Fatalf("AWS的第一个配置项为%+v", entry)
+	}
+	if comparison.Providers[2].Items == nil {
+		t.Fatal("缺少基线的服务商的配置项为nil，期望空数组")
+	}
+
+	// 移出控制族后对应的服务商变为缺失
+	if err := f.service.UnlinkConfigItems(ctx, family, []uint{azureItem}); err != nil {
+		t.Fatalf("解除关联失败: %v", err)
+	}
+	comparison, _ = f.service.CompareFamily(ctx, family)
+	if fmt.Sprint(comparison.MissingProviders) != "[AZURE GCP]" {
+		t.Fatalf("解除关联后缺少基线的服务商为%v", comparison.MissingProviders)
+	}
+}
+
+func TestCompareFamilyMissingProviders(t *testing.T) {
+	f := newFamilyFixture(t)
+	ctx := context.Background()
+
+	// 没有云服务商时对比结果为空
+	empty := f.family("空控制族")
+	comparison, err := f.service.CompareFamily(ctx, empty)
+	if err != nil || len(comparison.Providers) != 0 || comparison.MissingProviders == nil || len(comparison.MissingProviders) != 0 {
+		t.Fatalf("没有云服务商时返回%+v, %v", comparison, err)
+	}
+
+	// 控制族没有成员时所有服务商都缺失
+	f.provider("AWS")
+	f.provider("AZURE")
+	comparison, err = f.service.CompareFamily(ctx, empty)
+	if err != nil {
+		t.Fatalf("对比失败: %v", err)
+	}
+	if got := fmt.Sprint(columnSummary(comparison)); got != "[AWS:[]:true AZURE:[]:true]" {
+		t.Fatalf("对比结果为%s", got)
+	}
+	if fmt.Sprint(comparison.MissingProviders) != "[AWS AZURE]" {
+		t.Fatalf("缺少基线的服务商为%v", comparison.MissingProviders)
+	}
+
+	// 控制族不存在
+	_, err = f.service.CompareFamily(ctx, empty+1)
+	var serviceErr *ServiceError
+	if !errors.As(err, &serviceErr) || serviceErr.Code != ErrCodeNotFound {
+		t.Fatalf("控制族不存在时返回%v，期望未找到", err)
+	}
+}
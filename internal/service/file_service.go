@@ -0,0 +1,318 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/yourusername/cloud-eye/internal/models"
+	"github.com/yourusername/cloud-eye/internal/pkg/filestore"
+	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+	"github.com/yourusername/cloud-eye/internal/repository"
+	"go.uber.org/zap"
+)
+
+// 托管文件默认选项
+const (
+	DefaultFileLinkTTL         = time.Hour
+	DefaultFileExportRetention = 7 * 24 * time.Hour
+	DefaultFileImportRetention = 24 * time.Hour
+)
+
+// fileCleanupInterval 清理过期文件的间隔
+const fileCleanupInterval = time.Hour
+
+// fileCleanupBatch 每批清理的过期文件数
+const fileCleanupBatch = 100
+
+// 常见文件类型，部分系统的MIME数据库中没有xlsx
+var fileContentTypes = map[string]string{
+	".xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	".json": "application/json",
+}
+
+// FileOptions 托管文件选项，零值使用默认值
+type FileOptions struct {
+	LinkSecret      string        // 下载链接签名密钥，为空时随机生成，重启后已签发的链接失效
+	LinkTTL         time.Duration // 下载链接有效期，不超过文件的过期时间
+	ExportRetention time.Duration // 导出文件、评估结果等服务端生成文件的保留时长
+	ImportRetention time.Duration // 上传的导入文件的保留时长
+}
+
+// withDefaults 填充未设置的选项
+func (o FileOptions) withDefaults() FileOptions {
+	if o.LinkSecret == "" {
+		b := make([]byte, 32)
+		if _, err := rand.Read(b); err != nil {
+			panic(fmt.Sprintf("生成下载链接签名密钥失败: %v", err))
+		}
+		o.LinkSecret = hex.EncodeToString(b)
+		logger.Info("File link secret is not configured, using a random secret; download links expire on restart")
+	}
+	if o.LinkTTL <= 0 {
+		o.LinkTTL = DefaultFileLinkTTL
+	}
+	if o.ExportRetention <= 0 {
+		o.ExportRetention = DefaultFileExportRetention
+	}
+	if o.ImportRetention <= 0 {
+		o.ImportRetention = DefaultFileImportRetention
+	}
+	return o
+}
+
+// FileService 托管文件服务接口：保存导出和导入文件、签发有时效的下载链接，并清理过期文件
+type FileService interface {
+	Service
+	// Save 保存文件，size为内容长度，未知时为-1；文件在对应类型的保留时长后过期
+	Save(ctx context.Context, kind, name string, r io.Reader, size int64) (*models.StoredFile, error)
+	// SaveLocal 将本地文件保存到文件存储，成功后删除本地文件
+	SaveLocal(ctx context.Context, kind, path, name string) (*models.StoredFile, error)
+	// GetFile 获取未过期的文件
+	GetFile(ctx context.Context, id uint) (*models.StoredFile, error)
+	// Open 打开未过期的文件，调用方负责关闭返回的内容
+	Open(ctx context.Context, id uint) (*models.StoredFile, io.ReadCloser, error)
+	// DownloadURL 签发文件的下载链接，返回相对地址和链接的过期时间
+	DownloadURL(file *models.StoredFile) (string, time.Time)
+	// VerifyLink 校验下载链接的签名和过期时间
+	VerifyLink(id uint, expires int64, signature string) error
+	// Run 定期清理过期文件，直到ctx取消
+	Run(ctx context.Context)
+}
+
+// fileService 托管文件服务实现
+type fileService struct {
+	BaseService
+	repo  repository.FileRepository
+	store filestore.Store
+	opts  FileOptions
+}
+
+// NewFileService 创建托管文件服务
+func NewFileService(repo repository.FileRepository, store filestore.Store, opts FileOptions) FileService {
+	return &fileService{
+		repo:  repo,
+		store: store,
+		opts:  opts.withDefaults(),
+	}
+}
+
+// Save 先保存文件内容再保存记录，记录保存失败时删除已保存的内容
+func (s *fileService) Save(ctx context.Context, kind, name string, r io.Reader, size int64) (*models.StoredFile, error) {
+	ctx = WithContext(ctx)
+	logger.Info("Saving file", zap.String("kind", kind), zap.String("name", name))
+
+	retention, ok := s.retention(kind)
+	if !ok {
+		return nil, NewServiceError(ErrCodeInvalidData, "不支持的文件类型: "+kind, nil)
+	}
+
+	now := time.Now()
+	key, err := filestore.NewKey(kind, name, now)
+	if err != nil {
+		logger.Error("Failed to generate storage key", err)
+		return nil, NewServiceError(ErrCodeInternal, "保存文件失败", err)
+	}
+
+	counter := &countingReader{r: r}
+	contentType := contentTypeOf(name)
+	if err := s.store.Put(ctx, key, counter, size, contentType); err != nil {
+		logger.Error("Failed to store file", err, zap.String("key", key))
+		return nil, NewServiceError(ErrCodeInternal, "保存文件失败", err)
+	}
+
+	file := &models.StoredFile{
+		Kind:        kind,
+		Name:        name,
+		StorageKey:  key,
+		Size:        counter.n,
+		ContentType: contentType,
+		ExpiresAt:   now.Add(retention),
+	}
+	if err := s.repo.Create(ctx, file); err != nil {
+		logger.Error("Failed to create stored file", err, zap.String("key", key))
+		if err := s.store.Delete(context.Background(), key); err != nil {
+			logger.Error("Failed to delete orphaned file", err, zap.String("key", key))
+		}
+		return nil, NewServiceError(ErrCodeDatabase, "保存文件失败", err)
+	}
+
+	logger.Info("File saved", zap.Uint("id", file.ID), zap.String("key", key), zap.Int64("size", file.Size))
+	return file, nil
+}
+
+// SaveLocal 保存本地文件
+func (s *fileService) SaveLocal(ctx context.Context, kind, path, name string) (*models.StoredFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		logger.Error("Failed to open local file", err, zap.String("path", path))
+		return nil, NewServiceError(ErrCodeInternal, "保存文件失败", err)
+	}
+	size := int64(-1)
+	if info, err := f.Stat(); err == nil {
+		size = info.Size()
+	}
+	if name == "" {
+		name = filepath.Base(path)
+	}
+
+	file, err := s.Save(ctx, kind, name, f, size)
+	f.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.Remove(path); err != nil {
+		logger.Error("Failed to remove local file", err, zap.String("path", path))
+	}
+	return file, nil
+}
+
+// GetFile 获取文件，已过期但尚未清理的文件视为不存在
+func (s *fileService) GetFile(ctx context.Context, id uint) (*models.StoredFile, error) {
+	ctx = WithContext(ctx)
+
+	file, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		logger.Error("Failed to get stored file", err, zap.Uint("id", id))
+		return nil, NewServiceError(ErrCodeDatabase, "获取文件失败", err)
+	}
+	if file == nil || !file.ExpiresAt.After(time.Now()) {
+		return nil, NewServiceError(ErrCodeNotFound, "文件不存在或已过期", nil)
+	}
+	return file, nil
+}
+
+// Open 打开文件
+func (s *fileService) Open(ctx context.Context, id uint) (*models.StoredFile, io.ReadCloser, error) {
+	file, err := s.GetFile(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rc, err := s.store.Open(WithContext(ctx), file.StorageKey)
+	if err != nil {
+		if errors.Is(err, filestore.ErrNotFound) {
+			logger.Error("Stored file content is missing", err, zap.Uint("id", id), zap.String("key", file.StorageKey))
+			return nil, nil, NewServiceError(ErrCodeNotFound, "文件不存在或已过期", nil)
+		}
+		logger.Error("Failed to open stored file", err, zap.Uint("id", id))
+		return nil, nil, NewServiceError(ErrCodeInternal, "读取文件失败", err)
+	}
+	return file, rc, nil
+}
+
+// DownloadURL 签发下载链接
+func (s *fileService) DownloadURL(file *models.StoredFile) (string, time.Time) {
+	expiresAt := time.Now().Add(s.opts.LinkTTL)
+	if file.ExpiresAt.Before(expiresAt) {
+		expiresAt = file.ExpiresAt
+	}
+	expires := expiresAt.Unix()
+	signature := filestore.Sign(s.opts.LinkSecret, file.ID, expires)
+	return fmt.Sprintf("/api/v1/files/%d?expires=%d&signature=%s", file.ID, expires, signature), time.Unix(expires, 0)
+}
+
+// VerifyLink 校验下载链接
+func (s *fileService) VerifyLink(id uint, expires int64, signature string) error {
+	if signature == "" {
+		return NewServiceError(ErrCodeForbidden, "缺少下载链接签名", nil)
+	}
+	if !filestore.Verify(s.opts.LinkSecret, id, expires, signature, time.Now()) {
+		return NewServiceError(ErrCodeForbidden, "下载链接无效或已过期", nil)
+	}
+	return nil
+}
+
+// Run 运行过期文件清理
+func (s *fileService) Run(ctx context.Context) {
+	ticker := time.NewTicker(fileCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		s.cleanup(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// cleanup 分批删除过期文件，先删除内容再删除记录，删除失败的文件在下一轮重试
+func (s *fileService) cleanup(ctx context.Context) {
+	now := time.Now()
+	deleted := 0
+	for ctx.Err() == nil {
+		files, err := s.repo.Expired(ctx, now, fileCleanupBatch)
+		if err != nil {
+			logger.Error("Failed to get expired files", err)
+			break
+		}
+
+		failed := false
+		for _, file := range files {
+			if err := s.store.Delete(ctx, file.StorageKey); err != nil {
+				logger.Error("Failed to delete expired file", err, zap.Uint("id", file.ID), zap.String("key", file.StorageKey))
+				failed = true
+				continue
+			}
+			if err := s.repo.Delete(ctx, file.ID); err != nil {
+				logger.Error("Failed to delete expired file record", err, zap.Uint("id", file.ID))
+				failed = true
+				continue
+			}
+			deleted++
+		}
+		// 存在删除失败的文件时下一批会再次返回这些文件，留到下一轮重试
+		if failed || len(files) < fileCleanupBatch {
+			break
+		}
+	}
+	if deleted > 0 {
+		logger.Info("Deleted expired files", zap.Int("count", deleted))
+	}
+}
+
+// retention 返回文件类型的保留时长
+func (s *fileService) retention(kind string) (time.Duration, bool) {
+	switch kind {
+	case models.FileKindExport:
+		return s.opts.ExportRetention, true
+	case models.FileKindImport:
+		return s.opts.ImportRetention, true
+	}
+	return 0, false
+}
+
+// contentTypeOf 根据文件扩展名返回内容类型
+func contentTypeOf(name string) string {
+	ext := strings.ToLower(filepath.Ext(name))
+	if ct, ok := fileContentTypes[ext]; ok {
+		return ct
+	}
+	if ct := mime.TypeByExtension(ext); ct != "" {
+		return ct
+	}
+	return "application/octet-stream"
+}
+
+// countingReader 统计读取的字节数，用于记录长度未知的文件大小
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/cloud-eye/internal/models"
+	"github.com/yourusername/cloud-eye/internal/pkg/filestore"
+	"github.com/yourusername/cloud-eye/internal/repository"
+)
+
+// failingStore 删除指定存储键时失败的文件存储
+type failingStore struct {
+	filestore.Store
+	failDelete map[string]bool
+}
+
+func (s *failingStore) Delete(ctx context.Context, key string) error {
+	if s.failDelete[key] {
+		return errors.New("对象存储不可用")
+	}
+	return s.Store.Delete(ctx, key)
+}
+
+// failingFileRepository 创建记录时失败的文件仓库
+type failingFileRepository struct {
+	repository.FileRepository
+}
+
+func (failingFileRepository) Create(context.Context, *models.StoredFile) error {
+	return errors.New("数据库不可用")
+}
+
+func newTestFileService(t *testing.T) (*fileService, repository.FileRepository, *failingStore) {
+	t.Helper()
+	repo := repository.NewMemoryFileRepository(repository.NewMemoryStore())
+	store := &failingStore{Store: filestore.NewLocalStore(t.TempDir()), failDelete: map[string]bool{}}
+	s := NewFileService(repo, store, FileOptions{LinkSecret: "link-secret"}).(*fileService)
+	return s, repo, store
+}
+
+// putFile 保存内容和在expiresAt过期的记录
+func putFile(t *testing.T, repo repository.FileRepository, store filestore.Store, key string, expiresAt time.Time) *models.StoredFile {
+	t.Helper()
+	if err := store.Put(context.Background(), key, strings.NewReader(key), -1, ""); err != nil {
+		t.Fatalf("保存文件内容失败: %v", err)
+	}
+	file := &models.StoredFile{Kind: models.FileKindExport, Name: "a.xlsx", StorageKey: key, ContentType: "application/octet-stream", ExpiresAt: expiresAt}
+	if err := repo.Create(context.Background(), file); err != nil {
+		t.Fatalf("创建文件记录失败: %v", err)
+	}
+	return file
+}
+
+func storeHas(store filestore.Store, key string) bool {
+	rc, err := store.Open(context.Background(), key)
+	if err != nil {
+		return false
+	}
+	rc.Close()
+	return true
+}
+
+func TestFileCleanupDeletesExpiredFiles(t *testing.T) {
+	s, repo, store := newTestFileService(t)
+	ctx := context.Background()
+	past := time.Now().Add(-time.Minute)
+
+	// 超过一批的过期文件在一轮中全部删除
+	var expired []*models.StoredFile
+	for i := 0; i < fileCleanupBatch+20; i++ {
+		expired = append(expired, putFile(t, repo, store, fmt.Sprintf("exports/old/%03d.xlsx", i), past))
+	}
+	valid := putFile(t, repo, store, "exports/new/a.xlsx", time.Now().Add(time.Hour))
+
+	s.cleanup(ctx)
+
+	for _, file := range expired {
+		if got, _ := repo.GetByID(ctx, file.ID); got != nil {
+			t.Fatalf("过期文件%d的记录未删除", file.ID)
+		}
+		if storeHas(store, file.StorageKey) {
+			t.Fatalf("过期文件%s的内容未删除", file.StorageKey)
+		}
+	}
+	if got, _ := repo.GetByID(ctx, valid.ID); got == nil || !storeHas(store, valid.StorageKey) {
+		t.Fatal("未过期的文件被删除")
+	}
+}
+
+func TestFileCleanupRetriesFailedDeletes(t *testing.T) {
+	s, repo, store := newTestFileService(t)
+	ctx := context.Background()
+	past := time.Now().Add(-time.Minute)
+	stuck := putFile(t, repo, store, "exports/old/stuck.xlsx", past)
+	other := putFile(t, repo, store, "exports/old/other.xlsx", past)
+
+	// 内容删除失败时保留记录，否则内容成为无法清理的孤立文件
+	store.failDelete[stuck.StorageKey] = true
+	s.cleanup(ctx)
+	if got, _ := repo.GetByID(ctx, stuck.ID); got == nil {
+		t.Fatal("内容删除失败的文件记录被删除")
+	}
+	if got, _ := repo.GetByID(ctx, other.ID); got != nil || storeHas(store, other.StorageKey) {
+		t.Fatal("同一批中其他过期文件未删除")
+	}
+
+	store.failDelete[stuck.StorageKey] = false
+	s.cleanup(ctx)
+	if got, _ := repo.GetByID(ctx, stuck.ID); got != nil || storeHas(store, stuck.StorageKey) {
+		t.Fatal("下一轮清理未删除之前失败的文件")
+	}
+}
+
+func TestFileSaveRemovesOrphanedContent(t *testing.T) {
+	s, _, store := newTestFileService(t)
+	s.repo = failingFileRepository{}
+	root := store.Store.(*filestore.LocalStore).Root
+
+	_, err := s.Save(context.Background(), models.FileKindExport, "a.xlsx", strings.NewReader("内容"), -1)
+	var serviceErr *ServiceError
+	if !errors.As(err, &serviceErr) || serviceErr.Code != ErrCodeDatabase {
+		t.Fatalf("记录保存失败时返回%v，期望数据库错误", err)
+	}
+
+	var leftover []string
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			leftover = append(leftover, path)
+		}
+		return err
+	})
+	if err != nil || len(leftover) != 0 {
+		t.Fatalf("记录保存失败后文件内容未删除: %v, %v", leftover, err)
+	}
+}
+
+func TestFileDownloadLink(t *testing.T) {
+	s, _, _ := newTestFileService(t)
+	s.opts.LinkTTL = time.Hour
+
+	parse := func(link string) (uint, int64, string) {
+		u, err := url.Parse(link)
+		if err != nil {
+			t.Fatalf("无效的下载链接%s: %v", link, err)
+		}
+		id, _ := strconv.ParseUint(strings.TrimPrefix(u.Path, "/api/v1/files/"), 10, 64)
+		expires, _ := strconv.ParseInt(u.Query().Get("expires"), 10, 64)
+		return uint(id), expires, u.Query().Get("signature")
+	}
+
+	file := &models.StoredFile{BaseModel: models.BaseModel{ID: 7}, ExpiresAt: time.Now().Add(24 * time.Hour)}
+	link, expiresAt := s.DownloadURL(file)
+	id, expires, signature := parse(link)
+	if id != 7 || expires != expiresAt.Unix() {
+		t.Fatalf("下载链接为%s，过期时间%v", link, expiresAt)
+	}
+	if d := time.Until(expiresAt); d > time.Hour || d < 59*time.Minute {
+		t.Fatalf("链接有效期为%v，期望1小时", d)
+	}
+	if err := s.VerifyLink(id, expires, signature); err != nil {
+		t.Fatalf("校验有效链接失败: %v", err)
+	}
+
+	// 链接有效期不超过文件的过期时间
+	soon := &models.StoredFile{BaseModel: models.BaseModel{ID: 8}, ExpiresAt: time.Now().Add(10 * time.Minute)}
+	if _, expiresAt := s.DownloadURL(soon); expiresAt.Unix() != soon.ExpiresAt.Unix() {
+		t.Fatalf("链接过期时间为%v，期望与文件过期时间%v一致", expiresAt, soon.ExpiresAt)
+	}
+
+	tests := []struct {
+		name      string
+		id        uint
+		expires   int64
+		signature string
+	}{
+		{"缺少签名", id, expires, ""},
+		{"已过期", id, time.Now().Add(-time.Second).Unix(), filestore.Sign("link-secret", id, time.Now().Add(-time.Second).Unix())},
+		{"延长过期时间", id, expires + 3600, signature},
+		{"其他文件", id + 1, expires, signature},
+		{"其他密钥签发", id, expires, filestore.Sign("other-secret", id, expires)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.VerifyLink(tt.id, tt.expires, tt.signature)
+			var serviceErr *ServiceError
+			if !errors.As(err, &serviceErr) || serviceErr.Code != ErrCodeForbidden {
+				t.Fatalf("VerifyLink返回%v，期望禁止访问", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,877 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/yourusername/cloud-eye/internal/models"
+	"github.com/yourusername/cloud-eye/internal/pkg/evaluation"
+	"github.com/yourusername/cloud-eye/internal/pkg/excel"
+	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+	"github.com/yourusername/cloud-eye/internal/repository"
+	"go.uber.org/zap"
+)
+
+// 后台任务默认选项
+const (
+	DefaultJobWorkers      = 2
+	DefaultJobResultPath   = "./uploads/jobs"
+	DefaultJobPollInterval = time.Second
+	DefaultJobMaxAttempts  = 3
+	DefaultJobLease        = 30 * time.Second
+)
+
+// MaxJobEvaluationResources 单个评估任务的最大资源数，任务内按MaxEvaluationResources分批评估
+const MaxJobEvaluationResources = 50000
+
+// exportPageSize 导出任务每次加载的配置项数
+const exportPageSize = 100
+
+// maxJobError 任务错误信息的最大长度，与数据库列一致
+const maxJobError = 1000
+
+// maxJobOwner 实例标识的最大长度，与数据库列一致
+const maxJobOwner = 100
+
+// maxJobWarnings 任务保存的警告条数上限
+const maxJobWarnings = 100
+
+// errJobCanceled 任务执行中收到取消请求
+var errJobCanceled = errors.New("任务已取消")
+
+// JobOptions 后台任务执行选项，零值使用默认值
+type JobOptions struct {
+	Workers      int           // 并发执行的任务数
+	ResultPath   string        // 结果文件目录，每个任务使用以任务ID命名的子目录
+	PollInterval time.Duration // 检查等待执行任务的间隔
+	MaxAttempts  int           // 最大执行次数，服务重启等原因中断的任务重新执行时计数
+	// Lease 执行租约时长，执行中每隔三分之一租约续期一次；实例崩溃后任务在租约过期后由其他实例重新执行
+	Lease time.Duration
+	// Owner 当前实例的标识，记录在领取的任务中，为空时使用主机名和进程号
+	Owner string
+}
+
+// withDefaults 填充未设置的选项
+func (o JobOptions) withDefaults() JobOptions {
+	if o.Workers <= 0 {
+		o.Workers = DefaultJobWorkers
+	}
+	if o.ResultPath == "" {
+		o.ResultPath = DefaultJobResultPath
+	}
+	if o.PollInterval <= 0 {
+		o.PollInterval = DefaultJobPollInterval
+	}
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = DefaultJobMaxAttempts
+	}
+	if o.Lease <= 0 {
+		o.Lease = DefaultJobLease
+	}
+	if o.Owner == "" {
+		o.Owner = defaultJobOwner()
+	}
+	return o
+}
+
+// defaultJobOwner 生成实例标识：主机名、进程号和随机后缀，同一主机上重启的进程也不会重复
+func defaultJobOwner() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "cloudeye"
+	}
+	suffix := make([]byte, 4)
+	_, _ = rand.Read(suffix)
+	owner := fmt.Sprintf("%s-%d-%s", host, os.Getpid(), hex.EncodeToString(suffix))
+	if r := []rune(owner); len(r) > maxJobOwner {
+		owner = string(r[len(r)-maxJobOwner:])
+	}
+	return owner
+}
+
+// importJobParams 导入任务参数
+type importJobParams struct {
+	FileID   uint   `json:"file_id"` // 保存在文件存储中的上传文件，过期后由文件清理任务删除
+	FileName string `json:"file_name"`
+}
+
+// exportJobParams 导出任务参数
+type exportJobParams struct {
+	Filter  repository.ConfigItemFilter `json:"filter"`
+	GroupBy string                      `json:"group_by,omitempty"`
+}
+
+// evaluationJobParams 评估任务和评估报告任务参数
+type evaluationJobParams struct {
+	Resources []evaluation.Resource `json:"resources"`
+}
+
+// ImportJobResult 导入任务结果
+type ImportJobResult struct {
+	Count         int    `json:"count"`
+	ConfigItemIDs []uint `json:"config_item_ids"`
+}
+
+// ExportJobResult 导出任务结果
+type ExportJobResult struct {
+	Count int `json:"count"`
+}
+
+// jobOutcome 任务执行结果
+type jobOutcome struct {
+	Result   interface{} // 结果摘要
+	File     string      // 结果目录中的结果文件路径，任务成功后保存到文件存储
+	FileName string      // 结果文件的下载名称
+	FileID   uint        // 保存到文件存储后的文件ID
+	Warnings []string
+}
+
+// progressFunc 报告任务进度，任务被请求取消时返回errJobCanceled
+type progressFunc func(done, total int, message string) error
+
+// jobRunner 执行一种类型的任务，dir为任务的结果目录
+type jobRunner func(ctx context.Context, job *models.Job, dir string, progress progressFunc) (*jobOutcome, error)
+
+// JobService 后台任务服务接口：提交导入、导出、评估和评估报告任务，由worker在后台执行
+type JobService interface {
+	Service
+	// SubmitImport 提交导入任务，fileID为保存在文件存储中的上传文件
+	SubmitImport(ctx context.Context, fileID uint, fileName string) (*models.Job, error)
+	// SubmitExport 提交导出任务，导出全部符合条件的配置项，不受列表分页限制
+	SubmitExport(ctx context.Context, filter repository.ConfigItemFilter, groupBy string) (*models.Job, error)
+	// SubmitEvaluation 提交评估任务，结果为JSON评估报告
+	SubmitEvaluation(ctx context.Context, resources []evaluation.Resource) (*models.Job, error)
+	// SubmitReport 提交评估报告任务，结果为Excel评估报告
+	SubmitReport(ctx context.Context, resources []evaluation.Resource) (*models.Job, error)
+	GetJob(ctx context.Context, id uint) (*models.Job, error)
+	// ListJobs 按创建时间倒序分页查询任务
+	ListJobs(ctx context.Context, filter repository.JobFilter) (*repository.PageResult, error)
+	// CancelJob 取消任务，等待执行的任务立即取消，执行中的任务在当前步骤结束后取消
+	CancelJob(ctx context.Context, id uint) (*models.Job, error)
+	// ResultFile 打开执行成功的任务的结果文件，调用方负责关闭返回的内容
+	ResultFile(ctx context.Context, id uint) (*models.StoredFile, io.ReadCloser, error)
+	// Run 恢复租约过期的任务并运行worker，直到ctx取消后中断进行中的任务并放回队列
+	Run(ctx context.Context)
+}
+
+// jobService 后台任务服务实现
+type jobService struct {
+	BaseService
+	repo     repository.JobRepository
+	items    ConfigurationItemService
+	files    FileService
+	importer *excel.ConfigItemImporter
+	opts     JobOptions
+	runners  map[string]jobRunner
+	wake     chan struct{}
+	mu       sync.Mutex
+	inflight map[uint]context.CancelFunc
+}
+
+// NewJobService 创建后台任务服务
+func NewJobService(repo repository.JobRepository, items ConfigurationItemService, files FileService, opts JobOptions) JobService {
+	s := &jobService{
+		repo:     repo,
+		items:    items,
+		files:    files,
+		importer: excel.NewConfigItemImporter(),
+		opts:     opts.withDefaults(),
+		wake:     make(chan struct{}, 1),
+		inflight: make(map[uint]context.CancelFunc),
+	}
+	s.runners = map[string]jobRunner{
+		models.JobTypeImport:     s.runImport,
+		models.JobTypeExport:     s.runExport,
+		models.JobTypeEvaluation: s.runEvaluation,
+		models.JobTypeReport:     s.runReport,
+	}
+	return s
+}
+
+// SubmitImport 提交导入任务
+func (s *jobService) SubmitImport(ctx context.Context, fileID uint, fileName string) (*models.Job, error) {
+	ctx = WithContext(ctx)
+	logger.Info("Submitting import job", zap.Uint("fileID", fileID), zap.String("fileName", fileName))
+
+	return s.submit(ctx, models.JobTypeImport, importJobParams{FileID: fileID, FileName: fileName})
+}
+
+// SubmitExport 提交导出任务，提交时校验过滤条件
+func (s *jobService) SubmitExport(ctx context.Context, filter repository.ConfigItemFilter, groupBy string) (*models.Job, error) {
+	ctx = WithContext(ctx)
+	logger.Info("Submitting export job", zap.Any("filter", filter))
+
+	if groupBy != "" && groupBy != "category" {
+		return nil, NewServiceError(ErrCodeInvalidData, "不支持的分组维度: "+groupBy, nil)
+	}
+
+	// 导出需要服务商、产品、类别和标签名称，忽略字段选择、关联和分页参数
+	filter.Fields = nil
+	filter.Include = []string{"provider", "product.category", "tags"}
+	filter.Cursor = nil
+	filter.Page = 1
+	filter.PageSize = 1
+	if _, err := s.items.GetConfigItemsByFilter(ctx, filter); err != nil {
+		return nil, err
+	}
+	filter.PageSize = exportPageSize
+
+	return s.submit(ctx, models.JobTypeExport, exportJobParams{Filter: filter, GroupBy: groupBy})
+}
+
+// SubmitEvaluation 提交评估任务
+func (s *jobService) SubmitEvaluation(ctx context.Context, resources []evaluation.Resource) (*models.Job, error) {
+	ctx = WithContext(ctx)
+	logger.Info("Submitting evaluation job", zap.Int("count", len(resources)))
+
+	if err := validateJobResources(resources); err != nil {
+		return nil, err
+	}
+	return s.submit(ctx, models.JobTypeEvaluation, evaluationJobParams{Resources: resources})
+}
+
+// SubmitReport 提交评估报告任务
+func (s *jobService) SubmitReport(ctx context.Context, resources []evaluation.Resource) (*models.Job, error) {
+	ctx = WithContext(ctx)
+	logger.Info("Submitting report job", zap.Int("count", len(resources)))
+
+	if err := validateJobResources(resources); err != nil {
+		return nil, err
+	}
+	return s.submit(ctx, models.JobTypeReport, evaluationJobParams{Resources: resources})
+}
+
+// validateJobResources 校验评估任务的资源
+func validateJobResources(resources []evaluation.Resource) error {
+	if len(resources) == 0 {
+		return NewServiceError(ErrCodeInvalidData, "待评估的资源不能为空", nil)
+	}
+	if len(resources) > MaxJobEvaluationResources {
+		return NewServiceError(ErrCodeInvalidData, fmt.Sprintf("单个任务最多评估%d个资源", MaxJobEvaluationResources), nil)
+	}
+	for _, res := range resources {
+		if err := res.Validate(); err != nil {
+			return NewServiceError(ErrCodeInvalidData, err.Error(), nil)
+		}
+	}
+	return nil
+}
+
+// submit 保存任务并唤醒worker
+func (s *jobService) submit(ctx context.Context, jobType string, params interface{}) (*models.Job, error) {
+	data, err := json.Marshal(params)
+	if err != nil {
+		logger.Error("Failed to marshal job params", err, zap.String("type", jobType))
+		return nil, NewServiceError(ErrCodeInternal, "创建任务失败", err)
+	}
+
+	job := &models.Job{
+		Type:    jobType,
+		Status:  models.JobStatusQueued,
+		Params:  string(data),
+		Message: "等待执行",
+	}
+	if err := s.repo.Create(ctx, job); err != nil {
+		logger.Error("Failed to create job", err, zap.String("type", jobType))
+		return nil, NewServiceError(ErrCodeDatabase, "创建任务失败", err)
+	}
+
+	logger.Info("Job submitted", zap.Uint("id", job.ID), zap.String("type", jobType))
+	s.notify()
+	return job, nil
+}
+
+// GetJob 获取任务
+func (s *jobService) GetJob(ctx context.Context, id uint) (*models.Job, error) {
+	ctx = WithContext(ctx)
+
+	job, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		logger.Error("Failed to get job", err, zap.Uint("id", id))
+		return nil, NewServiceError(ErrCodeDatabase, "获取任务失败", err)
+	}
+	if job == nil {
+		return nil, NewServiceError(ErrCodeNotFound, "任务不存在", nil)
+	}
+	return job, nil
+}
+
+// ListJobs 分页查询任务
+func (s *jobService) ListJobs(ctx context.Context, filter repository.JobFilter) (*repository.PageResult, error) {
+	ctx = WithContext(ctx)
+	logger.Info("Listing jobs", zap.Any("filter", filter))
+
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.PageSize <= 0 {
+		filter.PageSize = 10
+	} else if filter.PageSize > 100 {
+		filter.PageSize = 100
+	}
+
+	result, err := s.repo.List(ctx, filter)
+	if err != nil {
+		logger.Error("Failed to list jobs", err)
+		return nil, NewServiceError(ErrCodeDatabase, "获取任务列表失败", err)
+	}
+	return result, nil
+}
+
+// CancelJob 取消任务
+func (s *jobService) CancelJob(ctx context.Context, id uint) (*models.Job, error) {
+	ctx = WithContext(ctx)
+	logger.Info("Canceling job", zap.Uint("id", id))
+
+	job, err := s.repo.RequestCancel(ctx, id)
+	if err != nil {
+		logger.Error("Failed to cancel job", err, zap.Uint("id", id))
+		return nil, NewServiceError(ErrCodeDatabase, "取消任务失败", err)
+	}
+	if job == nil {
+		return nil, NewServiceError(ErrCodeNotFound, "任务不存在", nil)
+	}
+	if job.Finished() && !job.CancelRequested {
+		return nil, NewServiceError(ErrCodeConflict, "任务已结束，不能取消", nil)
+	}
+
+	if job.Status == models.JobStatusCanceled {
+		s.cleanup(job)
+	}
+
+	// 本实例正在执行的任务立即中断，其他实例执行的任务在报告进度时检查取消标记
+	s.mu.Lock()
+	if cancel, ok := s.inflight[id]; ok {
+		cancel()
+	}
+	s.mu.Unlock()
+
+	return job, nil
+}
+
+// ResultFile 打开任务的结果文件，结果文件超过保留时长后被删除
+func (s *jobService) ResultFile(ctx context.Context, id uint) (*models.StoredFile, io.ReadCloser, error) {
+	job, err := s.GetJob(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if job.Status != models.JobStatusSucceeded {
+		return nil, nil, NewServiceError(ErrCodeConflict, "任务尚未成功完成", nil)
+	}
+	if job.ResultFileID == 0 {
+		return nil, nil, NewServiceError(ErrCodeNotFound, "任务没有结果文件", nil)
+	}
+	return s.files.Open(ctx, job.ResultFileID)
+}
+
+// notify 唤醒worker
+func (s *jobService) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run 运行worker
+func (s *jobService) Run(ctx context.Context) {
+	s.requeue(ctx)
+
+	logger.Info("Job worker started", zap.Int("workers", s.opts.Workers), zap.String("owner", s.opts.Owner))
+
+	ticker := time.NewTicker(s.opts.PollInterval)
+	defer ticker.Stop()
+	// 定期恢复其他实例崩溃后留下的租约过期任务
+	recovery := time.NewTicker(s.opts.Lease)
+	defer recovery.Stop()
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, s.opts.Workers)
+	for {
+		s.dispatch(ctx, slots, &wg)
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			logger.Info("Job worker stopped")
+			return
+		case <-s.wake:
+		case <-ticker.C:
+		case <-recovery.C:
+			s.requeue(ctx)
+		}
+	}
+}
+
+// requeue 将租约过期的执行中任务放回队列
+func (s *jobService) requeue(ctx context.Context) {
+	requeued, err := s.repo.Requeue(ctx)
+	if err != nil {
+		logger.Error("Failed to recover interrupted jobs", err)
+	} else if requeued > 0 {
+		logger.Info("Recovered interrupted jobs", zap.Int64("count", requeued))
+	}
+}
+
+// dispatch 领取等待执行的任务，在空闲的槽位中并发执行
+func (s *jobService) dispatch(ctx context.Context, slots chan struct{}, wg *sync.WaitGroup) {
+	free := cap(slots) - len(slots)
+	if free == 0 {
+		return
+	}
+
+	queued, err := s.repo.Queued(ctx, free)
+	if err != nil {
+		logger.Error("Failed to get queued jobs", err)
+		return
+	}
+
+	for i := range queued {
+		job := queued[i]
+		// 多次执行都被中断的任务可能导致服务异常退出，不再执行
+		if job.Attempts >= s.opts.MaxAttempts {
+			s.abandon(&job)
+			continue
+		}
+
+		select {
+		case slots <- struct{}{}:
+		default:
+			return
+		}
+
+		// 多个实例共享任务表时只有一个实例能领取成功
+		claimed, err := s.repo.Claim(ctx, job.ID, s.opts.Owner, s.opts.Lease)
+		if err != nil || !claimed {
+			<-slots
+			continue
+		}
+
+		jobCtx, cancel := context.WithCancel(ctx)
+		s.mu.Lock()
+		s.inflight[job.ID] = cancel
+		s.mu.Unlock()
+
+		wg.Add(1)
+		go func(id uint) {
+			defer wg.Done()
+			s.execute(jobCtx, id)
+
+			s.mu.Lock()
+			delete(s.inflight, id)
+			s.mu.Unlock()
+			cancel()
+			<-slots
+			s.notify()
+		}(job.ID)
+	}
+}
+
+// abandon 将超过最大执行次数的任务标记为失败
+func (s *jobService) abandon(job *models.Job) {
+	now := time.Now()
+	job.Status = models.JobStatusFailed
+	job.Error = fmt.Sprintf("任务已执行%d次均被中断，不再重试", job.Attempts)
+	job.FinishedAt = &now
+	if err := s.repo.Update(context.Background(), job); err != nil {
+		logger.Error("Failed to abandon job", err, zap.Uint("id", job.ID))
+		return
+	}
+	logger.Info("Job abandoned after max attempts", zap.Uint("id", job.ID), zap.Int("attempts", job.Attempts))
+	s.cleanup(job)
+}
+
+// execute 执行已领取的任务并保存结果
+func (s *jobService) execute(ctx context.Context, id uint) {
+	// 保存任务状态不受worker关闭影响
+	store := context.Background()
+
+	// 执行期间续期租约，租约丢失说明任务已被放回队列，停止执行
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var lost atomic.Bool
+	stop := make(chan struct{})
+	defer close(stop)
+	go s.heartbeat(id, stop, func() {
+		lost.Store(true)
+		cancel()
+	})
+
+	job, err := s.repo.GetByID(store, id)
+	if err != nil || job == nil {
+		logger.Error("Failed to load claimed job", err, zap.Uint("id", id))
+		return
+	}
+
+	runner, ok := s.runners[job.Type]
+	if !ok {
+		s.finish(job, nil, fmt.Errorf("不支持的任务类型: %s", job.Type))
+		return
+	}
+
+	// 重新执行前清除上次中断时留下的结果文件
+	dir := filepath.Join(s.opts.ResultPath, strconv.FormatUint(uint64(job.ID), 10))
+	if err := os.RemoveAll(dir); err != nil {
+		logger.Error("Failed to clear job result directory", err, zap.Uint("id", id))
+	}
+
+	logger.Info("Job started", zap.Uint("id", id), zap.String("type", job.Type), zap.Int("attempt", job.Attempts))
+	job.Progress, job.Total, job.Error, job.Warnings = 0, 0, "", nil
+
+	progress := func(done, total int, message string) error {
+		job.Progress, job.Total, job.Message = done, total, message
+		if err := s.repo.UpdateProgress(store, id, done, total, message); err != nil {
+			logger.Error("Failed to update job progress", err, zap.Uint("id", id))
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		current, err := s.repo.GetByID(store, id)
+		if err == nil && current != nil && current.CancelRequested {
+			return errJobCanceled
+		}
+		return nil
+	}
+
+	outcome, err := runner(ctx, job, dir, progress)
+	if err == nil && outcome != nil && outcome.File != "" {
+		err = s.storeResult(ctx, outcome)
+	}
+
+	if lost.Load() {
+		logger.Warn("Job lease lost, stopped without saving result", zap.Uint("id", id))
+		return
+	}
+	// worker关闭导致的中断将任务放回队列，由其他实例或下次启动时重新执行
+	if err != nil && ctx.Err() != nil && !s.cancelRequested(id) {
+		logger.Info("Job interrupted by shutdown", zap.Uint("id", id))
+		if err := s.repo.Release(store, id, s.opts.Owner); err != nil {
+			logger.Error("Failed to release interrupted job", err, zap.Uint("id", id))
+		}
+		return
+	}
+	s.finish(job, outcome, err)
+}
+
+// heartbeat 每隔三分之一租约续期一次，直到stop关闭；任务不再属于当前实例时调用lost
+func (s *jobService) heartbeat(id uint, stop <-chan struct{}, lost func()) {
+	ticker := time.NewTicker(s.opts.Lease / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+		renewed, err := s.repo.Heartbeat(context.Background(), id, s.opts.Owner, s.opts.Lease)
+		if err != nil {
+			// 数据库暂时不可用时下次重试，租约过期前恢复即可
+			logger.Error("Failed to renew job lease", err, zap.Uint("id", id))
+			continue
+		}
+		if !renewed {
+			lost()
+			return
+		}
+	}
+}
+
+// storeResult 将结果目录中的结果文件保存到文件存储
+func (s *jobService) storeResult(ctx context.Context, outcome *jobOutcome) error {
+	file, err := s.files.SaveLocal(ctx, models.FileKindExport, outcome.File, outcome.FileName)
+	if err != nil {
+		return fmt.Errorf("保存结果文件失败：%w", err)
+	}
+	outcome.FileID = file.ID
+	return nil
+}
+
+// cancelRequested 判断任务是否已被请求取消
+func (s *jobService) cancelRequested(id uint) bool {
+	job, err := s.repo.GetByID(context.Background(), id)
+	return err == nil && job != nil && job.CancelRequested
+}
+
+// finish 保存任务的最终状态和结果
+func (s *jobService) finish(job *models.Job, outcome *jobOutcome, runErr error) {
+	now := time.Now()
+	job.FinishedAt = &now
+	job.LeaseExpiresAt = nil
+
+	if outcome != nil {
+		job.Warnings = truncateWarnings(outcome.Warnings)
+	}
+
+	switch {
+	case runErr == nil:
+		job.Status = models.JobStatusSucceeded
+		job.Message = "执行成功"
+		if job.Total > 0 {
+			job.Progress = job.Total
+		}
+		if outcome != nil {
+			if outcome.Result != nil {
+				data, err := json.Marshal(outcome.Result)
+				if err != nil {
+					logger.Error("Failed to marshal job result", err, zap.Uint("id", job.ID))
+				}
+				job.Result = string(data)
+			}
+			job.ResultFileID, job.ResultName = outcome.FileID, outcome.FileName
+		}
+	case errors.Is(runErr, errJobCanceled) || errors.Is(runErr, context.Canceled) || s.cancelRequested(job.ID):
+		job.Status = models.JobStatusCanceled
+		job.Message = "已取消"
+	default:
+		job.Status = models.JobStatusFailed
+		job.Message = "执行失败"
+		job.Error = jobErrorMessage(runErr)
+	}
+
+	if err := s.repo.Update(context.Background(), job); err != nil {
+		logger.Error("Failed to save job result", err, zap.Uint("id", job.ID))
+		return
+	}
+	logger.Info("Job finished", zap.Uint("id", job.ID), zap.String("type", job.Type), zap.String("status", job.Status))
+	s.cleanup(job)
+}
+
+// cleanup 删除已结束的任务的结果目录，结果文件已保存到文件存储，导入任务的上传文件由文件清理任务删除
+func (s *jobService) cleanup(job *models.Job) {
+	dir := filepath.Join(s.opts.ResultPath, strconv.FormatUint(uint64(job.ID), 10))
+	if err := os.RemoveAll(dir); err != nil {
+		logger.Error("Failed to remove job result directory", err, zap.Uint("id", job.ID))
+	}
+}
+
+// jobErrorMessage 将执行错误转换为保存在任务中的错误信息
+func jobErrorMessage(err error) string {
+	msg := err.Error()
+	var serviceErr *ServiceError
+	if errors.As(err, &serviceErr) {
+		msg = serviceErr.Message
+	}
+	if r := []rune(msg); len(r) > maxJobError {
+		msg = string(r[:maxJobError])
+	}
+	return msg
+}
+
+// truncateWarnings 限制保存的警告条数
+func truncateWarnings(warnings []string) models.StringList {
+	if len(warnings) <= maxJobWarnings {
+		return warnings
+	}
+	truncated := append([]string{}, warnings[:maxJobWarnings-1]...)
+	return append(truncated, fmt.Sprintf("另有%d条警告未显示", len(warnings)-maxJobWarnings+1))
+}
+
+// decodeParams 解析任务参数
+func decodeParams(job *models.Job, params interface{}) error {
+	if err := json.Unmarshal([]byte(job.Params), params); err != nil {
+		return NewServiceError(ErrCodeInternal, "无效的任务参数", err)
+	}
+	return nil
+}
+
+// runImport 解析上传的Excel文件并批量导入配置项，所有记录在一个事务中导入
+func (s *jobService) runImport(ctx context.Context, job *models.Job, dir string, progress progressFunc) (*jobOutcome, error) {
+	var params importJobParams
+	if err := decodeParams(job, &params); err != nil {
+		return nil, err
+	}
+
+	if err := progress(0, 0, "解析Excel文件"); err != nil {
+		return nil, err
+	}
+	path, err := s.download(ctx, params.FileID, dir)
+	if err != nil {
+		return nil, err
+	}
+	items, warnings, err := s.importer.ParseConfigItems(ctx, path)
+	outcome := &jobOutcome{Warnings: warnings}
+	if err != nil {
+		return outcome, fmt.Errorf("解析Excel文件失败：%w", err)
+	}
+
+	if err := progress(0, len(items), "导入配置项"); err != nil {
+		return outcome, err
+	}
+	if err := s.items.BatchImportConfigItems(ctx, items); err != nil {
+		return outcome, err
+	}
+
+	result := ImportJobResult{Count: len(items), ConfigItemIDs: make([]uint, len(items))}
+	for i := range items {
+		result.ConfigItemIDs[i] = items[i].ID
+	}
+	outcome.Result = result
+	return outcome, progress(len(items), len(items), "导入完成")
+}
+
+// download 将文件存储中的上传文件复制到任务的结果目录，供按路径解析的Excel导入器读取
+func (s *jobService) download(ctx context.Context, fileID uint, dir string) (string, error) {
+	file, rc, err := s.files.Open(ctx, fileID)
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, "import"+filepath.Ext(file.Name))
+	out, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(out, rc); err != nil {
+		out.Close()
+		return "", err
+	}
+	return path, out.Close()
+}
+
+// runExport 按游标逐页加载符合条件的配置项并导出到Excel，加载期间新增或删除的配置项不会导致重复或遗漏
+func (s *jobService) runExport(ctx context.Context, job *models.Job, dir string, progress progressFunc) (*jobOutcome, error) {
+	var params exportJobParams
+	if err := decodeParams(job, &params); err != nil {
+		return nil, err
+	}
+
+	// 游标分页不统计总数，先查询一次总数用于显示进度
+	filter := params.Filter
+	filter.Page, filter.PageSize, filter.Cursor = 1, 1, nil
+	counted, err := s.items.GetConfigItemsByFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor := ""
+	filter.PageSize = exportPageSize
+	var items []models.ConfigurationItem
+	for {
+		filter.Cursor = &cursor
+		result, err := s.items.GetConfigItemsByFilter(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		page, _ := result.Data.([]models.ConfigurationItem)
+		items = append(items, page...)
+
+		total := int(counted.Total)
+		if total < len(items) {
+			total = len(items)
+		}
+		if err := progress(len(items), total, "加载配置项"); err != nil {
+			return nil, err
+		}
+		if result.NextCursor == "" {
+			break
+		}
+		cursor = result.NextCursor
+	}
+
+	if len(items) == 0 {
+		return nil, errors.New("没有符合条件的配置项")
+	}
+	if params.GroupBy == "category" {
+		excel.GroupByCategory(items)
+	}
+
+	if err := progress(len(items), len(items), "生成Excel文件"); err != nil {
+		return nil, err
+	}
+	exporter := &excel.ConfigItemExporter{ExportPath: dir}
+	path, err := exporter.Export(ctx, items)
+	if err != nil {
+		return nil, fmt.Errorf("导出Excel失败：%w", err)
+	}
+
+	return &jobOutcome{
+		Result:   ExportJobResult{Count: len(items)},
+		File:     path,
+		FileName: filepath.Base(path),
+	}, nil
+}
+
+// runEvaluation 评估资源配置并保存JSON评估报告
+func (s *jobService) runEvaluation(ctx context.Context, job *models.Job, dir string, progress progressFunc) (*jobOutcome, error) {
+	report, err := s.evaluate(ctx, job, progress)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	name := fmt.Sprintf("评估结果_%s.json", time.Now().Format("20060102150405"))
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return nil, err
+	}
+
+	return &jobOutcome{Result: report.Summary, File: path, FileName: name}, nil
+}
+
+// runReport 评估资源配置并生成Excel评估报告
+func (s *jobService) runReport(ctx context.Context, job *models.Job, dir string, progress progressFunc) (*jobOutcome, error) {
+	report, err := s.evaluate(ctx, job, progress)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := progress(job.Total, job.Total, "生成评估报告"); err != nil {
+		return nil, err
+	}
+	writer := &excel.ReportWriter{ReportPath: dir}
+	path, err := writer.Write(ctx, report)
+	if err != nil {
+		return nil, fmt.Errorf("生成评估报告失败：%w", err)
+	}
+
+	return &jobOutcome{Result: report.Summary, File: path, FileName: filepath.Base(path)}, nil
+}
+
+// evaluate 按MaxEvaluationResources分批评估任务中的资源并合并报告
+func (s *jobService) evaluate(ctx context.Context, job *models.Job, progress progressFunc) (*evaluation.Report, error) {
+	var params evaluationJobParams
+	if err := decodeParams(job, &params); err != nil {
+		return nil, err
+	}
+
+	total := len(params.Resources)
+	var reports []*evaluation.Report
+	for start := 0; start < total; start += MaxEvaluationResources {
+		if err := progress(start, total, "评估资源配置"); err != nil {
+			return nil, err
+		}
+		end := start + MaxEvaluationResources
+		if end > total {
+			end = total
+		}
+		report, err := s.items.EvaluateResources(ctx, params.Resources[start:end])
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+
+	if err := progress(total, total, "评估完成"); err != nil {
+		return nil, err
+	}
+	return evaluation.Merge(reports...), nil
+}
@@ -0,0 +1,318 @@
+package service
+
+import (
+	"context"
+
+	"github.com/yourusername/cloud-eye/internal/models"
+	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+	"github.com/yourusername/cloud-eye/internal/repository"
+	"go.uber.org/zap"
+)
+
+// ProductCategoryService 产品类别服务接口
+type ProductCategoryService interface {
+	Service
+	// GetCategoryTree 获取类别树，顶级类别的子类别填充在Children中
+	GetCategoryTree(ctx context.Context) ([]models.ProductCategory, error)
+	GetAllCategories(ctx context.Context) ([]models.ProductCategory, error)
+	GetCategoryByID(ctx context.Context, id uint) (*models.ProductCategory, error)
+	CreateCategory(ctx context.Context, category *models.ProductCategory) error
+	UpdateCategory(ctx context.Context, category *models.ProductCategory) error
+	DeleteCategory(ctx context.Context, id uint) error
+	AssignProducts(ctx context.Context, categoryID uint, productIDs []uint) error
+}
+
+// productCategoryService 产品类别服务实现
+type productCategoryService struct {
+	BaseService
+	repo        repository.ProductCategoryRepository
+	productRepo repository.CloudProductRepository
+	events      EventPublisher
+}
+
+// NewProductCategoryService 创建产品类别服务，产品的类别变化后通过events发布产品更新事件，events为nil时不发布
+func NewProductCategoryService(
+	repo repository.ProductCategoryRepository,
+	productRepo repository.CloudProductRepository,
+	events EventPublisher,
+) ProductCategoryService {
+	if events == nil {
+		events = noopPublisher{}
+	}
+	return &productCategoryService{
+		repo:        repo,
+		productRepo: productRepo,
+		events:      events,
+	}
+}
+
+// GetCategoryTree 获取类别树
+func (s *productCategoryService) GetCategoryTree(ctx context.Context) ([]models.ProductCategory, error) {
+	ctx = WithContext(ctx)
+	logger.Info("Getting product category tree")
+
+	categories, err := s.repo.GetAll(ctx)
+	if err != nil {
+		logger.Error("Failed to get all product categories", err)
+		return nil, NewServiceError(ErrCodeDatabase, "获取产品类别失败", err)
+	}
+
+	return buildCategoryTree(categories, nil), nil
+}
+
+// GetAllCategories 获取所有类别的平铺列表
+func (s *productCategoryService) GetAllCategories(ctx context.Context) ([]models.ProductCategory, error) {
+	ctx = WithContext(ctx)
+	logger.Info("Getting all product categories")
+
+	categories, err := s.repo.GetAll(ctx)
+	if err != nil {
+		logger.Error("Failed to get all product categories", err)
+		return nil, NewServiceError(ErrCodeDatabase, "获取产品类别失败", err)
+	}
+
+	return categories, nil
+}
+
+// GetCategoryByID 根据ID获取类别及其子类别树
+func (s *productCategoryService) GetCategoryByID(ctx context.Context, id uint) (*models.ProductCategory, error) {
+	ctx = WithContext(ctx)
+	logger.Info("Getting product category by ID", zap.Uint("id", id))
+
+	category, err := s.getCategory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := s.repo.GetAll(ctx)
+	if err != nil {
+		logger.Error("Failed to get all product categories", err)
+		return nil, NewServiceError(ErrCodeDatabase, "获取产品类别失败", err)
+	}
+	category.Children = buildCategoryTree(categories, &category.ID)
+
+	return category, nil
+}
+
+// CreateCategory 创建类别
+func (s *productCategoryService) CreateCategory(ctx context.Context, category *models.ProductCategory) error {
+	ctx = WithContext(ctx)
+	logger.Info("Creating product category", zap.String("name", category.Name), zap.String("code", category.Code))
+
+	existing, err := s.repo.GetByCode(ctx, category.Code)
+	if err != nil {
+		logger.Error("Failed to check product category code", err, zap.String("code", category.Code))
+		return NewServiceError(ErrCodeDatabase, "创建产品类别失败", err)
+	}
+
+	if existing != nil {
+		return NewServiceError(ErrCodeDuplicate, "产品类别代码已存在", nil)
+	}
+
+	if category.ParentID != nil {
+		if _, err := s.getCategory(ctx, *category.ParentID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.repo.Create(ctx, category); err != nil {
+		logger.Error("Failed to create product category", err)
+		return NewServiceError(ErrCodeDatabase, "创建产品类别失败", err)
+	}
+
+	return nil
+}
+
+// UpdateCategory 更新类别，不允许把类别移动到自身或其子类别之下
+func (s *productCategoryService) UpdateCategory(ctx context.Context, category *models.ProductCategory) error {
+	ctx = WithContext(ctx)
+	logger.Info("Updating product category", zap.Uint("id", category.ID))
+
+	existing, err := s.getCategory(ctx, category.ID)
+	if err != nil {
+		return err
+	}
+
+	// 如果更改了代码，检查新代码是否已存在
+	if category.Code != existing.Code {
+		codeCheck, err := s.repo.GetByCode(ctx, category.Code)
+		if err != nil {
+			logger.Error("Failed to check product category code", err, zap.String("code", category.Code))
+			return NewServiceError(ErrCodeDatabase, "更新产品类别失败", err)
+		}
+
+		if codeCheck != nil && codeCheck.ID != category.ID {
+			return NewServiceError(ErrCodeDuplicate, "产品类别代码已存在", nil)
+		}
+	}
+
+	if category.ParentID != nil {
+		if _, err := s.getCategory(ctx, *category.ParentID); err != nil {
+			return err
+		}
+
+		categories, err := s.repo.GetAll(ctx)
+		if err != nil {
+			logger.Error("Failed to get all product categories", err)
+			return NewServiceError(ErrCodeDatabase, "更新产品类别失败", err)
+		}
+		if isCategoryDescendant(categories, *category.ParentID, category.ID) {
+			return NewServiceError(ErrCodeInvalidData, "不能将类别移动到自身或其子类别之下", nil)
+		}
+	}
+
+	if err := s.repo.Update(ctx, category); err != nil {
+		logger.Error("Failed to update product category", err)
+		return NewServiceError(ErrCodeDatabase, "更新产品类别失败", err)
+	}
+
+	return nil
+}
+
+// DeleteCategory 删除类别，存在子类别时拒绝删除，类别下的产品变为未分类
+func (s *productCategoryService) DeleteCategory(ctx context.Context, id uint) error {
+	ctx = WithContext(ctx)
+	logger.Info("Deleting product category", zap.Uint("id", id))
+
+	if _, err := s.getCategory(ctx, id); err != nil {
+		return err
+	}
+
+	categories, err := s.repo.GetAll(ctx)
+	if err != nil {
+		logger.Error("Failed to get all product categories", err)
+		return NewServiceError(ErrCodeDatabase, "删除产品类别失败", err)
+	}
+	for _, c := range categories {
+		if c.ParentID != nil && *c.ParentID == id {
+			return NewServiceError(ErrCodeInvalidData, "该类别下存在子类别，请先删除或移动子类别", nil)
+		}
+	}
+
+	products, err := s.productRepo.List(ctx, repository.CloudProductFilter{CategoryIDs: []uint{id}})
+	if err != nil {
+		logger.Error("Failed to get category products", err, zap.Uint("id", id))
+		return NewServiceError(ErrCodeDatabase, "删除产品类别失败", err)
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		logger.Error("Failed to delete product category", err)
+		return NewServiceError(ErrCodeDatabase, "删除产品类别失败", err)
+	}
+
+	productIDs := make([]uint, 0, len(products))
+	for _, p := range products {
+		productIDs = append(productIDs, p.ID)
+	}
+	s.publishProductsUpdated(ctx, productIDs)
+	return nil
+}
+
+// AssignProducts 将产品归入类别，产品原有的类别会被替换
+func (s *productCategoryService) AssignProducts(ctx context.Context, categoryID uint, productIDs []uint) error {
+	ctx = WithContext(ctx)
+	logger.Info("Assigning products to category", zap.Uint("id", categoryID), zap.Uints("productIds", productIDs))
+
+	if len(productIDs) == 0 {
+		return NewServiceError(ErrCodeInvalidData, "产品ID列表不能为空", nil)
+	}
+
+	if _, err := s.getCategory(ctx, categoryID); err != nil {
+		return err
+	}
+
+	var changed []uint
+	for _, id := range productIDs {
+		product, err := s.productRepo.GetByID(ctx, id)
+		if err != nil {
+			logger.Error("Failed to check product existence", err, zap.Uint("id", id))
+			return NewServiceError(ErrCodeDatabase, "归类产品失败", err)
+		}
+
+		if product == nil {
+			return NewServiceError(ErrCodeNotFound, "云产品不存在", nil)
+		}
+		if product.CategoryID == nil || *product.CategoryID != categoryID {
+			changed = append(changed, id)
+		}
+	}
+
+	if err := s.repo.AssignProducts(ctx, productIDs, &categoryID); err != nil {
+		logger.Error("Failed to assign products to category", err)
+		return NewServiceError(ErrCodeDatabase, "归类产品失败", err)
+	}
+
+	// 已在该类别中的产品没有变化
+	s.publishProductsUpdated(ctx, changed)
+	return nil
+}
+
+// publishProductsUpdated 重新读取类别有变化的产品并逐条发布更新事件，读取失败只记录日志
+func (s *productCategoryService) publishProductsUpdated(ctx context.Context, productIDs []uint) {
+	var published []uint
+	for _, id := range productIDs {
+		if containsUint(published, id) {
+			continue
+		}
+		published = append(published, id)
+
+		product, err := s.productRepo.GetByID(ctx, id)
+		if err != nil {
+			logger.Error("Failed to load product for event", err, zap.Uint("id", id))
+			continue
+		}
+		if product == nil {
+			continue
+		}
+		s.events.Publish(ctx, models.EventProductUpdated, NewProductEventData(product))
+	}
+}
+
+// getCategory 获取类别，不存在时返回未找到错误
+func (s *productCategoryService) getCategory(ctx context.Context, id uint) (*models.ProductCategory, error) {
+	category, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		logger.Error("Failed to get product category by ID", err, zap.Uint("id", id))
+		return nil, NewServiceError(ErrCodeDatabase, "获取产品类别失败", err)
+	}
+
+	if category == nil {
+		return nil, NewServiceError(ErrCodeNotFound, "产品类别不存在", nil)
+	}
+
+	return category, nil
+}
+
+// buildCategoryTree 构造parentID下的类别子树，parentID为nil时返回顶级类别
+func buildCategoryTree(categories []models.ProductCategory, parentID *uint) []models.ProductCategory {
+	var tree []models.ProductCategory
+	for _, c := range categories {
+		if (parentID == nil && c.ParentID == nil) || (parentID != nil && c.ParentID != nil && *c.ParentID == *parentID) {
+			id := c.ID
+			c.Children = buildCategoryTree(categories, &id)
+			tree = append(tree, c)
+		}
+	}
+	return tree
+}
+
+// isCategoryDescendant 判断id是否为ancestorID本身或其子孙类别
+func isCategoryDescendant(categories []models.ProductCategory, id, ancestorID uint) bool {
+	parents := make(map[uint]*uint, len(categories))
+	for _, c := range categories {
+		parents[c.ID] = c.ParentID
+	}
+
+	// 以访问次数为上限，防止已有数据中存在环时死循环
+	for steps := 0; steps <= len(categories); steps++ {
+		if id == ancestorID {
+			return true
+		}
+		parent := parents[id]
+		if parent == nil {
+			return false
+		}
+		id = *parent
+	}
+	return true
+}
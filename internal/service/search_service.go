@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+
+	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+	"github.com/yourusername/cloud-eye/internal/pkg/search"
+	"github.com/yourusername/cloud-eye/internal/repository"
+	"go.uber.org/zap"
+)
+
+// SearchService 全文检索服务接口
+type SearchService interface {
+	Service
+	SearchConfigItems(ctx context.Context, query string, filter repository.ConfigItemSearchFilter) (*repository.PageResult, error)
+}
+
+// searchService 全文检索服务实现
+type searchService struct {
+	BaseService
+	repo repository.SearchRepository
+}
+
+// NewSearchService 创建全文检索服务
+func NewSearchService(repo repository.SearchRepository) SearchService {
+	return &searchService{
+		repo: repo,
+	}
+}
+
+// SearchConfigItems 检索配置项，query支持短语和字段作用域语法，详见search.Parse
+func (s *searchService) SearchConfigItems(ctx context.Context, query string, filter repository.ConfigItemSearchFilter) (*repository.PageResult, error) {
+	ctx = WithContext(ctx)
+	logger.Info("Searching configuration items", zap.String("query", query))
+
+	filter.Query = search.Parse(query)
+	if filter.Query.Empty() {
+		return nil, NewServiceError(ErrCodeInvalidData, "检索关键词不能为空", nil)
+	}
+
+	// 设置默认分页参数
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.PageSize <= 0 {
+		filter.PageSize = 10
+	} else if filter.PageSize > 100 {
+		filter.PageSize = 100
+	}
+
+	result, err := s.repo.SearchConfigItems(ctx, filter)
+	if err != nil {
+		logger.Error("Failed to search configuration items", err, zap.String("query", query))
+		return nil, NewServiceError(ErrCodeDatabase, "检索配置项失败", err)
+	}
+
+	return result, nil
+}
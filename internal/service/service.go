@@ -1,9 +1,12 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 
-	"github.com/yourusername/cloud-eye/internal/repository"
+	"github.com/yourusername/cloud-eye/internal/pkg/mergepatch"
 )
 
 // Service 定义了所有服务的通用接口
@@ -23,6 +26,8 @@ const (
 	ErrCodeDatabase    = 1003 // 数据库错误
 	ErrCodeDuplicate   = 1004 // 重复数据
 	ErrCodeInternal    = 1005 // 内部错误
+	ErrCodeConflict    = 1006 // 与现有数据冲突，例如存在依赖数据
+	ErrCodeForbidden   = 1007 // 无权访问，例如下载链接签名无效或已过期
 )
 
 // ServiceError 服务错误类型
@@ -55,4 +60,63 @@ func WithContext(ctx context.Context) context.Context {
 		return context.Background()
 	}
 	return ctx
-}
\ No newline at end of file
+}
+
+// patchReadOnlyFields 所有实体都不允许通过合并补丁修改的字段
+var patchReadOnlyFields = []string{"id", "created_at", "updated_at", "deleted_at", "tags"}
+
+// applyMergePatch 将JSON合并补丁（RFC 7396）应用到current，合并结果写入dst，返回补丁修改的列名。
+// 补丁中不允许出现只读字段和forbidden中的字段，未知字段视为错误
+func applyMergePatch(current interface{}, patch []byte, dst interface{}, forbidden ...string) ([]string, error) {
+	columns, err := mergepatch.Keys(patch)
+	if err != nil {
+		if errors.Is(err, mergepatch.ErrNotObject) {
+			return nil, err
+		}
+		return nil, errors.New("无效的合并补丁: " + err.Error())
+	}
+	if len(columns) == 0 {
+		return nil, errors.New("合并补丁中没有需要修改的字段")
+	}
+	for _, column := range columns {
+		if containsField(patchReadOnlyFields, column) || containsField(forbidden, column) {
+			return nil, errors.New("不能通过补丁修改字段: " + column)
+		}
+	}
+
+	doc, err := json.Marshal(current)
+	if err != nil {
+		return nil, err
+	}
+	merged, err := mergepatch.Apply(doc, patch)
+	if err != nil {
+		return nil, errors.New("无效的合并补丁: " + err.Error())
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(merged))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(dst); err != nil {
+		return nil, errors.New("无效的合并补丁: " + err.Error())
+	}
+	return columns, nil
+}
+
+// containsField 判断字段名是否在列表中
+func containsField(fields []string, field string) bool {
+	for _, f := range fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// containsUint 判断ID是否在列表中
+func containsUint(ids []uint, id uint) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/yourusername/cloud-eye/internal/models"
+	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+	"github.com/yourusername/cloud-eye/internal/repository"
+	"go.uber.org/zap"
+)
+
+// 周统计窗口
+const (
+	DefaultActivityWeeks = 12  // 未指定时间范围时统计最近12周
+	MaxActivityWeeks     = 104 // 最多统计两年
+)
+
+// 配置项分组统计维度
+const (
+	DimensionSeverity = "severity"
+	DimensionStatus   = "status"
+)
+
+// StatsOverview 仪表盘概览
+type StatsOverview struct {
+	Totals            *repository.StatsTotals     `json:"totals"`
+	Providers         []repository.ProviderStat   `json:"providers"`
+	Products          []repository.ProductStat    `json:"products"`
+	Categories        []repository.CategoryStat   `json:"categories"`
+	BySeverity        []repository.GroupCount     `json:"by_severity"`
+	ByStatus          []repository.GroupCount     `json:"by_status"`
+	WeeklyActivity    []repository.WeeklyActivity `json:"weekly_activity"`
+	UncoveredProducts []repository.ProductStat    `json:"uncovered_products"`
+}
+
+// StatsService 统计服务接口
+type StatsService interface {
+	Service
+	GetOverview(ctx context.Context) (*StatsOverview, error)
+	GetProviderStats(ctx context.Context) ([]repository.ProviderStat, error)
+	GetProductStats(ctx context.Context, providerID *uint) ([]repository.ProductStat, error)
+	GetCategoryStats(ctx context.Context) ([]repository.CategoryStat, error)
+	GetConfigItemBreakdown(ctx context.Context, dimension string) ([]repository.GroupCount, error)
+	GetWeeklyActivity(ctx context.Context, from, to *time.Time) ([]repository.WeeklyActivity, error)
+	GetUncoveredProducts(ctx context.Context) ([]repository.ProductStat, error)
+}
+
+// statsService 统计服务实现
+type statsService struct {
+	BaseService
+	repo repository.StatsRepository
+}
+
+// NewStatsService 创建统计服务
+func NewStatsService(repo repository.StatsRepository) StatsService {
+	return &statsService{
+		repo: repo,
+	}
+}
+
+// GetOverview 获取仪表盘概览，周统计使用默认时间窗口
+func (s *statsService) GetOverview(ctx context.Context) (*StatsOverview, error) {
+	ctx = WithContext(ctx)
+	logger.Info("Getting stats overview")
+
+	var overview StatsOverview
+	var err error
+
+	if overview.Totals, err = s.repo.Totals(ctx); err != nil {
+		logger.Error("Failed to get stats totals", err)
+		return nil, NewServiceError(ErrCodeDatabase, "获取统计数据失败", err)
+	}
+	if overview.Providers, err = s.GetProviderStats(ctx); err != nil {
+		return nil, err
+	}
+	if overview.Products, err = s.GetProductStats(ctx, nil); err != nil {
+		return nil, err
+	}
+	if overview.Categories, err = s.GetCategoryStats(ctx); err != nil {
+		return nil, err
+	}
+	if overview.BySeverity, err = s.GetConfigItemBreakdown(ctx, DimensionSeverity); err != nil {
+		return nil, err
+	}
+	if overview.ByStatus, err = s.GetConfigItemBreakdown(ctx, DimensionStatus); err != nil {
+		return nil, err
+	}
+	if overview.WeeklyActivity, err = s.GetWeeklyActivity(ctx, nil, nil); err != nil {
+		return nil, err
+	}
+	if overview.UncoveredProducts, err = s.GetUncoveredProducts(ctx); err != nil {
+		return nil, err
+	}
+
+	return &overview, nil
+}
+
+// GetProviderStats 获取各云服务商的产品数和配置项数
+func (s *statsService) GetProviderStats(ctx context.Context) ([]repository.ProviderStat, error) {
+	ctx = WithContext(ctx)
+	logger.Info("Getting provider stats")
+
+	stats, err := s.repo.ProviderStats(ctx)
+	if err != nil {
+		logger.Error("Failed to get provider stats", err)
+		return nil, NewServiceError(ErrCodeDatabase, "获取云服务商统计失败", err)
+	}
+	return stats, nil
+}
+
+// GetProductStats 获取各产品的配置项数
+func (s *statsService) GetProductStats(ctx context.Context, providerID *uint) ([]repository.ProductStat, error) {
+	ctx = WithContext(ctx)
+	logger.Info("Getting product stats")
+
+	stats, err := s.repo.ProductStats(ctx, providerID)
+	if err != nil {
+		logger.Error("Failed to get product stats", err)
+		return nil, NewServiceError(ErrCodeDatabase, "获取云产品统计失败", err)
+	}
+	return stats, nil
+}
+
+// GetCategoryStats 获取各产品类别的产品数和配置项数，并汇总子孙类别的合计
+func (s *statsService) GetCategoryStats(ctx context.Context) ([]repository.CategoryStat, error) {
+	ctx = WithContext(ctx)
+	logger.Info("Getting category stats")
+
+	stats, err := s.repo.CategoryStats(ctx)
+	if err != nil {
+		logger.Error("Failed to get category stats", err)
+		return nil, NewServiceError(ErrCodeDatabase, "获取产品类别统计失败", err)
+	}
+
+	// 将每个类别的直接计数累加到自身及所有祖先类别
+	index := make(map[uint]int, len(stats))
+	for i, stat := range stats {
+		index[stat.CategoryID] = i
+	}
+	for _, stat := range stats {
+		id := stat.CategoryID
+		for steps := 0; steps <= len(stats); steps++ {
+			i, ok := index[id]
+			if !ok {
+				break
+			}
+			stats[i].TotalProductCount += stat.ProductCount
+			stats[i].TotalConfigItemCount += stat.ConfigItemCount
+			if stats[i].ParentID == nil {
+				break
+			}
+			id = *stats[i].ParentID
+		}
+	}
+	return stats, nil
+}
+
+// GetConfigItemBreakdown 按风险等级或状态统计配置项数量，
+// 结果按预定义顺序返回并包含数量为0的取值，便于前端直接绘图
+func (s *statsService) GetConfigItemBreakdown(ctx context.Context, dimension string) ([]repository.GroupCount, error) {
+	ctx = WithContext(ctx)
+	logger.Info("Getting configuration item breakdown", zap.String("dimension", dimension))
+
+	var keys []string
+	switch dimension {
+	case DimensionSeverity:
+		keys = models.Severities
+	case DimensionStatus:
+		keys = models.Statuses
+	default:
+		return nil, NewServiceError(ErrCodeInvalidData, "不支持的统计维度: "+dimension, nil)
+	}
+
+	counts, err := s.repo.ConfigItemCounts(ctx, dimension)
+	if err != nil {
+		logger.Error("Failed to count configuration items", err, zap.String("dimension", dimension))
+		return nil, NewServiceError(ErrCodeDatabase, "获取配置项统计失败", err)
+	}
+
+	byKey := make(map[string]int64, len(counts))
+	for _, c := range counts {
+		byKey[c.Key] = c.Count
+	}
+
+	groups := make([]repository.GroupCount, 0, len(keys))
+	for _, k := range keys {
+		groups = append(groups, repository.GroupCount{Key: k, Count: byKey[k]})
+		delete(byKey, k)
+	}
+	// 历史数据中的未知取值追加在末尾
+	for _, c := range counts {
+		if _, ok := byKey[c.Key]; ok {
+			groups = append(groups, c)
+		}
+	}
+	return groups, nil
+}
+
+// GetWeeklyActivity 获取时间窗口内每周新增和更新的配置项数量，
+// from按所在周的周一对齐，未指定时默认统计最近DefaultActivityWeeks周，没有数据的周计为0
+func (s *statsService) GetWeeklyActivity(ctx context.Context, from, to *time.Time) ([]repository.WeeklyActivity, error) {
+	ctx = WithContext(ctx)
+
+	end := time.Now()
+	if to != nil {
+		end = *to
+	}
+	start := repository.WeekStart(end).AddDate(0, 0, -7*(DefaultActivityWeeks-1))
+	if from != nil {
+		start = repository.WeekStart(*from)
+	}
+
+	if !start.Before(end) {
+		return nil, NewServiceError(ErrCodeInvalidData, "统计开始时间必须早于结束时间", nil)
+	}
+	if end.Sub(start) > time.Duration(MaxActivityWeeks)*7*24*time.Hour {
+		return nil, NewServiceError(ErrCodeInvalidData, "统计时间范围不能超过104周", nil)
+	}
+
+	logger.Info("Getting weekly activity", zap.Time("from", start), zap.Time("to", end))
+
+	activity, err := s.repo.WeeklyActivity(ctx, start, end)
+	if err != nil {
+		logger.Error("Failed to get weekly activity", err)
+		return nil, NewServiceError(ErrCodeDatabase, "获取配置项变更统计失败", err)
+	}
+
+	byWeek := make(map[string]repository.WeeklyActivity, len(activity))
+	for _, a := range activity {
+		byWeek[a.WeekStart.Format("2006-01-02")] = a
+	}
+
+	var series []repository.WeeklyActivity
+	for week := start; week.Before(end); week = week.AddDate(0, 0, 7) {
+		a := byWeek[week.Format("2006-01-02")]
+		a.WeekStart = week
+		series = append(series, a)
+	}
+	return series, nil
+}
+
+// GetUncoveredProducts 获取尚无配置基线的产品（覆盖缺口）
+func (s *statsService) GetUncoveredProducts(ctx context.Context) ([]repository.ProductStat, error) {
+	ctx = WithContext(ctx)
+	logger.Info("Getting uncovered products")
+
+	products, err := s.repo.UncoveredProducts(ctx)
+	if err != nil {
+		logger.Error("Failed to get uncovered products", err)
+		return nil, NewServiceError(ErrCodeDatabase, "获取覆盖缺口统计失败", err)
+	}
+	return products, nil
+}
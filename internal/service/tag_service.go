@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/yourusername/cloud-eye/internal/models"
+	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+	"github.com/yourusername/cloud-eye/internal/repository"
+	"go.uber.org/zap"
+)
+
+// 标签限制
+const (
+	MaxTagNameLength   = 50 // 与tags.name列长度一致
+	DefaultSuggestSize = 10
+	MaxSuggestSize     = 50
+)
+
+// tagTargetNames 可打标签实体的显示名称
+var tagTargetNames = map[repository.TagTarget]string{
+	repository.TagTargetProvider:   "云服务商",
+	repository.TagTargetProduct:    "云产品",
+	repository.TagTargetConfigItem: "配置项",
+}
+
+// TagService 标签服务接口
+type TagService interface {
+	Service
+	GetAllTags(ctx context.Context) ([]models.Tag, error)
+	// SuggestTags 标签自动补全，按前缀匹配并按使用次数排序
+	SuggestTags(ctx context.Context, prefix string, limit int) ([]models.Tag, error)
+	DeleteTag(ctx context.Context, id uint) error
+	// AttachTags 为多个实体批量添加标签，不存在的标签自动创建
+	AttachTags(ctx context.Context, target repository.TagTarget, ids []uint, names []string) error
+	// DetachTags 批量移除多个实体的标签
+	DetachTags(ctx context.Context, target repository.TagTarget, ids []uint, names []string) error
+}
+
+// tagService 标签服务实现
+type tagService struct {
+	BaseService
+	repo   repository.TagRepository
+	events EventPublisher
+}
+
+// NewTagService 创建标签服务，实体的标签变化后通过events发布更新事件，events为nil时不发布
+func NewTagService(repo repository.TagRepository, events EventPublisher) TagService {
+	if events == nil {
+		events = noopPublisher{}
+	}
+	return &tagService{
+		repo:   repo,
+		events: events,
+	}
+}
+
+// GetAllTags 获取所有标签及其使用次数
+func (s *tagService) GetAllTags(ctx context.Context) ([]models.Tag, error) {
+	ctx = WithContext(ctx)
+	logger.Info("Getting all tags")
+
+	tags, err := s.repo.GetAll(ctx)
+	if err != nil {
+		logger.Error("Failed to get all tags", err)
+		return nil, NewServiceError(ErrCodeDatabase, "获取标签列表失败", err)
+	}
+
+	return tags, nil
+}
+
+// SuggestTags 标签自动补全
+func (s *tagService) SuggestTags(ctx context.Context, prefix string, limit int) ([]models.Tag, error) {
+	ctx = WithContext(ctx)
+	logger.Info("Suggesting tags", zap.String("prefix", prefix), zap.Int("limit", limit))
+
+	if limit <= 0 {
+		limit = DefaultSuggestSize
+	}
+	if limit > MaxSuggestSize {
+		limit = MaxSuggestSize
+	}
+
+	tags, err := s.repo.Suggest(ctx, strings.TrimSpace(prefix), limit)
+	if err != nil {
+		logger.Error("Failed to suggest tags", err)
+		return nil, NewServiceError(ErrCodeDatabase, "获取标签建议失败", err)
+	}
+
+	return tags, nil
+}
+
+// DeleteTag 删除标签，同时移除其在所有实体上的关联
+func (s *tagService) DeleteTag(ctx context.Context, id uint) error {
+	ctx = WithContext(ctx)
+	logger.Info("Deleting tag", zap.Uint("id", id))
+
+	tag, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		logger.Error("Failed to check tag existence", err, zap.Uint("id", id))
+		return NewServiceError(ErrCodeDatabase, "删除标签失败", err)
+	}
+
+	if tag == nil {
+		return NewServiceError(ErrCodeNotFound, "标签不存在", nil)
+	}
+
+	records, err := s.repo.Delete(ctx, id)
+	if err != nil {
+		logger.Error("Failed to delete tag", err)
+		return NewServiceError(ErrCodeDatabase, "删除标签失败", err)
+	}
+
+	publishTagsChanged(ctx, s.events, records)
+	return nil
+}
+
+// AttachTags 为多个实体批量添加标签
+func (s *tagService) AttachTags(ctx context.Context, target repository.TagTarget, ids []uint, names []string) error {
+	ctx = WithContext(ctx)
+	logger.Info("Attaching tags", zap.String("target", string(target)), zap.Uints("ids", ids), zap.Strings("tags", names))
+
+	names, err := s.checkBulkRequest(ctx, target, ids, names)
+	if err != nil {
+		return err
+	}
+
+	records, err := s.repo.Attach(ctx, target, ids, names)
+	if err != nil {
+		logger.Error("Failed to attach tags", err)
+		return NewServiceError(ErrCodeDatabase, "添加标签失败", err)
+	}
+
+	publishTagsChanged(ctx, s.events, records)
+	return nil
+}
+
+// DetachTags 批量移除多个实体的标签
+func (s *tagService) DetachTags(ctx context.Context, target repository.TagTarget, ids []uint, names []string) error {
+	ctx = WithContext(ctx)
+	logger.Info("Detaching tags", zap.String("target", string(target)), zap.Uints("ids", ids), zap.Strings("tags", names))
+
+	names, err := s.checkBulkRequest(ctx, target, ids, names)
+	if err != nil {
+		return err
+	}
+
+	records, err := s.repo.Detach(ctx, target, ids, names)
+	if err != nil {
+		logger.Error("Failed to detach tags", err)
+		return NewServiceError(ErrCodeDatabase, "移除标签失败", err)
+	}
+
+	publishTagsChanged(ctx, s.events, records)
+	return nil
+}
+
+// publishTagsChanged 为标签有变化的每个实体发布更新事件
+func publishTagsChanged(ctx context.Context, events EventPublisher, records *repository.CascadeRecords) {
+	for i := range records.Providers {
+		events.Publish(ctx, models.EventProviderUpdated, NewProviderEventData(&records.Providers[i]))
+	}
+	for i := range records.Products {
+		events.Publish(ctx, models.EventProductUpdated, NewProductEventData(&records.Products[i]))
+	}
+	for i := range records.ConfigItems {
+		events.Publish(ctx, models.EventConfigItemUpdated, NewConfigItemEventData(&records.ConfigItems[i]))
+	}
+}
+
+// checkBulkRequest 校验批量标签操作的实体类型、实体ID和标签名称，返回规范化后的标签名称
+func (s *tagService) checkBulkRequest(ctx context.Context, target repository.TagTarget, ids []uint, names []string) ([]string, error) {
+	if !target.Valid() {
+		return nil, NewServiceError(ErrCodeInvalidData, "不支持的标签对象: "+string(target), nil)
+	}
+
+	if len(ids) == 0 {
+		return nil, NewServiceError(ErrCodeInvalidData, "ID列表不能为空", nil)
+	}
+
+	names, msg := normalizeTagNames(names)
+	if msg != "" {
+		return nil, NewServiceError(ErrCodeInvalidData, msg, nil)
+	}
+	if len(names) == 0 {
+		return nil, NewServiceError(ErrCodeInvalidData, "标签列表不能为空", nil)
+	}
+
+	missing, err := s.repo.MissingTargets(ctx, target, ids)
+	if err != nil {
+		logger.Error("Failed to check tag targets", err)
+		return nil, NewServiceError(ErrCodeDatabase, "校验标签对象失败", err)
+	}
+	if len(missing) > 0 {
+		return nil, NewServiceError(ErrCodeNotFound, fmt.Sprintf("%s不存在: %v", tagTargetNames[target], missing), nil)
+	}
+
+	return names, nil
+}
+
+// normalizeTagNames 去除标签名称首尾空白、空值和重复值，校验失败时返回错误信息
+func normalizeTagNames(names []string) ([]string, string) {
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" || containsTagName(normalized, name) {
+			continue
+		}
+		if utf8.RuneCountInString(name) > MaxTagNameLength {
+			return nil, fmt.Sprintf("标签名称不能超过%d个字符: %s", MaxTagNameLength, name)
+		}
+		// 逗号用于多值查询参数和Excel标签列的分隔
+		if strings.ContainsAny(name, ",，") {
+			return nil, "标签名称不能包含逗号: " + name
+		}
+		normalized = append(normalized, name)
+	}
+	return normalized, ""
+}
+
+// containsTagName 判断标签名称是否已在列表中
+func containsTagName(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
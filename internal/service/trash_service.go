@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+
+	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+	"github.com/yourusername/cloud-eye/internal/repository"
+	"go.uber.org/zap"
+)
+
+// trashTargetNames 回收站中实体的显示名称
+var trashTargetNames = map[repository.TrashTarget]string{
+	repository.TrashTargetProvider:   "云服务商",
+	repository.TrashTargetProduct:    "云产品",
+	repository.TrashTargetConfigItem: "配置项",
+}
+
+// TrashService 回收站服务接口
+type TrashService interface {
+	Service
+	// ListTrash 按删除时间倒序列出回收站中的实体
+	ListTrash(ctx context.Context, filter repository.TrashFilter) (*repository.PageResult, error)
+	// Restore 恢复实体及与其一同删除的下级记录，上级仍在回收站中时拒绝恢复
+	Restore(ctx context.Context, target repository.TrashTarget, id uint) error
+	// Purge 彻底删除回收站中的实体及其下级记录，不可恢复
+	Purge(ctx context.Context, target repository.TrashTarget, id uint) error
+}
+
+// trashService 回收站服务实现
+type trashService struct {
+	BaseService
+	repo   repository.TrashRepository
+	events EventPublisher
+}
+
+// NewTrashService 创建回收站服务，恢复记录后通过events发布事件，events为nil时不发布
+func NewTrashService(repo repository.TrashRepository, events EventPublisher) TrashService {
+	if events == nil {
+		events = noopPublisher{}
+	}
+	return &trashService{
+		repo:   repo,
+		events: events,
+	}
+}
+
+// ListTrash 列出回收站中的实体
+func (s *trashService) ListTrash(ctx context.Context, filter repository.TrashFilter) (*repository.PageResult, error) {
+	ctx = WithContext(ctx)
+	logger.Info("Listing trash", zap.String("target", string(filter.Target)))
+
+	if !filter.Target.Valid() {
+		return nil, NewServiceError(ErrCodeInvalidData, "不支持的回收站对象: "+string(filter.Target), nil)
+	}
+
+	result, err := s.repo.List(ctx, filter)
+	if err != nil {
+		logger.Error("Failed to list trash", err)
+		return nil, NewServiceError(ErrCodeDatabase, "获取回收站列表失败", err)
+	}
+
+	return result, nil
+}
+
+// Restore 从回收站恢复实体
+func (s *trashService) Restore(ctx context.Context, target repository.TrashTarget, id uint) error {
+	ctx = WithContext(ctx)
+	logger.Info("Restoring from trash", zap.String("target", string(target)), zap.Uint("id", id))
+
+	if err := s.checkInTrash(ctx, target, id, "恢复"); err != nil {
+		return err
+	}
+
+	parentDeleted, err := s.repo.ParentDeleted(ctx, target, id)
+	if err != nil {
+		logger.Error("Failed to check parent in trash", err, zap.Uint("id", id))
+		return NewServiceError(ErrCodeDatabase, "恢复"+trashTargetNames[target]+"失败", err)
+	}
+	if parentDeleted {
+		parent := "云服务商"
+		if target == repository.TrashTargetConfigItem {
+			parent = "云产品"
+		}
+		return NewServiceError(ErrCodeConflict, "所属"+parent+"在回收站中，请先恢复"+parent, nil)
+	}
+
+	records, err := s.repo.Restore(ctx, target, id)
+	if err != nil {
+		logger.Error("Failed to restore from trash", err)
+		return NewServiceError(ErrCodeDatabase, "恢复"+trashTargetNames[target]+"失败", err)
+	}
+
+	// 恢复的记录对订阅方而言重新出现，逐条发布创建事件
+	publishCascadeRestored(ctx, s.events, records)
+	return nil
+}
+
+// Purge 彻底删除回收站中的实体
+func (s *trashService) Purge(ctx context.Context, target repository.TrashTarget, id uint) error {
+	ctx = WithContext(ctx)
+	logger.Info("Purging from trash", zap.String("target", string(target)), zap.Uint("id", id))
+
+	if err := s.checkInTrash(ctx, target, id, "彻底删除"); err != nil {
+		return err
+	}
+
+	if err := s.repo.Purge(ctx, target, id); err != nil {
+		logger.Error("Failed to purge from trash", err)
+		return NewServiceError(ErrCodeDatabase, "彻底删除"+trashTargetNames[target]+"失败", err)
+	}
+
+	return nil
+}
+
+// checkInTrash 校验实体类型，并检查实体是否在回收站中
+func (s *trashService) checkInTrash(ctx context.Context, target repository.TrashTarget, id uint, action string) error {
+	if !target.Valid() {
+		return NewServiceError(ErrCodeInvalidData, "不支持的回收站对象: "+string(target), nil)
+	}
+
+	deletedAt, err := s.repo.GetDeletedAt(ctx, target, id)
+	if err != nil {
+		logger.Error("Failed to check trash", err, zap.Uint("id", id))
+		return NewServiceError(ErrCodeDatabase, action+trashTargetNames[target]+"失败", err)
+	}
+	if deletedAt == nil {
+		return NewServiceError(ErrCodeNotFound, "回收站中不存在该"+trashTargetNames[target], nil)
+	}
+
+	return nil
+}
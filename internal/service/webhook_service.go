@@ -0,0 +1,638 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/yourusername/cloud-eye/internal/models"
+	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+	"github.com/yourusername/cloud-eye/internal/pkg/webhook"
+	"github.com/yourusername/cloud-eye/internal/repository"
+	"go.uber.org/zap"
+)
+
+// Webhook默认投递选项
+const (
+	DefaultWebhookMaxAttempts  = 6
+	DefaultWebhookRetryBackoff = 30 * time.Second
+	DefaultWebhookMaxBackoff   = time.Hour
+	DefaultWebhookTimeout      = 10 * time.Second
+	DefaultWebhookWorkers      = 4
+	DefaultWebhookPollInterval = time.Second
+)
+
+// minWebhookSecretLength 自定义签名密钥的最小长度
+const minWebhookSecretLength = 16
+
+// maxDeliveryError 投递记录中错误信息的最大长度，与数据库列一致
+const maxDeliveryError = 500
+
+// deliveryLeaseTimeouts 投递租约为单次请求超时时间的倍数，覆盖查询订阅、发送请求和保存结果的时间
+const deliveryLeaseTimeouts = 3
+
+// EventPublisher 发布数据变更事件，发布失败只记录日志，不影响触发事件的操作
+type EventPublisher interface {
+	Publish(ctx context.Context, event string, data interface{})
+}
+
+// noopPublisher 不发布事件
+type noopPublisher struct{}
+
+func (noopPublisher) Publish(context.Context, string, interface{}) {}
+
+// multiPublisher 依次向多个发布者发布事件
+type multiPublisher []EventPublisher
+
+// NewMultiPublisher 创建向多个发布者依次发布事件的发布者
+func NewMultiPublisher(publishers ...EventPublisher) EventPublisher {
+	return multiPublisher(publishers)
+}
+
+func (m multiPublisher) Publish(ctx context.Context, event string, data interface{}) {
+	for _, p := range m {
+		p.Publish(ctx, event, data)
+	}
+}
+
+// WebhookEvent 推送给订阅方的请求体
+type WebhookEvent struct {
+	ID        string      `json:"id"`    // 事件ID，重新投递时不变
+	Event     string      `json:"event"` // 事件类型
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"` // 配置项事件为变更后（删除时为删除前）的配置项
+}
+
+// ConfigItemEventData 配置项事件的数据，字段与REST接口的配置项响应一致，标签为名称列表
+type ConfigItemEventData struct {
+	ID                  uint      `json:"id"`
+	CloudProviderID     uint      `json:"cloud_provider_id"`
+	ProductID           uint      `json:"product_id"`
+	Name                string    `json:"name"`
+	RecommendedValue    string    `json:"recommended_value"`
+	RiskDescription     string    `json:"risk_description"`
+	CheckMethod         string    `json:"check_method"`
+	ConfigurationMethod string    `json:"configuration_method"`
+	Reference           string    `json:"reference"`
+	Severity            string    `json:"severity"`
+	Status              string    `json:"status"`
+	ControlFamilyID     *uint     `json:"control_family_id"`
+	Tags                []string  `json:"tags"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+// NewConfigItemEventData 由配置项生成事件数据
+func NewConfigItemEventData(item *models.ConfigurationItem) *ConfigItemEventData {
+	return &ConfigItemEventData{
+		ID:                  item.ID,
+		CloudProviderID:     item.CloudProviderID,
+		ProductID:           item.ProductID,
+		Name:                item.Name,
+		RecommendedValue:    item.RecommendedValue,
+		RiskDescription:     item.RiskDescription,
+		CheckMethod:         item.CheckMethod,
+		ConfigurationMethod: item.ConfigurationMethod,
+		Reference:           item.Reference,
+		Severity:            item.Severity,
+		Status:              item.Status,
+		ControlFamilyID:     item.ControlFamilyID,
+		Tags:                models.TagNames(item.Tags),
+		CreatedAt:           item.CreatedAt,
+		UpdatedAt:           item.UpdatedAt,
+	}
+}
+
+// ImportCompletedEvent import.completed事件的数据
+type ImportCompletedEvent struct {
+	Count         int    `json:"count"`
+	ConfigItemIDs []uint `json:"config_item_ids"`
+}
+
+// WebhookOptions Webhook投递选项，零值使用默认值
+type WebhookOptions struct {
+	MaxAttempts  int           // 最大投递次数（含首次）
+	RetryBackoff time.Duration // 首次重试间隔，之后每次翻倍
+	MaxBackoff   time.Duration // 重试间隔上限
+	Timeout      time.Duration // 单次请求超时时间
+	Workers      int           // 并发投递数
+	PollInterval time.Duration // 检查到期重试的间隔
+	Client       *http.Client  // 发送请求的HTTP客户端，为空时按Timeout创建
+}
+
+// withDefaults 填充未设置的选项
+func (o WebhookOptions) withDefaults() WebhookOptions {
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = DefaultWebhookMaxAttempts
+	}
+	if o.RetryBackoff <= 0 {
+		o.RetryBackoff = DefaultWebhookRetryBackoff
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = DefaultWebhookMaxBackoff
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = DefaultWebhookTimeout
+	}
+	if o.Workers <= 0 {
+		o.Workers = DefaultWebhookWorkers
+	}
+	if o.PollInterval <= 0 {
+		o.PollInterval = DefaultWebhookPollInterval
+	}
+	return o
+}
+
+// WebhookService Webhook订阅和投递服务接口
+type WebhookService interface {
+	Service
+	EventPublisher
+	GetAllWebhooks(ctx context.Context) ([]models.WebhookSubscription, error)
+	GetWebhookByID(ctx context.Context, id uint) (*models.WebhookSubscription, error)
+	// CreateWebhook 创建订阅，未提供签名密钥时自动生成
+	CreateWebhook(ctx context.Context, subscription *models.WebhookSubscription) error
+	// UpdateWebhook 更新订阅，未提供签名密钥时保留原密钥
+	UpdateWebhook(ctx context.Context, subscription *models.WebhookSubscription) error
+	// DeleteWebhook 删除订阅及其投递记录
+	DeleteWebhook(ctx context.Context, id uint) error
+	// ListDeliveries 按时间倒序分页查询订阅的投递记录
+	ListDeliveries(ctx context.Context, filter repository.DeliveryFilter) (*repository.PageResult, error)
+	// GetDelivery 获取投递记录详情，包含请求体
+	GetDelivery(ctx context.Context, subscriptionID, deliveryID uint) (*models.WebhookDelivery, error)
+	// Redeliver 以原请求体创建新的投递记录并立即投递
+	Redeliver(ctx context.Context, subscriptionID, deliveryID uint) (*models.WebhookDelivery, error)
+	// Run 运行投递worker，直到ctx取消后等待进行中的投递完成再返回
+	Run(ctx context.Context)
+}
+
+// webhookService Webhook服务实现
+type webhookService struct {
+	BaseService
+	repo   repository.WebhookRepository
+	sender *webhook.Sender
+	opts   WebhookOptions
+	wake   chan struct{}
+}
+
+// NewWebhookService 创建Webhook服务
+func NewWebhookService(repo repository.WebhookRepository, opts WebhookOptions) WebhookService {
+	opts = opts.withDefaults()
+	return &webhookService{
+		repo:   repo,
+		sender: webhook.NewSender(opts.Client, opts.Timeout),
+		opts:   opts,
+		wake:   make(chan struct{}, 1),
+	}
+}
+
+// GetAllWebhooks 获取所有订阅
+func (s *webhookService) GetAllWebhooks(ctx context.Context) ([]models.WebhookSubscription, error) {
+	ctx = WithContext(ctx)
+	logger.Info("Getting all webhook subscriptions")
+
+	subscriptions, err := s.repo.GetAll(ctx)
+	if err != nil {
+		logger.Error("Failed to get all webhook subscriptions", err)
+		return nil, NewServiceError(ErrCodeDatabase, "获取Webhook订阅列表失败", err)
+	}
+
+	return subscriptions, nil
+}
+
+// GetWebhookByID 根据ID获取订阅
+func (s *webhookService) GetWebhookByID(ctx context.Context, id uint) (*models.WebhookSubscription, error) {
+	ctx = WithContext(ctx)
+	logger.Info("Getting webhook subscription by ID", zap.Uint("id", id))
+
+	subscription, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		logger.Error("Failed to get webhook subscription by ID", err, zap.Uint("id", id))
+		return nil, NewServiceError(ErrCodeDatabase, "获取Webhook订阅失败", err)
+	}
+
+	if subscription == nil {
+		return nil, NewServiceError(ErrCodeNotFound, "Webhook订阅不存在", nil)
+	}
+
+	return subscription, nil
+}
+
+// CreateWebhook 创建订阅
+func (s *webhookService) CreateWebhook(ctx context.Context, subscription *models.WebhookSubscription) error {
+	ctx = WithContext(ctx)
+	logger.Info("Creating webhook subscription", zap.String("url", subscription.URL))
+
+	if msg := validateWebhook(subscription); msg != "" {
+		return NewServiceError(ErrCodeInvalidData, msg, nil)
+	}
+
+	if subscription.Secret == "" {
+		secret, err := webhook.GenerateSecret()
+		if err != nil {
+			logger.Error("Failed to generate webhook secret", err)
+			return NewServiceError(ErrCodeInternal, "生成签名密钥失败", err)
+		}
+		subscription.Secret = secret
+	}
+
+	if err := s.repo.Create(ctx, subscription); err != nil {
+		logger.Error("Failed to create webhook subscription", err)
+		return NewServiceError(ErrCodeDatabase, "创建Webhook订阅失败", err)
+	}
+
+	return nil
+}
+
+// UpdateWebhook 更新订阅
+func (s *webhookService) UpdateWebhook(ctx context.Context, subscription *models.WebhookSubscription) error {
+	ctx = WithContext(ctx)
+	logger.Info("Updating webhook subscription", zap.Uint("id", subscription.ID))
+
+	existing, err := s.GetWebhookByID(ctx, subscription.ID)
+	if err != nil {
+		return err
+	}
+
+	if msg := validateWebhook(subscription); msg != "" {
+		return NewServiceError(ErrCodeInvalidData, msg, nil)
+	}
+
+	if subscription.Secret == "" {
+		subscription.Secret = existing.Secret
+	}
+	subscription.CreatedAt = existing.CreatedAt
+
+	if err := s.repo.Update(ctx, subscription); err != nil {
+		logger.Error("Failed to update webhook subscription", err)
+		return NewServiceError(ErrCodeDatabase, "更新Webhook订阅失败", err)
+	}
+
+	return nil
+}
+
+// DeleteWebhook 删除订阅
+func (s *webhookService) DeleteWebhook(ctx context.Context, id uint) error {
+	ctx = WithContext(ctx)
+	logger.Info("Deleting webhook subscription", zap.Uint("id", id))
+
+	if _, err := s.GetWebhookByID(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		logger.Error("Failed to delete webhook subscription", err)
+		return NewServiceError(ErrCodeDatabase, "删除Webhook订阅失败", err)
+	}
+
+	return nil
+}
+
+// validateWebhook 校验订阅地址、事件类型和签名密钥，并去除重复的事件类型，校验失败时返回错误信息
+func validateWebhook(subscription *models.WebhookSubscription) string {
+	u, err := url.Parse(subscription.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "无效的Webhook地址，必须是http或https地址: " + subscription.URL
+	}
+
+	if len(subscription.Events) == 0 {
+		return "至少需要订阅一种事件"
+	}
+	events := make(models.StringList, 0, len(subscription.Events))
+	for _, event := range subscription.Events {
+		if !models.ValidWebhookEvent(event) {
+			return "不支持的事件类型: " + event
+		}
+		if !containsField(events, event) {
+			events = append(events, event)
+		}
+	}
+	subscription.Events = events
+
+	if subscription.Secret != "" && len(subscription.Secret) < minWebhookSecretLength {
+		return "签名密钥长度不能少于16个字符"
+	}
+	return ""
+}
+
+// ListDeliveries 查询订阅的投递记录
+func (s *webhookService) ListDeliveries(ctx context.Context, filter repository.DeliveryFilter) (*repository.PageResult, error) {
+	ctx = WithContext(ctx)
+	logger.Info("Listing webhook deliveries", zap.Uint("subscriptionId", filter.SubscriptionID))
+
+	if _, err := s.GetWebhookByID(ctx, filter.SubscriptionID); err != nil {
+		return nil, err
+	}
+
+	switch filter.Status {
+	case "", models.DeliveryStatusPending, models.DeliveryStatusSucceeded, models.DeliveryStatusFailed:
+	default:
+		return nil, NewServiceError(ErrCodeInvalidData, "无效的投递状态: "+filter.Status, nil)
+	}
+
+	result, err := s.repo.ListDeliveries(ctx, filter)
+	if err != nil {
+		logger.Error("Failed to list webhook deliveries", err)
+		return nil, NewServiceError(ErrCodeDatabase, "获取投递记录失败", err)
+	}
+
+	return result, nil
+}
+
+// GetDelivery 获取投递记录详情
+func (s *webhookService) GetDelivery(ctx context.Context, subscriptionID, deliveryID uint) (*models.WebhookDelivery, error) {
+	ctx = WithContext(ctx)
+	logger.Info("Getting webhook delivery", zap.Uint("subscriptionId", subscriptionID), zap.Uint("id", deliveryID))
+
+	delivery, err := s.repo.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		logger.Error("Failed to get webhook delivery", err, zap.Uint("id", deliveryID))
+		return nil, NewServiceError(ErrCodeDatabase, "获取投递记录失败", err)
+	}
+
+	if delivery == nil || delivery.SubscriptionID != subscriptionID {
+		return nil, NewServiceError(ErrCodeNotFound, "投递记录不存在", nil)
+	}
+
+	return delivery, nil
+}
+
+// Redeliver 重新投递
+func (s *webhookService) Redeliver(ctx context.Context, subscriptionID, deliveryID uint) (*models.WebhookDelivery, error) {
+	ctx = WithContext(ctx)
+	logger.Info("Redelivering webhook", zap.Uint("subscriptionId", subscriptionID), zap.Uint("id", deliveryID))
+
+	subscription, err := s.GetWebhookByID(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if !subscription.Active {
+		return nil, NewServiceError(ErrCodeConflict, "Webhook订阅已停用，无法重新投递", nil)
+	}
+
+	original, err := s.GetDelivery(ctx, subscriptionID, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	deliveries := []models.WebhookDelivery{{
+		SubscriptionID: subscriptionID,
+		EventID:        original.EventID,
+		Event:          original.Event,
+		Payload:        original.Payload,
+		Status:         models.DeliveryStatusPending,
+		NextAttemptAt:  &now,
+		RedeliveryOf:   &original.ID,
+	}}
+	if err := s.repo.CreateDeliveries(ctx, deliveries); err != nil {
+		logger.Error("Failed to create webhook redelivery", err)
+		return nil, NewServiceError(ErrCodeDatabase, "重新投递失败", err)
+	}
+	s.notify()
+
+	return &deliveries[0], nil
+}
+
+// Publish 为订阅了该事件的启用订阅创建投递记录，由投递worker异步发送；不支持订阅的事件类型忽略
+func (s *webhookService) Publish(ctx context.Context, event string, data interface{}) {
+	ctx = WithContext(ctx)
+	if !models.ValidWebhookEvent(event) {
+		return
+	}
+
+	subscriptions, err := s.repo.GetActive(ctx)
+	if err != nil {
+		logger.Error("Failed to get webhook subscriptions", err, zap.String("event", event))
+		return
+	}
+
+	var targets []models.WebhookSubscription
+	for i := range subscriptions {
+		if subscriptions[i].Subscribes(event) {
+			targets = append(targets, subscriptions[i])
+		}
+	}
+	if len(targets) == 0 {
+		return
+	}
+
+	eventID, err := webhook.NewEventID()
+	if err != nil {
+		logger.Error("Failed to generate webhook event ID", err, zap.String("event", event))
+		return
+	}
+	now := time.Now()
+	payload, err := json.Marshal(WebhookEvent{ID: eventID, Event: event, CreatedAt: now, Data: data})
+	if err != nil {
+		logger.Error("Failed to marshal webhook event", err, zap.String("event", event))
+		return
+	}
+
+	deliveries := make([]models.WebhookDelivery, len(targets))
+	for i, subscription := range targets {
+		deliveries[i] = models.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			EventID:        eventID,
+			Event:          event,
+			Payload:        string(payload),
+			Status:         models.DeliveryStatusPending,
+			NextAttemptAt:  &now,
+		}
+	}
+	if err := s.repo.CreateDeliveries(ctx, deliveries); err != nil {
+		logger.Error("Failed to create webhook deliveries", err, zap.String("event", event))
+		return
+	}
+
+	logger.Info("Webhook event published",
+		zap.String("event", event),
+		zap.String("eventId", eventID),
+		zap.Int("subscriptions", len(deliveries)))
+	s.notify()
+}
+
+// notify 唤醒投递worker
+func (s *webhookService) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run 运行投递worker：新事件发布后立即投递，失败的投递按指数退避在到期后重试；
+// 投递前领取记录并计入投递次数，多个实例共享投递记录时同一记录只由一个worker发送。
+// 投递结果未能保存（例如服务在发送后退出）时，租约过期后重新投递，因此同一投递可能被发送多次，接收方应按事件ID去重
+func (s *webhookService) Run(ctx context.Context) {
+	logger.Info("Webhook delivery worker started", zap.Int("workers", s.opts.Workers))
+
+	ticker := time.NewTicker(s.opts.PollInterval)
+	defer ticker.Stop()
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, s.opts.Workers)
+	for {
+		s.dispatch(ctx, slots, &wg)
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			logger.Info("Webhook delivery worker stopped")
+			return
+		case <-s.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatch 领取到期的投递记录，在空闲的投递槽位中并发发送
+func (s *webhookService) dispatch(ctx context.Context, slots chan struct{}, wg *sync.WaitGroup) {
+	free := cap(slots) - len(slots)
+	if free == 0 {
+		return
+	}
+
+	// 进行中的记录已领取，下次投递时间在租约到期后，不会再被取出
+	due, err := s.repo.DueDeliveries(ctx, time.Now(), free)
+	if err != nil {
+		logger.Error("Failed to get due webhook deliveries", err)
+		return
+	}
+
+	for i := range due {
+		delivery := due[i]
+		// 之前的投递都已发送但结果未能保存，不再发送
+		if delivery.Attempts >= s.opts.MaxAttempts {
+			s.abandon(&delivery)
+			continue
+		}
+
+		select {
+		case slots <- struct{}{}:
+		default:
+			return
+		}
+
+		// 多个实例共享投递记录时只有一个worker能领取成功
+		lease := deliveryLeaseTimeouts * s.opts.Timeout
+		claimed, err := s.repo.ClaimDelivery(ctx, delivery.ID, delivery.Attempts, lease)
+		if err != nil || !claimed {
+			<-slots
+			continue
+		}
+		now := time.Now()
+		next := now.Add(lease)
+		delivery.Attempts++
+		delivery.LastAttemptAt, delivery.NextAttemptAt = &now, &next
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.deliver(&delivery)
+			<-slots
+			s.notify()
+		}()
+	}
+}
+
+// abandon 将达到最大投递次数但最后一次结果未保存的记录标记为失败
+func (s *webhookService) abandon(delivery *models.WebhookDelivery) {
+	delivery.Status = models.DeliveryStatusFailed
+	delivery.Error = fmt.Sprintf("已投递%d次，最后一次投递的结果未能保存", delivery.Attempts)
+	delivery.NextAttemptAt = nil
+	s.saveDelivery(delivery)
+}
+
+// deliver 发送一次投递并保存结果，失败时按指数退避安排下一次投递，达到最大次数后标记为失败
+func (s *webhookService) deliver(delivery *models.WebhookDelivery) {
+	// 服务关闭时不中断进行中的投递，单次请求受超时时间限制
+	ctx, cancel := context.WithTimeout(context.Background(), s.opts.Timeout)
+	defer cancel()
+
+	subscription, err := s.repo.GetByID(ctx, delivery.SubscriptionID)
+	if err != nil {
+		logger.Error("Failed to get webhook subscription for delivery", err, zap.Uint("id", delivery.ID))
+		return
+	}
+	if subscription == nil {
+		// 订阅已删除，投递记录随订阅一起删除
+		return
+	}
+
+	if !subscription.Active {
+		delivery.Status = models.DeliveryStatusFailed
+		delivery.Error = "Webhook订阅已停用"
+		delivery.NextAttemptAt = nil
+		s.saveDelivery(delivery)
+		return
+	}
+
+	// 投递次数和投递时间已在领取时保存
+	result := s.sender.Send(ctx, webhook.Request{
+		URL:        subscription.URL,
+		Secret:     subscription.Secret,
+		Event:      delivery.Event,
+		EventID:    delivery.EventID,
+		DeliveryID: delivery.ID,
+		Body:       []byte(delivery.Payload),
+	})
+
+	now := time.Now()
+	delivery.ResponseStatus = result.StatusCode
+	delivery.ResponseBody = result.Body
+	delivery.DurationMs = result.Duration.Milliseconds()
+	delivery.Error = ""
+
+	switch {
+	case result.Err == nil:
+		delivery.Status = models.DeliveryStatusSucceeded
+		delivery.NextAttemptAt = nil
+	case delivery.Attempts >= s.opts.MaxAttempts:
+		delivery.Status = models.DeliveryStatusFailed
+		delivery.Error = truncateRunes(result.Err.Error(), maxDeliveryError)
+		delivery.NextAttemptAt = nil
+	default:
+		next := now.Add(webhook.Backoff(delivery.Attempts, s.opts.RetryBackoff, s.opts.MaxBackoff))
+		delivery.Error = truncateRunes(result.Err.Error(), maxDeliveryError)
+		delivery.NextAttemptAt = &next
+	}
+
+	logger.Info("Webhook delivered",
+		zap.Uint("id", delivery.ID),
+		zap.Uint("subscriptionId", delivery.SubscriptionID),
+		zap.String("event", delivery.Event),
+		zap.String("status", delivery.Status),
+		zap.Int("attempts", delivery.Attempts),
+		zap.Int("responseStatus", delivery.ResponseStatus),
+		zap.String("error", delivery.Error))
+	s.saveDelivery(delivery)
+}
+
+// saveDelivery 保存投递结果。保存失败时本次投递已在领取时计入投递次数，记录在租约过期后重新投递，
+// 达到最大投递次数后由abandon标记为失败，不会无限重发
+func (s *webhookService) saveDelivery(delivery *models.WebhookDelivery) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.opts.Timeout)
+	defer cancel()
+
+	saved, err := s.repo.UpdateDelivery(ctx, delivery)
+	if err != nil {
+		logger.Error("Failed to save webhook delivery, will retry after lease expires", err,
+			zap.Uint("id", delivery.ID), zap.Int("attempts", delivery.Attempts))
+		return
+	}
+	if !saved {
+		logger.Warn("Webhook delivery claimed again before result was saved",
+			zap.Uint("id", delivery.ID), zap.Int("attempts", delivery.Attempts))
+	}
+}
+
+// truncateRunes 按字符截断字符串
+func truncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
@@ -2,25 +2,34 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
-	"github.com/K1ng97/CloudEye/internal/api/handler"
-	"github.com/K1ng97/CloudEye/internal/api/router"
-	"github.com/K1ng97/CloudEye/internal/pkg/config"
-	"github.com/K1ng97/CloudEye/internal/pkg/database"
-	"github.com/K1ng97/CloudEye/internal/pkg/logger"
-	"github.com/K1ng97/CloudEye/internal/repository"
-	"github.com/K1ng97/CloudEye/internal/service"
+	"github.com/yourusername/cloud-eye/internal/api/grpcapi"
+	"github.com/yourusername/cloud-eye/internal/api/handler"
+	"github.com/yourusername/cloud-eye/internal/api/router"
+	"github.com/yourusername/cloud-eye/internal/pkg/config"
+	"github.com/yourusername/cloud-eye/internal/pkg/database"
+	"github.com/yourusername/cloud-eye/internal/pkg/filestore"
+	"github.com/yourusername/cloud-eye/internal/pkg/logger"
+	"github.com/yourusername/cloud-eye/internal/repository"
+	"github.com/yourusername/cloud-eye/internal/service"
 
 	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
 )
 
 func main() {
+	// 解析命令行参数
+	demo := flag.Bool("demo", false, "使用内存存储和演示数据运行，无需MySQL")
+	flag.Parse()
+
 	// 加载配置
 	cfg, err := config.LoadConfig("configs/config.yaml")
 	if err != nil {
@@ -40,35 +49,172 @@ func main() {
 	// 设置Gin模式
 	gin.SetMode(cfg.Server.Mode)
 
-	// 初始化数据库
-	err = database.InitDB()
-	if err != nil {
-		logger.Fatal("Failed to initialize database", err)
+	// 创建仓库层
+	var (
+		providerRepo   repository.CloudProviderRepository
+		productRepo    repository.CloudProductRepository
+		configItemRepo repository.ConfigurationItemRepository
+		searchRepo     repository.SearchRepository
+		statsRepo      repository.StatsRepository
+		familyRepo     repository.ControlFamilyRepository
+		categoryRepo   repository.ProductCategoryRepository
+		tagRepo        repository.TagRepository
+		trashRepo      repository.TrashRepository
+		webhookRepo    repository.WebhookRepository
+		eventRepo      repository.ChangeEventRepository
+		jobRepo        repository.JobRepository
+		fileRepo       repository.FileRepository
+	)
+	if *demo {
+		// 演示模式：使用内存存储并写入演示数据
+		logger.Info("Running in demo mode with in-memory storage")
+		store := repository.NewMemoryStore()
+		if err := repository.SeedDemoData(context.Background(), store); err != nil {
+			logger.Fatal("Failed to seed demo data", err)
+		}
+		providerRepo = repository.NewMemoryCloudProviderRepository(store)
+		productRepo = repository.NewMemoryCloudProductRepository(store)
+		configItemRepo = repository.NewMemoryConfigurationItemRepository(store)
+		searchRepo = repository.NewMemorySearchRepository(store)
+		statsRepo = repository.NewMemoryStatsRepository(store)
+		familyRepo = repository.NewMemoryControlFamilyRepository(store)
+		categoryRepo = repository.NewMemoryProductCategoryRepository(store)
+		tagRepo = repository.NewMemoryTagRepository(store)
+		trashRepo = repository.NewMemoryTrashRepository(store)
+		webhookRepo = repository.NewMemoryWebhookRepository(store)
+		eventRepo = repository.NewMemoryChangeEventRepository(store)
+		jobRepo = repository.NewMemoryJobRepository(store)
+		fileRepo = repository.NewMemoryFileRepository(store)
+	} else {
+		// 初始化数据库
+		err = database.InitDB()
+		if err != nil {
+			logger.Fatal("Failed to initialize database", err)
+		}
+
+		providerRepo = repository.NewCloudProviderRepository(database.DBClient)
+		productRepo = repository.NewCloudProductRepository(database.DBClient)
+		configItemRepo = repository.NewConfigurationItemRepository(database.DBClient)
+		searchRepo = repository.NewSearchRepository(database.DBClient)
+		statsRepo = repository.NewStatsRepository(database.DBClient)
+		familyRepo = repository.NewControlFamilyRepository(database.DBClient)
+		categoryRepo = repository.NewProductCategoryRepository(database.DBClient)
+		tagRepo = repository.NewTagRepository(database.DBClient)
+		trashRepo = repository.NewTrashRepository(database.DBClient)
+		webhookRepo = repository.NewWebhookRepository(database.DBClient)
+		eventRepo = repository.NewChangeEventRepository(database.DBClient)
+		jobRepo = repository.NewJobRepository(database.DBClient)
+		fileRepo = repository.NewFileRepository(database.DBClient)
 	}
 
-	// 创建仓库层
-	providerRepo := repository.NewCloudProviderRepository(database.DBClient)
-	productRepo := repository.NewCloudProductRepository(database.DBClient)
-	configItemRepo := repository.NewConfigurationItemRepository(database.DBClient)
+	// 创建文件存储
+	fileStore, err := filestore.New(filestore.Options{
+		Driver:    cfg.Files.Driver,
+		LocalPath: cfg.Files.LocalPath,
+		S3: filestore.S3Options{
+			Endpoint:        cfg.Files.S3.Endpoint,
+			Region:          cfg.Files.S3.Region,
+			Bucket:          cfg.Files.S3.Bucket,
+			AccessKeyID:     cfg.Files.S3.AccessKeyID,
+			SecretAccessKey: cfg.Files.S3.SecretAccessKey,
+			PathStyle:       cfg.Files.S3.PathStyle,
+		},
+	})
+	if err != nil {
+		logger.Fatal("Failed to initialize file store", err)
+	}
 
 	// 创建服务层
-	providerService := service.NewCloudProviderService(providerRepo)
-	productService := service.NewCloudProductService(productRepo, providerRepo)
-	configItemService := service.NewConfigurationItemService(configItemRepo, providerRepo, productRepo)
+	fileService := service.NewFileService(fileRepo, fileStore, service.FileOptions{
+		LinkSecret:      cfg.Files.LinkSecret,
+		LinkTTL:         cfg.Files.LinkTTL,
+		ExportRetention: cfg.Files.ExportRetention,
+		ImportRetention: cfg.Files.ImportRetention,
+	})
+	webhookService := service.NewWebhookService(webhookRepo, service.WebhookOptions{
+		MaxAttempts:  cfg.Webhook.MaxAttempts,
+		RetryBackoff: cfg.Webhook.RetryBackoff,
+		MaxBackoff:   cfg.Webhook.MaxBackoff,
+		Timeout:      cfg.Webhook.Timeout,
+		Workers:      cfg.Webhook.Workers,
+	})
+	changeFeedService := service.NewChangeFeedService(eventRepo, service.ChangeFeedOptions{
+		Heartbeat: cfg.Events.Heartbeat,
+		Retention: cfg.Events.Retention,
+	})
+	events := service.NewMultiPublisher(webhookService, changeFeedService)
+	providerService := service.NewCloudProviderService(providerRepo, events)
+	productService := service.NewCloudProductService(productRepo, providerRepo, categoryRepo, events)
+	configItemService := service.NewConfigurationItemService(configItemRepo, providerRepo, productRepo, events)
+	searchService := service.NewSearchService(searchRepo)
+	statsService := service.NewStatsService(statsRepo)
+	familyService := service.NewControlFamilyService(familyRepo, configItemRepo, providerRepo, events)
+	categoryService := service.NewProductCategoryService(categoryRepo, productRepo, events)
+	tagService := service.NewTagService(tagRepo, events)
+	trashService := service.NewTrashService(trashRepo, events)
+	jobService := service.NewJobService(jobRepo, configItemService, fileService, service.JobOptions{
+		Workers:    cfg.Jobs.Workers,
+		ResultPath: cfg.Jobs.ResultPath,
+		Lease:      cfg.Jobs.Lease,
+	})
 
 	// 创建处理器层
 	providerHandler := handler.NewCloudProviderHandler(providerService)
 	productHandler := handler.NewCloudProductHandler(productService)
-	configItemHandler := handler.NewConfigurationItemHandler(configItemService)
+	configItemHandler := handler.NewConfigurationItemHandler(configItemService, fileService)
+	searchHandler := handler.NewSearchHandler(searchService)
+	statsHandler := handler.NewStatsHandler(statsService)
+	familyHandler := handler.NewControlFamilyHandler(familyService)
+	categoryHandler := handler.NewProductCategoryHandler(categoryService)
+	tagHandler := handler.NewTagHandler(tagService)
+	trashHandler := handler.NewTrashHandler(trashService)
+	graphqlHandler := handler.NewGraphQLHandler(providerService, productService, configItemService)
+	webhookHandler := handler.NewWebhookHandler(webhookService)
+	eventHandler := handler.NewEventHandler(changeFeedService)
+	jobHandler := handler.NewJobHandler(jobService, fileService)
+	fileHandler := handler.NewFileHandler(fileService)
 
 	// 初始化路由
-	r := router.InitRouter(providerHandler, productHandler, configItemHandler)
+	r := router.InitRouter(providerHandler, productHandler, configItemHandler, searchHandler, statsHandler, familyHandler, categoryHandler, tagHandler, trashHandler, graphqlHandler, webhookHandler, eventHandler, jobHandler, fileHandler)
 
 	// 创建HTTP服务器
 	server := &http.Server{
 		Addr:    fmt.Sprintf(":%d", cfg.Server.Port),
 		Handler: r,
 	}
+	// Shutdown不会中断SSE长连接，关闭时先断开所有变更订阅
+	server.RegisterOnShutdown(changeFeedService.Close)
+
+	// 创建gRPC服务器，与HTTP服务器共用服务层，监听单独的端口
+	var grpcServer *grpc.Server
+	if cfg.Server.GRPCPort > 0 {
+		lis, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.Server.GRPCPort))
+		if err != nil {
+			logger.Fatal("Failed to listen on gRPC port", err)
+		}
+		grpcServer = grpcapi.NewServer(providerService, productService, configItemService)
+		go func() {
+			logger.Info(fmt.Sprintf("gRPC server is running on port %d", cfg.Server.GRPCPort))
+			if err := grpcServer.Serve(lis); err != nil {
+				logger.Fatal("Failed to start gRPC server", err)
+			}
+		}()
+	}
+
+	// 启动Webhook投递worker、后台任务worker、过期变更事件和过期文件清理
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	webhookDone := make(chan struct{})
+	go func() {
+		webhookService.Run(workerCtx)
+		close(webhookDone)
+	}()
+	jobsDone := make(chan struct{})
+	go func() {
+		jobService.Run(workerCtx)
+		close(jobsDone)
+	}()
+	go changeFeedService.Run(workerCtx)
+	go fileService.Run(workerCtx)
 
 	// 优雅关闭服务器
 	serverShutdown := make(chan struct{})
@@ -85,6 +231,29 @@ func main() {
 		if err := server.Shutdown(ctx); err != nil {
 			logger.Fatal("Server forced to shutdown:", err)
 		}
+		if grpcServer != nil {
+			// 等待进行中的调用结束，超时后强制关闭（例如未结束的流式调用）
+			stopped := make(chan struct{})
+			go func() {
+				grpcServer.GracefulStop()
+				close(stopped)
+			}()
+			select {
+			case <-stopped:
+			case <-ctx.Done():
+				grpcServer.Stop()
+			}
+		}
+
+		// 等待进行中的Webhook投递完成，未完成的投递在下次启动后继续；
+		// 进行中的后台任务被中断，下次启动后重新执行
+		stopWorkers()
+		for _, done := range []chan struct{}{webhookDone, jobsDone} {
+			select {
+			case <-done:
+			case <-ctx.Done():
+			}
+		}
 
 		close(serverShutdown)
 	}()
@@ -0,0 +1,349 @@
+// Package client CloudEye API的Go客户端。
+//
+// 客户端负责拼装请求、解析统一的{code,message,data}响应结构、分页遍历、认证令牌、
+// 失败重试以及通过context取消请求，例如：
+//
+//	c, err := client.New("http://localhost:8080", client.WithToken(token))
+//	if err != nil {
+//		return err
+//	}
+//	provider, err := c.GetProvider(ctx, 1)
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 默认配置
+const (
+	DefaultTimeout    = 30 * time.Second
+	DefaultMaxRetries = 2
+	DefaultRetryWait  = 200 * time.Millisecond
+	defaultUserAgent  = "cloudeye-go-client"
+	apiPrefix         = "/api/v1"
+	mergePatchType    = "application/merge-patch+json"
+	jsonContentType   = "application/json"
+	maxRetryWait      = 5 * time.Second
+)
+
+// Client CloudEye API客户端，可被多个goroutine并发使用
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	token      string
+	userAgent  string
+	maxRetries int
+	retryWait  time.Duration
+}
+
+// Option 客户端选项
+type Option func(*Client)
+
+// WithHTTPClient 使用自定义的http.Client，例如配置代理或TLS
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithToken 设置认证令牌，以"Authorization: Bearer <token>"发送
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithUserAgent 设置User-Agent
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithRetry 设置重试次数和首次重试前的等待时间，之后每次重试等待时间翻倍；maxRetries为0时不重试。
+// 只有GET、PUT、DELETE请求在网络错误或429、502、503、504响应时重试
+func WithRetry(maxRetries int, wait time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.retryWait = wait
+	}
+}
+
+// New 创建客户端，baseURL为服务地址，例如 http://localhost:8080
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(strings.TrimRight(baseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("无效的服务地址: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return nil, fmt.Errorf("无效的服务地址: %s", baseURL)
+	}
+
+	c := &Client{
+		baseURL:    u,
+		httpClient: &http.Client{Timeout: DefaultTimeout},
+		userAgent:  defaultUserAgent,
+		maxRetries: DefaultMaxRetries,
+		retryWait:  DefaultRetryWait,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// FieldError 单个字段的校验错误
+type FieldError struct {
+	Field   string `json:"field"`
+	Reason  string `json:"reason"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+// APIError 服务端返回的错误
+type APIError struct {
+	StatusCode int          // HTTP状态码
+	Code       int          // 业务错误码，例如4000、4004、4009
+	Message    string       // 错误信息
+	Errors     []FieldError // 字段级校验错误
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("cloudeye: %d %s (code %d)", e.StatusCode, e.Message, e.Code)
+	for _, fe := range e.Errors {
+		msg += fmt.Sprintf("; %s: %s", fe.Field, fe.Message)
+	}
+	return msg
+}
+
+// IsNotFound 判断错误是否为资源不存在
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsConflict 判断错误是否为数据冲突，例如代码重复
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// IsValidation 判断错误是否为请求参数校验失败
+func IsValidation(err error) bool {
+	return hasStatus(err, http.StatusBadRequest)
+}
+
+func hasStatus(err error, status int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == status
+}
+
+// envelope 统一响应结构
+type envelope struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+	Errors  []FieldError    `json:"errors"`
+}
+
+// request 一次API请求
+type request struct {
+	method      string
+	path        string
+	query       url.Values
+	body        []byte
+	contentType string
+}
+
+// newJSONRequest 创建JSON请求体的请求
+func newJSONRequest(method, path string, body interface{}) (*request, error) {
+	req := &request{method: method, path: path}
+	if body == nil {
+		return req, nil
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("序列化请求失败: %w", err)
+	}
+	req.body = data
+	req.contentType = jsonContentType
+	return req, nil
+}
+
+// call 发送JSON请求并把data解析到out，out为nil时忽略data
+func (c *Client) call(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	req, err := newJSONRequest(method, path, body)
+	if err != nil {
+		return err
+	}
+	req.query = query
+	return c.do(ctx, req, out)
+}
+
+// do 发送请求，按需重试，并解析统一响应结构
+func (c *Client) do(ctx context.Context, req *request, out interface{}) error {
+	var (
+		resp *http.Response
+		err  error
+	)
+	for attempt := 0; ; attempt++ {
+		resp, err = c.send(ctx, req)
+		if attempt >= c.maxRetries || !retryable(req.method, resp, err) || ctx.Err() != nil {
+			break
+		}
+
+		wait := c.backoff(attempt, resp)
+		if resp != nil {
+			// 丢弃响应体以复用连接
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return decodeResponse(resp, out)
+}
+
+// send 发送一次请求
+func (c *Client) send(ctx context.Context, req *request) (*http.Response, error) {
+	u := *c.baseURL
+	u.Path = c.baseURL.Path + req.path
+	if len(req.query) > 0 {
+		u.RawQuery = req.query.Encode()
+	}
+
+	var body io.Reader
+	if req.body != nil {
+		body = bytes.NewReader(req.body)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Accept", jsonContentType)
+	httpReq.Header.Set("User-Agent", c.userAgent)
+	if req.contentType != "" {
+		httpReq.Header.Set("Content-Type", req.contentType)
+	}
+	if c.token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	return c.httpClient.Do(httpReq)
+}
+
+// retryable 判断请求是否可以重试，只重试幂等请求
+func retryable(method string, resp *http.Response, err error) bool {
+	switch method {
+	case http.MethodGet, http.MethodPut, http.MethodDelete:
+	default:
+		return false
+	}
+
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// backoff 返回第attempt次重试前的等待时间，优先使用响应中的Retry-After
+func (c *Client) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			return minDuration(time.Duration(seconds)*time.Second, maxRetryWait)
+		}
+	}
+	return minDuration(c.retryWait<<uint(attempt), maxRetryWait)
+}
+
+func minDuration(a, b time.Duration) time.Duration {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// decodeResponse 解析统一响应结构，code不为0或HTTP状态码表示失败时返回APIError
+func decodeResponse(resp *http.Response, out interface{}) error {
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("读取响应失败: %w", err)
+	}
+
+	var env envelope
+	if err := json.Unmarshal(raw, &env); err != nil {
+		if resp.StatusCode >= http.StatusBadRequest {
+			return &APIError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(raw))}
+		}
+		return fmt.Errorf("解析响应失败: %w", err)
+	}
+
+	// 失败响应也可能携带数据，例如批量操作的执行结果
+	if out != nil && len(env.Data) > 0 && string(env.Data) != "null" {
+		if err := json.Unmarshal(env.Data, out); err != nil {
+			return fmt.Errorf("解析响应数据失败: %w", err)
+		}
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest || env.Code != 0 {
+		return &APIError{
+			StatusCode: resp.StatusCode,
+			Code:       env.Code,
+			Message:    env.Message,
+			Errors:     env.Errors,
+		}
+	}
+	return nil
+}
+
+// idPath 拼接资源ID路径
+func idPath(prefix string, id uint) string {
+	return prefix + "/" + strconv.FormatUint(uint64(id), 10)
+}
+
+// encodePatch 将合并补丁序列化为JSON，patch可以是[]byte、json.RawMessage或可序列化为JSON对象的值
+func encodePatch(patch interface{}) ([]byte, error) {
+	switch p := patch.(type) {
+	case []byte:
+		return p, nil
+	case json.RawMessage:
+		return p, nil
+	default:
+		return json.Marshal(p)
+	}
+}
+
+// patchJSON 发送JSON合并补丁请求
+func (c *Client) patchJSON(ctx context.Context, path string, patch, out interface{}) error {
+	body, err := encodePatch(patch)
+	if err != nil {
+		return fmt.Errorf("序列化补丁失败: %w", err)
+	}
+	return c.do(ctx, &request{
+		method:      http.MethodPatch,
+		path:        path,
+		body:        body,
+		contentType: mergePatchType,
+	}, out)
+}
@@ -0,0 +1,396 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+	"github.com/yourusername/cloud-eye/internal/apptest"
+	"github.com/yourusername/cloud-eye/pkg/client"
+)
+
+// newClient 启动与main.go一致的测试服务（内存存储和演示数据）并创建指向它的客户端
+func newClient(t *testing.T, opts ...client.Option) (*client.Client, *apptest.App) {
+	t.Helper()
+	app := apptest.New(t)
+	c, err := client.New(app.Server.URL, opts...)
+	if err != nil {
+		t.Fatalf("创建客户端失败: %v", err)
+	}
+	return c, app
+}
+
+func TestNewRejectsInvalidBaseURL(t *testing.T) {
+	for _, baseURL := range []string{"", "localhost:8080", "ftp://example.com", "http://"} {
+		if _, err := client.New(baseURL); err == nil {
+			t.Errorf("New(%q)成功，期望返回错误", baseURL)
+		}
+	}
+}
+
+func TestProviderLifecycle(t *testing.T) {
+	c, _ := newClient(t)
+	ctx := context.Background()
+
+	created, err := c.CreateProvider(ctx, client.ProviderInput{Name: "华为云", Code: "HUAWEI", Description: "华为云服务"})
+	if err != nil {
+		t.Fatalf("创建云服务商失败: %v", err)
+	}
+	if created.ID == 0 || created.Code != "HUAWEI" {
+		t.Fatalf("创建结果为%+v", created)
+	}
+
+	_, err = c.CreateProvider(ctx, client.ProviderInput{Name: "重复", Code: "HUAWEI"})
+	if !client.IsConflict(err) {
+		t.Fatalf("重复代码创建返回%v，期望冲突错误", err)
+	}
+
+	_, err = c.CreateProvider(ctx, client.ProviderInput{})
+	var apiErr *client.APIError
+	if !client.IsValidation(err) || !errors.As(err, &apiErr) || len(apiErr.Errors) == 0 {
+		t.Fatalf("空请求返回%v，期望带字段错误的校验失败", err)
+	}
+	if !hasFieldError(apiErr, "name") || !hasFieldError(apiErr, "code") {
+		t.Fatalf("字段错误为%+v，期望包含name和code", apiErr.Errors)
+	}
+
+	patched, err := c.PatchProvider(ctx, created.ID, map[string]interface{}{"description": nil})
+	if err != nil {
+		t.Fatalf("部分更新失败: %v", err)
+	}
+	if patched.Description != "" || patched.Name != "华为云" {
+		t.Fatalf("部分更新结果为%+v，期望只清空描述", patched)
+	}
+
+	if err := c.UpdateProvider(ctx, created.ID, client.ProviderInput{Name: "华为云Stack", Code: "HUAWEI"}); err != nil {
+		t.Fatalf("整体更新失败: %v", err)
+	}
+	got, err := c.GetProvider(ctx, created.ID)
+	if err != nil || got.Name != "华为云Stack" {
+		t.Fatalf("GetProvider = %+v, %v", got, err)
+	}
+
+	if err := c.DeleteProvider(ctx, created.ID, false); err != nil {
+		t.Fatalf("删除云服务商失败: %v", err)
+	}
+	if _, err := c.GetProvider(ctx, created.ID); !client.IsNotFound(err) {
+		t.Fatalf("删除后GetProvider返回%v，期望不存在", err)
+	}
+}
+
+func TestDeleteProviderRequiresCascade(t *testing.T) {
+	c, _ := newClient(t)
+	ctx := context.Background()
+
+	// 演示数据中AWS（ID为1）下有产品和配置项
+	if err := c.DeleteProvider(ctx, 1, false); !client.IsConflict(err) {
+		t.Fatalf("不指定cascade删除返回%v，期望冲突错误", err)
+	}
+	if err := c.DeleteProvider(ctx, 1, true); err != nil {
+		t.Fatalf("级联删除失败: %v", err)
+	}
+	if _, err := c.GetProduct(ctx, 1); !client.IsNotFound(err) {
+		t.Fatalf("级联删除后GetProduct返回%v，期望不存在", err)
+	}
+	if _, err := c.GetConfigItem(ctx, 1); !client.IsNotFound(err) {
+		t.Fatalf("级联删除后GetConfigItem返回%v，期望不存在", err)
+	}
+}
+
+func TestListingAndPagination(t *testing.T) {
+	c, _ := newClient(t)
+	ctx := context.Background()
+
+	providers, err := c.ListProviders(ctx, client.ProviderFilter{ListOptions: client.ListOptions{PageSize: 2}})
+	if err != nil || len(providers) != 5 {
+		t.Fatalf("ListProviders返回%d个, %v，期望忽略分页参数返回全部5个", len(providers), err)
+	}
+
+	page, err := c.ListProvidersPage(ctx, client.ProviderFilter{ListOptions: client.ListOptions{PageSize: 2, Sort: []string{"code"}}})
+	if err != nil {
+		t.Fatalf("ListProvidersPage失败: %v", err)
+	}
+	if page.Total != 5 || page.Page != 1 || len(page.Data) != 2 || page.Data[0].Code != "ALICLOUD" {
+		t.Fatalf("第一页为%+v", page.PageInfo)
+	}
+
+	var codes []string
+	err = c.EachProvider(ctx, client.ProviderFilter{ListOptions: client.ListOptions{PageSize: 2, Sort: []string{"-code"}}}, func(p client.Provider) error {
+		codes = append(codes, p.Code)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("EachProvider失败: %v", err)
+	}
+	if strings.Join(codes, ",") != "TENCENTCLOUD,GCP,AZURE,AWS,ALICLOUD" {
+		t.Fatalf("EachProvider遍历顺序为%v", codes)
+	}
+
+	// 存储类别（ID为2）包含子类别对象存储
+	products, err := c.ListProducts(ctx, client.ProductFilter{CategoryIDs: []uint{2}, ProviderIDs: []uint{1, 2}})
+	if err != nil {
+		t.Fatalf("ListProducts失败: %v", err)
+	}
+	if got := productCodes(products); got != "BLOB,S3" {
+		t.Fatalf("按类别和服务商过滤的产品为%s", got)
+	}
+
+	byCode, err := c.ListProviderProductsByCode(ctx, "AWS")
+	if err != nil || productCodes(byCode) != "EC2,RDS,S3" {
+		t.Fatalf("ListProviderProductsByCode = %v, %v", productCodes(byCode), err)
+	}
+
+	var ids []uint
+	stop := errors.New("stop")
+	err = c.EachConfigItem(ctx, client.ConfigItemFilter{ProviderIDs: []uint{1}, ListOptions: client.ListOptions{PageSize: 4}}, func(item client.ConfigItem) error {
+		if item.CloudProviderID != 1 {
+			t.Errorf("配置项%d不属于AWS", item.ID)
+		}
+		ids = append(ids, item.ID)
+		if len(ids) == 5 {
+			return stop
+		}
+		return nil
+	})
+	if err != stop || len(ids) != 5 {
+		t.Fatalf("EachConfigItem返回%v，遍历了%v，期望在第5项时停止", err, ids)
+	}
+}
+
+func TestConfigItemLifecycleAndBulk(t *testing.T) {
+	c, _ := newClient(t)
+	ctx := context.Background()
+
+	item, err := c.CreateConfigItem(ctx, client.ConfigItemInput{CloudProviderID: 1, ProductID: 1, Name: "启用IMDSv2", RecommendedValue: "required"})
+	if err != nil {
+		t.Fatalf("创建配置项失败: %v", err)
+	}
+
+	patched, err := c.PatchConfigItem(ctx, item.ID, []byte(`{"severity":"high"}`))
+	if err != nil || patched.Severity != "high" || patched.RecommendedValue != "required" {
+		t.Fatalf("PatchConfigItem = %+v, %v", patched, err)
+	}
+
+	items, err := c.ListProviderProductConfigItems(ctx, 1, 1)
+	if err != nil || len(items) != 3 {
+		t.Fatalf("ListProviderProductConfigItems返回%d项, %v，期望3项", len(items), err)
+	}
+
+	resp, err := c.BulkConfigItems(ctx, client.BulkRequest{
+		Mode: client.BulkModeAtomic,
+		Operations: []client.BulkOperation{
+			{Op: client.BulkOpTag, ID: item.ID, AddTags: []string{"元数据"}},
+			{Op: client.BulkOpDelete, ID: 99999},
+		},
+	})
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || resp == nil {
+		t.Fatalf("atomic模式存在失败项时返回%v, %+v，期望APIError和执行结果", err, resp)
+	}
+	if resp.Committed || len(resp.Results) != 2 || resp.Results[1].Status != "failed" {
+		t.Fatalf("批量操作结果为%+v", resp)
+	}
+	if got, _ := c.GetConfigItem(ctx, item.ID); len(got.Tags) != 0 {
+		t.Fatalf("回滚后配置项仍有标签%v", got.Tags)
+	}
+
+	resp, err = c.BulkConfigItems(ctx, client.BulkRequest{
+		Mode: client.BulkModeBestEffort,
+		Operations: []client.BulkOperation{
+			{Op: client.BulkOpUpdate, ID: item.ID, Patch: map[string]interface{}{"status": "deprecated"}},
+			{Op: client.BulkOpDelete, ID: 99999},
+		},
+	})
+	if err != nil || resp.Succeeded != 1 || resp.Failed != 1 {
+		t.Fatalf("best_effort批量操作返回%+v, %v", resp, err)
+	}
+
+	if err := c.DeleteConfigItem(ctx, item.ID); err != nil {
+		t.Fatalf("删除配置项失败: %v", err)
+	}
+	if _, err := c.GetConfigItem(ctx, item.ID); !client.IsNotFound(err) {
+		t.Fatalf("删除后GetConfigItem返回%v，期望不存在", err)
+	}
+}
+
+func TestExportAndDownload(t *testing.T) {
+	c, _ := newClient(t)
+	ctx := context.Background()
+
+	result, err := c.ExportConfigItems(ctx, client.ConfigItemFilter{ProductIDs: []uint{1}}, "")
+	if err != nil {
+		t.Fatalf("导出失败: %v", err)
+	}
+
+	var file bytes.Buffer
+	n, err := c.DownloadFile(ctx, result.DownloadURL, &file)
+	if err != nil || n != result.Size || n == 0 {
+		t.Fatalf("下载返回%d字节, %v，期望%d字节", n, err, result.Size)
+	}
+	f, err := excelize.OpenReader(&file)
+	if err != nil {
+		t.Fatalf("下载的文件不是有效的Excel文件: %v", err)
+	}
+	defer f.Close()
+	rows, err := f.GetRows(f.GetSheetList()[0])
+	if err != nil || len(rows) != 3 {
+		t.Fatalf("导出文件共%d行, %v，期望表头和2个配置项", len(rows), err)
+	}
+
+	if _, err := c.DownloadFile(ctx, result.DownloadURL+"0", &bytes.Buffer{}); err == nil {
+		t.Fatal("签名被篡改的下载地址下载成功，期望返回错误")
+	}
+	if _, err := c.DownloadFile(ctx, "https://example.com/file.xlsx", &bytes.Buffer{}); err == nil {
+		t.Fatal("非API地址下载成功，期望返回错误")
+	}
+}
+
+func TestImportConfigItems(t *testing.T) {
+	c, _ := newClient(t)
+	ctx := context.Background()
+
+	// 云服务商和云产品按代码引用，缺少推荐配置值的行被跳过
+	file := importFile(t,
+		[]string{"", "AWS", "AWS/EC2", "禁用串行控制台", "disabled"},
+		[]string{"", "GCP", "GCS", "禁止公开访问", "enforced"},
+		[]string{"", "AWS", "AWS/EC2", "缺少推荐配置值", ""},
+	)
+	count, err := c.ImportConfigItems(ctx, "items.xlsx", file)
+	if err != nil || count != 2 {
+		t.Fatalf("导入返回%d, %v，期望导入2项", count, err)
+	}
+	items, err := c.ListConfigItems(ctx, client.ConfigItemFilter{ProviderIDs: []uint{3}})
+	if err != nil || !hasConfigItem(items.Data, "禁止公开访问") {
+		t.Fatalf("导入后GCP的配置项为%+v, %v", items, err)
+	}
+	items, err = c.ListConfigItems(ctx, client.ConfigItemFilter{ProviderIDs: []uint{1}})
+	if err != nil || hasConfigItem(items.Data, "缺少推荐配置值") {
+		t.Fatalf("缺少推荐配置值的行被导入: %+v, %v", items, err)
+	}
+
+	file = importFile(t, []string{"", "NOPE", "EC2", "未知云服务商", "x"})
+	if _, err := c.ImportConfigItems(ctx, "items.xlsx", file); !client.IsNotFound(err) {
+		t.Fatalf("引用不存在的云服务商导入返回%v，期望不存在", err)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	c, _ := newClient(t)
+
+	report, err := c.Evaluate(context.Background(), []client.Resource{
+		{ID: "i-1", Provider: "AWS", Product: "EC2", Config: map[string]interface{}{}},
+		{ID: "x-1", Provider: "UNKNOWN", Product: "NONE", Config: map[string]interface{}{}},
+	})
+	if err != nil {
+		t.Fatalf("评估失败: %v", err)
+	}
+	if report.Summary.Resources != 2 || len(report.Summary.Unmatched) != 1 || report.Summary.Unmatched[0] != "x-1" {
+		t.Fatalf("评估统计为%+v", report.Summary)
+	}
+	for _, f := range report.Findings {
+		if f.ResourceID != "i-1" || f.Product != "EC2" {
+			t.Fatalf("评估结果包含无关的资源: %+v", f)
+		}
+	}
+	if report.Summary.Findings != len(report.Findings) || len(report.Findings) == 0 {
+		t.Fatalf("评估结果为%d项，统计为%d项", len(report.Findings), report.Summary.Findings)
+	}
+}
+
+func TestRetriesIdempotentRequestsOnUnavailable(t *testing.T) {
+	app := apptest.New(t)
+
+	// 在真实路由前注入失败：每个请求的第一次尝试返回503
+	var attempts int32
+	flaky := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1)%2 == 1 {
+			w.Header().Set("Retry-After", "0")
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		app.Server.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer flaky.Close()
+
+	c, err := client.New(flaky.URL, client.WithRetry(1, time.Millisecond))
+	if err != nil {
+		t.Fatalf("创建客户端失败: %v", err)
+	}
+	ctx := context.Background()
+
+	provider, err := c.GetProvider(ctx, 1)
+	if err != nil || provider.Code != "AWS" || attempts != 2 {
+		t.Fatalf("GetProvider = %+v, %v，共请求%d次，期望重试一次后成功", provider, err, attempts)
+	}
+
+	// POST不是幂等请求，不重试
+	atomic.StoreInt32(&attempts, 0)
+	_, err = c.CreateProvider(ctx, client.ProviderInput{Name: "华为云", Code: "HUAWEI"})
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable || attempts != 1 {
+		t.Fatalf("CreateProvider返回%v，共请求%d次，期望不重试直接返回503", err, attempts)
+	}
+}
+
+func TestContextCancellation(t *testing.T) {
+	c, _ := newClient(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := c.GetProvider(ctx, 1); !errors.Is(err, context.Canceled) {
+		t.Fatalf("已取消的context返回%v，期望context.Canceled", err)
+	}
+}
+
+func hasFieldError(err *client.APIError, field string) bool {
+	for _, fe := range err.Errors {
+		if fe.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+func productCodes(products []client.Product) string {
+	codes := make([]string, len(products))
+	for i, p := range products {
+		codes[i] = p.Code
+	}
+	sort.Strings(codes)
+	return strings.Join(codes, ",")
+}
+
+func hasConfigItem(items []client.ConfigItem, name string) bool {
+	for _, item := range items {
+		if item.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// importFile 生成导入用的Excel文件，rows按导出文件的列顺序填写
+func importFile(t *testing.T, rows ...[]string) *bytes.Buffer {
+	t.Helper()
+	f := excelize.NewFile()
+	defer f.Close()
+	sheet := f.GetSheetName(0)
+	f.SetSheetRow(sheet, "A1", &[]string{"ID", "云服务商", "云产品", "配置项名称", "推荐配置值"})
+	for i, row := range rows {
+		cell, _ := excelize.CoordinatesToCellName(1, i+2)
+		f.SetSheetRow(sheet, cell, &row)
+	}
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		t.Fatalf("生成Excel文件失败: %v", err)
+	}
+	return buf
+}
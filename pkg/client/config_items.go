@@ -0,0 +1,202 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"time"
+)
+
+const configItemsPath = apiPrefix + "/config-items"
+
+// 批量操作类型
+const (
+	BulkOpCreate = "create"
+	BulkOpUpdate = "update"
+	BulkOpDelete = "delete"
+	BulkOpMove   = "move"
+	BulkOpTag    = "tag"
+)
+
+// 批量操作模式
+const (
+	BulkModeAtomic     = "atomic"      // 任一操作失败则全部回滚
+	BulkModeBestEffort = "best_effort" // 逐项执行，失败项不影响其他操作
+)
+
+// BulkOperation 批量操作中的一项
+type BulkOperation struct {
+	Op         string           `json:"op"`
+	ID         uint             `json:"id,omitempty"`          // update、delete、move、tag时的配置项ID
+	Item       *ConfigItemInput `json:"item,omitempty"`        // create时的配置项
+	Patch      interface{}      `json:"patch,omitempty"`       // update时的JSON合并补丁
+	ProductID  uint             `json:"product_id,omitempty"`  // move时的目标云产品ID
+	AddTags    []string         `json:"add_tags,omitempty"`    // tag时添加的标签，create时为新配置项的标签
+	RemoveTags []string         `json:"remove_tags,omitempty"` // tag时移除的标签
+}
+
+// BulkRequest 批量操作请求，最多500项
+type BulkRequest struct {
+	Mode       string          `json:"mode,omitempty"`
+	Operations []BulkOperation `json:"operations"`
+}
+
+// BulkResult 单项操作的执行结果
+type BulkResult struct {
+	Index  int         `json:"index"`
+	Op     string      `json:"op"`
+	ID     uint        `json:"id,omitempty"`
+	Status string      `json:"status"` // succeeded、failed或skipped
+	Error  string      `json:"error,omitempty"`
+	Item   *ConfigItem `json:"item,omitempty"`
+}
+
+// BulkResponse 批量操作结果
+type BulkResponse struct {
+	Mode      string       `json:"mode"`
+	Committed bool         `json:"committed"`
+	Succeeded int          `json:"succeeded"`
+	Failed    int          `json:"failed"`
+	Results   []BulkResult `json:"results"`
+}
+
+// ExportResult 导出结果
+type ExportResult struct {
+	FileID      uint      `json:"fileId"`
+	FileName    string    `json:"fileName"`
+	Size        int64     `json:"size"`
+	DownloadURL string    `json:"downloadUrl"` // 带签名的相对下载地址，可传给DownloadFile
+	ExpiresAt   time.Time `json:"expiresAt"`   // 下载地址的过期时间
+}
+
+// ListConfigItems 分页获取配置项
+func (c *Client) ListConfigItems(ctx context.Context, filter ConfigItemFilter) (*ConfigItemPage, error) {
+	var page ConfigItemPage
+	if err := c.call(ctx, http.MethodGet, configItemsPath, filter.values(), nil, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// EachConfigItem 使用游标分页遍历所有符合条件的配置项，fn返回错误时停止遍历并返回该错误
+func (c *Client) EachConfigItem(ctx context.Context, filter ConfigItemFilter, fn func(ConfigItem) error) error {
+	return eachPage(&filter.ListOptions, func() (string, error) {
+		page, err := c.ListConfigItems(ctx, filter)
+		if err != nil {
+			return "", err
+		}
+		for _, item := range page.Data {
+			if err := fn(item); err != nil {
+				return "", err
+			}
+		}
+		return page.NextCursor, nil
+	})
+}
+
+// ListProviderProductConfigItems 获取指定云服务商和产品的配置项
+func (c *Client) ListProviderProductConfigItems(ctx context.Context, providerID, productID uint) ([]ConfigItem, error) {
+	path := idPath(idPath(providersPath, providerID)+"/products", productID) + "/config-items"
+
+	var items []ConfigItem
+	if err := c.call(ctx, http.MethodGet, path, nil, nil, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// GetConfigItem 获取配置项详情
+func (c *Client) GetConfigItem(ctx context.Context, id uint) (*ConfigItem, error) {
+	var item ConfigItem
+	if err := c.call(ctx, http.MethodGet, idPath(configItemsPath, id), nil, nil, &item); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// CreateConfigItem 创建配置项
+func (c *Client) CreateConfigItem(ctx context.Context, input ConfigItemInput) (*ConfigItem, error) {
+	var item ConfigItem
+	if err := c.call(ctx, http.MethodPost, configItemsPath, nil, input, &item); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// UpdateConfigItem 整体更新配置项，未提供的字段将被清空；只修改部分字段时使用PatchConfigItem
+func (c *Client) UpdateConfigItem(ctx context.Context, id uint, input ConfigItemInput) error {
+	return c.call(ctx, http.MethodPut, idPath(configItemsPath, id), nil, input, nil)
+}
+
+// PatchConfigItem 按JSON合并补丁部分更新配置项
+func (c *Client) PatchConfigItem(ctx context.Context, id uint, patch interface{}) (*ConfigItem, error) {
+	var item ConfigItem
+	if err := c.patchJSON(ctx, idPath(configItemsPath, id), patch, &item); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// DeleteConfigItem 删除配置项
+func (c *Client) DeleteConfigItem(ctx context.Context, id uint) error {
+	return c.call(ctx, http.MethodDelete, idPath(configItemsPath, id), nil, nil, nil)
+}
+
+// BulkConfigItems 批量操作配置项。atomic模式下存在失败项时返回APIError，
+// 同时返回各项的执行结果，便于定位失败的操作
+func (c *Client) BulkConfigItems(ctx context.Context, req BulkRequest) (*BulkResponse, error) {
+	var resp BulkResponse
+	err := c.call(ctx, http.MethodPost, configItemsPath+"/bulk", nil, req, &resp)
+	if err != nil && resp.Results == nil {
+		return nil, err
+	}
+	return &resp, err
+}
+
+// ExportConfigItems 将符合条件的配置项导出为Excel文件，返回带签名的下载地址，groupBy为category时按产品类别分组排列
+func (c *Client) ExportConfigItems(ctx context.Context, filter ConfigItemFilter, groupBy string) (*ExportResult, error) {
+	query := filter.values()
+	if groupBy != "" {
+		query.Set("group_by", groupBy)
+	}
+
+	var result ExportResult
+	if err := c.call(ctx, http.MethodGet, configItemsPath+"/export", query, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ImportConfigItems 上传.xlsx文件导入配置项，返回导入的数量
+func (c *Client) ImportConfigItems(ctx context.Context, filename string, r io.Reader) (int, error) {
+	// 导入文件通常不大，整体读入内存后发送
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	part, err := w.CreateFormFile("file", filename)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := io.Copy(part, r); err != nil {
+		return 0, fmt.Errorf("读取导入文件失败: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return 0, err
+	}
+
+	var result struct {
+		Count int `json:"count"`
+	}
+	err = c.do(ctx, &request{
+		method:      http.MethodPost,
+		path:        configItemsPath + "/import",
+		body:        buf.Bytes(),
+		contentType: w.FormDataContentType(),
+	}, &result)
+	if err != nil {
+		return 0, err
+	}
+	return result.Count, nil
+}
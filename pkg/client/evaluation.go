@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// 评估结果状态
+const (
+	EvaluationPass   = "pass"
+	EvaluationFail   = "fail"
+	EvaluationError  = "error"
+	EvaluationManual = "manual"
+)
+
+// Resource 待评估的云资源，Provider和Product为云服务商和云产品的编码
+type Resource struct {
+	ID       string                 `json:"id"`
+	Name     string                 `json:"name,omitempty"`
+	Provider string                 `json:"provider"`
+	Product  string                 `json:"product"`
+	Config   map[string]interface{} `json:"config"`
+}
+
+// Finding 一个配置项对一个资源的评估结果
+type Finding struct {
+	ResourceID   string      `json:"resource_id"`
+	ResourceName string      `json:"resource_name,omitempty"`
+	Provider     string      `json:"provider"`
+	Product      string      `json:"product"`
+	ItemID       uint        `json:"item_id"`
+	ItemName     string      `json:"item_name"`
+	Severity     string      `json:"severity"`
+	Status       string      `json:"status"`
+	Rule         string      `json:"rule,omitempty"`
+	Expected     interface{} `json:"expected,omitempty"`
+	Actual       interface{} `json:"actual,omitempty"`
+	Message      string      `json:"message,omitempty"`
+}
+
+// EvaluationSummary 评估结果统计
+type EvaluationSummary struct {
+	Resources int            `json:"resources"`
+	Findings  int            `json:"findings"`
+	Passed    int            `json:"passed"`
+	Failed    int            `json:"failed"`
+	Errors    int            `json:"errors"`
+	Manual    int            `json:"manual"`
+	FailedBy  map[string]int `json:"failed_by_severity"`
+	Unmatched []string       `json:"unmatched_resources,omitempty"`
+}
+
+// EvaluationReport 评估报告
+type EvaluationReport struct {
+	Summary  EvaluationSummary `json:"summary"`
+	Findings []Finding         `json:"findings"`
+}
+
+// Evaluate 使用服务端全部生效中的配置项评估资源配置，最多1000个资源
+func (c *Client) Evaluate(ctx context.Context, resources []Resource) (*EvaluationReport, error) {
+	body := map[string]interface{}{"resources": resources}
+
+	var report EvaluationReport
+	if err := c.call(ctx, http.MethodPost, configItemsPath+"/evaluate", nil, body, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
@@ -0,0 +1,34 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DownloadFile 下载导出等接口返回的文件，downloadURL为响应中带签名的下载地址，文件内容写入w，返回写入的字节数。
+// 下载地址过期时返回403的APIError
+func (c *Client) DownloadFile(ctx context.Context, downloadURL string, w io.Writer) (int64, error) {
+	u, err := url.Parse(downloadURL)
+	if err != nil || !strings.HasPrefix(u.Path, apiPrefix+"/") {
+		return 0, fmt.Errorf("无效的下载地址: %s", downloadURL)
+	}
+
+	resp, err := c.send(ctx, &request{method: http.MethodGet, path: u.Path, query: u.Query()})
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, decodeResponse(resp, nil)
+	}
+	n, err := io.Copy(w, resp.Body)
+	if err != nil {
+		return n, fmt.Errorf("下载文件失败: %w", err)
+	}
+	return n, nil
+}
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+const productsPath = apiPrefix + "/cloud-products"
+
+// ListProducts 获取符合条件的全部云产品，不分页；需要分页时使用ListProductsPage或EachProduct
+func (c *Client) ListProducts(ctx context.Context, filter ProductFilter) ([]Product, error) {
+	filter.Page, filter.PageSize, filter.Cursor = 0, 0, nil
+
+	var products []Product
+	if err := c.call(ctx, http.MethodGet, productsPath, filter.values(), nil, &products); err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+// ListProductsPage 分页获取云产品，未指定分页参数时获取第一页
+func (c *Client) ListProductsPage(ctx context.Context, filter ProductFilter) (*ProductPage, error) {
+	if !filter.paged() {
+		filter.Page = 1
+	}
+
+	var page ProductPage
+	if err := c.call(ctx, http.MethodGet, productsPath, filter.values(), nil, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// EachProduct 使用游标分页遍历所有符合条件的云产品，fn返回错误时停止遍历并返回该错误
+func (c *Client) EachProduct(ctx context.Context, filter ProductFilter, fn func(Product) error) error {
+	return eachPage(&filter.ListOptions, func() (string, error) {
+		page, err := c.ListProductsPage(ctx, filter)
+		if err != nil {
+			return "", err
+		}
+		for _, p := range page.Data {
+			if err := fn(p); err != nil {
+				return "", err
+			}
+		}
+		return page.NextCursor, nil
+	})
+}
+
+// ListProviderProducts 获取指定云服务商的产品
+func (c *Client) ListProviderProducts(ctx context.Context, providerID uint) ([]Product, error) {
+	var products []Product
+	if err := c.call(ctx, http.MethodGet, idPath(providersPath, providerID)+"/products", nil, nil, &products); err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+// ListProviderProductsByCode 获取指定云服务商代码的产品
+func (c *Client) ListProviderProductsByCode(ctx context.Context, providerCode string) ([]Product, error) {
+	path := providersPath + "/code/" + url.PathEscape(providerCode) + "/products"
+
+	var products []Product
+	if err := c.call(ctx, http.MethodGet, path, nil, nil, &products); err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+// GetProduct 获取云产品详情
+func (c *Client) GetProduct(ctx context.Context, id uint) (*Product, error) {
+	var product Product
+	if err := c.call(ctx, http.MethodGet, idPath(productsPath, id), nil, nil, &product); err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// CreateProduct 创建云产品
+func (c *Client) CreateProduct(ctx context.Context, input ProductInput) (*Product, error) {
+	var product Product
+	if err := c.call(ctx, http.MethodPost, productsPath, nil, input, &product); err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// UpdateProduct 整体更新云产品，未提供的字段将被清空；只修改部分字段时使用PatchProduct
+func (c *Client) UpdateProduct(ctx context.Context, id uint, input ProductInput) error {
+	return c.call(ctx, http.MethodPut, idPath(productsPath, id), nil, input, nil)
+}
+
+// PatchProduct 按JSON合并补丁部分更新云产品
+func (c *Client) PatchProduct(ctx context.Context, id uint, patch interface{}) (*Product, error) {
+	var product Product
+	if err := c.patchJSON(ctx, idPath(productsPath, id), patch, &product); err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// DeleteProduct 删除云产品
+func (c *Client) DeleteProduct(ctx context.Context, id uint) error {
+	return c.call(ctx, http.MethodDelete, idPath(productsPath, id), nil, nil, nil)
+}
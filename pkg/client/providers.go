@@ -0,0 +1,110 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+const providersPath = apiPrefix + "/cloud-providers"
+
+// ListProviders 获取符合条件的全部云服务商，不分页；需要分页时使用ListProvidersPage或EachProvider
+func (c *Client) ListProviders(ctx context.Context, filter ProviderFilter) ([]Provider, error) {
+	filter.Page, filter.PageSize, filter.Cursor = 0, 0, nil
+
+	var providers []Provider
+	if err := c.call(ctx, http.MethodGet, providersPath, filter.values(), nil, &providers); err != nil {
+		return nil, err
+	}
+	return providers, nil
+}
+
+// ListProvidersPage 分页获取云服务商，未指定分页参数时获取第一页
+func (c *Client) ListProvidersPage(ctx context.Context, filter ProviderFilter) (*ProviderPage, error) {
+	if !filter.paged() {
+		filter.Page = 1
+	}
+
+	var page ProviderPage
+	if err := c.call(ctx, http.MethodGet, providersPath, filter.values(), nil, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// EachProvider 使用游标分页遍历所有符合条件的云服务商，fn返回错误时停止遍历并返回该错误
+func (c *Client) EachProvider(ctx context.Context, filter ProviderFilter, fn func(Provider) error) error {
+	return eachPage(&filter.ListOptions, func() (string, error) {
+		page, err := c.ListProvidersPage(ctx, filter)
+		if err != nil {
+			return "", err
+		}
+		for _, p := range page.Data {
+			if err := fn(p); err != nil {
+				return "", err
+			}
+		}
+		return page.NextCursor, nil
+	})
+}
+
+// GetProvider 获取云服务商详情
+func (c *Client) GetProvider(ctx context.Context, id uint) (*Provider, error) {
+	var provider Provider
+	if err := c.call(ctx, http.MethodGet, idPath(providersPath, id), nil, nil, &provider); err != nil {
+		return nil, err
+	}
+	return &provider, nil
+}
+
+// CreateProvider 创建云服务商
+func (c *Client) CreateProvider(ctx context.Context, input ProviderInput) (*Provider, error) {
+	var provider Provider
+	if err := c.call(ctx, http.MethodPost, providersPath, nil, input, &provider); err != nil {
+		return nil, err
+	}
+	return &provider, nil
+}
+
+// UpdateProvider 整体更新云服务商，未提供的字段将被清空；只修改部分字段时使用PatchProvider
+func (c *Client) UpdateProvider(ctx context.Context, id uint, input ProviderInput) error {
+	return c.call(ctx, http.MethodPut, idPath(providersPath, id), nil, input, nil)
+}
+
+// PatchProvider 按JSON合并补丁部分更新云服务商，patch可以是map、结构体或JSON字节，值为nil的字段被清空
+func (c *Client) PatchProvider(ctx context.Context, id uint, patch interface{}) (*Provider, error) {
+	var provider Provider
+	if err := c.patchJSON(ctx, idPath(providersPath, id), patch, &provider); err != nil {
+		return nil, err
+	}
+	return &provider, nil
+}
+
+// DeleteProvider 删除云服务商，cascade为true时一并删除其产品和配置项
+func (c *Client) DeleteProvider(ctx context.Context, id uint, cascade bool) error {
+	var query url.Values
+	if cascade {
+		query = url.Values{"cascade": {"true"}}
+	}
+	return c.call(ctx, http.MethodDelete, idPath(providersPath, id), query, nil, nil)
+}
+
+// eachPage 从opts中的游标开始逐页获取，fetch返回下一页游标，为空时结束
+func eachPage(opts *ListOptions, fetch func() (string, error)) error {
+	if opts.Cursor == nil {
+		first := ""
+		opts.Cursor = &first
+	}
+	opts.Page = 0
+
+	for {
+		next, err := fetch()
+		if err != nil {
+			return err
+		}
+		if next == "" {
+			return nil
+		}
+		opts.Cursor = &next
+	}
+}
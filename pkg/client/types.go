@@ -0,0 +1,256 @@
+package client
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Tag 标签
+type Tag struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+// Provider 云服务商
+type Provider struct {
+	ID           uint      `json:"id"`
+	Name         string    `json:"name"`
+	Code         string    `json:"code"`
+	Description  string    `json:"description"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	Products     []Product `json:"products,omitempty"`
+	Tags         []Tag     `json:"tags,omitempty"`
+	ProductCount *int64    `json:"product_count,omitempty"`
+}
+
+// Category 产品类别
+type Category struct {
+	ID          uint   `json:"id"`
+	ParentID    *uint  `json:"parent_id"`
+	Name        string `json:"name"`
+	Code        string `json:"code"`
+	Description string `json:"description"`
+}
+
+// Product 云产品
+type Product struct {
+	ID              uint         `json:"id"`
+	CloudProviderID uint         `json:"cloud_provider_id"`
+	Name            string       `json:"name"`
+	Code            string       `json:"code"`
+	Description     string       `json:"description"`
+	CategoryID      *uint        `json:"category_id"`
+	CreatedAt       time.Time    `json:"created_at"`
+	UpdatedAt       time.Time    `json:"updated_at"`
+	Provider        *Provider    `json:"provider,omitempty"`
+	Category        *Category    `json:"category,omitempty"`
+	ConfigItems     []ConfigItem `json:"config_items,omitempty"`
+	Tags            []Tag        `json:"tags,omitempty"`
+	ConfigItemCount *int64       `json:"config_item_count,omitempty"`
+}
+
+// ConfigItem 配置项
+type ConfigItem struct {
+	ID                  uint      `json:"id"`
+	CloudProviderID     uint      `json:"cloud_provider_id"`
+	ProductID           uint      `json:"product_id"`
+	Name                string    `json:"name"`
+	RecommendedValue    string    `json:"recommended_value"`
+	RiskDescription     string    `json:"risk_description"`
+	CheckMethod         string    `json:"check_method"`
+	ConfigurationMethod string    `json:"configuration_method"`
+	Reference           string    `json:"reference"`
+	Severity            string    `json:"severity"`
+	Status              string    `json:"status"`
+	ControlFamilyID     *uint     `json:"control_family_id"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+	Provider            *Provider `json:"provider,omitempty"`
+	Product             *Product  `json:"product,omitempty"`
+	Tags                []Tag     `json:"tags,omitempty"`
+}
+
+// ProviderInput 创建或整体更新云服务商的请求
+type ProviderInput struct {
+	Name        string `json:"name"`
+	Code        string `json:"code"`
+	Description string `json:"description"`
+}
+
+// ProductInput 创建或整体更新云产品的请求
+type ProductInput struct {
+	CloudProviderID uint   `json:"cloud_provider_id"`
+	Name            string `json:"name"`
+	Code            string `json:"code"`
+	Description     string `json:"description"`
+	CategoryID      *uint  `json:"category_id,omitempty"`
+}
+
+// ConfigItemInput 创建或整体更新配置项的请求
+type ConfigItemInput struct {
+	CloudProviderID     uint   `json:"cloud_provider_id"`
+	ProductID           uint   `json:"product_id"`
+	Name                string `json:"name"`
+	RecommendedValue    string `json:"recommended_value"`
+	RiskDescription     string `json:"risk_description,omitempty"`
+	CheckMethod         string `json:"check_method,omitempty"`
+	ConfigurationMethod string `json:"configuration_method,omitempty"`
+	Reference           string `json:"reference,omitempty"`
+	Severity            string `json:"severity,omitempty"`
+	Status              string `json:"status,omitempty"`
+}
+
+// PageInfo 分页信息
+type PageInfo struct {
+	Total      int64  `json:"total"`                 // 总记录数，游标分页时为-1
+	Page       int    `json:"page"`                  // 当前页码，游标分页时为0
+	PageSize   int    `json:"page_size"`             // 每页大小
+	NextCursor string `json:"next_cursor,omitempty"` // 下一页游标，为空表示没有下一页
+	PrevCursor string `json:"prev_cursor,omitempty"` // 上一页游标
+}
+
+// ProviderPage 云服务商分页结果
+type ProviderPage struct {
+	PageInfo
+	Data []Provider `json:"data"`
+}
+
+// ProductPage 云产品分页结果
+type ProductPage struct {
+	PageInfo
+	Data []Product `json:"data"`
+}
+
+// ConfigItemPage 配置项分页结果
+type ConfigItemPage struct {
+	PageInfo
+	Data []ConfigItem `json:"data"`
+}
+
+// ListOptions 列表查询的通用参数
+type ListOptions struct {
+	Page     int     // 页码，从1开始
+	PageSize int     // 每页记录数
+	Cursor   *string // 游标，非nil时使用游标分页，空字符串表示第一页
+	Sort     []string
+	Keyword  string
+	// Include 加载的关联，nil使用服务端默认值，空切片表示不加载
+	Include     []string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	UpdatedFrom *time.Time
+	UpdatedTo   *time.Time
+}
+
+// paged 是否指定了分页参数
+func (o *ListOptions) paged() bool {
+	return o.Cursor != nil || o.Page > 0 || o.PageSize > 0
+}
+
+func (o *ListOptions) values() url.Values {
+	q := url.Values{}
+	if o.Page > 0 {
+		q.Set("page", strconv.Itoa(o.Page))
+	}
+	if o.PageSize > 0 {
+		q.Set("page_size", strconv.Itoa(o.PageSize))
+	}
+	if o.Cursor != nil {
+		q.Set("cursor", *o.Cursor)
+	}
+	if len(o.Sort) > 0 {
+		q.Set("sort", strings.Join(o.Sort, ","))
+	}
+	if o.Keyword != "" {
+		q.Set("keyword", o.Keyword)
+	}
+	if o.Include != nil {
+		q.Set("include", strings.Join(o.Include, ","))
+	}
+	setTime(q, "created_from", o.CreatedFrom)
+	setTime(q, "created_to", o.CreatedTo)
+	setTime(q, "updated_from", o.UpdatedFrom)
+	setTime(q, "updated_to", o.UpdatedTo)
+	return q
+}
+
+// ProviderFilter 云服务商查询条件
+type ProviderFilter struct {
+	ListOptions
+	Codes      []string
+	WithCounts bool // 返回每个服务商的产品数
+}
+
+func (f *ProviderFilter) values() url.Values {
+	q := f.ListOptions.values()
+	setList(q, "code", f.Codes)
+	if f.WithCounts {
+		q.Set("with_counts", "true")
+	}
+	return q
+}
+
+// ProductFilter 云产品查询条件
+type ProductFilter struct {
+	ListOptions
+	ProviderIDs []uint
+	Codes       []string
+	CategoryIDs []uint // 包含子类别
+	WithCounts  bool   // 返回每个产品的配置项数
+}
+
+func (f *ProductFilter) values() url.Values {
+	q := f.ListOptions.values()
+	setIDs(q, "cloud_provider_id", f.ProviderIDs)
+	setList(q, "code", f.Codes)
+	setIDs(q, "category_id", f.CategoryIDs)
+	if f.WithCounts {
+		q.Set("with_counts", "true")
+	}
+	return q
+}
+
+// ConfigItemFilter 配置项查询条件
+type ConfigItemFilter struct {
+	ListOptions
+	ProviderIDs []uint
+	ProductIDs  []uint
+	CategoryIDs []uint // 包含子类别
+	Tags        []string
+	TagMatch    string // or（默认）或and
+}
+
+func (f *ConfigItemFilter) values() url.Values {
+	q := f.ListOptions.values()
+	setIDs(q, "cloud_provider_id", f.ProviderIDs)
+	setIDs(q, "product_id", f.ProductIDs)
+	setIDs(q, "category_id", f.CategoryIDs)
+	setList(q, "tag", f.Tags)
+	if f.TagMatch != "" {
+		q.Set("tag_match", f.TagMatch)
+	}
+	return q
+}
+
+func setList(q url.Values, key string, values []string) {
+	if len(values) > 0 {
+		q.Set(key, strings.Join(values, ","))
+	}
+}
+
+func setIDs(q url.Values, key string, ids []uint) {
+	values := make([]string, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, strconv.FormatUint(uint64(id), 10))
+	}
+	setList(q, key, values)
+}
+
+func setTime(q url.Values, key string, t *time.Time) {
+	if t != nil {
+		q.Set(key, t.Format(time.RFC3339))
+	}
+}